@@ -34,6 +34,7 @@ import (
 	"hcm/pkg/runtime/gwparser"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the api server
@@ -71,6 +72,10 @@ func (as *apiService) prepare(opt *options.Option) error {
 
 	logs.InitLogger(cc.ApiServer().Log.Logs())
 
+	if err := tracing.InitTracing(cc.ApiServer().Tracing); err != nil {
+		return fmt.Errorf("init tracing failed, err: %v", err)
+	}
+
 	logs.Infof("load settings from config file success.")
 
 	if err := gwparser.Init(opt.DisableJWT, opt.PublicKey); err != nil {
@@ -83,7 +88,8 @@ func (as *apiService) prepare(opt *options.Option) error {
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
 	// new api server discovery client.
-	discOpt := serviced.DiscoveryOption{Services: []cc.Name{cc.CloudServerName}}
+	discOpt := serviced.DiscoveryOption{Services: []cc.Name{cc.CloudServerName, cc.DataServiceName,
+		cc.AuthServerName}}
 	dis, err := serviced.NewDiscovery(cc.ApiServer().Service, discOpt)
 	if err != nil {
 		return fmt.Errorf("new service discovery faield, err: %v", err)
@@ -29,8 +29,12 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/gwparser"
+	"hcm/pkg/runtime/ratelimit"
+	"hcm/pkg/tracing"
 
 	"github.com/emicklei/go-restful/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // restFilter returns api server's restful request filter, we filter all requests base on URL.
@@ -38,8 +42,16 @@ func (p *proxy) restFilter() restful.FilterFunction {
 	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
 		r, w := req.Request, resp.ResponseWriter
 
+		// api server is the entrance of the trace, kt.Header() carries the trace context to the backend services.
+		ctx, span := tracing.Tracer().Start(tracing.Extract(r.Context(), r.Header), r.Method+" "+r.URL.Path,
+			trace.WithSpanKind(trace.SpanKindServer))
+		defer func() {
+			span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode()))
+			span.End()
+		}()
+
 		// parse request
-		kt, err := gwparser.Parse(r.Context(), r.Header)
+		kt, err := gwparser.Parse(ctx, r.Header)
 		if err != nil {
 			w.WriteHeader(http.StatusForbidden)
 			fmt.Fprintf(w, errf.Error(err).Error())
@@ -47,6 +59,23 @@ func (p *proxy) restFilter() restful.FilterFunction {
 		}
 		req.Request.Header = kt.Header()
 
+		// requests using api token can only access the apis in its scopes.
+		if err = gwparser.ValidateScope(kt, r.URL.Path); err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, errf.Error(err).Error())
+			return
+		}
+
+		if p.limiter != nil {
+			limitReq := &ratelimit.Request{AppCode: kt.AppCode, User: kt.User, Method: r.Method, Path: r.URL.Path}
+			if decision := p.limiter.Allow(limitReq); !decision.Allowed {
+				logs.V(3).Infof("request %s %s is rejected by rate limit rule %s, appcode: %s, user: %s, rid: %s",
+					r.Method, r.URL.Path, decision.Rule, kt.AppCode, kt.User, kt.Rid)
+				ratelimit.WriteRejected(w, decision)
+				return
+			}
+		}
+
 		body, err := peekRequest(r)
 		if err != nil {
 			w.WriteHeader(http.StatusForbidden)
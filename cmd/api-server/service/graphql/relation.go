@@ -0,0 +1,190 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package graphql
+
+import (
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	gql "hcm/pkg/runtime/graphql"
+	"hcm/pkg/tools/slice"
+)
+
+// resolveCvmDisks resolve the disks of cvms by disk_cvm_rel table.
+func (r *resolver) resolveCvmDisks(params *gql.ResolveParams) ([]interface{}, error) {
+	cvmIDs := sourceIDs(params.Sources)
+
+	cvmResMap := make(map[string][]interface{})
+	for _, ids := range slice.Split(slice.Unique(cvmIDs), constant.BatchOperationMaxLimit) {
+		listReq := &dataproto.DiskCvmRelWithDiskListReq{CvmIDs: ids}
+		disks, err := r.client.DataService().Global.ListDiskCvmRelWithDisk(params.Kit.Ctx, params.Kit.Header(),
+			listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, disk := range disks {
+			cvmResMap[disk.CvmID] = append(cvmResMap[disk.CvmID], disk.DiskResult)
+		}
+	}
+
+	return r.groupByCvm(params.Kit, meta.Disk, cvmIDs, cvmResMap)
+}
+
+// resolveCvmEips resolve the eips of cvms by eip_cvm_rel table.
+func (r *resolver) resolveCvmEips(params *gql.ResolveParams) ([]interface{}, error) {
+	cvmIDs := sourceIDs(params.Sources)
+
+	cvmResMap := make(map[string][]interface{})
+	for _, ids := range slice.Split(slice.Unique(cvmIDs), constant.BatchOperationMaxLimit) {
+		listReq := &dataproto.EipCvmRelWithEipListReq{CvmIDs: ids}
+		eips, err := r.client.DataService().Global.ListEipCvmRelWithEip(params.Kit.Ctx, params.Kit.Header(),
+			listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, eip := range eips {
+			cvmResMap[eip.CvmID] = append(cvmResMap[eip.CvmID], eip.EipResult)
+		}
+	}
+
+	return r.groupByCvm(params.Kit, meta.Eip, cvmIDs, cvmResMap)
+}
+
+// resolveCvmSecurityGroups resolve the security groups of cvms by security_group_cvm_rel table.
+func (r *resolver) resolveCvmSecurityGroups(params *gql.ResolveParams) ([]interface{}, error) {
+	cvmIDs := sourceIDs(params.Sources)
+
+	cvmResMap := make(map[string][]interface{})
+	for _, ids := range slice.Split(slice.Unique(cvmIDs), constant.BatchOperationMaxLimit) {
+		listReq := &dataproto.SGCvmRelWithSecurityGroupListReq{CvmIDs: ids}
+		sgs, err := r.client.DataService().Global.SGCvmRel.ListWithSecurityGroup(params.Kit.Ctx,
+			params.Kit.Header(), listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sg := range sgs {
+			cvmResMap[sg.CvmID] = append(cvmResMap[sg.CvmID], sg.BaseSecurityGroup)
+		}
+	}
+
+	return r.groupByCvm(params.Kit, meta.SecurityGroup, cvmIDs, cvmResMap)
+}
+
+// resolveCvmNetworkInterfaces resolve the network interfaces of cvms by network_interface_cvm_rel table.
+func (r *resolver) resolveCvmNetworkInterfaces(params *gql.ResolveParams) ([]interface{}, error) {
+	cvmIDs := sourceIDs(params.Sources)
+
+	niCvmMap := make(map[string][]string)
+	niIDs := make([]string, 0)
+	for _, ids := range slice.Split(slice.Unique(cvmIDs), int(core.DefaultMaxPageLimit)) {
+		listReq := &core.ListReq{
+			Filter: tools.ContainersExpression("cvm_id", ids),
+			Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+		}
+
+		for {
+			rels, err := r.client.DataService().Global.NetworkInterfaceCvmRel.List(params.Kit.Ctx,
+				params.Kit.Header(), listReq)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, rel := range rels.Details {
+				if _, exists := niCvmMap[rel.NetworkInterfaceID]; !exists {
+					niIDs = append(niIDs, rel.NetworkInterfaceID)
+				}
+				niCvmMap[rel.NetworkInterfaceID] = append(niCvmMap[rel.NetworkInterfaceID], rel.CvmID)
+			}
+
+			if uint(len(rels.Details)) < core.DefaultMaxPageLimit {
+				break
+			}
+			listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+		}
+	}
+
+	cvmResMap := make(map[string][]interface{})
+	for _, ids := range slice.Split(niIDs, int(core.DefaultMaxPageLimit)) {
+		listReq := &core.ListReq{
+			Filter: tools.ContainersExpression("id", ids),
+			Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+		}
+		nis, err := r.client.DataService().Global.NetworkInterface.List(params.Kit.Ctx, params.Kit.Header(),
+			listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ni := range nis.Details {
+			for _, cvmID := range niCvmMap[ni.ID] {
+				cvmResMap[cvmID] = append(cvmResMap[cvmID], ni)
+			}
+		}
+	}
+
+	return r.groupByCvm(params.Kit, meta.NetworkInterface, cvmIDs, cvmResMap)
+}
+
+// groupByCvm authorize the related resources of cvms, and returns the resources of each cvm in order of cvm ids.
+func (r *resolver) groupByCvm(kt *kit.Kit, resType meta.ResourceType, cvmIDs []string,
+	cvmResMap map[string][]interface{}) ([]interface{}, error) {
+
+	// authorize the related resources of all the cvms in one batch.
+	allRes := make([]interface{}, 0)
+	for _, cvmID := range cvmIDs {
+		allRes = append(allRes, cvmResMap[cvmID]...)
+	}
+
+	sources, err := toSources(allRes)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := r.authorizeNodes(kt, resType, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(cvmIDs))
+	offset := 0
+	for i, cvmID := range cvmIDs {
+		count := len(cvmResMap[cvmID])
+		values[i] = nodes[offset : offset+count]
+		offset += count
+	}
+
+	return values, nil
+}
+
+// sourceIDs returns the ids of the sources.
+func sourceIDs(sources []gql.Source) []string {
+	ids := make([]string, len(sources))
+	for i, source := range sources {
+		ids[i], _ = source["id"].(string)
+	}
+
+	return ids
+}
@@ -0,0 +1,306 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package graphql
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/iam/sys"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+	gql "hcm/pkg/runtime/graphql"
+)
+
+// resolver resolves the hcm resources and relationships.
+type resolver struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	// maxListLimit is the max count of cvms returned by a list query.
+	maxListLimit uint
+}
+
+// scalarFields returns the scalar field definitions that are read from the source by name.
+func scalarFields(names ...string) map[string]*gql.FieldDefinition {
+	fields := make(map[string]*gql.FieldDefinition, len(names))
+	for _, name := range names {
+		fields[name] = new(gql.FieldDefinition)
+	}
+
+	return fields
+}
+
+var revisionFields = []string{"creator", "reviser", "created_at", "updated_at"}
+
+// querySchema returns the root query type of the schema.
+func (r *resolver) querySchema() *gql.Object {
+	cvm := &gql.Object{
+		Name: "Cvm",
+		Fields: scalarFields(append([]string{"id", "cloud_id", "name", "vendor", "bk_biz_id", "bk_cloud_id",
+			"account_id", "region", "zone", "cloud_vpc_ids", "vpc_ids", "cloud_subnet_ids", "subnet_ids",
+			"cloud_image_id", "os_name", "memo", "status", "private_ipv4_addresses", "private_ipv6_addresses",
+			"public_ipv4_addresses", "public_ipv6_addresses", "machine_type", "cloud_created_time",
+			"cloud_launched_time", "cloud_expired_time"}, revisionFields...)...),
+	}
+
+	disk := &gql.Object{
+		Name: "Disk",
+		Fields: scalarFields(append([]string{"id", "vendor", "account_id", "name", "bk_biz_id", "cloud_id",
+			"region", "zone", "disk_size", "disk_type", "status", "is_system_disk", "memo"}, revisionFields...)...),
+	}
+
+	eip := &gql.Object{
+		Name: "Eip",
+		Fields: scalarFields(append([]string{"id", "vendor", "account_id", "name", "cloud_id", "bk_biz_id",
+			"region", "instance_id", "instance_type", "status", "public_ip", "private_ip"}, revisionFields...)...),
+	}
+
+	securityGroup := &gql.Object{
+		Name: "SecurityGroup",
+		Fields: scalarFields(append([]string{"id", "vendor", "cloud_id", "region", "name", "memo", "account_id",
+			"bk_biz_id"}, revisionFields...)...),
+	}
+
+	networkInterface := &gql.Object{
+		Name: "NetworkInterface",
+		Fields: scalarFields(append([]string{"id", "vendor", "name", "account_id", "region", "zone", "cloud_id",
+			"vpc_id", "cloud_vpc_id", "subnet_id", "cloud_subnet_id", "private_ipv4", "private_ipv6", "public_ipv4",
+			"public_ipv6", "bk_biz_id", "instance_id"}, revisionFields...)...),
+	}
+
+	cvm.Fields["disks"] = &gql.FieldDefinition{Type: disk, Resolve: r.resolveCvmDisks}
+	cvm.Fields["eips"] = &gql.FieldDefinition{Type: eip, Resolve: r.resolveCvmEips}
+	cvm.Fields["security_groups"] = &gql.FieldDefinition{Type: securityGroup, Resolve: r.resolveCvmSecurityGroups}
+	cvm.Fields["network_interfaces"] = &gql.FieldDefinition{Type: networkInterface,
+		Resolve: r.resolveCvmNetworkInterfaces}
+
+	return &gql.Object{
+		Name: "Query",
+		Fields: map[string]*gql.FieldDefinition{
+			"cvm":  {Type: cvm, Resolve: r.resolveCvm},
+			"cvms": {Type: cvm, Resolve: r.resolveCvms},
+		},
+	}
+}
+
+// resolveCvm resolve cvm by id, arguments: id(required).
+func (r *resolver) resolveCvm(params *gql.ResolveParams) ([]interface{}, error) {
+	id, ok := params.Args["id"].(string)
+	if !ok || len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "argument id is required and must be string")
+	}
+
+	expr := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			filter.AtomRule{Field: "id", Op: filter.Equal.Factory(), Value: id},
+			filter.AtomRule{Field: "recycle_status", Op: filter.NotEqual.Factory(), Value: enumor.RecycleStatus},
+		},
+	}
+	listReq := &dataproto.CvmListReq{Filter: expr, Page: &core.BasePage{Limit: 1}}
+	result, err := r.client.DataService().Global.Cvm.ListCvm(params.Kit.Ctx, params.Kit.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return make([]interface{}, len(params.Sources)), nil
+	}
+
+	sources, err := toSources(result.Details)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := r.authorizeNodes(params.Kit, meta.Cvm, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	return repeat(nodes[0], len(params.Sources)), nil
+}
+
+// resolveCvms list authorized cvms, arguments: ids, account_id, vendor, region, bk_biz_id, start, limit.
+func (r *resolver) resolveCvms(params *gql.ResolveParams) ([]interface{}, error) {
+	rules := []filter.RuleFactory{
+		filter.AtomRule{Field: "recycle_status", Op: filter.NotEqual.Factory(), Value: enumor.RecycleStatus},
+	}
+
+	if ids, exists := params.Args["ids"]; exists {
+		idList, ok := ids.([]interface{})
+		if !ok || len(idList) == 0 || uint(len(idList)) > r.maxListLimit {
+			return nil, errf.Newf(errf.InvalidParameter, "argument ids must be a list of 1-%d ids", r.maxListLimit)
+		}
+		rules = append(rules, filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: idList})
+	}
+
+	for _, field := range []string{"account_id", "vendor", "region"} {
+		value, exists := params.Args[field]
+		if !exists {
+			continue
+		}
+
+		if _, ok := value.(string); !ok {
+			return nil, errf.Newf(errf.InvalidParameter, "argument %s must be string", field)
+		}
+		rules = append(rules, filter.AtomRule{Field: field, Op: filter.Equal.Factory(), Value: value})
+	}
+
+	if bizID, exists := params.Args["bk_biz_id"]; exists {
+		if _, ok := bizID.(int64); !ok {
+			return nil, errf.New(errf.InvalidParameter, "argument bk_biz_id must be int")
+		}
+		rules = append(rules, filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(), Value: bizID})
+	}
+
+	page, err := r.parsePage(params.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	// only list the cvms that user has permission, the same as the list cvm api.
+	authOpt := &meta.ListAuthResInput{Type: meta.Cvm, Action: meta.Find}
+	expr, noPerm, err := r.authorizer.ListAuthInstWithFilter(params.Kit, authOpt,
+		&filter.Expression{Op: filter.And, Rules: rules}, "account_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if noPerm {
+		return repeat(make([]gql.Source, 0), len(params.Sources)), nil
+	}
+
+	listReq := &dataproto.CvmListReq{Filter: expr, Page: page}
+	result, err := r.client.DataService().Global.Cvm.ListCvm(params.Kit.Ctx, params.Kit.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := toSources(result.Details)
+	if err != nil {
+		return nil, err
+	}
+
+	return repeat(sources, len(params.Sources)), nil
+}
+
+// parsePage parse the start and limit arguments to page, results are sorted by id for stable pagination.
+func (r *resolver) parsePage(args map[string]interface{}) (*core.BasePage, error) {
+	page := &core.BasePage{Limit: r.maxListLimit, Sort: "id", Order: core.Ascending}
+
+	if start, exists := args["start"]; exists {
+		value, ok := start.(int64)
+		if !ok || value < 0 {
+			return nil, errf.New(errf.InvalidParameter, "argument start must be non-negative int")
+		}
+		page.Start = uint32(value)
+	}
+
+	if limit, exists := args["limit"]; exists {
+		value, ok := limit.(int64)
+		if !ok || value <= 0 || uint(value) > r.maxListLimit {
+			return nil, errf.Newf(errf.InvalidParameter, "argument limit must be int in range 1-%d",
+				r.maxListLimit)
+		}
+		page.Limit = uint(value)
+	}
+
+	return page, nil
+}
+
+// authorizeNodes authorize find permission of the resources, unauthorized resources are replaced by error.
+func (r *resolver) authorizeNodes(kt *kit.Kit, resType meta.ResourceType, sources []gql.Source) ([]interface{},
+	error) {
+
+	nodes := make([]interface{}, len(sources))
+	if len(sources) == 0 {
+		return nodes, nil
+	}
+
+	// resources with the same account, vendor and region have the same permission, authorize them only once.
+	authIndex := make(map[string]int)
+	authRes := make([]meta.ResourceAttribute, 0)
+	sourceAuthIdx := make([]int, len(sources))
+	for i, source := range sources {
+		accountID, _ := source["account_id"].(string)
+		vendor, _ := source["vendor"].(string)
+		region, _ := source["region"].(string)
+
+		key := accountID + "/" + vendor + "/" + region
+		idx, exists := authIndex[key]
+		if !exists {
+			attribute := map[string]interface{}{sys.VendorAttribute: vendor}
+			if len(region) != 0 {
+				attribute[sys.RegionAttribute] = region
+			}
+
+			idx = len(authRes)
+			authIndex[key] = idx
+			authRes = append(authRes, meta.ResourceAttribute{Basic: &meta.Basic{Type: resType, Action: meta.Find,
+				ResourceID: accountID}, Attribute: attribute})
+		}
+		sourceAuthIdx[i] = idx
+	}
+
+	decisions, _, err := r.authorizer.Authorize(kt, authRes...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, source := range sources {
+		if !decisions[sourceAuthIdx[i]].Authorized {
+			nodes[i] = errf.Newf(errf.PermissionDenied, "no permission to find %s %v", resType, source["id"])
+			continue
+		}
+		nodes[i] = source
+	}
+
+	return nodes, nil
+}
+
+// toSources convert the resources to graphql sources.
+func toSources[T any](resources []T) ([]gql.Source, error) {
+	sources := make([]gql.Source, len(resources))
+	for i := range resources {
+		source, err := gql.ToSource(resources[i])
+		if err != nil {
+			return nil, fmt.Errorf("convert resource to graphql source failed, err: %v", err)
+		}
+		sources[i] = source
+	}
+
+	return sources, nil
+}
+
+// repeat returns the values of the sources that resolves to the same value, e.g. the root query fields.
+func repeat(value interface{}, count int) []interface{} {
+	values := make([]interface{}, count)
+	for i := range values {
+		values[i] = value
+	}
+
+	return values
+}
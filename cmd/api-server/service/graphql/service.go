@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package graphql is the read-only graphql query gateway of hcm resources and their relationships, relationships
+// are resolved in batches by the relation tables, and each resource node is authorized by iam.
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	gql "hcm/pkg/runtime/graphql"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// Service is the graphql query service.
+type Service struct {
+	schema *gql.Schema
+}
+
+// NewService create the graphql query service.
+func NewService(cliSet *client.ClientSet, authorizer auth.Authorizer, opt cc.GraphQL) *Service {
+	r := &resolver{
+		client:       cliSet,
+		authorizer:   authorizer,
+		maxListLimit: opt.MaxListLimit,
+	}
+
+	return &Service{
+		schema: &gql.Schema{
+			Query:    r.querySchema(),
+			MaxDepth: opt.MaxDepth,
+		},
+	}
+}
+
+// WebService returns the graphql web service, the filter is the same as the proxied apis.
+func (s *Service) WebService(filter restful.FilterFunction) *restful.WebService {
+	ws := new(restful.WebService)
+
+	ws.Path("/api/v1/graphql")
+	ws.Filter(filter)
+	ws.Produces(restful.MIME_JSON)
+
+	ws.Route(ws.POST("").To(s.Query))
+
+	return ws
+}
+
+// Query execute the graphql query request.
+func (s *Service) Query(req *restful.Request, resp *restful.Response) {
+	r := req.Request
+
+	kt, err := kit.FromHeader(r.Context(), r.Header)
+	if err != nil {
+		logs.Errorf("get kit from graphql request header failed, err: %v, rid: %s", err,
+			r.Header.Get(constant.RidKey))
+		writeResponse(resp, http.StatusBadRequest,
+			gql.ErrorResponse(errf.NewFromErr(errf.InvalidParameter, err)))
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logs.Errorf("read graphql request body failed, err: %v, rid: %s", err, kt.Rid)
+		writeResponse(resp, http.StatusBadRequest,
+			gql.ErrorResponse(errf.NewFromErr(errf.InvalidParameter, err)))
+		return
+	}
+
+	// use number to keep the precision of int64 variables.
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	query := new(gql.Request)
+	if err = decoder.Decode(query); err != nil {
+		logs.Errorf("decode graphql request failed, err: %v, rid: %s", err, kt.Rid)
+		writeResponse(resp, http.StatusBadRequest,
+			gql.ErrorResponse(errf.NewFromErr(errf.DecodeRequestFailed, err)))
+		return
+	}
+
+	if len(query.Query) == 0 {
+		writeResponse(resp, http.StatusBadRequest, gql.ErrorResponse(errf.New(errf.InvalidParameter,
+			"query is required")))
+		return
+	}
+
+	writeResponse(resp, http.StatusOK, s.schema.Execute(kt, query))
+}
+
+func writeResponse(resp *restful.Response, status int, result *gql.Response) {
+	if err := resp.WriteHeaderAndJson(status, result, restful.MIME_JSON); err != nil {
+		logs.Errorf("write graphql response failed, err: %v", err)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/logs"
+	"hcm/pkg/runtime/ratelimit"
 	"hcm/pkg/serviced"
 
 	"github.com/emicklei/go-restful/v3"
@@ -40,10 +41,12 @@ import (
 type proxy struct {
 	discovery map[cc.Name]*discovery.APIDiscovery
 	cli       *http.Client
+	// limiter is the api rate limiter, it is nil if rate limit is not enabled.
+	limiter *ratelimit.Limiter
 }
 
 // newProxy create new rest proxy.
-func newProxy(dis serviced.Discover, cli *http.Client) (*proxy, error) {
+func newProxy(dis serviced.Discover, cli *http.Client, limiter *ratelimit.Limiter) (*proxy, error) {
 	apiDiscovery := make(map[cc.Name]*discovery.APIDiscovery)
 
 	discoverServices := []cc.Name{cc.CloudServerName}
@@ -54,6 +57,7 @@ func newProxy(dis serviced.Discover, cli *http.Client) (*proxy, error) {
 	p := &proxy{
 		discovery: apiDiscovery,
 		cli:       cli,
+		limiter:   limiter,
 	}
 
 	return p, nil
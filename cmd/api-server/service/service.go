@@ -27,20 +27,28 @@ import (
 	"strconv"
 	"time"
 
+	"hcm/cmd/api-server/service/graphql"
 	"hcm/pkg/cc"
+	apicli "hcm/pkg/client"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/handler"
+	"hcm/pkg/iam/auth"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/rest/client"
+	"hcm/pkg/runtime/gwparser"
+	"hcm/pkg/runtime/ratelimit"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
 	"hcm/pkg/tools/ssl"
+
+	"github.com/emicklei/go-restful/v3"
 )
 
 // Service do all the api server's work
 type Service struct {
-	proxy *proxy
+	proxy   *proxy
+	graphql *graphql.Service
 }
 
 // NewService create a service instance.
@@ -63,13 +71,30 @@ func NewService(dis serviced.Discover) (*Service, error) {
 		return nil, err
 	}
 
-	p, err := newProxy(dis, cli)
+	limiter, err := ratelimit.NewFromConfig(context.Background(), cc.APIServerName, cc.ApiServer().RateLimit,
+		cc.ApiServer().Service.Etcd)
+	if err != nil {
+		return nil, fmt.Errorf("init rate limiter failed, err: %v", err)
+	}
+
+	p, err := newProxy(dis, cli, limiter)
 	if err != nil {
 		return nil, err
 	}
 
+	apiClientSet := apicli.NewClientSet(cli, dis)
+
+	// requests using hcm issued api token are validated by data-service stored token hash.
+	gwparser.InitTokenParser(newTokenValidator(apiClientSet))
+
+	authorizer, err := auth.NewAuthorizer(dis, network.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("new authorizer failed, err: %v", err)
+	}
+
 	return &Service{
-		proxy: p,
+		proxy:   p,
+		graphql: graphql.NewService(apiClientSet, authorizer, cc.ApiServer().GraphQL),
 	}, nil
 }
 
@@ -78,6 +103,7 @@ func (s *Service) ListenAndServeRest() error {
 
 	root := http.NewServeMux()
 	root.HandleFunc("/", s.proxy.apiSet().ServeHTTP)
+	root.Handle("/api/v1/graphql", restful.NewContainer().Add(s.graphql.WebService(s.proxy.restFilter())))
 	root.HandleFunc("/healthz", s.Healthz)
 	handler.SetCommonHandler(root)
 
@@ -0,0 +1,198 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	prototoken "hcm/pkg/api/data-service/token"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/gwparser"
+)
+
+const (
+	// tokenCacheTTL is the ttl of validated api token cache, revoked token may still be valid within the ttl.
+	tokenCacheTTL = 30 * time.Second
+	// tokenLastUsedInterval is the minimum interval of updating api token last used info.
+	tokenLastUsedInterval = time.Minute
+)
+
+// tokenValidator validates hcm issued api token by its hash stored in data-service.
+type tokenValidator struct {
+	client *client.ClientSet
+
+	lock  sync.Mutex
+	cache map[string]*cachedToken
+}
+
+type cachedToken struct {
+	identity  *gwparser.TokenIdentity
+	expiredAt time.Time
+	// cachedAt is the time when the token is cached, cache is invalid after cachedAt + tokenCacheTTL.
+	cachedAt   time.Time
+	lastUsedAt time.Time
+}
+
+func newTokenValidator(cs *client.ClientSet) *tokenValidator {
+	return &tokenValidator{
+		client: cs,
+		cache:  make(map[string]*cachedToken),
+	}
+}
+
+// Validate hcm issued api token.
+func (v *tokenValidator) Validate(ctx context.Context, token string, clientIP string) (*gwparser.TokenIdentity,
+	error) {
+
+	sum := sha256.Sum256([]byte(token))
+	hash := hex.EncodeToString(sum[:])
+
+	now := time.Now()
+	cached, exists := v.getCache(hash, now)
+	if !exists {
+		var err error
+		cached, err = v.fetchToken(ctx, hash, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !cached.expiredAt.IsZero() && now.After(cached.expiredAt) {
+		return nil, errf.New(errf.PermissionDenied, "api token is expired")
+	}
+
+	v.updateLastUsed(cached, clientIP, now)
+
+	return cached.identity, nil
+}
+
+func (v *tokenValidator) getCache(hash string, now time.Time) (*cachedToken, bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	cached, exists := v.cache[hash]
+	if !exists {
+		return nil, false
+	}
+
+	if now.Sub(cached.cachedAt) > tokenCacheTTL {
+		delete(v.cache, hash)
+		return nil, false
+	}
+
+	return cached, true
+}
+
+func (v *tokenValidator) fetchToken(ctx context.Context, hash string, now time.Time) (*cachedToken, error) {
+	kt := newTokenKit(ctx)
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("token_hash", hash),
+		Page:   core.DefaultBasePage,
+	}
+	result, err := v.client.DataService().Global.Token.ListApiToken(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list api token by hash failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, errf.New(errf.PermissionDenied, "validate api token failed")
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.New(errf.PermissionDenied, "api token is invalid")
+	}
+
+	token := result.Details[0]
+	if token.Revoked {
+		return nil, errf.New(errf.PermissionDenied, "api token is revoked")
+	}
+
+	cached := &cachedToken{
+		identity: &gwparser.TokenIdentity{
+			TokenID: token.ID,
+			User:    token.Subject,
+			Scopes:  token.Scopes,
+		},
+		cachedAt: now,
+	}
+
+	if len(token.ExpiredAt) != 0 {
+		cached.expiredAt, err = time.Parse(constant.TimeStdFormat, token.ExpiredAt)
+		if err != nil {
+			logs.Errorf("parse api token(%s) expired_at %s failed, err: %v, rid: %s", token.ID, token.ExpiredAt,
+				err, kt.Rid)
+			return nil, errf.New(errf.PermissionDenied, "api token is invalid")
+		}
+	}
+
+	if len(token.LastUsedAt) != 0 {
+		cached.lastUsedAt, _ = time.Parse(constant.TimeStdFormat, token.LastUsedAt)
+	}
+
+	v.lock.Lock()
+	v.cache[hash] = cached
+	v.lock.Unlock()
+
+	return cached, nil
+}
+
+// updateLastUsed update api token last used info asynchronously, updates are throttled by tokenLastUsedInterval.
+func (v *tokenValidator) updateLastUsed(cached *cachedToken, clientIP string, now time.Time) {
+	v.lock.Lock()
+	if now.Sub(cached.lastUsedAt) < tokenLastUsedInterval {
+		v.lock.Unlock()
+		return
+	}
+	cached.lastUsedAt = now
+	v.lock.Unlock()
+
+	go func() {
+		kt := newTokenKit(context.Background())
+		req := &prototoken.ApiTokenLastUsedUpdateReq{
+			LastUsedAt: now.Format(constant.TimeStdFormat),
+			LastUsedIP: clientIP,
+		}
+		err := v.client.DataService().Global.Token.UpdateApiTokenLastUsed(kt.Ctx, kt.Header(),
+			cached.identity.TokenID, req)
+		if err != nil {
+			logs.Errorf("update api token(%s) last used info failed, err: %v, rid: %s", cached.identity.TokenID,
+				err, kt.Rid)
+		}
+	}()
+}
+
+// newTokenKit generate kit for validating api token.
+func newTokenKit(ctx context.Context) *kit.Kit {
+	kt := kit.New()
+	if ctx != nil {
+		kt.Ctx = ctx
+	}
+	kt.User = constant.ApiTokenValidatorUserKey
+	kt.AppCode = constant.ApiTokenAppCodeKey
+	return kt
+}
@@ -34,6 +34,7 @@ import (
 	"hcm/pkg/runtime/ctl/cmd"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the auth server
@@ -43,7 +44,9 @@ func Run(opt *options.Option) error {
 		return err
 	}
 
-	svc, err := service.NewService(as.sd, cc.AuthServer().IAM, cc.AuthServer().Esb, as.disableAuth, as.disableWriteOpt)
+	setting := cc.AuthServer()
+	svc, err := service.NewService(as.sd, setting.Authorizer, setting.IAM, setting.Esb, as.disableAuth,
+		as.disableWriteOpt)
 	if err != nil {
 		return fmt.Errorf("initialize service failed, err: %v", err)
 	}
@@ -80,6 +83,10 @@ func (as *authService) prepare(opt *options.Option) error {
 
 	logs.InitLogger(cc.AuthServer().Log.Logs())
 
+	if err := tracing.InitTracing(cc.AuthServer().Tracing); err != nil {
+		return fmt.Errorf("init tracing failed, err: %v", err)
+	}
+
 	logs.Infof("load settings from config file success.")
 
 	// init metrics
@@ -75,6 +75,12 @@ func AdaptAuthOptions(a *meta.ResourceAttribute) (client.ActionID, []client.Reso
 		return genProxyResourceFind(a)
 	case meta.CostManage:
 		return genCostManageResource(a)
+	case meta.Rbac:
+		return genRbacResource(a)
+	case meta.ServiceAccount:
+		return genServiceAccountResource(a)
+	case meta.EventSubscription:
+		return genEventSubscriptionResource(a)
 	default:
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm auth type: %s", a.Basic.Type)
 	}
@@ -36,6 +36,7 @@ import (
 	"hcm/pkg/iam/client"
 	"hcm/pkg/iam/meta"
 	"hcm/pkg/iam/sdk/auth"
+	"hcm/pkg/iam/sdk/operator"
 	"hcm/pkg/iam/sys"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -551,6 +552,78 @@ func (a *Auth) ListAuthorizedInstances(cts *rest.Contexts) (interface{}, error)
 		return nil, err
 	}
 
+	if authorizeList.Policy != nil {
+		return a.genAttrAuthorizeList(cts.Kit, req.Type, authorizeList.Policy)
+	}
+
+	return authorizeList, nil
+}
+
+// genAttrAuthorizeList generate authorized list by attribute based policy, the filter is used to list the
+// resources under account, and the account ids are the accounts that may have authorized resources.
+func (a *Auth) genAttrAuthorizeList(kt *kit.Kit, resType meta.ResourceType, policy *operator.Policy) (
+	*client.AuthorizeList, error) {
+
+	// account table does not have the attributes of the resources under account like region, the account that
+	// may have authorized resources is regarded as authorized.
+	if resType == meta.Account {
+		accountExpr, isAnyAccount, err := auth.PolicyToExpr(policy, sys.AccountAttributeFields, true)
+		if err != nil {
+			logs.Errorf("convert policy to account filter failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		return &client.AuthorizeList{IsAny: isAnyAccount, Filter: accountExpr}, nil
+	}
+
+	expr, isAny, err := auth.PolicyToExpr(policy, sys.ResourceAttributeFields, false)
+	if err != nil {
+		logs.Errorf("convert policy to resource filter failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if isAny {
+		return &client.AuthorizeList{IsAny: true}, nil
+	}
+
+	// attributes that account does not have are regarded as matched, so that all accounts that may have
+	// authorized resources are returned.
+	accountExpr, isAnyAccount, err := auth.PolicyToExpr(policy, sys.AccountAttributeFields, true)
+	if err != nil {
+		logs.Errorf("convert policy to account filter failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authorizeList := &client.AuthorizeList{IsAny: isAnyAccount, Filter: expr}
+	if isAnyAccount {
+		return authorizeList, nil
+	}
+
+	ids := make([]string, 0)
+	page := &core.BasePage{Start: 0, Limit: client.BkIAMMaxPageSize}
+	for {
+		req := &dsproto.ListInstancesReq{
+			ResourceType: sys.Account,
+			Filter:       accountExpr,
+			Page:         page,
+		}
+		resp, err := a.ds.Global.Auth.ListInstances(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list account by attribute policy failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			ids = append(ids, one.ID)
+		}
+
+		if uint(len(resp.Details)) < page.Limit {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+	authorizeList.Ids = ids
+
 	return authorizeList, nil
 }
 
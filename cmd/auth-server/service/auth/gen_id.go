@@ -406,10 +406,12 @@ func genEipResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resour
 
 // genCloudResResource generate all cloud resource related iam resource.
 func genCloudResResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	// resource attributes like vendor and region are used for attribute based authorization
 	res := client.Resource{
-		System: sys.SystemIDHCM,
-		Type:   sys.Account,
-		ID:     a.ResourceID,
+		System:    sys.SystemIDHCM,
+		Type:      sys.Account,
+		ID:        a.ResourceID,
+		Attribute: a.Attribute,
 	}
 
 	switch a.Basic.Action {
@@ -452,9 +454,39 @@ func genProxyResourceFind(a *meta.ResourceAttribute) (client.ActionID, []client.
 // genCostManageResource generate cost manage related iam resource.
 func genCostManageResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
 	switch a.Basic.Action {
-	case meta.Find:
+	case meta.Find, meta.Update:
 		return sys.CostManage, make([]client.Resource, 0), nil
 	default:
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
 	}
 }
+
+// genRbacResource generate built-in rbac role and role binding related iam resource.
+func genRbacResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.RbacManage, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genServiceAccountResource generate service account and its api token related iam resource.
+func genServiceAccountResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.ServiceAccountManage, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genEventSubscriptionResource generate resource lifecycle event and its webhook subscription related iam resource.
+func genEventSubscriptionResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.EventSubscriptionManage, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
@@ -23,17 +23,20 @@ import (
 	"hcm/cmd/auth-server/types"
 	"hcm/pkg/api/core"
 	dataservice "hcm/pkg/api/data-service"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/sys"
 	"hcm/pkg/kit"
+	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
 )
 
 // FetchInstanceInfo obtain resource instance details in batch.
 func (i *IAM) FetchInstanceInfo(kt *kit.Kit, resType client.TypeID, ft *types.FetchInstanceInfoFilter) (
 	[]map[string]interface{}, error) {
 
-	// TODO: f.Attrs need to deal with, if add attribute authentication.
-
 	expr := &filter.Expression{
 		Op: filter.And,
 		Rules: []filter.RuleFactory{
@@ -55,6 +58,16 @@ func (i *IAM) FetchInstanceInfo(kt *kit.Kit, resType client.TypeID, ft *types.Fe
 		return nil, err
 	}
 
+	ids := make([]string, 0, len(resp.Details))
+	for _, one := range resp.Details {
+		ids = append(ids, one.ID)
+	}
+
+	attrMap, err := i.fetchInstanceAttrs(kt, resType, ids, ft.Attrs)
+	if err != nil {
+		return nil, err
+	}
+
 	results := make([]map[string]interface{}, 0)
 	for _, one := range resp.Details {
 		result := make(map[string]interface{}, 0)
@@ -62,8 +75,51 @@ func (i *IAM) FetchInstanceInfo(kt *kit.Kit, resType client.TypeID, ft *types.Fe
 			InstanceID: one.ID,
 		}
 		result[types.NameField] = one.DisplayName
+		for attr, value := range attrMap[one.ID] {
+			result[attr] = value
+		}
 		results = append(results, result)
 	}
 
 	return results, nil
 }
+
+// fetchInstanceAttrs fetch attributes that iam needs of resource instances, returns id to attributes map.
+func (i *IAM) fetchInstanceAttrs(kt *kit.Kit, resType client.TypeID, ids []string, attrs []string) (
+	map[string]map[string]interface{}, error) {
+
+	attrMap := make(map[string]map[string]interface{})
+	if resType != sys.Account {
+		return attrMap, nil
+	}
+
+	needVendor := false
+	for _, attr := range attrs {
+		if attr == sys.VendorAttribute {
+			needVendor = true
+			break
+		}
+	}
+
+	if !needVendor || len(ids) == 0 {
+		return attrMap, nil
+	}
+
+	for _, batch := range slice.Split(ids, int(core.DefaultMaxPageLimit)) {
+		req := &protocloud.AccountListReq{
+			Filter: tools.ContainersExpression("id", batch),
+			Page:   &core.BasePage{Limit: core.DefaultMaxPageLimit},
+		}
+		resp, err := i.ds.Global.Account.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list account failed, err: %v, ids: %v, rid: %s", err, batch, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			attrMap[one.ID] = map[string]interface{}{sys.VendorAttribute: string(one.Vendor)}
+		}
+	}
+
+	return attrMap, nil
+}
@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package iam
+
+import (
+	"sort"
+	"strings"
+
+	"hcm/cmd/auth-server/types"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/sys"
+)
+
+// vendorNameMap is the display name of the vendors that can be used in attribute based policy.
+var vendorNameMap = map[enumor.Vendor]string{
+	enumor.TCloud: "腾讯云",
+	enumor.Aws:    "亚马逊云",
+	enumor.Gcp:    "谷歌云",
+	enumor.Azure:  "微软云",
+	enumor.HuaWei: "华为云",
+}
+
+// ListAttr query the list of attributes that a resource type can use to configure permissions.
+func (i *IAM) ListAttr(resType client.TypeID) ([]types.AttrResource, error) {
+	if resType != sys.Account {
+		return make([]types.AttrResource, 0), nil
+	}
+
+	attrs := make([]types.AttrResource, 0, len(sys.AttributeNameMap))
+	for id, name := range sys.AttributeNameMap {
+		attrs = append(attrs, types.AttrResource{ID: id, DisplayName: name})
+	}
+
+	sort.Slice(attrs, func(m, n int) bool { return attrs[m].ID < attrs[n].ID })
+	return attrs, nil
+}
+
+// ListAttrValue gets a list of values for an attribute of a resource type.
+func (i *IAM) ListAttrValue(resType client.TypeID, ft *types.ListAttrValueFilter, page types.Page) (
+	*types.ListAttrValueResult, error) {
+
+	if resType != sys.Account {
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s does not have attributes", resType)
+	}
+
+	values := make([]types.AttrValueResource, 0)
+	switch ft.Attr {
+	case sys.VendorAttribute:
+		for vendor, name := range vendorNameMap {
+			values = append(values, types.AttrValueResource{ID: string(vendor), DisplayName: name})
+		}
+		sort.Slice(values, func(m, n int) bool { return values[m].ID.(string) < values[n].ID.(string) })
+
+	case sys.RegionAttribute:
+		// regions are different between vendors and can not be enumerated, so use the input value as region.
+		for _, id := range ft.IDs {
+			if region, ok := id.(string); ok {
+				values = append(values, types.AttrValueResource{ID: region, DisplayName: region})
+			}
+		}
+
+		if len(ft.Keyword) != 0 {
+			values = append(values, types.AttrValueResource{ID: ft.Keyword, DisplayName: ft.Keyword})
+		}
+
+		return &types.ListAttrValueResult{Count: int64(len(values)), Results: values}, nil
+
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "attribute %s is not supported", ft.Attr)
+	}
+
+	// filter vendor values by ids and keyword
+	filtered := make([]types.AttrValueResource, 0, len(values))
+	idMap := make(map[interface{}]struct{}, len(ft.IDs))
+	for _, id := range ft.IDs {
+		idMap[id] = struct{}{}
+	}
+
+	for _, value := range values {
+		if len(idMap) != 0 {
+			if _, exists := idMap[value.ID]; !exists {
+				continue
+			}
+		}
+
+		if len(ft.Keyword) != 0 && !strings.Contains(value.DisplayName, ft.Keyword) &&
+			!strings.Contains(value.ID.(string), ft.Keyword) {
+			continue
+		}
+
+		filtered = append(filtered, value)
+	}
+
+	result := &types.ListAttrValueResult{Count: int64(len(filtered)), Results: make([]types.AttrValueResource, 0)}
+	if page.Offset >= uint(len(filtered)) {
+		return result, nil
+	}
+
+	end := uint(len(filtered))
+	if page.Limit > 0 && page.Offset+page.Limit < end {
+		end = page.Offset + page.Limit
+	}
+	result.Results = filtered[page.Offset:end]
+
+	return result, nil
+}
@@ -25,8 +25,14 @@ import (
 	"hcm/cmd/auth-server/types"
 	"hcm/pkg/api/core"
 	dataservice "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/sdk/auth"
+	"hcm/pkg/iam/sdk/operator"
+	"hcm/pkg/iam/sys"
 	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
 )
 
 // ListInstances query instances based on filter criteria.
@@ -79,9 +85,96 @@ func (i *IAM) ListInstances(kt *kit.Kit, resType client.TypeID, option *types.Li
 }
 
 // ListInstancesWithAttributes list resource instances that user is privileged to access by policy, returns id list.
+// Note: only attributes that the resource type itself has can be calculated here, attributes like region of the
+// resources under account need to be set in resource attribute when authorizing.
 func (i *IAM) ListInstancesWithAttributes(ctx context.Context, opts *client.ListWithAttributes) (idList []string,
 	err error) {
 
-	// TODO implement this when attribute auth is enabled
-	return make([]string, 0), nil
+	kt := newKit(ctx)
+
+	if opts.Type != sys.Account {
+		logs.Errorf("resource type %s does not support attribute auth, rid: %s", opts.Type, kt.Rid)
+		return make([]string, 0), nil
+	}
+
+	rules := make([]filter.RuleFactory, 0, len(opts.AttrPolicies))
+	for _, policy := range opts.AttrPolicies {
+		for _, attr := range auth.ListPolicyAttributes(policy) {
+			if _, exists := sys.AccountAttributeFields[attr]; !exists {
+				// the resource to be authorized does not carry this attribute, regard it as unauthorized.
+				logs.Warnf("account does not have attribute %s, policy is not matched, rid: %s", attr, kt.Rid)
+				return make([]string, 0), nil
+			}
+		}
+
+		expr, isAny, err := auth.PolicyToExpr(policy, sys.AccountAttributeFields, false)
+		if err != nil {
+			logs.Errorf("convert policy to filter failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		if isAny {
+			if opts.Operator == operator.Or {
+				rules = make([]filter.RuleFactory, 0)
+				break
+			}
+			continue
+		}
+		rules = append(rules, expr)
+	}
+
+	op := filter.And
+	if opts.Operator == operator.Or && len(rules) > 0 {
+		op = filter.Or
+	}
+	expr := &filter.Expression{Op: op, Rules: rules}
+
+	if len(opts.IDList) != 0 {
+		idRule := &filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: opts.IDList}
+		if len(rules) == 0 {
+			expr.Rules = []filter.RuleFactory{idRule}
+		} else {
+			expr = &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{idRule, expr}}
+		}
+	}
+
+	ids := make([]string, 0)
+	page := &core.BasePage{Start: 0, Limit: client.BkIAMMaxPageSize}
+	for {
+		req := &dataservice.ListInstancesReq{
+			ResourceType: opts.Type,
+			Filter:       expr,
+			Page:         page,
+		}
+		resp, err := i.ds.Global.Auth.ListInstances(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list instances with attributes failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			ids = append(ids, one.ID)
+		}
+
+		if uint(len(resp.Details)) < page.Limit {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+
+	return ids, nil
+}
+
+// newKit generate kit for fetching resource instances in authorize process.
+func newKit(ctx context.Context) *kit.Kit {
+	kt := kit.New()
+	if ctx != nil {
+		kt.Ctx = ctx
+		if rid, ok := ctx.Value(constant.RidKey).(string); ok && len(rid) != 0 {
+			kt.Rid = rid
+		}
+	}
+	kt.User = constant.AuthAttrFetcherUserKey
+	kt.AppCode = constant.AuthAttrFetcherAppCodeKey
+	return kt
 }
@@ -72,16 +72,22 @@ func (i *IAM) PullResource(cts *rest.Contexts) (interface{}, error) {
 		return info, nil
 
 	case types.ListAttrMethod:
-		// attribute authentication is not needed for the time being,
-		// so the interface does not need to be implemented
-		logs.Errorf("pull resource method list_attr not support, rid: %s", cts.Kit.Rid)
-		return nil, errf.New(errf.InvalidParameter, "list_attr not support")
+		return i.ListAttr(req.Type)
 
 	case types.ListAttrValueMethod:
-		// attribute authentication is not needed for the time being,
-		// so the interface does not need to be implemented
-		logs.Errorf("pull resource method list_attr_value not support, rid: %s", cts.Kit.Rid)
-		return nil, errf.New(errf.InvalidParameter, "list_attr_value not support")
+		filter, ok := req.Filter.(types.ListAttrValueFilter)
+		if !ok {
+			logs.Errorf("filter %v is not the right type for list_attr_value method, rid: %s", filter, cts.Kit.Rid)
+			return nil, errf.New(errf.InvalidParameter, "filter type not right")
+		}
+
+		result, err := i.ListAttrValue(req.Type, &filter, req.Page)
+		if err != nil {
+			logs.Errorf("list attribute value failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		return result, nil
 
 	case types.ListInstanceByPolicyMethod:
 		// sdk authentication is used, and there is no need to support this interface.
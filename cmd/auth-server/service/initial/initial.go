@@ -23,6 +23,7 @@ import (
 	"hcm/cmd/auth-server/service/capability"
 	authserver "hcm/pkg/api/auth-server"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/rbac"
 	"hcm/pkg/iam/sys"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
@@ -32,18 +33,21 @@ import (
 type Initial struct {
 	// iam client.
 	iamSys *sys.Sys
+	// rbac is the built-in rbac authorizer, it is nil when authorize by iam.
+	rbac *rbac.Authorizer
 	// disableAuth defines whether iam authorization is disabled
 	disableAuth bool
 }
 
 // NewInitial new initial.
-func NewInitial(iamSys *sys.Sys, disableAuth bool) (*Initial, error) {
+func NewInitial(iamSys *sys.Sys, rbac *rbac.Authorizer, disableAuth bool) (*Initial, error) {
 	if iamSys == nil {
 		return nil, errf.New(errf.InvalidParameter, "iam sys is nil")
 	}
 
 	i := &Initial{
 		iamSys:      iamSys,
+		rbac:        rbac,
 		disableAuth: disableAuth,
 	}
 
@@ -55,6 +59,7 @@ func (i *Initial) InitInitialService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("InitAuthCenter", "POST", "/init/authcenter", i.InitAuthCenter)
+	h.Add("InitRbac", "POST", "/init/rbac", i.InitRbac)
 
 	h.Load(c.WebService)
 }
@@ -72,6 +77,12 @@ func (i *Initial) InitAuthCenter(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.New(errf.Aborted, "authorize function is disabled, can not init auth center.")
 	}
 
+	// if authorize by built-in rbac, the auth model is initialized by init rbac instead
+	if i.rbac != nil {
+		logs.Errorf("authorizer is built-in rbac, can not init auth center, rid: %s", cts.Kit.Rid)
+		return nil, errf.New(errf.Aborted, "authorizer is built-in rbac, can not init auth center.")
+	}
+
 	if err := req.Validate(); err != nil {
 		logs.Errorf("request param validate failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
@@ -84,3 +95,23 @@ func (i *Initial) InitAuthCenter(cts *rest.Contexts) (interface{}, error) {
 
 	return nil, nil
 }
+
+// InitRbac export the auth model as the builtin roles of built-in rbac, and bind the admin role to the admins.
+func (i *Initial) InitRbac(cts *rest.Contexts) (interface{}, error) {
+	req := new(authserver.InitRbacReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if i.rbac == nil {
+		logs.Errorf("authorizer is not built-in rbac, can not init rbac, rid: %s", cts.Kit.Rid)
+		return nil, errf.New(errf.Aborted, "authorizer is not built-in rbac, can not init rbac.")
+	}
+
+	if err := i.rbac.InitBuiltinRoles(cts.Kit, req.Admins); err != nil {
+		logs.Errorf("init rbac builtin roles failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
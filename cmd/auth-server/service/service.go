@@ -32,6 +32,7 @@ import (
 	apicli "hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/rbac"
 	pkgauth "hcm/pkg/iam/sdk/auth"
 	"hcm/pkg/iam/sys"
 	"hcm/pkg/logs"
@@ -62,10 +63,10 @@ type Service struct {
 }
 
 // NewService create a service instance.
-func NewService(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb, disableAuth bool,
-	disableWriteOpt *options.DisableWriteOption) (*Service, error) {
+func NewService(sd serviced.Discover, authorizer cc.Authorizer, iamSettings cc.IAM, esbSettings cc.Esb,
+	disableAuth bool, disableWriteOpt *options.DisableWriteOption) (*Service, error) {
 
-	cli, err := newClientSet(sd, iamSettings, esbSettings, disableAuth)
+	cli, err := newClientSet(sd, authorizer, iamSettings, esbSettings, disableAuth)
 	if err != nil {
 		return nil, fmt.Errorf("new client set failed, err: %v", err)
 	}
@@ -89,7 +90,8 @@ func NewService(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb, di
 	return s, nil
 }
 
-func newClientSet(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb, disableAuth bool) (*ClientSet, error) {
+func newClientSet(sd serviced.Discover, authorizer cc.Authorizer, iamSettings cc.IAM, esbSettings cc.Esb,
+	disableAuth bool) (*ClientSet, error) {
 
 	logs.Infof("start initialize the client set.")
 
@@ -142,18 +144,28 @@ func newClientSet(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb,
 		return nil, err
 	}
 
-	authSdk, err := pkgauth.NewAuth(iamCli, iamLgc, esbClient)
-	if err != nil {
-		return nil, fmt.Errorf("new iam auth sdk failed, err: %v", err)
-	}
-	logs.Infof("initialize iam auth sdk success.")
-
 	cs := &ClientSet{
 		ds:     apiClientSet.DataService(),
 		sys:    iamSys,
-		auth:   authSdk,
 		esbCli: esbClient,
 	}
+
+	switch authorizer.Type {
+	case cc.RbacAuthorizer:
+		cs.rbac, err = rbac.NewAuthorizer(apiClientSet.DataService())
+		if err != nil {
+			return nil, fmt.Errorf("new rbac authorizer failed, err: %v", err)
+		}
+		cs.auth = cs.rbac
+		logs.Infof("initialize built-in rbac authorizer success.")
+	default:
+		cs.auth, err = pkgauth.NewAuth(iamCli, iamLgc, esbClient)
+		if err != nil {
+			return nil, fmt.Errorf("new iam auth sdk failed, err: %v", err)
+		}
+		logs.Infof("initialize iam auth sdk success.")
+	}
+
 	logs.Infof("initialize the client set success.")
 	return cs, nil
 }
@@ -166,6 +178,8 @@ type ClientSet struct {
 	sys *sys.Sys
 	// auth related operate.
 	auth pkgauth.Authorizer
+	// rbac is the built-in rbac authorizer, only set when authorize by rbac.
+	rbac *rbac.Authorizer
 	// esb client.
 	esbCli esb.Client
 }
@@ -174,7 +188,7 @@ type ClientSet struct {
 func (s *Service) initLogicModule() error {
 	var err error
 
-	s.initial, err = initial.NewInitial(s.client.sys, s.disableAuth)
+	s.initial, err = initial.NewInitial(s.client.sys, s.client.rbac, s.disableAuth)
 	if err != nil {
 		return err
 	}
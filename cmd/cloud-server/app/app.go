@@ -33,6 +33,7 @@ import (
 	"hcm/pkg/runtime/ctl"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the cloud server.
@@ -69,6 +70,10 @@ func (ds *cloudServer) prepare(opt *options.Option) error {
 
 	logs.InitLogger(cc.CloudServer().Log.Logs())
 
+	if err := tracing.InitTracing(cc.CloudServer().Tracing); err != nil {
+		return fmt.Errorf("init tracing failed, err: %v", err)
+	}
+
 	logs.Infof("load settings from config file success.")
 
 	// init metrics
@@ -0,0 +1,369 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy defines the tag policy logics of cloud-server, including the creation time check of the tags,
+// and the evaluation and auto remediation of the account's resources.
+package tagpolicy
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/api/core"
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
+	coretagpolicy "hcm/pkg/api/core/tag-policy"
+	prototagpolicy "hcm/pkg/api/data-service/tag-policy"
+	hcresourcetag "hcm/pkg/api/hc-service/resource-tag"
+	"hcm/pkg/client"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// TagWritableVendors is the vendors whose resource tags can be written back to cloud, the missing required tags are
+// only remediated for these vendors.
+var TagWritableVendors = []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.Azure, enumor.Gcp, enumor.HuaWei}
+
+// CheckTags check if the tags of the resource to create comply with the enabled tag policies of the account and biz.
+func CheckTags(kt *kit.Kit, cli *dataservice.Client, accountID string, bkBizID int64,
+	resType enumor.CloudResourceType, tags []coreresourcetag.Tag) error {
+
+	scope := []filter.RuleFactory{filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID}}
+	if bkBizID > 0 {
+		scope = append(scope, filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(), Value: bkBizID})
+	}
+
+	policies, err := listPolicies(kt, cli, &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			filter.AtomRule{Field: "enabled", Op: filter.Equal.Factory(), Value: true},
+			&filter.Expression{Op: filter.Or, Rules: scope},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	messages := make([]string, 0)
+	for _, policy := range policies {
+		if !slice.IsItemInSlice(policy.ResTypes, resType) {
+			continue
+		}
+
+		checker, err := coretagpolicy.NewChecker(policy.Rules)
+		if err != nil {
+			logs.Errorf("tag policy %s rules are invalid, err: %v, rid: %s", policy.ID, err, kt.Rid)
+			return err
+		}
+
+		for _, violation := range checker.Check(tags) {
+			messages = append(messages, fmt.Sprintf("%s(policy: %s)", violation.Detail, policy.Name))
+		}
+	}
+
+	if len(messages) != 0 {
+		return errf.Newf(errf.InvalidParameter, "tags violate tag policy, %s", strings.Join(messages, "; "))
+	}
+
+	return nil
+}
+
+// AddResourceTags add the tags to the resource on cloud, the existing tags of the resource on cloud are kept unless
+// they have the same key with the added tags.
+func AddResourceTags(kt *kit.Kit, cli *client.ClientSet, vendor enumor.Vendor, resType enumor.CloudResourceType,
+	resID string, tags []coreresourcetag.Tag) error {
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tableName, err := resType.ConvTableName()
+	if err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &hcresourcetag.ResourceTagAddReq{
+		ResType: string(tableName),
+		ResID:   resID,
+		Tags:    tags,
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return cli.HCService().TCloud.ResourceTag.AddResourceTag(kt.Ctx, kt.Header(), req)
+	case enumor.Aws:
+		return cli.HCService().Aws.ResourceTag.AddResourceTag(kt.Ctx, kt.Header(), req)
+	case enumor.Azure:
+		return cli.HCService().Azure.ResourceTag.AddResourceTag(kt.Ctx, kt.Header(), req)
+	case enumor.Gcp:
+		return cli.HCService().Gcp.ResourceTag.AddResourceTag(kt.Ctx, kt.Header(), req)
+	case enumor.HuaWei:
+		return cli.HCService().HuaWei.ResourceTag.AddResourceTag(kt.Ctx, kt.Header(), req)
+	default:
+		return errf.Newf(errf.InvalidParameter, "%s does not support the update of resource tags", vendor)
+	}
+}
+
+// Evaluate the tags of the account's resources against the tag policies, the missing required tags of the auto
+// remediate policies are set to their default values, and the resources are evaluated again after the remediation.
+func Evaluate(kt *kit.Kit, cli *client.ClientSet, accountID string) (*coretagpolicy.EvaluateResult, error) {
+	evaluateReq := &prototagpolicy.TagPolicyEvaluateReq{AccountID: accountID}
+	result, err := cli.DataService().Global.TagPolicy.EvaluateTagPolicy(kt.Ctx, kt.Header(), evaluateReq)
+	if err != nil {
+		logs.Errorf("evaluate tag policy failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+		return nil, err
+	}
+
+	if result.ViolationCount == 0 {
+		return result, nil
+	}
+
+	remediated, err := remediate(kt, cli, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if remediated == 0 {
+		return result, nil
+	}
+
+	logs.Infof("tag policy remediated %d resources of account %s, rid: %s", remediated, accountID, kt.Rid)
+
+	result, err = cli.DataService().Global.TagPolicy.EvaluateTagPolicy(kt.Ctx, kt.Header(), evaluateReq)
+	if err != nil {
+		logs.Errorf("evaluate tag policy after remediation failed, err: %v, account: %s, rid: %s", err, accountID,
+			kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// remediateResource is the resource whose missing required tags are to be remediated by the policies.
+type remediateResource struct {
+	resType   enumor.CloudResourceType
+	resID     string
+	vendor    enumor.Vendor
+	policyIDs []string
+}
+
+// remediate set the default values of the missing required tags of the auto remediate policies to the resources,
+// returns the count of the remediated resources. one resource's remediation failure does not affect the others.
+func remediate(kt *kit.Kit, cli *client.ClientSet, accountID string) (int, error) {
+	policies, err := listPolicies(kt, cli.DataService(), &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			filter.AtomRule{Field: "enabled", Op: filter.Equal.Factory(), Value: true},
+			filter.AtomRule{Field: "auto_remediate", Op: filter.Equal.Factory(), Value: true},
+			&filter.Expression{
+				Op: filter.Or,
+				Rules: []filter.RuleFactory{
+					filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+					filter.AtomRule{Field: "bk_biz_id", Op: filter.GreaterThan.Factory(), Value: 0},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	checkers := make(map[string]*coretagpolicy.Checker, len(policies))
+	for _, policy := range policies {
+		checker, err := coretagpolicy.NewChecker(policy.Rules)
+		if err != nil {
+			logs.Errorf("tag policy %s rules are invalid, err: %v, rid: %s", policy.ID, err, kt.Rid)
+			return 0, err
+		}
+		checkers[policy.ID] = checker
+	}
+
+	if len(checkers) == 0 {
+		return 0, nil
+	}
+
+	resources, err := listRemediateResources(kt, cli.DataService(), accountID, checkers)
+	if err != nil {
+		return 0, err
+	}
+
+	remediated := 0
+	for _, res := range resources {
+		if !slice.IsItemInSlice(TagWritableVendors, res.vendor) {
+			continue
+		}
+
+		tags, err := listResourceTags(kt, cli.DataService(), res.resType, res.resID)
+		if err != nil {
+			return remediated, err
+		}
+
+		existTags := make(map[string]string, len(tags))
+		for _, tag := range tags {
+			existTags[tag.Key] = tag.Value
+		}
+
+		for _, policyID := range res.policyIDs {
+			tags, _ = checkers[policyID].Remediate(tags)
+		}
+
+		// only the remediated tags are added, so that the tags on cloud which are not synced yet are not overwritten.
+		added := make([]coreresourcetag.Tag, 0)
+		for _, tag := range tags {
+			if value, exists := existTags[tag.Key]; !exists || value != tag.Value {
+				added = append(added, tag)
+			}
+		}
+
+		if len(added) == 0 {
+			continue
+		}
+
+		if err = AddResourceTags(kt, cli, res.vendor, res.resType, res.resID, added); err != nil {
+			logs.Errorf("remediate %s %s tags failed, err: %v, rid: %s", res.resType, res.resID, err, kt.Rid)
+			continue
+		}
+		remediated++
+	}
+
+	return remediated, nil
+}
+
+// listRemediateResources list the resources that miss the required tags of the auto remediate policies.
+func listRemediateResources(kt *kit.Kit, cli *dataservice.Client, accountID string,
+	checkers map[string]*coretagpolicy.Checker) ([]*remediateResource, error) {
+
+	policyIDs := make([]string, 0, len(checkers))
+	for id := range checkers {
+		policyIDs = append(policyIDs, id)
+	}
+
+	expr, err := tools.And(
+		filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+		filter.AtomRule{Field: "reason", Op: filter.Equal.Factory(), Value: enumor.MissingTagViolation},
+		filter.AtomRule{Field: "policy_id", Op: filter.In.Factory(), Value: policyIDs},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]*remediateResource, 0)
+	resMap := make(map[string]*remediateResource)
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+		Fields: []string{"policy_id", "res_type", "res_id", "vendor"},
+	}
+	for {
+		result, err := cli.Global.TagPolicy.ListTagPolicyViolation(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list tag policy violation failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			key := string(one.ResType) + "/" + one.ResID
+			res, exists := resMap[key]
+			if !exists {
+				res = &remediateResource{resType: one.ResType, resID: one.ResID, vendor: one.Vendor}
+				resMap[key] = res
+				resources = append(resources, res)
+			}
+
+			if !slice.IsItemInSlice(res.policyIDs, one.PolicyID) {
+				res.policyIDs = append(res.policyIDs, one.PolicyID)
+			}
+		}
+
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return resources, nil
+}
+
+// listPolicies list all the tag policies that match the filter.
+func listPolicies(kt *kit.Kit, cli *dataservice.Client, expr *filter.Expression) ([]coretagpolicy.TagPolicy,
+	error) {
+
+	policies := make([]coretagpolicy.TagPolicy, 0)
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+	for {
+		result, err := cli.Global.TagPolicy.ListTagPolicy(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list tag policy failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		policies = append(policies, result.Details...)
+
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return policies, nil
+}
+
+// listResourceTags list the tags of the resource from the resource tag store.
+func listResourceTags(kt *kit.Kit, cli *dataservice.Client, resType enumor.CloudResourceType, resID string) (
+	[]coreresourcetag.Tag, error) {
+
+	tableName, err := resType.ConvTableName()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, err := tools.And(
+		filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: tableName},
+		filter.AtomRule{Field: "res_id", Op: filter.Equal.Factory(), Value: resID},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+		Fields: []string{"tag_key", "tag_value"},
+	}
+	result, err := cli.Global.ResourceTag.ListResourceTag(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list %s %s tags failed, err: %v, rid: %s", resType, resID, err, kt.Rid)
+		return nil, err
+	}
+
+	tags := make([]coreresourcetag.Tag, 0, len(result.Details))
+	for _, one := range result.Details {
+		tags = append(tags, coreresourcetag.Tag{Key: one.TagKey, Value: one.TagValue})
+	}
+
+	return tags, nil
+}
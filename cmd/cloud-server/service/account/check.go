@@ -118,10 +118,13 @@ func ParseAndCheckAwsExtension(
 			cts.Kit.Ctx,
 			cts.Kit.Header(),
 			&hcproto.AwsAccountCheckReq{
-				CloudAccountID:   extension.CloudAccountID,
-				CloudIamUsername: extension.CloudIamUsername,
-				CloudSecretID:    extension.CloudSecretID,
-				CloudSecretKey:   extension.CloudSecretKey,
+				CloudAccountID:      extension.CloudAccountID,
+				CloudIamUsername:    extension.CloudIamUsername,
+				CloudSecretID:       extension.CloudSecretID,
+				CloudSecretKey:      extension.CloudSecretKey,
+				CloudCredentialType: extension.CloudCredentialType,
+				CloudRoleArn:        extension.CloudRoleArn,
+				CloudExternalID:     extension.CloudExternalID,
 			},
 		)
 		if err != nil {
@@ -189,6 +192,7 @@ func ParseAndCheckGcpExtension(
 			&hcproto.GcpAccountCheckReq{
 				CloudProjectID:        extension.CloudProjectID,
 				CloudServiceSecretKey: extension.CloudServiceSecretKey,
+				CloudCredentialType:   extension.CloudCredentialType,
 			},
 		)
 		if err != nil {
@@ -222,6 +226,7 @@ func ParseAndCheckAzureExtension(
 				CloudSubscriptionID:  extension.CloudSubscriptionID,
 				CloudApplicationID:   extension.CloudApplicationID,
 				CloudClientSecretKey: extension.CloudClientSecretKey,
+				CloudCredentialType:  extension.CloudCredentialType,
 			},
 		)
 		if err != nil {
@@ -345,10 +350,13 @@ func (a *accountSvc) parseAndCheckAwsExtensionByID(
 			cts.Kit.Ctx,
 			cts.Kit.Header(),
 			&hcproto.AwsAccountCheckReq{
-				CloudAccountID:   account.Extension.CloudAccountID,
-				CloudIamUsername: extension.CloudIamUsername,
-				CloudSecretID:    extension.CloudSecretID,
-				CloudSecretKey:   extension.CloudSecretKey,
+				CloudAccountID:      account.Extension.CloudAccountID,
+				CloudIamUsername:    extension.CloudIamUsername,
+				CloudSecretID:       extension.CloudSecretID,
+				CloudSecretKey:      extension.CloudSecretKey,
+				CloudCredentialType: extension.CloudCredentialType,
+				CloudRoleArn:        extension.CloudRoleArn,
+				CloudExternalID:     extension.CloudExternalID,
 			},
 		)
 		if err != nil {
@@ -432,6 +440,7 @@ func (a *accountSvc) parseAndCheckGcpExtensionByID(
 			&hcproto.GcpAccountCheckReq{
 				CloudProjectID:        account.Extension.CloudProjectID,
 				CloudServiceSecretKey: extension.CloudServiceSecretKey,
+				CloudCredentialType:   extension.CloudCredentialType,
 			},
 		)
 		if err != nil {
@@ -473,6 +482,7 @@ func (a *accountSvc) parseAndCheckAzureExtensionByID(
 				CloudSubscriptionID:  account.Extension.CloudSubscriptionID,
 				CloudApplicationID:   extension.CloudApplicationID,
 				CloudClientSecretKey: extension.CloudClientSecretKey,
+				CloudCredentialType:  extension.CloudCredentialType,
 			},
 		)
 		if err != nil {
@@ -0,0 +1,117 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	proto "hcm/pkg/api/cloud-server/account"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// ListHealth list the health of the accounts which user has the permission to view.
+func (a *accountSvc) ListHealth(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.AccountHealthListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 校验用户是否有查看权限，有权限的ID列表
+	accountIDs, isAny, err := a.listAuthorized(cts, meta.Find, meta.Account)
+	if err != nil {
+		return nil, err
+	}
+	// 无任何账号权限
+	if len(accountIDs) == 0 && !isAny {
+		return &dataproto.AccountHealthListResult{Details: make([]corecloud.AccountHealth, 0)}, nil
+	}
+
+	// 构造权限过滤条件
+	var reqFilter *filter.Expression
+	if isAny {
+		reqFilter = req.Filter
+	} else {
+		reqFilter = &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "account_id", Op: filter.In.Factory(), Value: accountIDs},
+			},
+		}
+		// 加上请求里过滤条件
+		if req.Filter != nil && !req.Filter.IsEmpty() {
+			reqFilter.Rules = append(reqFilter.Rules, req.Filter)
+		}
+	}
+
+	if reqFilter == nil {
+		reqFilter = tools.AllExpression()
+	}
+
+	return a.client.DataService().Global.Account.ListHealth(cts.Kit.Ctx, cts.Kit.Header(),
+		&core.ListReq{Filter: reqFilter, Page: req.Page})
+}
+
+// CheckHealth check the health of the account immediately and record the check result.
+func (a *accountSvc) CheckHealth(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.AccountHealthCheckReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	accountID := cts.PathParameter("account_id").String()
+
+	// 校验用户有该账号的更新权限
+	if err := a.checkPermission(cts, meta.Update, accountID); err != nil {
+		return nil, err
+	}
+
+	// 查询该账号对应的Vendor
+	baseInfo, err := a.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.AccountCloudResType, accountID)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	accounts := []*corecloud.BaseAccount{{ID: accountID, Vendor: baseInfo.Vendor}}
+	records, err := a.healthChecker.Check(cts.Kit, accounts, req.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errf.New(errf.Aborted, "account health check is not finished, please try again later")
+	}
+
+	return records[0], nil
+}
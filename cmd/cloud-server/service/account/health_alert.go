@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// accountHealthAlert is the alert of the account whose health status is changed.
+type accountHealthAlert struct {
+	Vendor              enumor.Vendor                        `json:"vendor"`
+	AccountID           string                               `json:"account_id"`
+	Status              enumor.AccountHealthStatus           `json:"status"`
+	PreviousStatus      enumor.AccountHealthStatus           `json:"previous_status"`
+	CheckAt             string                               `json:"check_at"`
+	Error               string                               `json:"error,omitempty"`
+	ConsecutiveFailures uint64                               `json:"consecutive_failures,omitempty"`
+	MissingPermissions  []corecloud.AccountMissingPermission `json:"missing_permissions,omitempty"`
+}
+
+// accountHealthNotifier notifies the accounts whose health status is changed.
+type accountHealthNotifier interface {
+	Notify(kt *kit.Kit, alerts []accountHealthAlert) error
+}
+
+// newAccountHealthNotifier new account health notifier, the alerts are only recorded if no webhook configured.
+func newAccountHealthNotifier(webhooks []string) accountHealthNotifier {
+	if len(webhooks) == 0 {
+		return new(logNotifier)
+	}
+
+	return &webhookNotifier{
+		webhooks: webhooks,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// logNotifier only prints the alerts to log.
+type logNotifier struct{}
+
+// Notify account health alerts.
+func (n *logNotifier) Notify(kt *kit.Kit, alerts []accountHealthAlert) error {
+	for _, one := range alerts {
+		logs.Infof("account health status changed, account: %s, vendor: %s, status: %s -> %s, error: %s, "+
+			"missing permissions: %d, rid: %s", one.AccountID, one.Vendor, one.PreviousStatus, one.Status, one.Error,
+			len(one.MissingPermissions), kt.Rid)
+	}
+
+	return nil
+}
+
+// webhookNotifier posts the alerts to the webhooks as json.
+type webhookNotifier struct {
+	webhooks []string
+	client   *http.Client
+}
+
+// accountHealthAlertBody is the body posted to the webhook.
+type accountHealthAlertBody struct {
+	Accounts []accountHealthAlert `json:"accounts"`
+}
+
+// Notify account health alerts, every webhook is tried even if some of them failed.
+func (n *webhookNotifier) Notify(kt *kit.Kit, alerts []accountHealthAlert) error {
+	body, err := json.Marshal(accountHealthAlertBody{Accounts: alerts})
+	if err != nil {
+		return err
+	}
+
+	var hitErr error
+	for _, webhook := range n.webhooks {
+		if err = n.post(kt, webhook, body); err != nil {
+			logs.Errorf("post account health alert to webhook failed, webhook: %s, err: %v, rid: %s", webhook, err,
+				kt.Rid)
+			hitErr = err
+		}
+	}
+
+	return hitErr
+}
+
+func (n *webhookNotifier) post(kt *kit.Kit, webhook string, body []byte) error {
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.RidKey, kt.Rid)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responds with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
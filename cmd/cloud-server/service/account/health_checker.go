@@ -0,0 +1,223 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	hcproto "hcm/pkg/api/hc-service/account"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// accountHealthChecker checks the health of the accounts by hc-service, records the check result and notifies
+// the accounts whose health status is changed.
+type accountHealthChecker struct {
+	client   *client.ClientSet
+	notifier accountHealthNotifier
+	// failureThreshold is the consecutive failures count that an unavailable account is alerted.
+	failureThreshold uint64
+}
+
+// Check the health of the accounts, returns the check result of the accounts. the account is skipped and not
+// recorded if hc-service can not be reached, because it's not the account's fault.
+func (c *accountHealthChecker) Check(kt *kit.Kit, accounts []*corecloud.BaseAccount, region string) (
+	[]dataproto.AccountHealthRecordReq, error) {
+
+	if len(accounts) == 0 {
+		return make([]dataproto.AccountHealthRecordReq, 0), nil
+	}
+
+	accountIDs := make([]string, 0, len(accounts))
+	for _, one := range accounts {
+		accountIDs = append(accountIDs, one.ID)
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("account_id", accountIDs),
+		Page:   core.DefaultBasePage,
+	}
+	exists, err := c.client.DataService().Global.Account.ListHealth(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list account health failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	prevMap := make(map[string]corecloud.AccountHealth, len(exists.Details))
+	for _, one := range exists.Details {
+		prevMap[one.AccountID] = one
+	}
+
+	records := make([]dataproto.AccountHealthRecordReq, 0, len(accounts))
+	for _, one := range accounts {
+		record, err := c.checkOne(kt, one.Vendor, one.ID, region)
+		if err != nil {
+			logs.Errorf("check account health failed, account: %s, err: %v, rid: %s", one.ID, err, kt.Rid)
+			continue
+		}
+
+		records = append(records, *record)
+	}
+
+	if len(records) == 0 {
+		return records, nil
+	}
+
+	for start := 0; start < len(records); start += constant.BatchOperationMaxLimit {
+		end := start + constant.BatchOperationMaxLimit
+		if end > len(records) {
+			end = len(records)
+		}
+
+		recordReq := &dataproto.AccountHealthBatchRecordReq{Records: records[start:end]}
+		if err = c.client.DataService().Global.Account.BatchRecordHealth(kt.Ctx, kt.Header(),
+			recordReq); err != nil {
+			logs.Errorf("record account health failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	alerts := make([]accountHealthAlert, 0)
+	for _, one := range records {
+		prev, exist := prevMap[one.AccountID]
+		var prevPtr *corecloud.AccountHealth
+		if exist {
+			prevPtr = &prev
+		}
+
+		if alert, need := c.buildAlert(prevPtr, one); need {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	if len(alerts) != 0 {
+		if err = c.notifier.Notify(kt, alerts); err != nil {
+			logs.Errorf("notify account health alert failed, err: %v, rid: %s", err, kt.Rid)
+		}
+	}
+
+	return records, nil
+}
+
+// checkOne check the health of one account, the error returned by hc-service means the credential can not be
+// used, other error means hc-service is not available.
+func (c *accountHealthChecker) checkOne(kt *kit.Kit, vendor enumor.Vendor, accountID, region string) (
+	*dataproto.AccountHealthRecordReq, error) {
+
+	req := &hcproto.AccountHealthCheckReq{AccountID: accountID, Region: region}
+
+	var result *hcproto.AccountHealthCheckResult
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		result, err = c.client.HCService().TCloud.Account.HealthCheck(kt.Ctx, kt.Header(), req)
+	case enumor.Aws:
+		result, err = c.client.HCService().Aws.Account.HealthCheck(kt.Ctx, kt.Header(), req)
+	case enumor.HuaWei:
+		result, err = c.client.HCService().HuaWei.Account.HealthCheck(kt.Ctx, kt.Header(), req)
+	case enumor.Gcp:
+		result, err = c.client.HCService().Gcp.Account.HealthCheck(kt.Ctx, kt.Header(), req)
+	case enumor.Azure:
+		result, err = c.client.HCService().Azure.Account.HealthCheck(kt.Ctx, kt.Header(), req)
+	default:
+		return nil, fmt.Errorf("no support vendor: %s", vendor)
+	}
+
+	record := &dataproto.AccountHealthRecordReq{
+		Vendor:    vendor,
+		AccountID: accountID,
+		CheckAt:   time.Now().Format(time.RFC3339),
+	}
+
+	if err != nil {
+		if _, ok := err.(*errf.ErrorF); !ok {
+			return nil, err
+		}
+
+		record.Status = enumor.UnavailableAccountStatus
+		record.Error = err.Error()
+		return record, nil
+	}
+
+	record.Status = enumor.HealthyAccountStatus
+	record.MissingPermissions = make([]corecloud.AccountMissingPermission, 0, len(result.MissingPermissions))
+	for _, one := range result.MissingPermissions {
+		record.MissingPermissions = append(record.MissingPermissions, corecloud.AccountMissingPermission{
+			ResType: one.ResType,
+			Action:  one.Action,
+			Reason:  one.Reason,
+		})
+	}
+
+	if len(record.MissingPermissions) != 0 {
+		record.Status = enumor.MissingPermissionAccountStatus
+	}
+
+	return record, nil
+}
+
+// buildAlert build the alert if the account health status is changed. unavailable account is alerted only when
+// the consecutive failures reaches the threshold to avoid alerting on occasional failure, and recovery is alerted
+// only if the unhealthy status is alerted before.
+func (c *accountHealthChecker) buildAlert(prev *corecloud.AccountHealth, record dataproto.AccountHealthRecordReq) (
+	accountHealthAlert, bool) {
+
+	prevStatus := enumor.HealthyAccountStatus
+	prevFailures := uint64(0)
+	if prev != nil {
+		prevStatus = prev.Status
+		prevFailures = prev.ConsecutiveFailures
+	}
+
+	alert := accountHealthAlert{
+		Vendor:             record.Vendor,
+		AccountID:          record.AccountID,
+		Status:             record.Status,
+		PreviousStatus:     prevStatus,
+		CheckAt:            record.CheckAt,
+		Error:              record.Error,
+		MissingPermissions: record.MissingPermissions,
+	}
+
+	switch record.Status {
+	case enumor.UnavailableAccountStatus:
+		alert.ConsecutiveFailures = prevFailures + 1
+		return alert, alert.ConsecutiveFailures == c.failureThreshold
+
+	case enumor.MissingPermissionAccountStatus:
+		return alert, prevStatus != enumor.MissingPermissionAccountStatus
+
+	default:
+		if prevStatus == enumor.UnavailableAccountStatus {
+			return alert, prevFailures >= c.failureThreshold
+		}
+
+		return alert, !prevStatus.IsHealthy()
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"time"
+
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+)
+
+// AccountHealthCheck 定时检查资源账号的凭证是否可用，以及是否缺少资源同步所需的权限
+func AccountHealthCheck(opt cc.AccountHealth, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	intervalMin := time.Duration(opt.CheckIntervalMin) * time.Minute
+	logs.Infof("account health check pipeline enable && start, checkIntervalMin: %v", intervalMin)
+
+	checker := newAccountHealthChecker(opt, cliSet)
+	for {
+		time.Sleep(intervalMin)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := kit.New()
+		kt.User = constant.AccountHealthTimingUserKey
+		kt.AppCode = constant.AccountHealthTimingAppCodeKey
+
+		start := time.Now()
+		logs.Infof("account health check pipeline start, time: %v, rid: %s", start, kt.Rid)
+
+		allAccountHealthCheck(kt, cliSet.DataService(), checker)
+
+		logs.Infof("account health check pipeline end, cost: %v, rid: %s", time.Since(start), kt.Rid)
+	}
+}
+
+func newAccountHealthChecker(opt cc.AccountHealth, cliSet *client.ClientSet) *accountHealthChecker {
+	return &accountHealthChecker{
+		client:           cliSet,
+		notifier:         newAccountHealthNotifier(opt.AlertWebhooks),
+		failureThreshold: opt.FailureThreshold,
+	}
+}
+
+// allAccountHealthCheck check the health of all resource accounts page by page.
+func allAccountHealthCheck(kt *kit.Kit, cli *dataservice.Client, checker *accountHealthChecker) {
+	listReq := &protocloud.AccountListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{
+					Field: "type",
+					Op:    filter.Equal.Factory(),
+					Value: enumor.ResourceAccount,
+				},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: core.DefaultMaxPageLimit,
+		},
+	}
+
+	for {
+		accounts, err := cli.Global.Account.List(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("account health check list account failed, err: %v, rid: %s", err, kt.Rid)
+			return
+		}
+
+		if _, err = checker.Check(kt, accounts.Details, ""); err != nil {
+			logs.Errorf("check account health failed, err: %v, rid: %s", err, kt.Rid)
+		}
+
+		if len(accounts.Details) < int(core.DefaultMaxPageLimit) {
+			return
+		}
+
+		listReq.Page.Cursor = core.NewIDPageCursor(accounts.Details[len(accounts.Details)-1].ID)
+	}
+}
@@ -0,0 +1,435 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"fmt"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	proto "hcm/pkg/api/cloud-server/account"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	hcproto "hcm/pkg/api/hc-service/account"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/iam/sys"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/TencentBlueKing/gopkg/conv"
+)
+
+// organizationMemberKeyField 账号扩展字段中标识组织成员的字段
+var organizationMemberKeyField = map[enumor.Vendor]string{
+	enumor.TCloud: "cloud_main_account_id",
+	enumor.Aws:    "cloud_account_id",
+	enumor.Azure:  "cloud_subscription_id",
+	enumor.Gcp:    "cloud_project_id",
+}
+
+// ListOrganizationMember list the member accounts of the organization, and mark the members already imported.
+func (a *accountSvc) ListOrganizationMember(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	req := new(proto.OrganizationMemberListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(vendor); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Account, Action: meta.Import}}
+	if err := a.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	members, err := a.listOrganizationMember(cts.Kit, vendor, req.Credential, req.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		cloudIDs = append(cloudIDs, member.CloudID)
+	}
+
+	imported, err := a.listImportedAccount(cts.Kit, vendor, cloudIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]proto.OrganizationMember, 0, len(members))
+	for _, member := range members {
+		detail := proto.OrganizationMember{OrganizationMember: member}
+		if account, exists := imported[member.CloudID]; exists {
+			detail.Imported = true
+			detail.AccountID = account.ID
+			detail.AccountName = account.Name
+		}
+		details = append(details, detail)
+	}
+
+	return &proto.OrganizationMemberListResult{Details: details}, nil
+}
+
+// ImportOrganizationMember import the organization members as resource accounts in batch.
+func (a *accountSvc) ImportOrganizationMember(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	req := new(proto.OrganizationMemberImportReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(vendor); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Account, Action: meta.Import}}
+	if err := a.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	members, err := a.validateImportMember(cts.Kit, vendor, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *core.BatchCreateResult
+	switch vendor {
+	case enumor.TCloud:
+		result, err = a.importTCloudMember(cts.Kit, req)
+	case enumor.Aws:
+		result, err = a.importAwsMember(cts.Kit, req)
+	case enumor.Azure:
+		result, err = a.importAzureMember(cts.Kit, req, members)
+	case enumor.Gcp:
+		result, err = a.importGcpMember(cts.Kit, req, members)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "organization import not support vendor: %s", vendor)
+	}
+	if err != nil {
+		logs.Errorf("import %s organization member failed, err: %v, rid: %s", vendor, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 授予创建者创建资源默认附加权限
+	for index, accountID := range result.IDs {
+		inst := &meta.RegisterResCreatorActionInst{
+			Type: string(sys.Account),
+			ID:   accountID,
+			Name: req.Members[index].Name,
+		}
+		if err = a.authorizer.RegisterResourceCreatorAction(cts.Kit, inst); err != nil {
+			logs.Errorf("import account %s success, but add create action associate permissions failed, err: %v, "+
+				"rid: %s", accountID, err, cts.Kit.Rid)
+			return nil, fmt.Errorf("import accounts success, but add create action associate permissions failed, "+
+				"err: %v", err)
+		}
+	}
+
+	return result, nil
+}
+
+// validateImportMember validate the members to import belong to the organization and have not been imported, and
+// return the organization members which key is the cloud id.
+func (a *accountSvc) validateImportMember(kt *kit.Kit, vendor enumor.Vendor, req *proto.OrganizationMemberImportReq) (
+	map[string]typeaccount.OrganizationMember, error) {
+
+	members, err := a.listOrganizationMember(kt, vendor, req.Credential, req.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberMap := make(map[string]typeaccount.OrganizationMember, len(members))
+	for _, member := range members {
+		memberMap[member.CloudID] = member
+	}
+
+	cloudIDs := make([]string, 0, len(req.Members))
+	names := make([]string, 0, len(req.Members))
+	for _, member := range req.Members {
+		if _, exists := memberMap[member.CloudID]; !exists {
+			return nil, errf.Newf(errf.InvalidParameter, "%s is not the member of the organization", member.CloudID)
+		}
+		cloudIDs = append(cloudIDs, member.CloudID)
+		names = append(names, member.Name)
+	}
+
+	imported, err := a.listImportedAccount(kt, vendor, cloudIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(imported) != 0 {
+		ids := make([]string, 0, len(imported))
+		for cloudID := range imported {
+			ids = append(ids, cloudID)
+		}
+		return nil, errf.Newf(errf.InvalidParameter, "members(%v) have already been imported", ids)
+	}
+
+	// TODO: 与新增账号申请一样，后续需要解决并发问题
+	listReq := &dataproto.AccountListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "name", Op: filter.In.Factory(), Value: names},
+			},
+		},
+		Page: core.DefaultBasePage,
+	}
+	accounts, err := a.client.DataService().Global.Account.List(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(accounts.Details) != 0 {
+		duplicates := make([]string, 0, len(accounts.Details))
+		for _, account := range accounts.Details {
+			duplicates = append(duplicates, account.Name)
+		}
+		return nil, errf.Newf(errf.InvalidParameter, "account names(%v) already exist", duplicates)
+	}
+
+	return memberMap, nil
+}
+
+func (a *accountSvc) listOrganizationMember(kt *kit.Kit, vendor enumor.Vendor, cred *proto.OrganizationCredential,
+	parentID string) ([]typeaccount.OrganizationMember, error) {
+
+	var (
+		result *hcproto.OrganizationMemberListResult
+		err    error
+	)
+
+	switch vendor {
+	case enumor.TCloud:
+		result, err = a.client.HCService().TCloud.Account.ListOrganizationMember(kt.Ctx, kt.Header(),
+			&hcproto.TCloudOrganizationMemberListReq{
+				CloudSecretID:  cred.CloudSecretID,
+				CloudSecretKey: cred.CloudSecretKey,
+				ParentID:       parentID,
+			})
+	case enumor.Aws:
+		result, err = a.client.HCService().Aws.Account.ListOrganizationMember(kt.Ctx, kt.Header(),
+			&hcproto.AwsOrganizationMemberListReq{
+				CloudSecretID:  cred.CloudSecretID,
+				CloudSecretKey: cred.CloudSecretKey,
+				ParentID:       parentID,
+			})
+	case enumor.Azure:
+		result, err = a.client.HCService().Azure.Account.ListOrganizationMember(kt.Ctx, kt.Header(),
+			&hcproto.AzureOrganizationMemberListReq{
+				CloudTenantID:        cred.CloudTenantID,
+				CloudSubscriptionID:  cred.CloudSubscriptionID,
+				CloudApplicationID:   cred.CloudApplicationID,
+				CloudClientSecretKey: cred.CloudClientSecretKey,
+				CloudCredentialType:  cred.CloudCredentialType,
+				ParentID:             parentID,
+			})
+	case enumor.Gcp:
+		result, err = a.client.HCService().Gcp.Account.ListOrganizationMember(kt.Ctx, kt.Header(),
+			&hcproto.GcpOrganizationMemberListReq{
+				CloudProjectID:        cred.CloudProjectID,
+				CloudServiceSecretKey: cred.CloudServiceSecretKey,
+				CloudCredentialType:   cred.CloudCredentialType,
+				ParentID:              parentID,
+			})
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "organization import not support vendor: %s", vendor)
+	}
+	if err != nil {
+		logs.Errorf("list %s organization member failed, err: %v, parent: %s, rid: %s", vendor, err, parentID,
+			kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+// listImportedAccount list the accounts of the organization members, returns map which key is the member cloud id.
+func (a *accountSvc) listImportedAccount(kt *kit.Kit, vendor enumor.Vendor, cloudIDs []string) (
+	map[string]*dataproto.BaseAccountWithExtensionListResp, error) {
+
+	keyField := organizationMemberKeyField[vendor]
+	result := make(map[string]*dataproto.BaseAccountWithExtensionListResp)
+	for _, ids := range slice.Split(cloudIDs, int(core.DefaultMaxPageLimit)) {
+		listReq := &dataproto.AccountListReq{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+					filter.AtomRule{Field: "extension." + keyField, Op: filter.JSONIn.Factory(), Value: ids},
+				},
+			},
+			Page: core.DefaultBasePage,
+		}
+		accounts, err := a.client.DataService().Global.Account.ListWithExtension(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list %s imported account failed, err: %v, rid: %s", vendor, err, kt.Rid)
+			return nil, err
+		}
+
+		for _, account := range accounts.Details {
+			result[conv.ToString(account.Extension[keyField])] = account
+		}
+	}
+
+	return result, nil
+}
+
+func (a *accountSvc) importTCloudMember(kt *kit.Kit, req *proto.OrganizationMemberImportReq) (
+	*core.BatchCreateResult, error) {
+
+	createReq := &dataproto.AccountBatchCreateReq[dataproto.TCloudAccountExtensionCreateReq]{
+		Accounts: make([]dataproto.AccountCreateReq[dataproto.TCloudAccountExtensionCreateReq], 0, len(req.Members)),
+	}
+	for _, member := range req.Members {
+		account := dataproto.AccountCreateReq[dataproto.TCloudAccountExtensionCreateReq]{
+			Name:     member.Name,
+			Managers: member.Managers,
+			Type:     enumor.ResourceAccount,
+			Site:     req.Site,
+			Memo:     member.Memo,
+			BkBizIDs: member.BkBizIDs,
+			Extension: &dataproto.TCloudAccountExtensionCreateReq{
+				CloudMainAccountID: member.CloudID,
+				CloudSubAccountID:  member.CloudSubAccountID,
+				CloudSecretID:      member.CloudSecretID,
+				CloudSecretKey:     member.CloudSecretKey,
+			},
+		}
+		createReq.Accounts = append(createReq.Accounts, account)
+	}
+
+	return a.client.DataService().TCloud.Account.BatchCreate(kt.Ctx, kt.Header(), createReq)
+}
+
+func (a *accountSvc) importAwsMember(kt *kit.Kit, req *proto.OrganizationMemberImportReq) (
+	*core.BatchCreateResult, error) {
+
+	partition := "aws"
+	if req.Site == enumor.ChinaSite {
+		partition = "aws-cn"
+	}
+
+	createReq := &dataproto.AccountBatchCreateReq[dataproto.AwsAccountExtensionCreateReq]{
+		Accounts: make([]dataproto.AccountCreateReq[dataproto.AwsAccountExtensionCreateReq], 0, len(req.Members)),
+	}
+	for _, member := range req.Members {
+		// 成员账号通过扮演组织统一下发的角色接入，无需为每个成员账号创建密钥
+		account := dataproto.AccountCreateReq[dataproto.AwsAccountExtensionCreateReq]{
+			Name:     member.Name,
+			Managers: member.Managers,
+			Type:     enumor.ResourceAccount,
+			Site:     req.Site,
+			Memo:     member.Memo,
+			BkBizIDs: member.BkBizIDs,
+			Extension: &dataproto.AwsAccountExtensionCreateReq{
+				CloudAccountID:      member.CloudID,
+				CloudCredentialType: enumor.AssumeRoleCredential,
+				CloudRoleArn:        fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, member.CloudID, req.MemberRoleName),
+				CloudExternalID:     req.MemberExternalID,
+			},
+		}
+		createReq.Accounts = append(createReq.Accounts, account)
+	}
+
+	return a.client.DataService().Aws.Account.BatchCreate(kt.Ctx, kt.Header(), createReq)
+}
+
+func (a *accountSvc) importAzureMember(kt *kit.Kit, req *proto.OrganizationMemberImportReq,
+	members map[string]typeaccount.OrganizationMember) (*core.BatchCreateResult, error) {
+
+	cred := req.Credential
+	createReq := &dataproto.AccountBatchCreateReq[dataproto.AzureAccountExtensionCreateReq]{
+		Accounts: make([]dataproto.AccountCreateReq[dataproto.AzureAccountExtensionCreateReq], 0, len(req.Members)),
+	}
+	for _, member := range req.Members {
+		// 订阅继承租户下应用的凭证
+		account := dataproto.AccountCreateReq[dataproto.AzureAccountExtensionCreateReq]{
+			Name:     member.Name,
+			Managers: member.Managers,
+			Type:     enumor.ResourceAccount,
+			Site:     req.Site,
+			Memo:     member.Memo,
+			BkBizIDs: member.BkBizIDs,
+			Extension: &dataproto.AzureAccountExtensionCreateReq{
+				CloudTenantID:         cred.CloudTenantID,
+				CloudSubscriptionID:   member.CloudID,
+				CloudSubscriptionName: memberName(members[member.CloudID]),
+				CloudApplicationID:    cred.CloudApplicationID,
+				CloudApplicationName:  cred.CloudApplicationName,
+				CloudClientSecretID:   cred.CloudClientSecretID,
+				CloudClientSecretKey:  cred.CloudClientSecretKey,
+				CloudCredentialType:   cred.CloudCredentialType,
+			},
+		}
+		createReq.Accounts = append(createReq.Accounts, account)
+	}
+
+	return a.client.DataService().Azure.Account.BatchCreate(kt.Ctx, kt.Header(), createReq)
+}
+
+func (a *accountSvc) importGcpMember(kt *kit.Kit, req *proto.OrganizationMemberImportReq,
+	members map[string]typeaccount.OrganizationMember) (*core.BatchCreateResult, error) {
+
+	cred := req.Credential
+	createReq := &dataproto.AccountBatchCreateReq[dataproto.GcpAccountExtensionCreateReq]{
+		Accounts: make([]dataproto.AccountCreateReq[dataproto.GcpAccountExtensionCreateReq], 0, len(req.Members)),
+	}
+	for _, member := range req.Members {
+		// 项目继承组织服务账号的凭证
+		account := dataproto.AccountCreateReq[dataproto.GcpAccountExtensionCreateReq]{
+			Name:     member.Name,
+			Managers: member.Managers,
+			Type:     enumor.ResourceAccount,
+			Site:     req.Site,
+			Memo:     member.Memo,
+			BkBizIDs: member.BkBizIDs,
+			Extension: &dataproto.GcpAccountExtensionCreateReq{
+				CloudProjectID:          member.CloudID,
+				CloudProjectName:        memberName(members[member.CloudID]),
+				CloudServiceAccountID:   cred.CloudServiceAccountID,
+				CloudServiceAccountName: cred.CloudServiceAccountName,
+				CloudServiceSecretID:    cred.CloudServiceSecretID,
+				CloudServiceSecretKey:   cred.CloudServiceSecretKey,
+				CloudCredentialType:     cred.CloudCredentialType,
+			},
+		}
+		createReq.Accounts = append(createReq.Accounts, account)
+	}
+
+	return a.client.DataService().Gcp.Account.BatchCreate(kt.Ctx, kt.Header(), createReq)
+}
+
+// memberName 部分成员在云上没有名称，使用成员ID作为名称
+func memberName(member typeaccount.OrganizationMember) string {
+	if len(member.Name) != 0 {
+		return member.Name
+	}
+
+	return member.CloudID
+}
@@ -25,6 +25,7 @@ import (
 
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/cc"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/auth"
@@ -35,9 +36,10 @@ import (
 // InitAccountService initial the account service
 func InitAccountService(c *capability.Capability) {
 	svc := &accountSvc{
-		client:     c.ApiClient,
-		authorizer: c.Authorizer,
-		audit:      c.Audit,
+		client:        c.ApiClient,
+		authorizer:    c.Authorizer,
+		audit:         c.Audit,
+		healthChecker: newAccountHealthChecker(cc.CloudServer().AccountHealth, c.ApiClient),
 	}
 
 	h := rest.NewHandler()
@@ -51,6 +53,16 @@ func InitAccountService(c *capability.Capability) {
 	h.Add("DeleteAccount", http.MethodDelete, "/accounts/{account_id}", svc.DeleteAccount)
 	h.Add("DeleteValidate", http.MethodPost, "/accounts/{account_id}/delete/validate", svc.DeleteValidate)
 
+	// 账号健康状态
+	h.Add("ListHealth", http.MethodPost, "/accounts/health/list", svc.ListHealth)
+	h.Add("CheckHealth", http.MethodPost, "/accounts/{account_id}/health/check", svc.CheckHealth)
+
+	// 组织成员账号导入
+	h.Add("ListOrganizationMember", http.MethodPost, "/vendors/{vendor}/accounts/organizations/members/list",
+		svc.ListOrganizationMember)
+	h.Add("ImportOrganizationMember", http.MethodPost, "/vendors/{vendor}/accounts/organizations/members/import",
+		svc.ImportOrganizationMember)
+
 	// 获取账号配额
 	h.Add("GetTCloudZoneQuota", http.MethodPost, "/bizs/{bk_biz_id}/vendors/tcloud/accounts/{account_id}/zones/quotas",
 		svc.GetTCloudZoneQuota)
@@ -73,6 +85,8 @@ type accountSvc struct {
 	client     *client.ClientSet
 	authorizer auth.Authorizer
 	audit      audit.Interface
+	// healthChecker check and record account health.
+	healthChecker *accountHealthChecker
 }
 
 func (a *accountSvc) checkPermission(cts *rest.Contexts, action meta.Action, accountID string) error {
@@ -166,9 +166,12 @@ func (a *accountSvc) updateForAws(
 	var shouldUpdatedExtension *dataproto.AwsAccountExtensionUpdateReq = nil
 	if req.Extension != nil {
 		shouldUpdatedExtension = &dataproto.AwsAccountExtensionUpdateReq{
-			CloudIamUsername: extension.CloudIamUsername,
-			CloudSecretID:    &extension.CloudSecretID,
-			CloudSecretKey:   &extension.CloudSecretKey,
+			CloudIamUsername:    extension.CloudIamUsername,
+			CloudSecretID:       &extension.CloudSecretID,
+			CloudSecretKey:      &extension.CloudSecretKey,
+			CloudCredentialType: normalizeCredentialType(extension.CloudCredentialType),
+			CloudRoleArn:        &extension.CloudRoleArn,
+			CloudExternalID:     &extension.CloudExternalID,
 		}
 	}
 
@@ -263,6 +266,7 @@ func (a *accountSvc) updateForGcp(
 			CloudServiceAccountName: &extension.CloudServiceAccountName,
 			CloudServiceSecretID:    &extension.CloudServiceSecretID,
 			CloudServiceSecretKey:   &extension.CloudServiceSecretKey,
+			CloudCredentialType:     normalizeCredentialType(extension.CloudCredentialType),
 		}
 	}
 
@@ -310,6 +314,7 @@ func (a *accountSvc) updateForAzure(
 			CloudApplicationName: &extension.CloudApplicationName,
 			CloudClientSecretID:  &extension.CloudClientSecretID,
 			CloudClientSecretKey: &extension.CloudClientSecretKey,
+			CloudCredentialType:  normalizeCredentialType(extension.CloudCredentialType),
 		}
 	}
 
@@ -332,3 +337,12 @@ func (a *accountSvc) updateForAzure(
 	return nil, nil
 
 }
+
+// normalizeCredentialType 更新时凭证类型需要显式写入，否则从其他凭证类型切换回密钥时，空值会在合并时被忽略
+func normalizeCredentialType(credentialType enumor.CloudCredentialType) enumor.CloudCredentialType {
+	if credentialType.IsSecretKey() {
+		return enumor.SecretKeyCredential
+	}
+
+	return credentialType
+}
@@ -0,0 +1,112 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package aggregation defines the cloud resource aggregation api, which is used by dashboards to count resources
+// grouped by fields like vendor, region and status.
+package aggregation
+
+import (
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	daocloud "hcm/pkg/dal/dao/cloud"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitService initialize the cloud resource aggregation service.
+func InitService(c *capability.Capability) {
+	svc := &aggregationSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("AggregateResource", "POST", "/resources/{res_type}/aggregate", svc.AggregateResource)
+
+	// biz apis
+	h.Add("AggregateBizResource", "POST", "/bizs/{bk_biz_id}/resources/{res_type}/aggregate",
+		svc.AggregateBizResource)
+
+	h.Load(c.WebService)
+}
+
+type aggregationSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// AggregateResource aggregate the authorized cloud resources.
+func (svc *aggregationSvc) AggregateResource(cts *rest.Contexts) (interface{}, error) {
+	return svc.aggregateResource(cts, handler.ListResourceAuthRes)
+}
+
+// AggregateBizResource aggregate the cloud resources of the biz.
+func (svc *aggregationSvc) AggregateBizResource(cts *rest.Contexts) (interface{}, error) {
+	return svc.aggregateResource(cts, handler.ListBizAuthRes)
+}
+
+func (svc *aggregationSvc) aggregateResource(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (
+	interface{}, error) {
+
+	resType := enumor.CloudResourceType(cts.PathParameter("res_type").String())
+	columnTypes, exists := daocloud.AggregateColumnTypes[resType]
+	if !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s does not support aggregation", resType)
+	}
+
+	req := new(core.AggregateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// only the authorized resources are aggregated
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.ResourceType(resType), Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &core.AggregateResult{Details: make([]core.AggregateDetail, 0)}, nil
+	}
+
+	// filter out resources in recycle bin
+	if _, exists = columnTypes["recycle_status"]; exists {
+		expr, err = tools.And(expr, &filter.AtomRule{Field: "recycle_status", Op: filter.NotEqual.Factory(),
+			Value: enumor.RecycleStatus})
+		if err != nil {
+			return nil, err
+		}
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.Cloud.AggregateResource(cts.Kit.Ctx, cts.Kit.Header(), resType, req)
+}
@@ -320,6 +320,9 @@ func (a *applicationSvc) deliver(cts *rest.Contexts, application *dataproto.Appl
 		return
 	}
 
+	// 交付时创建资源后会立即查询资源进行业务分配等操作，需读主库，避免从库延迟导致查询不到新创建的资源
+	cts.Kit.ForceReadPrimary()
+
 	// 执行交付
 	deliverStatus, deliveryDetail, err := handler.Deliver()
 	// Note: 排查需要，这里无论失败还是成功，都记录日志，因为没有异步框架可以记录这些信息
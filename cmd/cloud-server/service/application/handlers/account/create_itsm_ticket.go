@@ -63,6 +63,8 @@ func (a *ApplicationOfAddAccount) RenderItsmForm() (string, error) {
 			{Label: "账号ID", Value: req.Extension["cloud_account_id"]},
 			{Label: "IAM用户名称", Value: req.Extension["cloud_iam_username"]},
 			{Label: "SecretId/密钥ID", Value: req.Extension["cloud_secret_id"]},
+			{Label: "凭证类型", Value: req.Extension["cloud_credential_type"]},
+			{Label: "扮演角色ARN", Value: req.Extension["cloud_role_arn"]},
 		}...)
 	case enumor.HuaWei:
 		formItems = append(formItems, []formItem{
@@ -80,6 +82,7 @@ func (a *ApplicationOfAddAccount) RenderItsmForm() (string, error) {
 			{Label: "服务账号ID", Value: req.Extension["cloud_service_account_id"]},
 			{Label: "服务账号名称", Value: req.Extension["cloud_service_account_name"]},
 			{Label: "服务账号密钥ID", Value: req.Extension["cloud_service_secret_id"]},
+			{Label: "凭证类型", Value: req.Extension["cloud_credential_type"]},
 		}...)
 	case enumor.Azure:
 		formItems = append(formItems, []formItem{
@@ -89,6 +92,7 @@ func (a *ApplicationOfAddAccount) RenderItsmForm() (string, error) {
 			{Label: "应用程序(客户端) ID", Value: req.Extension["cloud_application_id"]},
 			{Label: "应用程序名称", Value: req.Extension["cloud_application_name"]},
 			{Label: "客户端密钥ID", Value: req.Extension["cloud_client_secret_id"]},
+			{Label: "凭证类型", Value: req.Extension["cloud_credential_type"]},
 		}...)
 	}
 
@@ -110,6 +110,10 @@ func (a *ApplicationOfAddAccount) createForAws() (string, error) {
 				CloudIamUsername: a.req.Extension["cloud_iam_username"],
 				CloudSecretID:    a.req.Extension["cloud_secret_id"],
 				CloudSecretKey:   a.req.Extension["cloud_secret_key"],
+
+				CloudCredentialType: enumor.CloudCredentialType(a.req.Extension["cloud_credential_type"]),
+				CloudRoleArn:        a.req.Extension["cloud_role_arn"],
+				CloudExternalID:     a.req.Extension["cloud_external_id"],
 			},
 		},
 	)
@@ -165,6 +169,8 @@ func (a *ApplicationOfAddAccount) createForGcp() (string, error) {
 				CloudServiceAccountName: a.req.Extension["cloud_service_account_name"],
 				CloudServiceSecretID:    a.req.Extension["cloud_service_secret_id"],
 				CloudServiceSecretKey:   a.req.Extension["cloud_service_secret_key"],
+
+				CloudCredentialType: enumor.CloudCredentialType(a.req.Extension["cloud_credential_type"]),
 			},
 		},
 	)
@@ -193,6 +199,8 @@ func (a *ApplicationOfAddAccount) createForAzure() (string, error) {
 				CloudApplicationName:  a.req.Extension["cloud_application_name"],
 				CloudClientSecretID:   a.req.Extension["cloud_client_secret_id"],
 				CloudClientSecretKey:  a.req.Extension["cloud_client_secret_key"],
+
+				CloudCredentialType: enumor.CloudCredentialType(a.req.Extension["cloud_credential_type"]),
 			},
 		},
 	)
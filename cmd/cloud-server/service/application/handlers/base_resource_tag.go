@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package handlers
+
+import (
+	logicstagpolicy "hcm/cmd/cloud-server/logics/tag-policy"
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/logs"
+)
+
+// SetResourceTags 为交付的资源设置申请单中的标签，资源已经创建成功，设置标签失败只记录日志，不影响资源交付，
+// 缺失的标签由标签策略的评估记录违规并按需补全
+func (a *BaseApplicationHandler) SetResourceTags(resType enumor.CloudResourceType, resIDs []string,
+	tags []coreresourcetag.Tag) {
+
+	if len(tags) == 0 {
+		return
+	}
+
+	for _, id := range resIDs {
+		err := logicstagpolicy.AddResourceTags(a.Cts.Kit, a.Client, a.vendor, resType, id, tags)
+		if err != nil {
+			logs.Errorf("set %s %s tags failed, err: %v, tags: %v, rid: %s", resType, id, err, tags, a.Cts.Kit.Rid)
+		}
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"errors"
 
 	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	logicstagpolicy "hcm/cmd/cloud-server/logics/tag-policy"
+	"hcm/pkg/criteria/enumor"
 )
 
 // CheckReq 检查申请单的数据是否正确
@@ -35,6 +37,13 @@ func (a *ApplicationOfCreateAwsCvm) CheckReq() error {
 		return err
 	}
 
+	// 校验申请的标签是否满足账号和业务的标签策略
+	err := logicstagpolicy.CheckTags(a.Cts.Kit, a.Client.DataService(), a.req.AccountID, a.req.BkBizID,
+		enumor.CvmCloudResType, a.req.Tags)
+	if err != nil {
+		return err
+	}
+
 	// TCloud 支持 DryRun，可预校验
 	result, err := a.Client.HCService().Aws.Cvm.BatchCreateCvm(
 		a.Cts.Kit.Ctx,
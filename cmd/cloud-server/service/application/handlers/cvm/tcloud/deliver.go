@@ -61,6 +61,9 @@ func (a *ApplicationOfCreateTCloudCvm) Deliver() (enumor.ApplicationStatus, map[
 		return enumor.DeliverError, deliverDetail, err
 	}
 
+	// 设置申请的标签
+	a.SetResourceTags(enumor.CvmCloudResType, cvmIDs, a.req.Tags)
+
 	status := enumor.Completed
 	// 部分成功
 	if len(result.SuccessCloudIDs) != int(a.req.RequiredCount) {
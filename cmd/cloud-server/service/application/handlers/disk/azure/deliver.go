@@ -35,8 +35,18 @@ func (a *ApplicationOfCreateAzureDisk) Deliver() (status enumor.ApplicationStatu
 		return enumor.DeliverError, map[string]interface{}{"error": err.Error()}, err
 	}
 
-	return logics.CheckResultAndAssign(a.Cts.Kit, a.Client.DataService(), result, uint32(a.req.DiskCount),
-		a.req.BkBizID, a.Audit)
+	status, deliverDetail, err = logics.CheckResultAndAssign(a.Cts.Kit, a.Client.DataService(), result,
+		uint32(a.req.DiskCount), a.req.BkBizID, a.Audit)
+	if err != nil {
+		return status, deliverDetail, err
+	}
+
+	// 设置申请的标签
+	if ids, ok := deliverDetail["disk_ids"].([]string); ok {
+		a.SetResourceTags(enumor.DiskCloudResType, ids, a.req.Tags)
+	}
+
+	return status, deliverDetail, nil
 }
 
 // toHcProtoCreateReq ...
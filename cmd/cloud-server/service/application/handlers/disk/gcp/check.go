@@ -19,7 +19,11 @@
 
 package gcp
 
-import logicsaccount "hcm/cmd/cloud-server/logics/account"
+import (
+	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	logicstagpolicy "hcm/cmd/cloud-server/logics/tag-policy"
+	"hcm/pkg/criteria/enumor"
+)
 
 // CheckReq ...
 func (a *ApplicationOfCreateGcpDisk) CheckReq() error {
@@ -31,5 +35,12 @@ func (a *ApplicationOfCreateGcpDisk) CheckReq() error {
 		return err
 	}
 
+	// 校验申请的标签是否满足账号和业务的标签策略
+	err := logicstagpolicy.CheckTags(a.Cts.Kit, a.Client.DataService(), a.req.AccountID, a.req.BkBizID,
+		enumor.DiskCloudResType, a.req.Tags)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -88,6 +88,9 @@ func (a *ApplicationOfCreateAwsVpc) Deliver() (enumor.ApplicationStatus, map[str
 		}
 	}
 
+	// 设置申请的标签
+	a.SetResourceTags(enumor.VpcCloudResType, []string{result.ID}, a.req.Tags)
+
 	return enumor.Completed, map[string]interface{}{"vpc_id": result.ID}, nil
 }
 
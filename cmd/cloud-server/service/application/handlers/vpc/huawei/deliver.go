@@ -89,6 +89,9 @@ func (a *ApplicationOfCreateHuaWeiVpc) Deliver() (enumor.ApplicationStatus, map[
 		}
 	}
 
+	// 设置申请的标签
+	a.SetResourceTags(enumor.VpcCloudResType, []string{result.ID}, a.req.Tags)
+
 	return enumor.Completed, map[string]interface{}{"vpc_id": result.ID}, nil
 }
 
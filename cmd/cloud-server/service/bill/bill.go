@@ -45,6 +45,8 @@ func InitBillService(c *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("ListBills", "POST", "/vendors/{vendor}/bills/list", svc.ListBills)
+	h.Add("ListCostAnomaly", "POST", "/bills/cost_anomalies/list", svc.ListCostAnomaly)
+	h.Add("UpdateCostAnomaly", "PATCH", "/bills/cost_anomalies/{id}", svc.UpdateCostAnomaly)
 
 	h.Load(c.WebService)
 }
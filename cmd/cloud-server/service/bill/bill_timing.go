@@ -104,9 +104,7 @@ func allAccountBillConfig(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.V
 		},
 	}
 
-	start := uint32(0)
 	for {
-		listReq.Page.Start = start
 		accounts, err := listAccountWithRetry(kt, cliSet.DataService(), listReq)
 		if err != nil {
 			logs.Errorf("%s account bill config get list account failed, err: %v, rid: %s", vendor, err, kt.Rid)
@@ -133,7 +131,7 @@ func allAccountBillConfig(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.V
 			break
 		}
 
-		start += uint32(core.DefaultMaxPageLimit)
+		listReq.Page.Cursor = core.NewIDPageCursor(accounts[len(accounts)-1].ID)
 	}
 }
 
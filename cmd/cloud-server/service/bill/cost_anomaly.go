@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	csbill "hcm/pkg/api/cloud-server/bill"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// ListCostAnomaly list cost anomalies.
+func (b *billSvc) ListCostAnomaly(cts *rest.Contexts) (interface{}, error) {
+	req := new(csbill.CostAnomalyListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	return b.client.DataService().Global.Bill.ListCostAnomaly(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// UpdateCostAnomaly update cost anomaly status or memo, such as acknowledge or resolve an anomaly.
+func (b *billSvc) UpdateCostAnomaly(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csbill.CostAnomalyUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := b.checkPermission(cts, meta.CostManage, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &dataproto.CostAnomalyBatchUpdateReq{
+		Anomalies: []dataproto.CostAnomalyUpdateReq{
+			{
+				ID:     id,
+				Status: req.Status,
+				Memo:   req.Memo,
+			},
+		},
+	}
+	if err := b.client.DataService().Global.Bill.BatchUpdateCostAnomaly(cts.Kit.Ctx, cts.Kit.Header(),
+		updateReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
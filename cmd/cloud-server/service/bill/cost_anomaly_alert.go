@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	dataproto "hcm/pkg/api/data-service/cloud/bill"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// costAnomalyNotifier notifies the new detected cost anomalies.
+type costAnomalyNotifier interface {
+	Notify(kt *kit.Kit, anomalies []dataproto.CostAnomalyCreateReq) error
+}
+
+// newCostAnomalyNotifier new cost anomaly notifier, the anomalies are only recorded if no webhook configured.
+func newCostAnomalyNotifier(webhooks []string) costAnomalyNotifier {
+	if len(webhooks) == 0 {
+		return new(logNotifier)
+	}
+
+	return &webhookNotifier{
+		webhooks: webhooks,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// logNotifier only prints the cost anomalies to log.
+type logNotifier struct{}
+
+// Notify cost anomalies.
+func (n *logNotifier) Notify(kt *kit.Kit, anomalies []dataproto.CostAnomalyCreateReq) error {
+	for _, one := range anomalies {
+		logs.Infof("found cost anomaly, account: %s, %s: %s, bill date: %s, actual: %f, expected: %f, rid: %s",
+			one.AccountID, one.Dimension, one.DimensionValue, one.BillDate, one.ActualCost, one.ExpectedCost, kt.Rid)
+	}
+
+	return nil
+}
+
+// webhookNotifier posts the cost anomalies to the webhooks as json.
+type webhookNotifier struct {
+	webhooks []string
+	client   *http.Client
+}
+
+// costAnomalyAlert is the body posted to the webhook.
+type costAnomalyAlert struct {
+	Anomalies []dataproto.CostAnomalyCreateReq `json:"anomalies"`
+}
+
+// Notify cost anomalies, every webhook is tried even if some of them failed.
+func (n *webhookNotifier) Notify(kt *kit.Kit, anomalies []dataproto.CostAnomalyCreateReq) error {
+	body, err := json.Marshal(costAnomalyAlert{Anomalies: anomalies})
+	if err != nil {
+		return err
+	}
+
+	var hitErr error
+	for _, webhook := range n.webhooks {
+		if err = n.post(kt, webhook, body); err != nil {
+			logs.Errorf("post cost anomaly alert to webhook failed, webhook: %s, err: %v, rid: %s", webhook, err,
+				kt.Rid)
+			hitErr = err
+		}
+	}
+
+	return hitErr
+}
+
+func (n *webhookNotifier) post(kt *kit.Kit, webhook string, body []byte) error {
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.RidKey, kt.Rid)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responds with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
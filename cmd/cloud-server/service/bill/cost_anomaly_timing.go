@@ -0,0 +1,329 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"time"
+
+	typesBill "hcm/pkg/adaptor/types/bill"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dataproto "hcm/pkg/api/data-service/cloud/bill"
+	hcbillservice "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/anomaly"
+)
+
+// CostAnomalyDetect 定时检测云账单的每日费用异常
+func CostAnomalyDetect(opt cc.CostAnomaly, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	intervalMin := time.Duration(opt.DetectIntervalMin) * time.Minute
+	logs.Infof("cost anomaly detect pipeline enable && start, detectIntervalMin: %v", intervalMin)
+
+	notifier := newCostAnomalyNotifier(opt.AlertWebhooks)
+	for {
+		time.Sleep(intervalMin)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := kit.New()
+		kt.User = constant.BillTimingUserKey
+		kt.AppCode = constant.BillTimingAppCodeKey
+
+		start := time.Now()
+		logs.Infof("cost anomaly detect pipeline start, time: %v, rid: %s", start, kt.Rid)
+
+		allAccountCostAnomalyDetect(kt, cliSet, opt, notifier)
+
+		logs.Infof("cost anomaly detect pipeline end, cost: %v, rid: %s", time.Since(start), kt.Rid)
+	}
+}
+
+// allAccountCostAnomalyDetect detect cost anomalies of all aws accounts, only the accounts with a ready bill
+// config can be detected, because the daily cost is queried from the cost and usage report.
+func allAccountCostAnomalyDetect(kt *kit.Kit, cliSet *client.ClientSet, opt cc.CostAnomaly,
+	notifier costAnomalyNotifier) {
+
+	listReq := &protocloud.AccountListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{
+					Field: "vendor",
+					Op:    filter.Equal.Factory(),
+					Value: enumor.Aws,
+				},
+				&filter.AtomRule{
+					Field: "type",
+					Op:    filter.Equal.Factory(),
+					Value: enumor.ResourceAccount,
+				},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: core.DefaultMaxPageLimit,
+		},
+	}
+
+	for {
+		accounts, err := listAccountWithRetry(kt, cliSet.DataService(), listReq)
+		if err != nil {
+			logs.Errorf("cost anomaly detect get list account failed, err: %v, rid: %s", err, kt.Rid)
+			break
+		}
+
+		for _, one := range accounts {
+			anomalies, err := detectAwsCostAnomaly(kt, cliSet, opt, one.ID)
+			if err != nil {
+				logs.Errorf("detect aws account cost anomaly failed, accountID: %s, err: %v, rid: %s", one.ID,
+					err, kt.Rid)
+				continue
+			}
+
+			if len(anomalies) == 0 {
+				continue
+			}
+
+			if err = notifier.Notify(kt, anomalies); err != nil {
+				logs.Errorf("notify cost anomaly failed, accountID: %s, err: %v, rid: %s", one.ID, err, kt.Rid)
+			}
+		}
+
+		if len(accounts) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		listReq.Page.Cursor = core.NewIDPageCursor(accounts[len(accounts)-1].ID)
+	}
+}
+
+// costSeriesKey is the key of one daily cost series of an account.
+type costSeriesKey struct {
+	dimension enumor.CostAnomalyDimension
+	value     string
+}
+
+// detectAwsCostAnomaly detect the cost anomalies of the last lookback days of one aws account, and save the
+// anomalies which are not detected before. returns the new created anomalies.
+func detectAwsCostAnomaly(kt *kit.Kit, cliSet *client.ClientSet, opt cc.CostAnomaly, accountID string) (
+	[]dataproto.CostAnomalyCreateReq, error) {
+
+	// the cost of today is incomplete, so the detection ends at yesterday.
+	now := time.Now()
+	end := now.AddDate(0, 0, -1)
+	since := now.AddDate(0, 0, -opt.LookbackDays)
+	begin := since.AddDate(0, 0, -opt.WindowDays)
+	sinceDate := since.Format(typesBill.BillDateLayout)
+
+	costReq := &hcbillservice.AwsDailyCostListReq{
+		AccountID: accountID,
+		BeginDate: begin.Format(typesBill.BillDateLayout),
+		EndDate:   end.Format(typesBill.BillDateLayout),
+	}
+	costs, err := cliSet.HCService().Aws.Bill.ListDailyCost(kt.Ctx, kt.Header(), costReq)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := ""
+	seriesMap := make(map[costSeriesKey][]anomaly.Point)
+	for _, one := range costs {
+		if len(currency) == 0 {
+			currency = one.Currency
+		}
+
+		point := anomaly.Point{Date: one.BillDate, Value: one.Cost}
+		keys := []costSeriesKey{
+			{dimension: enumor.AccountCostDimension},
+			{dimension: enumor.ServiceCostDimension, value: one.Service},
+			{dimension: enumor.RegionCostDimension, value: one.Region},
+		}
+		for _, key := range keys {
+			seriesMap[key] = append(seriesMap[key], point)
+		}
+	}
+
+	detectOpt := &anomaly.Option{
+		WindowDays:     opt.WindowDays,
+		MinHistoryDays: opt.MinHistoryDays,
+		SeasonDays:     anomaly.DefaultOption().SeasonDays,
+		RatioThreshold: opt.RatioThreshold,
+		ScoreThreshold: opt.ScoreThreshold,
+		MinDelta:       opt.MinCostDelta,
+	}
+
+	billDates := make([]string, 0, opt.LookbackDays)
+	for day := since; !day.After(end); day = day.AddDate(0, 0, 1) {
+		billDates = append(billDates, day.Format(typesBill.BillDateLayout))
+	}
+
+	existMap, err := listExistCostAnomaly(kt, cliSet, accountID, billDates)
+	if err != nil {
+		return nil, err
+	}
+
+	anomalies := make([]dataproto.CostAnomalyCreateReq, 0)
+	for key, points := range seriesMap {
+		results, err := anomaly.Detect(points, sinceDate, detectOpt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			if !result.IsAnomaly {
+				continue
+			}
+
+			if _, exist := existMap[costAnomalyUniqueKey(key.dimension, key.value, result.Date)]; exist {
+				continue
+			}
+
+			anomalies = append(anomalies, dataproto.CostAnomalyCreateReq{
+				Vendor:         enumor.Aws,
+				AccountID:      accountID,
+				Dimension:      key.dimension,
+				DimensionValue: key.value,
+				BillDate:       result.Date,
+				Currency:       currency,
+				ActualCost:     result.Actual,
+				ExpectedCost:   result.Expected,
+				Ratio:          result.Ratio,
+				Score:          result.Score,
+				TopItems:       listAwsCostAnomalyTopItems(kt, cliSet, opt, accountID, key, result.Date),
+			})
+		}
+	}
+
+	for start := 0; start < len(anomalies); start += constant.BatchOperationMaxLimit {
+		end := start + constant.BatchOperationMaxLimit
+		if end > len(anomalies) {
+			end = len(anomalies)
+		}
+
+		createReq := &dataproto.CostAnomalyBatchCreateReq{Anomalies: anomalies[start:end]}
+		if _, err = cliSet.DataService().Global.Bill.BatchCreateCostAnomaly(kt.Ctx, kt.Header(),
+			createReq); err != nil {
+			return nil, err
+		}
+	}
+
+	return anomalies, nil
+}
+
+// listAwsCostAnomalyTopItems list the line items which contribute most to the anomaly, the anomaly is still
+// saved without them if the query failed.
+func listAwsCostAnomalyTopItems(kt *kit.Kit, cliSet *client.ClientSet, opt cc.CostAnomaly, accountID string,
+	key costSeriesKey, billDate string) []cloud.CostAnomalyItem {
+
+	req := &hcbillservice.AwsTopCostItemListReq{
+		AccountID: accountID,
+		BillDate:  billDate,
+		Limit:     opt.TopItemLimit,
+	}
+
+	switch key.dimension {
+	case enumor.ServiceCostDimension:
+		req.Service = key.value
+	case enumor.RegionCostDimension:
+		req.Region = key.value
+	}
+
+	items, err := cliSet.HCService().Aws.Bill.ListTopCostItem(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list aws top cost item failed, req: %+v, err: %v, rid: %s", req, err, kt.Rid)
+		return nil
+	}
+
+	result := make([]cloud.CostAnomalyItem, 0, len(items))
+	for _, one := range items {
+		result = append(result, cloud.CostAnomalyItem{
+			Service:    one.Service,
+			Region:     one.Region,
+			ResourceID: one.ResourceID,
+			UsageType:  one.UsageType,
+			Operation:  one.Operation,
+			Cost:       one.Cost,
+		})
+	}
+
+	return result
+}
+
+// listExistCostAnomaly list the anomalies of the account detected on the bill dates, returns the unique key map.
+func listExistCostAnomaly(kt *kit.Kit, cliSet *client.ClientSet, accountID string, billDates []string) (
+	map[string]struct{}, error) {
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{
+					Field: "account_id",
+					Op:    filter.Equal.Factory(),
+					Value: accountID,
+				},
+				&filter.AtomRule{
+					Field: "bill_date",
+					Op:    filter.In.Factory(),
+					Value: billDates,
+				},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: core.DefaultMaxPageLimit,
+		},
+	}
+
+	existMap := make(map[string]struct{})
+	for {
+		result, err := cliSet.DataService().Global.Bill.ListCostAnomaly(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			existMap[costAnomalyUniqueKey(one.Dimension, one.DimensionValue, one.BillDate)] = struct{}{}
+		}
+
+		if len(result.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return existMap, nil
+}
+
+func costAnomalyUniqueKey(dimension enumor.CostAnomalyDimension, value, billDate string) string {
+	return fmt.Sprintf("%s/%s/%s", dimension, value, billDate)
+}
@@ -0,0 +1,345 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bizassignrule defines the biz assign rule api, the unassigned resources of the account are assigned to biz
+// by the rules after each account resource sync, and the rules can be previewed or applied manually.
+package bizassignrule
+
+import (
+	"hcm/cmd/cloud-server/service/capability"
+	csbizassignrule "hcm/pkg/api/cloud-server/biz-assign-rule"
+	"hcm/pkg/api/core"
+	corebizassignrule "hcm/pkg/api/core/biz-assign-rule"
+	protobizassignrule "hcm/pkg/api/data-service/biz-assign-rule"
+	"hcm/pkg/client"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitService initialize the biz assign rule service.
+func InitService(c *capability.Capability) {
+	svc := &bizAssignRuleSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateBizAssignRule", "POST", "/biz_assign_rules/create", svc.CreateBizAssignRule)
+	h.Add("UpdateBizAssignRule", "PATCH", "/biz_assign_rules/{id}", svc.UpdateBizAssignRule)
+	h.Add("ListBizAssignRule", "POST", "/biz_assign_rules/list", svc.ListBizAssignRule)
+	h.Add("DeleteBizAssignRule", "DELETE", "/biz_assign_rules/{id}", svc.DeleteBizAssignRule)
+	h.Add("PreviewBizAssignRule", "POST", "/biz_assign_rules/preview", svc.PreviewBizAssignRule)
+	h.Add("ApplyBizAssignRule", "POST", "/biz_assign_rules/apply", svc.ApplyBizAssignRule)
+
+	h.Load(c.WebService)
+}
+
+type bizAssignRuleSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// authorizeAssign check if user has the permission to assign the account's resources to the bizs.
+func (svc *bizAssignRuleSvc) authorizeAssign(kt *kit.Kit, accountID string, bizIDs ...int64) error {
+	authRes := make([]meta.ResourceAttribute, 0, len(bizIDs))
+	for _, bizID := range bizIDs {
+		authRes = append(authRes, meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CloudResource,
+			Action: meta.Assign, ResourceID: accountID}, BizID: bizID})
+	}
+
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes...)
+}
+
+// authorizeFind check if user has the permission to find the account's resources.
+func (svc *bizAssignRuleSvc) authorizeFind(kt *kit.Kit, accountID string) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CloudResource, Action: meta.Find,
+		ResourceID: accountID}}
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes)
+}
+
+// getBizAssignRule get biz assign rule by id.
+func (svc *bizAssignRuleSvc) getBizAssignRule(kt *kit.Kit, id string) (*corebizassignrule.BizAssignRule, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.DefaultBasePage,
+	}
+	result, err := svc.client.DataService().Global.BizAssignRule.ListBizAssignRule(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "biz assign rule %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
+
+// CreateBizAssignRule create biz assign rule.
+func (svc *bizAssignRuleSvc) CreateBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(csbizassignrule.BizAssignRuleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeAssign(cts.Kit, req.AccountID, req.BkBizID); err != nil {
+		return nil, err
+	}
+
+	createReq := &protobizassignrule.BizAssignRuleCreateReq{
+		Name:      req.Name,
+		AccountID: req.AccountID,
+		ResTypes:  req.ResTypes,
+		RuleType:  req.RuleType,
+		TagKey:    req.TagKey,
+		TagValue:  req.TagValue,
+		VpcID:     req.VpcID,
+		BkBizID:   req.BkBizID,
+		Priority:  req.Priority,
+		Enabled:   req.Enabled,
+		Memo:      req.Memo,
+	}
+	return svc.client.DataService().Global.BizAssignRule.CreateBizAssignRule(cts.Kit.Ctx, cts.Kit.Header(),
+		createReq)
+}
+
+// UpdateBizAssignRule update biz assign rule.
+func (svc *bizAssignRuleSvc) UpdateBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csbizassignrule.BizAssignRuleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rule, err := svc.getBizAssignRule(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	bizIDs := []int64{rule.BkBizID}
+	if req.BkBizID != 0 && req.BkBizID != rule.BkBizID {
+		bizIDs = append(bizIDs, req.BkBizID)
+	}
+	if err = svc.authorizeAssign(cts.Kit, rule.AccountID, bizIDs...); err != nil {
+		return nil, err
+	}
+
+	updateReq := &protobizassignrule.BizAssignRuleUpdateReq{
+		Name:     req.Name,
+		ResTypes: req.ResTypes,
+		TagValue: req.TagValue,
+		BkBizID:  req.BkBizID,
+		Priority: req.Priority,
+		Enabled:  req.Enabled,
+		Memo:     req.Memo,
+	}
+	if err = svc.client.DataService().Global.BizAssignRule.UpdateBizAssignRule(cts.Kit.Ctx, cts.Kit.Header(), id,
+		updateReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBizAssignRule list biz assign rule of the account.
+func (svc *bizAssignRuleSvc) ListBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(csbizassignrule.BizAssignRuleListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeFind(cts.Kit, req.AccountID); err != nil {
+		return nil, err
+	}
+
+	rules := []filter.RuleFactory{tools.EqualExpression("account_id", req.AccountID)}
+	if req.Filter != nil {
+		rules = append(rules, req.Filter)
+	}
+	expr, err := tools.And(rules...)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   req.Page,
+	}
+	result, err := svc.client.DataService().Global.BizAssignRule.ListBizAssignRule(cts.Kit.Ctx, cts.Kit.Header(),
+		listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csbizassignrule.BizAssignRuleListResult{Count: result.Count, Details: result.Details}, nil
+}
+
+// DeleteBizAssignRule delete biz assign rule, the assigned resources are not affected.
+func (svc *bizAssignRuleSvc) DeleteBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	rule, err := svc.getBizAssignRule(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.authorizeAssign(cts.Kit, rule.AccountID, rule.BkBizID); err != nil {
+		return nil, err
+	}
+
+	deleteReq := &protobizassignrule.BizAssignRuleDeleteReq{Filter: tools.EqualExpression("id", id)}
+	if err = svc.client.DataService().Global.BizAssignRule.DeleteBizAssignRule(cts.Kit.Ctx, cts.Kit.Header(),
+		deleteReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// PreviewBizAssignRule returns the unassigned resources that match the rules without assigning them.
+func (svc *bizAssignRuleSvc) PreviewBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(csbizassignrule.BizAssignRuleApplyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeFind(cts.Kit, req.AccountID); err != nil {
+		return nil, err
+	}
+
+	applyReq := &protobizassignrule.BizAssignRuleApplyReq{
+		AccountID: req.AccountID,
+		RuleIDs:   req.RuleIDs,
+		DryRun:    true,
+	}
+	return svc.client.DataService().Global.BizAssignRule.ApplyBizAssignRule(cts.Kit.Ctx, cts.Kit.Header(),
+		applyReq)
+}
+
+// ApplyBizAssignRule assign the unassigned resources that match the rules to biz immediately.
+func (svc *bizAssignRuleSvc) ApplyBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(csbizassignrule.BizAssignRuleApplyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// authorize the assignment to the bizs of all the rules to apply.
+	expr := tools.EqualWithOpExpression(filter.And, map[string]interface{}{"account_id": req.AccountID,
+		"enabled": true})
+	if len(req.RuleIDs) != 0 {
+		expr = &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: req.AccountID},
+				filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: req.RuleIDs},
+			},
+		}
+	}
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+		Fields: []string{"bk_biz_id"},
+	}
+	rules, err := svc.client.DataService().Global.BizAssignRule.ListBizAssignRule(cts.Kit.Ctx, cts.Kit.Header(),
+		listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules.Details) == 0 {
+		return &corebizassignrule.ApplyResult{Details: make([]corebizassignrule.ApplyDetail, 0)}, nil
+	}
+
+	bizIDs := make([]int64, 0)
+	bizIDMap := make(map[int64]struct{})
+	for _, rule := range rules.Details {
+		if _, exists := bizIDMap[rule.BkBizID]; exists {
+			continue
+		}
+		bizIDMap[rule.BkBizID] = struct{}{}
+		bizIDs = append(bizIDs, rule.BkBizID)
+	}
+
+	if err = svc.authorizeAssign(cts.Kit, req.AccountID, bizIDs...); err != nil {
+		return nil, err
+	}
+
+	applyReq := &protobizassignrule.BizAssignRuleApplyReq{
+		AccountID: req.AccountID,
+		RuleIDs:   req.RuleIDs,
+	}
+	return svc.client.DataService().Global.BizAssignRule.ApplyBizAssignRule(cts.Kit.Ctx, cts.Kit.Header(),
+		applyReq)
+}
+
+// ApplyAccountRules apply all the enabled biz assign rules of the account, it's called after the account's resources
+// are synced, so that the new synced resources are assigned to biz by the rules.
+func ApplyAccountRules(kt *kit.Kit, dataCli *dataservice.Client, accountID string) error {
+	// 资源刚同步入库，规则匹配和之后的标签策略评估需读主库，避免从库延迟导致新同步的资源未被处理
+	kt.ForceReadPrimary()
+
+	applyReq := &protobizassignrule.BizAssignRuleApplyReq{AccountID: accountID}
+	result, err := dataCli.Global.BizAssignRule.ApplyBizAssignRule(kt.Ctx, kt.Header(), applyReq)
+	if err != nil {
+		logs.Errorf("apply biz assign rule failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+		return err
+	}
+
+	for _, detail := range result.Details {
+		logs.Infof("biz assign rule %s assigned %d %s to biz %d, account: %s, rid: %s", detail.RuleID,
+			len(detail.ResIDs), detail.ResType, detail.BkBizID, accountID, kt.Rid)
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package changefeed defines the resource change feed api, integrations can follow the insert/update/delete changes
+// of resources from a cursor instead of polling the list api.
+package changefeed
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/service/capability"
+	cschangefeed "hcm/pkg/api/cloud-server/change-feed"
+	"hcm/pkg/api/core"
+	corechangefeed "hcm/pkg/api/core/change-feed"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/table"
+	tablechangefeed "hcm/pkg/dal/table/change-feed"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// pollInterval is the interval to query the new changes when the watch request is waiting.
+const pollInterval = time.Second
+
+// InitService initialize the resource change feed service.
+func InitService(c *capability.Capability, opt cc.ChangeFeed) {
+	svc := &changeFeedSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		maxWait:    time.Duration(opt.MaxWaitSec) * time.Second,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("WatchChangeFeed", "POST", "/change_feeds/{res_type}/watch", svc.Watch)
+
+	h.Load(c.WebService)
+}
+
+type changeFeedSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	maxWait    time.Duration
+}
+
+// Watch returns the changes of the resource type after the cursor in order, if there is no change, it waits for
+// the new changes until wait_sec is reached.
+func (svc *changeFeedSvc) Watch(cts *rest.Contexts) (interface{}, error) {
+	resType := table.Name(cts.PathParameter("res_type").String())
+	if err := tablechangefeed.ValidateResType(resType); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(cschangefeed.WatchReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRule, noPerm, err := svc.listAuthRule(cts.Kit, resType)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Cursor == nil {
+		cursor, err := svc.latestCursor(cts.Kit, resType)
+		if err != nil {
+			return nil, err
+		}
+		return &cschangefeed.WatchResult{Cursor: cursor, Details: make([]corechangefeed.ChangeFeed, 0)}, nil
+	}
+
+	if err = svc.validateCursor(cts.Kit, resType, *req.Cursor); err != nil {
+		return nil, err
+	}
+
+	result := &cschangefeed.WatchResult{Cursor: *req.Cursor, Details: make([]corechangefeed.ChangeFeed, 0)}
+	if noPerm {
+		return result, nil
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = cschangefeed.DefaultWatchLimit
+	}
+
+	wait := time.Duration(req.WaitSec) * time.Second
+	if wait > svc.maxWait {
+		wait = svc.maxWait
+	}
+	deadline := time.Now().Add(wait)
+
+	for {
+		rules := []filter.RuleFactory{
+			filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: resType},
+			filter.AtomRule{Field: "seq", Op: filter.GreaterThan.Factory(), Value: *req.Cursor},
+		}
+		if authRule != nil {
+			rules = append(rules, authRule)
+		}
+
+		listReq := &core.ListReq{
+			Filter: &filter.Expression{Op: filter.And, Rules: rules},
+			Page:   &core.BasePage{Start: 0, Limit: limit, Sort: "seq", Order: core.Ascending},
+		}
+		changes, err := svc.client.DataService().Global.ChangeFeed.ListChangeFeed(cts.Kit.Ctx, cts.Kit.Header(),
+			listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(changes.Details) != 0 {
+			result.Cursor = changes.Details[len(changes.Details)-1].Seq
+			result.Details = changes.Details
+			return result, nil
+		}
+
+		if !time.Now().Add(pollInterval).Before(deadline) {
+			return result, nil
+		}
+
+		select {
+		case <-cts.Kit.Ctx.Done():
+			return result, nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// listAuthRule returns the account filter rule of the resources that user has find permission, returns true if
+// user has no permission of any resource.
+func (svc *changeFeedSvc) listAuthRule(kt *kit.Kit, resType table.Name) (filter.RuleFactory, bool, error) {
+	authInst, err := svc.authorizer.ListAuthorizedInstances(kt, &meta.ListAuthResInput{
+		Type: meta.ResourceType(resType), Action: meta.Find})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// change feed only records the account of the resource, so attribute based permission can not be applied.
+	if len(authInst.Filter) != 0 {
+		return nil, false, errf.Newf(errf.PermissionDenied, "change feed requires %s find permission of accounts, "+
+			"attribute based permission is not supported", resType)
+	}
+
+	if authInst.IsAny {
+		return nil, false, nil
+	}
+
+	if len(authInst.IDs) == 0 {
+		return nil, true, nil
+	}
+
+	return filter.AtomRule{Field: "account_id", Op: filter.In.Factory(), Value: authInst.IDs}, false, nil
+}
+
+// latestCursor returns the seq of the latest sequenced change of the resource type, which is used to watch the
+// changes from now on.
+func (svc *changeFeedSvc) latestCursor(kt *kit.Kit, resType table.Name) (uint64, error) {
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: resType},
+				filter.AtomRule{Field: "seq", Op: filter.GreaterThan.Factory(), Value: 0},
+			},
+		},
+		Page:   &core.BasePage{Start: 0, Limit: 1, Sort: "seq", Order: core.Descending},
+		Fields: []string{"id", "seq"},
+	}
+	changes, err := svc.client.DataService().Global.ChangeFeed.ListChangeFeed(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(changes.Details) == 0 {
+		return 0, nil
+	}
+
+	return changes.Details[0].Seq, nil
+}
+
+// validateCursor validate if the change of the cursor still exists, the changes after the cursor may have been
+// cleaned if it does not exist.
+func (svc *changeFeedSvc) validateCursor(kt *kit.Kit, resType table.Name, cursor uint64) error {
+	if cursor == 0 {
+		return nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "seq", Op: filter.Equal.Factory(), Value: cursor},
+				filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: resType},
+			},
+		},
+		Page: &core.BasePage{Count: true},
+	}
+	result, err := svc.client.DataService().Global.ChangeFeed.ListChangeFeed(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return err
+	}
+
+	if result.Count == 0 {
+		logs.Infof("%s change feed cursor %d is expired, rid: %s", resType, cursor, kt.Rid)
+		return errf.Newf(errf.ChangeFeedCursorExpired, "cursor %d is expired or invalid, please do a full resync "+
+			"and watch from the latest cursor", cursor)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	csevent "hcm/pkg/api/cloud-server/event"
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/event"
+	protoevent "hcm/pkg/api/data-service/event"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// ListDeadLetter list the dead letters of event webhook subscription.
+func (svc *eventSvc) ListDeadLetter(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csevent.DeadLetterListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	listFilter, err := tools.And(tools.EqualExpression("subscription_id", id), req.Filter)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: listFilter,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.Event.ListDeadLetter(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// RedeliverDeadLetter deliver the events of the dead letters to the subscription once again, the dead letters
+// which are delivered successfully are removed, others are kept with the attempts accumulated.
+func (svc *eventSvc) RedeliverDeadLetter(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csevent.DeadLetterRedeliverReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	sub, err := getSubscription(cts.Kit, svc.client, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := svc.cipher.DecryptFromBase64(sub.Secret)
+	if err != nil {
+		logs.Errorf("decrypt event subscription %s secret failed, err: %v, rid: %s", id, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// list dead letters of the subscription and the events of them.
+	letterFilter, err := tools.And(tools.EqualExpression("subscription_id", id),
+		tools.ContainersExpression("id", req.IDs))
+	if err != nil {
+		return nil, err
+	}
+	letterReq := &core.ListReq{Filter: letterFilter, Page: core.DefaultBasePage}
+	letters, err := svc.client.DataService().Global.Event.ListDeadLetter(cts.Kit.Ctx, cts.Kit.Header(), letterReq)
+	if err != nil {
+		return nil, err
+	}
+
+	eventIDs := make([]uint64, 0, len(letters.Details))
+	for _, letter := range letters.Details {
+		eventIDs = append(eventIDs, letter.EventID)
+	}
+
+	eventMap := make(map[uint64]*coreevent.Event, len(eventIDs))
+	if len(eventIDs) != 0 {
+		eventReq := &core.ListReq{Filter: tools.ContainersExpression("id", eventIDs), Page: core.DefaultBasePage}
+		events, err := svc.client.DataService().Global.Event.ListEvent(cts.Kit.Ctx, cts.Kit.Header(), eventReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range events.Details {
+			eventMap[events.Details[i].ID] = &events.Details[i]
+		}
+	}
+
+	result := &csevent.DeadLetterRedeliverResult{
+		Succeeded: make([]string, 0),
+		Failed:    make([]csevent.DeadLetterRedeliverFail, 0),
+	}
+
+	found := make(map[string]struct{}, len(letters.Details))
+	for _, letter := range letters.Details {
+		found[letter.ID] = struct{}{}
+
+		e, exists := eventMap[letter.EventID]
+		if !exists {
+			result.Failed = append(result.Failed, csevent.DeadLetterRedeliverFail{ID: letter.ID,
+				Error: "event has been expired"})
+			continue
+		}
+
+		if err = svc.deliverer.deliver(cts.Kit, sub.Endpoint, secret, e); err != nil {
+			result.Failed = append(result.Failed, csevent.DeadLetterRedeliverFail{ID: letter.ID, Error: err.Error()})
+
+			createReq := &protoevent.DeadLetterCreateReq{
+				SubscriptionID: id,
+				EventID:        e.ID,
+				EventType:      e.Type,
+				Attempts:       1,
+				LastError:      err.Error(),
+			}
+			if err = svc.client.DataService().Global.Event.CreateDeadLetter(cts.Kit.Ctx, cts.Kit.Header(),
+				createReq); err != nil {
+				logs.Errorf("update event dead letter %s failed, err: %v, rid: %s", letter.ID, err, cts.Kit.Rid)
+			}
+			continue
+		}
+
+		result.Succeeded = append(result.Succeeded, letter.ID)
+	}
+
+	for _, letterID := range req.IDs {
+		if _, exists := found[letterID]; !exists {
+			result.Failed = append(result.Failed, csevent.DeadLetterRedeliverFail{ID: letterID,
+				Error: "dead letter not found"})
+		}
+	}
+
+	if len(result.Succeeded) != 0 {
+		deleteReq := &protoevent.DeadLetterDeleteReq{Filter: tools.ContainersExpression("id", result.Succeeded)}
+		if err = svc.client.DataService().Global.Event.DeleteDeadLetter(cts.Kit.Ctx, cts.Kit.Header(),
+			deleteReq); err != nil {
+			logs.Errorf("delete redelivered event dead letters failed, err: %v, ids: %v, rid: %s", err,
+				result.Succeeded, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,115 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	coreevent "hcm/pkg/api/core/event"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+const (
+	// maxRetryBackoff is the max backoff duration between the delivery attempts of an event.
+	maxRetryBackoff = 30 * time.Second
+	// maxErrorBodyLen is the max length of the response body of the failed delivery kept in the error.
+	maxErrorBodyLen = 512
+)
+
+// deliverer delivers the events to the webhook endpoints of the subscriptions with signature.
+type deliverer struct {
+	httpCli     *http.Client
+	maxAttempts uint
+}
+
+func newDeliverer(opt cc.EventBus) *deliverer {
+	return &deliverer{
+		httpCli:     &http.Client{Timeout: time.Duration(opt.TimeoutSec) * time.Second},
+		maxAttempts: opt.MaxAttempts,
+	}
+}
+
+// deliver delivers the event to the endpoint once, the event is delivered successfully only if the endpoint
+// responds with 2xx status code.
+func (d *deliverer) deliver(kt *kit.Kit, endpoint, secret string, e *coreevent.Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal event failed, err: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.RidKey, kt.Rid)
+	req.Header.Set(constant.EventIDKey, strconv.FormatUint(e.ID, 10))
+	req.Header.Set(constant.EventTypeKey, e.Type)
+	req.Header.Set(constant.EventTimestampKey, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(constant.EventSignatureKey, coreevent.Sign(secret, timestamp, body))
+
+	resp, err := d.httpCli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLen))
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("endpoint responds with status code %d, body: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+// deliverWithRetry delivers the event to the endpoint, and retries with exponential backoff if it's failed,
+// returns the attempts and the last error if it's still failed after max attempts.
+func (d *deliverer) deliverWithRetry(kt *kit.Kit, endpoint, secret string, e *coreevent.Event) (uint, error) {
+	backoff := time.Second
+	var err error
+	for attempt := uint(1); ; attempt++ {
+		if err = d.deliver(kt, endpoint, secret, e); err == nil {
+			return attempt, nil
+		}
+
+		if attempt >= d.maxAttempts {
+			return attempt, err
+		}
+
+		logs.Warnf("deliver event %d to %s failed, retry after %s, attempt: %d, err: %v, rid: %s", e.ID, endpoint,
+			backoff, attempt, err, kt.Rid)
+
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
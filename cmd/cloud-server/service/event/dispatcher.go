@@ -0,0 +1,232 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/event"
+	protoevent "hcm/pkg/api/data-service/event"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+)
+
+// cleanInterval is the interval to clean the expired events.
+const cleanInterval = time.Hour
+
+// Dispatch 定时按订阅的游标拉取资源生命周期事件，投递到订阅的webhook地址，重试多次后仍失败的事件进入死信队列
+func Dispatch(opt cc.EventBus, sd serviced.ServiceDiscover, cliSet *client.ClientSet, cipher cryptography.Crypto) {
+	interval := time.Duration(opt.IntervalSec) * time.Second
+	logs.Infof("event dispatcher enable && start, interval: %v", interval)
+
+	d := &dispatcher{
+		client:    cliSet,
+		cipher:    cipher,
+		deliverer: newDeliverer(opt),
+		state:     sd,
+		batchSize: opt.BatchSize,
+	}
+
+	var lastCleanTime time.Time
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := newDispatcherKit()
+		d.dispatchAll(kt)
+
+		if time.Since(lastCleanTime) >= cleanInterval {
+			cleanExpiredEvent(kt, cliSet, opt.RetentionDay)
+			lastCleanTime = time.Now()
+		}
+	}
+}
+
+type dispatcher struct {
+	client    *client.ClientSet
+	cipher    cryptography.Crypto
+	deliverer *deliverer
+	state     serviced.State
+	batchSize uint
+	// running is the ids of the subscriptions that are being dispatched, each subscription is dispatched by only one
+	// goroutine at a time to keep the order of its events.
+	running sync.Map
+}
+
+func newDispatcherKit() *kit.Kit {
+	kt := kit.New()
+	kt.User = constant.EventDispatcherUserKey
+	kt.AppCode = constant.EventDispatcherAppCodeKey
+	return kt
+}
+
+// dispatchAll dispatch the events to all enabled subscriptions, each subscription is dispatched on its own schedule
+// without waiting for the others, so that a slow or dead endpoint only holds up its own subscription. subscriptions
+// whose last dispatching is not finished are skipped in this round.
+func (d *dispatcher) dispatchAll(kt *kit.Kit) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("enabled", true),
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+
+	for {
+		result, err := d.client.DataService().Global.Event.ListSubscription(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("event dispatcher list subscription failed, err: %v, rid: %s", err, kt.Rid)
+			return
+		}
+
+		for i := range result.Details {
+			sub := &result.Details[i]
+			if _, running := d.running.LoadOrStore(sub.ID, struct{}{}); running {
+				continue
+			}
+
+			go func() {
+				defer d.running.Delete(sub.ID)
+				d.dispatch(newDispatcherKit(), sub)
+			}()
+		}
+
+		if len(result.Details) < int(core.DefaultMaxPageLimit) {
+			return
+		}
+
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+}
+
+// dispatch deliver a batch of events after the cursor of the subscription, and then move the cursor forward. the
+// cursor is the commit sequence of the events, which never skips the events committed out of the order of ids.
+func (d *dispatcher) dispatch(kt *kit.Kit, sub *protoevent.SubscriptionDetail) {
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "seq", Op: filter.GreaterThan.Factory(), Value: sub.LastEventID},
+			},
+		},
+		Page: &core.BasePage{Start: 0, Limit: d.batchSize, Sort: "seq", Order: core.Ascending},
+	}
+	events, err := d.client.DataService().Global.Event.ListEvent(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list events of subscription %s failed, err: %v, rid: %s", sub.ID, err, kt.Rid)
+		return
+	}
+
+	if len(events.Details) == 0 {
+		return
+	}
+
+	secret, err := d.cipher.DecryptFromBase64(sub.Secret)
+	if err != nil {
+		logs.Errorf("decrypt event subscription %s secret failed, err: %v, rid: %s", sub.ID, err, kt.Rid)
+		return
+	}
+
+	cursor := sub.LastEventID
+	for i := range events.Details {
+		// stop delivering if the master is changed, the new master continues from the cursor.
+		if !d.state.IsMaster() {
+			break
+		}
+
+		e := &events.Details[i]
+		if sub.Filter.Match(e) {
+			d.deliver(kt, sub, secret, e)
+		}
+
+		cursor = e.Seq
+	}
+
+	if cursor == sub.LastEventID {
+		return
+	}
+
+	updateReq := &protoevent.SubscriptionUpdateReq{LastEventID: &cursor, PrevLastEventID: &sub.LastEventID}
+	err = d.client.DataService().Global.Event.UpdateSubscription(kt.Ctx, kt.Header(), sub.ID, updateReq)
+	if err != nil {
+		if errf.Error(err).Code == errf.RecordNotFound {
+			logs.Infof("subscription %s is replayed or deleted during dispatching, skip moving cursor to %d, "+
+				"rid: %s", sub.ID, cursor, kt.Rid)
+			return
+		}
+
+		logs.Errorf("move subscription %s cursor to %d failed, err: %v, rid: %s", sub.ID, cursor, err, kt.Rid)
+		return
+	}
+}
+
+// deliver deliver the event to the subscription with retry, and put it into the dead letter queue if it's failed.
+func (d *dispatcher) deliver(kt *kit.Kit, sub *protoevent.SubscriptionDetail, secret string, e *coreevent.Event) {
+	attempts, err := d.deliverer.deliverWithRetry(kt, sub.Endpoint, secret, e)
+	if err == nil {
+		return
+	}
+
+	logs.Errorf("deliver event %d to subscription %s failed, attempts: %d, err: %v, rid: %s", e.ID, sub.ID,
+		attempts, err, kt.Rid)
+
+	createReq := &protoevent.DeadLetterCreateReq{
+		SubscriptionID: sub.ID,
+		EventID:        e.ID,
+		EventType:      e.Type,
+		Attempts:       attempts,
+		LastError:      err.Error(),
+	}
+	if err = d.client.DataService().Global.Event.CreateDeadLetter(kt.Ctx, kt.Header(), createReq); err != nil {
+		logs.Errorf("create dead letter of event %d for subscription %s failed, err: %v, rid: %s", e.ID, sub.ID,
+			err, kt.Rid)
+	}
+}
+
+// cleanExpiredEvent delete the events which are created before the retention days.
+func cleanExpiredEvent(kt *kit.Kit, cliSet *client.ClientSet, retentionDay uint) {
+	expiredTime := time.Now().Add(-time.Duration(retentionDay) * 24 * time.Hour).Format(constant.TimeStdFormat)
+	deleteReq := &protoevent.EventDeleteReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "created_at", Op: filter.LessThan.Factory(), Value: expiredTime},
+			},
+		},
+	}
+
+	result, err := cliSet.DataService().Global.Event.DeleteEvent(kt.Ctx, kt.Header(), deleteReq)
+	if err != nil {
+		logs.Errorf("clean expired events failed, err: %v, rid: %s", err, kt.Rid)
+		return
+	}
+
+	logs.Infof("clean %d expired events created before %s, rid: %s", result.Deleted, expiredTime, kt.Rid)
+}
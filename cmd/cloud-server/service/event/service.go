@@ -0,0 +1,94 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event defines the resource lifecycle event and its webhook subscription management api, and delivers the
+// events to the subscriptions.
+package event
+
+import (
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
+	protoevent "hcm/pkg/api/data-service/event"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the resource lifecycle event and its webhook subscription management service.
+func InitService(c *capability.Capability, opt cc.EventBus) {
+	svc := &eventSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		cipher:     c.Cipher,
+		deliverer:  newDeliverer(opt),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListEvent", "POST", "/events/list", svc.ListEvent)
+
+	h.Add("CreateEventSubscription", "POST", "/event_subscriptions/create", svc.CreateSubscription)
+	h.Add("UpdateEventSubscription", "PATCH", "/event_subscriptions/{id}", svc.UpdateSubscription)
+	h.Add("ListEventSubscription", "POST", "/event_subscriptions/list", svc.ListSubscription)
+	h.Add("DeleteEventSubscription", "DELETE", "/event_subscriptions/{id}", svc.DeleteSubscription)
+	h.Add("ReplayEventSubscription", "POST", "/event_subscriptions/{id}/replay", svc.ReplaySubscription)
+
+	h.Add("ListEventDeadLetter", "POST", "/event_subscriptions/{id}/dead_letters/list", svc.ListDeadLetter)
+	h.Add("RedeliverEventDeadLetter", "POST", "/event_subscriptions/{id}/dead_letters/redeliver",
+		svc.RedeliverDeadLetter)
+
+	h.Load(c.WebService)
+}
+
+type eventSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	cipher     cryptography.Crypto
+	deliverer  *deliverer
+}
+
+// authorize check if user has event subscription manage permission.
+func (svc *eventSvc) authorize(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.EventSubscription, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
+
+// getSubscription get event subscription with its encrypted secret by id.
+func getSubscription(kt *kit.Kit, cliSet *client.ClientSet, id string) (*protoevent.SubscriptionDetail, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.DefaultBasePage,
+	}
+	result, err := cliSet.DataService().Global.Event.ListSubscription(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "event subscription %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
@@ -0,0 +1,205 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	csevent "hcm/pkg/api/cloud-server/event"
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/event"
+	protoevent "hcm/pkg/api/data-service/event"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// CreateSubscription create event webhook subscription, the secret is generated if it's not set, and it is only
+// returned once in the create result.
+func (svc *eventSvc) CreateSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(csevent.SubscriptionCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if len(secret) == 0 {
+		var err error
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	createReq := &protoevent.SubscriptionCreateReq{
+		Name:        req.Name,
+		Endpoint:    req.Endpoint,
+		Secret:      svc.cipher.EncryptToBase64(secret),
+		Filter:      req.Filter,
+		Enabled:     req.Enabled,
+		LastEventID: req.LastEventID,
+		Memo:        req.Memo,
+	}
+	result, err := svc.client.DataService().Global.Event.CreateSubscription(cts.Kit.Ctx, cts.Kit.Header(), createReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csevent.SubscriptionCreateResult{ID: result.ID, Secret: secret}, nil
+}
+
+// generateSecret generate the signature secret of event subscription.
+func generateSecret() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// UpdateSubscription update event webhook subscription.
+func (svc *eventSvc) UpdateSubscription(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csevent.SubscriptionUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &protoevent.SubscriptionUpdateReq{
+		Endpoint: req.Endpoint,
+		Filter:   req.Filter,
+		Enabled:  req.Enabled,
+		Memo:     req.Memo,
+	}
+	if len(req.Secret) != 0 {
+		updateReq.Secret = svc.cipher.EncryptToBase64(req.Secret)
+	}
+
+	if err := svc.client.DataService().Global.Event.UpdateSubscription(cts.Kit.Ctx, cts.Kit.Header(), id,
+		updateReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListSubscription list event webhook subscription without secret.
+func (svc *eventSvc) ListSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(csevent.SubscriptionListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.client.DataService().Global.Event.ListSubscription(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]coreevent.Subscription, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, one.Subscription)
+	}
+
+	return &csevent.SubscriptionListResult{Count: result.Count, Details: details}, nil
+}
+
+// DeleteSubscription delete event webhook subscription and its dead letters.
+func (svc *eventSvc) DeleteSubscription(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.authorize(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	deleteReq := &protoevent.SubscriptionDeleteReq{Filter: tools.EqualExpression("id", id)}
+	if err := svc.client.DataService().Global.Event.DeleteSubscription(cts.Kit.Ctx, cts.Kit.Header(),
+		deleteReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ReplaySubscription reset the cursor of event webhook subscription, the matched events after the cursor are
+// delivered again by the dispatcher.
+func (svc *eventSvc) ReplaySubscription(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csevent.SubscriptionReplayReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &protoevent.SubscriptionUpdateReq{LastEventID: req.LastEventID}
+	if err := svc.client.DataService().Global.Event.UpdateSubscription(cts.Kit.Ctx, cts.Kit.Header(), id,
+		updateReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
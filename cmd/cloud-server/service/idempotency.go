@@ -0,0 +1,124 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"time"
+
+	protoidem "hcm/pkg/api/data-service/idempotency"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/serviced"
+)
+
+// idempotencyCleanInterval is the interval to delete the expired idempotency records.
+const idempotencyCleanInterval = time.Hour
+
+// idempotencyStore persists the idempotency keys of cloud server's mutating requests by data service.
+type idempotencyStore struct {
+	client *client.ClientSet
+	opt    cc.Idempotency
+}
+
+var _ rest.IdempotencyStore = new(idempotencyStore)
+
+func newIdempotencyStore(cliSet *client.ClientSet, opt cc.Idempotency) *idempotencyStore {
+	return &idempotencyStore{client: cliSet, opt: opt}
+}
+
+// Acquire saves the record as processing if the key is not used, otherwise returns the existing record of the key.
+func (s *idempotencyStore) Acquire(kt *kit.Kit, record *rest.IdempotencyRecord) (*rest.IdempotencyRecord, error) {
+	req := &protoidem.RecordAcquireReq{
+		RecordKey:  recordKey(record),
+		TimeoutSec: s.opt.ProcessingTimeoutSec,
+	}
+	result, err := s.client.DataService().Global.Idempotency.AcquireRecord(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Acquired {
+		return nil, nil
+	}
+
+	existing := &rest.IdempotencyRecord{
+		Owner:       record.Owner,
+		Key:         record.Key,
+		RequestHash: result.Record.RequestHash,
+		Completed:   result.Record.State == enumor.IdempotencyCompleted,
+	}
+	if existing.Completed {
+		existing.Response = []byte(result.Record.Response)
+	}
+
+	return existing, nil
+}
+
+// Complete saves the response of the succeeded request for replay.
+func (s *idempotencyStore) Complete(kt *kit.Kit, record *rest.IdempotencyRecord) error {
+	req := &protoidem.RecordCompleteReq{
+		RecordKey:    recordKey(record),
+		Response:     string(record.Response),
+		RetentionSec: s.opt.RetentionHour * 3600,
+	}
+	return s.client.DataService().Global.Idempotency.CompleteRecord(kt.Ctx, kt.Header(), req)
+}
+
+// Release removes the processing record of the failed request.
+func (s *idempotencyStore) Release(kt *kit.Kit, record *rest.IdempotencyRecord) error {
+	req := &protoidem.RecordReleaseReq{RecordKey: recordKey(record)}
+	return s.client.DataService().Global.Idempotency.ReleaseRecord(kt.Ctx, kt.Header(), req)
+}
+
+func recordKey(record *rest.IdempotencyRecord) protoidem.RecordKey {
+	return protoidem.RecordKey{
+		Owner:          record.Owner,
+		IdempotencyKey: record.Key,
+		RequestHash:    record.RequestHash,
+	}
+}
+
+// cleanExpiredIdempotencyRecord delete the expired idempotency records periodically.
+func cleanExpiredIdempotencyRecord(sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	for {
+		time.Sleep(idempotencyCleanInterval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := kit.New()
+		kt.User = constant.IdempotencyCleanerUserKey
+		kt.AppCode = constant.IdempotencyCleanerAppCodeKey
+
+		result, err := cliSet.DataService().Global.Idempotency.DeleteExpiredRecord(kt.Ctx, kt.Header())
+		if err != nil {
+			logs.Errorf("delete expired idempotency record failed, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+
+		logs.V(3).Infof("delete %d expired idempotency records, rid: %s", result.Count, kt.Rid)
+	}
+}
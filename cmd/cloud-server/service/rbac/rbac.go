@@ -0,0 +1,314 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac defines the built-in rbac role management api.
+package rbac
+
+import (
+	"hcm/cmd/cloud-server/service/capability"
+	csrbac "hcm/pkg/api/cloud-server/rbac"
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	protorbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/iam/rbac"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the rbac role management service.
+func InitService(c *capability.Capability) {
+	svc := &rbacSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListRbacAction", "POST", "/rbac/actions/list", svc.ListAction)
+
+	h.Add("CreateRbacRole", "POST", "/rbac/roles/create", svc.CreateRole)
+	h.Add("UpdateRbacRole", "PATCH", "/rbac/roles/{id}", svc.UpdateRole)
+	h.Add("ListRbacRole", "POST", "/rbac/roles/list", svc.ListRole)
+	h.Add("DeleteRbacRole", "DELETE", "/rbac/roles/{id}", svc.DeleteRole)
+
+	h.Add("CreateRbacRoleBinding", "POST", "/rbac/role_bindings/create", svc.CreateRoleBinding)
+	h.Add("ListRbacRoleBinding", "POST", "/rbac/role_bindings/list", svc.ListRoleBinding)
+	h.Add("DeleteRbacRoleBinding", "DELETE", "/rbac/role_bindings/{id}", svc.DeleteRoleBinding)
+
+	h.Load(c.WebService)
+}
+
+type rbacSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// ListAction list the actions which can be granted by roles.
+func (svc *rbacSvc) ListAction(cts *rest.Contexts) (interface{}, error) {
+	if err := svc.checkPermission(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return rbac.ListActions(), nil
+}
+
+// CreateRole create rbac role.
+func (svc *rbacSvc) CreateRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(csrbac.RoleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	if rbac.IsBuiltinRole(req.Name) {
+		return nil, errf.Newf(errf.InvalidParameter, "role name %s is reserved by builtin role", req.Name)
+	}
+
+	if err := validateActions(req.Actions); err != nil {
+		return nil, err
+	}
+
+	createReq := &protorbac.RoleCreateReq{
+		Name:    req.Name,
+		Actions: req.Actions,
+		Memo:    req.Memo,
+	}
+	return svc.client.DataService().Global.Rbac.CreateRole(cts.Kit.Ctx, cts.Kit.Header(), createReq)
+}
+
+// UpdateRole update rbac role, builtin role can not be updated.
+func (svc *rbacSvc) UpdateRole(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csrbac.RoleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	if err := svc.validateNotBuiltin(cts, id); err != nil {
+		return nil, err
+	}
+
+	if len(req.Name) != 0 && rbac.IsBuiltinRole(req.Name) {
+		return nil, errf.Newf(errf.InvalidParameter, "role name %s is reserved by builtin role", req.Name)
+	}
+
+	if err := validateActions(req.Actions); err != nil {
+		return nil, err
+	}
+
+	updateReq := &protorbac.RoleUpdateReq{
+		Name:    req.Name,
+		Actions: req.Actions,
+		Memo:    req.Memo,
+	}
+	if err := svc.client.DataService().Global.Rbac.UpdateRole(cts.Kit.Ctx, cts.Kit.Header(), id,
+		updateReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListRole list rbac role.
+func (svc *rbacSvc) ListRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(csrbac.RoleListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.Rbac.ListRole(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// DeleteRole delete rbac role and the bindings of the role, builtin role can not be deleted.
+func (svc *rbacSvc) DeleteRole(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.checkPermission(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	if err := svc.validateNotBuiltin(cts, id); err != nil {
+		return nil, err
+	}
+
+	deleteReq := &protorbac.RoleDeleteReq{Filter: tools.EqualExpression("id", id)}
+	if err := svc.client.DataService().Global.Rbac.DeleteRole(cts.Kit.Ctx, cts.Kit.Header(), deleteReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// CreateRoleBinding bind rbac role to the subjects on the scope.
+func (svc *rbacSvc) CreateRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(csrbac.RoleBindingCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	createReq := &protorbac.RoleBindingBatchCreateReq{
+		Bindings: make([]protorbac.RoleBindingCreateReq, 0, len(req.Subjects)),
+	}
+	for _, subject := range req.Subjects {
+		createReq.Bindings = append(createReq.Bindings, protorbac.RoleBindingCreateReq{
+			RoleID:    req.RoleID,
+			Subject:   subject,
+			ScopeType: req.ScopeType,
+			ScopeIDs:  req.ScopeIDs,
+		})
+	}
+	return svc.client.DataService().Global.Rbac.BatchCreateRoleBinding(cts.Kit.Ctx, cts.Kit.Header(), createReq)
+}
+
+// ListRoleBinding list rbac role binding.
+func (svc *rbacSvc) ListRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(csrbac.RoleBindingListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.Rbac.ListRoleBinding(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// DeleteRoleBinding delete rbac role binding.
+func (svc *rbacSvc) DeleteRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.checkPermission(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	deleteReq := &protorbac.RoleBindingDeleteReq{Filter: tools.EqualExpression("id", id)}
+	if err := svc.client.DataService().Global.Rbac.DeleteRoleBinding(cts.Kit.Ctx, cts.Kit.Header(),
+		deleteReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// validateNotBuiltin validate the role exists and is not builtin, builtin roles are maintained by init rbac.
+func (svc *rbacSvc) validateNotBuiltin(cts *rest.Contexts, id string) error {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.DefaultBasePage,
+		Fields: []string{"id", "name", "builtin"},
+	}
+	result, err := svc.client.DataService().Global.Rbac.ListRole(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Details) == 0 {
+		return errf.Newf(errf.RecordNotFound, "rbac role %s not found", id)
+	}
+
+	if result.Details[0].Builtin {
+		return errf.Newf(errf.InvalidParameter, "builtin role %s can not be modified", result.Details[0].Name)
+	}
+
+	return nil
+}
+
+// validateActions validate the actions are all defined in the action model.
+func validateActions(actions []string) error {
+	actionMap := make(map[string]corerbac.Action)
+	for _, action := range rbac.ListActions() {
+		actionMap[action.ID] = action
+	}
+
+	for _, action := range actions {
+		if _, exists := actionMap[action]; !exists {
+			return errf.Newf(errf.InvalidParameter, "action %s is not defined", action)
+		}
+	}
+
+	return nil
+}
+
+// checkPermission check rbac manage permission.
+func (svc *rbacSvc) checkPermission(cts *rest.Contexts, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Rbac, Action: action}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resourcehistory
+
+import (
+	"time"
+
+	protohistory "hcm/pkg/api/data-service/resource-history"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+)
+
+const cleanInterval = time.Hour
+
+// CleanExpiredResourceHistory clean the resource histories that exceed the retention days.
+func CleanExpiredResourceHistory(opt cc.ResourceHistory, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	for {
+		time.Sleep(cleanInterval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := kit.New()
+		kt.User = constant.ResourceHistoryCleanerUserKey
+		kt.AppCode = constant.ResourceHistoryCleanerAppCodeKey
+
+		expireTime := time.Now().AddDate(0, 0, -int(opt.RetentionDay)).Format(constant.TimeStdFormat)
+		req := &protohistory.ResourceHistoryDeleteReq{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					filter.AtomRule{Field: "created_at", Op: filter.LessThan.Factory(), Value: expireTime},
+				},
+			},
+		}
+		result, err := cliSet.DataService().Global.ResourceHistory.DeleteResourceHistory(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("delete expired resource history failed, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+
+		logs.V(3).Infof("delete %d expired resource histories, rid: %s", result.Deleted, kt.Rid)
+	}
+}
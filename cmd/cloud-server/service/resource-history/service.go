@@ -0,0 +1,188 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcehistory defines the resource change history api, the field level diffs of every change of a
+// resource, including the sync ones, and the state of a resource at a specified time can be queried.
+package resourcehistory
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
+	protohistory "hcm/pkg/api/data-service/resource-history"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/table"
+	tablehistory "hcm/pkg/dal/table/resource-history"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the resource change history service.
+func InitService(c *capability.Capability, opt cc.ResourceHistory) {
+	svc := &historySvc{
+		client:       c.ApiClient,
+		authorizer:   c.Authorizer,
+		retentionDay: int(opt.RetentionDay),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListResourceHistory", "POST", "/resources/{res_type}/{res_id}/histories/list", svc.ListResourceHistory)
+	h.Add("GetResourceState", "POST", "/resources/{res_type}/{res_id}/state", svc.GetResourceState)
+
+	h.Load(c.WebService)
+}
+
+type historySvc struct {
+	client       *client.ClientSet
+	authorizer   auth.Authorizer
+	retentionDay int
+}
+
+// ListResourceHistory list the change histories of a resource with the field level diffs.
+func (svc *historySvc) ListResourceHistory(cts *rest.Contexts) (interface{}, error) {
+	resType, resID, err := parseResPath(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(protohistory.ResourceHistoryListReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err = svc.authorizeResource(cts.Kit, resType, resID); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.ResourceHistory.ListResourceHistory(cts.Kit.Ctx, cts.Kit.Header(),
+		resType, resID, req)
+}
+
+// GetResourceState get the state of a resource at the specified time, the time should be within the retention days
+// of the histories, because the changes before it may have been cleaned.
+func (svc *historySvc) GetResourceState(cts *rest.Contexts) (interface{}, error) {
+	resType, resID, err := parseResPath(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(protohistory.ResourceStateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	stateTime, err := time.Parse(constant.TimeStdFormat, req.Time)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if stateTime.Before(time.Now().AddDate(0, 0, -svc.retentionDay)) {
+		return nil, errf.Newf(errf.InvalidParameter, "time %s exceeds the %d retention days of resource history",
+			req.Time, svc.retentionDay)
+	}
+
+	if err = svc.authorizeResource(cts.Kit, resType, resID); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.ResourceHistory.GetResourceState(cts.Kit.Ctx, cts.Kit.Header(), resType,
+		resID, req)
+}
+
+// authorizeResource check if user has the find permission of the account that the resource belongs to. the account
+// is taken from the histories, or the current state if the resource has no history, nothing can be queried if
+// neither of them exists.
+func (svc *historySvc) authorizeResource(kt *kit.Kit, resType table.Name, resID string) error {
+	authInst, err := svc.authorizer.ListAuthorizedInstances(kt, &meta.ListAuthResInput{
+		Type: meta.ResourceType(resType), Action: meta.Find})
+	if err != nil {
+		return err
+	}
+
+	// history only records the account of the resource, so attribute based permission can not be applied.
+	if len(authInst.Filter) != 0 {
+		return errf.Newf(errf.PermissionDenied, "resource history requires %s find permission of accounts, "+
+			"attribute based permission is not supported", resType)
+	}
+
+	if authInst.IsAny {
+		return nil
+	}
+
+	listReq := &protohistory.ResourceHistoryListReq{Page: &core.BasePage{Start: 0, Limit: 1}}
+	histories, err := svc.client.DataService().Global.ResourceHistory.ListResourceHistory(kt.Ctx, kt.Header(),
+		resType, resID, listReq)
+	if err != nil {
+		return err
+	}
+
+	accountID := ""
+	if len(histories.Details) != 0 {
+		accountID = histories.Details[0].AccountID
+	} else {
+		stateReq := &protohistory.ResourceStateReq{Time: time.Now().Format(constant.TimeStdFormat)}
+		state, err := svc.client.DataService().Global.ResourceHistory.GetResourceState(kt.Ctx, kt.Header(),
+			resType, resID, stateReq)
+		if err != nil {
+			return err
+		}
+
+		if !state.Exist {
+			return nil
+		}
+		accountID = state.AccountID
+	}
+
+	for _, id := range authInst.IDs {
+		if id == accountID {
+			return nil
+		}
+	}
+
+	return errf.Newf(errf.PermissionDenied, "no %s find permission of account %s", resType, accountID)
+}
+
+func parseResPath(cts *rest.Contexts) (table.Name, string, error) {
+	resType := table.Name(cts.PathParameter("res_type").String())
+	if err := tablehistory.ValidateResType(resType); err != nil {
+		return "", "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resID := cts.PathParameter("res_id").String()
+	if len(resID) == 0 {
+		return "", "", errf.New(errf.InvalidParameter, "res_id is required")
+	}
+
+	return resType, resID, nil
+}
@@ -0,0 +1,197 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines the resource tag api, tags of the cloud resources are synced from cloud, and can be
+// updated to cloud.
+package resourcetag
+
+import (
+	"hcm/cmd/cloud-server/service/capability"
+	csresourcetag "hcm/pkg/api/cloud-server/resource-tag"
+	"hcm/pkg/api/core"
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
+	hcresourcetag "hcm/pkg/api/hc-service/resource-tag"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableresourcetag "hcm/pkg/dal/table/resource-tag"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitService initialize the resource tag service.
+func InitService(c *capability.Capability) {
+	svc := &resourceTagSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListResourceTag", "GET", "/resource_tags/{res_type}/{res_id}", svc.ListResourceTag)
+	h.Add("UpdateResourceTag", "PUT", "/resource_tags/{res_type}/{res_id}", svc.UpdateResourceTag)
+
+	// biz apis
+	h.Add("ListBizResourceTag", "GET", "/bizs/{bk_biz_id}/resource_tags/{res_type}/{res_id}",
+		svc.ListBizResourceTag)
+	h.Add("UpdateBizResourceTag", "PUT", "/bizs/{bk_biz_id}/resource_tags/{res_type}/{res_id}",
+		svc.UpdateBizResourceTag)
+
+	h.Load(c.WebService)
+}
+
+type resourceTagSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// ListResourceTag list the tags of the resource.
+func (svc *resourceTagSvc) ListResourceTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResourceTag(cts, handler.ResValidWithAuth)
+}
+
+// ListBizResourceTag list the tags of the biz resource.
+func (svc *resourceTagSvc) ListBizResourceTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResourceTag(cts, handler.BizValidWithAuth)
+}
+
+func (svc *resourceTagSvc) listResourceTag(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	resType, basicInfo, err := svc.validateResource(cts, validHandler, meta.Find)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := tools.And(
+		filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: resType},
+		filter.AtomRule{Field: "res_id", Op: filter.Equal.Factory(), Value: basicInfo.ID},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "tag_key", Order: core.Ascending},
+		Fields: []string{"tag_key", "tag_value"},
+	}
+	result, err := svc.client.DataService().Global.ResourceTag.ListResourceTag(cts.Kit.Ctx, cts.Kit.Header(),
+		listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]coreresourcetag.Tag, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coreresourcetag.Tag{Key: one.TagKey, Value: one.TagValue})
+	}
+
+	return &csresourcetag.ResourceTagListResult{Details: details}, nil
+}
+
+// UpdateResourceTag update the tags of the resource on cloud.
+func (svc *resourceTagSvc) UpdateResourceTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.updateResourceTag(cts, handler.ResValidWithAuth)
+}
+
+// UpdateBizResourceTag update the tags of the biz resource on cloud.
+func (svc *resourceTagSvc) UpdateBizResourceTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.updateResourceTag(cts, handler.BizValidWithAuth)
+}
+
+func (svc *resourceTagSvc) updateResourceTag(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csresourcetag.ResourceTagUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resType, basicInfo, err := svc.validateResource(cts, validHandler, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	hcReq := &hcresourcetag.ResourceTagUpdateReq{
+		ResType: string(resType),
+		ResID:   basicInfo.ID,
+		Tags:    req.Tags,
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		err = svc.client.HCService().TCloud.ResourceTag.UpdateResourceTag(cts.Kit.Ctx, cts.Kit.Header(), hcReq)
+	case enumor.Aws:
+		err = svc.client.HCService().Aws.ResourceTag.UpdateResourceTag(cts.Kit.Ctx, cts.Kit.Header(), hcReq)
+	case enumor.Azure:
+		err = svc.client.HCService().Azure.ResourceTag.UpdateResourceTag(cts.Kit.Ctx, cts.Kit.Header(), hcReq)
+	case enumor.Gcp:
+		err = svc.client.HCService().Gcp.ResourceTag.UpdateResourceTag(cts.Kit.Ctx, cts.Kit.Header(), hcReq)
+	case enumor.HuaWei:
+		err = svc.client.HCService().HuaWei.ResourceTag.UpdateResourceTag(cts.Kit.Ctx, cts.Kit.Header(), hcReq)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "%s does not support the update of resource tags",
+			basicInfo.Vendor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// validateResource validate the resource type and authorize the resource.
+func (svc *resourceTagSvc) validateResource(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	action meta.Action) (table.Name, *types.CloudResourceBasicInfo, error) {
+
+	resType := table.Name(cts.PathParameter("res_type").String())
+	if err := tableresourcetag.ValidateResType(resType); err != nil {
+		return "", nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resID := cts.PathParameter("res_id").String()
+	if len(resID) == 0 {
+		return "", nil, errf.New(errf.InvalidParameter, "res_id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.CloudResourceType(resType), resID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+		ResType: meta.ResourceType(resType), Action: action, BasicInfo: basicInfo})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return resType, basicInfo, nil
+}
@@ -30,26 +30,35 @@ import (
 	"hcm/cmd/cloud-server/logics"
 	logicaudit "hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/account"
+	"hcm/cmd/cloud-server/service/aggregation"
 	"hcm/cmd/cloud-server/service/application"
 	"hcm/cmd/cloud-server/service/assign"
 	"hcm/cmd/cloud-server/service/audit"
 	"hcm/cmd/cloud-server/service/bill"
+	bizassignrule "hcm/cmd/cloud-server/service/biz-assign-rule"
 	"hcm/cmd/cloud-server/service/capability"
+	changefeed "hcm/cmd/cloud-server/service/change-feed"
 	"hcm/cmd/cloud-server/service/cvm"
 	"hcm/cmd/cloud-server/service/disk"
 	"hcm/cmd/cloud-server/service/eip"
+	"hcm/cmd/cloud-server/service/event"
 	"hcm/cmd/cloud-server/service/firewall"
 	"hcm/cmd/cloud-server/service/image"
 	instancetype "hcm/cmd/cloud-server/service/instance-type"
 	networkinterface "hcm/cmd/cloud-server/service/network-interface"
+	"hcm/cmd/cloud-server/service/rbac"
 	"hcm/cmd/cloud-server/service/recycle"
 	"hcm/cmd/cloud-server/service/region"
 	resourcegroup "hcm/cmd/cloud-server/service/resource-group"
+	resourcehistory "hcm/cmd/cloud-server/service/resource-history"
+	resourcetag "hcm/cmd/cloud-server/service/resource-tag"
 	routetable "hcm/cmd/cloud-server/service/route-table"
 	securitygroup "hcm/cmd/cloud-server/service/security-group"
 	"hcm/cmd/cloud-server/service/subnet"
 	"hcm/cmd/cloud-server/service/sync"
 	"hcm/cmd/cloud-server/service/sync/lock"
+	tagpolicy "hcm/cmd/cloud-server/service/tag-policy"
+	"hcm/cmd/cloud-server/service/token"
 	"hcm/cmd/cloud-server/service/vpc"
 	"hcm/cmd/cloud-server/service/zone"
 	"hcm/pkg/cc"
@@ -62,6 +71,7 @@ import (
 	"hcm/pkg/metrics"
 	"hcm/pkg/rest"
 	restcli "hcm/pkg/rest/client"
+	"hcm/pkg/runtime/ratelimit"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
 	"hcm/pkg/thirdparty/esb"
@@ -79,6 +89,8 @@ type Service struct {
 	cipher     cryptography.Crypto
 	// EsbClient 调用接入ESB的第三方系统API集合
 	esbClient esb.Client
+	// limiter 接口限流器，未开启限流时为nil
+	limiter *ratelimit.Limiter
 }
 
 // NewService create a service instance.
@@ -121,12 +133,20 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 		return nil, err
 	}
 
+	// 接口限流器，各实例通过etcd协调分担限流阈值
+	limiter, err := ratelimit.NewFromConfig(context.Background(), cc.CloudServerName, cc.CloudServer().RateLimit,
+		cc.CloudServer().Service.Etcd)
+	if err != nil {
+		return nil, fmt.Errorf("init rate limiter failed, err: %v", err)
+	}
+
 	svr := &Service{
 		client:     apiClientSet,
 		authorizer: authorizer,
 		audit:      logicaudit.NewAudit(apiClientSet.DataService()),
 		cipher:     cipher,
 		esbClient:  esbClient,
+		limiter:    limiter,
 	}
 
 	etcdCfg, err := cc.CloudServer().Service.Etcd.ToConfig()
@@ -148,16 +168,33 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 		go bill.CloudBillConfigCreate(interval, sd, apiClientSet)
 	}
 
+	if cc.CloudServer().CostAnomaly.Enable {
+		go bill.CostAnomalyDetect(cc.CloudServer().CostAnomaly, sd, apiClientSet)
+	}
+
+	if cc.CloudServer().AccountHealth.Enable {
+		go account.AccountHealthCheck(cc.CloudServer().AccountHealth, sd, apiClientSet)
+	}
+
+	if cc.CloudServer().EventBus.Enable {
+		go event.Dispatch(cc.CloudServer().EventBus, sd, apiClientSet, cipher)
+		go changefeed.CleanExpiredChangeFeed(cc.CloudServer().ChangeFeed, sd, apiClientSet)
+	}
+
+	go resourcehistory.CleanExpiredResourceHistory(cc.CloudServer().ResourceHistory, sd, apiClientSet)
+
 	recycle.RecycleTiming(apiClientSet, sd, cc.CloudServer().Recycle)
 
+	// 所有写接口支持通过 Idempotency-Key 请求头保证幂等
+	rest.SetIdempotencyStore(newIdempotencyStore(apiClientSet, cc.CloudServer().Idempotency))
+	go cleanExpiredIdempotencyRecord(sd, apiClientSet)
+
 	return svr, nil
 }
 
 // newCipherFromConfig 根据配置文件里的加密配置，选择配置的算法并生成对应的加解密器
 func newCipherFromConfig(cryptoConfig cc.Crypto) (cryptography.Crypto, error) {
-	// TODO: 目前只支持国际加密，还未支持中国国家商业加密，待后续支持再调整
-	cfg := cryptoConfig.AesGcm
-	return cryptography.NewAESGcm([]byte(cfg.Key), []byte(cfg.Nonce))
+	return cryptography.NewCrypto(cryptoConfig.CryptoOption())
 }
 
 // ListenAndServeRest listen and serve the restful server
@@ -221,6 +258,9 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	ws := new(restful.WebService)
 	ws.Path("/api/v1/cloud")
 	ws.Produces(restful.MIME_JSON)
+	if s.limiter != nil {
+		ws.Filter(ratelimit.RestfulFilter(s.limiter))
+	}
 
 	c := &capability.Capability{
 		WebService: ws,
@@ -253,6 +293,15 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	assign.InitService(c)
 	recycle.InitService(c)
 	bill.InitBillService(c)
+	rbac.InitService(c)
+	token.InitService(c)
+	event.InitService(c, cc.CloudServer().EventBus)
+	changefeed.InitService(c, cc.CloudServer().ChangeFeed)
+	resourcehistory.InitService(c, cc.CloudServer().ResourceHistory)
+	resourcetag.InitService(c)
+	bizassignrule.InitService(c)
+	tagpolicy.InitService(c)
+	aggregation.InitService(c)
 
 	return restful.NewContainer().Add(c.WebService)
 }
@@ -22,6 +22,8 @@ package aws
 import (
 	"time"
 
+	logicstagpolicy "hcm/cmd/cloud-server/logics/tag-policy"
+	bizassignrule "hcm/cmd/cloud-server/service/biz-assign-rule"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/validator"
@@ -110,5 +112,15 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet, opt *SyncAllResource
 		return hitErr
 	}
 
+	// 资源同步后执行账号的业务自动分配规则，将匹配规则的未分配资源分配到业务，资源已同步完成，规则执行失败只记录日志
+	if err := bizassignrule.ApplyAccountRules(kt, cliSet.DataService(), opt.AccountID); err != nil {
+		logs.Errorf("apply account %s biz assign rules failed, err: %v, rid: %s", opt.AccountID, err, kt.Rid)
+	}
+
+	// 业务分配后评估账号资源的标签策略，记录违规资源，并按策略自动补全缺失的必填标签
+	if _, hitErr = logicstagpolicy.Evaluate(kt, cliSet, opt.AccountID); hitErr != nil {
+		return hitErr
+	}
+
 	return nil
 }
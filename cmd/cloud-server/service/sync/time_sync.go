@@ -109,10 +109,8 @@ func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor)
 			Limit: core.DefaultMaxPageLimit,
 		},
 	}
-	start := uint32(0)
 	syncPublicResource := true
 	for {
-		listReq.Page.Start = start
 		accounts, err := listAccountWithRetry(kt, cliSet.DataService(), listReq)
 		if err != nil {
 			logs.Errorf("list account failed, err: %v, rid: %s", err, kt.Rid)
@@ -158,7 +156,7 @@ func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor)
 			break
 		}
 
-		start += uint32(core.DefaultMaxPageLimit)
+		listReq.Page.Cursor = core.NewIDPageCursor(accounts[len(accounts)-1].ID)
 	}
 }
 
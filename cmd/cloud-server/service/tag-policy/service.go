@@ -0,0 +1,296 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy defines the tag policy api, the tags of the account's resources are evaluated against the
+// policies after each account resource sync, and the violations can be listed as a report.
+package tagpolicy
+
+import (
+	logicstagpolicy "hcm/cmd/cloud-server/logics/tag-policy"
+	"hcm/cmd/cloud-server/service/capability"
+	cstagpolicy "hcm/pkg/api/cloud-server/tag-policy"
+	"hcm/pkg/api/core"
+	coretagpolicy "hcm/pkg/api/core/tag-policy"
+	prototagpolicy "hcm/pkg/api/data-service/tag-policy"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitService initialize the tag policy service.
+func InitService(c *capability.Capability) {
+	svc := &tagPolicySvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateTagPolicy", "POST", "/tag_policies/create", svc.CreateTagPolicy)
+	h.Add("UpdateTagPolicy", "PATCH", "/tag_policies/{id}", svc.UpdateTagPolicy)
+	h.Add("ListTagPolicy", "POST", "/tag_policies/list", svc.ListTagPolicy)
+	h.Add("DeleteTagPolicy", "DELETE", "/tag_policies/{id}", svc.DeleteTagPolicy)
+	h.Add("EvaluateTagPolicy", "POST", "/tag_policies/evaluate", svc.EvaluateTagPolicy)
+	h.Add("ListTagPolicyViolation", "POST", "/tag_policies/violations/list", svc.ListTagPolicyViolation)
+
+	h.Load(c.WebService)
+}
+
+type tagPolicySvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// authorize check if user has the permission of the tag policy scope, account policy is authorized by the account,
+// biz policy is authorized by the biz.
+func (svc *tagPolicySvc) authorize(kt *kit.Kit, accountID string, bkBizID int64, action meta.Action) error {
+	var authRes meta.ResourceAttribute
+	switch {
+	case len(accountID) != 0 && action == meta.Find:
+		authRes = meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CloudResource, Action: meta.Find,
+			ResourceID: accountID}}
+	case len(accountID) != 0:
+		authRes = meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Account, Action: meta.Update,
+			ResourceID: accountID}}
+	case action == meta.Find:
+		authRes = meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Biz, Action: meta.Access}, BizID: bkBizID}
+	default:
+		authRes = meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Biz, Action: meta.Update}, BizID: bkBizID}
+	}
+
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes)
+}
+
+// scopeExpr generate the filter expression of the account or biz scope, combined with the user's filter.
+func scopeExpr(req *cstagpolicy.ScopeListReq) (*filter.Expression, error) {
+	rules := make([]filter.RuleFactory, 0)
+	if len(req.AccountID) != 0 {
+		rules = append(rules, tools.EqualExpression("account_id", req.AccountID))
+	} else {
+		rules = append(rules, tools.EqualExpression("bk_biz_id", req.BkBizID))
+	}
+
+	if req.Filter != nil {
+		rules = append(rules, req.Filter)
+	}
+
+	return tools.And(rules...)
+}
+
+// getTagPolicy get tag policy by id.
+func (svc *tagPolicySvc) getTagPolicy(kt *kit.Kit, id string) (*coretagpolicy.TagPolicy, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.DefaultBasePage,
+	}
+	result, err := svc.client.DataService().Global.TagPolicy.ListTagPolicy(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "tag policy %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
+
+// CreateTagPolicy create tag policy.
+func (svc *tagPolicySvc) CreateTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(cstagpolicy.TagPolicyCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, req.AccountID, req.BkBizID, meta.Create); err != nil {
+		return nil, err
+	}
+
+	createReq := &prototagpolicy.TagPolicyCreateReq{
+		Name:          req.Name,
+		AccountID:     req.AccountID,
+		BkBizID:       req.BkBizID,
+		ResTypes:      req.ResTypes,
+		Rules:         req.Rules,
+		AutoRemediate: req.AutoRemediate,
+		Enabled:       req.Enabled,
+		Memo:          req.Memo,
+	}
+	return svc.client.DataService().Global.TagPolicy.CreateTagPolicy(cts.Kit.Ctx, cts.Kit.Header(), createReq)
+}
+
+// UpdateTagPolicy update tag policy.
+func (svc *tagPolicySvc) UpdateTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(cstagpolicy.TagPolicyUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	policy, err := svc.getTagPolicy(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.authorize(cts.Kit, policy.AccountID, policy.BkBizID, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &prototagpolicy.TagPolicyUpdateReq{
+		Name:          req.Name,
+		ResTypes:      req.ResTypes,
+		Rules:         req.Rules,
+		AutoRemediate: req.AutoRemediate,
+		Enabled:       req.Enabled,
+		Memo:          req.Memo,
+	}
+	if err = svc.client.DataService().Global.TagPolicy.UpdateTagPolicy(cts.Kit.Ctx, cts.Kit.Header(), id,
+		updateReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListTagPolicy list tag policy of the account or biz.
+func (svc *tagPolicySvc) ListTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(cstagpolicy.ScopeListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, req.AccountID, req.BkBizID, meta.Find); err != nil {
+		return nil, err
+	}
+
+	expr, err := scopeExpr(req)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   req.Page,
+	}
+	result, err := svc.client.DataService().Global.TagPolicy.ListTagPolicy(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cstagpolicy.TagPolicyListResult{Count: result.Count, Details: result.Details}, nil
+}
+
+// DeleteTagPolicy delete tag policy, the violations of the policy are deleted as well.
+func (svc *tagPolicySvc) DeleteTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	policy, err := svc.getTagPolicy(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.authorize(cts.Kit, policy.AccountID, policy.BkBizID, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	deleteReq := &prototagpolicy.TagPolicyDeleteReq{Filter: tools.EqualExpression("id", id)}
+	if err = svc.client.DataService().Global.TagPolicy.DeleteTagPolicy(cts.Kit.Ctx, cts.Kit.Header(),
+		deleteReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// EvaluateTagPolicy evaluate the tags of the account's resources against the tag policies immediately, the missing
+// required tags of the auto remediate policies are set to their default values.
+func (svc *tagPolicySvc) EvaluateTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(cstagpolicy.TagPolicyEvaluateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, req.AccountID, 0, meta.Update); err != nil {
+		return nil, err
+	}
+
+	return logicstagpolicy.Evaluate(cts.Kit, svc.client, req.AccountID)
+}
+
+// ListTagPolicyViolation list the tag policy violations of the account's or biz's resources, it's the violation
+// report of the last evaluation.
+func (svc *tagPolicySvc) ListTagPolicyViolation(cts *rest.Contexts) (interface{}, error) {
+	req := new(cstagpolicy.ScopeListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, req.AccountID, req.BkBizID, meta.Find); err != nil {
+		return nil, err
+	}
+
+	expr, err := scopeExpr(req)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   req.Page,
+	}
+	result, err := svc.client.DataService().Global.TagPolicy.ListTagPolicyViolation(cts.Kit.Ctx, cts.Kit.Header(),
+		listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cstagpolicy.ViolationListResult{Count: result.Count, Details: result.Details}, nil
+}
@@ -0,0 +1,186 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package token
+
+import (
+	cstoken "hcm/pkg/api/cloud-server/token"
+	"hcm/pkg/api/core"
+	prototoken "hcm/pkg/api/data-service/token"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+)
+
+// CreateApiToken create personal api token of current user.
+func (svc *tokenSvc) CreateApiToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(cstoken.ApiTokenCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := validateNotApiTokenRequest(cts); err != nil {
+		return nil, err
+	}
+
+	return svc.createApiToken(cts, enumor.UserApiToken, cts.Kit.User, req)
+}
+
+// ListApiToken list personal api tokens of current user.
+func (svc *tokenSvc) ListApiToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(cstoken.ApiTokenListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.listApiToken(cts, enumor.UserApiToken, cts.Kit.User, req)
+}
+
+// RevokeApiToken revoke api token, revoked api token can not be used anymore.
+func (svc *tokenSvc) RevokeApiToken(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.checkApiTokenPermission(cts, id, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &prototoken.ApiTokenUpdateReq{Revoked: converter.ValToPtr(true)}
+	if err := svc.client.DataService().Global.Token.UpdateApiToken(cts.Kit.Ctx, cts.Kit.Header(), id,
+		updateReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// DeleteApiToken delete api token.
+func (svc *tokenSvc) DeleteApiToken(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.checkApiTokenPermission(cts, id, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	deleteReq := &prototoken.ApiTokenDeleteReq{Filter: tools.EqualExpression("id", id)}
+	if err := svc.client.DataService().Global.Token.DeleteApiToken(cts.Kit.Ctx, cts.Kit.Header(),
+		deleteReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// createApiToken create api token of the subject, the token is only returned once.
+func (svc *tokenSvc) createApiToken(cts *rest.Contexts, subjectType enumor.ApiTokenSubjectType, subject string,
+	req *cstoken.ApiTokenCreateReq) (*cstoken.ApiTokenCreateResult, error) {
+
+	token, hash, prefix, err := generateToken()
+	if err != nil {
+		logs.Errorf("generate api token failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	createReq := &prototoken.ApiTokenCreateReq{
+		Name:        req.Name,
+		SubjectType: subjectType,
+		Subject:     subject,
+		TokenHash:   hash,
+		TokenPrefix: prefix,
+		Scopes:      req.Scopes,
+		ExpiredAt:   req.ExpiredAt,
+		Memo:        req.Memo,
+	}
+	result, err := svc.client.DataService().Global.Token.CreateApiToken(cts.Kit.Ctx, cts.Kit.Header(), createReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cstoken.ApiTokenCreateResult{ID: result.ID, Token: token}, nil
+}
+
+// listApiToken list api tokens of the subject.
+func (svc *tokenSvc) listApiToken(cts *rest.Contexts, subjectType enumor.ApiTokenSubjectType, subject string,
+	req *cstoken.ApiTokenListReq) (*prototoken.ApiTokenListResult, error) {
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "subject_type", Op: filter.Equal.Factory(), Value: subjectType},
+				filter.AtomRule{Field: "subject", Op: filter.Equal.Factory(), Value: subject},
+				req.Filter,
+			},
+		},
+		Page: req.Page,
+	}
+	return svc.client.DataService().Global.Token.ListApiToken(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// checkApiTokenPermission check if user can manage the api token, personal api token can only be managed by its
+// owner, service account api token can be managed by those who can manage the service account.
+func (svc *tokenSvc) checkApiTokenPermission(cts *rest.Contexts, id string, action meta.Action) error {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.DefaultBasePage,
+	}
+	result, err := svc.client.DataService().Global.Token.ListApiToken(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Details) == 0 {
+		return errf.Newf(errf.RecordNotFound, "api token %s not found", id)
+	}
+
+	token := result.Details[0]
+	switch token.SubjectType {
+	case enumor.UserApiToken:
+		if token.Subject != cts.Kit.User {
+			return errf.New(errf.PermissionDenied, "personal api token can only be managed by its owner")
+		}
+		return nil
+	case enumor.ServiceAccountApiToken:
+		sa, err := svc.getServiceAccount(cts, "name", token.Subject)
+		if err != nil {
+			return err
+		}
+		return svc.checkServiceAccountPermission(cts, sa, action)
+	default:
+		return errf.Newf(errf.InvalidParameter, "unsupported api token subject type: %s", token.SubjectType)
+	}
+}
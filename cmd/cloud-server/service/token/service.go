@@ -0,0 +1,135 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package token defines the hcm issued api token and service account management api.
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
+	coretoken "hcm/pkg/api/core/token"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// InitService initialize the api token and service account management service.
+func InitService(c *capability.Capability) {
+	svc := &tokenSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateApiToken", "POST", "/api_tokens/create", svc.CreateApiToken)
+	h.Add("ListApiToken", "POST", "/api_tokens/list", svc.ListApiToken)
+	h.Add("RevokeApiToken", "POST", "/api_tokens/{id}/revoke", svc.RevokeApiToken)
+	h.Add("DeleteApiToken", "DELETE", "/api_tokens/{id}", svc.DeleteApiToken)
+
+	h.Add("CreateServiceAccount", "POST", "/service_accounts/create", svc.CreateServiceAccount)
+	h.Add("UpdateServiceAccount", "PATCH", "/service_accounts/{id}", svc.UpdateServiceAccount)
+	h.Add("ListServiceAccount", "POST", "/service_accounts/list", svc.ListServiceAccount)
+	h.Add("DeleteServiceAccount", "DELETE", "/service_accounts/{id}", svc.DeleteServiceAccount)
+	h.Add("CreateServiceAccountApiToken", "POST", "/service_accounts/{id}/api_tokens/create",
+		svc.CreateServiceAccountApiToken)
+	h.Add("ListServiceAccountApiToken", "POST", "/service_accounts/{id}/api_tokens/list",
+		svc.ListServiceAccountApiToken)
+
+	h.Load(c.WebService)
+}
+
+type tokenSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// hasManagePermission check if user has service account manage permission.
+func (svc *tokenSvc) hasManagePermission(cts *rest.Contexts, action meta.Action) (bool, error) {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.ServiceAccount, Action: action}}
+	_, authorized, err := svc.authorizer.Authorize(cts.Kit, authRes)
+	if err != nil {
+		return false, err
+	}
+
+	return authorized, nil
+}
+
+// checkServiceAccountPermission check if user can manage the service account, managers of the service account and
+// users with service account manage permission can manage it.
+func (svc *tokenSvc) checkServiceAccountPermission(cts *rest.Contexts, sa *coretoken.ServiceAccount,
+	action meta.Action) error {
+
+	if slice.IsItemInSlice(sa.Managers, cts.Kit.User) {
+		return nil
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.ServiceAccount, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes)
+}
+
+// getServiceAccount get service account by id or name.
+func (svc *tokenSvc) getServiceAccount(cts *rest.Contexts, field, value string) (*coretoken.ServiceAccount, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression(field, value),
+		Page:   core.DefaultBasePage,
+	}
+	result, err := svc.client.DataService().Global.Token.ListServiceAccount(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "service account %s not found", value)
+	}
+
+	return &result.Details[0], nil
+}
+
+// validateNotApiTokenRequest api token can not be used to issue new api tokens, in case that the scopes of api
+// token are bypassed.
+func validateNotApiTokenRequest(cts *rest.Contexts) error {
+	if cts.Kit.AppCode == constant.ApiTokenAppCodeKey {
+		return errf.New(errf.PermissionDenied, "api token can not be used to issue api token")
+	}
+
+	return nil
+}
+
+// generateToken generate api token, returns the token, its hash and the prefix used for display.
+func generateToken() (token string, hash string, prefix string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", "", err
+	}
+
+	token = constant.ApiTokenPrefix + hex.EncodeToString(buf)
+	sum := sha256.Sum256([]byte(token))
+
+	return token, hex.EncodeToString(sum[:]), token[:12], nil
+}
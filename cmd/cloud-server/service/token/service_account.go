@@ -0,0 +1,213 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package token
+
+import (
+	cstoken "hcm/pkg/api/cloud-server/token"
+	"hcm/pkg/api/core"
+	prototoken "hcm/pkg/api/data-service/token"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// CreateServiceAccount create service account.
+func (svc *tokenSvc) CreateServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(cstoken.ServiceAccountCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.ServiceAccount, Action: meta.Create}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	createReq := &prototoken.ServiceAccountCreateReq{
+		Name:     req.Name,
+		Managers: req.Managers,
+		Memo:     req.Memo,
+	}
+	return svc.client.DataService().Global.Token.CreateServiceAccount(cts.Kit.Ctx, cts.Kit.Header(), createReq)
+}
+
+// UpdateServiceAccount update service account.
+func (svc *tokenSvc) UpdateServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(cstoken.ServiceAccountUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	sa, err := svc.getServiceAccount(cts, "id", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.checkServiceAccountPermission(cts, sa, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &prototoken.ServiceAccountUpdateReq{
+		Managers: req.Managers,
+		Memo:     req.Memo,
+	}
+	if err = svc.client.DataService().Global.Token.UpdateServiceAccount(cts.Kit.Ctx, cts.Kit.Header(), id,
+		updateReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListServiceAccount list service account, users without service account manage permission can only list the
+// service accounts that they manage.
+func (svc *tokenSvc) ListServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(cstoken.ServiceAccountListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authorized, err := svc.hasManagePermission(cts, meta.Find)
+	if err != nil {
+		return nil, err
+	}
+
+	listFilter := req.Filter
+	if !authorized {
+		listFilter = &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "managers", Op: filter.JSONContains.Factory(), Value: cts.Kit.User},
+				req.Filter,
+			},
+		}
+	}
+
+	listReq := &core.ListReq{
+		Filter: listFilter,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.Token.ListServiceAccount(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// DeleteServiceAccount delete service account and its api tokens.
+func (svc *tokenSvc) DeleteServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	sa, err := svc.getServiceAccount(cts, "id", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.checkServiceAccountPermission(cts, sa, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	deleteReq := &prototoken.ServiceAccountDeleteReq{Filter: tools.EqualExpression("id", id)}
+	if err = svc.client.DataService().Global.Token.DeleteServiceAccount(cts.Kit.Ctx, cts.Kit.Header(),
+		deleteReq); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// CreateServiceAccountApiToken create api token of service account.
+func (svc *tokenSvc) CreateServiceAccountApiToken(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(cstoken.ApiTokenCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := validateNotApiTokenRequest(cts); err != nil {
+		return nil, err
+	}
+
+	sa, err := svc.getServiceAccount(cts, "id", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.checkServiceAccountPermission(cts, sa, meta.Create); err != nil {
+		return nil, err
+	}
+
+	return svc.createApiToken(cts, enumor.ServiceAccountApiToken, sa.Name, req)
+}
+
+// ListServiceAccountApiToken list api tokens of service account.
+func (svc *tokenSvc) ListServiceAccountApiToken(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(cstoken.ApiTokenListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	sa, err := svc.getServiceAccount(cts, "id", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.checkServiceAccountPermission(cts, sa, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.listApiToken(cts, enumor.ServiceAccountApiToken, sa.Name, req)
+}
@@ -27,15 +27,21 @@ import (
 	"hcm/cmd/data-service/options"
 	"hcm/cmd/data-service/service"
 	"hcm/pkg/cc"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/metrics"
 	"hcm/pkg/runtime/ctl"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the data service.
 func Run(opt *options.Option) error {
+	if opt.MigrateDryRun {
+		return migrateDryRun(opt)
+	}
+
 	ds := new(dataService)
 	if err := ds.prepare(opt); err != nil {
 		return err
@@ -68,6 +74,10 @@ func (ds *dataService) prepare(opt *options.Option) error {
 
 	logs.InitLogger(cc.DataService().Log.Logs())
 
+	if err := tracing.InitTracing(cc.DataService().Tracing); err != nil {
+		return fmt.Errorf("init tracing failed, err: %v", err)
+	}
+
 	logs.Infof("load settings from config file success.")
 
 	// init metrics
@@ -90,7 +100,8 @@ func (ds *dataService) prepare(opt *options.Option) error {
 	ds.sd = sd
 
 	// init hcm control tool
-	if err := ctl.LoadCtl(ctl.WithBasics(sd)...); err != nil {
+	cmds := append(ctl.WithBasics(sd), svc.ReEncryptAccountSecretCmd(), svc.MigrateCmd())
+	if err := ctl.LoadCtl(cmds...); err != nil {
 		return fmt.Errorf("load control tool failed, err: %v", err)
 	}
 
@@ -116,3 +127,31 @@ func (ds *dataService) finalizer() {
 	logs.Infof("shutting down service, deregister service success.")
 	return
 }
+
+// migrateDryRun print the pending db schema migration sql without applying them.
+func migrateDryRun(opt *options.Option) error {
+	if err := cc.LoadSettings(opt.Sys); err != nil {
+		return fmt.Errorf("load settings from config files failed, err: %v", err)
+	}
+
+	migrator, err := service.NewMigrationRunner(cc.DataService())
+	if err != nil {
+		return fmt.Errorf("initialize migration runner failed, err: %v", err)
+	}
+
+	result, err := migrator.Migrate(kit.New(), true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("-- current version: %d, baselined: %v, pending: %d\n", result.CurrentVersion, result.Baselined,
+		len(result.Migrations))
+	for _, m := range result.Migrations {
+		fmt.Printf("\n-- %s, checksum: %s\n", m.Name, m.Checksum)
+		for _, stmt := range m.SQL {
+			fmt.Printf("%s;\n", stmt)
+		}
+	}
+
+	return nil
+}
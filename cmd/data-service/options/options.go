@@ -29,6 +29,8 @@ import (
 // Option defines the app's runtime flag options.
 type Option struct {
 	Sys *cc.SysOption
+	// MigrateDryRun defines if only print the pending schema migration sql and exit.
+	MigrateDryRun bool
 }
 
 // InitOptions init data service's options from command flags.
@@ -36,6 +38,7 @@ func InitOptions() *Option {
 	fs := pflag.CommandLine
 	sysOpt := flags.SysFlags(fs)
 	opt := &Option{Sys: sysOpt}
+	fs.BoolVar(&opt.MigrateDryRun, "dry-run", false, "print the pending db schema migration sql and exit.")
 
 	// parses the command-line flags from os.Args[1:]. must be called after all flags are defined
 	// and before flags are accessed by the program.
@@ -25,7 +25,9 @@ import (
 
 	"hcm/cmd/data-service/service/capability"
 	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/event"
 	proto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao"
@@ -33,7 +35,9 @@ import (
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	tableapplication "hcm/pkg/dal/table/application"
+	tableevent "hcm/pkg/dal/table/event"
 	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 
@@ -120,7 +124,35 @@ func (svc *applicationSvc) Update(cts *rest.Contexts) (interface{}, error) {
 		application.DeliveryDetail = tabletype.JsonField(*req.DeliveryDetail)
 	}
 
-	err := svc.dao.Application().Update(cts.Kit, tools.EqualExpression("id", applicationID), application)
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", applicationID),
+		Page:   &core.BasePage{Count: false, Start: 0, Limit: 1},
+	}
+	listResp, err := svc.dao.Application().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list application failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list application failed, err: %v", err)
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := svc.dao.Application().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", applicationID),
+			application)
+		if err != nil {
+			return nil, err
+		}
+
+		// emit application status changed event, e.g. application.pass
+		if len(listResp.Details) == 0 || listResp.Details[0].Status == application.Status {
+			return nil, nil
+		}
+
+		event, err := newApplicationEvent(cts.Kit, listResp.Details[0], application.Status)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, svc.dao.Event().BatchCreateWithTx(cts.Kit, txn, []*tableevent.EventTable{event})
+	})
 	if err != nil {
 		logs.Errorf("update application failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, fmt.Errorf("update application failed, err: %v", err)
@@ -129,6 +161,40 @@ func (svc *applicationSvc) Update(cts *rest.Contexts) (interface{}, error) {
 	return nil, nil
 }
 
+// newApplicationEvent new the application status changed event.
+func newApplicationEvent(kt *kit.Kit, application *tableapplication.ApplicationTable, status string) (
+	*tableevent.EventTable, error) {
+
+	detail, err := tabletype.NewJsonField(map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":        application.ID,
+			"sn":        application.SN,
+			"type":      application.Type,
+			"status":    application.Status,
+			"applicant": application.Applicant,
+		},
+		"changed": map[string]interface{}{
+			"status": status,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tableevent.EventTable{
+		Type:     tableevent.EventType(coreevent.ApplicationResType, status),
+		ResType:  coreevent.ApplicationResType,
+		Action:   status,
+		ResID:    application.ID,
+		ResName:  application.SN,
+		BkBizID:  constant.UnassignedBiz,
+		Operator: kt.User,
+		Rid:      kt.Rid,
+		AppCode:  kt.AppCode,
+		Detail:   detail,
+	}, nil
+}
+
 func (svc *applicationSvc) convertToApplicationResp(
 	application *tableapplication.ApplicationTable,
 ) *proto.ApplicationResp {
@@ -0,0 +1,290 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bizassignrule
+
+import (
+	"sort"
+
+	dscloud "hcm/cmd/data-service/service/cloud"
+	"hcm/cmd/data-service/service/cloud/cvm"
+	"hcm/pkg/api/core"
+	corebizassignrule "hcm/pkg/api/core/biz-assign-rule"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	protobizassignrule "hcm/pkg/api/data-service/biz-assign-rule"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableaudit "hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ApplyBizAssignRule apply the biz assign rules of the account in priority order, the unassigned resources that match
+// the rule are assigned to the rule's biz, one resource is only assigned by the first matched rule. the matched
+// resources are only returned if it is a dry run.
+func (svc *service) ApplyBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(protobizassignrule.BizAssignRuleApplyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rules, err := svc.listApplyRules(cts.Kit, req)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := svc.matchRules(cts.Kit, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &corebizassignrule.ApplyResult{DryRun: req.DryRun, Details: details}
+	if req.DryRun || len(details) == 0 {
+		return result, nil
+	}
+
+	if err = svc.assignMatched(cts.Kit, details); err != nil {
+		return nil, err
+	}
+
+	// sync the assigned cvms to cmdb biz, the assignment is already committed, so only log the error here.
+	cvmBizIDs := make([]int64, 0)
+	for _, detail := range details {
+		if detail.ResType == enumor.CvmCloudResType && !slice.IsItemInSlice(cvmBizIDs, detail.BkBizID) {
+			cvmBizIDs = append(cvmBizIDs, detail.BkBizID)
+		}
+	}
+
+	for _, bizID := range cvmBizIDs {
+		if err = cvm.SyncCvmToCmdb(cts.Kit, req.AccountID, bizID); err != nil {
+			logs.Errorf("sync cvm to cmdb failed, err: %v, accountID: %s, bkBizID: %d, rid: %s", err,
+				req.AccountID, bizID, cts.Kit.Rid)
+		}
+	}
+
+	return result, nil
+}
+
+// listApplyRules list the rules to apply, and sort them by priority.
+func (svc *service) listApplyRules(kt *kit.Kit, req *protobizassignrule.BizAssignRuleApplyReq) (
+	[]corebizassignrule.BizAssignRule, error) {
+
+	expr := tools.EqualWithOpExpression(filter.And, map[string]interface{}{"account_id": req.AccountID,
+		"enabled": true})
+	if len(req.RuleIDs) != 0 {
+		expr = &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: req.AccountID},
+				filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: req.RuleIDs},
+			},
+		}
+	}
+
+	opt := &types.ListOption{
+		Filter: expr,
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+	result, err := svc.dao.BizAssignRule().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list biz assign rule failed, err: %v, account: %s, rid: %s", err, req.AccountID, kt.Rid)
+		return nil, err
+	}
+
+	rules := make([]corebizassignrule.BizAssignRule, 0, len(result.Details))
+	for _, one := range result.Details {
+		rules = append(rules, convBizAssignRule(one))
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority < rules[j].Priority
+		}
+		return rules[i].ID < rules[j].ID
+	})
+
+	return rules, nil
+}
+
+// matchRules list the unassigned resources that match the rules, resources matched by the former rule are skipped.
+func (svc *service) matchRules(kt *kit.Kit, rules []corebizassignrule.BizAssignRule) (
+	[]corebizassignrule.ApplyDetail, error) {
+
+	matched := make(map[enumor.CloudResourceType]map[string]struct{})
+	details := make([]corebizassignrule.ApplyDetail, 0)
+	for _, rule := range rules {
+		for _, resType := range rule.ResTypes {
+			expr, err := ruleResExpr(rule, resType)
+			if err != nil {
+				return nil, err
+			}
+
+			ids, err := svc.dao.Cloud().ListResourceIDs(kt, resType, expr)
+			if err != nil {
+				logs.Errorf("list biz assign rule %s matched %s failed, err: %v, rid: %s", rule.ID, resType, err,
+					kt.Rid)
+				return nil, err
+			}
+
+			if _, exists := matched[resType]; !exists {
+				matched[resType] = make(map[string]struct{})
+			}
+
+			resIDs := make([]string, 0, len(ids))
+			for _, id := range ids {
+				if _, exists := matched[resType][id]; exists {
+					continue
+				}
+				matched[resType][id] = struct{}{}
+				resIDs = append(resIDs, id)
+			}
+
+			if len(resIDs) == 0 {
+				continue
+			}
+
+			details = append(details, corebizassignrule.ApplyDetail{
+				RuleID:   rule.ID,
+				RuleName: rule.Name,
+				ResType:  resType,
+				BkBizID:  rule.BkBizID,
+				ResIDs:   resIDs,
+			})
+		}
+	}
+
+	return details, nil
+}
+
+// ruleResExpr generate the filter expression of the unassigned resources that match the rule.
+func ruleResExpr(rule corebizassignrule.BizAssignRule, resType enumor.CloudResourceType) (*filter.Expression,
+	error) {
+
+	expr := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: rule.AccountID},
+			filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(), Value: constant.UnassignedBiz},
+		},
+	}
+
+	switch rule.RuleType {
+	case enumor.TagBizAssignRule:
+		expr.Rules = append(expr.Rules, filter.AtomRule{Field: filter.TagFieldPrefix + rule.TagKey,
+			Op: filter.Equal.Factory(), Value: rule.TagValue})
+	case enumor.VpcBizAssignRule:
+		switch resType {
+		case enumor.VpcCloudResType:
+			expr.Rules = append(expr.Rules, filter.AtomRule{Field: "id", Op: filter.Equal.Factory(),
+				Value: rule.VpcID})
+		case enumor.CvmCloudResType:
+			expr.Rules = append(expr.Rules, filter.AtomRule{Field: "vpc_ids", Op: filter.JSONContains.Factory(),
+				Value: rule.VpcID})
+		default:
+			expr.Rules = append(expr.Rules, filter.AtomRule{Field: "vpc_id", Op: filter.Equal.Factory(),
+				Value: rule.VpcID})
+		}
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported biz assign rule type: %s", rule.RuleType)
+	}
+
+	return expr, nil
+}
+
+// assignMatched assign the matched resources to biz and create the assign audits.
+func (svc *service) assignMatched(kt *kit.Kit, details []corebizassignrule.ApplyDetail) error {
+	_, err := svc.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		allAudits := make([]*tableaudit.AuditTable, 0)
+		for _, detail := range details {
+			auditType, exists := dscloud.AssignResAuditTypeMap[detail.ResType]
+			if !exists {
+				return nil, errf.Newf(errf.InvalidParameter, "resource type %s cannot be assigned", detail.ResType)
+			}
+
+			for _, batch := range slice.Split(detail.ResIDs, constant.BatchOperationMaxLimit) {
+				// 匹配后资源可能已被手动分配到其他业务，加锁查出仍未分配的资源，只对这些资源生成审计并分配，避免覆盖
+				unassignedExpr := &filter.Expression{
+					Op: filter.And,
+					Rules: []filter.RuleFactory{
+						filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: batch},
+						filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(),
+							Value: constant.UnassignedBiz},
+					},
+				}
+				ids, err := svc.dao.Cloud().LockResourceIDsWithTx(kt, txn, detail.ResType, unassignedExpr)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(ids) == 0 {
+					continue
+				}
+
+				assigns := make([]protoaudit.CloudResourceAssignInfo, 0, len(ids))
+				for _, id := range ids {
+					assigns = append(assigns, protoaudit.CloudResourceAssignInfo{
+						ResType:         auditType,
+						ResID:           id,
+						AssignedResType: enumor.BizAuditAssignedResType,
+						AssignedResID:   detail.BkBizID,
+					})
+				}
+
+				// audits are generated before the assignment to record the resources' original biz.
+				audits, err := svc.audit.GenCloudResAssignAudit(kt,
+					&protoaudit.CloudResourceAssignAuditReq{Assigns: assigns})
+				if err != nil {
+					return nil, err
+				}
+				allAudits = append(allAudits, audits...)
+
+				assignExpr := tools.ContainersExpression("id", ids)
+				err = svc.dao.Cloud().AssignResourceToBiz(kt, txn, detail.ResType, assignExpr, detail.BkBizID)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if len(allAudits) == 0 {
+			return nil, nil
+		}
+
+		return nil, svc.dao.Audit().BatchCreateWithTx(kt, txn, allAudits)
+	})
+	if err != nil {
+		logs.Errorf("assign biz assign rule matched resources failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,298 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bizassignrule defines the data-service api of biz assign rule, and applies the rules to assign the
+// unassigned resources to biz.
+package bizassignrule
+
+import (
+	"fmt"
+
+	auditcloud "hcm/cmd/data-service/service/audit/cloud"
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corebizassignrule "hcm/pkg/api/core/biz-assign-rule"
+	protobizassignrule "hcm/pkg/api/data-service/biz-assign-rule"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablebizassignrule "hcm/pkg/dal/table/biz-assign-rule"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the biz assign rule service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao:   cap.Dao,
+		audit: auditcloud.NewCloudAudit(cap.Dao),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateBizAssignRule", "POST", "/biz_assign_rules/create", svc.CreateBizAssignRule)
+	h.Add("UpdateBizAssignRule", "PATCH", "/biz_assign_rules/{id}", svc.UpdateBizAssignRule)
+	h.Add("ListBizAssignRule", "POST", "/biz_assign_rules/list", svc.ListBizAssignRule)
+	h.Add("DeleteBizAssignRule", "DELETE", "/biz_assign_rules/batch", svc.DeleteBizAssignRule)
+	h.Add("ApplyBizAssignRule", "POST", "/biz_assign_rules/apply", svc.ApplyBizAssignRule)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao   dao.Set
+	audit *auditcloud.Audit
+}
+
+// CreateBizAssignRule create biz assign rule.
+func (svc *service) CreateBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(protobizassignrule.BizAssignRuleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if req.RuleType == enumor.VpcBizAssignRule {
+		if err := svc.checkVpcInAccount(cts.Kit, req.AccountID, req.VpcID); err != nil {
+			return nil, err
+		}
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	model := &tablebizassignrule.BizAssignRuleTable{
+		Name:      req.Name,
+		AccountID: req.AccountID,
+		ResTypes:  convResTypes(req.ResTypes),
+		RuleType:  req.RuleType,
+		TagKey:    req.TagKey,
+		TagValue:  &req.TagValue,
+		VpcID:     req.VpcID,
+		BkBizID:   req.BkBizID,
+		Priority:  &req.Priority,
+		Enabled:   &enabled,
+		Memo:      req.Memo,
+		Creator:   cts.Kit.User,
+		Reviser:   cts.Kit.User,
+	}
+	id, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.BizAssignRule().CreateWithTx(cts.Kit, txn, model)
+	})
+	if err != nil {
+		logs.Errorf("create biz assign rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	ruleID, ok := id.(string)
+	if !ok {
+		return nil, fmt.Errorf("create biz assign rule but return id type not string, id type: %v", id)
+	}
+
+	return &core.CreateResult{ID: ruleID}, nil
+}
+
+// checkVpcInAccount check if the vpc of the vpc rule belongs to the account of the rule.
+func (svc *service) checkVpcInAccount(kt *kit.Kit, accountID, vpcID string) error {
+	expr := tools.EqualWithOpExpression(filter.And, map[string]interface{}{"id": vpcID, "account_id": accountID})
+	ids, err := svc.dao.Cloud().ListResourceIDs(kt, enumor.VpcCloudResType, expr)
+	if err != nil {
+		logs.Errorf("list vpc failed, err: %v, id: %s, rid: %s", err, vpcID, kt.Rid)
+		return err
+	}
+
+	if len(ids) == 0 {
+		return errf.Newf(errf.InvalidParameter, "vpc %s not found in account %s", vpcID, accountID)
+	}
+
+	return nil
+}
+
+// UpdateBizAssignRule update biz assign rule.
+func (svc *service) UpdateBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(protobizassignrule.BizAssignRuleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablebizassignrule.BizAssignRuleTable{
+		Name:     req.Name,
+		TagValue: req.TagValue,
+		BkBizID:  req.BkBizID,
+		Priority: req.Priority,
+		Enabled:  req.Enabled,
+		Memo:     req.Memo,
+		Reviser:  cts.Kit.User,
+	}
+
+	// res types are validated by the rule type which can not be updated.
+	if len(req.ResTypes) != 0 {
+		rule, err := svc.getBizAssignRule(cts.Kit, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = corebizassignrule.ValidateRuleResTypes(rule.RuleType, req.ResTypes); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		model.ResTypes = convResTypes(req.ResTypes)
+	}
+
+	if err := svc.dao.BizAssignRule().Update(cts.Kit, tools.EqualExpression("id", id), model); err != nil {
+		logs.Errorf("update biz assign rule failed, id: %s, err: %v, rid: %s", id, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// getBizAssignRule get biz assign rule by id.
+func (svc *service) getBizAssignRule(kt *kit.Kit, id string) (*tablebizassignrule.BizAssignRuleTable, error) {
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.DefaultBasePage,
+	}
+	result, err := svc.dao.BizAssignRule().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list biz assign rule failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "biz assign rule %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
+
+// ListBizAssignRule list biz assign rule.
+func (svc *service) ListBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.BizAssignRule().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list biz assign rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list biz assign rule failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protobizassignrule.BizAssignRuleListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]corebizassignrule.BizAssignRule, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, convBizAssignRule(one))
+	}
+
+	return &protobizassignrule.BizAssignRuleListResult{Details: details}, nil
+}
+
+// DeleteBizAssignRule delete biz assign rule.
+func (svc *service) DeleteBizAssignRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(protobizassignrule.BizAssignRuleDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.BizAssignRule().DeleteWithTx(cts.Kit, txn, req.Filter)
+	})
+	if err != nil {
+		logs.Errorf("delete biz assign rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func convResTypes(resTypes []enumor.CloudResourceType) tabletype.StringArray {
+	result := make(tabletype.StringArray, 0, len(resTypes))
+	for _, resType := range resTypes {
+		result = append(result, string(resType))
+	}
+
+	return result
+}
+
+func convBizAssignRule(one tablebizassignrule.BizAssignRuleTable) corebizassignrule.BizAssignRule {
+	resTypes := make([]enumor.CloudResourceType, 0, len(one.ResTypes))
+	for _, resType := range one.ResTypes {
+		resTypes = append(resTypes, enumor.CloudResourceType(resType))
+	}
+
+	return corebizassignrule.BizAssignRule{
+		ID:        one.ID,
+		Name:      one.Name,
+		AccountID: one.AccountID,
+		ResTypes:  resTypes,
+		RuleType:  one.RuleType,
+		TagKey:    one.TagKey,
+		TagValue:  converter.PtrToVal(one.TagValue),
+		VpcID:     one.VpcID,
+		BkBizID:   one.BkBizID,
+		Priority:  converter.PtrToVal(one.Priority),
+		Enabled:   converter.PtrToVal(one.Enabled),
+		Memo:      one.Memo,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
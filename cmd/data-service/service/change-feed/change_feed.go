@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package changefeed defines the data-service api of resource change feed.
+package changefeed
+
+import (
+	"fmt"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corechangefeed "hcm/pkg/api/core/change-feed"
+	protochangefeed "hcm/pkg/api/data-service/change-feed"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the change feed service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListChangeFeed", "POST", "/change_feeds/list", svc.ListChangeFeed)
+	h.Add("DeleteChangeFeed", "DELETE", "/change_feeds/batch", svc.DeleteChangeFeed)
+
+	h.Load(cap.WebService)
+
+	go svc.sequence()
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// ListChangeFeed list change feed, changes can be pulled from a cursor by filtering the seq greater than the cursor
+// and sorting by seq in ascending order.
+func (svc *service) ListChangeFeed(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.ChangeFeed().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list change feed failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list change feed failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protochangefeed.ChangeFeedListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]corechangefeed.ChangeFeed, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		var seq uint64
+		if one.Seq != nil {
+			seq = *one.Seq
+		}
+
+		details = append(details, corechangefeed.ChangeFeed{
+			ID:        one.ID,
+			Seq:       seq,
+			ResType:   string(one.ResType),
+			ResID:     one.ResID,
+			AccountID: one.AccountID,
+			Action:    one.Action,
+			CreatedAt: one.CreatedAt.String(),
+		})
+	}
+
+	return &protochangefeed.ChangeFeedListResult{Details: details}, nil
+}
+
+// DeleteChangeFeed delete change feed, it's used to clean the expired changes.
+func (svc *service) DeleteChangeFeed(cts *rest.Contexts) (interface{}, error) {
+	req := new(protochangefeed.ChangeFeedDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	deleted, err := svc.dao.ChangeFeed().Delete(cts.Kit, req.Filter)
+	if err != nil {
+		logs.Errorf("delete change feed failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &protochangefeed.ChangeFeedDeleteResult{Deleted: deleted}, nil
+}
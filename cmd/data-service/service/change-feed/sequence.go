@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package changefeed
+
+import (
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+const (
+	// sequenceInterval is the interval to assign the commit sequences to the new committed change feeds, the changes
+	// can be watched after they are sequenced.
+	sequenceInterval = 500 * time.Millisecond
+	// sequenceBatch is the max count of change feeds that are sequenced in one transaction.
+	sequenceBatch = 500
+)
+
+// sequence assigns the commit sequences to the committed change feeds in order continuously, it can be run in all
+// the data-service instances, because the sequence counter is locked in the sequencing transaction.
+func (svc *service) sequence() {
+	for {
+		time.Sleep(sequenceInterval)
+
+		for {
+			kt := kit.New()
+			kt.User = constant.ChangeFeedSequencerUserKey
+			kt.AppCode = constant.ChangeFeedSequencerAppCodeKey
+
+			count, err := svc.dao.ChangeFeed().Sequence(kt, sequenceBatch)
+			if err != nil {
+				logs.Errorf("sequence change feed failed, err: %v, rid: %s", err, kt.Rid)
+				break
+			}
+
+			if count < sequenceBatch {
+				break
+			}
+		}
+	}
+}
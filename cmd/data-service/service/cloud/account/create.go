@@ -127,3 +127,101 @@ func createAccount[T protocloud.AccountExtensionCreateReq, PT protocloud.SecretE
 
 	return &core.CreateResult{ID: id}, nil
 }
+
+// BatchCreateAccount batch create accounts of the same vendor in one transaction.
+func (svc *service) BatchCreateAccount(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	switch vendor {
+	case enumor.TCloud:
+		return batchCreateAccount[protocloud.TCloudAccountExtensionCreateReq](vendor, svc, cts)
+	case enumor.Aws:
+		return batchCreateAccount[protocloud.AwsAccountExtensionCreateReq](vendor, svc, cts)
+	case enumor.HuaWei:
+		return batchCreateAccount[protocloud.HuaWeiAccountExtensionCreateReq](vendor, svc, cts)
+	case enumor.Gcp:
+		return batchCreateAccount[protocloud.GcpAccountExtensionCreateReq](vendor, svc, cts)
+	case enumor.Azure:
+		return batchCreateAccount[protocloud.AzureAccountExtensionCreateReq](vendor, svc, cts)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func batchCreateAccount[T protocloud.AccountExtensionCreateReq, PT protocloud.SecretEncryptor[T]](
+	vendor enumor.Vendor, svc *service, cts *rest.Contexts) (interface{}, error) {
+
+	req := new(protocloud.AccountBatchCreateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	accounts := make([]*tablecloud.AccountTable, 0, len(req.Accounts))
+	for _, one := range req.Accounts {
+		// 将参数里的SecretKey加密
+		p := PT(one.Extension)
+		p.EncryptSecretKey(svc.cipher)
+
+		extensionJson, err := json.MarshalToString(one.Extension)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		accounts = append(accounts, &tablecloud.AccountTable{
+			Vendor:    string(vendor),
+			Name:      one.Name,
+			Managers:  one.Managers,
+			Type:      string(one.Type),
+			Site:      string(one.Site),
+			Memo:      one.Memo,
+			Extension: tabletype.JsonField(extensionJson),
+			Creator:   cts.Kit.User,
+			Reviser:   cts.Kit.User,
+		})
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids := make([]string, 0, len(accounts))
+		rels := make([]*tablecloud.AccountBizRelTable, 0)
+		for index, account := range accounts {
+			accountID, err := svc.dao.Account().CreateWithTx(cts.Kit, txn, account)
+			if err != nil {
+				return nil, fmt.Errorf("create account %s failed, err: %v", account.Name, err)
+			}
+			ids = append(ids, accountID)
+
+			for _, bizID := range req.Accounts[index].BkBizIDs {
+				rels = append(rels, &tablecloud.AccountBizRelTable{
+					BkBizID:   bizID,
+					AccountID: accountID,
+					Creator:   cts.Kit.User,
+				})
+			}
+		}
+
+		if len(rels) != 0 {
+			if err := svc.dao.AccountBizRel().BatchCreateWithTx(cts.Kit, txn, rels); err != nil {
+				return nil, fmt.Errorf("batch create account_biz_rels failed, err: %v", err)
+			}
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create account but return ids type not []string, ids type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
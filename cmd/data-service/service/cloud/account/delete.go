@@ -89,6 +89,11 @@ func (svc *service) DeleteAccount(cts *rest.Contexts) (interface{}, error) {
 			return nil, err
 		}
 
+		delAccountHealthFilter := tools.ContainersExpression("account_id", delAccountIDs)
+		if err := svc.dao.AccountHealth().DeleteWithTx(cts.Kit, txn, delAccountHealthFilter); err != nil {
+			return nil, err
+		}
+
 		// create audit
 		audits := make([]*tableaudit.AuditTable, 0, len(accounts))
 		for _, one := range accounts {
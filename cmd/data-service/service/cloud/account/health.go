@@ -0,0 +1,211 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablecloud "hcm/pkg/dal/table/cloud"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// maxHealthErrorLength is the max rune length of the last error stored in account health.
+const maxHealthErrorLength = 1024
+
+// BatchRecordAccountHealth record account health check result, create the account health if not exists.
+func (svc *service) BatchRecordAccountHealth(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.AccountHealthBatchRecordReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	accountIDs := make([]string, 0, len(req.Records))
+	for _, one := range req.Records {
+		accountIDs = append(accountIDs, one.AccountID)
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("account_id", accountIDs),
+		Page:   core.DefaultBasePage,
+	}
+	listResp, err := svc.dao.AccountHealth().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list account health failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	existMap := make(map[string]tablecloud.AccountHealthTable, len(listResp.Details))
+	for _, one := range listResp.Details {
+		existMap[one.AccountID] = one
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Records {
+			model, err := convAccountHealthTable(one)
+			if err != nil {
+				return nil, errf.NewFromErr(errf.InvalidParameter, err)
+			}
+
+			exist, ok := existMap[one.AccountID]
+			if !ok {
+				model.Vendor = one.Vendor
+				model.AccountID = one.AccountID
+				model.Creator = cts.Kit.User
+				model.Reviser = cts.Kit.User
+				if _, err = svc.dao.AccountHealth().CreateWithTx(cts.Kit, txn, model); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			if len(model.LastErrorAt) != 0 {
+				model.ConsecutiveFailures = exist.ConsecutiveFailures + 1
+			}
+			model.Reviser = cts.Kit.User
+			if err = svc.dao.AccountHealth().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", exist.ID),
+				model); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("record account health failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// convAccountHealthTable convert account health check result to the fields need to be saved, account which is
+// unavailable only records the error, and the missing permissions detected last time are kept.
+func convAccountHealthTable(req protocloud.AccountHealthRecordReq) (*tablecloud.AccountHealthTable, error) {
+	model := &tablecloud.AccountHealthTable{
+		Status:      req.Status,
+		LastCheckAt: req.CheckAt,
+	}
+
+	if req.Status == enumor.UnavailableAccountStatus {
+		model.LastErrorAt = req.CheckAt
+		model.LastError = truncateRunes(req.Error, maxHealthErrorLength)
+		model.ConsecutiveFailures = 1
+		return model, nil
+	}
+
+	permissions := req.MissingPermissions
+	if permissions == nil {
+		permissions = make([]corecloud.AccountMissingPermission, 0)
+	}
+	missing, err := tabletype.NewJsonField(permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	model.LastSuccessAt = req.CheckAt
+	model.MissingPermissions = missing
+	return model, nil
+}
+
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+
+	return string(runes[:max])
+}
+
+// ListAccountHealth list account health.
+func (svc *service) ListAccountHealth(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.AccountHealth().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list account health failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list account health failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protocloud.AccountHealthListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]corecloud.AccountHealth, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		permissions := make([]corecloud.AccountMissingPermission, 0)
+		if len(one.MissingPermissions) != 0 {
+			if err = json.UnmarshalFromString(string(one.MissingPermissions), &permissions); err != nil {
+				logs.Errorf("unmarshal account missing permissions failed, id: %s, err: %v, rid: %s", one.ID, err,
+					cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, corecloud.AccountHealth{
+			ID:                  one.ID,
+			Vendor:              one.Vendor,
+			AccountID:           one.AccountID,
+			Status:              one.Status,
+			LastCheckAt:         one.LastCheckAt,
+			LastSuccessAt:       one.LastSuccessAt,
+			LastErrorAt:         one.LastErrorAt,
+			LastError:           one.LastError,
+			ConsecutiveFailures: one.ConsecutiveFailures,
+			MissingPermissions:  permissions,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protocloud.AccountHealthListResult{Details: details}, nil
+}
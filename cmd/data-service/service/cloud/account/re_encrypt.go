@@ -0,0 +1,295 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableapplication "hcm/pkg/dal/table/application"
+	tablecloud "hcm/pkg/dal/table/cloud"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+)
+
+// vendorSecretKeyField is the encrypted secret key field of account extension.
+var vendorSecretKeyField = map[enumor.Vendor]string{
+	enumor.TCloud: "cloud_secret_key",
+	enumor.Aws:    "cloud_secret_key",
+	enumor.HuaWei: "cloud_secret_key",
+	enumor.Gcp:    "cloud_service_secret_key",
+	enumor.Azure:  "cloud_client_secret_key",
+}
+
+// ReEncryptResult is the result of re-encrypting account secrets.
+type ReEncryptResult struct {
+	// Total is the count of accounts scanned.
+	Total uint64 `json:"total"`
+	// ReEncrypted is the count of accounts whose secret is re-encrypted, or need to be re-encrypted in dry run.
+	ReEncrypted uint64 `json:"re_encrypted"`
+	// FailedIDs is the ids of the accounts which failed to re-encrypt.
+	FailedIDs []string `json:"failed_ids"`
+	// ApplicationTotal is the count of unfinished applications scanned.
+	ApplicationTotal uint64 `json:"application_total"`
+	// ApplicationReEncrypted is the count of applications whose secret is re-encrypted, or need to be re-encrypted
+	// in dry run.
+	ApplicationReEncrypted uint64 `json:"application_re_encrypted"`
+	// FailedApplicationIDs is the ids of the applications which failed to re-encrypt.
+	FailedApplicationIDs []string `json:"failed_application_ids"`
+}
+
+// unfinishedAppStatuses is the statuses of the applications which are not delivered yet, their contents are still
+// decrypted when they are delivered.
+var unfinishedAppStatuses = []enumor.ApplicationStatus{enumor.Pending, enumor.Pass, enumor.Delivering}
+
+// cvmPasswordVendors is the vendors whose create cvm applications encrypt the password into the content.
+var cvmPasswordVendors = map[enumor.Vendor]struct{}{
+	enumor.TCloud: {},
+	enumor.Aws:    {},
+	enumor.HuaWei: {},
+	enumor.Azure:  {},
+}
+
+// ReEncryptSecret re-encrypt the account secrets which are not encrypted by the current key and algorithm, such
+// as the secrets encrypted by the legacy aes gcm key or a rotated kms key. the secrets encrypted into the contents
+// of the unfinished add account and create cvm applications are also re-encrypted, so that they can still be
+// delivered after the old key is retired. it only reports the secrets need to be re-encrypted when dry run is set.
+func ReEncryptSecret(kt *kit.Kit, daoSet dao.Set, cipher cryptography.Crypto, dryRun bool) (*ReEncryptResult,
+	error) {
+
+	rotatable, ok := cipher.(cryptography.Rotatable)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "crypto does not support key rotation, set crypto.kms first")
+	}
+
+	kt.User = constant.ReEncryptUserKey
+	result := &ReEncryptResult{FailedIDs: make([]string, 0), FailedApplicationIDs: make([]string, 0)}
+	opt := &types.ListOption{
+		Filter: tools.AllExpression(),
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "id"},
+	}
+
+	for {
+		list, err := daoSet.Account().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list account failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range list.Details {
+			result.Total++
+
+			reEncrypted, err := reEncryptAccountSecret(kt, daoSet, rotatable, one, dryRun)
+			if err != nil {
+				logs.Errorf("re-encrypt account %s secret failed, err: %v, rid: %s", one.ID, err, kt.Rid)
+				result.FailedIDs = append(result.FailedIDs, one.ID)
+				continue
+			}
+
+			if reEncrypted {
+				result.ReEncrypted++
+			}
+		}
+
+		if len(list.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		opt.Page.Cursor = core.NewIDPageCursor(list.Details[len(list.Details)-1].ID)
+	}
+
+	if err := reEncryptApplicationSecrets(kt, daoSet, rotatable, dryRun, result); err != nil {
+		return nil, err
+	}
+
+	logs.Infof("re-encrypt account secret done, dry run: %v, result: %+v, rid: %s", dryRun, result, kt.Rid)
+	return result, nil
+}
+
+func reEncryptAccountSecret(kt *kit.Kit, daoSet dao.Set, cipher cryptography.Rotatable,
+	account *tablecloud.AccountTable, dryRun bool) (bool, error) {
+
+	field, exists := vendorSecretKeyField[enumor.Vendor(account.Vendor)]
+	if !exists || len(account.Extension) == 0 {
+		return false, nil
+	}
+
+	extension := make(map[string]interface{})
+	if err := json.UnmarshalFromString(string(account.Extension), &extension); err != nil {
+		return false, fmt.Errorf("unmarshal extension failed, err: %v", err)
+	}
+
+	secretKey, _ := extension[field].(string)
+	if len(secretKey) == 0 || !cipher.NeedReEncrypt(secretKey) {
+		return false, nil
+	}
+
+	plaintext, err := cipher.DecryptFromBase64(secretKey)
+	if err != nil {
+		return false, fmt.Errorf("decrypt secret failed, err: %v", err)
+	}
+
+	if dryRun {
+		return true, nil
+	}
+
+	updated, err := json.UpdateMerge(map[string]interface{}{field: cipher.EncryptToBase64(plaintext)},
+		string(account.Extension))
+	if err != nil {
+		return false, fmt.Errorf("json UpdateMerge extension failed, err: %v", err)
+	}
+
+	model := &tablecloud.AccountTable{
+		Extension: tabletype.JsonField(updated),
+		Reviser:   kt.User,
+	}
+	if err = daoSet.Account().Update(kt, tools.EqualExpression("id", account.ID), model); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// reEncryptApplicationSecrets re-encrypt the secrets in the contents of the unfinished applications.
+func reEncryptApplicationSecrets(kt *kit.Kit, daoSet dao.Set, cipher cryptography.Rotatable, dryRun bool,
+	result *ReEncryptResult) error {
+
+	opt := &types.ListOption{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "type", Op: filter.In.Factory(),
+					Value: []enumor.ApplicationType{enumor.AddAccount, enumor.CreateCvm}},
+				filter.AtomRule{Field: "status", Op: filter.In.Factory(), Value: unfinishedAppStatuses},
+			},
+		},
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "id"},
+	}
+
+	for {
+		list, err := daoSet.Application().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list application failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+
+		for _, one := range list.Details {
+			result.ApplicationTotal++
+
+			reEncrypted, err := reEncryptApplicationSecret(kt, daoSet, cipher, one, dryRun)
+			if err != nil {
+				logs.Errorf("re-encrypt application %s secret failed, err: %v, rid: %s", one.ID, err, kt.Rid)
+				result.FailedApplicationIDs = append(result.FailedApplicationIDs, one.ID)
+				continue
+			}
+
+			if reEncrypted {
+				result.ApplicationReEncrypted++
+			}
+		}
+
+		if len(list.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		opt.Page.Cursor = core.NewIDPageCursor(list.Details[len(list.Details)-1].ID)
+	}
+
+	return nil
+}
+
+func reEncryptApplicationSecret(kt *kit.Kit, daoSet dao.Set, cipher cryptography.Rotatable,
+	app *tableapplication.ApplicationTable, dryRun bool) (bool, error) {
+
+	content := make(map[string]interface{})
+	if err := json.UnmarshalFromString(string(app.Content), &content); err != nil {
+		return false, fmt.Errorf("unmarshal content failed, err: %v", err)
+	}
+
+	// 申请单内容中加密的字段，新增账号为扩展字段中的密钥，创建主机为密码
+	vendor, _ := content["vendor"].(string)
+	secrets := content
+	fields := make([]string, 0)
+	switch enumor.ApplicationType(app.Type) {
+	case enumor.AddAccount:
+		extension, _ := content["extension"].(map[string]interface{})
+		if field, exists := vendorSecretKeyField[enumor.Vendor(vendor)]; exists && extension != nil {
+			secrets = extension
+			fields = append(fields, field)
+		}
+	case enumor.CreateCvm:
+		if _, exists := cvmPasswordVendors[enumor.Vendor(vendor)]; exists {
+			fields = append(fields, "password", "confirmed_password")
+		}
+	}
+
+	reEncrypted := false
+	for _, field := range fields {
+		secret, _ := secrets[field].(string)
+		if len(secret) == 0 || !cipher.NeedReEncrypt(secret) {
+			continue
+		}
+
+		plaintext, err := cipher.DecryptFromBase64(secret)
+		if err != nil {
+			return false, fmt.Errorf("decrypt %s failed, err: %v", field, err)
+		}
+
+		secrets[field] = cipher.EncryptToBase64(plaintext)
+		reEncrypted = true
+	}
+
+	if !reEncrypted || dryRun {
+		return reEncrypted, nil
+	}
+
+	updated, err := json.MarshalToString(content)
+	if err != nil {
+		return false, fmt.Errorf("marshal content failed, err: %v", err)
+	}
+
+	// 只更新仍未交付的申请单，避免覆盖期间已变更状态的申请单
+	expr := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			filter.AtomRule{Field: "id", Op: filter.Equal.Factory(), Value: app.ID},
+			filter.AtomRule{Field: "status", Op: filter.Equal.Factory(), Value: app.Status},
+		},
+	}
+	model := &tableapplication.ApplicationTable{
+		Content: tabletype.JsonField(updated),
+		Reviser: kt.User,
+	}
+	if err = daoSet.Application().Update(kt, expr, model); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
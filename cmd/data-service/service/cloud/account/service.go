@@ -36,6 +36,7 @@ func InitService(cap *capability.Capability) {
 	h := rest.NewHandler()
 
 	h.Add("CreateAccount", "POST", "/vendors/{vendor}/accounts/create", svc.CreateAccount)
+	h.Add("BatchCreateAccount", "POST", "/vendors/{vendor}/accounts/batch/create", svc.BatchCreateAccount)
 	h.Add("UpdateAccount", "PATCH", "/vendors/{vendor}/accounts/{account_id}", svc.UpdateAccount)
 	h.Add("GetAccount", "GET", "/vendors/{vendor}/accounts/{account_id}", svc.GetAccount)
 	h.Add("ListAccount", "POST", "/accounts/list", svc.ListAccount)
@@ -43,6 +44,9 @@ func InitService(cap *capability.Capability) {
 	h.Add("DeleteAccount", "DELETE", "/accounts", svc.DeleteAccount)
 	h.Add("DeleteValidate", "POST", "/accounts/{account_id}/delete/validate", svc.DeleteValidate)
 
+	h.Add("BatchRecordAccountHealth", "POST", "/accounts/health/batch/record", svc.BatchRecordAccountHealth)
+	h.Add("ListAccountHealth", "POST", "/accounts/health/list", svc.ListAccountHealth)
+
 	h.Load(cap.WebService)
 }
 
@@ -42,6 +42,10 @@ func InitBillConfigService(cap *capability.Capability) {
 	h.Add("BatchDeleteAccountBillConfig", "DELETE", "/bills/config/batch",
 		svc.BatchDeleteAccountBillConfig)
 
+	h.Add("BatchCreateCostAnomaly", "POST", "/bills/cost_anomalies/batch/create", svc.BatchCreateCostAnomaly)
+	h.Add("BatchUpdateCostAnomaly", "PATCH", "/bills/cost_anomalies/batch", svc.BatchUpdateCostAnomaly)
+	h.Add("ListCostAnomaly", "POST", "/bills/cost_anomalies/list", svc.ListCostAnomaly)
+
 	h.Load(cap.WebService)
 }
 
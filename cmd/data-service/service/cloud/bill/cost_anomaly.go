@@ -0,0 +1,189 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablebill "hcm/pkg/dal/table/cloud/bill"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateCostAnomaly batch create cost anomaly.
+func (svc *billConfigSvc) BatchCreateCostAnomaly(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.CostAnomalyBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	anomalyIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		anomalies := make([]tablebill.CostAnomalyTable, 0, len(req.Anomalies))
+		for _, one := range req.Anomalies {
+			topItems, err := tabletype.NewJsonField(one.TopItems)
+			if err != nil {
+				return nil, errf.NewFromErr(errf.InvalidParameter, err)
+			}
+
+			anomalies = append(anomalies, tablebill.CostAnomalyTable{
+				Vendor:         one.Vendor,
+				AccountID:      one.AccountID,
+				Dimension:      one.Dimension,
+				DimensionValue: one.DimensionValue,
+				BillDate:       one.BillDate,
+				Currency:       one.Currency,
+				ActualCost:     one.ActualCost,
+				ExpectedCost:   one.ExpectedCost,
+				Ratio:          one.Ratio,
+				Score:          one.Score,
+				TopItems:       topItems,
+				Status:         enumor.OpenCostAnomalyStatus,
+				Creator:        cts.Kit.User,
+				Reviser:        cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.CostAnomaly().BatchCreateWithTx(cts.Kit, txn, anomalies)
+		if err != nil {
+			return nil, fmt.Errorf("create cost anomaly failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := anomalyIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create cost anomaly but return id type is not string, id type: %v",
+			reflect.TypeOf(anomalyIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateCostAnomaly batch update cost anomaly status and memo.
+func (svc *billConfigSvc) BatchUpdateCostAnomaly(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.CostAnomalyBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	for _, one := range req.Anomalies {
+		anomaly := &tablebill.CostAnomalyTable{
+			Status:  one.Status,
+			Memo:    one.Memo,
+			Reviser: cts.Kit.User,
+		}
+
+		if err := svc.dao.CostAnomaly().Update(cts.Kit, tools.EqualExpression("id", one.ID), anomaly); err != nil {
+			logs.Errorf("update cost anomaly failed, id: %s, err: %v, rid: %s", one.ID, err, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// ListCostAnomaly list cost anomaly.
+func (svc *billConfigSvc) ListCostAnomaly(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.CostAnomaly().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list cost anomaly failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list cost anomaly failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dsbill.CostAnomalyListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]cloud.CostAnomaly, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		items := make([]cloud.CostAnomalyItem, 0)
+		if len(one.TopItems) != 0 {
+			if err = json.UnmarshalFromString(string(one.TopItems), &items); err != nil {
+				logs.Errorf("unmarshal cost anomaly top items failed, id: %s, err: %v, rid: %s", one.ID, err,
+					cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, cloud.CostAnomaly{
+			ID:             one.ID,
+			Vendor:         one.Vendor,
+			AccountID:      one.AccountID,
+			Dimension:      one.Dimension,
+			DimensionValue: one.DimensionValue,
+			BillDate:       one.BillDate,
+			Currency:       one.Currency,
+			ActualCost:     one.ActualCost,
+			ExpectedCost:   one.ExpectedCost,
+			Ratio:          one.Ratio,
+			Score:          one.Score,
+			TopItems:       items,
+			Status:         one.Status,
+			Memo:           one.Memo,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dsbill.CostAnomalyListResult{Details: details}, nil
+}
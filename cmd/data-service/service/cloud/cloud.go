@@ -26,6 +26,7 @@ import (
 	"hcm/cmd/data-service/service/audit/cloud"
 	"hcm/cmd/data-service/service/capability"
 	"hcm/cmd/data-service/service/cloud/cvm"
+	"hcm/pkg/api/core"
 	"hcm/pkg/api/data-service/audit"
 	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/criteria/constant"
@@ -56,6 +57,7 @@ func InitCloudService(cap *capability.Capability) {
 	h.Add("GetResourceBasicInfo", http.MethodPost, "/cloud/resources/bases/{type}/id/{id}", svc.GetResourceBasicInfo)
 	h.Add("ListResourceBasicInfo", http.MethodPost, "/cloud/resources/bases/list", svc.ListResourceBasicInfo)
 	h.Add("AssignResourceToBiz", http.MethodPost, "/cloud/resources/assign/bizs", svc.AssignResourceToBiz)
+	h.Add("AggregateResource", http.MethodPost, "/cloud/resources/aggregate/{type}", svc.AggregateResource)
 
 	h.Load(cap.WebService)
 }
@@ -133,7 +135,8 @@ func (svc cloudSvc) ListResourceBasicInfo(cts *rest.Contexts) (interface{}, erro
 	return result, nil
 }
 
-var assignResAuditTypeMap = map[enumor.CloudResourceType]enumor.AuditResourceType{
+// AssignResAuditTypeMap is the audit resource type of the cloud resource types that can be assigned to biz.
+var AssignResAuditTypeMap = map[enumor.CloudResourceType]enumor.AuditResourceType{
 	enumor.SecurityGroupCloudResType:    enumor.SecurityGroupAuditResType,
 	enumor.VpcCloudResType:              enumor.VpcCloudAuditResType,
 	enumor.SubnetCloudResType:           enumor.SubnetAuditResType,
@@ -165,7 +168,7 @@ func (svc cloudSvc) AssignResourceToBiz(cts *rest.Contexts) (interface{}, error)
 				hasCvmAssign = true
 			}
 
-			auditType, exists := assignResAuditTypeMap[resType]
+			auditType, exists := AssignResAuditTypeMap[resType]
 			if !exists {
 				return nil, errf.Newf(errf.InvalidParameter, "resource type %s cannot be assigned", resType)
 			}
@@ -234,3 +237,33 @@ func (svc cloudSvc) AssignResourceToBiz(cts *rest.Contexts) (interface{}, error)
 
 	return nil, nil
 }
+
+// AggregateResource aggregate cloud resources grouped by the specified fields.
+func (svc cloudSvc) AggregateResource(cts *rest.Contexts) (interface{}, error) {
+	resType := enumor.CloudResourceType(cts.PathParameter("type").String())
+	if len(resType) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "resource type is required")
+	}
+
+	req := new(core.AggregateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.AggregateOption{
+		Filter:  req.Filter,
+		GroupBy: req.GroupBy,
+		Metrics: req.Metrics,
+		Limit:   req.Limit,
+	}
+	details, err := svc.dao.Cloud().Aggregate(cts.Kit, resType, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.AggregateResult{Details: details}, nil
+}
@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/event"
+	protoevent "hcm/pkg/api/data-service/event"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	tableevent "hcm/pkg/dal/table/event"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// maxLastErrorLen is the max length of the last delivery error saved in dead letter.
+const maxLastErrorLen = 1024
+
+// CreateDeadLetter create event dead letter.
+func (svc *service) CreateDeadLetter(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoevent.DeadLetterCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	lastError := req.LastError
+	if len(lastError) > maxLastErrorLen {
+		lastError = lastError[:maxLastErrorLen]
+	}
+
+	model := &tableevent.DeadLetterTable{
+		SubscriptionID: req.SubscriptionID,
+		EventID:        req.EventID,
+		EventType:      req.EventType,
+		Attempts:       req.Attempts,
+		LastError:      lastError,
+	}
+	if err := svc.dao.EventDeadLetter().Create(cts.Kit, model); err != nil {
+		logs.Errorf("create event dead letter failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListDeadLetter list event dead letter.
+func (svc *service) ListDeadLetter(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.EventDeadLetter().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list event dead letter failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list event dead letter failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protoevent.DeadLetterListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]coreevent.DeadLetter, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, coreevent.DeadLetter{
+			ID:             one.ID,
+			SubscriptionID: one.SubscriptionID,
+			EventID:        one.EventID,
+			EventType:      one.EventType,
+			Attempts:       one.Attempts,
+			LastError:      one.LastError,
+			CreatedAt:      one.CreatedAt.String(),
+		})
+	}
+
+	return &protoevent.DeadLetterListResult{Details: details}, nil
+}
+
+// DeleteDeadLetter delete event dead letter.
+func (svc *service) DeleteDeadLetter(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoevent.DeadLetterDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.EventDeadLetter().Delete(cts.Kit, req.Filter); err != nil {
+		logs.Errorf("delete event dead letter failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
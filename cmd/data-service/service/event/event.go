@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/event"
+	protoevent "hcm/pkg/api/data-service/event"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// ListEvent list event, events can be pulled from a cursor by filtering the id greater than the cursor and sorting
+// by id in ascending order.
+func (svc *service) ListEvent(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.Event().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list event failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list event failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protoevent.EventListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]coreevent.Event, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, coreevent.Event{
+			ID:         one.ID,
+			Seq:        converter.PtrToVal(one.Seq),
+			Type:       one.Type,
+			ResType:    one.ResType,
+			Action:     one.Action,
+			ResID:      one.ResID,
+			CloudResID: one.CloudResID,
+			ResName:    one.ResName,
+			BkBizID:    one.BkBizID,
+			Vendor:     one.Vendor,
+			AccountID:  one.AccountID,
+			Operator:   one.Operator,
+			Rid:        one.Rid,
+			AppCode:    one.AppCode,
+			Detail:     json.RawMessage(one.Detail),
+			CreatedAt:  one.CreatedAt.String(),
+		})
+	}
+
+	return &protoevent.EventListResult{Details: details}, nil
+}
+
+// DeleteEvent delete event, it's used to clean the expired events.
+func (svc *service) DeleteEvent(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoevent.EventDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	deleted, err := svc.dao.Event().Delete(cts.Kit, req.Filter)
+	if err != nil {
+		logs.Errorf("delete event failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &protoevent.EventDeleteResult{Deleted: deleted}, nil
+}
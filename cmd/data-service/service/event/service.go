@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event defines the data-service api of resource lifecycle events and their webhook subscriptions.
+package event
+
+import (
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the event and event subscription service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListEvent", "POST", "/events/list", svc.ListEvent)
+	h.Add("DeleteEvent", "DELETE", "/events/batch", svc.DeleteEvent)
+
+	h.Add("CreateEventSubscription", "POST", "/event_subscriptions/create", svc.CreateSubscription)
+	h.Add("UpdateEventSubscription", "PATCH", "/event_subscriptions/{id}", svc.UpdateSubscription)
+	h.Add("ListEventSubscription", "POST", "/event_subscriptions/list", svc.ListSubscription)
+	h.Add("DeleteEventSubscription", "DELETE", "/event_subscriptions/batch", svc.DeleteSubscription)
+
+	h.Add("CreateEventDeadLetter", "POST", "/event_dead_letters/create", svc.CreateDeadLetter)
+	h.Add("ListEventDeadLetter", "POST", "/event_dead_letters/list", svc.ListDeadLetter)
+	h.Add("DeleteEventDeadLetter", "DELETE", "/event_dead_letters/batch", svc.DeleteDeadLetter)
+
+	h.Load(cap.WebService)
+
+	go svc.sequence()
+}
+
+type service struct {
+	dao dao.Set
+}
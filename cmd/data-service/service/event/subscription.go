@@ -0,0 +1,283 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreevent "hcm/pkg/api/core/event"
+	protoevent "hcm/pkg/api/data-service/event"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableevent "hcm/pkg/dal/table/event"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateSubscription create event subscription.
+func (svc *service) CreateSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoevent.SubscriptionCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	filterField, err := tabletype.NewJsonField(req.Filter)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	// subscribe from the latest event if the cursor is not specified.
+	lastEventID := req.LastEventID
+	if lastEventID == nil {
+		latest, err := svc.latestEventSeq(cts.Kit)
+		if err != nil {
+			return nil, err
+		}
+		lastEventID = &latest
+	}
+
+	model := &tableevent.SubscriptionTable{
+		Name:        req.Name,
+		Endpoint:    req.Endpoint,
+		Secret:      req.Secret,
+		Filter:      filterField,
+		Enabled:     &enabled,
+		LastEventID: lastEventID,
+		Memo:        req.Memo,
+		Creator:     cts.Kit.User,
+		Reviser:     cts.Kit.User,
+	}
+	id, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.EventSubscription().CreateWithTx(cts.Kit, txn, model)
+	})
+	if err != nil {
+		logs.Errorf("create event subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	subscriptionID, ok := id.(string)
+	if !ok {
+		return nil, fmt.Errorf("create event subscription but return id type not string, id type: %v", id)
+	}
+
+	return &core.CreateResult{ID: subscriptionID}, nil
+}
+
+// latestEventSeq returns the seq of the latest sequenced event, returns 0 if there is no event.
+func (svc *service) latestEventSeq(kt *kit.Kit) (uint64, error) {
+	opt := &types.ListOption{
+		Filter: &filter.Expression{
+			Op:    filter.And,
+			Rules: []filter.RuleFactory{filter.AtomRule{Field: "seq", Op: filter.GreaterThan.Factory(), Value: 0}},
+		},
+		Page:   &core.BasePage{Limit: 1, Sort: "seq", Order: core.Descending},
+		Fields: []string{"id", "seq"},
+	}
+	result, err := svc.dao.Event().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list latest event failed, err: %v, rid: %s", err, kt.Rid)
+		return 0, err
+	}
+
+	if len(result.Details) == 0 {
+		return 0, nil
+	}
+
+	return converter.PtrToVal(result.Details[0].Seq), nil
+}
+
+// UpdateSubscription update event subscription.
+func (svc *service) UpdateSubscription(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(protoevent.SubscriptionUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableevent.SubscriptionTable{
+		Endpoint:    req.Endpoint,
+		Secret:      req.Secret,
+		Enabled:     req.Enabled,
+		LastEventID: req.LastEventID,
+		Memo:        req.Memo,
+		Reviser:     cts.Kit.User,
+	}
+
+	if req.Filter != nil {
+		filterField, err := tabletype.NewJsonField(req.Filter)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		model.Filter = filterField
+	}
+
+	expr := tools.EqualExpression("id", id)
+	if req.PrevLastEventID != nil {
+		expr = tools.EqualWithOpExpression(filter.And, map[string]interface{}{"id": id,
+			"last_event_id": *req.PrevLastEventID})
+	}
+
+	if err := svc.dao.EventSubscription().Update(cts.Kit, expr, model); err != nil {
+		logs.Errorf("update event subscription failed, id: %s, err: %v, rid: %s", id, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListSubscription list event subscription.
+func (svc *service) ListSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.EventSubscription().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list event subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list event subscription failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protoevent.SubscriptionListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]protoevent.SubscriptionDetail, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		var subFilter *coreevent.SubscriptionFilter
+		if len(one.Filter) != 0 {
+			subFilter = new(coreevent.SubscriptionFilter)
+			if err = json.Unmarshal([]byte(one.Filter), subFilter); err != nil {
+				logs.Errorf("unmarshal event subscription %s filter failed, err: %v, rid: %s", one.ID, err,
+					cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, protoevent.SubscriptionDetail{
+			Subscription: coreevent.Subscription{
+				ID:          one.ID,
+				Name:        one.Name,
+				Endpoint:    one.Endpoint,
+				Filter:      subFilter,
+				Enabled:     converter.PtrToVal(one.Enabled),
+				LastEventID: converter.PtrToVal(one.LastEventID),
+				Memo:        one.Memo,
+				Revision: core.Revision{
+					Creator:   one.Creator,
+					Reviser:   one.Reviser,
+					CreatedAt: one.CreatedAt.String(),
+					UpdatedAt: one.UpdatedAt.String(),
+				},
+			},
+			Secret: one.Secret,
+		})
+	}
+
+	return &protoevent.SubscriptionListResult{Details: details}, nil
+}
+
+// DeleteSubscription delete event subscription and the dead letters of the subscriptions.
+func (svc *service) DeleteSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoevent.SubscriptionDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   core.DefaultBasePage,
+		Fields: []string{"id"},
+	}
+	listResp, err := svc.dao.EventSubscription().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list event subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(listResp.Details))
+	for _, one := range listResp.Details {
+		ids = append(ids, one.ID)
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.EventDeadLetter().DeleteWithTx(cts.Kit, txn,
+			tools.ContainersExpression("subscription_id", ids)); err != nil {
+			return nil, err
+		}
+
+		if err := svc.dao.EventSubscription().DeleteWithTx(cts.Kit, txn,
+			tools.ContainersExpression("id", ids)); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete event subscription failed, ids: %v, err: %v, rid: %s", ids, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,134 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package idempotency
+
+import (
+	"time"
+
+	protoidem "hcm/pkg/api/data-service/idempotency"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	tableidem "hcm/pkg/dal/table/idempotency"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// expiredAt returns the expired time after the duration, utc is used so that it's comparable as string.
+func expiredAt(sec uint) string {
+	return time.Now().Add(time.Duration(sec) * time.Second).UTC().Format(constant.TimeStdFormat)
+}
+
+// AcquireIdempotencyRecord save the idempotency key as processing if it's not used, otherwise return the existing
+// record of the key.
+func (svc *service) AcquireIdempotencyRecord(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoidem.RecordAcquireReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableidem.RecordTable{
+		Owner:          req.Owner,
+		IdempotencyKey: req.IdempotencyKey,
+		RequestHash:    req.RequestHash,
+		ExpiredAt:      expiredAt(req.TimeoutSec),
+		Creator:        cts.Kit.User,
+	}
+	acquired, existing, err := svc.dao.IdempotencyRecord().Acquire(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("acquire idempotency record failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if acquired {
+		return &protoidem.RecordAcquireResult{Acquired: true}, nil
+	}
+
+	record := &protoidem.Record{RequestHash: existing.RequestHash, State: existing.State}
+	if existing.Response != nil {
+		record.Response = *existing.Response
+	}
+
+	return &protoidem.RecordAcquireResult{Acquired: false, Record: record}, nil
+}
+
+// CompleteIdempotencyRecord save the response of the processing idempotency record.
+func (svc *service) CompleteIdempotencyRecord(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoidem.RecordCompleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableidem.RecordTable{
+		Owner:          req.Owner,
+		IdempotencyKey: req.IdempotencyKey,
+		RequestHash:    req.RequestHash,
+		Response:       &req.Response,
+		ExpiredAt:      expiredAt(req.RetentionSec),
+	}
+	if err := svc.dao.IdempotencyRecord().Complete(cts.Kit, model); err != nil {
+		logs.Errorf("complete idempotency record failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ReleaseIdempotencyRecord delete the processing idempotency record, so that the key can be used again.
+func (svc *service) ReleaseIdempotencyRecord(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoidem.RecordReleaseReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableidem.RecordTable{
+		Owner:          req.Owner,
+		IdempotencyKey: req.IdempotencyKey,
+		RequestHash:    req.RequestHash,
+	}
+	if err := svc.dao.IdempotencyRecord().Release(cts.Kit, model); err != nil {
+		logs.Errorf("release idempotency record failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// DeleteExpiredIdempotencyRecord delete all the expired idempotency records.
+func (svc *service) DeleteExpiredIdempotencyRecord(cts *rest.Contexts) (interface{}, error) {
+	count, err := svc.dao.IdempotencyRecord().DeleteExpired(cts.Kit)
+	if err != nil {
+		logs.Errorf("delete expired idempotency record failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &protoidem.RecordDeleteExpiredResult{Count: count}, nil
+}
@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idempotency defines the data-service api of the idempotency keys of mutating requests.
+package idempotency
+
+import (
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the idempotency record service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("AcquireIdempotencyRecord", "POST", "/idempotency_records/acquire", svc.AcquireIdempotencyRecord)
+	h.Add("CompleteIdempotencyRecord", "PATCH", "/idempotency_records/complete", svc.CompleteIdempotencyRecord)
+	h.Add("ReleaseIdempotencyRecord", "DELETE", "/idempotency_records/release", svc.ReleaseIdempotencyRecord)
+	h.Add("DeleteExpiredIdempotencyRecord", "DELETE", "/idempotency_records/expired",
+		svc.DeleteExpiredIdempotencyRecord)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
@@ -0,0 +1,70 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"fmt"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/migration"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/ctl/cmd"
+	sqlscripts "hcm/scripts/sql"
+)
+
+// NewMigrationRunner create the schema migration runner with the embedded migration scripts.
+func NewMigrationRunner(setting cc.DataServiceSetting) (*migration.Runner, error) {
+	db, err := dao.Connect(setting.Database.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &migration.Option{
+		BaselineVersion: setting.Migration.BaselineVersion,
+		LockTimeoutSec:  setting.Migration.LockTimeoutSec,
+	}
+	return migration.NewRunner(db, sqlscripts.Migrations, opt)
+}
+
+// migrate apply the pending schema migrations if migration is enabled.
+func (s *Service) migrate() error {
+	if !cc.DataService().Migration.Enable {
+		return nil
+	}
+
+	kt := kit.New()
+	result, err := s.migrator.Migrate(kt, false)
+	if err != nil {
+		return fmt.Errorf("migrate db schema failed, err: %v", err)
+	}
+
+	logs.Infof("migrate db schema success, current version: %d, baselined: %v, applied: %d, rid: %s",
+		result.CurrentVersion, result.Baselined, len(result.Migrations), kt.Rid)
+	return nil
+}
+
+// MigrateCmd returns the control tool command to apply the pending schema migrations.
+func (s *Service) MigrateCmd() cmd.Cmd {
+	return cmd.WithMigrate(func(kt *kit.Kit, dryRun bool) (interface{}, error) {
+		return s.migrator.Migrate(kt, dryRun)
+	})
+}
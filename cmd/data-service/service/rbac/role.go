@@ -0,0 +1,203 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	protorbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablerbac "hcm/pkg/dal/table/rbac"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateRole create rbac role.
+func (svc *service) CreateRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(protorbac.RoleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablerbac.RoleTable{
+		Name:    req.Name,
+		Actions: req.Actions,
+		Builtin: converter.ValToPtr(req.Builtin),
+		Memo:    req.Memo,
+		Creator: cts.Kit.User,
+		Reviser: cts.Kit.User,
+	}
+	id, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.RbacRole().CreateWithTx(cts.Kit, txn, model)
+	})
+	if err != nil {
+		logs.Errorf("create rbac role failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	roleID, ok := id.(string)
+	if !ok {
+		return nil, fmt.Errorf("create rbac role but return id type not string, id type: %v", id)
+	}
+
+	return &core.CreateResult{ID: roleID}, nil
+}
+
+// UpdateRole update rbac role.
+func (svc *service) UpdateRole(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(protorbac.RoleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablerbac.RoleTable{
+		Name:    req.Name,
+		Actions: req.Actions,
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+	if err := svc.dao.RbacRole().Update(cts.Kit, tools.EqualExpression("id", id), model); err != nil {
+		logs.Errorf("update rbac role failed, id: %s, err: %v, rid: %s", id, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListRole list rbac role.
+func (svc *service) ListRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.RbacRole().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list rbac role failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list rbac role failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protorbac.RoleListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]corerbac.Role, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, corerbac.Role{
+			ID:      one.ID,
+			Name:    one.Name,
+			Actions: one.Actions,
+			Builtin: converter.PtrToVal(one.Builtin),
+			Memo:    one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protorbac.RoleListResult{Details: details}, nil
+}
+
+// DeleteRole delete rbac role and the bindings of the roles, builtin role can not be deleted.
+func (svc *service) DeleteRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(protorbac.RoleDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   core.DefaultBasePage,
+		Fields: []string{"id", "name", "builtin"},
+	}
+	listResp, err := svc.dao.RbacRole().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list rbac role failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(listResp.Details))
+	for _, one := range listResp.Details {
+		if converter.PtrToVal(one.Builtin) {
+			return nil, errf.Newf(errf.InvalidParameter, "builtin role %s can not be deleted", one.Name)
+		}
+		ids = append(ids, one.ID)
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.RbacRoleBinding().DeleteWithTx(cts.Kit, txn,
+			tools.ContainersExpression("role_id", ids)); err != nil {
+			return nil, err
+		}
+
+		if err := svc.dao.RbacRole().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", ids)); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete rbac role failed, ids: %v, err: %v, rid: %s", ids, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
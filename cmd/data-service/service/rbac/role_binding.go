@@ -0,0 +1,167 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	protorbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablerbac "hcm/pkg/dal/table/rbac"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateRoleBinding batch create rbac role binding.
+func (svc *service) BatchCreateRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(protorbac.RoleBindingBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	roleIDs := make([]string, 0, len(req.Bindings))
+	for _, one := range req.Bindings {
+		roleIDs = append(roleIDs, one.RoleID)
+	}
+	roleIDs = slice.Unique(roleIDs)
+
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", roleIDs),
+		Page:   &core.BasePage{Count: true},
+	}
+	listResp, err := svc.dao.RbacRole().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("count rbac role failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if int(listResp.Count) != len(roleIDs) {
+		return nil, errf.Newf(errf.InvalidParameter, "rbac roles %v not all exist", roleIDs)
+	}
+
+	models := make([]tablerbac.RoleBindingTable, 0, len(req.Bindings))
+	for _, one := range req.Bindings {
+		scopeIDs := one.ScopeIDs
+		if one.ScopeType == enumor.AllRbacScope {
+			scopeIDs = make([]string, 0)
+		}
+
+		models = append(models, tablerbac.RoleBindingTable{
+			RoleID:    one.RoleID,
+			Subject:   one.Subject,
+			ScopeType: one.ScopeType,
+			ScopeIDs:  tabletype.StringArray(scopeIDs),
+			Creator:   cts.Kit.User,
+		})
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.RbacRoleBinding().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("batch create rbac role binding failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	bindingIDs, ok := ids.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create rbac role binding but return ids type not []string, ids type: %v", ids)
+	}
+
+	return &core.BatchCreateResult{IDs: bindingIDs}, nil
+}
+
+// ListRoleBinding list rbac role binding.
+func (svc *service) ListRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.RbacRoleBinding().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list rbac role binding failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list rbac role binding failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protorbac.RoleBindingListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]corerbac.RoleBinding, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, corerbac.RoleBinding{
+			ID:        one.ID,
+			RoleID:    one.RoleID,
+			Subject:   one.Subject,
+			ScopeType: one.ScopeType,
+			ScopeIDs:  one.ScopeIDs,
+			Creator:   one.Creator,
+			CreatedAt: one.CreatedAt.String(),
+		})
+	}
+
+	return &protorbac.RoleBindingListResult{Details: details}, nil
+}
+
+// DeleteRoleBinding delete rbac role binding.
+func (svc *service) DeleteRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(protorbac.RoleBindingDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.RbacRoleBinding().DeleteWithTx(cts.Kit, txn, req.Filter)
+	})
+	if err != nil {
+		logs.Errorf("delete rbac role binding failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,298 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcehistory defines the data-service api of resource change history.
+package resourcehistory
+
+import (
+	"fmt"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corehistory "hcm/pkg/api/core/resource-history"
+	protohistory "hcm/pkg/api/data-service/resource-history"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablehistory "hcm/pkg/dal/table/resource-history"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitService initial the resource history service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListResourceHistory", "POST", "/resource_histories/{res_type}/{res_id}/list", svc.ListResourceHistory)
+	h.Add("GetResourceState", "POST", "/resource_histories/{res_type}/{res_id}/state", svc.GetResourceState)
+	h.Add("DeleteResourceHistory", "DELETE", "/resource_histories/batch", svc.DeleteResourceHistory)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// ListResourceHistory list the change histories of a resource in the change order with the field level diffs, the
+// diff of a change is compared between its snapshot and the snapshot of the next change, or the current state of
+// the resource if it's the latest change.
+func (svc *service) ListResourceHistory(cts *rest.Contexts) (interface{}, error) {
+	resType, resID, err := parseResPath(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(protohistory.ResourceHistoryListReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rules := resRules(resType, resID)
+	if len(req.StartTime) != 0 {
+		rules = append(rules, filter.AtomRule{Field: "created_at", Op: filter.GreaterThanEqual.Factory(),
+			Value: req.StartTime})
+	}
+	if len(req.EndTime) != 0 {
+		rules = append(rules, filter.AtomRule{Field: "created_at", Op: filter.LessThanEqual.Factory(),
+			Value: req.EndTime})
+	}
+
+	opt := &types.ListOption{
+		Filter: &filter.Expression{Op: filter.And, Rules: rules},
+		Page: &core.BasePage{Count: req.Page.Count, Start: req.Page.Start, Limit: req.Page.Limit, Sort: "id",
+			Order: core.Ascending},
+	}
+	daoResp, err := svc.dao.ResourceHistory().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list %s %s history failed, err: %v, rid: %s", resType, resID, err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list resource history failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protohistory.ResourceHistoryListResult{Count: daoResp.Count}, nil
+	}
+
+	if len(daoResp.Details) == 0 {
+		return &protohistory.ResourceHistoryListResult{Details: make([]corehistory.ResourceHistory, 0)}, nil
+	}
+
+	// the state after the last change of this page is the snapshot of the next change or the current state.
+	last := daoResp.Details[len(daoResp.Details)-1]
+	lastAfter, err := svc.stateAfter(cts.Kit, last)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]corehistory.ResourceHistory, len(daoResp.Details))
+	after := lastAfter
+	for idx := len(daoResp.Details) - 1; idx >= 0; idx-- {
+		one := daoResp.Details[idx]
+
+		before, err := parseSnapshot(one)
+		if err != nil {
+			logs.Errorf("parse %s %s history %d snapshot failed, err: %v, rid: %s", resType, resID, one.ID, err,
+				cts.Kit.Rid)
+			return nil, err
+		}
+
+		if one.Action == enumor.ChangeFeedDelete {
+			after = nil
+		}
+
+		details[idx] = corehistory.ResourceHistory{
+			ID:        one.ID,
+			ResType:   string(one.ResType),
+			ResID:     one.ResID,
+			AccountID: one.AccountID,
+			Action:    one.Action,
+			Operator:  one.Operator,
+			Rid:       one.Rid,
+			CreatedAt: one.CreatedAt.String(),
+			Changes:   corehistory.Diff(before, after),
+		}
+		after = before
+	}
+
+	return &protohistory.ResourceHistoryListResult{Details: details}, nil
+}
+
+// stateAfter returns the state of the resource after the change of the history.
+func (svc *service) stateAfter(kt *kit.Kit, history tablehistory.ResourceHistoryTable) (map[string]interface{},
+	error) {
+
+	if history.Action == enumor.ChangeFeedDelete {
+		return nil, nil
+	}
+
+	rules := append(resRules(history.ResType, history.ResID),
+		filter.AtomRule{Field: "id", Op: filter.GreaterThan.Factory(), Value: history.ID})
+	next, err := svc.firstHistory(kt, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != nil {
+		return parseSnapshot(*next)
+	}
+
+	return svc.currentState(kt, history.ResType, history.ResID)
+}
+
+// GetResourceState get the state of a resource at the specified time, it's the snapshot of the first change after
+// the time, or the current state of the resource if there is no change after the time.
+func (svc *service) GetResourceState(cts *rest.Contexts) (interface{}, error) {
+	resType, resID, err := parseResPath(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(protohistory.ResourceStateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rules := append(resRules(resType, resID),
+		filter.AtomRule{Field: "created_at", Op: filter.GreaterThan.Factory(), Value: req.Time})
+	next, err := svc.firstHistory(cts.Kit, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	var state map[string]interface{}
+	if next != nil {
+		if state, err = parseSnapshot(*next); err != nil {
+			return nil, err
+		}
+	} else {
+		if state, err = svc.currentState(cts.Kit, resType, resID); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &corehistory.ResourceState{Exist: state != nil, State: state}
+	if next != nil {
+		result.AccountID = next.AccountID
+	} else if accountID, ok := state["account_id"].(string); ok {
+		result.AccountID = accountID
+	}
+
+	return result, nil
+}
+
+// firstHistory returns the first history matched by the rules in the change order, returns nil if not found.
+func (svc *service) firstHistory(kt *kit.Kit, rules []filter.RuleFactory) (*tablehistory.ResourceHistoryTable,
+	error) {
+
+	opt := &types.ListOption{
+		Filter: &filter.Expression{Op: filter.And, Rules: rules},
+		Page:   &core.BasePage{Start: 0, Limit: 1, Sort: "id", Order: core.Ascending},
+	}
+	daoResp, err := svc.dao.ResourceHistory().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list first resource history failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if len(daoResp.Details) == 0 {
+		return nil, nil
+	}
+
+	return &daoResp.Details[0], nil
+}
+
+// currentState returns the current state of the resource, returns nil if it does not exist.
+func (svc *service) currentState(kt *kit.Kit, resType table.Name, resID string) (map[string]interface{}, error) {
+	snapshot, err := svc.dao.ResourceHistory().GetSnapshot(kt, resType, resID)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	return corehistory.ParseSnapshot(string(*snapshot))
+}
+
+// DeleteResourceHistory delete resource history, it's used to clean the expired histories.
+func (svc *service) DeleteResourceHistory(cts *rest.Contexts) (interface{}, error) {
+	req := new(protohistory.ResourceHistoryDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	deleted, err := svc.dao.ResourceHistory().Delete(cts.Kit, req.Filter)
+	if err != nil {
+		logs.Errorf("delete resource history failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &protohistory.ResourceHistoryDeleteResult{Deleted: deleted}, nil
+}
+
+func parseResPath(cts *rest.Contexts) (table.Name, string, error) {
+	resType := table.Name(cts.PathParameter("res_type").String())
+	if err := tablehistory.ValidateResType(resType); err != nil {
+		return "", "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resID := cts.PathParameter("res_id").String()
+	if len(resID) == 0 {
+		return "", "", errf.New(errf.InvalidParameter, "res_id is required")
+	}
+
+	return resType, resID, nil
+}
+
+func resRules(resType table.Name, resID string) []filter.RuleFactory {
+	return []filter.RuleFactory{
+		filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: resType},
+		filter.AtomRule{Field: "res_id", Op: filter.Equal.Factory(), Value: resID},
+	}
+}
+
+// parseSnapshot parse the snapshot of the history, which is the state before the change.
+func parseSnapshot(history tablehistory.ResourceHistoryTable) (map[string]interface{}, error) {
+	if history.Snapshot == nil {
+		return nil, nil
+	}
+
+	return corehistory.ParseSnapshot(string(*history.Snapshot))
+}
@@ -0,0 +1,150 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines the data-service api of resource tag.
+package resourcetag
+
+import (
+	"fmt"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
+	protoresourcetag "hcm/pkg/api/data-service/resource-tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableresourcetag "hcm/pkg/dal/table/resource-tag"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the resource tag service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListResourceTag", "POST", "/resource_tags/list", svc.ListResourceTag)
+	h.Add("ReplaceResourceTag", "PUT", "/resource_tags/replace", svc.ReplaceResourceTag)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// ListResourceTag list resource tag.
+func (svc *service) ListResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.ResourceTag().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list resource tag failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list resource tag failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protoresourcetag.ResourceTagListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]coreresourcetag.ResourceTag, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, coreresourcetag.ResourceTag{
+			ID:        one.ID,
+			ResType:   string(one.ResType),
+			ResID:     one.ResID,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			TagKey:    one.TagKey,
+			TagValue:  one.TagValue,
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		})
+	}
+
+	return &protoresourcetag.ResourceTagListResult{Details: details}, nil
+}
+
+// ReplaceResourceTag replace all the tags of the resources.
+func (svc *service) ReplaceResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoresourcetag.ResourceTagReplaceReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resType := table.Name(req.ResType)
+	if err := tableresourcetag.ValidateResType(resType); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resIDs := make([]string, 0, len(req.Resources))
+	models := make([]tableresourcetag.ResourceTagTable, 0)
+	for _, one := range req.Resources {
+		resIDs = append(resIDs, one.ResID)
+
+		for _, tag := range one.Tags {
+			models = append(models, tableresourcetag.ResourceTagTable{
+				ResType:   resType,
+				ResID:     one.ResID,
+				Vendor:    one.Vendor,
+				AccountID: one.AccountID,
+				TagKey:    tag.Key,
+				TagValue:  tag.Value,
+				Creator:   cts.Kit.User,
+				Reviser:   cts.Kit.User,
+			})
+		}
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.ResourceTag().ReplaceWithTx(cts.Kit, txn, resType, resIDs, models)
+	})
+	if err != nil {
+		logs.Errorf("replace %s resource tag failed, err: %v, rid: %s", resType, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
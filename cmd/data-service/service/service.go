@@ -30,7 +30,9 @@ import (
 	"hcm/cmd/data-service/service/application"
 	"hcm/cmd/data-service/service/audit"
 	"hcm/cmd/data-service/service/auth"
+	bizassignrule "hcm/cmd/data-service/service/biz-assign-rule"
 	"hcm/cmd/data-service/service/capability"
+	changefeed "hcm/cmd/data-service/service/change-feed"
 	"hcm/cmd/data-service/service/cloud"
 	"hcm/cmd/data-service/service/cloud/account"
 	accountbizrel "hcm/cmd/data-service/service/cloud/account-biz-rel"
@@ -48,15 +50,25 @@ import (
 	routetable "hcm/cmd/data-service/service/cloud/route-table"
 	sgcvmrel "hcm/cmd/data-service/service/cloud/security-group-cvm-rel"
 	"hcm/cmd/data-service/service/cloud/zone"
+	"hcm/cmd/data-service/service/event"
+	"hcm/cmd/data-service/service/idempotency"
+	"hcm/cmd/data-service/service/rbac"
 	recyclerecord "hcm/cmd/data-service/service/recycle-record"
+	resourcehistory "hcm/cmd/data-service/service/resource-history"
+	resourcetag "hcm/cmd/data-service/service/resource-tag"
+	tagpolicy "hcm/cmd/data-service/service/tag-policy"
+	"hcm/cmd/data-service/service/token"
 	"hcm/pkg/cc"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/cryptography"
 	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/migration"
 	"hcm/pkg/handler"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/metrics"
 	"hcm/pkg/rest"
+	"hcm/pkg/runtime/ctl/cmd"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/thirdparty/esb"
 	"hcm/pkg/tools/ssl"
@@ -70,10 +82,16 @@ type Service struct {
 	dao       dao.Set
 	cipher    cryptography.Crypto
 	esbClient esb.Client
+	migrator  *migration.Runner
 }
 
 // NewService create a service instance.
 func NewService() (*Service, error) {
+	migrator, err := NewMigrationRunner(cc.DataService())
+	if err != nil {
+		return nil, err
+	}
+
 	dao, err := dao.NewDaoSet(cc.DataService().Database)
 	if err != nil {
 		return nil, err
@@ -96,16 +114,27 @@ func NewService() (*Service, error) {
 		dao:       dao,
 		cipher:    cipher,
 		esbClient: esbClient,
+		migrator:  migrator,
+	}
+
+	// apply the pending schema migrations before serving.
+	if err = svr.migrate(); err != nil {
+		return nil, err
 	}
 
 	return svr, nil
 }
 
+// ReEncryptAccountSecretCmd returns the control tool command to re-encrypt account secrets by the current key.
+func (s *Service) ReEncryptAccountSecretCmd() cmd.Cmd {
+	return cmd.WithReEncryptAccountSecret(func(kt *kit.Kit, dryRun bool) (interface{}, error) {
+		return account.ReEncryptSecret(kt, s.dao, s.cipher, dryRun)
+	})
+}
+
 // newCipherFromConfig 根据配置文件里的加密配置，选择配置的算法并生成对应的加解密器
 func newCipherFromConfig(cryptoConfig cc.Crypto) (cryptography.Crypto, error) {
-	// TODO: 目前只支持国际加密，还未支持中国国家商业加密，待后续支持再调整
-	cfg := cryptoConfig.AesGcm
-	return cryptography.NewAESGcm([]byte(cfg.Key), []byte(cfg.Nonce))
+	return cryptography.NewCrypto(cryptoConfig.CryptoOption())
 }
 
 // ListenAndServeRest listen and serve the restful server
@@ -191,6 +220,15 @@ func (s *Service) apiSet() *restful.Container {
 	resourcegroup.InitAzureResourceGroupService(capability)
 	region.InitAzureRegionService(capability)
 	audit.InitAuditService(capability)
+	rbac.InitService(capability)
+	token.InitService(capability)
+	idempotency.InitService(capability)
+	event.InitService(capability)
+	changefeed.InitService(capability)
+	resourcehistory.InitService(capability)
+	resourcetag.InitService(capability)
+	bizassignrule.InitService(capability)
+	tagpolicy.InitService(capability)
 	eip.InitEipService(capability)
 	zone.InitZoneService(capability)
 	image.InitService(capability)
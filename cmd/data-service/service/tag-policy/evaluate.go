@@ -0,0 +1,270 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tagpolicy
+
+import (
+	"hcm/pkg/api/core"
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
+	coretagpolicy "hcm/pkg/api/core/tag-policy"
+	prototagpolicy "hcm/pkg/api/data-service/tag-policy"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tabletagpolicy "hcm/pkg/dal/table/tag-policy"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// evaluateBatchSize is the count of resources whose tags are evaluated in one batch.
+const evaluateBatchSize = 100
+
+// evaluatePolicy is the enabled tag policy to evaluate with its compiled checker.
+type evaluatePolicy struct {
+	policy  *coretagpolicy.TagPolicy
+	checker *coretagpolicy.Checker
+}
+
+// EvaluateTagPolicy evaluate the tags of all the resources of the account against the enabled tag policies of the
+// account and the bizs that the resources belong to, the former violations of the account are replaced by the result.
+func (svc *service) EvaluateTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(prototagpolicy.TagPolicyEvaluateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	policies, err := svc.listEvaluatePolicies(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(coretagpolicy.EvaluateResult)
+	violations := make([]tabletagpolicy.ViolationTable, 0)
+	for _, resType := range coretagpolicy.PolicyResTypes {
+		if !isResTypeEvaluated(policies, resType) {
+			continue
+		}
+
+		ids, err := svc.dao.Cloud().ListResourceIDs(cts.Kit, resType, tools.EqualExpression("account_id",
+			req.AccountID))
+		if err != nil {
+			logs.Errorf("list account %s %s failed, err: %v, rid: %s", req.AccountID, resType, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, batch := range slice.Split(ids, evaluateBatchSize) {
+			resViolations, resCount, violatedCount, err := svc.evaluateResources(cts.Kit, policies, resType, batch)
+			if err != nil {
+				return nil, err
+			}
+
+			result.ResCount += resCount
+			result.ViolatedResCount += violatedCount
+			violations = append(violations, resViolations...)
+		}
+	}
+	result.ViolationCount = uint64(len(violations))
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.TagPolicyViolation().ReplaceWithTx(cts.Kit, txn, req.AccountID, violations)
+	})
+	if err != nil {
+		logs.Errorf("replace account %s tag policy violations failed, err: %v, rid: %s", req.AccountID, err,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// listEvaluatePolicies list the enabled policies of the account and all the enabled biz policies, the biz policies
+// are matched with the resource's biz when evaluating.
+func (svc *service) listEvaluatePolicies(kt *kit.Kit, accountID string) ([]evaluatePolicy, error) {
+	expr := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			filter.AtomRule{Field: "enabled", Op: filter.Equal.Factory(), Value: true},
+			&filter.Expression{
+				Op: filter.Or,
+				Rules: []filter.RuleFactory{
+					filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+					filter.AtomRule{Field: "bk_biz_id", Op: filter.GreaterThan.Factory(), Value: 0},
+				},
+			},
+		},
+	}
+
+	policies := make([]evaluatePolicy, 0)
+	page := &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit}
+	for {
+		result, err := svc.dao.TagPolicy().List(kt, &types.ListOption{Filter: expr, Page: page})
+		if err != nil {
+			logs.Errorf("list tag policy failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			policy, err := convTagPolicy(one)
+			if err != nil {
+				logs.Errorf("convert tag policy %s failed, err: %v, rid: %s", one.ID, err, kt.Rid)
+				return nil, err
+			}
+
+			checker, err := coretagpolicy.NewChecker(policy.Rules)
+			if err != nil {
+				logs.Errorf("tag policy %s rules are invalid, err: %v, rid: %s", one.ID, err, kt.Rid)
+				return nil, err
+			}
+
+			policies = append(policies, evaluatePolicy{policy: policy, checker: checker})
+		}
+
+		if len(result.Details) < int(page.Limit) {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+
+	return policies, nil
+}
+
+// isResTypeEvaluated check if the resource type is evaluated by any of the policies.
+func isResTypeEvaluated(policies []evaluatePolicy, resType enumor.CloudResourceType) bool {
+	for _, one := range policies {
+		if slice.IsItemInSlice(one.policy.ResTypes, resType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// evaluateResources evaluate the tags of the resources against the policies that apply to them, returns the
+// violations, the count of the evaluated resources and the count of the violated resources. the resources whose
+// tags are not synced from cloud are skipped, they can never comply with the policies.
+func (svc *service) evaluateResources(kt *kit.Kit, policies []evaluatePolicy, resType enumor.CloudResourceType,
+	ids []string) ([]tabletagpolicy.ViolationTable, uint64, uint64, error) {
+
+	infos, err := svc.dao.Cloud().ListResourceBasicInfo(kt, resType, ids, types.CommonBasicInfoFields...)
+	if err != nil {
+		logs.Errorf("list %s basic info failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+		return nil, 0, 0, err
+	}
+
+	tagMap, err := svc.listResourceTags(kt, resType, ids)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	violations := make([]tabletagpolicy.ViolationTable, 0)
+	resCount, violatedCount := uint64(0), uint64(0)
+	for _, info := range infos {
+		if !coretagpolicy.IsResTypeTagged(info.Vendor, resType) {
+			continue
+		}
+		resCount++
+
+		violated := false
+		for _, one := range policies {
+			if !slice.IsItemInSlice(one.policy.ResTypes, resType) {
+				continue
+			}
+
+			// biz policy only applies to the resources of the biz.
+			if one.policy.BkBizID > 0 && one.policy.BkBizID != info.BkBizID {
+				continue
+			}
+
+			for _, violation := range one.checker.Check(tagMap[info.ID]) {
+				violated = true
+				violations = append(violations, tabletagpolicy.ViolationTable{
+					PolicyID:  one.policy.ID,
+					ResType:   resType,
+					ResID:     info.ID,
+					Vendor:    info.Vendor,
+					AccountID: info.AccountID,
+					BkBizID:   info.BkBizID,
+					TagKey:    violation.TagKey,
+					Reason:    violation.Reason,
+					Detail:    violation.Detail,
+					Creator:   kt.User,
+					Reviser:   kt.User,
+				})
+			}
+		}
+
+		if violated {
+			violatedCount++
+		}
+	}
+
+	return violations, resCount, violatedCount, nil
+}
+
+// listResourceTags list the tags of the resources from the resource tag store, returns the map of resource id to
+// its tags.
+func (svc *service) listResourceTags(kt *kit.Kit, resType enumor.CloudResourceType, ids []string) (
+	map[string][]coreresourcetag.Tag, error) {
+
+	tableName, err := resType.ConvTableName()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, err := tools.And(
+		filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: tableName},
+		filter.AtomRule{Field: "res_id", Op: filter.In.Factory(), Value: ids},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tagMap := make(map[string][]coreresourcetag.Tag, len(ids))
+	page := &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit}
+	for {
+		opt := &types.ListOption{Filter: expr, Page: page, Fields: []string{"res_id", "tag_key", "tag_value"}}
+		result, err := svc.dao.ResourceTag().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list %s tags failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			tagMap[one.ResID] = append(tagMap[one.ResID], coreresourcetag.Tag{Key: one.TagKey, Value: one.TagValue})
+		}
+
+		if len(result.Details) < int(page.Limit) {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+
+	return tagMap, nil
+}
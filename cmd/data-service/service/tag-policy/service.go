@@ -0,0 +1,313 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy defines the data-service api of tag policy, and evaluates the resources' tags against the
+// policies to record the violations.
+package tagpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coretagpolicy "hcm/pkg/api/core/tag-policy"
+	prototagpolicy "hcm/pkg/api/data-service/tag-policy"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tabletagpolicy "hcm/pkg/dal/table/tag-policy"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the tag policy service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateTagPolicy", "POST", "/tag_policies/create", svc.CreateTagPolicy)
+	h.Add("UpdateTagPolicy", "PATCH", "/tag_policies/{id}", svc.UpdateTagPolicy)
+	h.Add("ListTagPolicy", "POST", "/tag_policies/list", svc.ListTagPolicy)
+	h.Add("DeleteTagPolicy", "DELETE", "/tag_policies/batch", svc.DeleteTagPolicy)
+	h.Add("EvaluateTagPolicy", "POST", "/tag_policies/evaluate", svc.EvaluateTagPolicy)
+	h.Add("ListTagPolicyViolation", "POST", "/tag_policies/violations/list", svc.ListTagPolicyViolation)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// CreateTagPolicy create tag policy.
+func (svc *service) CreateTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(prototagpolicy.TagPolicyCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rules, err := tabletype.NewJsonField(req.Rules)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	autoRemediate := false
+	if req.AutoRemediate != nil {
+		autoRemediate = *req.AutoRemediate
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	model := &tabletagpolicy.TagPolicyTable{
+		Name:          req.Name,
+		AccountID:     req.AccountID,
+		BkBizID:       req.BkBizID,
+		ResTypes:      convResTypes(req.ResTypes),
+		Rules:         rules,
+		AutoRemediate: &autoRemediate,
+		Enabled:       &enabled,
+		Memo:          req.Memo,
+		Creator:       cts.Kit.User,
+		Reviser:       cts.Kit.User,
+	}
+	id, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.TagPolicy().CreateWithTx(cts.Kit, txn, model)
+	})
+	if err != nil {
+		logs.Errorf("create tag policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	policyID, ok := id.(string)
+	if !ok {
+		return nil, fmt.Errorf("create tag policy but return id type not string, id type: %v", id)
+	}
+
+	return &core.CreateResult{ID: policyID}, nil
+}
+
+// UpdateTagPolicy update tag policy.
+func (svc *service) UpdateTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(prototagpolicy.TagPolicyUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tabletagpolicy.TagPolicyTable{
+		Name:          req.Name,
+		AutoRemediate: req.AutoRemediate,
+		Enabled:       req.Enabled,
+		Memo:          req.Memo,
+		Reviser:       cts.Kit.User,
+	}
+
+	if len(req.ResTypes) != 0 {
+		model.ResTypes = convResTypes(req.ResTypes)
+	}
+
+	if len(req.Rules) != 0 {
+		rules, err := tabletype.NewJsonField(req.Rules)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		model.Rules = rules
+	}
+
+	if err := svc.dao.TagPolicy().Update(cts.Kit, tools.EqualExpression("id", id), model); err != nil {
+		logs.Errorf("update tag policy failed, id: %s, err: %v, rid: %s", id, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListTagPolicy list tag policy.
+func (svc *service) ListTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.TagPolicy().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list tag policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list tag policy failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &prototagpolicy.TagPolicyListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]coretagpolicy.TagPolicy, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		policy, err := convTagPolicy(one)
+		if err != nil {
+			logs.Errorf("convert tag policy %s failed, err: %v, rid: %s", one.ID, err, cts.Kit.Rid)
+			return nil, err
+		}
+		details = append(details, *policy)
+	}
+
+	return &prototagpolicy.TagPolicyListResult{Details: details}, nil
+}
+
+// DeleteTagPolicy delete tag policy, the violations of the policy are deleted as well.
+func (svc *service) DeleteTagPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(prototagpolicy.TagPolicyDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.TagPolicy().DeleteWithTx(cts.Kit, txn, req.Filter)
+	})
+	if err != nil {
+		logs.Errorf("delete tag policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListTagPolicyViolation list tag policy violation.
+func (svc *service) ListTagPolicyViolation(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.TagPolicyViolation().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list tag policy violation failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list tag policy violation failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &prototagpolicy.ViolationListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]coretagpolicy.TagPolicyViolation, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, coretagpolicy.TagPolicyViolation{
+			ID:        one.ID,
+			PolicyID:  one.PolicyID,
+			ResType:   one.ResType,
+			ResID:     one.ResID,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			BkBizID:   one.BkBizID,
+			TagKey:    one.TagKey,
+			Reason:    one.Reason,
+			Detail:    one.Detail,
+			CreatedAt: one.CreatedAt.String(),
+		})
+	}
+
+	return &prototagpolicy.ViolationListResult{Details: details}, nil
+}
+
+func convResTypes(resTypes []enumor.CloudResourceType) tabletype.StringArray {
+	result := make(tabletype.StringArray, 0, len(resTypes))
+	for _, resType := range resTypes {
+		result = append(result, string(resType))
+	}
+
+	return result
+}
+
+func convTagPolicy(one tabletagpolicy.TagPolicyTable) (*coretagpolicy.TagPolicy, error) {
+	resTypes := make([]enumor.CloudResourceType, 0, len(one.ResTypes))
+	for _, resType := range one.ResTypes {
+		resTypes = append(resTypes, enumor.CloudResourceType(resType))
+	}
+
+	rules := make([]coretagpolicy.TagRule, 0)
+	if len(one.Rules) != 0 {
+		if err := json.Unmarshal([]byte(one.Rules), &rules); err != nil {
+			return nil, err
+		}
+	}
+
+	return &coretagpolicy.TagPolicy{
+		ID:            one.ID,
+		Name:          one.Name,
+		AccountID:     one.AccountID,
+		BkBizID:       one.BkBizID,
+		ResTypes:      resTypes,
+		Rules:         rules,
+		AutoRemediate: converter.PtrToVal(one.AutoRemediate),
+		Enabled:       converter.PtrToVal(one.Enabled),
+		Memo:          one.Memo,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}, nil
+}
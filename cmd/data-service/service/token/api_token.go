@@ -0,0 +1,205 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package token
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coretoken "hcm/pkg/api/core/token"
+	prototoken "hcm/pkg/api/data-service/token"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tabletoken "hcm/pkg/dal/table/token"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateApiToken create api token, only the hash of the token is stored.
+func (svc *service) CreateApiToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(prototoken.ApiTokenCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tabletoken.ApiTokenTable{
+		Name:        req.Name,
+		SubjectType: req.SubjectType,
+		Subject:     req.Subject,
+		TokenHash:   req.TokenHash,
+		TokenPrefix: req.TokenPrefix,
+		Scopes:      req.Scopes,
+		ExpiredAt:   req.ExpiredAt,
+		Revoked:     converter.ValToPtr(false),
+		Memo:        req.Memo,
+		Creator:     cts.Kit.User,
+		Reviser:     cts.Kit.User,
+	}
+	id, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.ApiToken().CreateWithTx(cts.Kit, txn, model)
+	})
+	if err != nil {
+		logs.Errorf("create api token failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	tokenID, ok := id.(string)
+	if !ok {
+		return nil, fmt.Errorf("create api token but return id type not string, id type: %v", id)
+	}
+
+	return &core.CreateResult{ID: tokenID}, nil
+}
+
+// UpdateApiToken update api token.
+func (svc *service) UpdateApiToken(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(prototoken.ApiTokenUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tabletoken.ApiTokenTable{
+		Revoked: req.Revoked,
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+	if err := svc.dao.ApiToken().Update(cts.Kit, tools.EqualExpression("id", id), model); err != nil {
+		logs.Errorf("update api token failed, id: %s, err: %v, rid: %s", id, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// UpdateApiTokenLastUsed update api token last used info.
+func (svc *service) UpdateApiTokenLastUsed(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(prototoken.ApiTokenLastUsedUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.ApiToken().UpdateLastUsed(cts.Kit, id, req.LastUsedAt, req.LastUsedIP); err != nil {
+		logs.Errorf("update api token last used info failed, id: %s, err: %v, rid: %s", id, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListApiToken list api token.
+func (svc *service) ListApiToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.ApiToken().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list api token failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list api token failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &prototoken.ApiTokenListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]coretoken.ApiToken, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, coretoken.ApiToken{
+			ID:          one.ID,
+			Name:        one.Name,
+			SubjectType: one.SubjectType,
+			Subject:     one.Subject,
+			TokenPrefix: one.TokenPrefix,
+			Scopes:      one.Scopes,
+			ExpiredAt:   one.ExpiredAt,
+			Revoked:     converter.PtrToVal(one.Revoked),
+			LastUsedAt:  one.LastUsedAt,
+			LastUsedIP:  one.LastUsedIP,
+			Memo:        one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &prototoken.ApiTokenListResult{Details: details}, nil
+}
+
+// DeleteApiToken delete api token.
+func (svc *service) DeleteApiToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(prototoken.ApiTokenDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.ApiToken().DeleteWithTx(cts.Kit, txn, req.Filter)
+	})
+	if err != nil {
+		logs.Errorf("delete api token failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package token defines the data-service api of hcm issued api tokens and service accounts.
+package token
+
+import (
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the api token and service account service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateApiToken", "POST", "/api_tokens/create", svc.CreateApiToken)
+	h.Add("UpdateApiToken", "PATCH", "/api_tokens/{id}", svc.UpdateApiToken)
+	h.Add("UpdateApiTokenLastUsed", "PATCH", "/api_tokens/{id}/last_used", svc.UpdateApiTokenLastUsed)
+	h.Add("ListApiToken", "POST", "/api_tokens/list", svc.ListApiToken)
+	h.Add("DeleteApiToken", "DELETE", "/api_tokens/batch", svc.DeleteApiToken)
+
+	h.Add("CreateServiceAccount", "POST", "/service_accounts/create", svc.CreateServiceAccount)
+	h.Add("UpdateServiceAccount", "PATCH", "/service_accounts/{id}", svc.UpdateServiceAccount)
+	h.Add("ListServiceAccount", "POST", "/service_accounts/list", svc.ListServiceAccount)
+	h.Add("DeleteServiceAccount", "DELETE", "/service_accounts/batch", svc.DeleteServiceAccount)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
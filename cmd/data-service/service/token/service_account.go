@@ -0,0 +1,209 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package token
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coretoken "hcm/pkg/api/core/token"
+	prototoken "hcm/pkg/api/data-service/token"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tabletoken "hcm/pkg/dal/table/token"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateServiceAccount create service account.
+func (svc *service) CreateServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(prototoken.ServiceAccountCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tabletoken.ServiceAccountTable{
+		Name:     req.Name,
+		Managers: req.Managers,
+		Memo:     req.Memo,
+		Creator:  cts.Kit.User,
+		Reviser:  cts.Kit.User,
+	}
+	id, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return svc.dao.ServiceAccount().CreateWithTx(cts.Kit, txn, model)
+	})
+	if err != nil {
+		logs.Errorf("create service account failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	saID, ok := id.(string)
+	if !ok {
+		return nil, fmt.Errorf("create service account but return id type not string, id type: %v", id)
+	}
+
+	return &core.CreateResult{ID: saID}, nil
+}
+
+// UpdateServiceAccount update service account.
+func (svc *service) UpdateServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(prototoken.ServiceAccountUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tabletoken.ServiceAccountTable{
+		Managers: req.Managers,
+		Memo:     req.Memo,
+		Reviser:  cts.Kit.User,
+	}
+	if err := svc.dao.ServiceAccount().Update(cts.Kit, tools.EqualExpression("id", id), model); err != nil {
+		logs.Errorf("update service account failed, id: %s, err: %v, rid: %s", id, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListServiceAccount list service account.
+func (svc *service) ListServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.ServiceAccount().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list service account failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list service account failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &prototoken.ServiceAccountListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]coretoken.ServiceAccount, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, coretoken.ServiceAccount{
+			ID:       one.ID,
+			Name:     one.Name,
+			Managers: one.Managers,
+			Memo:     one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &prototoken.ServiceAccountListResult{Details: details}, nil
+}
+
+// DeleteServiceAccount delete service account and the api tokens of the service accounts.
+func (svc *service) DeleteServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(prototoken.ServiceAccountDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   core.DefaultBasePage,
+		Fields: []string{"id", "name"},
+	}
+	listResp, err := svc.dao.ServiceAccount().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list service account failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(listResp.Details))
+	names := make([]string, 0, len(listResp.Details))
+	for _, one := range listResp.Details {
+		ids = append(ids, one.ID)
+		names = append(names, one.Name)
+	}
+
+	tokenExpr := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "subject_type", Op: filter.Equal.Factory(),
+				Value: string(enumor.ServiceAccountApiToken)},
+			&filter.AtomRule{Field: "subject", Op: filter.In.Factory(), Value: names},
+		},
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.ApiToken().DeleteWithTx(cts.Kit, txn, tokenExpr); err != nil {
+			return nil, err
+		}
+
+		if err := svc.dao.ServiceAccount().DeleteWithTx(cts.Kit, txn,
+			tools.ContainersExpression("id", ids)); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete service account failed, ids: %v, err: %v, rid: %s", ids, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -32,6 +32,7 @@ import (
 	"hcm/pkg/runtime/ctl"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the hc service.
@@ -68,6 +69,10 @@ func (ds *hcService) prepare(opt *options.Option) error {
 
 	logs.InitLogger(cc.HCService().Log.Logs())
 
+	if err := tracing.InitTracing(cc.HCService().Tracing); err != nil {
+		return fmt.Errorf("init tracing failed, err: %v", err)
+	}
+
 	logs.Infof("load settings from config file success.")
 
 	// init metrics
@@ -35,6 +35,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -111,9 +112,24 @@ func (cli *client) SecurityGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncS
 			return nil, err
 		}
 	}
+	if err = cli.syncSGTags(kt, params, sgFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
+// syncSGTags sync the tags of the security groups from cloud to db.
+func (cli *client) syncSGTags(kt *kit.Kit, params *SyncBaseParams, sgFromCloud []securitygroup.AwsSG) error {
+	sgFromDB, err := cli.listSGFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	return common.SyncCloudResourceTags(kt, cli.dbCli, table.SecurityGroupTable, enumor.Aws, params.AccountID,
+		sgFromCloud, sgFromDB)
+}
+
 func (cli *client) updateSG(kt *kit.Kit, accountID string, region string,
 	updateMap map[string]securitygroup.AwsSG) error {
 
@@ -390,7 +406,7 @@ func (cli *client) RemoveSecurityGroupDeleteFromCloud(kt *kit.Kit, accountID str
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
@@ -36,6 +36,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -93,9 +94,24 @@ func (cli *client) Vpc(kt *kit.Kit, params *SyncBaseParams, opt *SyncVpcOption)
 		}
 	}
 
+	if err = cli.syncVpcTags(kt, params, vpcFromCloud); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
+// syncVpcTags sync the tags of the vpcs from cloud to db.
+func (cli *client) syncVpcTags(kt *kit.Kit, params *SyncBaseParams, vpcFromCloud []types.AwsVpc) error {
+	vpcFromDB, err := cli.listVpcFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	return common.SyncCloudResourceTags(kt, cli.dbCli, table.VpcTable, enumor.Aws, params.AccountID, vpcFromCloud,
+		vpcFromDB)
+}
+
 func (cli *client) deleteVpc(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
 	if len(delCloudIDs) == 0 {
 		return fmt.Errorf("delete vpc, cloudIDs is required")
@@ -351,7 +367,7 @@ func (cli *client) RemoveVpcDeleteFromCloud(kt *kit.Kit, accountID string, regio
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/api/core"
 	"hcm/pkg/api/core/cloud/cvm"
 	corecvm "hcm/pkg/api/core/cloud/cvm"
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/criteria/constant"
@@ -35,6 +36,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -93,9 +95,37 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 		}
 	}
 
+	if err = cli.syncCvmTags(kt, params, cvmFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
+// syncCvmTags sync the tags of the cvms from cloud to db.
+func (cli *client) syncCvmTags(kt *kit.Kit, params *SyncBaseParams, cvmFromCloud []typescvm.AzureCvm) error {
+	cvmFromDB, err := cli.listCvmFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	opt := &common.ResourceTagOption{
+		ResType:   table.CvmTable,
+		Vendor:    enumor.Azure,
+		AccountID: params.AccountID,
+		IDMap:     make(map[string]string, len(cvmFromDB)),
+		CloudTags: make(map[string][]coreresourcetag.Tag, len(cvmFromCloud)),
+	}
+	for _, one := range cvmFromDB {
+		opt.IDMap[one.CloudID] = one.ID
+	}
+	for _, one := range cvmFromCloud {
+		opt.CloudTags[one.GetCloudID()] = common.TagsFromMap(one.Tags)
+	}
+
+	return common.SyncResourceTags(kt, cli.dbCli, opt)
+}
+
 func (cli *client) listCvmFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typescvm.AzureCvm, error) {
 	if err := params.Validate(); err != nil {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
@@ -645,7 +675,7 @@ func (cli *client) RemoveCvmDeleteFromCloud(kt *kit.Kit, accountID string, resGr
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
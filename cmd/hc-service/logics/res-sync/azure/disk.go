@@ -32,6 +32,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -90,9 +91,24 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 		}
 	}
 
+	if err = cli.syncDiskTags(kt, params, diskFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
+// syncDiskTags sync the tags of the disks from cloud to db.
+func (cli *client) syncDiskTags(kt *kit.Kit, params *SyncBaseParams, diskFromCloud []typesdisk.AzureDisk) error {
+	diskFromDB, err := cli.listDiskFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	return common.SyncCloudResourceTags(kt, cli.dbCli, table.DiskTable, enumor.Azure, params.AccountID, diskFromCloud,
+		diskFromDB)
+}
+
 func (cli *client) updateDisk(kt *kit.Kit, accountID string, resGroupName string, bootMap map[string]struct{},
 	updateMap map[string]typesdisk.AzureDisk) error {
 
@@ -352,7 +368,7 @@ func (cli *client) RemoveDiskDeleteFromCloud(kt *kit.Kit, accountID string, resG
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
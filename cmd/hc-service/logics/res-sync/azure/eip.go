@@ -32,6 +32,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -91,9 +92,24 @@ func (cli *client) Eip(kt *kit.Kit, params *SyncBaseParams, opt *SyncEipOption)
 		}
 	}
 
+	if err = cli.syncEipTags(kt, params, eipFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
+// syncEipTags sync the tags of the eips from cloud to db.
+func (cli *client) syncEipTags(kt *kit.Kit, params *SyncBaseParams, eipFromCloud []*typeseip.AzureEip) error {
+	eipFromDB, err := cli.listEipFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	return common.SyncCloudResourceTags(kt, cli.dbCli, table.EipTable, enumor.Azure, params.AccountID, eipFromCloud,
+		eipFromDB)
+}
+
 // RemoveEipDeleteFromCloud ...
 func (cli *client) RemoveEipDeleteFromCloud(kt *kit.Kit, accountID string, resGroupName string) error {
 
@@ -159,7 +175,7 @@ func (cli *client) RemoveEipDeleteFromCloud(kt *kit.Kit, accountID string, resGr
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
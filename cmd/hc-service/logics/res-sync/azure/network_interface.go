@@ -36,6 +36,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -94,9 +95,24 @@ func (cli *client) NetworkInterface(kt *kit.Kit, params *SyncBaseParams, opt *Sy
 		}
 	}
 
+	if err = cli.syncNetworkInterfaceTags(kt, params, niFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
+// syncNetworkInterfaceTags sync the tags of the network interfaces from cloud to db.
+func (cli *client) syncNetworkInterfaceTags(kt *kit.Kit, params *SyncBaseParams, niFromCloud []typesni.AzureNI) error {
+	niFromDB, err := cli.listNetworkInterfaceFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	return common.SyncCloudResourceTags(kt, cli.dbCli, table.NetworkInterfaceTable, enumor.Azure, params.AccountID,
+		niFromCloud, niFromDB)
+}
+
 // RemoveNetworkInterfaceDeleteFromCloud ...
 func (cli *client) RemoveNetworkInterfaceDeleteFromCloud(kt *kit.Kit, accountID string, resGroupName string) error {
 
@@ -162,7 +178,7 @@ func (cli *client) RemoveNetworkInterfaceDeleteFromCloud(kt *kit.Kit, accountID
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
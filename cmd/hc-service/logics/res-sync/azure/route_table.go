@@ -36,6 +36,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -123,9 +124,26 @@ func (cli *client) RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRout
 		return nil, err
 	}
 
+	if err = cli.syncRouteTableTags(kt, params, routeTableFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
+// syncRouteTableTags sync the tags of the route tables from cloud to db.
+func (cli *client) syncRouteTableTags(kt *kit.Kit, params *SyncBaseParams,
+	routeTableFromCloud []typesroutetable.AzureRouteTable) error {
+
+	routeTableFromDB, err := cli.listRouteTableFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	return common.SyncCloudResourceTags(kt, cli.dbCli, table.RouteTableTable, enumor.Azure, params.AccountID,
+		routeTableFromCloud, routeTableFromDB)
+}
+
 func (cli *client) syncRoute(kt *kit.Kit, params *SyncBaseParams,
 	routeTableFromCloud []typesroutetable.AzureRouteTable) error {
 
@@ -412,7 +430,7 @@ func (cli *client) RemoveRouteTableDeleteFromCloud(kt *kit.Kit, accountID string
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB[len(resultFromDB)-1].ID)
 	}
 
 	return nil
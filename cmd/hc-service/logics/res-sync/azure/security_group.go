@@ -34,6 +34,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -110,9 +111,26 @@ func (cli *client) SecurityGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncS
 		}
 	}
 
+	if err = cli.syncSGTags(kt, params, sgFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
+// syncSGTags sync the tags of the security groups from cloud to db.
+func (cli *client) syncSGTags(kt *kit.Kit, params *SyncBaseParams,
+	sgFromCloud []securitygroup.AzureSecurityGroup) error {
+
+	sgFromDB, err := cli.listSGFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	return common.SyncCloudResourceTags(kt, cli.dbCli, table.SecurityGroupTable, enumor.Azure, params.AccountID,
+		sgFromCloud, sgFromDB)
+}
+
 func (cli *client) createSG(kt *kit.Kit, accountID string, resGroupName string,
 	addSlice []securitygroup.AzureSecurityGroup) ([]string, error) {
 
@@ -361,7 +379,7 @@ func (cli *client) RemoveSecurityGroupDeleteFromCloud(kt *kit.Kit, accountID str
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	typestag "hcm/pkg/adaptor/types/tag"
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
+	dataresourcetag "hcm/pkg/api/data-service/resource-tag"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// tagMaxLength is the max length of the tag key and value that can be saved.
+const tagMaxLength = 255
+
+// ResourceTagOption defines the options to sync the tags of the resources.
+type ResourceTagOption struct {
+	ResType   table.Name
+	Vendor    enumor.Vendor
+	AccountID string
+	// IDMap is the map of the synced resources' cloud id to hcm id.
+	IDMap map[string]string
+	// CloudTags is the map of the synced resources' cloud id to their tags on cloud, the tags of the resources
+	// in IDMap but not in CloudTags are cleared.
+	CloudTags map[string][]coreresourcetag.Tag
+}
+
+// SyncResourceTags replace the tags of the synced resources in db with the tags on cloud.
+func SyncResourceTags(kt *kit.Kit, dataCli *dataservice.Client, opt *ResourceTagOption) error {
+	if opt == nil || len(opt.IDMap) == 0 {
+		return nil
+	}
+
+	items := make([]dataresourcetag.ResourceTagReplaceItem, 0, len(opt.IDMap))
+	for cloudID, id := range opt.IDMap {
+		items = append(items, dataresourcetag.ResourceTagReplaceItem{
+			ResID:     id,
+			Vendor:    opt.Vendor,
+			AccountID: opt.AccountID,
+			Tags:      normalizeTags(opt.CloudTags[cloudID]),
+		})
+	}
+
+	for _, batch := range slice.Split(items, constant.RelResourceOperationMaxLimit) {
+		req := &dataresourcetag.ResourceTagReplaceReq{
+			ResType:   string(opt.ResType),
+			Resources: batch,
+		}
+		if err := dataCli.Global.ResourceTag.ReplaceResourceTag(kt.Ctx, kt.Header(), req); err != nil {
+			logs.Errorf("[%s] sync %s tags failed, err: %v, account: %s, rid: %s", opt.Vendor, opt.ResType, err,
+				opt.AccountID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DBResource is the synced resource in db, which has both hcm id and cloud id.
+type DBResource interface {
+	GetID() string
+	GetCloudID() string
+}
+
+// SyncCloudResourceTags sync the tags carried by the resources listed from cloud to db, fromDB should be listed
+// after the resources are synced, so that the tags of the new created resources are synced too.
+func SyncCloudResourceTags[C typestag.TaggedResource, D DBResource](kt *kit.Kit, dataCli *dataservice.Client,
+	resType table.Name, vendor enumor.Vendor, accountID string, fromCloud []C, fromDB []D) error {
+
+	opt := &ResourceTagOption{
+		ResType:   resType,
+		Vendor:    vendor,
+		AccountID: accountID,
+		IDMap:     make(map[string]string, len(fromDB)),
+		CloudTags: make(map[string][]coreresourcetag.Tag, len(fromCloud)),
+	}
+	for _, one := range fromDB {
+		opt.IDMap[one.GetCloudID()] = one.GetID()
+	}
+	for _, one := range fromCloud {
+		cloudTags := one.GetTags()
+		tags := make([]coreresourcetag.Tag, 0, len(cloudTags))
+		for _, tag := range cloudTags {
+			tags = append(tags, coreresourcetag.Tag{Key: tag.Key, Value: tag.Value})
+		}
+		opt.CloudTags[one.GetCloudID()] = tags
+	}
+
+	return SyncResourceTags(kt, dataCli, opt)
+}
+
+// normalizeTags drop the tags that can not be saved, e.g. tags with empty or duplicated key, and truncate the
+// tags that exceed the max length, so that the tags on cloud do not block the resource sync.
+func normalizeTags(tags []coreresourcetag.Tag) []coreresourcetag.Tag {
+	result := make([]coreresourcetag.Tag, 0, len(tags))
+	keys := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		key := strings.TrimSpace(tag.Key)
+		if len(key) == 0 || utf8.RuneCountInString(key) > tagMaxLength {
+			continue
+		}
+
+		if _, exists := keys[key]; exists {
+			continue
+		}
+		keys[key] = struct{}{}
+
+		value := tag.Value
+		if runes := []rune(value); len(runes) > tagMaxLength {
+			value = string(runes[:tagMaxLength])
+		}
+
+		result = append(result, coreresourcetag.Tag{Key: key, Value: value})
+		if len(result) == coreresourcetag.TagMaxCount {
+			break
+		}
+	}
+
+	return result
+}
+
+// TagsFromMap convert the tags of map format to resource tags, e.g. azure tags and gcp labels.
+func TagsFromMap[T string | *string](tags map[string]T) []coreresourcetag.Tag {
+	result := make([]coreresourcetag.Tag, 0, len(tags))
+	for key, value := range tags {
+		switch v := any(value).(type) {
+		case string:
+			result = append(result, coreresourcetag.Tag{Key: key, Value: v})
+		case *string:
+			tag := coreresourcetag.Tag{Key: key}
+			if v != nil {
+				tag.Value = *v
+			}
+			result = append(result, tag)
+		}
+	}
+
+	return result
+}
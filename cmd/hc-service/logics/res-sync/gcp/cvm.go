@@ -30,6 +30,7 @@ import (
 	"hcm/pkg/api/core"
 	"hcm/pkg/api/core/cloud/cvm"
 	corecvm "hcm/pkg/api/core/cloud/cvm"
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/criteria/constant"
@@ -37,6 +38,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -97,6 +99,10 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 		}
 	}
 
+	if err = cli.syncCvmTags(kt, params, opt.Zone, cvmFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
@@ -592,6 +598,32 @@ func (cli *client) deleteCvm(kt *kit.Kit, accountID string, zone string, delClou
 	return nil
 }
 
+// syncCvmTags sync the tags of the cvms from cloud to db.
+func (cli *client) syncCvmTags(kt *kit.Kit, params *SyncBaseParams, zone string,
+	cvmFromCloud []typescvm.GcpCvm) error {
+
+	cvmFromDB, err := cli.listCvmFromDB(kt, params, zone)
+	if err != nil {
+		return err
+	}
+
+	opt := &common.ResourceTagOption{
+		ResType:   table.CvmTable,
+		Vendor:    enumor.Gcp,
+		AccountID: params.AccountID,
+		IDMap:     make(map[string]string, len(cvmFromDB)),
+		CloudTags: make(map[string][]coreresourcetag.Tag, len(cvmFromCloud)),
+	}
+	for _, one := range cvmFromDB {
+		opt.IDMap[one.CloudID] = one.ID
+	}
+	for _, one := range cvmFromCloud {
+		opt.CloudTags[one.GetCloudID()] = common.TagsFromMap(one.Labels)
+	}
+
+	return common.SyncResourceTags(kt, cli.dbCli, opt)
+}
+
 func (cli *client) listCvmFromCloud(kt *kit.Kit, params *SyncBaseParams, option *SyncCvmOption) ([]typescvm.GcpCvm, error) {
 	if err := params.Validate(); err != nil {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
@@ -712,7 +744,7 @@ func (cli *client) RemoveCvmDeleteFromCloud(kt *kit.Kit, accountID string, zone
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
@@ -31,6 +31,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -89,9 +90,26 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 		}
 	}
 
+	if err = cli.syncDiskTags(kt, params, opt, diskFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
+// syncDiskTags sync the tags of the disks from cloud to db.
+func (cli *client) syncDiskTags(kt *kit.Kit, params *SyncBaseParams,
+	opt *SyncDiskOption, diskFromCloud []adaptordisk.GcpDisk) error {
+
+	diskFromDB, err := cli.listDiskFromDB(kt, params, opt)
+	if err != nil {
+		return err
+	}
+
+	return common.SyncCloudResourceTags(kt, cli.dbCli, table.DiskTable, enumor.Gcp, params.AccountID, diskFromCloud,
+		diskFromDB)
+}
+
 func (cli *client) listDiskFromCloud(kt *kit.Kit, params *SyncBaseParams,
 	option *SyncDiskOption) ([]adaptordisk.GcpDisk, error) {
 
@@ -384,7 +402,7 @@ func (cli *client) RemoveDiskDeleteFromCloud(kt *kit.Kit, accountID string, zone
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
@@ -412,7 +412,7 @@ func (cli *client) RemoveFirewallDeleteFromCloud(kt *kit.Kit, accountID string)
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
@@ -310,7 +310,7 @@ func (cli *client) RemoveRouteDeleteFromCloud(kt *kit.Kit, accountID string, zon
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
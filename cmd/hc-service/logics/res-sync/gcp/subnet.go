@@ -158,7 +158,7 @@ func (cli *client) RemoveSubnetDeleteFromCloud(kt *kit.Kit, accountID, region st
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
@@ -156,7 +156,7 @@ func (cli *client) RemoveVpcDeleteFromCloud(kt *kit.Kit, accountID string) error
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
@@ -36,6 +36,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -108,11 +109,26 @@ func (cli *client) SecurityGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncS
 		}
 	}
 
+	if err = cli.syncSGTags(kt, params, sgFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
+// syncSGTags sync the tags of the security groups from cloud to db.
+func (cli *client) syncSGTags(kt *kit.Kit, params *SyncBaseParams, sgFromCloud []securitygroup.TCloudSG) error {
+	sgFromDB, err := cli.listSGFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	return common.SyncCloudResourceTags(kt, cli.dbCli, table.SecurityGroupTable, enumor.TCloud, params.AccountID,
+		sgFromCloud, sgFromDB)
+}
+
 func (cli *client) updateSG(kt *kit.Kit, accountID string,
-		updateMap map[string]securitygroup.TCloudSG) error {
+	updateMap map[string]securitygroup.TCloudSG) error {
 
 	if len(updateMap) <= 0 {
 		return fmt.Errorf("sg updateMap is <= 0, not update")
@@ -150,7 +166,7 @@ func (cli *client) updateSG(kt *kit.Kit, accountID string,
 }
 
 func (cli *client) createSG(kt *kit.Kit, accountID string, region string,
-		addSlice []securitygroup.TCloudSG) ([]string, error) {
+	addSlice []securitygroup.TCloudSG) ([]string, error) {
 
 	if len(addSlice) <= 0 {
 		return nil, fmt.Errorf("sg addSlice is <= 0, not create")
@@ -252,7 +268,7 @@ func (cli *client) listSGFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]secur
 }
 
 func (cli *client) listSGFromDB(kt *kit.Kit, params *SyncBaseParams) (
-		[]cloudcore.SecurityGroup[cloudcore.TCloudSecurityGroupExtension], error) {
+	[]cloudcore.SecurityGroup[cloudcore.TCloudSecurityGroupExtension], error) {
 
 	if err := params.Validate(); err != nil {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
@@ -325,7 +341,7 @@ func (cli *client) RemoveSecurityGroupDeleteFromCloud(kt *kit.Kit, accountID str
 		for _, one := range resultFromDB.Details {
 			cloudIDs = append(cloudIDs, one.CloudID)
 		}
-		
+
 		if len(cloudIDs) == 0 {
 			break
 		}
@@ -353,7 +369,7 @@ func (cli *client) RemoveSecurityGroupDeleteFromCloud(kt *kit.Kit, accountID str
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
@@ -34,6 +34,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -91,9 +92,24 @@ func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOp
 		}
 	}
 
+	if err = cli.syncSubnetTags(kt, params, subnetFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
+// syncSubnetTags sync the tags of the subnets from cloud to db.
+func (cli *client) syncSubnetTags(kt *kit.Kit, params *SyncBaseParams, subnetFromCloud []types.TCloudSubnet) error {
+	subnetFromDB, err := cli.listSubnetFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	return common.SyncCloudResourceTags(kt, cli.dbCli, table.SubnetTable, enumor.TCloud, params.AccountID,
+		subnetFromCloud, subnetFromDB)
+}
+
 func (cli *client) deleteSubnet(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
 	if len(delCloudIDs) == 0 {
 		return fmt.Errorf("delete subnet, cloudIDs is required")
@@ -389,7 +405,7 @@ func (cli *client) RemoveSubnetDeleteFromCloud(kt *kit.Kit, accountID string, re
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
@@ -34,6 +34,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -91,9 +92,24 @@ func (cli *client) Vpc(kt *kit.Kit, params *SyncBaseParams, opt *SyncVpcOption)
 		}
 	}
 
+	if err = cli.syncVpcTags(kt, params, vpcFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
+// syncVpcTags sync the tags of the vpcs from cloud to db.
+func (cli *client) syncVpcTags(kt *kit.Kit, params *SyncBaseParams, vpcFromCloud []types.TCloudVpc) error {
+	vpcFromDB, err := cli.listVpcFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	return common.SyncCloudResourceTags(kt, cli.dbCli, table.VpcTable, enumor.TCloud, params.AccountID, vpcFromCloud,
+		vpcFromDB)
+}
+
 // RemoveVpcDeleteFromCloud ...
 func (cli *client) RemoveVpcDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
 
@@ -158,7 +174,7 @@ func (cli *client) RemoveVpcDeleteFromCloud(kt *kit.Kit, accountID string, regio
 			break
 		}
 
-		req.Page.Start += constant.BatchOperationMaxLimit
+		req.Page.Cursor = core.NewIDPageCursor(resultFromDB.Details[len(resultFromDB.Details)-1].ID)
 	}
 
 	return nil
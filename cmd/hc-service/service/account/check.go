@@ -20,8 +20,10 @@
 package account
 
 import (
+	"hcm/pkg/adaptor/aws"
 	"hcm/pkg/adaptor/types"
 	proto "hcm/pkg/api/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/rest"
 )
@@ -61,16 +63,22 @@ func (svc *service) AwsAccountCheck(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	client, err := svc.ad.Adaptor().Aws(&types.BaseSecret{CloudSecretID: req.CloudSecretID,
-		CloudSecretKey: req.CloudSecretKey}, req.CloudAccountID)
+	var client *aws.Aws
+	var err error
+	info := &types.AwsAccountInfo{CloudAccountID: req.CloudAccountID, CloudIamUsername: req.CloudIamUsername}
+	if req.CloudCredentialType == enumor.AssumeRoleCredential {
+		client, err = svc.ad.Adaptor().AwsAssumeRole(&types.AwsAssumeRole{RoleArn: req.CloudRoleArn,
+			ExternalID: req.CloudExternalID}, req.CloudAccountID)
+		info.CloudRoleArn = req.CloudRoleArn
+	} else {
+		client, err = svc.ad.Adaptor().Aws(&types.BaseSecret{CloudSecretID: req.CloudSecretID,
+			CloudSecretKey: req.CloudSecretKey}, req.CloudAccountID)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.AccountCheck(
-		cts.Kit,
-		&types.AwsAccountInfo{CloudAccountID: req.CloudAccountID, CloudIamUsername: req.CloudIamUsername},
-	)
+	err = client.AccountCheck(cts.Kit, info)
 
 	return nil, err
 }
@@ -112,7 +120,7 @@ func (svc *service) GcpAccountCheck(cts *rest.Contexts) (interface{}, error) {
 	}
 
 	client, err := svc.ad.Adaptor().Gcp(&types.GcpCredential{CloudProjectID: req.CloudProjectID,
-		Json: []byte(req.CloudServiceSecretKey)})
+		Json: []byte(req.CloudServiceSecretKey), CredentialType: req.CloudCredentialType})
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +143,7 @@ func (svc *service) AzureAccountCheck(cts *rest.Contexts) (interface{}, error) {
 	client, err := svc.ad.Adaptor().Azure(&types.AzureCredential{
 		CloudTenantID: req.CloudTenantID, CloudSubscriptionID: req.CloudSubscriptionID,
 		CloudApplicationID: req.CloudApplicationID, CloudClientSecretKey: req.CloudClientSecretKey,
+		CredentialType: req.CloudCredentialType,
 	})
 	if err != nil {
 		return nil, err
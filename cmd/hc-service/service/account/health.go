@@ -0,0 +1,192 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"hcm/pkg/adaptor/types"
+	typeaccount "hcm/pkg/adaptor/types/account"
+	proto "hcm/pkg/api/hc-service/account"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// permissionLister list the missing permissions of resource sync.
+type permissionLister interface {
+	ListMissingPermission(kt *kit.Kit, opt *typeaccount.ListMissingPermissionOption) (
+		[]typeaccount.MissingPermission, error)
+}
+
+// TCloudAccountHealthCheck check tcloud account health by the stored secret.
+func (svc *service) TCloudAccountHealthCheck(cts *rest.Contexts) (interface{}, error) {
+	req, err := decodeHealthCheckReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := svc.dataCli.TCloud.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.AccountCheck(cts.Kit, &types.TCloudAccountInfo{
+		CloudMainAccountID: account.Extension.CloudMainAccountID,
+		CloudSubAccountID:  account.Extension.CloudSubAccountID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return listMissingPermission(cts.Kit, client, req)
+}
+
+// AwsAccountHealthCheck check aws account health by the stored credential.
+func (svc *service) AwsAccountHealthCheck(cts *rest.Contexts) (interface{}, error) {
+	req, err := decodeHealthCheckReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := svc.dataCli.Aws.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &types.AwsAccountInfo{
+		CloudAccountID:   account.Extension.CloudAccountID,
+		CloudIamUsername: account.Extension.CloudIamUsername,
+	}
+	if account.Extension.CloudCredentialType == enumor.AssumeRoleCredential {
+		info.CloudRoleArn = account.Extension.CloudRoleArn
+	}
+
+	if err = client.AccountCheck(cts.Kit, info); err != nil {
+		return nil, err
+	}
+
+	return listMissingPermission(cts.Kit, client, req)
+}
+
+// HuaWeiAccountHealthCheck check huawei account health by the stored secret.
+func (svc *service) HuaWeiAccountHealthCheck(cts *rest.Contexts) (interface{}, error) {
+	req, err := decodeHealthCheckReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := svc.dataCli.HuaWei.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.AccountCheck(cts.Kit, &types.HuaWeiAccountInfo{
+		CloudMainAccountName: account.Extension.CloudMainAccountName,
+		CloudSubAccountID:    account.Extension.CloudSubAccountID,
+		CloudSubAccountName:  account.Extension.CloudSubAccountName,
+		CloudIamUserID:       account.Extension.CloudIamUserID,
+		CloudIamUsername:     account.Extension.CloudIamUsername,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return listMissingPermission(cts.Kit, client, req)
+}
+
+// GcpAccountHealthCheck check gcp account health by the stored credential.
+func (svc *service) GcpAccountHealthCheck(cts *rest.Contexts) (interface{}, error) {
+	req, err := decodeHealthCheckReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Gcp(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = client.AccountCheck(cts.Kit); err != nil {
+		return nil, err
+	}
+
+	return listMissingPermission(cts.Kit, client, req)
+}
+
+// AzureAccountHealthCheck check azure account health by the stored credential.
+func (svc *service) AzureAccountHealthCheck(cts *rest.Contexts) (interface{}, error) {
+	req, err := decodeHealthCheckReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Azure(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = client.AccountCheck(cts.Kit); err != nil {
+		return nil, err
+	}
+
+	return listMissingPermission(cts.Kit, client, req)
+}
+
+func decodeHealthCheckReq(cts *rest.Contexts) (*proto.AccountHealthCheckReq, error) {
+	req := new(proto.AccountHealthCheckReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return req, nil
+}
+
+func listMissingPermission(kt *kit.Kit, lister permissionLister, req *proto.AccountHealthCheckReq) (
+	*proto.AccountHealthCheckResult, error) {
+
+	missing, err := lister.ListMissingPermission(kt, &typeaccount.ListMissingPermissionOption{Region: req.Region})
+	if err != nil {
+		logs.Errorf("list account missing permission failed, err: %v, account: %s, rid: %s", err, req.AccountID,
+			kt.Rid)
+		return nil, err
+	}
+
+	return &proto.AccountHealthCheckResult{MissingPermissions: missing}, nil
+}
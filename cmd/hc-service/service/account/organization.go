@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"hcm/pkg/adaptor/types"
+	typeaccount "hcm/pkg/adaptor/types/account"
+	proto "hcm/pkg/api/hc-service/account"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// organizationMemberLister list the member accounts of the organization.
+type organizationMemberLister interface {
+	ListOrganizationMember(kt *kit.Kit, opt *typeaccount.ListOrganizationMemberOption) (
+		[]typeaccount.OrganizationMember, error)
+}
+
+// TCloudListOrganizationMember list tcloud organization members.
+func (svc *service) TCloudListOrganizationMember(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.TCloudOrganizationMemberListReq)
+	if err := decodeAndValidate(cts, req); err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Adaptor().TCloud(&types.BaseSecret{CloudSecretID: req.CloudSecretID,
+		CloudSecretKey: req.CloudSecretKey})
+	if err != nil {
+		return nil, err
+	}
+
+	return listOrganizationMember(cts.Kit, client, req.ParentID)
+}
+
+// AwsListOrganizationMember list aws organization member accounts.
+func (svc *service) AwsListOrganizationMember(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.AwsOrganizationMemberListReq)
+	if err := decodeAndValidate(cts, req); err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Adaptor().Aws(&types.BaseSecret{CloudSecretID: req.CloudSecretID,
+		CloudSecretKey: req.CloudSecretKey}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return listOrganizationMember(cts.Kit, client, req.ParentID)
+}
+
+// AzureListOrganizationMember list azure subscriptions under the management group.
+func (svc *service) AzureListOrganizationMember(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.AzureOrganizationMemberListReq)
+	if err := decodeAndValidate(cts, req); err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Adaptor().Azure(&types.AzureCredential{
+		CloudTenantID: req.CloudTenantID, CloudSubscriptionID: req.CloudSubscriptionID,
+		CloudApplicationID: req.CloudApplicationID, CloudClientSecretKey: req.CloudClientSecretKey,
+		CredentialType: req.CloudCredentialType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return listOrganizationMember(cts.Kit, client, req.ParentID)
+}
+
+// GcpListOrganizationMember list gcp projects under the organization or folder.
+func (svc *service) GcpListOrganizationMember(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.GcpOrganizationMemberListReq)
+	if err := decodeAndValidate(cts, req); err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Adaptor().Gcp(&types.GcpCredential{CloudProjectID: req.CloudProjectID,
+		Json: []byte(req.CloudServiceSecretKey), CredentialType: req.CloudCredentialType})
+	if err != nil {
+		return nil, err
+	}
+
+	return listOrganizationMember(cts.Kit, client, req.ParentID)
+}
+
+func decodeAndValidate(cts *rest.Contexts, req interface{ Validate() error }) error {
+	if err := cts.DecodeInto(req); err != nil {
+		return errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return nil
+}
+
+func listOrganizationMember(kt *kit.Kit, lister organizationMemberLister, parentID string) (
+	*proto.OrganizationMemberListResult, error) {
+
+	members, err := lister.ListOrganizationMember(kt, &typeaccount.ListOrganizationMemberOption{ParentID: parentID})
+	if err != nil {
+		logs.Errorf("list organization member failed, err: %v, parent: %s, rid: %s", err, parentID, kt.Rid)
+		return nil, err
+	}
+
+	return &proto.OrganizationMemberListResult{Details: members}, nil
+}
@@ -25,13 +25,15 @@ import (
 
 	"hcm/cmd/hc-service/service/capability"
 	cloudadaptor "hcm/cmd/hc-service/service/cloud-adaptor"
+	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/rest"
 )
 
 // InitAccountService initial the service service
 func InitAccountService(cap *capability.Capability) {
 	svc := &service{
-		ad: cap.CloudAdaptor,
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
 	}
 
 	h := rest.NewHandler()
@@ -42,6 +44,26 @@ func InitAccountService(cap *capability.Capability) {
 	h.Add("GcpAccountCheck", http.MethodPost, "/vendors/gcp/accounts/check", svc.GcpAccountCheck)
 	h.Add("AzureAccountCheck", http.MethodPost, "/vendors/azure/accounts/check", svc.AzureAccountCheck)
 
+	// 账号健康检查
+	h.Add("TCloudAccountHealthCheck", http.MethodPost, "/vendors/tcloud/accounts/health/check",
+		svc.TCloudAccountHealthCheck)
+	h.Add("AwsAccountHealthCheck", http.MethodPost, "/vendors/aws/accounts/health/check", svc.AwsAccountHealthCheck)
+	h.Add("HuaWeiAccountHealthCheck", http.MethodPost, "/vendors/huawei/accounts/health/check",
+		svc.HuaWeiAccountHealthCheck)
+	h.Add("GcpAccountHealthCheck", http.MethodPost, "/vendors/gcp/accounts/health/check", svc.GcpAccountHealthCheck)
+	h.Add("AzureAccountHealthCheck", http.MethodPost, "/vendors/azure/accounts/health/check",
+		svc.AzureAccountHealthCheck)
+
+	// 组织成员账号发现
+	h.Add("TCloudListOrganizationMember", http.MethodPost, "/vendors/tcloud/accounts/organizations/members/list",
+		svc.TCloudListOrganizationMember)
+	h.Add("AwsListOrganizationMember", http.MethodPost, "/vendors/aws/accounts/organizations/members/list",
+		svc.AwsListOrganizationMember)
+	h.Add("AzureListOrganizationMember", http.MethodPost, "/vendors/azure/accounts/organizations/members/list",
+		svc.AzureListOrganizationMember)
+	h.Add("GcpListOrganizationMember", http.MethodPost, "/vendors/gcp/accounts/organizations/members/list",
+		svc.GcpListOrganizationMember)
+
 	// 获取账号配额
 	h.Add("GetTCloudAccountZoneQuota", http.MethodPost, "/vendors/tcloud/accounts/zones/quotas",
 		svc.GetTCloudAccountZoneQuota)
@@ -54,5 +76,6 @@ func InitAccountService(cap *capability.Capability) {
 }
 
 type service struct {
-	ad *cloudadaptor.CloudAdaptorClient
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
 }
@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	typesBill "hcm/pkg/adaptor/types/bill"
+	"hcm/pkg/api/core/cloud"
+	hcbillservice "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// AwsListDailyCost list aws daily cost aggregated by service and region.
+func (b bill) AwsListDailyCost(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcbillservice.AwsDailyCostListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	billInfo, err := b.getReadyBillInfo(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := b.ad.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		logs.Errorf("aws daily cost get cloud client failed, req: %+v, err: %v, rid: %s", req, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	opt := &typesBill.AwsDailyCostOption{
+		BeginDate: req.BeginDate,
+		EndDate:   req.EndDate,
+	}
+	costs, err := cli.ListDailyCost(cts.Kit, opt, billInfo)
+	if err != nil {
+		logs.Errorf("request adaptor list aws daily cost failed, req: %+v, err: %v, rid: %s", req, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return costs, nil
+}
+
+// AwsListTopCostItem list aws line items with the highest cost of one day.
+func (b bill) AwsListTopCostItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcbillservice.AwsTopCostItemListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	billInfo, err := b.getReadyBillInfo(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := b.ad.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		logs.Errorf("aws top cost item get cloud client failed, req: %+v, err: %v, rid: %s", req, err,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	opt := &typesBill.AwsTopCostItemOption{
+		BillDate: req.BillDate,
+		Service:  req.Service,
+		Region:   req.Region,
+		Limit:    req.Limit,
+	}
+	items, err := cli.ListTopCostItem(cts.Kit, opt, billInfo)
+	if err != nil {
+		logs.Errorf("request adaptor list aws top cost item failed, req: %+v, err: %v, rid: %s", req, err,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// getReadyBillInfo get the bill config of the account whose bill pipeline has been done.
+func (b bill) getReadyBillInfo(kt *kit.Kit, accountID string) (
+	*cloud.AccountBillConfig[cloud.AwsBillConfigExtension], error) {
+
+	billInfo, err := b.GetBillInfo(kt, accountID)
+	if err != nil {
+		logs.Errorf("aws bill config get base info db failed, accountID: %s, err: %v, rid: %s", accountID, err,
+			kt.Rid)
+		return nil, err
+	}
+
+	if billInfo == nil {
+		return nil, errf.Newf(errf.RecordNotFound, "account_id: %s is not found", accountID)
+	}
+
+	if billInfo.Status != constant.StatusSuccess {
+		return nil, errf.Newf(errf.Aborted, "account_id: %s has not ready yet", accountID)
+	}
+
+	return billInfo, nil
+}
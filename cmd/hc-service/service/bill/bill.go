@@ -48,6 +48,8 @@ func InitBillService(cap *capability.Capability) {
 	h.Add("AwsGetBillList", "POST", "/vendors/aws/bills/list", v.AwsGetBillList)
 	h.Add("AwsBillsPipeline", "POST", "/vendors/aws/bills/pipeline", v.AwsBillPipeline)
 	h.Add("AwsBillConfigDelete", "DELETE", "/vendors/aws/bills/{id}", v.AwsBillConfigDelete)
+	h.Add("AwsListDailyCost", "POST", "/vendors/aws/bills/daily_costs/list", v.AwsListDailyCost)
+	h.Add("AwsListTopCostItem", "POST", "/vendors/aws/bills/top_cost_items/list", v.AwsListTopCostItem)
 	h.Add("TCloudGetBillList", "POST", "/vendors/tcloud/bills/list", v.TCloudGetBillList)
 	h.Add("HuaWeiGetBillList", "POST", "/vendors/huawei/bills/list", v.HuaWeiGetBillList)
 	h.Add("AzureGetBillList", "POST", "/vendors/azure/bills/list", v.AzureGetBillList)
@@ -26,14 +26,15 @@ import (
 	"hcm/pkg/adaptor/gcp"
 	"hcm/pkg/adaptor/huawei"
 	"hcm/pkg/adaptor/tcloud"
+	"hcm/pkg/adaptor/types"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/kit"
 )
 
-// NewCloudAdaptorClient new cloud adaptor client.
-func NewCloudAdaptorClient(dataCli *dataservice.Client) *CloudAdaptorClient {
+// NewCloudAdaptorClient new cloud adaptor client, identity is the trusted identity used to get short-lived credential.
+func NewCloudAdaptorClient(identity *types.TrustedIdentity, dataCli *dataservice.Client) *CloudAdaptorClient {
 	return &CloudAdaptorClient{
-		adaptor:   adaptor.New(),
+		adaptor:   adaptor.NewWithIdentity(identity),
 		secretCli: NewSecretClient(dataCli),
 	}
 }
@@ -61,12 +62,16 @@ func (cli *CloudAdaptorClient) TCloud(kt *kit.Kit, accountID string) (*tcloud.TC
 
 // Aws return aws client.
 func (cli *CloudAdaptorClient) Aws(kt *kit.Kit, accountID string) (*aws.Aws, error) {
-	secret, cloudAccountID, err := cli.secretCli.AwsSecret(kt, accountID)
+	cred, err := cli.secretCli.AwsCredential(kt, accountID)
 	if err != nil {
 		return nil, err
 	}
 
-	return cli.adaptor.Aws(secret, cloudAccountID)
+	if cred.Role != nil {
+		return cli.adaptor.AwsAssumeRole(cred.Role, cred.CloudAccountID)
+	}
+
+	return cli.adaptor.Aws(cred.Secret, cred.CloudAccountID)
 }
 
 // HuaWei return huawei client.
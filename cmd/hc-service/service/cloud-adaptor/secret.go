@@ -57,6 +57,7 @@ func (cli *SecretClient) TCloudSecret(kt *kit.Kit, accountID string) (*types.Bas
 	secret := &types.BaseSecret{
 		CloudSecretID:  account.Extension.CloudSecretID,
 		CloudSecretKey: account.Extension.CloudSecretKey,
+		CloudAccountID: account.Extension.CloudMainAccountID,
 	}
 
 	if err := secret.Validate(); err != nil {
@@ -66,31 +67,57 @@ func (cli *SecretClient) TCloudSecret(kt *kit.Kit, accountID string) (*types.Bas
 	return secret, nil
 }
 
-// AwsSecret get aws secret and validate secret.
-func (cli *SecretClient) AwsSecret(kt *kit.Kit, accountID string) (*types.BaseSecret, string, error) {
+// AwsCredential defines the credential of aws account, Role is set when the credential type is assume role,
+// otherwise Secret is set.
+type AwsCredential struct {
+	Secret         *types.BaseSecret
+	Role           *types.AwsAssumeRole
+	CloudAccountID string
+}
+
+// AwsCredential get aws credential and validate credential.
+func (cli *SecretClient) AwsCredential(kt *kit.Kit, accountID string) (*AwsCredential, error) {
 	account, err := cli.data.Aws.Account.Get(kt.Ctx, kt.Header(), accountID)
 	if err != nil {
-		return nil, "", fmt.Errorf("get aws account failed, err: %v", err)
+		return nil, fmt.Errorf("get aws account failed, err: %v", err)
 	}
 
 	if account.Type != enumor.ResourceAccount {
-		return nil, "", fmt.Errorf("account: %s not resource account type", accountID)
+		return nil, fmt.Errorf("account: %s not resource account type", accountID)
 	}
 
 	if account.Extension == nil {
-		return nil, "", errors.New("aws account extension is nil")
+		return nil, errors.New("aws account extension is nil")
 	}
 
-	secret := &types.BaseSecret{
-		CloudSecretID:  account.Extension.CloudSecretID,
-		CloudSecretKey: account.Extension.CloudSecretKey,
-	}
+	cred := &AwsCredential{CloudAccountID: account.Extension.CloudAccountID}
 
-	if err := secret.Validate(); err != nil {
-		return nil, "", err
+	switch {
+	case account.Extension.CloudCredentialType.IsSecretKey():
+		cred.Secret = &types.BaseSecret{
+			CloudSecretID:  account.Extension.CloudSecretID,
+			CloudSecretKey: account.Extension.CloudSecretKey,
+		}
+
+		if err := cred.Secret.Validate(); err != nil {
+			return nil, err
+		}
+
+	case account.Extension.CloudCredentialType == enumor.AssumeRoleCredential:
+		cred.Role = &types.AwsAssumeRole{
+			RoleArn:    account.Extension.CloudRoleArn,
+			ExternalID: account.Extension.CloudExternalID,
+		}
+
+		if err := cred.Role.Validate(); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("aws not support credential type: %s", account.Extension.CloudCredentialType)
 	}
 
-	return secret, account.Extension.CloudAccountID, nil
+	return cred, nil
 }
 
 // HuaWeiSecret get huawei secret and validate secret.
@@ -111,6 +138,7 @@ func (cli *SecretClient) HuaWeiSecret(kt *kit.Kit, accountID string) (*types.Bas
 	secret := &types.BaseSecret{
 		CloudSecretID:  account.Extension.CloudSecretID,
 		CloudSecretKey: account.Extension.CloudSecretKey,
+		CloudAccountID: account.Extension.CloudSubAccountID,
 	}
 
 	if err := secret.Validate(); err != nil {
@@ -140,6 +168,7 @@ func (cli *SecretClient) AzureCredential(kt *kit.Kit, accountID string) (*types.
 		CloudSubscriptionID:  account.Extension.CloudSubscriptionID,
 		CloudApplicationID:   account.Extension.CloudApplicationID,
 		CloudClientSecretKey: account.Extension.CloudClientSecretKey,
+		CredentialType:       account.Extension.CloudCredentialType,
 	}
 
 	if err := cred.Validate(); err != nil {
@@ -167,6 +196,7 @@ func (cli *SecretClient) GcpCredential(kt *kit.Kit, accountID string) (*types.Gc
 	cred := &types.GcpCredential{
 		CloudProjectID: account.Extension.CloudProjectID,
 		Json:           []byte(account.Extension.CloudServiceSecretKey),
+		CredentialType: account.Extension.CloudCredentialType,
 	}
 
 	if err := cred.Validate(); err != nil {
@@ -61,7 +61,7 @@ func HuaWeiSyncImage(da *imageAdaptor, cts *rest.Contexts) (interface{}, error)
 			Platform: platform,
 		}
 
-		datas, err := client.ListImage(opt)
+		datas, err := client.ListImage(cts.Kit, opt)
 		if err != nil {
 			logs.Errorf("request adaptor to list huawei Image failed, err: %v, rid: %s", err, cts.Kit.Rid)
 			return nil, err
@@ -162,7 +162,7 @@ func HuaWeiSyncImage(da *imageAdaptor, cts *rest.Contexts) (interface{}, error)
 
 		if len(deleteIDs) > 0 {
 			realDeleteIDs := make([]string, 0)
-			datas, err := client.ListImage(opt)
+			datas, err := client.ListImage(cts.Kit, opt)
 			if err != nil {
 				logs.Errorf("request adaptor to list huawei Image failed, err: %v, rid: %s", err, cts.Kit.Rid)
 				return nil, err
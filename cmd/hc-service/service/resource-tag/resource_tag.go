@@ -0,0 +1,409 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines the hc-service api of resource tag, the tags are written back to cloud.
+package resourcetag
+
+import (
+	"net/http"
+
+	"hcm/cmd/hc-service/service/capability"
+	cloudclient "hcm/cmd/hc-service/service/cloud-adaptor"
+	typestag "hcm/pkg/adaptor/types/tag"
+	"hcm/pkg/api/core"
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
+	dataresourcetag "hcm/pkg/api/data-service/resource-tag"
+	protoresourcetag "hcm/pkg/api/hc-service/resource-tag"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableresourcetag "hcm/pkg/dal/table/resource-tag"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+)
+
+// InitResourceTagService initial the resource tag service
+func InitResourceTagService(cap *capability.Capability) {
+	svc := &service{
+		adaptor: cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("UpdateResourceTag", http.MethodPut, "/vendors/{vendor}/resource_tags/update", svc.UpdateResourceTag)
+	h.Add("AddResourceTag", http.MethodPut, "/vendors/{vendor}/resource_tags/add", svc.AddResourceTag)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	adaptor *cloudclient.CloudAdaptorClient
+	dataCli *dataservice.Client
+}
+
+// tcloudTagResource is the tcloud service type and resource prefix of the resource type.
+var tcloudTagResource = map[table.Name][2]string{
+	table.CvmTable:              {"cvm", "instance"},
+	table.DiskTable:             {"cvm", "volume"},
+	table.EipTable:              {"cvm", "eip"},
+	table.SecurityGroupTable:    {"cvm", "sg"},
+	table.VpcTable:              {"vpc", "vpc"},
+	table.SubnetTable:           {"vpc", "subnet"},
+	table.RouteTableTable:       {"vpc", "rtb"},
+	table.NetworkInterfaceTable: {"vpc", "eni"},
+}
+
+// UpdateResourceTag update the tags of the resource, tags are written back to cloud first, then saved to db.
+func (svc *service) UpdateResourceTag(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(protoresourcetag.ResourceTagUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resType := table.Name(req.ResType)
+	info, err := svc.getResource(cts.Kit, vendor, resType, req.ResID)
+	if err != nil {
+		return nil, err
+	}
+
+	existTags, err := svc.listTags(cts.Kit, resType, req.ResID)
+	if err != nil {
+		return nil, err
+	}
+
+	upsertTags := make([]typestag.Tag, 0)
+	for _, one := range req.Tags {
+		value, exists := existTags[one.Key]
+		if !exists || value != one.Value {
+			upsertTags = append(upsertTags, typestag.Tag{Key: one.Key, Value: one.Value})
+		}
+		delete(existTags, one.Key)
+	}
+
+	deleteKeys := make([]string, 0, len(existTags))
+	for key := range existTags {
+		deleteKeys = append(deleteKeys, key)
+	}
+
+	if len(upsertTags) == 0 && len(deleteKeys) == 0 {
+		return nil, nil
+	}
+
+	if err = svc.updateCloudTags(cts.Kit, resType, info, upsertTags, deleteKeys); err != nil {
+		return nil, err
+	}
+
+	return nil, svc.replaceTags(cts.Kit, resType, info, req.Tags)
+}
+
+// AddResourceTag add the tags to the resource, the tags are only upserted on cloud, the other tags of the resource
+// on cloud are kept even if they are not synced to db yet.
+func (svc *service) AddResourceTag(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(protoresourcetag.ResourceTagAddReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resType := table.Name(req.ResType)
+	info, err := svc.getResource(cts.Kit, vendor, resType, req.ResID)
+	if err != nil {
+		return nil, err
+	}
+
+	existTags, err := svc.listTags(cts.Kit, resType, req.ResID)
+	if err != nil {
+		return nil, err
+	}
+
+	upsertTags := make([]typestag.Tag, 0, len(req.Tags))
+	for _, one := range req.Tags {
+		upsertTags = append(upsertTags, typestag.Tag{Key: one.Key, Value: one.Value})
+		delete(existTags, one.Key)
+	}
+
+	if err = svc.updateCloudTags(cts.Kit, resType, info, upsertTags, nil); err != nil {
+		return nil, err
+	}
+
+	tags := make([]coreresourcetag.Tag, 0, len(existTags)+len(req.Tags))
+	for key, value := range existTags {
+		tags = append(tags, coreresourcetag.Tag{Key: key, Value: value})
+	}
+	tags = append(tags, req.Tags...)
+
+	return nil, svc.replaceTags(cts.Kit, resType, info, tags)
+}
+
+// getResource get the basic info of the resource whose tags are to update, and check if it belongs to the vendor.
+func (svc *service) getResource(kt *kit.Kit, vendor enumor.Vendor, resType table.Name, resID string) (
+	*types.CloudResourceBasicInfo, error) {
+
+	if err := tableresourcetag.ValidateResType(resType); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	info, err := svc.dataCli.Global.Cloud.GetResourceBasicInfo(kt.Ctx, kt.Header(),
+		enumor.CloudResourceType(resType), resID, "id", "vendor", "account_id", "region", "cloud_id")
+	if err != nil {
+		logs.Errorf("get %s %s basic info failed, err: %v, rid: %s", resType, resID, err, kt.Rid)
+		return nil, err
+	}
+
+	if info.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "%s %s is not %s resource", resType, resID, vendor)
+	}
+
+	return info, nil
+}
+
+// listTags list the tags of the resource in db, returns the map of tag key to tag value.
+func (svc *service) listTags(kt *kit.Kit, resType table.Name, resID string) (map[string]string, error) {
+	expr, err := tools.And(
+		filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: resType},
+		filter.AtomRule{Field: "res_id", Op: filter.Equal.Factory(), Value: resID},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+		Fields: []string{"tag_key", "tag_value"},
+	}
+	result, err := svc.dataCli.Global.ResourceTag.ListResourceTag(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list %s %s tags failed, err: %v, rid: %s", resType, resID, err, kt.Rid)
+		return nil, err
+	}
+
+	tags := make(map[string]string, len(result.Details))
+	for _, one := range result.Details {
+		tags[one.TagKey] = one.TagValue
+	}
+
+	return tags, nil
+}
+
+// replaceTags replace the tags of the resource in db.
+func (svc *service) replaceTags(kt *kit.Kit, resType table.Name, info *types.CloudResourceBasicInfo,
+	tags []coreresourcetag.Tag) error {
+
+	replaceReq := &dataresourcetag.ResourceTagReplaceReq{
+		ResType: string(resType),
+		Resources: []dataresourcetag.ResourceTagReplaceItem{{
+			ResID:     info.ID,
+			Vendor:    info.Vendor,
+			AccountID: info.AccountID,
+			Tags:      tags,
+		}},
+	}
+	if err := svc.dataCli.Global.ResourceTag.ReplaceResourceTag(kt.Ctx, kt.Header(), replaceReq); err != nil {
+		logs.Errorf("replace %s %s tags failed, err: %v, rid: %s", resType, info.ID, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// updateCloudTags upsert and delete the tags of the resource on cloud, the other tags on cloud are not changed.
+func (svc *service) updateCloudTags(kt *kit.Kit, resType table.Name, info *types.CloudResourceBasicInfo,
+	upsertTags []typestag.Tag, deleteKeys []string) error {
+
+	switch info.Vendor {
+	case enumor.TCloud:
+		return svc.updateTCloudTags(kt, resType, info, upsertTags, deleteKeys)
+	case enumor.Aws:
+		return svc.updateAwsTags(kt, info, upsertTags, deleteKeys)
+	case enumor.Azure:
+		return svc.updateAzureTags(kt, info, upsertTags, deleteKeys)
+	case enumor.Gcp:
+		return svc.updateGcpLabels(kt, resType, info, upsertTags, deleteKeys)
+	case enumor.HuaWei:
+		return svc.updateHuaWeiTags(kt, resType, info, upsertTags, deleteKeys)
+	default:
+		return errf.Newf(errf.InvalidParameter, "%s does not support the update of resource tags", info.Vendor)
+	}
+}
+
+func (svc *service) updateTCloudTags(kt *kit.Kit, resType table.Name, info *types.CloudResourceBasicInfo,
+	upsertTags []typestag.Tag, deleteKeys []string) error {
+
+	resource, exists := tcloudTagResource[resType]
+	if !exists {
+		return errf.Newf(errf.InvalidParameter, "tcloud %s does not support the update of resource tags", resType)
+	}
+
+	client, err := svc.adaptor.TCloud(kt, info.AccountID)
+	if err != nil {
+		return err
+	}
+
+	opt := &typestag.TCloudTagUpdateOption{
+		Region:         info.Region,
+		ServiceType:    resource[0],
+		ResourcePrefix: resource[1],
+		CloudResID:     info.CloudID,
+		UpsertTags:     upsertTags,
+		DeleteKeys:     deleteKeys,
+	}
+	return client.UpdateResourceTags(kt, opt)
+}
+
+func (svc *service) updateAwsTags(kt *kit.Kit, info *types.CloudResourceBasicInfo, upsertTags []typestag.Tag,
+	deleteKeys []string) error {
+
+	client, err := svc.adaptor.Aws(kt, info.AccountID)
+	if err != nil {
+		return err
+	}
+
+	opt := &typestag.AwsTagUpdateOption{
+		Region:     info.Region,
+		CloudResID: info.CloudID,
+		UpsertTags: upsertTags,
+		DeleteKeys: deleteKeys,
+	}
+	return client.UpdateResourceTags(kt, opt)
+}
+
+// gcpLabelResource is the gcp resource type that supports labels.
+var gcpLabelResource = map[table.Name]typestag.ResType{
+	table.CvmTable:  typestag.CvmResType,
+	table.DiskTable: typestag.DiskResType,
+	table.EipTable:  typestag.EipResType,
+}
+
+func (svc *service) updateGcpLabels(kt *kit.Kit, resType table.Name, info *types.CloudResourceBasicInfo,
+	upsertTags []typestag.Tag, deleteKeys []string) error {
+
+	labelResType, exists := gcpLabelResource[resType]
+	if !exists {
+		return errf.Newf(errf.InvalidParameter, "gcp %s does not support the update of resource labels", resType)
+	}
+
+	opt := &typestag.GcpLabelUpdateOption{
+		ResType:    labelResType,
+		UpsertTags: upsertTags,
+		DeleteKeys: deleteKeys,
+	}
+
+	// gcp labels are set by resource name and zone or region, which are not in the basic info.
+	switch resType {
+	case table.CvmTable:
+		cvm, err := svc.dataCli.Gcp.Cvm.GetCvm(kt.Ctx, kt.Header(), info.ID)
+		if err != nil {
+			logs.Errorf("get gcp cvm %s failed, err: %v, rid: %s", info.ID, err, kt.Rid)
+			return err
+		}
+		opt.Location, opt.Name = cvm.Zone, cvm.Name
+	case table.DiskTable:
+		disk, err := svc.dataCli.Gcp.RetrieveDisk(kt.Ctx, kt.Header(), info.ID)
+		if err != nil {
+			logs.Errorf("get gcp disk %s failed, err: %v, rid: %s", info.ID, err, kt.Rid)
+			return err
+		}
+		opt.Location, opt.Name = disk.Zone, disk.Name
+	case table.EipTable:
+		eip, err := svc.dataCli.Gcp.RetrieveEip(kt.Ctx, kt.Header(), info.ID)
+		if err != nil {
+			logs.Errorf("get gcp eip %s failed, err: %v, rid: %s", info.ID, err, kt.Rid)
+			return err
+		}
+		opt.Location, opt.Name = eip.Region, converter.PtrToVal(eip.Name)
+	}
+
+	client, err := svc.adaptor.Gcp(kt, info.AccountID)
+	if err != nil {
+		return err
+	}
+
+	return client.UpdateResourceLabels(kt, opt)
+}
+
+// huaweiTagResource is the huawei resource type that supports the update of tags.
+var huaweiTagResource = map[table.Name]typestag.ResType{
+	table.CvmTable:  typestag.CvmResType,
+	table.DiskTable: typestag.DiskResType,
+	table.VpcTable:  typestag.VpcResType,
+}
+
+func (svc *service) updateHuaWeiTags(kt *kit.Kit, resType table.Name, info *types.CloudResourceBasicInfo,
+	upsertTags []typestag.Tag, deleteKeys []string) error {
+
+	tagResType, exists := huaweiTagResource[resType]
+	if !exists {
+		return errf.Newf(errf.InvalidParameter, "huawei %s does not support the update of resource tags", resType)
+	}
+
+	client, err := svc.adaptor.HuaWei(kt, info.AccountID)
+	if err != nil {
+		return err
+	}
+
+	opt := &typestag.HuaWeiTagUpdateOption{
+		ResType:    tagResType,
+		Region:     info.Region,
+		CloudResID: info.CloudID,
+		UpsertTags: upsertTags,
+		DeleteKeys: deleteKeys,
+	}
+	return client.UpdateResourceTags(kt, opt)
+}
+
+func (svc *service) updateAzureTags(kt *kit.Kit, info *types.CloudResourceBasicInfo, upsertTags []typestag.Tag,
+	deleteKeys []string) error {
+
+	client, err := svc.adaptor.Azure(kt, info.AccountID)
+	if err != nil {
+		return err
+	}
+
+	opt := &typestag.AzureTagUpdateOption{
+		CloudResID: info.CloudID,
+		UpsertTags: upsertTags,
+		DeleteKeys: deleteKeys,
+	}
+	return client.UpdateResourceTags(kt, opt)
+}
@@ -40,14 +40,18 @@ import (
 	instancetype "hcm/cmd/hc-service/service/instance-type"
 	"hcm/cmd/hc-service/service/region"
 	resourcegroup "hcm/cmd/hc-service/service/resource-group"
+	resourcetag "hcm/cmd/hc-service/service/resource-tag"
 	routetable "hcm/cmd/hc-service/service/route-table"
 	securitygroup "hcm/cmd/hc-service/service/security-group"
 	"hcm/cmd/hc-service/service/subnet"
 	"hcm/cmd/hc-service/service/sync"
 	"hcm/cmd/hc-service/service/vpc"
 	"hcm/cmd/hc-service/service/zone"
+	"hcm/pkg/adaptor/throttle"
+	"hcm/pkg/adaptor/types"
 	"hcm/pkg/cc"
 	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/handler"
 	"hcm/pkg/logs"
@@ -76,7 +80,15 @@ func NewService(dis serviced.Discover) (*Service, error) {
 
 	cliSet := client.NewClientSet(cli, dis)
 
-	cloudAdaptor := cloudadaptor.NewCloudAdaptorClient(cliSet.DataService())
+	// all the adaptor clients, including the ones used by poller and resource sync, share the global throttler.
+	throttleOpt, err := cloudApiThrottleOption(cc.HCService().CloudApiRateLimit)
+	if err != nil {
+		return nil, err
+	}
+	throttle.Init(throttleOpt)
+
+	cloudAdaptor := cloudadaptor.NewCloudAdaptorClient(trustedIdentity(cc.HCService().TrustedIdentity),
+		cliSet.DataService())
 
 	svr := &Service{
 		clientSet:    cliSet,
@@ -86,6 +98,48 @@ func NewService(dis serviced.Discover) (*Service, error) {
 	return svr, nil
 }
 
+// trustedIdentity convert the trusted identity setting to the trusted identity used by adaptor.
+func trustedIdentity(opt cc.TrustedIdentity) *types.TrustedIdentity {
+	identity := &types.TrustedIdentity{
+		AwsRegion:               opt.Aws.Region,
+		AwsSessionDuration:      time.Duration(opt.Aws.SessionDurationMin) * time.Minute,
+		AzureFederatedTokenFile: opt.Azure.FederatedTokenFile,
+	}
+
+	if len(opt.Aws.SecretID) != 0 {
+		identity.Aws = &types.BaseSecret{CloudSecretID: opt.Aws.SecretID, CloudSecretKey: opt.Aws.SecretKey}
+	}
+
+	return identity
+}
+
+// cloudApiThrottleOption convert the cloud api rate limit setting to the option of adaptor throttler.
+func cloudApiThrottleOption(opt cc.CloudApiRateLimit) (*throttle.Option, error) {
+	throttleOpt := &throttle.Option{
+		Enable:     opt.Enable,
+		QPS:        opt.QPS,
+		Burst:      int(opt.Burst),
+		MaxBackoff: time.Duration(opt.MaxBackoffSec) * time.Second,
+		Rules:      make([]throttle.Rule, 0, len(opt.Rules)),
+	}
+
+	for _, rule := range opt.Rules {
+		vendor := enumor.Vendor(rule.Vendor)
+		if err := vendor.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid cloudApiRateLimit rule, err: %v", err)
+		}
+
+		throttleOpt.Rules = append(throttleOpt.Rules, throttle.Rule{
+			Vendor: vendor,
+			Family: rule.Family,
+			QPS:    rule.QPS,
+			Burst:  int(rule.Burst),
+		})
+	}
+
+	return throttleOpt, nil
+}
+
 // ListenAndServeRest listen and serve the restful server
 func (s *Service) ListenAndServeRest() error {
 	root := http.NewServeMux()
@@ -169,6 +223,7 @@ func (s *Service) apiSet() *restful.Container {
 	eip.InitEipService(c)
 	instancetype.InitInstanceTypeService(c)
 	resourcegroup.InitResourceGroupService(c)
+	resourcetag.InitResourceTagService(c)
 	sync.InitService(c)
 	bill.InitBillService(c)
 
@@ -45,6 +45,9 @@ type Handler interface {
 func ResourceSync(cts *rest.Contexts, handler Handler) error {
 	kt := cts.Kit
 
+	// 同步过程中会查询刚写入的资源（如关联资源的ID），整个同步请求都需读主库，避免读到从库延迟的数据
+	kt.ForceReadPrimary()
+
 	// 解析请求参数到handler实现中，构建同步需要的客户端
 	if err := handler.Prepare(cts); err != nil {
 		logs.Errorf("%s sync handler to prepare failed, err: %v, rid: %s", handler.Name(), err, kt.Rid)
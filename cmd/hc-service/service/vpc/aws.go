@@ -82,6 +82,9 @@ func (v vpc) AwsVpcCreate(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.New(errf.Aborted, "create result is invalid")
 	}
 
+	// 子网创建时会查询刚创建的vpc，需读主库
+	cts.Kit.ForceReadPrimary()
+
 	// create aws subnets
 	if len(req.Extension.Subnets) > 0 {
 		subnetCreateOpt := &subnet.SubnetCreateOptions[subnetproto.AwsSubnetCreateExt]{
@@ -83,6 +83,9 @@ func (v vpc) AzureVpcCreate(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.New(errf.Aborted, "create result is invalid")
 	}
 
+	// 子网创建时会查询刚创建的vpc，需读主库
+	cts.Kit.ForceReadPrimary()
+
 	if len(req.Extension.Subnets) == 0 {
 		return core.CreateResult{ID: result.IDs[0]}, nil
 	}
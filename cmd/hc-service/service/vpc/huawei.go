@@ -97,6 +97,9 @@ func (v vpc) HuaWeiVpcCreate(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.New(errf.Aborted, "create result is invalid")
 	}
 
+	// 子网创建时会查询刚创建的vpc，需读主库
+	cts.Kit.ForceReadPrimary()
+
 	// create huawei subnets
 	if len(req.Extension.Subnets) == 0 {
 		return core.CreateResult{ID: result.IDs[0]}, nil
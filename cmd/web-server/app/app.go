@@ -33,6 +33,7 @@ import (
 	"hcm/pkg/runtime/ctl/cmd"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the web server
@@ -65,6 +66,10 @@ func (s *webService) prepare(opt *options.Option) error {
 
 	logs.InitLogger(cc.WebServer().Log.Logs())
 
+	if err := tracing.InitTracing(cc.WebServer().Tracing); err != nil {
+		return fmt.Errorf("init tracing failed, err: %v", err)
+	}
+
 	logs.Infof("load settings from config file success.")
 
 	// init metrics
@@ -72,7 +77,7 @@ func (s *webService) prepare(opt *options.Option) error {
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
 	// new api server discovery client.
-	discOpt := serviced.DiscoveryOption{Services: []cc.Name{cc.CloudServerName, cc.AuthServerName}}
+	discOpt := serviced.DiscoveryOption{Services: []cc.Name{cc.CloudServerName, cc.AuthServerName, cc.DataServiceName}}
 	dis, err := serviced.NewDiscovery(cc.WebServer().Service, discOpt)
 	if err != nil {
 		return fmt.Errorf("new service discovery faield, err: %v", err)
@@ -21,19 +21,21 @@ package service
 
 import (
 	"bytes"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"regexp"
 	"strings"
 
+	"hcm/cmd/web-server/service/login"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
-	"hcm/pkg/thirdparty/esb"
 	"hcm/pkg/tools/uuid"
+	"hcm/pkg/tracing"
 
 	"github.com/emicklei/go-restful/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func isITSMCallbackRequest(req *restful.Request) bool {
@@ -44,56 +46,18 @@ func isITSMCallbackRequest(req *restful.Request) bool {
 	return false
 }
 
-func newCheckLogin(esbClient esb.Client, bkLoginUrl, bkLoginCookieName string) func(*restful.Request) (string, error) {
-	if bkLoginCookieName == "bk_ticket" {
-		// 解析Login URL
-		oaLoginClient, err := newOALoginClient(bkLoginUrl)
-		if err != nil {
-			// 登录有问题，则启动没意义
-			panic(err)
-		}
-
-		return func(req *restful.Request) (string, error) {
-			// 获取cookie
-			cookie, err := req.Request.Cookie(bkLoginCookieName)
-			// Note: err只有一个ErrNoCookie可能，所以这里是无登录票据的情况
-			if err != nil || cookie.Value == "" {
-				return "", fmt.Errorf("%s cookie don't exists", bkLoginCookieName)
-			}
-			// 校验bk_token是否有效
-			username, err := oaLoginClient.Verify(req.Request.Context(), cookie.Value)
-			if err != nil {
-				return "", err
-			}
-			return username, nil
-		}
-	}
-
-	// 默认只能是bk_token,不支持其他的
-	bkLoginCookieName = "bk_token"
-
-	return func(req *restful.Request) (string, error) {
-		// 获取cookie
-		cookie, err := req.Request.Cookie(bkLoginCookieName)
-		// Note: err只有一个ErrNoCookie可能，所以这里是无登录票据的情况
-		if err != nil || cookie.Value == "" {
-			return "", fmt.Errorf("%s cookie don't exists", bkLoginCookieName)
-		}
-		// 校验bk_token是否有效
-		username, err := esbClient.Login().IsLogin(req.Request.Context(), cookie.Value)
-		if err != nil {
-			return "", err
-		}
-		return username, nil
-	}
-}
-
-// NewUserAuthenticateFilter ...
-func NewUserAuthenticateFilter(esbClient esb.Client, bkLoginUrl, bkLoginCookieName string) restful.FilterFunction {
-
-	checkLogin := newCheckLogin(esbClient, bkLoginUrl, bkLoginCookieName)
-
+// NewUserAuthenticateFilter authenticate user by the login authenticator.
+func NewUserAuthenticateFilter(authenticator login.Authenticator) restful.FilterFunction {
 	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		ctx, span := tracing.Tracer().Start(tracing.Extract(req.Request.Context(), req.Request.Header),
+			req.Request.Method+" "+req.Request.URL.Path, trace.WithSpanKind(trace.SpanKindServer))
+		defer func() {
+			span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode()))
+			span.End()
+		}()
+		// 将当前的trace上下文写入Header，代理到cloud-server的请求以及本服务的处理函数都会延续该trace
+		tracing.Inject(ctx, req.Request.Header)
+
 		var err error
 		username := ""
 		// 对于itsm 的回调请求，不能用户认证，而是处理请求时进行单独的Token认证，这里直接通过
@@ -101,7 +65,7 @@ func NewUserAuthenticateFilter(esbClient esb.Client, bkLoginUrl, bkLoginCookieNa
 			username = "itsm_callback"
 			req.Request.Header.Set(constant.RidKey, uuid.UUID())
 		} else {
-			username, err = checkLogin(req)
+			username, err = authenticator.Authenticate(req)
 			if err != nil {
 				resp.WriteError(http.StatusUnauthorized, err)
 				return
@@ -113,7 +77,7 @@ func NewUserAuthenticateFilter(esbClient esb.Client, bkLoginUrl, bkLoginCookieNa
 		req.Request.Header.Set(constant.AppCodeKey, "hcm-web-server")
 
 		// 使用Kit便于校验通用的Header是否满足
-		kt, err := kit.FromHeader(req.Request.Context(), req.Request.Header)
+		kt, err := kit.FromHeader(ctx, req.Request.Header)
 		if err != nil {
 			resp.WriteError(http.StatusForbidden, err)
 			return
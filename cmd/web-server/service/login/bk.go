@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package login
+
+import (
+	"fmt"
+
+	"hcm/pkg/thirdparty/esb"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// bkAuthenticator authenticates user by blueking login, bk_token cookie is verified by esb login api, bk_ticket
+// cookie is verified by oa login api.
+type bkAuthenticator struct {
+	loginUrl   string
+	checkLogin func(*restful.Request) (string, error)
+}
+
+func newBkAuthenticator(esbClient esb.Client, bkLoginUrl, bkLoginCookieName string) (*bkAuthenticator, error) {
+	checkLogin, err := newCheckLogin(esbClient, bkLoginUrl, bkLoginCookieName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bkAuthenticator{
+		loginUrl:   bkLoginUrl,
+		checkLogin: checkLogin,
+	}, nil
+}
+
+// Authenticate returns the user that blueking login cookie belongs to.
+func (a *bkAuthenticator) Authenticate(req *restful.Request) (string, error) {
+	return a.checkLogin(req)
+}
+
+// LoginURL returns the blueking login url.
+func (a *bkAuthenticator) LoginURL() string {
+	return a.loginUrl
+}
+
+// WebService blueking login pages are provided by blueking login, no api is needed.
+func (a *bkAuthenticator) WebService() *restful.WebService {
+	return nil
+}
+
+func newCheckLogin(esbClient esb.Client, bkLoginUrl, bkLoginCookieName string) (func(*restful.Request) (string,
+	error), error) {
+
+	if bkLoginCookieName == "bk_ticket" {
+		// 解析Login URL
+		oaLoginClient, err := newOALoginClient(bkLoginUrl)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(req *restful.Request) (string, error) {
+			// 获取cookie
+			cookie, err := req.Request.Cookie(bkLoginCookieName)
+			// Note: err只有一个ErrNoCookie可能，所以这里是无登录票据的情况
+			if err != nil || cookie.Value == "" {
+				return "", fmt.Errorf("%s cookie don't exists", bkLoginCookieName)
+			}
+			// 校验bk_token是否有效
+			username, err := oaLoginClient.Verify(req.Request.Context(), cookie.Value)
+			if err != nil {
+				return "", err
+			}
+			return username, nil
+		}, nil
+	}
+
+	// 默认只能是bk_token,不支持其他的
+	bkLoginCookieName = "bk_token"
+
+	return func(req *restful.Request) (string, error) {
+		// 获取cookie
+		cookie, err := req.Request.Cookie(bkLoginCookieName)
+		// Note: err只有一个ErrNoCookie可能，所以这里是无登录票据的情况
+		if err != nil || cookie.Value == "" {
+			return "", fmt.Errorf("%s cookie don't exists", bkLoginCookieName)
+		}
+		// 校验bk_token是否有效
+		username, err := esbClient.Login().IsLogin(req.Request.Context(), cookie.Value)
+		if err != nil {
+			return "", err
+		}
+		return username, nil
+	}, nil
+}
+
+var _ Authenticator = new(bkAuthenticator)
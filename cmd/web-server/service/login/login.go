@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package login defines the user login authenticators of web-server.
+package login
+
+import (
+	"context"
+	"fmt"
+
+	"hcm/pkg/cc"
+	apiclient "hcm/pkg/client"
+	"hcm/pkg/thirdparty/esb"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// Authenticator authenticates the user of web requests.
+type Authenticator interface {
+	// Authenticate returns the name of the user that the request is logged in as.
+	Authenticate(req *restful.Request) (string, error)
+	// LoginURL returns the url that frontend redirects to for login and logout, the redirect url is set by c_url
+	// parameter, is_from_logout=1 parameter means logout.
+	LoginURL() string
+	// WebService returns the login related apis that do not need authentication, e.g. sso callback, returns nil
+	// if no api is needed.
+	WebService() *restful.WebService
+}
+
+// NewAuthenticator create authenticator by login config.
+func NewAuthenticator(ctx context.Context, web cc.Web, esbClient esb.Client, client *apiclient.ClientSet) (
+	Authenticator, error) {
+
+	switch web.Login.Type {
+	case cc.BkLogin:
+		return newBkAuthenticator(esbClient, web.BkLoginUrl, web.BkLoginCookieName)
+	case cc.OIDCLogin:
+		return newOIDCAuthenticator(ctx, web.Login.OIDC, client)
+	default:
+		return nil, fmt.Errorf("unsupported login type: %s", web.Login.Type)
+	}
+}
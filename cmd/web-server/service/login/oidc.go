@@ -0,0 +1,306 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package login
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"hcm/pkg/cc"
+	apiclient "hcm/pkg/client"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest/client"
+	"hcm/pkg/thirdparty/oidc"
+	"hcm/pkg/tools/ssl"
+	"hcm/pkg/tools/uuid"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// ssoPath is the path prefix of sso apis.
+	ssoPath = "/sso"
+	// sessionCookieName is the cookie name of the login session.
+	sessionCookieName = "bkhcm_session"
+	// stateCookieName is the cookie name of the oidc authorization request state.
+	stateCookieName = "bkhcm_oidc_state"
+	// stateTTL is the max duration between redirecting to the provider and the callback.
+	stateTTL = 10 * time.Minute
+)
+
+// oidcAuthenticator authenticates user by OpenID Connect authorization code flow, the logged-in user is stored in
+// signed session cookie.
+type oidcAuthenticator struct {
+	cfg      cc.OIDC
+	provider *oidc.Provider
+	signer   *signer
+	roles    *roleSyncer
+}
+
+func newOIDCAuthenticator(ctx context.Context, cfg cc.OIDC, apiClient *apiclient.ClientSet) (*oidcAuthenticator,
+	error) {
+
+	var tlsConfig *ssl.TLSConfig
+	if cfg.TLS.Enable() || cfg.TLS.InsecureSkipVerify {
+		tlsConfig = &ssl.TLSConfig{
+			InsecureSkipVerify: cfg.TLS.InsecureSkipVerify,
+			CertFile:           cfg.TLS.CertFile,
+			KeyFile:            cfg.TLS.KeyFile,
+			CAFile:             cfg.TLS.CAFile,
+			Password:           cfg.TLS.Password,
+		}
+	}
+
+	cli, err := client.NewClient(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := oidc.NewProvider(ctx, &oidc.Option{
+		Issuer:       cfg.Issuer,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       cfg.Scopes,
+		Client:       cli,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &oidcAuthenticator{
+		cfg:      cfg,
+		provider: provider,
+		signer:   &signer{secret: []byte(cfg.SessionSecret)},
+		roles:    newRoleSyncer(apiClient, cfg.GroupRoles),
+	}, nil
+}
+
+// session is the login session stored in cookie.
+type session struct {
+	User string `json:"u"`
+}
+
+// authState is the state of authorization request stored in cookie, it is verified in callback.
+type authState struct {
+	State       string `json:"s"`
+	Nonce       string `json:"n"`
+	RedirectURL string `json:"r"`
+}
+
+// Authenticate returns the user of the session cookie.
+func (a *oidcAuthenticator) Authenticate(req *restful.Request) (string, error) {
+	cookie, err := req.Request.Cookie(sessionCookieName)
+	if err != nil || cookie.Value == "" {
+		return "", fmt.Errorf("%s cookie don't exists", sessionCookieName)
+	}
+
+	sess := new(session)
+	if err = a.signer.verify(cookie.Value, sess); err != nil {
+		return "", fmt.Errorf("session is invalid, err: %v", err)
+	}
+
+	if len(sess.User) == 0 {
+		return "", errors.New("session user is empty")
+	}
+
+	return sess.User, nil
+}
+
+// LoginURL returns the sso login url.
+func (a *oidcAuthenticator) LoginURL() string {
+	return ssoPath + "/login"
+}
+
+// WebService returns the sso login, callback and logout apis.
+func (a *oidcAuthenticator) WebService() *restful.WebService {
+	ws := new(restful.WebService)
+	ws.Path(ssoPath)
+
+	ws.Route(ws.GET("/login").To(a.login))
+	// frontend redirects to {login url}/?c_url={redirect url}
+	ws.Route(ws.GET("/login/{subpath:*}").To(a.login))
+	ws.Route(ws.GET("/callback").To(a.callback))
+	ws.Route(ws.GET("/logout").To(a.logout))
+
+	return ws
+}
+
+// login redirect to the provider's authorization page, or logout if is_from_logout=1 is set.
+func (a *oidcAuthenticator) login(req *restful.Request, resp *restful.Response) {
+	if req.QueryParameter("is_from_logout") == "1" {
+		a.logout(req, resp)
+		return
+	}
+
+	st := &authState{RedirectURL: safeRedirectURL(req.Request, req.QueryParameter("c_url"))}
+	var err error
+	if st.State, err = randomString(); err != nil {
+		resp.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	if st.Nonce, err = randomString(); err != nil {
+		resp.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	signed, err := a.signer.sign(st, stateTTL)
+	if err != nil {
+		resp.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	setCookie(req.Request, resp, stateCookieName, signed, stateTTL)
+	http.Redirect(resp.ResponseWriter, req.Request, a.provider.AuthCodeURL(st.State, st.Nonce), http.StatusFound)
+}
+
+// callback verify the authorization response, exchange the code to id token, sync the roles of user's groups and
+// create the login session.
+func (a *oidcAuthenticator) callback(req *restful.Request, resp *restful.Response) {
+	rid := uuid.UUID()
+
+	if errMsg := req.QueryParameter("error"); len(errMsg) != 0 {
+		logs.Errorf("oidc authorization failed, err: %s, desc: %s, rid: %s", errMsg,
+			req.QueryParameter("error_description"), rid)
+		resp.WriteErrorString(http.StatusUnauthorized, "sso authorization failed: "+errMsg)
+		return
+	}
+
+	cookie, err := req.Request.Cookie(stateCookieName)
+	if err != nil || cookie.Value == "" {
+		resp.WriteErrorString(http.StatusBadRequest, "sso state cookie don't exists")
+		return
+	}
+
+	st := new(authState)
+	if err = a.signer.verify(cookie.Value, st); err != nil || st.State != req.QueryParameter("state") {
+		resp.WriteErrorString(http.StatusBadRequest, "sso state is invalid")
+		return
+	}
+
+	_, claims, err := a.provider.Exchange(req.Request.Context(), req.QueryParameter("code"), st.Nonce)
+	if err != nil {
+		logs.Errorf("oidc exchange authorization code failed, err: %v, rid: %s", err, rid)
+		resp.WriteErrorString(http.StatusUnauthorized, "sso authorization code is invalid")
+		return
+	}
+
+	user, ok := claims[a.cfg.UsernameClaim].(string)
+	if !ok || len(user) == 0 {
+		logs.Errorf("oidc id token lacks username claim %s, rid: %s", a.cfg.UsernameClaim, rid)
+		resp.WriteErrorString(http.StatusUnauthorized, "sso id token lacks username")
+		return
+	}
+
+	if err = a.roles.sync(req.Request.Context(), rid, user, groupsFromClaims(claims, a.cfg.GroupsClaim)); err != nil {
+		logs.Errorf("sync user %s roles by oidc groups failed, err: %v, rid: %s", user, err, rid)
+		resp.WriteErrorString(http.StatusInternalServerError, "sync user roles failed")
+		return
+	}
+
+	ttl := time.Duration(a.cfg.SessionTTLSec) * time.Second
+	signed, err := a.signer.sign(&session{User: user}, ttl)
+	if err != nil {
+		resp.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	setCookie(req.Request, resp, stateCookieName, "", -1)
+	setCookie(req.Request, resp, sessionCookieName, signed, ttl)
+
+	logs.Infof("user %s login by oidc success, rid: %s", user, rid)
+	http.Redirect(resp.ResponseWriter, req.Request, st.RedirectURL, http.StatusFound)
+}
+
+// logout clear the login session and logout from the provider if it supports.
+func (a *oidcAuthenticator) logout(req *restful.Request, resp *restful.Response) {
+	setCookie(req.Request, resp, sessionCookieName, "", -1)
+
+	redirect := a.cfg.PostLogoutRedirectURL
+	if endSession := a.provider.EndSessionURL("", redirect); len(endSession) != 0 {
+		redirect = endSession
+	}
+
+	http.Redirect(resp.ResponseWriter, req.Request, redirect, http.StatusFound)
+}
+
+// groupsFromClaims get user groups from claims, the claim can be string array or string.
+func groupsFromClaims(claims jwt.MapClaims, claim string) []string {
+	switch value := claims[claim].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		groups := make([]string, 0, len(value))
+		for _, one := range value {
+			if group, ok := one.(string); ok {
+				groups = append(groups, group)
+			}
+		}
+		return groups
+	default:
+		return nil
+	}
+}
+
+// safeRedirectURL only allow redirecting to hcm itself after login, in case of open redirect.
+func safeRedirectURL(req *http.Request, redirect string) string {
+	u, err := url.Parse(redirect)
+	if err != nil || len(redirect) == 0 {
+		return "/"
+	}
+
+	if len(u.Host) == 0 {
+		if len(u.Path) == 0 || u.Path[0] != '/' || len(u.Scheme) != 0 {
+			return "/"
+		}
+		return u.RequestURI()
+	}
+
+	if u.Host != req.Host || (u.Scheme != "http" && u.Scheme != "https") {
+		return "/"
+	}
+
+	return u.String()
+}
+
+// setCookie set http only cookie, cookie is deleted if ttl is negative.
+func setCookie(req *http.Request, resp *restful.Response, name, value string, ttl time.Duration) {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   req.TLS != nil || req.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	if ttl < 0 {
+		cookie.MaxAge = -1
+	} else {
+		cookie.MaxAge = int(ttl.Seconds())
+	}
+
+	http.SetCookie(resp.ResponseWriter, cookie)
+}
@@ -0,0 +1,174 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package login
+
+import (
+	"context"
+
+	"hcm/pkg/api/core"
+	protorbac "hcm/pkg/api/data-service/rbac"
+	apiclient "hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// roleSyncer grants or revokes the built-in rbac roles of user by the groups that user belongs to, only the roles
+// configured in group roles mapping are managed, roles bound manually are not affected.
+type roleSyncer struct {
+	client *apiclient.ClientSet
+	// groupRoles is the mapping of group to role names.
+	groupRoles map[string][]string
+	// managedRoles is all the role names in the mapping.
+	managedRoles []string
+}
+
+func newRoleSyncer(client *apiclient.ClientSet, groupRoles map[string][]string) *roleSyncer {
+	managed := make([]string, 0)
+	for _, roles := range groupRoles {
+		managed = append(managed, roles...)
+	}
+
+	return &roleSyncer{
+		client:       client,
+		groupRoles:   groupRoles,
+		managedRoles: slice.Unique(managed),
+	}
+}
+
+// sync the managed role bindings of the user with all scope by user's groups.
+func (r *roleSyncer) sync(ctx context.Context, rid, user string, groups []string) error {
+	if len(r.managedRoles) == 0 {
+		return nil
+	}
+
+	kt := kit.New()
+	kt.Ctx = ctx
+	kt.Rid = rid
+	kt.User = constant.SSORoleSyncUserKey
+	kt.AppCode = constant.SSORoleSyncAppCodeKey
+
+	roleIDs, err := r.listRoleIDs(kt)
+	if err != nil {
+		return err
+	}
+
+	expected := make(map[string]struct{})
+	for _, group := range groups {
+		for _, name := range r.groupRoles[group] {
+			id, exists := roleIDs[name]
+			if !exists {
+				logs.Warnf("role %s mapped by group %s not exists, skip it, rid: %s", name, group, kt.Rid)
+				continue
+			}
+			expected[id] = struct{}{}
+		}
+	}
+
+	managedIDs := make([]string, 0, len(roleIDs))
+	for _, id := range roleIDs {
+		managedIDs = append(managedIDs, id)
+	}
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "subject", Op: filter.Equal.Factory(), Value: user},
+				filter.AtomRule{Field: "scope_type", Op: filter.Equal.Factory(), Value: enumor.AllRbacScope},
+				filter.AtomRule{Field: "role_id", Op: filter.In.Factory(), Value: managedIDs},
+			},
+		},
+		Page: core.DefaultBasePage,
+	}
+	bindings, err := r.client.DataService().Global.Rbac.ListRoleBinding(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return err
+	}
+
+	staleIDs := make([]string, 0)
+	for _, binding := range bindings.Details {
+		if _, exists := expected[binding.RoleID]; exists {
+			delete(expected, binding.RoleID)
+			continue
+		}
+		staleIDs = append(staleIDs, binding.ID)
+	}
+
+	if len(expected) != 0 {
+		createReq := &protorbac.RoleBindingBatchCreateReq{
+			Bindings: make([]protorbac.RoleBindingCreateReq, 0, len(expected)),
+		}
+		for id := range expected {
+			createReq.Bindings = append(createReq.Bindings, protorbac.RoleBindingCreateReq{
+				RoleID:    id,
+				Subject:   user,
+				ScopeType: enumor.AllRbacScope,
+			})
+		}
+
+		if _, err = r.client.DataService().Global.Rbac.BatchCreateRoleBinding(kt.Ctx, kt.Header(),
+			createReq); err != nil {
+			return err
+		}
+	}
+
+	if len(staleIDs) != 0 {
+		deleteReq := &protorbac.RoleBindingDeleteReq{
+			Filter: &filter.Expression{
+				Op:    filter.And,
+				Rules: []filter.RuleFactory{filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: staleIDs}},
+			},
+		}
+		if err = r.client.DataService().Global.Rbac.DeleteRoleBinding(kt.Ctx, kt.Header(), deleteReq); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listRoleIDs list the managed roles, returns the mapping of role name to id.
+func (r *roleSyncer) listRoleIDs(kt *kit.Kit) (map[string]string, error) {
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "name", Op: filter.In.Factory(), Value: r.managedRoles},
+			},
+		},
+		Page:   core.DefaultBasePage,
+		Fields: []string{"id", "name"},
+	}
+	roles, err := r.client.DataService().Global.Rbac.ListRole(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDs := make(map[string]string, len(roles.Details))
+	for _, role := range roles.Details {
+		roleIDs[role.Name] = role.ID
+	}
+
+	return roleIDs, nil
+}
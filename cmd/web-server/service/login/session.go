@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package login
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// signer signs and verifies the cookie values, so that the login state can be stored in cookies and shared by all
+// web-server instances.
+type signer struct {
+	secret []byte
+}
+
+// sign encode the value with its expire time and sign it.
+func (s *signer) sign(value interface{}, ttl time.Duration) (string, error) {
+	payload, err := json.Marshal(signedValue{Value: value, ExpiredAt: time.Now().Add(ttl).Unix()})
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded)), nil
+}
+
+// verify the signature and expire time of the signed value, and decode it into value.
+func (s *signer) verify(signed string, value interface{}) error {
+	encoded, sig, found := strings.Cut(signed, ".")
+	if !found {
+		return errors.New("signed value is invalid")
+	}
+
+	decodedSig, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(decodedSig, s.mac(encoded)) {
+		return errors.New("signature is invalid")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+
+	sv := signedValue{Value: value}
+	if err = json.Unmarshal(payload, &sv); err != nil {
+		return err
+	}
+
+	if time.Now().Unix() > sv.ExpiredAt {
+		return errors.New("signed value is expired")
+	}
+
+	return nil
+}
+
+func (s *signer) mac(encoded string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(encoded))
+	return h.Sum(nil)
+}
+
+type signedValue struct {
+	Value     interface{} `json:"v"`
+	ExpiredAt int64       `json:"e"`
+}
+
+// randomString generate random string used as oidc state and nonce.
+func randomString() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -36,6 +36,7 @@ import (
 	"hcm/cmd/web-server/service/cloud/subnet"
 	"hcm/cmd/web-server/service/cloud/vpc"
 	"hcm/cmd/web-server/service/cmdb"
+	"hcm/cmd/web-server/service/login"
 	"hcm/cmd/web-server/service/user"
 	"hcm/pkg/cc"
 	apiclient "hcm/pkg/client"
@@ -65,6 +66,8 @@ type Service struct {
 	proxy *proxy
 	// authorizer 鉴权所需接口集合
 	authorizer auth.Authorizer
+	// authenticator 用户登录认证
+	authenticator login.Authenticator
 }
 
 // NewService create a service instance.
@@ -109,11 +112,18 @@ func NewService(dis serviced.Discover) (*Service, error) {
 		return nil, err
 	}
 
+	// 创建用户登录认证，登录有问题，则启动没意义
+	authenticator, err := login.NewAuthenticator(context.Background(), cc.WebServer().Web, esbClient, apiClientSet)
+	if err != nil {
+		return nil, fmt.Errorf("create login authenticator failed, err: %v", err)
+	}
+
 	return &Service{
-		client:     apiClientSet,
-		esbClient:  esbClient,
-		proxy:      p,
-		authorizer: authorizer,
+		client:        apiClientSet,
+		esbClient:     esbClient,
+		proxy:         p,
+		authorizer:    authorizer,
+		authenticator: authenticator,
 	}, nil
 }
 
@@ -147,6 +157,10 @@ func (s *Service) ListenAndServeRest() error {
 	// Add container filter to respond to OPTIONS
 	container.Filter(container.OPTIONSFilter)
 	container.Add(s.staticFileSet())
+	// 单点登录等无需认证的登录相关API
+	if ws := s.authenticator.WebService(); ws != nil {
+		container.Add(ws)
+	}
 	container.Add(s.apiSet())
 	container.Add(s.proxyApiSet())
 	container.Add(s.indexSet())
@@ -208,7 +222,7 @@ func (s *Service) apiSet() *restful.WebService {
 
 	// Note: 所有API接口都需要经过用户认证
 	ws.Path("/api/v1/web").Filter(
-		NewUserAuthenticateFilter(s.esbClient, cc.WebServer().Web.BkLoginUrl, cc.WebServer().Web.BkLoginCookieName),
+		NewUserAuthenticateFilter(s.authenticator),
 	)
 
 	c := &capability.Capability{
@@ -234,7 +248,7 @@ func (s *Service) proxyApiSet() *restful.WebService {
 
 	// Note: 所有API接口都需要经过用户认证
 	ws.Path("/api/v1/cloud").Filter(
-		NewUserAuthenticateFilter(s.esbClient, cc.WebServer().Web.BkLoginUrl, cc.WebServer().Web.BkLoginCookieName),
+		NewUserAuthenticateFilter(s.authenticator),
 	)
 	ws.Route(ws.GET("{.*}").To(s.proxy.Do))
 	ws.Route(ws.POST("{.*}").To(s.proxy.Do))
@@ -293,7 +307,7 @@ func (s *Service) indexHandleFunc(req *restful.Request, resp *restful.Response)
 
 	// 渲染模板
 	content := map[string]interface{}{
-		"BK_LOGIN_URL":         cc.WebServer().Web.BkLoginUrl,
+		"BK_LOGIN_URL":         s.authenticator.LoginURL(),
 		"BK_COMPONENT_API_URL": cc.WebServer().Web.BkComponentApiUrl,
 		"BK_ITSM_URL":          cc.WebServer().Web.BkItsmUrl,
 		"VERSION":              version.VERSION,
@@ -29,11 +29,23 @@ import (
 )
 
 // Adaptor holds all the supported operations by the adaptor.
-type Adaptor struct{}
+type Adaptor struct {
+	// identity is the trusted identity of hcm used to get short-lived credential of cloud accounts.
+	identity *types.TrustedIdentity
+}
 
 // New a Adaptor pointer
 func New() *Adaptor {
-	return &Adaptor{}
+	return &Adaptor{identity: new(types.TrustedIdentity)}
+}
+
+// NewWithIdentity new a Adaptor pointer with the trusted identity used to get short-lived credential.
+func NewWithIdentity(identity *types.TrustedIdentity) *Adaptor {
+	if identity == nil {
+		identity = new(types.TrustedIdentity)
+	}
+
+	return &Adaptor{identity: identity}
 }
 
 // TCloud returns tencent cloud operations.
@@ -46,6 +58,11 @@ func (a *Adaptor) Aws(s *types.BaseSecret, cloudAccountID string) (*aws.Aws, err
 	return aws.NewAws(s, cloudAccountID)
 }
 
+// AwsAssumeRole returns Aws operations which uses the short-lived credential of the assumed role.
+func (a *Adaptor) AwsAssumeRole(role *types.AwsAssumeRole, cloudAccountID string) (*aws.Aws, error) {
+	return aws.NewAwsWithAssumeRole(a.identity, role, cloudAccountID)
+}
+
 // Gcp returns Gcp operations.
 func (a *Adaptor) Gcp(credential *types.GcpCredential) (*gcp.Gcp, error) {
 	return gcp.NewGcp(credential)
@@ -53,6 +70,13 @@ func (a *Adaptor) Gcp(credential *types.GcpCredential) (*gcp.Gcp, error) {
 
 // Azure returns Azure operations.
 func (a *Adaptor) Azure(credential *types.AzureCredential) (*azure.Azure, error) {
+	// the credential belongs to the caller, fill the federated token file of the trusted identity in a copy of it.
+	if credential != nil && len(credential.FederatedTokenFile) == 0 {
+		copied := *credential
+		copied.FederatedTokenFile = a.identity.AzureFederatedTokenFile
+		credential = &copied
+	}
+
 	return azure.NewAzure(credential)
 }
 
@@ -69,6 +69,16 @@ func (a *Aws) AccountCheck(kt *kit.Kit, opt *types.AwsAccountInfo) error {
 	}
 
 	split := strings.Split(*resp.Arn, "/")
+	if len(opt.CloudRoleArn) != 0 {
+		// assumed role arn format: arn:aws:sts::{account}:assumed-role/{role name}/{session name}
+		roleName := opt.CloudRoleArn[strings.LastIndex(opt.CloudRoleArn, "/")+1:]
+		if len(split) < 3 || split[len(split)-2] != roleName {
+			return fmt.Errorf("caller identity %s is not the assumed role %s", *resp.Arn, opt.CloudRoleArn)
+		}
+
+		return nil
+	}
+
 	if split[len(split)-1] != opt.CloudIamUsername {
 		return fmt.Errorf("iam user name does not match the account to which the secret belongs")
 	}
@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"errors"
+	"fmt"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	// defaultPermissionProbeRegion is the region used to probe permission when region is not specified.
+	defaultPermissionProbeRegion = "us-east-1"
+	errDryRunOperation           = "DryRunOperation"
+	errUnauthorizedOperation     = "UnauthorizedOperation"
+)
+
+// ListMissingPermission list the ec2 permissions which are required by resource sync but not granted. all the probes
+// are called with DryRun, so that only permission is checked and no resource is actually read.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/CommonParameters.html
+func (a *Aws) ListMissingPermission(kt *kit.Kit, opt *typeaccount.ListMissingPermissionOption) (
+	[]typeaccount.MissingPermission, error) {
+
+	region := defaultPermissionProbeRegion
+	if opt != nil && len(opt.Region) != 0 {
+		region = opt.Region
+	}
+
+	client, err := a.clientSet.ec2Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("init aws client failed, err: %v", err)
+	}
+
+	dryRun := func(err error) error {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == errDryRunOperation {
+			return nil
+		}
+		return err
+	}
+
+	probes := []typeaccount.PermissionProbe{
+		{ResType: enumor.VpcCloudResType, Action: "ec2:DescribeVpcs", Probe: func() error {
+			_, err := client.DescribeVpcsWithContext(kt.Ctx, &ec2.DescribeVpcsInput{DryRun: aws.Bool(true)})
+			return dryRun(err)
+		}},
+		{ResType: enumor.SubnetCloudResType, Action: "ec2:DescribeSubnets", Probe: func() error {
+			_, err := client.DescribeSubnetsWithContext(kt.Ctx, &ec2.DescribeSubnetsInput{DryRun: aws.Bool(true)})
+			return dryRun(err)
+		}},
+		{ResType: enumor.SecurityGroupCloudResType, Action: "ec2:DescribeSecurityGroups", Probe: func() error {
+			_, err := client.DescribeSecurityGroupsWithContext(kt.Ctx,
+				&ec2.DescribeSecurityGroupsInput{DryRun: aws.Bool(true)})
+			return dryRun(err)
+		}},
+		{ResType: enumor.CvmCloudResType, Action: "ec2:DescribeInstances", Probe: func() error {
+			_, err := client.DescribeInstancesWithContext(kt.Ctx, &ec2.DescribeInstancesInput{DryRun: aws.Bool(true)})
+			return dryRun(err)
+		}},
+		{ResType: enumor.DiskCloudResType, Action: "ec2:DescribeVolumes", Probe: func() error {
+			_, err := client.DescribeVolumesWithContext(kt.Ctx, &ec2.DescribeVolumesInput{DryRun: aws.Bool(true)})
+			return dryRun(err)
+		}},
+		{ResType: enumor.EipCloudResType, Action: "ec2:DescribeAddresses", Probe: func() error {
+			_, err := client.DescribeAddressesWithContext(kt.Ctx, &ec2.DescribeAddressesInput{DryRun: aws.Bool(true)})
+			return dryRun(err)
+		}},
+		{ResType: enumor.RouteTableCloudResType, Action: "ec2:DescribeRouteTables", Probe: func() error {
+			_, err := client.DescribeRouteTablesWithContext(kt.Ctx,
+				&ec2.DescribeRouteTablesInput{DryRun: aws.Bool(true)})
+			return dryRun(err)
+		}},
+	}
+
+	missing, err := typeaccount.ProbeMissingPermission(probes, func(err error) bool {
+		var awsErr awserr.Error
+		return errors.As(err, &awsErr) && awsErr.Code() == errUnauthorizedOperation
+	})
+	if err != nil {
+		logs.Errorf("probe aws permission failed, err: %v, region: %s, rid: %s", err, region, kt.Rid)
+		return nil, err
+	}
+
+	return missing, nil
+}
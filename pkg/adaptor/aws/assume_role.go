@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/errf"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+const (
+	// defaultStsRegion is the default region of sts endpoint used to assume role.
+	defaultStsRegion = "us-east-1"
+	// assumeRoleSessionName is the session name of the assumed role, which is recorded in cloud trail.
+	assumeRoleSessionName = "hcm"
+	// assumeRoleExpiryWindow refresh the assumed role credential before it's expired, to avoid the credential expired
+	// during a long request.
+	assumeRoleExpiryWindow = 5 * time.Minute
+)
+
+// assumeRoleCredCache caches the assumed role credentials of the roles, the key is the hash of trusted identity
+// secret + role arn + external id. credentials.Credentials caches the sts token and refreshes it after expired by
+// itself, and it's safe for concurrent use, so the same role is only assumed once during the validity period of
+// the token.
+var assumeRoleCredCache = types.NewCredentialCache(types.CredentialCacheTTL)
+
+// NewAwsWithAssumeRole new aws which uses the short-lived credential of the role assumed by the trusted identity.
+func NewAwsWithAssumeRole(identity *types.TrustedIdentity, role *types.AwsAssumeRole, cloudAccountID string) (
+	*Aws, error) {
+
+	if identity == nil {
+		return nil, errf.New(errf.InvalidParameter, "trusted identity is required")
+	}
+
+	if role == nil {
+		return nil, errf.New(errf.InvalidParameter, "assume role is required")
+	}
+
+	if err := role.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cred, err := assumeRoleCredentials(identity, role)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := &clientSet{credentials: cred, httpClient: newHTTPClient(cloudAccountID)}
+	return &Aws{clientSet: cs, cloudAccountID: cloudAccountID}, nil
+}
+
+func assumeRoleCredentials(identity *types.TrustedIdentity, role *types.AwsAssumeRole) (*credentials.Credentials,
+	error) {
+
+	rootID, rootKey := "", ""
+	if identity.Aws != nil {
+		rootID, rootKey = identity.Aws.CloudSecretID, identity.Aws.CloudSecretKey
+	}
+	owner := fmt.Sprintf("%s/%s", role.RoleArn, role.ExternalID)
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", rootID, rootKey, owner)))
+	key := hex.EncodeToString(sum[:])
+
+	if cred, exist := assumeRoleCredCache.Load(key); exist {
+		return cred.(*credentials.Credentials), nil
+	}
+
+	region := identity.AwsRegion
+	if len(region) == 0 {
+		region = defaultStsRegion
+	}
+
+	cfg := &aws.Config{Region: aws.String(region)}
+	if identity.Aws != nil {
+		if err := identity.Aws.Validate(); err != nil {
+			return nil, fmt.Errorf("aws trusted identity is invalid, err: %v", err)
+		}
+		cfg.Credentials = credentials.NewStaticCredentials(identity.Aws.CloudSecretID, identity.Aws.CloudSecretKey, "")
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("init aws trusted identity session failed, err: %v", err)
+	}
+
+	cred := stscreds.NewCredentials(sess, role.RoleArn, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = assumeRoleSessionName
+		p.ExpiryWindow = assumeRoleExpiryWindow
+		if identity.AwsSessionDuration > 0 {
+			p.Duration = identity.AwsSessionDuration
+		}
+		if len(role.ExternalID) != 0 {
+			p.ExternalID = aws.String(role.ExternalID)
+		}
+	})
+
+	return assumeRoleCredCache.LoadOrStore(owner, key, cred).(*credentials.Credentials), nil
+}
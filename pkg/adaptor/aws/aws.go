@@ -30,7 +30,7 @@ func NewAws(s *types.BaseSecret, cloudAccountID string) (*Aws, error) {
 		return nil, err
 	}
 
-	return &Aws{clientSet: newClientSet(s), cloudAccountID: cloudAccountID}, nil
+	return &Aws{clientSet: newClientSet(s, cloudAccountID), cloudAccountID: cloudAccountID}, nil
 }
 
 // Aws is aws operator.
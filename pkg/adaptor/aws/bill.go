@@ -196,23 +196,27 @@ func (a *Aws) GetAwsAthenaQuery(kt *kit.Kit, query string,
 		var ip athena.GetQueryResultsInput
 		ip.SetQueryExecutionId(*result.QueryExecutionId)
 
-		op, err := client.GetQueryResults(&ip)
-		if err != nil {
-			logs.Errorf("aws cloud athena get query result err, queryExecutionId: %s, err: %v, rid: %s",
-				*result.QueryExecutionId, err, kt.Rid)
-			return nil, err
-		}
-
 		list := make([]map[string]string, 0)
 		resultMap := make([]string, 0)
-		for index, row := range op.ResultSet.Rows {
-			// parse table field
-			if index == 0 {
-				for _, column := range row.Data {
-					tmpField := converter.PtrToVal(column.VarCharValue)
-					resultMap = append(resultMap, tmpField)
+		// athena returns at most 1000 rows each page, the header row is only contained in the first page.
+		for isFirstPage := true; ; isFirstPage = false {
+			op, err := client.GetQueryResults(&ip)
+			if err != nil {
+				logs.Errorf("aws cloud athena get query result err, queryExecutionId: %s, err: %v, rid: %s",
+					*result.QueryExecutionId, err, kt.Rid)
+				return nil, err
+			}
+
+			for index, row := range op.ResultSet.Rows {
+				// parse table field
+				if isFirstPage && index == 0 {
+					for _, column := range row.Data {
+						tmpField := converter.PtrToVal(column.VarCharValue)
+						resultMap = append(resultMap, tmpField)
+					}
+					continue
 				}
-			} else {
+
 				tmpMap := make(map[string]string, 0)
 				for colKey, column := range row.Data {
 					tmpValue := converter.PtrToVal(column.VarCharValue)
@@ -230,6 +234,11 @@ func (a *Aws) GetAwsAthenaQuery(kt *kit.Kit, query string,
 				}
 				list = append(list, tmpMap)
 			}
+
+			if op.NextToken == nil || len(*op.NextToken) == 0 {
+				break
+			}
+			ip.SetNextToken(*op.NextToken)
 		}
 
 		return list, nil
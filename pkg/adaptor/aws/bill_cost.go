@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	typesBill "hcm/pkg/adaptor/types/bill"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+const (
+	// QueryDailyCostSQL 按天、服务、地域汇总云账单费用的SQL
+	QueryDailyCostSQL = "SELECT date_format(line_item_usage_start_date, '%%Y-%%m-%%d') AS bill_date, " +
+		"line_item_product_code AS service, product_region AS region, line_item_currency_code AS currency, " +
+		"SUM(line_item_unblended_cost) AS cost FROM %s.%s WHERE date(line_item_usage_start_date) >= date '%s' " +
+		"AND date(line_item_usage_start_date) <= date '%s' GROUP BY 1, 2, 3, 4"
+	// QueryTopCostItemSQL 查询某天费用最高的资源明细的SQL
+	QueryTopCostItemSQL = "SELECT line_item_product_code AS service, product_region AS region, " +
+		"line_item_resource_id AS resource_id, line_item_usage_type AS usage_type, " +
+		"line_item_operation AS operation, SUM(line_item_unblended_cost) AS cost FROM %s.%s " +
+		"WHERE date(line_item_usage_start_date) = date '%s'%s GROUP BY 1, 2, 3, 4, 5 ORDER BY 6 DESC LIMIT %d"
+)
+
+// ListDailyCost list the daily cost aggregated by service and region.
+func (a *Aws) ListDailyCost(kt *kit.Kit, opt *typesBill.AwsDailyCostOption,
+	billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) ([]typesBill.AwsDailyCost, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(QueryDailyCostSQL, billInfo.CloudDatabaseName, billInfo.CloudTableName, opt.BeginDate,
+		opt.EndDate)
+	list, err := a.GetAwsAthenaQuery(kt, sql, billInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	costs := make([]typesBill.AwsDailyCost, 0, len(list))
+	for _, one := range list {
+		cost, err := parseCost(one["cost"])
+		if err != nil {
+			logs.Errorf("parse aws daily cost failed, row: %v, err: %v, rid: %s", one, err, kt.Rid)
+			return nil, err
+		}
+
+		costs = append(costs, typesBill.AwsDailyCost{
+			BillDate: one["bill_date"],
+			Service:  one["service"],
+			Region:   one["region"],
+			Currency: one["currency"],
+			Cost:     cost,
+		})
+	}
+
+	return costs, nil
+}
+
+// ListTopCostItem list the line items with the highest cost of one day.
+func (a *Aws) ListTopCostItem(kt *kit.Kit, opt *typesBill.AwsTopCostItemOption,
+	billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) ([]typesBill.AwsCostItem, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	condition := ""
+	if len(opt.Service) != 0 {
+		condition += fmt.Sprintf(" AND line_item_product_code = '%s'", escapeSQLString(opt.Service))
+	}
+
+	if len(opt.Region) != 0 {
+		condition += fmt.Sprintf(" AND product_region = '%s'", escapeSQLString(opt.Region))
+	}
+
+	sql := fmt.Sprintf(QueryTopCostItemSQL, billInfo.CloudDatabaseName, billInfo.CloudTableName, opt.BillDate,
+		condition, opt.Limit)
+	list, err := a.GetAwsAthenaQuery(kt, sql, billInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]typesBill.AwsCostItem, 0, len(list))
+	for _, one := range list {
+		cost, err := parseCost(one["cost"])
+		if err != nil {
+			logs.Errorf("parse aws cost item failed, row: %v, err: %v, rid: %s", one, err, kt.Rid)
+			return nil, err
+		}
+
+		items = append(items, typesBill.AwsCostItem{
+			Service:    one["service"],
+			Region:     one["region"],
+			ResourceID: one["resource_id"],
+			UsageType:  one["usage_type"],
+			Operation:  one["operation"],
+			Cost:       cost,
+		})
+	}
+
+	return items, nil
+}
+
+func parseCost(value string) (float64, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
+
+// escapeSQLString escape the single quote of the string literal used in athena sql.
+func escapeSQLString(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
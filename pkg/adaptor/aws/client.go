@@ -20,7 +20,11 @@
 package aws
 
 import (
+	"net/http"
+
+	"hcm/pkg/adaptor/throttle"
 	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -29,6 +33,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	curservice "github.com/aws/aws-sdk-go/service/costandusagereportservice"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sts"
 )
@@ -42,17 +47,27 @@ const (
 
 type clientSet struct {
 	credentials *credentials.Credentials
+	// httpClient limits the calls of the account by the global throttler.
+	httpClient *http.Client
+}
+
+func newClientSet(secret *types.BaseSecret, cloudAccountID string) *clientSet {
+	return &clientSet{
+		credentials: credentials.NewStaticCredentials(secret.CloudSecretID, secret.CloudSecretKey, ""),
+		httpClient:  newHTTPClient(cloudAccountID),
+	}
 }
 
-func newClientSet(secret *types.BaseSecret) *clientSet {
-	return &clientSet{credentials.NewStaticCredentials(secret.CloudSecretID, secret.CloudSecretKey, "")}
+// newHTTPClient returns the http client which limits the calls of the account.
+func newHTTPClient(cloudAccountID string) *http.Client {
+	return &http.Client{Transport: throttle.NewTransport(enumor.Aws, cloudAccountID, nil)}
 }
 
 func (c *clientSet) ec2Client(region string) (*ec2.EC2, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -76,7 +91,7 @@ func (c *clientSet) stsClient() (*sts.STS, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -96,7 +111,7 @@ func (c *clientSet) athenaClient(region string) (*athena.Athena, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -120,7 +135,7 @@ func (c *clientSet) s3Client(region string) (*s3.S3, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -144,7 +159,7 @@ func (c *clientSet) costAndUsageReportClient(region string) (*curservice.Costand
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -168,7 +183,7 @@ func (c *clientSet) cloudFormationClient(region string) (*cloudformation.CloudFo
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -187,3 +202,27 @@ func (c *clientSet) cloudFormationClient(region string) (*cloudformation.CloudFo
 
 	return cloudformation.New(sess, aws.NewConfig().WithRegion(region)), nil
 }
+
+func (c *clientSet) organizationsClient(region string) (*organizations.Organizations, error) {
+	cfg := &aws.Config{
+		Credentials: c.credentials,
+		DisableSSL:  nil,
+		HTTPClient:  c.httpClient,
+		LogLevel:    nil,
+		Logger:      nil,
+		MaxRetries:  nil,
+		Retryer:     nil,
+		SleepDelay:  nil,
+	}
+
+	if len(region) != 0 {
+		cfg.Region = aws.String(region)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return organizations.New(sess), nil
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"hcm/pkg/adaptor/types/eip"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
@@ -92,6 +93,7 @@ func (a *Aws) ListEip(kt *kit.Kit, opt *eip.AwsEipListOption) (*eip.AwsEipListRe
 			PrivateIpAddress:   address.PrivateIpAddress,
 			NetworkBorderGroup: address.NetworkBorderGroup,
 			NetworkInterfaceId: address.NetworkInterfaceId,
+			Tags:               tag.FromAws(address.Tags),
 		}
 	}
 
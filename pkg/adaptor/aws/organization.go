@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+)
+
+// organizationsRegion aws organizations is a global service, and its endpoint is in us-east-1.
+const organizationsRegion = "us-east-1"
+
+// ListOrganizationMember list the member accounts of the organization, the credential must be of the management
+// account or a delegated administrator account.
+// reference: https://docs.aws.amazon.com/organizations/latest/APIReference/API_ListAccounts.html
+func (a *Aws) ListOrganizationMember(kt *kit.Kit, opt *typeaccount.ListOrganizationMemberOption) (
+	[]typeaccount.OrganizationMember, error) {
+
+	if opt == nil {
+		opt = new(typeaccount.ListOrganizationMemberOption)
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := a.clientSet.organizationsClient(organizationsRegion)
+	if err != nil {
+		return nil, fmt.Errorf("new organizations client failed, err: %v", err)
+	}
+
+	members := make([]typeaccount.OrganizationMember, 0)
+	if len(opt.ParentID) == 0 {
+		err = client.ListAccountsPagesWithContext(kt.Ctx, new(organizations.ListAccountsInput),
+			func(output *organizations.ListAccountsOutput, _ bool) bool {
+				for _, one := range output.Accounts {
+					members = append(members, convOrganizationMember(one, ""))
+				}
+				return true
+			})
+		if err != nil {
+			logs.Errorf("list aws organization accounts failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		return members, nil
+	}
+
+	if err = listAccountsUnderParent(kt, client, opt.ParentID, &members); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// listAccountsUnderParent list the accounts under the parent and its child organizational units recursively.
+func listAccountsUnderParent(kt *kit.Kit, client *organizations.Organizations, parentID string,
+	members *[]typeaccount.OrganizationMember) error {
+
+	input := &organizations.ListAccountsForParentInput{ParentId: aws.String(parentID)}
+	err := client.ListAccountsForParentPagesWithContext(kt.Ctx, input,
+		func(output *organizations.ListAccountsForParentOutput, _ bool) bool {
+			for _, one := range output.Accounts {
+				*members = append(*members, convOrganizationMember(one, parentID))
+			}
+			return true
+		})
+	if err != nil {
+		logs.Errorf("list aws accounts for parent failed, parent: %s, err: %v, rid: %s", parentID, err, kt.Rid)
+		return err
+	}
+
+	unitIDs := make([]string, 0)
+	unitInput := &organizations.ListOrganizationalUnitsForParentInput{ParentId: aws.String(parentID)}
+	err = client.ListOrganizationalUnitsForParentPagesWithContext(kt.Ctx, unitInput,
+		func(output *organizations.ListOrganizationalUnitsForParentOutput, _ bool) bool {
+			for _, one := range output.OrganizationalUnits {
+				unitIDs = append(unitIDs, aws.StringValue(one.Id))
+			}
+			return true
+		})
+	if err != nil {
+		logs.Errorf("list aws organizational units for parent failed, parent: %s, err: %v, rid: %s", parentID,
+			err, kt.Rid)
+		return err
+	}
+
+	for _, unitID := range unitIDs {
+		if err = listAccountsUnderParent(kt, client, unitID, members); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func convOrganizationMember(account *organizations.Account, parentID string) typeaccount.OrganizationMember {
+	return typeaccount.OrganizationMember{
+		CloudID:  aws.StringValue(account.Id),
+		Name:     aws.StringValue(account.Name),
+		Email:    aws.StringValue(account.Email),
+		Status:   aws.StringValue(account.Status),
+		ParentID: parentID,
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	"hcm/pkg/adaptor/types/core"
 	routetable "hcm/pkg/adaptor/types/route-table"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/converter"
@@ -121,6 +122,7 @@ func convertRouteTable(data *ec2.RouteTable, region string) *routetable.AwsRoute
 		CloudVpcID: converter.PtrToVal(data.VpcId),
 		Region:     region,
 		Extension:  new(routetable.AwsRouteTableExtension),
+		Tags:       tag.FromAws(data.Tags),
 	}
 
 	name, _ := parseTags(data.Tags)
@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/adaptor/poller"
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	cidrtools "hcm/pkg/tools/cidr"
@@ -188,6 +189,7 @@ func convertSubnet(data *ec2.Subnet, region string) *types.AwsSubnet {
 	s := &types.AwsSubnet{
 		CloudVpcID: converter.PtrToVal(data.VpcId),
 		CloudID:    converter.PtrToVal(data.SubnetId),
+		Tags:       tag.FromAws(data.Tags),
 		Extension: &types.AwsSubnetExtension{
 			State:                       converter.PtrToVal(data.State),
 			Region:                      region,
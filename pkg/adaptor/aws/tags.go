@@ -20,6 +20,10 @@
 package aws
 
 import (
+	"hcm/pkg/adaptor/types/tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
 	"hcm/pkg/tools/converter"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -69,3 +73,55 @@ func parseTags(tags []*ec2.Tag) (string, []*ec2.Tag) {
 
 	return "", tags
 }
+
+// UpdateResourceTags upsert and delete the tags of the ec2 resource.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_CreateTags.html
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DeleteTags.html
+func (a *Aws) UpdateResourceTags(kt *kit.Kit, opt *tag.AwsTagUpdateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "update option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if len(opt.UpsertTags) != 0 {
+		tags := make([]*ec2.Tag, 0, len(opt.UpsertTags))
+		for _, one := range opt.UpsertTags {
+			tags = append(tags, &ec2.Tag{Key: aws.String(one.Key), Value: aws.String(one.Value)})
+		}
+
+		req := &ec2.CreateTagsInput{
+			Resources: []*string{aws.String(opt.CloudResID)},
+			Tags:      tags,
+		}
+		if _, err = client.CreateTagsWithContext(kt.Ctx, req); err != nil {
+			logs.Errorf("create aws resource tags failed, err: %v, res: %s, rid: %s", err, opt.CloudResID, kt.Rid)
+			return err
+		}
+	}
+
+	if len(opt.DeleteKeys) != 0 {
+		tags := make([]*ec2.Tag, 0, len(opt.DeleteKeys))
+		for _, key := range opt.DeleteKeys {
+			tags = append(tags, &ec2.Tag{Key: aws.String(key)})
+		}
+
+		req := &ec2.DeleteTagsInput{
+			Resources: []*string{aws.String(opt.CloudResID)},
+			Tags:      tags,
+		}
+		if _, err = client.DeleteTagsWithContext(kt.Ctx, req); err != nil {
+			logs.Errorf("delete aws resource tags failed, err: %v, res: %s, rid: %s", err, opt.CloudResID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/adaptor/poller"
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/api/core/cloud"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
@@ -212,6 +213,7 @@ func convertVpc(data *ec2.Vpc, region string) *types.AwsVpc {
 	v := &types.AwsVpc{
 		CloudID: converter.PtrToVal(data.VpcId),
 		Region:  region,
+		Tags:    tag.FromAws(data.Tags),
 		Extension: &cloud.AwsVpcExtension{
 			State:           converter.PtrToVal(data.State),
 			InstanceTenancy: converter.PtrToVal(data.InstanceTenancy),
@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+// ListMissingPermission list the rbac permissions which are required by resource sync but not granted, region of
+// option is ignored because the probed apis list resources of the whole subscription. only the first page of each
+// api is requested.
+// reference: https://learn.microsoft.com/en-us/azure/role-based-access-control/resource-provider-operations
+func (az *Azure) ListMissingPermission(kt *kit.Kit, _ *typeaccount.ListMissingPermissionOption) (
+	[]typeaccount.MissingPermission, error) {
+
+	vpcCli, err := az.clientSet.vpcClient()
+	if err != nil {
+		return nil, err
+	}
+
+	sgCli, err := az.clientSet.securityGroupClient()
+	if err != nil {
+		return nil, err
+	}
+
+	routeTableCli, err := az.clientSet.routeTableClient()
+	if err != nil {
+		return nil, err
+	}
+
+	eipCli, err := az.clientSet.publicIPAddressesClient()
+	if err != nil {
+		return nil, err
+	}
+
+	niCli, err := az.clientSet.networkInterfaceClient()
+	if err != nil {
+		return nil, err
+	}
+
+	vmCli, err := az.clientSet.virtualMachineClient()
+	if err != nil {
+		return nil, err
+	}
+
+	diskCli, err := az.clientSet.diskClient()
+	if err != nil {
+		return nil, fmt.Errorf("new azure disk client failed, err: %v", err)
+	}
+
+	probes := []typeaccount.PermissionProbe{
+		{ResType: enumor.VpcCloudResType, Action: "Microsoft.Network/virtualNetworks/read", Probe: func() error {
+			_, err := vpcCli.NewListAllPager(nil).NextPage(kt.Ctx)
+			return err
+		}},
+		{ResType: enumor.SecurityGroupCloudResType, Action: "Microsoft.Network/networkSecurityGroups/read",
+			Probe: func() error {
+				_, err := sgCli.NewListAllPager(nil).NextPage(kt.Ctx)
+				return err
+			}},
+		{ResType: enumor.RouteTableCloudResType, Action: "Microsoft.Network/routeTables/read", Probe: func() error {
+			_, err := routeTableCli.NewListAllPager(nil).NextPage(kt.Ctx)
+			return err
+		}},
+		{ResType: enumor.EipCloudResType, Action: "Microsoft.Network/publicIPAddresses/read", Probe: func() error {
+			_, err := eipCli.NewListAllPager(nil).NextPage(kt.Ctx)
+			return err
+		}},
+		{ResType: enumor.NetworkInterfaceCloudResType, Action: "Microsoft.Network/networkInterfaces/read",
+			Probe: func() error {
+				_, err := niCli.NewListAllPager(nil).NextPage(kt.Ctx)
+				return err
+			}},
+		{ResType: enumor.CvmCloudResType, Action: "Microsoft.Compute/virtualMachines/read", Probe: func() error {
+			_, err := vmCli.NewListAllPager(nil).NextPage(kt.Ctx)
+			return err
+		}},
+		{ResType: enumor.DiskCloudResType, Action: "Microsoft.Compute/disks/read", Probe: func() error {
+			_, err := diskCli.NewListPager(nil).NextPage(kt.Ctx)
+			return err
+		}},
+	}
+
+	missing, err := typeaccount.ProbeMissingPermission(probes, func(err error) bool {
+		var respErr *azcore.ResponseError
+		return errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden
+	})
+	if err != nil {
+		logs.Errorf("probe azure permission failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return missing, nil
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"hcm/pkg/adaptor/types"
@@ -35,6 +36,7 @@ import (
 	"hcm/pkg/rest/client"
 	"hcm/pkg/tools/json"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/consumption/armconsumption"
 )
 
@@ -101,6 +103,33 @@ func newBillClient(server string, token *LoginTokenProto) (*billClient, error) {
 	return &billClient{client: restCli, LoginToken: token}, nil
 }
 
+func (c *clientSet) getToken(kt *kit.Kit) (*LoginTokenProto, error) {
+	if c.credential.CredentialType.IsSecretKey() {
+		return getToken(kt, c.credential)
+	}
+
+	// managed identity and workload identity has no client secret, get the token by the identity credential.
+	credential, err := c.newCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	accessToken, err := credential.GetToken(kt.Ctx, policy.TokenRequestOptions{Scopes: []string{ManageServerURL +
+		".default"}})
+	if err != nil {
+		return nil, fmt.Errorf("get azure access token failed, err: %v", err)
+	}
+
+	token := &LoginTokenProto{
+		SubscriptionID: c.credential.CloudSubscriptionID,
+		AccessToken:    accessToken.Token,
+		TokenType:      "Bearer",
+		ExpiresOn:      strconv.FormatInt(accessToken.ExpiresOn.Unix(), 10),
+		Resource:       ManageServerURL,
+	}
+	return token, nil
+}
+
 func getToken(kt *kit.Kit, credential *types.AzureCredential) (*LoginTokenProto, error) {
 	cli, err := newBillClient(LoginServerURL, nil)
 	if err != nil {
@@ -21,12 +21,16 @@ package azure
 
 import (
 	"fmt"
+	"net/http"
 
+	"hcm/pkg/adaptor/throttle"
 	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
 	armcomputev4 "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
@@ -43,13 +47,21 @@ func newClientSet(credential *types.AzureCredential) *clientSet {
 	return &clientSet{credential}
 }
 
+// clientOptions returns the options of arm clients, the calls of the subscription are limited by the global throttler.
+func (c *clientSet) clientOptions() *arm.ClientOptions {
+	transport := throttle.NewTransport(enumor.Azure, c.credential.CloudSubscriptionID, nil)
+	return &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{Transport: &http.Client{Transport: transport}},
+	}
+}
+
 func (c *clientSet) subscriptionClient() (*armsubscription.SubscriptionsClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armsubscription.NewSubscriptionsClient(credential, nil)
+	client, err := armsubscription.NewSubscriptionsClient(credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure subscription client failed, err: %v", err)
 	}
@@ -58,7 +70,7 @@ func (c *clientSet) subscriptionClient() (*armsubscription.SubscriptionsClient,
 }
 
 func (c *clientSet) vpcClient() (*armnetwork.VirtualNetworksClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
@@ -66,7 +78,7 @@ func (c *clientSet) vpcClient() (*armnetwork.VirtualNetworksClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewVirtualNetworksClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewVirtualNetworksClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -74,7 +86,7 @@ func (c *clientSet) vpcClient() (*armnetwork.VirtualNetworksClient, error) {
 }
 
 func (c *clientSet) usageClient() (*armnetwork.UsagesClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
@@ -82,7 +94,7 @@ func (c *clientSet) usageClient() (*armnetwork.UsagesClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewUsagesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewUsagesClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure usage client failed, err: %v", err)
 	}
@@ -90,7 +102,7 @@ func (c *clientSet) usageClient() (*armnetwork.UsagesClient, error) {
 }
 
 func (c *clientSet) subnetClient() (*armnetwork.SubnetsClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
@@ -98,7 +110,7 @@ func (c *clientSet) subnetClient() (*armnetwork.SubnetsClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewSubnetsClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewSubnetsClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -106,35 +118,28 @@ func (c *clientSet) subnetClient() (*armnetwork.SubnetsClient, error) {
 }
 
 func (c *clientSet) diskClient() (*armcompute.DisksClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
-	return armcompute.NewDisksClient(c.credential.CloudSubscriptionID, credential, nil)
+	return armcompute.NewDisksClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 }
 
 func (c *clientSet) imageClient() (*armcomputev4.VirtualMachineImagesClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
-	return armcomputev4.NewVirtualMachineImagesClient(c.credential.CloudSubscriptionID, credential, nil)
-}
-
-func (c *clientSet) newClientSecretCredential() (*azidentity.ClientSecretCredential, error) {
-	return azidentity.NewClientSecretCredential(
-		c.credential.CloudTenantID,
-		c.credential.CloudApplicationID,
-		c.credential.CloudClientSecretKey, nil)
+	return armcomputev4.NewVirtualMachineImagesClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 }
 
 func (c *clientSet) securityGroupClient() (*armnetwork.SecurityGroupsClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewSecurityGroupsClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewSecurityGroupsClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure security group client failed, err: %v", err)
 	}
@@ -143,12 +148,12 @@ func (c *clientSet) securityGroupClient() (*armnetwork.SecurityGroupsClient, err
 }
 
 func (c *clientSet) virtualMachineClient() (*armcompute.VirtualMachinesClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armcompute.NewVirtualMachinesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armcompute.NewVirtualMachinesClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure virtual machines client failed, err: %v", err)
 	}
@@ -157,12 +162,12 @@ func (c *clientSet) virtualMachineClient() (*armcompute.VirtualMachinesClient, e
 }
 
 func (c *clientSet) virtualMachineSizeClient() (*armcompute.VirtualMachineSizesClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armcompute.NewVirtualMachineSizesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armcompute.NewVirtualMachineSizesClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure virtual machine sizes client failed, err: %v", err)
 	}
@@ -171,12 +176,12 @@ func (c *clientSet) virtualMachineSizeClient() (*armcompute.VirtualMachineSizesC
 }
 
 func (c *clientSet) resourceGroupsClient() (*armresources.ResourceGroupsClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armresources.NewResourceGroupsClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armresources.NewResourceGroupsClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init resourceGroups client failed, err: %v", err)
 	}
@@ -184,13 +189,27 @@ func (c *clientSet) resourceGroupsClient() (*armresources.ResourceGroupsClient,
 	return client, nil
 }
 
+func (c *clientSet) tagsClient() (*armresources.TagsClient, error) {
+	credential, err := c.newCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := armresources.NewTagsClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
+	if err != nil {
+		return nil, fmt.Errorf("init tags client failed, err: %v", err)
+	}
+
+	return client, nil
+}
+
 func (c *clientSet) regionClient() (*armsubscriptions.Client, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armsubscriptions.NewClient(credential, nil)
+	client, err := armsubscriptions.NewClient(credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init region client failed, err: %v", err)
 	}
@@ -199,7 +218,7 @@ func (c *clientSet) regionClient() (*armsubscriptions.Client, error) {
 }
 
 func (c *clientSet) routeTableClient() (*armnetwork.RouteTablesClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
@@ -207,7 +226,7 @@ func (c *clientSet) routeTableClient() (*armnetwork.RouteTablesClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewRouteTablesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewRouteTablesClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -215,7 +234,7 @@ func (c *clientSet) routeTableClient() (*armnetwork.RouteTablesClient, error) {
 }
 
 func (c *clientSet) routeClient() (*armnetwork.RoutesClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
@@ -223,7 +242,7 @@ func (c *clientSet) routeClient() (*armnetwork.RoutesClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewRoutesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewRoutesClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -231,11 +250,11 @@ func (c *clientSet) routeClient() (*armnetwork.RoutesClient, error) {
 }
 
 func (c *clientSet) publicIPAddressesClient() (*armnetwork.PublicIPAddressesClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
-	client, err := armnetwork.NewPublicIPAddressesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewPublicIPAddressesClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure public ip addresses client failed, err: %v", err)
 	}
@@ -243,12 +262,12 @@ func (c *clientSet) publicIPAddressesClient() (*armnetwork.PublicIPAddressesClie
 }
 
 func (c *clientSet) networkInterfaceClient() (*armnetwork.InterfacesClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init network interface credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewInterfacesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewInterfacesClient(c.credential.CloudSubscriptionID, credential, c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init network interface client failed, err: %v", err)
 	}
@@ -257,13 +276,13 @@ func (c *clientSet) networkInterfaceClient() (*armnetwork.InterfacesClient, erro
 }
 
 func (c *clientSet) networkInterfaceIPConfigClient() (*armnetwork.InterfaceIPConfigurationsClient, error) {
-	credential, err := c.newClientSecretCredential()
+	credential, err := c.newCredential()
 	if err != nil {
 		return nil, fmt.Errorf("init network interface ipconfig credential failed, err: %v", err)
 	}
 
 	client, err := armnetwork.NewInterfaceIPConfigurationsClient(c.credential.CloudSubscriptionID, credential,
-		nil)
+		c.clientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init network interface ipconfig client failed, err: %v", err)
 	}
@@ -272,7 +291,7 @@ func (c *clientSet) networkInterfaceIPConfigClient() (*armnetwork.InterfaceIPCon
 }
 
 func (c *clientSet) usageDetailClient(kt *kit.Kit) (*billClient, error) {
-	token, err := getToken(kt, c.credential)
+	token, err := c.getToken(kt)
 	if err != nil {
 		logs.Errorf("usage detail get token failed, err: %v", err)
 		return nil, err
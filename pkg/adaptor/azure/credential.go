@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+// identityCredCache caches the managed identity and workload identity credentials, the key is credential type +
+// tenant id + application id + federated token file. azidentity credentials cache the access token and refresh it
+// after expired by themselves, so the reused credential only requests a new token when the previous one is going to
+// expire.
+var identityCredCache = types.NewCredentialCache(types.CredentialCacheTTL)
+
+// newCredential new azure token credential by the credential type of the account.
+func (c *clientSet) newCredential() (azcore.TokenCredential, error) {
+	cred := c.credential
+
+	if cred.CredentialType.IsSecretKey() {
+		return azidentity.NewClientSecretCredential(cred.CloudTenantID, cred.CloudApplicationID,
+			cred.CloudClientSecretKey, nil)
+	}
+
+	owner := fmt.Sprintf("%s/%s/%s", cred.CredentialType, cred.CloudTenantID, cred.CloudApplicationID)
+	key := owner + "/" + cred.FederatedTokenFile
+	if tokenCred, exist := identityCredCache.Load(key); exist {
+		return tokenCred.(azcore.TokenCredential), nil
+	}
+
+	var tokenCred azcore.TokenCredential
+	var err error
+	switch cred.CredentialType {
+	case enumor.ManagedIdentityCredential:
+		opt := new(azidentity.ManagedIdentityCredentialOptions)
+		if len(cred.CloudApplicationID) != 0 {
+			opt.ID = azidentity.ClientID(cred.CloudApplicationID)
+		}
+		tokenCred, err = azidentity.NewManagedIdentityCredential(opt)
+
+	case enumor.WorkloadIdentityCredential:
+		tokenFile := cred.FederatedTokenFile
+		tokenCred, err = azidentity.NewClientAssertionCredential(cred.CloudTenantID, cred.CloudApplicationID,
+			func(context.Context) (string, error) {
+				// the federated token file is rotated by the platform, so read it every time the assertion is needed.
+				token, err := os.ReadFile(tokenFile)
+				if err != nil {
+					return "", fmt.Errorf("read azure federated token file failed, err: %v", err)
+				}
+				return strings.TrimSpace(string(token)), nil
+			}, nil)
+
+	default:
+		return nil, fmt.Errorf("azure not support credential type: %s", cred.CredentialType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return identityCredCache.LoadOrStore(owner, key, tokenCred).(azcore.TokenCredential), nil
+}
@@ -239,6 +239,7 @@ func converterToAzureCvm(vms []*armcompute.VirtualMachine) []*typecvm.AzureCvm {
 			Location: SPtrToLowerNoSpaceSPtr(v.Location),
 			Type:     v.Type,
 			Zones:    v.Zones,
+			Tags:     v.Tags,
 		}
 
 		if v.Properties == nil {
@@ -25,6 +25,7 @@ import (
 	"hcm/pkg/adaptor/types/core"
 	typecvm "hcm/pkg/adaptor/types/cvm"
 	"hcm/pkg/adaptor/types/disk"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -251,6 +252,7 @@ func converterDisk(disks []*armcompute.Disk) []*disk.AzureDisk {
 			OSType:   (*string)(v.Properties.OSType),
 			SKUName:  (*string)(v.SKU.Name),
 			SKUTier:  v.SKU.Tier,
+			Tags:     tag.FromMap(v.Tags),
 		}
 		typesDisk = append(typesDisk, tmp)
 	}
@@ -27,6 +27,7 @@ import (
 
 	"hcm/pkg/adaptor/types/core"
 	"hcm/pkg/adaptor/types/eip"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
@@ -107,6 +108,7 @@ func convertEip(one *armnetwork.PublicIPAddress, resGroupName string) *eip.Azure
 		ResourceGroupName:      strings.ToLower(resGroupName),
 		Location:               one.Location,
 		PublicIPAddressVersion: (*string)(one.Properties.PublicIPAddressVersion),
+		Tags:                   tag.FromMap(one.Tags),
 	}
 
 	if one.Properties.DNSSettings != nil {
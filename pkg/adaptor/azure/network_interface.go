@@ -27,6 +27,7 @@ import (
 	"hcm/pkg/adaptor/types/core"
 	"hcm/pkg/adaptor/types/eip"
 	typesniproto "hcm/pkg/adaptor/types/network-interface"
+	"hcm/pkg/adaptor/types/tag"
 	coreni "hcm/pkg/api/core/cloud/network-interface"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
@@ -192,6 +193,7 @@ func (a *Azure) ConvertCloudNetworkInterface(kt *kit.Kit, data *armnetwork.Inter
 		Name:    SPtrToLowerSPtr(data.Name),
 		Region:  SPtrToLowerSPtr(data.Location),
 		CloudID: SPtrToLowerSPtr(data.ID),
+		Tags:    tag.FromMap(data.Tags),
 	}
 
 	if data.Properties == nil {
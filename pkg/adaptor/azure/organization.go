@@ -0,0 +1,158 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// managementGroupAPIVersion is the api version of management group descendants api.
+const managementGroupAPIVersion = "2020-05-01"
+
+// ListOrganizationMember list the subscriptions under the management group, all the subscriptions which the
+// credential has the permission to access are listed if management group is not set.
+// reference: https://learn.microsoft.com/en-us/rest/api/managementgroups/management-groups/get-descendants
+func (az *Azure) ListOrganizationMember(kt *kit.Kit, opt *typeaccount.ListOrganizationMemberOption) (
+	[]typeaccount.OrganizationMember, error) {
+
+	if opt == nil {
+		opt = new(typeaccount.ListOrganizationMemberOption)
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(opt.ParentID) != 0 {
+		return az.listManagementGroupSubscription(kt, opt.ParentID)
+	}
+
+	client, err := az.clientSet.regionClient()
+	if err != nil {
+		return nil, fmt.Errorf("new subscription client failed, err: %v", err)
+	}
+
+	members := make([]typeaccount.OrganizationMember, 0)
+	pager := client.NewListPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(kt.Ctx)
+		if err != nil {
+			logs.Errorf("list azure subscriptions failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range page.Value {
+			member := typeaccount.OrganizationMember{
+				CloudID: converter.PtrToVal(one.SubscriptionID),
+				Name:    converter.PtrToVal(one.DisplayName),
+			}
+			if one.State != nil {
+				member.Status = string(*one.State)
+			}
+			members = append(members, member)
+		}
+	}
+
+	return members, nil
+}
+
+// managementGroupDescendants is the response of management group descendants api, the sdk of management group is
+// not imported, so the api is called by the arm pipeline directly.
+type managementGroupDescendants struct {
+	Value []struct {
+		Name       string `json:"name"`
+		Type       string `json:"type"`
+		Properties struct {
+			DisplayName string `json:"displayName"`
+			Parent      struct {
+				ID string `json:"id"`
+			} `json:"parent"`
+		} `json:"properties"`
+	} `json:"value"`
+	NextLink string `json:"nextLink"`
+}
+
+func (az *Azure) listManagementGroupSubscription(kt *kit.Kit, groupID string) (
+	[]typeaccount.OrganizationMember, error) {
+
+	credential, err := az.clientSet.newCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := arm.NewClient("azure.Client", "v1.0.0", credential, az.clientSet.clientOptions())
+	if err != nil {
+		return nil, fmt.Errorf("init arm client failed, err: %v", err)
+	}
+
+	link := fmt.Sprintf("%s/providers/Microsoft.Management/managementGroups/%s/descendants?api-version=%s",
+		strings.TrimSuffix(client.Endpoint(), "/"), url.PathEscape(groupID), managementGroupAPIVersion)
+
+	members := make([]typeaccount.OrganizationMember, 0)
+	for len(link) != 0 {
+		req, err := runtime.NewRequest(kt.Ctx, http.MethodGet, link)
+		if err != nil {
+			return nil, err
+		}
+		req.Raw().Header["Accept"] = []string{"application/json"}
+
+		resp, err := client.Pipeline().Do(req)
+		if err != nil {
+			logs.Errorf("list azure management group descendants failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		if !runtime.HasStatusCode(resp, http.StatusOK) {
+			return nil, runtime.NewResponseError(resp)
+		}
+
+		result := new(managementGroupDescendants)
+		if err = runtime.UnmarshalAsJSON(resp, result); err != nil {
+			return nil, err
+		}
+
+		for _, one := range result.Value {
+			if !strings.HasSuffix(one.Type, "/subscriptions") {
+				continue
+			}
+
+			members = append(members, typeaccount.OrganizationMember{
+				CloudID:  one.Name,
+				Name:     one.Properties.DisplayName,
+				ParentID: one.Properties.Parent.ID,
+			})
+		}
+
+		link = result.NextLink
+	}
+
+	return members, nil
+}
@@ -25,6 +25,7 @@ import (
 
 	"hcm/pkg/adaptor/types/core"
 	routetable "hcm/pkg/adaptor/types/route-table"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/converter"
@@ -240,6 +241,7 @@ func (a *Azure) ConvertRouteTable(data *armnetwork.RouteTable, resourceGroup,
 		CloudID: SPtrToLowerStr(data.ID),
 		Name:    SPtrToLowerStr(data.Name),
 		Region:  SPtrToLowerNoSpaceStr(data.Location),
+		Tags:    tag.FromMap(data.Tags),
 		Extension: &routetable.AzureRouteTableExtension{
 			ResourceGroupName:   strings.ToLower(resourceGroup),
 			CloudSubscriptionID: strings.ToLower(subscription),
@@ -24,6 +24,7 @@ import (
 
 	"hcm/pkg/adaptor/types/core"
 	securitygroup "hcm/pkg/adaptor/types/security-group"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -238,6 +239,7 @@ func (az *Azure) converCloudToSecurityGroup(cloud *armnetwork.SecurityGroup) *se
 		Etag:            cloud.Etag,
 		FlushConnection: nil,
 		ResourceGUID:    nil,
+		Tags:            tag.FromMap(cloud.Tags),
 	}
 	if cloud.Properties != nil {
 		respSecurityGroup.FlushConnection = cloud.Properties.FlushConnection
@@ -269,6 +271,7 @@ func (az *Azure) getSecurityGroupByCloudID(kt *kit.Kit, resGroupName, cloudID st
 		Etag:            resp.SecurityGroup.Etag,
 		FlushConnection: nil,
 		ResourceGUID:    nil,
+		Tags:            tag.FromMap(resp.SecurityGroup.Tags),
 	}
 	if resp.SecurityGroup.Properties != nil {
 		sg.FlushConnection = resp.SecurityGroup.Properties.FlushConnection
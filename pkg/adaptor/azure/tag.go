@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"hcm/pkg/adaptor/types/tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+)
+
+// UpdateResourceTags upsert and delete the tags of the resource, the other tags of the resource on cloud are kept.
+// reference: https://learn.microsoft.com/en-us/rest/api/resources/tags/update-at-scope
+func (az *Azure) UpdateResourceTags(kt *kit.Kit, opt *tag.AzureTagUpdateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "update option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.tagsClient()
+	if err != nil {
+		return err
+	}
+
+	if len(opt.UpsertTags) != 0 {
+		tags := make(map[string]*string, len(opt.UpsertTags))
+		for _, one := range opt.UpsertTags {
+			tags[one.Key] = converter.ValToPtr(one.Value)
+		}
+
+		req := armresources.TagsPatchResource{
+			Operation:  converter.ValToPtr(armresources.TagsPatchOperationMerge),
+			Properties: &armresources.Tags{Tags: tags},
+		}
+		if _, err = client.UpdateAtScope(kt.Ctx, opt.CloudResID, req, nil); err != nil {
+			logs.Errorf("merge azure resource tags failed, err: %v, res: %s, rid: %s", err, opt.CloudResID, kt.Rid)
+			return err
+		}
+	}
+
+	if len(opt.DeleteKeys) == 0 {
+		return nil
+	}
+
+	// azure deletes the tags by name and value pairs, so the values of the tags to delete are got from cloud.
+	resp, err := client.GetAtScope(kt.Ctx, opt.CloudResID, nil)
+	if err != nil {
+		logs.Errorf("get azure resource tags failed, err: %v, res: %s, rid: %s", err, opt.CloudResID, kt.Rid)
+		return err
+	}
+
+	cloudTags := make(map[string]*string)
+	if resp.Properties != nil {
+		cloudTags = resp.Properties.Tags
+	}
+
+	tags := make(map[string]*string, len(opt.DeleteKeys))
+	for _, key := range opt.DeleteKeys {
+		if value, exists := cloudTags[key]; exists {
+			tags[key] = value
+		}
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	req := armresources.TagsPatchResource{
+		Operation:  converter.ValToPtr(armresources.TagsPatchOperationDelete),
+		Properties: &armresources.Tags{Tags: tags},
+	}
+	if _, err = client.UpdateAtScope(kt.Ctx, opt.CloudResID, req, nil); err != nil {
+		logs.Errorf("delete azure resource tags failed, err: %v, res: %s, rid: %s", err, opt.CloudResID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
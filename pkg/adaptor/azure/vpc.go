@@ -25,6 +25,7 @@ import (
 
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/api/core/cloud"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -236,6 +237,7 @@ func convertVpc(data *armnetwork.VirtualNetwork, resourceGroup string) *types.Az
 		CloudID: SPtrToLowerStr(data.ID),
 		Name:    SPtrToLowerStr(data.Name),
 		Region:  SPtrToLowerNoSpaceStr(data.Location),
+		Tags:    tag.FromMap(data.Tags),
 		Extension: &types.AzureVpcExtension{
 			ResourceGroupName: strings.ToLower(resourceGroup),
 			DNSServers:        make([]string, 0),
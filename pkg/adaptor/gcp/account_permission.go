@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"google.golang.org/api/googleapi"
+)
+
+// ListMissingPermission list the iam permissions which are required by resource sync but not granted, region of
+// option is ignored because the probed apis are all global or aggregated.
+// reference: https://cloud.google.com/compute/docs/access/iam-permissions
+func (g *Gcp) ListMissingPermission(kt *kit.Kit, _ *typeaccount.ListMissingPermissionOption) (
+	[]typeaccount.MissingPermission, error) {
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, fmt.Errorf("new gcp compute client failed, err: %v", err)
+	}
+
+	projectID := g.CloudProjectID()
+	probes := []typeaccount.PermissionProbe{
+		{ResType: enumor.VpcCloudResType, Action: "compute.networks.list", Probe: func() error {
+			_, err := client.Networks.List(projectID).MaxResults(1).Context(kt.Ctx).Do()
+			return err
+		}},
+		{ResType: enumor.SubnetCloudResType, Action: "compute.subnetworks.list", Probe: func() error {
+			_, err := client.Subnetworks.AggregatedList(projectID).MaxResults(1).Context(kt.Ctx).Do()
+			return err
+		}},
+		{ResType: enumor.GcpFirewallRuleCloudResType, Action: "compute.firewalls.list", Probe: func() error {
+			_, err := client.Firewalls.List(projectID).MaxResults(1).Context(kt.Ctx).Do()
+			return err
+		}},
+		{ResType: enumor.RouteCloudResType, Action: "compute.routes.list", Probe: func() error {
+			_, err := client.Routes.List(projectID).MaxResults(1).Context(kt.Ctx).Do()
+			return err
+		}},
+		{ResType: enumor.EipCloudResType, Action: "compute.addresses.list", Probe: func() error {
+			_, err := client.Addresses.AggregatedList(projectID).MaxResults(1).Context(kt.Ctx).Do()
+			return err
+		}},
+		{ResType: enumor.CvmCloudResType, Action: "compute.instances.list", Probe: func() error {
+			_, err := client.Instances.AggregatedList(projectID).MaxResults(1).Context(kt.Ctx).Do()
+			return err
+		}},
+		{ResType: enumor.DiskCloudResType, Action: "compute.disks.list", Probe: func() error {
+			_, err := client.Disks.AggregatedList(projectID).MaxResults(1).Context(kt.Ctx).Do()
+			return err
+		}},
+	}
+
+	missing, err := typeaccount.ProbeMissingPermission(probes, func(err error) bool {
+		var apiErr *googleapi.Error
+		return errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden
+	})
+	if err != nil {
+		logs.Errorf("probe gcp permission failed, err: %v, project: %s, rid: %s", err, projectID, kt.Rid)
+		return nil, err
+	}
+
+	return missing, nil
+}
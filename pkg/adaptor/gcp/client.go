@@ -21,13 +21,18 @@ package gcp
 
 import (
 	"fmt"
+	"net/http"
 
+	"hcm/pkg/adaptor/throttle"
 	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 
 	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/cloudresourcemanager/v3"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 )
 
 type clientSet struct {
@@ -38,8 +43,29 @@ func newClientSet(credential *types.GcpCredential) *clientSet {
 	return &clientSet{credential}
 }
 
+// clientOption returns the client option with the credential, the calls of the project are limited by the global
+// throttler.
+func (c *clientSet) clientOption(kt *kit.Kit) (option.ClientOption, error) {
+	credOpt, err := c.credentialOption(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	base := throttle.NewTransport(enumor.Gcp, c.credential.CloudProjectID, nil)
+	transport, err := htransport.NewTransport(kt.Ctx, base, credOpt, option.WithScopes(compute.CloudPlatformScope))
+	if err != nil {
+		return nil, fmt.Errorf("init gcp transport failed, err: %v, rid: %s", err, kt.Rid)
+	}
+
+	return option.WithHTTPClient(&http.Client{Transport: transport}), nil
+}
+
 func (c *clientSet) computeClient(kt *kit.Kit) (*compute.Service, error) {
-	opt := option.WithCredentialsJSON(c.credential.Json)
+	opt, err := c.clientOption(kt)
+	if err != nil {
+		return nil, err
+	}
+
 	service, err := compute.NewService(kt.Ctx, opt)
 	if err != nil {
 		return nil, err
@@ -48,8 +74,26 @@ func (c *clientSet) computeClient(kt *kit.Kit) (*compute.Service, error) {
 	return service, nil
 }
 
+func (c *clientSet) resourceManagerClient(kt *kit.Kit) (*cloudresourcemanager.Service, error) {
+	opt, err := c.clientOption(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := cloudresourcemanager.NewService(kt.Ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
 func (c *clientSet) bigQueryClient(kt *kit.Kit) (*bigquery.Client, error) {
-	opt := option.WithCredentialsJSON(c.credential.Json)
+	opt, err := c.clientOption(kt)
+	if err != nil {
+		return nil, err
+	}
+
 	service, err := bigquery.NewClient(kt.Ctx, c.credential.CloudProjectID, opt)
 	if err != nil {
 		return nil, fmt.Errorf("gcp.bigquery.NewClient, projectID: %s, err: %+v",
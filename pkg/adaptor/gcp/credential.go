@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/kit"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+)
+
+// federatedTokenSourceCache caches the token source of workload identity credential configuration of the projects,
+// the key is the sha256 of the configuration. every token acquisition of external account needs to exchange token
+// with google sts (and iam credentials when impersonation is configured), reuse the token source to avoid doing it
+// for every client.
+var federatedTokenSourceCache = types.NewCredentialCache(types.CredentialCacheTTL)
+
+// credentialOption returns the client option of the credential by the credential type of the account.
+func (c *clientSet) credentialOption(kt *kit.Kit) (option.ClientOption, error) {
+	if c.credential.CredentialType.IsSecretKey() {
+		return option.WithCredentialsJSON(c.credential.Json), nil
+	}
+
+	sum := sha256.Sum256(c.credential.Json)
+	key := hex.EncodeToString(sum[:])
+	if ts, exist := federatedTokenSourceCache.Load(key); exist {
+		return option.WithTokenSource(ts.(oauth2.TokenSource)), nil
+	}
+
+	// the token source lives longer than the request, so it must not be bound to the request context.
+	cred, err := google.CredentialsFromJSON(context.Background(), c.credential.Json, compute.CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("parse gcp credential configuration failed, err: %v, rid: %s", err, kt.Rid)
+	}
+
+	// the configuration hash is also used as the owner, because the accounts of the same project may have different
+	// configurations, they must not evict each other's token source. the stale ones are evicted when expired.
+	ts := federatedTokenSourceCache.LoadOrStore(key, key, oauth2.ReuseTokenSource(nil, cred.TokenSource))
+	return option.WithTokenSource(ts.(oauth2.TokenSource)), nil
+}
@@ -24,6 +24,7 @@ import (
 
 	"hcm/pkg/adaptor/poller"
 	"hcm/pkg/adaptor/types/eip"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -310,6 +311,7 @@ func convert(resp *compute.AddressList, region string) []*eip.GcpEip {
 			Subnetwork:   item.Subnetwork,
 			SelfLink:     item.SelfLink,
 			Users:        item.Users,
+			Tags:         tag.FromMap(item.Labels),
 		}
 		switch item.AddressType {
 		case "EXTERNAL":
@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"fmt"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"google.golang.org/api/cloudresourcemanager/v3"
+)
+
+// ListOrganizationMember list the active projects of the organization or folder, all the projects which the
+// credential has the permission to access are listed if parent is not set.
+// reference: https://cloud.google.com/resource-manager/reference/rest/v3/projects/search
+func (g *Gcp) ListOrganizationMember(kt *kit.Kit, opt *typeaccount.ListOrganizationMemberOption) (
+	[]typeaccount.OrganizationMember, error) {
+
+	if opt == nil {
+		opt = new(typeaccount.ListOrganizationMemberOption)
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := g.clientSet.resourceManagerClient(kt)
+	if err != nil {
+		return nil, fmt.Errorf("new resource manager client failed, err: %v", err)
+	}
+
+	members := make([]typeaccount.OrganizationMember, 0)
+	if len(opt.ParentID) == 0 {
+		err = client.Projects.Search().Query("state:ACTIVE").Pages(kt.Ctx,
+			func(resp *cloudresourcemanager.SearchProjectsResponse) error {
+				for _, one := range resp.Projects {
+					members = append(members, convOrganizationMember(one))
+				}
+				return nil
+			})
+		if err != nil {
+			logs.Errorf("search gcp projects failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		return members, nil
+	}
+
+	if err = listProjectsUnderParent(kt, client, opt.ParentID, &members); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// listProjectsUnderParent list the projects under the parent and its child folders recursively.
+func listProjectsUnderParent(kt *kit.Kit, client *cloudresourcemanager.Service, parent string,
+	members *[]typeaccount.OrganizationMember) error {
+
+	err := client.Projects.List().Parent(parent).Pages(kt.Ctx,
+		func(resp *cloudresourcemanager.ListProjectsResponse) error {
+			for _, one := range resp.Projects {
+				if one.State != "ACTIVE" {
+					continue
+				}
+				*members = append(*members, convOrganizationMember(one))
+			}
+			return nil
+		})
+	if err != nil {
+		logs.Errorf("list gcp projects failed, parent: %s, err: %v, rid: %s", parent, err, kt.Rid)
+		return err
+	}
+
+	folders := make([]string, 0)
+	err = client.Folders.List().Parent(parent).Pages(kt.Ctx, func(resp *cloudresourcemanager.ListFoldersResponse) error {
+		for _, one := range resp.Folders {
+			if one.State != "ACTIVE" {
+				continue
+			}
+			folders = append(folders, one.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		logs.Errorf("list gcp folders failed, parent: %s, err: %v, rid: %s", parent, err, kt.Rid)
+		return err
+	}
+
+	for _, folder := range folders {
+		if err = listProjectsUnderParent(kt, client, folder, members); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func convOrganizationMember(project *cloudresourcemanager.Project) typeaccount.OrganizationMember {
+	return typeaccount.OrganizationMember{
+		CloudID:  project.ProjectId,
+		Name:     project.DisplayName,
+		Status:   project.State,
+		ParentID: project.Parent,
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"hcm/pkg/adaptor/types/eip"
+	"hcm/pkg/adaptor/types/tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// UpdateResourceLabels upsert and delete the labels of the cvm, disk and eip.
+// gcp only supports to set all the labels with the fingerprint, so labels are got from cloud and merged first, the
+// labels that are not included in the option are kept.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/setLabels
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/setLabels
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/addresses/setLabels
+func (g *Gcp) UpdateResourceLabels(kt *kit.Kit, opt *tag.GcpLabelUpdateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "update option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	switch opt.ResType {
+	case tag.CvmResType:
+		err = g.updateCvmLabels(kt, client, opt)
+	case tag.DiskResType:
+		err = g.updateDiskLabels(kt, client, opt)
+	case tag.EipResType:
+		err = g.updateEipLabels(kt, client, opt)
+	}
+
+	if err != nil {
+		logs.Errorf("set gcp %s labels failed, err: %v, name: %s, rid: %s", opt.ResType, err, opt.Name, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func (g *Gcp) updateCvmLabels(kt *kit.Kit, client *compute.Service, opt *tag.GcpLabelUpdateOption) error {
+	projectID := g.CloudProjectID()
+	instance, err := client.Instances.Get(projectID, opt.Location, opt.Name).Context(kt.Ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	req := &compute.InstancesSetLabelsRequest{
+		LabelFingerprint: instance.LabelFingerprint,
+		Labels:           mergeLabels(instance.Labels, opt),
+	}
+	_, err = client.Instances.SetLabels(projectID, opt.Location, opt.Name, req).Context(kt.Ctx).RequestId(kt.Rid).Do()
+	return err
+}
+
+func (g *Gcp) updateDiskLabels(kt *kit.Kit, client *compute.Service, opt *tag.GcpLabelUpdateOption) error {
+	projectID := g.CloudProjectID()
+	disk, err := client.Disks.Get(projectID, opt.Location, opt.Name).Context(kt.Ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	req := &compute.ZoneSetLabelsRequest{
+		LabelFingerprint: disk.LabelFingerprint,
+		Labels:           mergeLabels(disk.Labels, opt),
+	}
+	_, err = client.Disks.SetLabels(projectID, opt.Location, opt.Name, req).Context(kt.Ctx).RequestId(kt.Rid).Do()
+	return err
+}
+
+func (g *Gcp) updateEipLabels(kt *kit.Kit, client *compute.Service, opt *tag.GcpLabelUpdateOption) error {
+	projectID := g.CloudProjectID()
+	if opt.Location == eip.GcpGlobalRegion {
+		address, err := client.GlobalAddresses.Get(projectID, opt.Name).Context(kt.Ctx).Do()
+		if err != nil {
+			return err
+		}
+
+		req := &compute.GlobalSetLabelsRequest{
+			LabelFingerprint: address.LabelFingerprint,
+			Labels:           mergeLabels(address.Labels, opt),
+		}
+		_, err = client.GlobalAddresses.SetLabels(projectID, opt.Name, req).Context(kt.Ctx).Do()
+		return err
+	}
+
+	address, err := client.Addresses.Get(projectID, opt.Location, opt.Name).Context(kt.Ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	req := &compute.RegionSetLabelsRequest{
+		LabelFingerprint: address.LabelFingerprint,
+		Labels:           mergeLabels(address.Labels, opt),
+	}
+	_, err = client.Addresses.SetLabels(projectID, opt.Location, opt.Name, req).Context(kt.Ctx).RequestId(kt.Rid).Do()
+	return err
+}
+
+// mergeLabels merge the labels of cloud with the labels to upsert and delete.
+func mergeLabels(labels map[string]string, opt *tag.GcpLabelUpdateOption) map[string]string {
+	result := make(map[string]string, len(labels)+len(opt.UpsertTags))
+	for key, value := range labels {
+		result[key] = value
+	}
+
+	for _, one := range opt.UpsertTags {
+		result[one.Key] = one.Value
+	}
+
+	for _, key := range opt.DeleteKeys {
+		delete(result, key)
+	}
+
+	return result
+}
@@ -40,7 +40,7 @@ func (h *HuaWei) AccountCheck(kt *kit.Kit, opt *types.HuaWeiAccountInfo) error {
 		return err
 	}
 
-	domainsResp, err := client.KeystoneListAuthDomains(nil)
+	domainsResp, err := callSDK(kt, h.clientSet, "KeystoneListAuthDomains", client.KeystoneListAuthDomains, nil)
 	if err != nil {
 		logs.Errorf("KeystoneListAuthDomains failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -71,7 +71,7 @@ func (h *HuaWei) GetAccountQuota(kt *kit.Kit, opt *typeaccount.GetHuaWeiAccountZ
 		return nil, err
 	}
 
-	resp, err := client.ShowServerLimits(nil)
+	resp, err := callSDK(kt, h.clientSet, "ShowServerLimits", client.ShowServerLimits, nil)
 	if err != nil {
 		logs.Errorf("show huawei server limit failed, err: %v, rid: %s", err, kt.Rid)
 		return nil, err
@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
+	ecsmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2/model"
+	eipmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/eip/v2/model"
+	evsmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+	v2 "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v2/model"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v3/model"
+)
+
+// defaultPermissionProbeRegion is the region used to probe permission when region is not specified.
+const defaultPermissionProbeRegion = "ap-southeast-1"
+
+// ListMissingPermission list the iam permissions which are required by resource sync but not granted.
+// reference: https://support.huaweicloud.com/usermanual-iam/iam_01_0605.html
+func (h *HuaWei) ListMissingPermission(kt *kit.Kit, opt *typeaccount.ListMissingPermissionOption) (
+	[]typeaccount.MissingPermission, error) {
+
+	region := defaultPermissionProbeRegion
+	if opt != nil && len(opt.Region) != 0 {
+		region = opt.Region
+	}
+
+	vpcCli, err := h.clientSet.vpcClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("new huawei vpc client failed, err: %v", err)
+	}
+
+	vpcV2Cli, err := h.clientSet.vpcClientV2(region)
+	if err != nil {
+		return nil, fmt.Errorf("new huawei vpc v2 client failed, err: %v", err)
+	}
+
+	ecsCli, err := h.clientSet.ecsClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("new huawei ecs client failed, err: %v", err)
+	}
+
+	evsCli, err := h.clientSet.evsClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("new huawei evs client failed, err: %v", err)
+	}
+
+	eipCli, err := h.clientSet.eipClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("new huawei eip client failed, err: %v", err)
+	}
+
+	limit := converter.ValToPtr(int32(1))
+	probes := []typeaccount.PermissionProbe{
+		{ResType: enumor.VpcCloudResType, Action: "vpc:vpcs:list", Probe: func() error {
+			_, err := callSDK(kt, h.clientSet, "ListVpcs", vpcCli.ListVpcs, &model.ListVpcsRequest{Limit: limit})
+			return err
+		}},
+		{ResType: enumor.SubnetCloudResType, Action: "vpc:subnets:get", Probe: func() error {
+			_, err := callSDK(kt, h.clientSet, "ListSubnets", vpcV2Cli.ListSubnets,
+				&v2.ListSubnetsRequest{Limit: limit})
+			return err
+		}},
+		{ResType: enumor.SecurityGroupCloudResType, Action: "vpc:securityGroups:get", Probe: func() error {
+			_, err := callSDK(kt, h.clientSet, "ListSecurityGroups", vpcCli.ListSecurityGroups,
+				&model.ListSecurityGroupsRequest{Limit: limit})
+			return err
+		}},
+		{ResType: enumor.RouteTableCloudResType, Action: "vpc:routeTables:list", Probe: func() error {
+			_, err := callSDK(kt, h.clientSet, "ListRouteTables", vpcV2Cli.ListRouteTables,
+				&v2.ListRouteTablesRequest{Limit: limit})
+			return err
+		}},
+		{ResType: enumor.EipCloudResType, Action: "vpc:publicIps:list", Probe: func() error {
+			_, err := callSDK(kt, h.clientSet, "ListPublicips", eipCli.ListPublicips,
+				&eipmodel.ListPublicipsRequest{Limit: limit})
+			return err
+		}},
+		{ResType: enumor.CvmCloudResType, Action: "ecs:cloudServers:list", Probe: func() error {
+			_, err := callSDK(kt, h.clientSet, "ListServersDetails", ecsCli.ListServersDetails,
+				&ecsmodel.ListServersDetailsRequest{Limit: limit})
+			return err
+		}},
+		{ResType: enumor.DiskCloudResType, Action: "evs:volumes:list", Probe: func() error {
+			_, err := callSDK(kt, h.clientSet, "ListVolumes", evsCli.ListVolumes,
+				&evsmodel.ListVolumesRequest{Limit: limit})
+			return err
+		}},
+	}
+
+	missing, err := typeaccount.ProbeMissingPermission(probes, func(err error) bool {
+		var respErr *sdkerr.ServiceResponseError
+		return errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden
+	})
+	if err != nil {
+		logs.Errorf("probe huawei permission failed, err: %v, region: %s, rid: %s", err, region, kt.Rid)
+		return nil, err
+	}
+
+	return missing, nil
+}
@@ -32,7 +32,7 @@ import (
 
 // GetBillList get bill list.
 // reference: https://support.huaweicloud.com/api-oce/mbc_00003.html
-func (h *HuaWei) GetBillList(_ *kit.Kit, opt *typesBill.HuaWeiBillListOption) (
+func (h *HuaWei) GetBillList(kt *kit.Kit, opt *typesBill.HuaWeiBillListOption) (
 	*model.ListCustomerselfResourceRecordDetailsResponse, error) {
 
 	if err := opt.Validate(); err != nil {
@@ -54,7 +54,8 @@ func (h *HuaWei) GetBillList(_ *kit.Kit, opt *typesBill.HuaWeiBillListOption) (
 		req.Body.Limit = opt.Page.Limit
 	}
 
-	resp, err := client.ListCustomerselfResourceRecordDetails(req)
+	resp, err := callSDK(kt, h.clientSet, "ListCustomerselfResourceRecordDetails",
+		client.ListCustomerselfResourceRecordDetails, req)
 	if err != nil {
 		logs.Errorf("huawei bill list request adaptor failed, opt: %+v, err: %+v", opt, err)
 		return nil, err
@@ -21,12 +21,17 @@ package huawei
 
 import (
 	"fmt"
+	"net/http"
 
+	"hcm/pkg/adaptor/throttle"
 	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/logs"
 
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/auth/basic"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/auth/global"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/config"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/httphandler"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/region"
 	bssintl "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/bssintl/v2"
 	bssintlv2region "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/bssintl/v2/region"
@@ -55,9 +60,16 @@ const (
 type clientSet struct {
 	credentials       *basic.Credentials
 	globalCredentials *global.Credentials
+	// account is the account whose calls are limited by the global throttler.
+	account string
 }
 
 func newClientSet(secret *types.BaseSecret) *clientSet {
+	account := secret.CloudAccountID
+	if len(account) == 0 {
+		account = throttle.SecretAccount(secret.CloudSecretID)
+	}
+
 	return &clientSet{
 		credentials: basic.NewCredentialsBuilder().
 			WithAk(secret.CloudSecretID).
@@ -67,9 +79,31 @@ func newClientSet(secret *types.BaseSecret) *clientSet {
 			WithAk(secret.CloudSecretID).
 			WithSk(secret.CloudSecretKey).
 			Build(),
+		account: account,
 	}
 }
 
+// httpConfig returns the http config of clients, huawei sdk does not support custom transport, so the calls of the
+// account are limited by the global throttler in the request and response handlers. the calls are not traced here,
+// because the handlers only get the copies of the request and response, the sdk call sites are traced by callSDK.
+func (c *clientSet) httpConfig() *config.HttpConfig {
+	handler := httphandler.NewHttpHandler().
+		AddRequestHandler(func(req http.Request) {
+			// request handler is called before the request is sent, it blocks the call until it's allowed.
+			key := throttle.KeyOf(enumor.HuaWei, c.account, &req)
+			if err := throttle.Global().Wait(req.Context(), key); err != nil {
+				logs.Errorf("wait huawei api throttle failed, err: %v, key: %+v", err, key)
+			}
+		}).
+		AddResponseHandler(func(resp http.Response) {
+			// only the status code is inspected, the body of the copied response must not be read.
+			throttled, retryAfter := throttle.IsThrottled(enumor.HuaWei, &resp)
+			throttle.Global().Observe(throttle.KeyOf(enumor.HuaWei, c.account, resp.Request), throttled, retryAfter)
+		})
+
+	return config.DefaultHttpConfig().WithHttpHandler(handler)
+}
+
 func (c *clientSet) iamClient(region *region.Region) (client *iam.IamClient, err error) {
 	defer func() {
 		if p := recover(); p != nil {
@@ -81,7 +115,7 @@ func (c *clientSet) iamClient(region *region.Region) (client *iam.IamClient, err
 		iam.IamClientBuilder().
 			WithRegion(region).
 			WithCredential(c.credentials).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -97,7 +131,7 @@ func (c *clientSet) iamRegionClient(region string) (client *iam.IamClient, err e
 		iam.IamClientBuilder().
 			WithRegion(iamregion.ValueOf(region)).
 			WithCredential(c.credentials).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -113,7 +147,7 @@ func (c *clientSet) evsClient(region string) (client *evs.EvsClient, err error)
 		evs.EvsClientBuilder().
 			WithRegion(evsregion.ValueOf(region)).
 			WithCredential(c.credentials).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -130,7 +164,7 @@ func (c *clientSet) vpcClient(regionID string) (cli *vpc.VpcClient, err error) {
 		vpc.VpcClientBuilder().
 			WithRegion(vpcregion.ValueOf(regionID)).
 			WithCredential(c.credentials).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -147,7 +181,7 @@ func (c *clientSet) vpcClientV2(regionID string) (cli *vpcv2.VpcClient, err erro
 		vpcv2.VpcClientBuilder().
 			WithRegion(vpcregion.ValueOf(regionID)).
 			WithCredential(c.credentials).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -164,7 +198,7 @@ func (c *clientSet) imsClientV2(region *region.Region) (cli *ims.ImsClient, err
 		ims.ImsClientBuilder().
 			WithRegion(region).
 			WithCredential(c.credentials).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return cli, nil
@@ -181,7 +215,7 @@ func (c *clientSet) ecsClient(regionID string) (cli *ecs.EcsClient, err error) {
 		ecs.EcsClientBuilder().
 			WithRegion(ecsregion.ValueOf(regionID)).
 			WithCredential(c.credentials).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -198,7 +232,7 @@ func (c *clientSet) dcsClient(regionID string) (cli *dcs.DcsClient, err error) {
 		dcs.DcsClientBuilder().
 			WithRegion(dcsregion.ValueOf(regionID)).
 			WithCredential(c.credentials).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -215,7 +249,7 @@ func (c *clientSet) eipClient(regionID string) (cli *eip.EipClient, err error) {
 		eip.EipClientBuilder().
 			WithRegion(eipregion.ValueOf(regionID)).
 			WithCredential(c.credentials).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return cli, nil
@@ -232,7 +266,7 @@ func (c *clientSet) eipV3Client(regionID string) (cli *eipv3.EipClient, err erro
 		eipv3.EipClientBuilder().
 			WithRegion(eipv3region.ValueOf(regionID)).
 			WithCredential(c.credentials).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return cli, nil
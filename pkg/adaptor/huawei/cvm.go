@@ -65,7 +65,7 @@ func (h *HuaWei) ListCvmNew(kt *kit.Kit, opt *typecvm.HuaWeiListOption) ([]typec
 		req.Offset = converter.ValToPtr(opt.Page.Offset)
 	}
 
-	resp, err := client.ListServersDetails(req)
+	resp, err := callSDK(kt, h.clientSet, "ListServersDetails", client.ListServersDetails, req)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +106,7 @@ func (h *HuaWei) ListCvm(kt *kit.Kit, opt *typecvm.HuaWeiListOption) (*[]model.S
 		req.Offset = converter.ValToPtr(opt.Page.Offset)
 	}
 
-	resp, err := client.ListServersDetails(req)
+	resp, err := callSDK(kt, h.clientSet, "ListServersDetails", client.ListServersDetails, req)
 	if err != nil {
 		if strings.Contains(err.Error(), ErrDataNotFound) {
 			return nil, nil
@@ -149,7 +149,7 @@ func (h *HuaWei) DeleteCvm(kt *kit.Kit, opt *typecvm.HuaWeiDeleteOption) error {
 		},
 	}
 
-	_, err = client.DeleteServers(req)
+	_, err = callSDK(kt, h.clientSet, "DeleteServers", client.DeleteServers, req)
 	if err != nil {
 		logs.Errorf("delete huawei cvm failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -189,7 +189,7 @@ func (h *HuaWei) StartCvm(kt *kit.Kit, opt *typecvm.HuaWeiStartOption) error {
 		},
 	}
 
-	_, err = client.BatchStartServers(req)
+	_, err = callSDK(kt, h.clientSet, "BatchStartServers", client.BatchStartServers, req)
 	if err != nil {
 		logs.Errorf("batch start huawei cvm failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -247,7 +247,7 @@ func (h *HuaWei) StopCvm(kt *kit.Kit, opt *typecvm.HuaWeiStopOption) error {
 		},
 	}
 
-	_, err = client.BatchStopServers(req)
+	_, err = callSDK(kt, h.clientSet, "BatchStopServers", client.BatchStopServers, req)
 	if err != nil {
 		logs.Errorf("batch stop huawei cvm failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -305,7 +305,7 @@ func (h *HuaWei) RebootCvm(kt *kit.Kit, opt *typecvm.HuaWeiRebootOption) error {
 		},
 	}
 
-	_, err = client.BatchRebootServers(req)
+	_, err = callSDK(kt, h.clientSet, "BatchRebootServers", client.BatchRebootServers, req)
 	if err != nil {
 		logs.Errorf("batch reboot huawei cvm failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -354,7 +354,7 @@ func (h *HuaWei) ResetCvmPwd(kt *kit.Kit, opt *typecvm.HuaWeiResetPwdOption) err
 		},
 	}
 
-	_, err = client.BatchResetServersPassword(req)
+	_, err = callSDK(kt, h.clientSet, "BatchResetServersPassword", client.BatchResetServersPassword, req)
 	if err != nil {
 		logs.Errorf("batch reset pwd huawei cvm failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -471,7 +471,7 @@ func (h *HuaWei) CreateCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (*polle
 		}
 	}
 
-	resp, err := client.CreateServers(req)
+	resp, err := callSDK(kt, h.clientSet, "CreateServers", client.CreateServers, req)
 	if err != nil {
 		logs.Errorf("create huawei cvm failed, err: %v, rid: %s", err, kt.Rid)
 		return nil, err
@@ -581,7 +581,7 @@ func poll(client *HuaWei, kt *kit.Kit, region string, cloudIDs []*string) ([]mod
 			return nil, err
 		}
 
-		resp, err := cvmCli.ListServersDetails(req)
+		resp, err := callSDK(kt, client.clientSet, "ListServersDetails", cvmCli.ListServersDetails, req)
 		if err != nil {
 			return nil, err
 		}
@@ -641,7 +641,7 @@ func (h *createCvmPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*
 			return nil, err
 		}
 
-		resp, err := cvmCli.ListServersDetails(req)
+		resp, err := callSDK(kt, client.clientSet, "ListServersDetails", cvmCli.ListServersDetails, req)
 		if err != nil {
 			return nil, err
 		}
@@ -41,7 +41,7 @@ func (h *HuaWei) CreateDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption) (*pol
 		return nil, errf.New(errf.InvalidParameter, "huawei disk create option is required")
 	}
 
-	resp, err := h.createDisk(opt)
+	resp, err := h.createDisk(kt, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +56,7 @@ func (h *HuaWei) CreateDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption) (*pol
 	return respPoller.PollUntilDone(h, kt, common.StringPtrs(*resp.VolumeIds), nil)
 }
 
-func (h *HuaWei) createDisk(opt *disk.HuaWeiDiskCreateOption) (*model.CreateVolumeResponse, error) {
+func (h *HuaWei) createDisk(kt *kit.Kit, opt *disk.HuaWeiDiskCreateOption) (*model.CreateVolumeResponse, error) {
 	client, err := h.clientSet.evsClient(opt.Region)
 	if err != nil {
 		return nil, err
@@ -67,7 +67,7 @@ func (h *HuaWei) createDisk(opt *disk.HuaWeiDiskCreateOption) (*model.CreateVolu
 		return nil, err
 	}
 
-	return client.CreateVolume(req)
+	return callSDK(kt, h.clientSet, "CreateVolume", client.CreateVolume, req)
 }
 
 // TODO: sync-todo 改好后统一删除ListDisk函数
@@ -96,7 +96,7 @@ func (h *HuaWei) ListDiskNew(kt *kit.Kit, opt *disk.HuaWeiDiskListOption) ([]dis
 		req.Ids = converter.StringSliceToSliceStringPtr(opt.CloudIDs)
 	}
 
-	resp, err := client.ListVolumes(req)
+	resp, err := callSDK(kt, h.clientSet, "ListVolumes", client.ListVolumes, req)
 	if err != nil {
 		if strings.Contains(err.Error(), ErrDataNotFound) {
 			return make([]disk.HuaWeiDisk, 0), nil
@@ -138,7 +138,7 @@ func (h *HuaWei) ListDisk(kt *kit.Kit, opt *disk.HuaWeiDiskListOption) ([]model.
 		req.Ids = converter.StringSliceToSliceStringPtr(opt.CloudIDs)
 	}
 
-	resp, err := client.ListVolumes(req)
+	resp, err := callSDK(kt, h.clientSet, "ListVolumes", client.ListVolumes, req)
 	if err != nil {
 		if strings.Contains(err.Error(), ErrDataNotFound) {
 			return make([]model.VolumeDetail, 0), nil
@@ -167,7 +167,7 @@ func (h *HuaWei) DeleteDisk(kt *kit.Kit, opt *disk.HuaWeiDiskDeleteOption) error
 		return err
 	}
 
-	resp, err := client.DeleteVolume(req)
+	resp, err := callSDK(kt, h.clientSet, "DeleteVolume", client.DeleteVolume, req)
 	if err != nil {
 		logs.Errorf(
 			"huawei delete disk failed, err: %v, rid: %s, job id: %s",
@@ -198,7 +198,7 @@ func (h *HuaWei) AttachDisk(kt *kit.Kit, opt *disk.HuaWeiDiskAttachOption) error
 		return err
 	}
 
-	_, err = client.AttachServerVolume(req)
+	_, err = callSDK(kt, h.clientSet, "AttachServerVolume", client.AttachServerVolume, req)
 	if err != nil {
 		logs.Errorf("huawei attach disk failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -228,7 +228,7 @@ func (h *HuaWei) DetachDisk(kt *kit.Kit, opt *disk.HuaWeiDiskDetachOption) error
 		return err
 	}
 
-	_, err = client.DetachServerVolume(req)
+	_, err = callSDK(kt, h.clientSet, "DetachServerVolume", client.DetachServerVolume, req)
 	if err != nil {
 		logs.Errorf("huawei detach disk failed, err: %v, rid: %s, job id: %s", err, kt.Rid)
 		return err
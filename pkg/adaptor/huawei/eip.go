@@ -63,7 +63,7 @@ func (h *HuaWei) ListEip(kt *kit.Kit, opt *eip.HuaWeiEipListOption) (*eip.HuaWei
 		req.Marker = opt.Marker
 	}
 
-	resp, err := client.ListPublicips(req)
+	resp, err := callSDK(kt, h.clientSet, "ListPublicips", client.ListPublicips, req)
 	if err != nil {
 		if strings.Contains(err.Error(), ErrDataNotFound) {
 			return new(eip.HuaWeiEipListResult), nil
@@ -96,7 +96,7 @@ func (h *HuaWei) ListEip(kt *kit.Kit, opt *eip.HuaWeiEipListOption) (*eip.HuaWei
 		if publicIp.BandwidthId != nil {
 			request := &model.ShowBandwidthRequest{}
 			request.BandwidthId = converter.PtrToVal(publicIp.BandwidthId)
-			response, _ := client.ShowBandwidth(request)
+			response, _ := callSDK(kt, h.clientSet, "ShowBandwidth", client.ShowBandwidth, request)
 			if response.Bandwidth != nil {
 				if response.Bandwidth.ChargeMode != nil {
 					eips[idx].ChargeMode = response.Bandwidth.ChargeMode.Value()
@@ -125,7 +125,7 @@ func (h *HuaWei) DeleteEip(kt *kit.Kit, opt *eip.HuaWeiEipDeleteOption) error {
 		return err
 	}
 
-	_, err = client.DeletePublicip(req)
+	_, err = callSDK(kt, h.clientSet, "DeletePublicip", client.DeletePublicip, req)
 	if err != nil {
 		logs.Errorf("delete huawei eip failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -151,7 +151,7 @@ func (h *HuaWei) AssociateEip(kt *kit.Kit, opt *eip.HuaWeiEipAssociateOption) er
 		return err
 	}
 
-	_, err = client.UpdatePublicip(req)
+	_, err = callSDK(kt, h.clientSet, "UpdatePublicip", client.UpdatePublicip, req)
 	if err != nil {
 		logs.Errorf("associate huawei eip failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -184,7 +184,7 @@ func (h *HuaWei) DisassociateEip(kt *kit.Kit, opt *eip.HuaWeiEipDisassociateOpti
 		return err
 	}
 
-	_, err = client.UpdatePublicip(req)
+	_, err = callSDK(kt, h.clientSet, "UpdatePublicip", client.UpdatePublicip, req)
 	if err != nil {
 		logs.Errorf("disassociate huawei eip failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -224,7 +224,7 @@ func (h *HuaWei) CreateEip(kt *kit.Kit, opt *eip.HuaWeiEipCreateOption) (*poller
 			return nil, err
 		}
 
-		resp, err := client.CreatePrePaidPublicip(req)
+		resp, err := callSDK(kt, h.clientSet, "CreatePrePaidPublicip", client.CreatePrePaidPublicip, req)
 		if err != nil {
 			return nil, err
 		}
@@ -239,7 +239,7 @@ func (h *HuaWei) CreateEip(kt *kit.Kit, opt *eip.HuaWeiEipCreateOption) (*poller
 		return nil, err
 	}
 
-	resp, err := client.CreatePublicip(req)
+	resp, err := callSDK(kt, h.clientSet, "CreatePublicip", client.CreatePublicip, req)
 	if err != nil {
 		return nil, err
 	}
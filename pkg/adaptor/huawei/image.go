@@ -22,6 +22,7 @@ package huawei
 import (
 	"hcm/pkg/adaptor/types/image"
 	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
 
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ims/v2/model"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ims/v2/region"
@@ -29,7 +30,7 @@ import (
 
 // ListImage 查询公共镜像列表
 // reference: https://support.huaweicloud.com/api-ims/ims_03_0602.html
-func (h *HuaWei) ListImage(opt *image.HuaWeiImageListOption) (*image.HuaWeiImageListResult, error) {
+func (h *HuaWei) ListImage(kt *kit.Kit, opt *image.HuaWeiImageListOption) (*image.HuaWeiImageListResult, error) {
 
 	client, err := h.clientSet.imsClientV2(region.ValueOf(opt.Region))
 	if err != nil {
@@ -46,7 +47,7 @@ func (h *HuaWei) ListImage(opt *image.HuaWeiImageListOption) (*image.HuaWeiImage
 		Status:    &status,
 	}
 
-	resp, err := client.ListImages(req)
+	resp, err := callSDK(kt, h.clientSet, "ListImages", client.ListImages, req)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +51,7 @@ func (h *HuaWei) ListInstanceType(kt *kit.Kit, opt *typesinstancetype.HuaWeiInst
 		AvailabilityZone: &opt.Zone,
 	}
 
-	resp, err := client.ListFlavors(req)
+	resp, err := callSDK(kt, h.clientSet, "ListFlavors", client.ListFlavors, req)
 	if err != nil {
 		logs.Errorf("list huawei instance type failed, err: %v, rid: %s", err, kt.Rid)
 		return nil, err
@@ -45,7 +45,7 @@ func (h *HuaWei) ListNetworkInterface(kt *kit.Kit, opt *typesniproto.HuaWeiNILis
 
 	req := new(ecsmodel.ListServerInterfacesRequest)
 	req.ServerId = opt.ServerID
-	resp, err := client.ListServerInterfaces(req)
+	resp, err := callSDK(kt, h.clientSet, "ListServerInterfaces", client.ListServerInterfaces, req)
 	if err != nil {
 		if strings.Contains(err.Error(), ErrDataNotFound) {
 			return new(typesniproto.HuaWeiInterfaceListResult), nil
@@ -195,7 +195,7 @@ func (h *HuaWei) GetPublicIpsMapByPortIDs(kt *kit.Kit, opt *typesniproto.HuaWeiE
 
 	req := new(eipmodel.ListPublicipsRequest)
 	req.VnicPortId = converter.ValToPtr(opt.VnicPortIDs)
-	resp, err := client.ListPublicips(req)
+	resp, err := callSDK(kt, h.clientSet, "ListPublicips", client.ListPublicips, req)
 	if err != nil {
 		logs.Errorf("list huawei eip failed, region: %s, err: %v, rid: %s", opt.Region, err, kt.Rid)
 		return nil, err
@@ -239,7 +239,7 @@ func (h *HuaWei) GetSecurityGroupsByPortID(kt *kit.Kit, opt *typesniproto.HuaWei
 
 	req := new(vpcmodel.ShowPortRequest)
 	req.PortId = opt.PortID
-	resp, err := client.ShowPort(req)
+	resp, err := callSDK(kt, h.clientSet, "ShowPort", client.ShowPort, req)
 	if err != nil {
 		logs.Errorf("list huawei port info failed, region: %s, portID: %s, err: %v, rid: %s",
 			opt.Region, opt.PortID, err, kt.Rid)
@@ -271,7 +271,7 @@ func (h *HuaWei) GetSecurityGroupsByNetID(kt *kit.Kit, opt *typesniproto.HuaWeiP
 
 	req := new(vpcmodel.ListPortsRequest)
 	req.NetworkId = converter.ValToPtr(opt.NetID)
-	resp, err := client.ListPorts(req)
+	resp, err := callSDK(kt, h.clientSet, "ListPorts", client.ListPorts, req)
 	if err != nil {
 		logs.Errorf("list huawei ports failed, region: %s, netID: %s, err: %v, rid: %s",
 			opt.Region, opt.NetID, err, kt.Rid)
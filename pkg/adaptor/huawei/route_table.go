@@ -53,7 +53,7 @@ func (h *HuaWei) UpdateRouteTable(kt *kit.Kit, opt *routetable.HuaWeiRouteTableU
 		},
 	}
 
-	_, err = vpcClient.UpdateRouteTable(req)
+	_, err = callSDK(kt, h.clientSet, "UpdateRouteTable", vpcClient.UpdateRouteTable, req)
 	if err != nil {
 		logs.Errorf("update huawei route table failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -78,7 +78,7 @@ func (h *HuaWei) DeleteRouteTable(kt *kit.Kit, opt *core.BaseRegionalDeleteOptio
 		RoutetableId: opt.ResourceID,
 	}
 
-	_, err = vpcClient.DeleteRouteTable(req)
+	_, err = callSDK(kt, h.clientSet, "DeleteRouteTable", vpcClient.DeleteRouteTable, req)
 	if err != nil {
 		logs.Errorf("delete huawei route table failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -118,7 +118,7 @@ func (h *HuaWei) ListRouteTables(kt *kit.Kit, opt *routetable.HuaWeiRouteTableLi
 		req.Limit = opt.Page.Limit
 	}
 
-	resp, err := vpcClient.ListRouteTables(req)
+	resp, err := callSDK(kt, h.clientSet, "ListRouteTables", vpcClient.ListRouteTables, req)
 	if err != nil {
 		if strings.Contains(err.Error(), ErrDataNotFound) {
 			return nil, nil
@@ -179,7 +179,7 @@ func (h *HuaWei) ListRouteTableIDs(kt *kit.Kit, opt *routetable.HuaWeiRouteTable
 		req.Limit = opt.Page.Limit
 	}
 
-	resp, err := vpcClient.ListRouteTables(req)
+	resp, err := callSDK(kt, h.clientSet, "ListRouteTables", vpcClient.ListRouteTables, req)
 	if err != nil {
 		if strings.Contains(err.Error(), ErrDataNotFound) {
 			return make([]string, 0), nil
@@ -216,7 +216,7 @@ func (h *HuaWei) GetRouteTable(kt *kit.Kit, opt *routetable.HuaWeiRouteTableGetO
 		RoutetableId: opt.ID,
 	}
 
-	resp, err := vpcClient.ShowRouteTable(req)
+	resp, err := callSDK(kt, h.clientSet, "ShowRouteTable", vpcClient.ShowRouteTable, req)
 	if err != nil {
 		logs.Errorf("get huawei route table failed, err: %v, rid: %s", err, kt.Rid)
 		return nil, fmt.Errorf("get huawei route table failed, err: %v", err)
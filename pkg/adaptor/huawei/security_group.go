@@ -60,7 +60,7 @@ func (h *HuaWei) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.HuaWeiCreat
 			},
 		},
 	}
-	resp, err := client.CreateSecurityGroup(req)
+	resp, err := callSDK(kt, h.clientSet, "CreateSecurityGroup", client.CreateSecurityGroup, req)
 	if err != nil {
 		logs.Errorf("create huawei security group failed, err: %v, rid: %s", err, kt.Rid)
 		return nil, err
@@ -93,7 +93,7 @@ func (h *HuaWei) DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.HuaWeiDelet
 	req := &model.DeleteSecurityGroupRequest{
 		SecurityGroupId: opt.CloudID,
 	}
-	_, err = client.DeleteSecurityGroup(req)
+	_, err = callSDK(kt, h.clientSet, "DeleteSecurityGroup", client.DeleteSecurityGroup, req)
 	if err != nil {
 		logs.Errorf("delete huawei security group failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -132,7 +132,7 @@ func (h *HuaWei) UpdateSecurityGroup(kt *kit.Kit, opt *securitygroup.HuaWeiUpdat
 		req.Body.SecurityGroup.Name = &opt.Name
 	}
 
-	_, err = client.UpdateSecurityGroup(req)
+	_, err = callSDK(kt, h.clientSet, "UpdateSecurityGroup", client.UpdateSecurityGroup, req)
 	if err != nil {
 		logs.Errorf("update huawei security group failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -170,7 +170,7 @@ func (h *HuaWei) ListSecurityGroupNew(kt *kit.Kit, opt *securitygroup.HuaWeiList
 		req.Limit = opt.Page.Limit
 	}
 
-	resp, err := client.ListSecurityGroups(req)
+	resp, err := callSDK(kt, h.clientSet, "ListSecurityGroups", client.ListSecurityGroups, req)
 	if err != nil {
 		if strings.Contains(err.Error(), ErrDataNotFound) {
 			return nil, nil, nil
@@ -215,7 +215,7 @@ func (h *HuaWei) ListSecurityGroup(kt *kit.Kit, opt *securitygroup.HuaWeiListOpt
 		req.Limit = opt.Page.Limit
 	}
 
-	resp, err := client.ListSecurityGroups(req)
+	resp, err := callSDK(kt, h.clientSet, "ListSecurityGroups", client.ListSecurityGroups, req)
 	if err != nil {
 		if strings.Contains(err.Error(), ErrDataNotFound) {
 			return nil, nil, nil
@@ -252,7 +252,7 @@ func (h *HuaWei) SecurityGroupCvmAssociate(kt *kit.Kit, opt *securitygroup.HuaWe
 		},
 	}
 
-	_, err = client.NovaAssociateSecurityGroup(req)
+	_, err = callSDK(kt, h.clientSet, "NovaAssociateSecurityGroup", client.NovaAssociateSecurityGroup, req)
 	if err != nil {
 		logs.Errorf("associate tcloud security group and cvm failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -286,7 +286,7 @@ func (h *HuaWei) SecurityGroupCvmDisassociate(kt *kit.Kit, opt *securitygroup.Hu
 		},
 	}
 
-	_, err = client.NovaDisassociateSecurityGroup(req)
+	_, err = callSDK(kt, h.clientSet, "NovaDisassociateSecurityGroup", client.NovaDisassociateSecurityGroup, req)
 	if err != nil {
 		logs.Errorf("disassociate tcloud security group and cvm failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -75,7 +75,7 @@ func (h *HuaWei) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.Hua
 			SecurityGroupRule: rule,
 		},
 	}
-	resp, err := client.CreateSecurityGroupRule(req)
+	resp, err := callSDK(kt, h.clientSet, "CreateSecurityGroupRule", client.CreateSecurityGroupRule, req)
 	if err != nil {
 		logs.Errorf("create huawei security group rule failed, err: %v, rid: %s", err, kt.Rid)
 		return nil, err
@@ -104,7 +104,7 @@ func (h *HuaWei) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.Hua
 	req := &model.DeleteSecurityGroupRuleRequest{
 		SecurityGroupRuleId: opt.CloudRuleID,
 	}
-	_, err = client.DeleteSecurityGroupRule(req)
+	_, err = callSDK(kt, h.clientSet, "DeleteSecurityGroupRule", client.DeleteSecurityGroupRule, req)
 	if err != nil {
 		logs.Errorf("delete huawei security group rule failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -140,7 +140,7 @@ func (h *HuaWei) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.HuaWe
 		req.Limit = opt.Page.Limit
 	}
 
-	resp, err := client.ListSecurityGroupRules(req)
+	resp, err := callSDK(kt, h.clientSet, "ListSecurityGroupRules", client.ListSecurityGroupRules, req)
 	if err != nil {
 		logs.Errorf("list huawei security group rule failed, err: %v, rid: %s", err, kt.Rid)
 		return nil, err
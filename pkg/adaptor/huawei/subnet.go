@@ -65,7 +65,7 @@ func (h *HuaWei) CreateSubnet(kt *kit.Kit, opt *types.HuaWeiSubnetCreateOption)
 		},
 	}
 
-	resp, err := subnetClient.CreateSubnet(req)
+	resp, err := callSDK(kt, h.clientSet, "CreateSubnet", subnetClient.CreateSubnet, req)
 	if err != nil {
 		logs.Errorf("create huawei subnet failed, err: %v, rid: %s", err, kt.Rid)
 		return nil, err
@@ -112,7 +112,7 @@ func (h *HuaWei) UpdateSubnet(kt *kit.Kit, opt *types.HuaWeiSubnetUpdateOption)
 		},
 	}
 
-	_, err = vpcClient.UpdateSubnet(req)
+	_, err = callSDK(kt, h.clientSet, "UpdateSubnet", vpcClient.UpdateSubnet, req)
 	if err != nil {
 		logs.Errorf("create huawei subnet failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -138,7 +138,7 @@ func (h *HuaWei) DeleteSubnet(kt *kit.Kit, opt *types.HuaWeiSubnetDeleteOption)
 		SubnetId: opt.ResourceID,
 	}
 
-	_, err = vpcClient.DeleteSubnet(req)
+	_, err = callSDK(kt, h.clientSet, "DeleteSubnet", vpcClient.DeleteSubnet, req)
 	if err != nil {
 		logs.Errorf("delete huawei subnet failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -170,7 +170,7 @@ func (h *HuaWei) ListSubnet(kt *kit.Kit, opt *types.HuaWeiSubnetListOption) (*ty
 		req.VpcId = &opt.CloudVpcID
 	}
 
-	resp, err := vpcClient.ListSubnets(req)
+	resp, err := callSDK(kt, h.clientSet, "ListSubnets", vpcClient.ListSubnets, req)
 	if err != nil {
 		if strings.Contains(err.Error(), ErrDataNotFound) {
 			return new(types.HuaWeiSubnetListResult), nil
@@ -215,7 +215,7 @@ func (h *HuaWei) ListSubnetByID(kt *kit.Kit, opt *types.HuaWeiSubnetListByIDOpti
 		VpcId:  &opt.CloudVpcID,
 	}
 	for {
-		resp, err := vpcClient.ListSubnets(req)
+		resp, err := callSDK(kt, h.clientSet, "ListSubnets", vpcClient.ListSubnets, req)
 		if err != nil {
 			logs.Errorf("list huawei subnet failed, err: %v, rid: %s", err, kt.Rid)
 			return nil, fmt.Errorf("list huawei subnet failed, err: %v", err)
@@ -308,7 +308,7 @@ func (h *HuaWei) GetSubnetIPAvailabilities(kt *kit.Kit, opt *types.HuaWeiVpcIPAv
 		NetworkId: opt.SubnetID,
 	}
 
-	resp, err := vpcClient.ShowNetworkIpAvailabilities(req)
+	resp, err := callSDK(kt, h.clientSet, "ShowNetworkIpAvailabilities", vpcClient.ShowNetworkIpAvailabilities, req)
 	if err != nil {
 		logs.Errorf("get huawei vpc ip availabilities failed, err: %v, rid: %s", err, kt.Rid)
 		return nil, err
@@ -351,7 +351,7 @@ func (h *createSubnetPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs
 		req := new(model.ListSubnetsRequest)
 		req.VpcId = h.vpcID
 
-		resp, err := vpcClient.ListSubnets(req)
+		resp, err := callSDK(kt, client.clientSet, "ListSubnets", vpcClient.ListSubnets, req)
 		if err != nil {
 			if strings.Contains(err.Error(), ErrDataNotFound) {
 				return make([]model.Subnet, 0), nil
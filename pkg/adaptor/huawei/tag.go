@@ -0,0 +1,198 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"hcm/pkg/adaptor/types/tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	ecsmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2/model"
+	evsmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+	vpcmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v2/model"
+)
+
+// UpdateResourceTags upsert and delete the tags of the cvm, disk and vpc.
+// reference: https://support.huaweicloud.com/api-ecs/ecs_02_1002.html
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2085.html
+// reference: https://support.huaweicloud.com/api-vpc/vpc_tag_0004.html
+func (h *HuaWei) UpdateResourceTags(kt *kit.Kit, opt *tag.HuaWeiTagUpdateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "update option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	var err error
+	switch opt.ResType {
+	case tag.CvmResType:
+		err = h.updateCvmTags(kt, opt)
+	case tag.DiskResType:
+		err = h.updateDiskTags(kt, opt)
+	case tag.VpcResType:
+		err = h.updateVpcTags(kt, opt)
+	}
+
+	if err != nil {
+		logs.Errorf("update huawei %s tags failed, err: %v, res: %s, rid: %s", opt.ResType, err, opt.CloudResID,
+			kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func (h *HuaWei) updateCvmTags(kt *kit.Kit, opt *tag.HuaWeiTagUpdateOption) error {
+	client, err := h.clientSet.ecsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if len(opt.UpsertTags) != 0 {
+		tags := make([]ecsmodel.ServerTag, 0, len(opt.UpsertTags))
+		for _, one := range opt.UpsertTags {
+			tags = append(tags, ecsmodel.ServerTag{Key: one.Key, Value: one.Value})
+		}
+
+		req := &ecsmodel.BatchCreateServerTagsRequest{
+			ServerId: opt.CloudResID,
+			Body: &ecsmodel.BatchCreateServerTagsRequestBody{
+				Action: ecsmodel.GetBatchCreateServerTagsRequestBodyActionEnum().CREATE,
+				Tags:   tags,
+			},
+		}
+		if _, err = callSDK(kt, h.clientSet, "BatchCreateServerTags", client.BatchCreateServerTags, req); err != nil {
+			return err
+		}
+	}
+
+	if len(opt.DeleteKeys) != 0 {
+		tags := make([]ecsmodel.ServerTag, 0, len(opt.DeleteKeys))
+		for _, key := range opt.DeleteKeys {
+			tags = append(tags, ecsmodel.ServerTag{Key: key})
+		}
+
+		req := &ecsmodel.BatchDeleteServerTagsRequest{
+			ServerId: opt.CloudResID,
+			Body: &ecsmodel.BatchDeleteServerTagsRequestBody{
+				Action: ecsmodel.GetBatchDeleteServerTagsRequestBodyActionEnum().DELETE,
+				Tags:   tags,
+			},
+		}
+		if _, err = callSDK(kt, h.clientSet, "BatchDeleteServerTags", client.BatchDeleteServerTags, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HuaWei) updateDiskTags(kt *kit.Kit, opt *tag.HuaWeiTagUpdateOption) error {
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if len(opt.UpsertTags) != 0 {
+		tags := make([]evsmodel.Tag, 0, len(opt.UpsertTags))
+		for _, one := range opt.UpsertTags {
+			tags = append(tags, evsmodel.Tag{Key: one.Key, Value: one.Value})
+		}
+
+		req := &evsmodel.BatchCreateVolumeTagsRequest{
+			VolumeId: opt.CloudResID,
+			Body: &evsmodel.BatchCreateVolumeTagsRequestBody{
+				Action: evsmodel.GetBatchCreateVolumeTagsRequestBodyActionEnum().CREATE,
+				Tags:   tags,
+			},
+		}
+		if _, err = callSDK(kt, h.clientSet, "BatchCreateVolumeTags", client.BatchCreateVolumeTags, req); err != nil {
+			return err
+		}
+	}
+
+	if len(opt.DeleteKeys) != 0 {
+		tags := make([]evsmodel.DeleteTagsOption, 0, len(opt.DeleteKeys))
+		for _, key := range opt.DeleteKeys {
+			tags = append(tags, evsmodel.DeleteTagsOption{Key: key})
+		}
+
+		req := &evsmodel.BatchDeleteVolumeTagsRequest{
+			VolumeId: opt.CloudResID,
+			Body: &evsmodel.BatchDeleteVolumeTagsRequestBody{
+				Action: evsmodel.GetBatchDeleteVolumeTagsRequestBodyActionEnum().DELETE,
+				Tags:   tags,
+			},
+		}
+		if _, err = callSDK(kt, h.clientSet, "BatchDeleteVolumeTags", client.BatchDeleteVolumeTags, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HuaWei) updateVpcTags(kt *kit.Kit, opt *tag.HuaWeiTagUpdateOption) error {
+	client, err := h.clientSet.vpcClientV2(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if len(opt.UpsertTags) != 0 {
+		tags := make([]vpcmodel.ResourceTag, 0, len(opt.UpsertTags))
+		for _, one := range opt.UpsertTags {
+			tags = append(tags, vpcmodel.ResourceTag{Key: one.Key, Value: one.Value})
+		}
+
+		req := &vpcmodel.BatchCreateVpcTagsRequest{
+			VpcId: opt.CloudResID,
+			Body: &vpcmodel.BatchCreateVpcTagsRequestBody{
+				Action: vpcmodel.GetBatchCreateVpcTagsRequestBodyActionEnum().CREATE,
+				Tags:   tags,
+			},
+		}
+		if _, err = callSDK(kt, h.clientSet, "BatchCreateVpcTags", client.BatchCreateVpcTags, req); err != nil {
+			return err
+		}
+	}
+
+	if len(opt.DeleteKeys) != 0 {
+		tags := make([]vpcmodel.ResourceTag, 0, len(opt.DeleteKeys))
+		for _, key := range opt.DeleteKeys {
+			tags = append(tags, vpcmodel.ResourceTag{Key: key})
+		}
+
+		req := &vpcmodel.BatchDeleteVpcTagsRequest{
+			VpcId: opt.CloudResID,
+			Body: &vpcmodel.BatchDeleteVpcTagsRequestBody{
+				Action: vpcmodel.GetBatchDeleteVpcTagsRequestBodyActionEnum().DELETE,
+				Tags:   tags,
+			},
+		}
+		if _, err = callSDK(kt, h.clientSet, "BatchDeleteVpcTags", client.BatchDeleteVpcTags, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// callSDK calls the api of huawei sdk in the span of the call. huawei sdk does not support custom transport, so the
+// calls can not be traced in the throttle transport like the other vendors, the sdk call sites are wrapped instead.
+func callSDK[Req, Resp any](kt *kit.Kit, c *clientSet, api string, call func(Req) (Resp, error), req Req) (
+	resp Resp, err error) {
+
+	_, span := tracing.Tracer().Start(kt.Ctx, string(enumor.HuaWei)+" "+api,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("cloud.vendor", string(enumor.HuaWei)),
+			attribute.String("cloud.account", c.account), attribute.String("cloud.api", api)))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	return call(req)
+}
@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/adaptor/poller"
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/api/core/cloud"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
@@ -61,7 +62,7 @@ func (h *HuaWei) CreateVpc(kt *kit.Kit, opt *types.HuaWeiVpcCreateOption) error
 		},
 	}
 
-	resp, err := vpcClient.CreateVpc(req)
+	resp, err := callSDK(kt, h.clientSet, "CreateVpc", vpcClient.CreateVpc, req)
 	if err != nil {
 		logs.Errorf("create huawei vpc failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -101,7 +102,7 @@ func (h *HuaWei) UpdateVpc(kt *kit.Kit, opt *types.HuaWeiVpcUpdateOption) error
 		},
 	}
 
-	_, err = vpcClient.UpdateVpc(req)
+	_, err = callSDK(kt, h.clientSet, "UpdateVpc", vpcClient.UpdateVpc, req)
 	if err != nil {
 		logs.Errorf("update huawei vpc failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -126,7 +127,7 @@ func (h *HuaWei) DeleteVpc(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) erro
 		VpcId: opt.ResourceID,
 	}
 
-	_, err = vpcClient.DeleteVpc(req)
+	_, err = callSDK(kt, h.clientSet, "DeleteVpc", vpcClient.DeleteVpc, req)
 	if err != nil {
 		logs.Errorf("delete huawei vpc failed, err: %v, rid: %s", err, kt.Rid)
 		return err
@@ -162,7 +163,7 @@ func (h *HuaWei) ListVpc(kt *kit.Kit, opt *types.HuaWeiVpcListOption) (*types.Hu
 		req.Name = &opt.Names
 	}
 
-	resp, err := vpcClient.ListVpcs(req)
+	resp, err := callSDK(kt, h.clientSet, "ListVpcs", vpcClient.ListVpcs, req)
 	if err != nil {
 		if strings.Contains(err.Error(), ErrDataNotFound) {
 			return new(types.HuaWeiVpcListResult), nil
@@ -191,6 +192,7 @@ func convertVpc(data *model.Vpc, region string) *types.HuaWeiVpc {
 		Name:    data.Name,
 		Region:  region,
 		Memo:    converter.ValToPtr(data.Description),
+		Tags:    make([]tag.Tag, 0, len(data.Tags)),
 		Extension: &cloud.HuaWeiVpcExtension{
 			Cidr:                nil,
 			Status:              data.Status,
@@ -212,6 +214,10 @@ func convertVpc(data *model.Vpc, region string) *types.HuaWeiVpc {
 		})
 	}
 
+	for _, one := range data.Tags {
+		v.Tags = append(v.Tags, tag.Tag{Key: one.Key, Value: one.Value})
+	}
+
 	return v
 }
 
@@ -252,7 +258,7 @@ func (h *createVpcPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*
 			return nil, fmt.Errorf("new vpc client failed, err: %v", err)
 		}
 
-		resp, err := vpcClient.ListVpcs(req)
+		resp, err := callSDK(kt, client.clientSet, "ListVpcs", vpcClient.ListVpcs, req)
 		if err != nil {
 			if strings.Contains(err.Error(), ErrDataNotFound) {
 				return make([]model.Vpc, 0), nil
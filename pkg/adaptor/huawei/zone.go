@@ -48,7 +48,7 @@ func (h *HuaWei) ListZone(kt *kit.Kit, opt *typeszone.HuaWeiZoneListOption) ([]m
 	}
 
 	req := &model.ListAvailableZonesRequest{}
-	resp, err := client.ListAvailableZones(req)
+	resp, err := callSDK(kt, h.clientSet, "ListAvailableZones", client.ListAvailableZones, req)
 	if err != nil {
 		logs.Errorf("list huawei zone failed, err: %v, rid: %s", err, kt.Rid)
 	}
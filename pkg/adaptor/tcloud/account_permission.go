@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	sdkerr "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+)
+
+// defaultPermissionProbeRegion is the region used to probe permission when region is not specified.
+const defaultPermissionProbeRegion = "ap-guangzhou"
+
+// ListMissingPermission list the cam permissions which are required by resource sync but not granted.
+// reference: https://cloud.tencent.com/document/api/213/15694
+func (t *TCloud) ListMissingPermission(kt *kit.Kit, opt *typeaccount.ListMissingPermissionOption) (
+	[]typeaccount.MissingPermission, error) {
+
+	region := defaultPermissionProbeRegion
+	if opt != nil && len(opt.Region) != 0 {
+		region = opt.Region
+	}
+
+	vpcCli, err := t.clientSet.vpcClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud vpc client failed, err: %v", err)
+	}
+
+	cvmCli, err := t.clientSet.cvmClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud cvm client failed, err: %v", err)
+	}
+
+	cbsCli, err := t.clientSet.cbsClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud cbs client failed, err: %v", err)
+	}
+
+	probes := []typeaccount.PermissionProbe{
+		{ResType: enumor.VpcCloudResType, Action: "vpc:DescribeVpcs", Probe: func() error {
+			req := vpc.NewDescribeVpcsRequest()
+			req.Limit = common.StringPtr("1")
+			_, err := vpcCli.DescribeVpcsWithContext(kt.Ctx, req)
+			return err
+		}},
+		{ResType: enumor.SubnetCloudResType, Action: "vpc:DescribeSubnets", Probe: func() error {
+			req := vpc.NewDescribeSubnetsRequest()
+			req.Limit = common.StringPtr("1")
+			_, err := vpcCli.DescribeSubnetsWithContext(kt.Ctx, req)
+			return err
+		}},
+		{ResType: enumor.SecurityGroupCloudResType, Action: "vpc:DescribeSecurityGroups", Probe: func() error {
+			req := vpc.NewDescribeSecurityGroupsRequest()
+			req.Limit = common.StringPtr("1")
+			_, err := vpcCli.DescribeSecurityGroupsWithContext(kt.Ctx, req)
+			return err
+		}},
+		{ResType: enumor.RouteTableCloudResType, Action: "vpc:DescribeRouteTables", Probe: func() error {
+			req := vpc.NewDescribeRouteTablesRequest()
+			req.Limit = common.StringPtr("1")
+			_, err := vpcCli.DescribeRouteTablesWithContext(kt.Ctx, req)
+			return err
+		}},
+		{ResType: enumor.EipCloudResType, Action: "vpc:DescribeAddresses", Probe: func() error {
+			req := vpc.NewDescribeAddressesRequest()
+			req.Limit = common.Int64Ptr(1)
+			_, err := vpcCli.DescribeAddressesWithContext(kt.Ctx, req)
+			return err
+		}},
+		{ResType: enumor.CvmCloudResType, Action: "cvm:DescribeInstances", Probe: func() error {
+			req := cvm.NewDescribeInstancesRequest()
+			req.Limit = common.Int64Ptr(1)
+			_, err := cvmCli.DescribeInstancesWithContext(kt.Ctx, req)
+			return err
+		}},
+		{ResType: enumor.DiskCloudResType, Action: "cvm:DescribeDisks", Probe: func() error {
+			req := cbs.NewDescribeDisksRequest()
+			req.Limit = common.Uint64Ptr(1)
+			_, err := cbsCli.DescribeDisksWithContext(kt.Ctx, req)
+			return err
+		}},
+	}
+
+	missing, err := typeaccount.ProbeMissingPermission(probes, isUnauthorizedErr)
+	if err != nil {
+		logs.Errorf("probe tcloud permission failed, err: %v, region: %s, rid: %s", err, region, kt.Rid)
+		return nil, err
+	}
+
+	return missing, nil
+}
+
+// isUnauthorizedErr UnauthorizedOperation、AuthFailure.UnauthorizedOperation 均表示子账号未被授权
+func isUnauthorizedErr(err error) bool {
+	var sdkErr *sdkerr.TencentCloudSDKError
+	if !errors.As(err, &sdkErr) {
+		return false
+	}
+
+	return strings.Contains(sdkErr.GetCode(), "UnauthorizedOperation")
+}
@@ -20,7 +20,11 @@
 package tcloud
 
 import (
+	"net/http"
+
+	"hcm/pkg/adaptor/throttle"
 	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
 
 	billing "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/billing/v20180709"
 	cam "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cam/v20190116"
@@ -38,12 +42,20 @@ const (
 type clientSet struct {
 	credential *common.Credential
 	profile    *profile.ClientProfile
+	// transport limits the calls of the account, it's shared by all the clients of the account.
+	transport http.RoundTripper
 }
 
 func newClientSet(s *types.BaseSecret, profile *profile.ClientProfile) *clientSet {
+	account := s.CloudAccountID
+	if len(account) == 0 {
+		account = throttle.SecretAccount(s.CloudSecretID)
+	}
+
 	return &clientSet{
 		credential: common.NewCredential(s.CloudSecretID, s.CloudSecretKey),
 		profile:    profile,
+		transport:  throttle.NewTransport(enumor.TCloud, account, nil),
 	}
 }
 
@@ -52,6 +64,7 @@ func (c *clientSet) camServiceClient(region string) (*cam.Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	client.WithHttpTransport(c.transport)
 
 	return client, nil
 }
@@ -61,6 +74,7 @@ func (c *clientSet) cvmClient(region string) (*cvm.Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	client.WithHttpTransport(c.transport)
 
 	return client, nil
 }
@@ -70,6 +84,7 @@ func (c *clientSet) cbsClient(region string) (*cbs.Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	client.WithHttpTransport(c.transport)
 
 	return client, nil
 }
@@ -79,15 +94,25 @@ func (c *clientSet) vpcClient(region string) (*vpc.Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	client.WithHttpTransport(c.transport)
 
 	return client, nil
 }
 
+// commonClient is used to call the apis of the services whose sdk is not imported, e.g. tag.
+func (c *clientSet) commonClient(region string) *common.Client {
+	client := common.NewCommonClient(c.credential, region, c.profile)
+	client.WithHttpTransport(c.transport)
+
+	return client
+}
+
 func (c *clientSet) billClient() (*billing.Client, error) {
 	client, err := billing.NewClient(c.credential, "", c.profile)
 	if err != nil {
 		return nil, err
 	}
+	client.WithHttpTransport(c.transport)
 
 	return client, nil
 }
@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/adaptor/poller"
 	"hcm/pkg/adaptor/types/core"
 	"hcm/pkg/adaptor/types/eip"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -93,6 +94,7 @@ func (t *TCloud) ListEip(kt *kit.Kit, opt *eip.TCloudEipListOption) (*eip.TCloud
 			Bandwidth:               address.Bandwidth,
 			InternetChargeType:      address.InternetChargeType,
 			InternetServiceProvider: address.InternetServiceProvider,
+			Tags:                    tag.FromTCloudVpc(address.TagSet),
 		}
 	}
 
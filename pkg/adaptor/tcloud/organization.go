@@ -0,0 +1,112 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+)
+
+const (
+	organizationService = "organization"
+	organizationVersion = "2021-03-31"
+	// organizationMemberLimit is the max limit of DescribeOrganizationMembers.
+	organizationMemberLimit = 50
+)
+
+// organizationMemberResp is the response of DescribeOrganizationMembers. the sdk of organization is not imported,
+// so the api is called by common request.
+type organizationMemberResp struct {
+	Response struct {
+		Items []struct {
+			MemberUin  int64  `json:"MemberUin"`
+			Name       string `json:"Name"`
+			MemberType string `json:"MemberType"`
+			NodeId     int64  `json:"NodeId"`
+		} `json:"Items"`
+		Total int64 `json:"Total"`
+	} `json:"Response"`
+}
+
+// ListOrganizationMember list the member accounts of the group account organization, the credential must be of
+// the organization admin account.
+// reference: https://cloud.tencent.com/document/api/850/67049
+func (t *TCloud) ListOrganizationMember(kt *kit.Kit, opt *typeaccount.ListOrganizationMemberOption) (
+	[]typeaccount.OrganizationMember, error) {
+
+	if opt == nil {
+		opt = new(typeaccount.ListOrganizationMemberOption)
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	client := common.NewCommonClient(t.clientSet.credential, "", t.clientSet.profile)
+	client.WithHttpTransport(t.clientSet.transport)
+
+	members := make([]typeaccount.OrganizationMember, 0)
+	for offset := 0; ; offset += organizationMemberLimit {
+		req := tchttp.NewCommonRequest(organizationService, organizationVersion, "DescribeOrganizationMembers")
+		params := map[string]interface{}{"Offset": offset, "Limit": organizationMemberLimit}
+		if err := req.SetActionParameters(params); err != nil {
+			return nil, err
+		}
+
+		resp := tchttp.NewCommonResponse()
+		if err := client.Send(req, resp); err != nil {
+			logs.Errorf("describe tcloud organization members failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		result := new(organizationMemberResp)
+		if err := json.Unmarshal(resp.GetBody(), result); err != nil {
+			return nil, fmt.Errorf("unmarshal organization members failed, err: %v", err)
+		}
+
+		for _, one := range result.Response.Items {
+			nodeID := strconv.FormatInt(one.NodeId, 10)
+			if len(opt.ParentID) != 0 && opt.ParentID != nodeID {
+				continue
+			}
+
+			members = append(members, typeaccount.OrganizationMember{
+				CloudID:  strconv.FormatInt(one.MemberUin, 10),
+				Name:     one.Name,
+				Status:   one.MemberType,
+				ParentID: nodeID,
+			})
+		}
+
+		if len(result.Response.Items) < organizationMemberLimit {
+			break
+		}
+	}
+
+	return members, nil
+}
@@ -25,6 +25,7 @@ import (
 
 	"hcm/pkg/adaptor/types/core"
 	routetable "hcm/pkg/adaptor/types/route-table"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/converter"
@@ -113,6 +114,7 @@ func convertRouteTable(data *vpc.RouteTable, region string) *routetable.TCloudRo
 		Name:       converter.PtrToVal(data.RouteTableName),
 		CloudVpcID: converter.PtrToVal(data.VpcId),
 		Region:     region,
+		Tags:       tag.FromTCloudVpc(data.TagSet),
 		Extension: &routetable.TCloudRouteTableExtension{
 			Main: converter.PtrToVal(data.Main),
 		},
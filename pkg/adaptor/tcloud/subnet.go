@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/adaptor/poller"
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/converter"
@@ -189,6 +190,7 @@ func convertSubnet(data *vpc.Subnet, region string) *types.TCloudSubnet {
 		CloudVpcID: converter.PtrToVal(data.VpcId),
 		CloudID:    converter.PtrToVal(data.SubnetId),
 		Name:       converter.PtrToVal(data.SubnetName),
+		Tags:       tag.FromTCloudVpc(data.TagSet),
 		Extension: &types.TCloudSubnetExtension{
 			IsDefault:               converter.PtrToVal(data.IsDefault),
 			Region:                  region,
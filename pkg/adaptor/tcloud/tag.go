@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/adaptor/types/tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	cam "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cam/v20190116"
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+)
+
+const (
+	tagService = "tag"
+	tagVersion = "2018-08-13"
+)
+
+// UpdateResourceTags upsert and delete the tags of the resource. the tag sdk is not imported, so the api is called
+// by the common client.
+// reference: https://cloud.tencent.com/document/api/651/35321
+func (t *TCloud) UpdateResourceTags(kt *kit.Kit, opt *tag.TCloudTagUpdateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "update option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ownerUin, err := t.getOwnerUin(kt)
+	if err != nil {
+		return err
+	}
+
+	replaceTags := make([]map[string]interface{}, 0, len(opt.UpsertTags))
+	for _, one := range opt.UpsertTags {
+		replaceTags = append(replaceTags, map[string]interface{}{"TagKey": one.Key, "TagValue": one.Value})
+	}
+
+	deleteTags := make([]map[string]interface{}, 0, len(opt.DeleteKeys))
+	for _, key := range opt.DeleteKeys {
+		deleteTags = append(deleteTags, map[string]interface{}{"TagKey": key})
+	}
+
+	// resource is described in six-segment format, e.g. qcs::cvm:ap-guangzhou:uin/1234567:instance/ins-abcdefg
+	params := map[string]interface{}{
+		"Resource": fmt.Sprintf("qcs::%s:%s:uin/%s:%s/%s", opt.ServiceType, opt.Region, ownerUin,
+			opt.ResourcePrefix, opt.CloudResID),
+	}
+	if len(replaceTags) != 0 {
+		params["ReplaceTags"] = replaceTags
+	}
+	if len(deleteTags) != 0 {
+		params["DeleteTags"] = deleteTags
+	}
+
+	req := tchttp.NewCommonRequest(tagService, tagVersion, "ModifyResourceTags")
+	req.SetContext(kt.Ctx)
+	if err = req.SetActionParameters(params); err != nil {
+		return err
+	}
+
+	if err = t.clientSet.commonClient(opt.Region).Send(req, tchttp.NewCommonResponse()); err != nil {
+		logs.Errorf("modify tcloud resource tags failed, err: %v, res: %s, rid: %s", err, opt.CloudResID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// getOwnerUin get the main account uin of the secret.
+func (t *TCloud) getOwnerUin(kt *kit.Kit) (string, error) {
+	camClient, err := t.clientSet.camServiceClient("")
+	if err != nil {
+		return "", fmt.Errorf("new cam client failed, err: %v", err)
+	}
+
+	resp, err := camClient.GetUserAppIdWithContext(kt.Ctx, cam.NewGetUserAppIdRequest())
+	if err != nil {
+		return "", fmt.Errorf("get user app id failed, err: %v", err)
+	}
+
+	if resp.Response.OwnerUin == nil {
+		return "", errors.New("user owner uin is empty")
+	}
+
+	return *resp.Response.OwnerUin, nil
+}
@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/adaptor/poller"
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/api/core/cloud"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
@@ -151,6 +152,7 @@ func convertVpc(data *vpc.Vpc, region string) *types.TCloudVpc {
 		CloudID: converter.PtrToVal(data.VpcId),
 		Name:    converter.PtrToVal(data.VpcName),
 		Region:  region,
+		Tags:    tag.FromTCloudVpc(data.TagSet),
 		Extension: &cloud.TCloudVpcExtension{
 			Cidr:            nil,
 			IsDefault:       converter.PtrToVal(data.IsDefault),
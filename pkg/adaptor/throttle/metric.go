@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package throttle
+
+import (
+	"sync"
+
+	"hcm/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const subSystem = "cloud_api"
+
+var (
+	metricOnce     sync.Once
+	throttleMetric *metric
+)
+
+// initMetric register the metrics lazily, because metrics can only be registered after the metric service is started.
+func initMetric() {
+	metricOnce.Do(func() {
+		m := new(metric)
+
+		m.requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subSystem,
+			Name:      "total_request_count",
+			Help:      "the total count of the calls to cloud vendor api",
+		}, []string{"vendor", "family"})
+		metrics.Register().MustRegister(m.requests)
+
+		m.throttled = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subSystem,
+			Name:      "total_throttled_count",
+			Help:      "the total count of the calls throttled by cloud vendor",
+		}, []string{"vendor", "family"})
+		metrics.Register().MustRegister(m.throttled)
+
+		m.waitSec = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subSystem,
+			Name:      "wait_seconds",
+			Help:      "the seconds that calls to cloud vendor api wait for the rate limit",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 2, 5, 10, 30, 60},
+		}, []string{"vendor", "family"})
+		metrics.Register().MustRegister(m.waitSec)
+
+		m.limit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subSystem,
+			Name:      "limit_qps",
+			Help:      "the current calls per second limit of an api family of a cloud account",
+		}, []string{"vendor", "account", "family"})
+		metrics.Register().MustRegister(m.limit)
+
+		throttleMetric = m
+	})
+}
+
+type metric struct {
+	// requests record the total count of the calls to cloud vendor api.
+	requests *prometheus.CounterVec
+	// throttled record the total count of the calls throttled by cloud vendor.
+	throttled *prometheus.CounterVec
+	// waitSec record the seconds that calls wait for the rate limit.
+	waitSec *prometheus.HistogramVec
+	// limit record the current rate limit which is adjusted adaptively.
+	limit *prometheus.GaugeVec
+}
@@ -0,0 +1,344 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package throttle limits the outbound calls of the cloud vendor apis, the calls of the same cloud account and the same
+// api family share a token bucket, and the rate of the bucket backs off adaptively when the vendor throttles the calls.
+package throttle
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"sync"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultQPS is the default calls per second of an api family of a cloud account.
+	DefaultQPS = 20
+	// DefaultMaxBackoff is the default max duration that calls are paused after throttled by vendor.
+	DefaultMaxBackoff = time.Minute
+
+	// minBackoff is the duration that calls are paused after first throttled by vendor.
+	minBackoff = time.Second
+	// minRateRatio is the min ratio of the configured rate that the rate can be decreased to after throttled.
+	minRateRatio = 1.0 / 16
+	// recoverInterval is the interval to increase the decreased rate when calls are not throttled.
+	recoverInterval = 10 * time.Second
+	// recoverRatio is the ratio of the configured rate that is increased every recover interval.
+	recoverRatio = 1.0 / 8
+	// idleTimeout is the idle time after which the bucket is removed.
+	idleTimeout = 10 * time.Minute
+)
+
+// Key is the key of a token bucket.
+type Key struct {
+	Vendor enumor.Vendor
+	// Account is the cloud account of the calls, it's the main account id, subscription id or project id.
+	Account string
+	// Family is the api family of the calls, such as cvm, vpc of tcloud, ec2 of aws, Microsoft.Compute of azure.
+	Family string
+}
+
+// SecretAccount returns the account of the calls whose cloud account id is unknown, e.g. the calls to validate
+// the secret of an account to be created. the account is derived from the hash of the secret id, so that the secret
+// id is not exposed in the metric labels and the span attributes.
+func SecretAccount(secretID string) string {
+	sum := sha256.Sum256([]byte(secretID))
+	return "secret-" + hex.EncodeToString(sum[:8])
+}
+
+// Option is the option of the throttler.
+type Option struct {
+	Enable bool
+	// QPS is the default calls per second of an api family of a cloud account.
+	QPS float64
+	// Burst is the default max burst calls of an api family of a cloud account.
+	Burst int
+	// MaxBackoff is the max duration that calls are paused after throttled by vendor.
+	MaxBackoff time.Duration
+	// Rules overwrites the default limit of vendors or api families.
+	Rules []Rule
+}
+
+// Rule overwrites the default limit of a vendor or an api family.
+type Rule struct {
+	Vendor enumor.Vendor
+	// Family is the api family, empty means all the api families of the vendor.
+	Family string
+	QPS    float64
+	Burst  int
+}
+
+// DefaultOption returns the default option of the throttler.
+func DefaultOption() *Option {
+	return &Option{Enable: true, QPS: DefaultQPS, Burst: DefaultQPS, MaxBackoff: DefaultMaxBackoff}
+}
+
+// limitOf returns the calls per second and burst of the key.
+func (o *Option) limitOf(key Key) (float64, int) {
+	qps, burst := o.QPS, o.Burst
+	matched := false
+	for _, rule := range o.Rules {
+		if rule.Vendor != key.Vendor {
+			continue
+		}
+
+		// rule of the api family takes precedence over the rule of the vendor.
+		if rule.Family == key.Family || (len(rule.Family) == 0 && !matched) {
+			qps, burst = rule.QPS, rule.Burst
+			matched = len(rule.Family) != 0
+		}
+	}
+
+	if qps <= 0 {
+		qps = DefaultQPS
+	}
+
+	if burst <= 0 {
+		burst = int(math.Ceil(qps))
+	}
+
+	return qps, burst
+}
+
+// Throttler limits the outbound calls of the cloud vendor apis.
+type Throttler struct {
+	opt     *Option
+	lock    sync.Mutex
+	buckets map[Key]*bucket
+	// lastSweep is the last time that idle buckets are removed.
+	lastSweep time.Time
+}
+
+// New create a throttler, default option is used if opt is nil.
+func New(opt *Option) *Throttler {
+	if opt == nil {
+		opt = DefaultOption()
+	}
+
+	if opt.MaxBackoff <= 0 {
+		opt.MaxBackoff = DefaultMaxBackoff
+	}
+
+	return &Throttler{opt: opt, buckets: make(map[Key]*bucket), lastSweep: time.Now()}
+}
+
+// Wait blocks until the call of the key is allowed, or the context is done.
+func (t *Throttler) Wait(ctx context.Context, key Key) error {
+	if !t.opt.Enable {
+		return nil
+	}
+
+	initMetric()
+	start := time.Now()
+	b := t.getBucket(key, start)
+
+	if pause := b.pauseLeft(start); pause > 0 {
+		timer := time.NewTimer(pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if err := b.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	throttleMetric.waitSec.WithLabelValues(string(key.Vendor), key.Family).Observe(time.Since(start).Seconds())
+	throttleMetric.requests.WithLabelValues(string(key.Vendor), key.Family).Inc()
+	return nil
+}
+
+// Observe adjusts the rate of the key by the result of the call, the rate is decreased and calls are paused when the
+// call is throttled by vendor, and the decreased rate is recovered gradually when calls are not throttled.
+func (t *Throttler) Observe(key Key, throttled bool, retryAfter time.Duration) {
+	if !t.opt.Enable {
+		return
+	}
+
+	initMetric()
+	now := time.Now()
+	b := t.getBucket(key, now)
+
+	var limit rate.Limit
+	if throttled {
+		throttleMetric.throttled.WithLabelValues(string(key.Vendor), key.Family).Inc()
+		limit = b.backoff(now, retryAfter, t.opt.MaxBackoff)
+	} else {
+		limit = b.recover(now)
+	}
+
+	throttleMetric.limit.WithLabelValues(string(key.Vendor), key.Account, key.Family).Set(float64(limit))
+}
+
+// getBucket get the bucket of the key, create it if not exists.
+func (t *Throttler) getBucket(key Key, now time.Time) *bucket {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.sweep(now)
+
+	b, exists := t.buckets[key]
+	if !exists {
+		qps, burst := t.opt.limitOf(key)
+		b = &bucket{
+			limiter:   rate.NewLimiter(rate.Limit(qps), burst),
+			baseLimit: rate.Limit(qps),
+			baseBurst: burst,
+		}
+		t.buckets[key] = b
+	}
+
+	b.lastSeen = now
+	return b
+}
+
+// sweep remove the idle buckets.
+func (t *Throttler) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < idleTimeout {
+		return
+	}
+
+	t.lastSweep = now
+	for key, b := range t.buckets {
+		if now.Sub(b.lastSeen) > idleTimeout {
+			delete(t.buckets, key)
+			throttleMetric.limit.DeleteLabelValues(string(key.Vendor), key.Account, key.Family)
+		}
+	}
+}
+
+// bucket is the token bucket of an api family of a cloud account.
+type bucket struct {
+	limiter   *rate.Limiter
+	baseLimit rate.Limit
+	baseBurst int
+	lastSeen  time.Time
+
+	lock sync.Mutex
+	// backoffDur is the duration that calls are paused after last throttled, it's doubled when throttled continuously.
+	backoffDur time.Duration
+	// pauseUntil is the time until which calls are paused.
+	pauseUntil time.Time
+	// lastAdjust is the last time that the rate is adjusted.
+	lastAdjust time.Time
+}
+
+func (b *bucket) pauseLeft(now time.Time) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.pauseUntil.Sub(now)
+}
+
+// backoff halves the rate and pauses the calls, returns the rate after adjusted.
+func (b *bucket) backoff(now time.Time, retryAfter, maxBackoff time.Duration) rate.Limit {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// calls sent before paused may be throttled together, they are regarded as one throttling.
+	if now.Before(b.pauseUntil) {
+		return b.limiter.Limit()
+	}
+
+	b.backoffDur *= 2
+	if b.backoffDur < minBackoff {
+		b.backoffDur = minBackoff
+	}
+	if b.backoffDur > maxBackoff {
+		b.backoffDur = maxBackoff
+	}
+
+	pause := b.backoffDur
+	if retryAfter > pause {
+		pause = retryAfter
+	}
+	if pause > maxBackoff {
+		pause = maxBackoff
+	}
+	b.pauseUntil = now.Add(pause)
+
+	limit := b.limiter.Limit() / 2
+	if minLimit := b.baseLimit * minRateRatio; limit < minLimit {
+		limit = minLimit
+	}
+	b.setLimit(now, limit)
+	return limit
+}
+
+// recover increases the decreased rate gradually, returns the rate after adjusted.
+func (b *bucket) recover(now time.Time) rate.Limit {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	limit := b.limiter.Limit()
+	if limit >= b.baseLimit || now.Sub(b.lastAdjust) < recoverInterval {
+		return limit
+	}
+
+	limit += b.baseLimit * recoverRatio
+	if limit >= b.baseLimit {
+		limit = b.baseLimit
+		b.backoffDur = 0
+	}
+	b.setLimit(now, limit)
+	return limit
+}
+
+// setLimit set the rate, and the burst is adjusted in proportion to the rate.
+func (b *bucket) setLimit(now time.Time, limit rate.Limit) {
+	burst := int(math.Ceil(float64(b.baseBurst) * float64(limit/b.baseLimit)))
+	if burst < 1 {
+		burst = 1
+	}
+
+	b.limiter.SetLimitAt(now, limit)
+	b.limiter.SetBurstAt(now, burst)
+	b.lastAdjust = now
+}
+
+var (
+	globalLock sync.RWMutex
+	global     = New(nil)
+)
+
+// Init replace the global throttler with the option, it should be called before the adaptor clients are used.
+func Init(opt *Option) {
+	globalLock.Lock()
+	defer globalLock.Unlock()
+
+	global = New(opt)
+}
+
+// Global returns the global throttler shared by all the adaptor clients.
+func Global() *Throttler {
+	globalLock.RLock()
+	defer globalLock.RUnlock()
+
+	return global
+}
@@ -0,0 +1,223 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package throttle
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+
+	"golang.org/x/time/rate"
+)
+
+func TestLimitOf(t *testing.T) {
+	opt := &Option{
+		QPS: 20,
+		Rules: []Rule{
+			{Vendor: enumor.Azure, Family: "Microsoft.Compute", QPS: 2, Burst: 4},
+			{Vendor: enumor.Azure, QPS: 5},
+		},
+	}
+
+	cases := []struct {
+		key   Key
+		qps   float64
+		burst int
+	}{
+		{key: Key{Vendor: enumor.Azure, Family: "Microsoft.Compute"}, qps: 2, burst: 4},
+		{key: Key{Vendor: enumor.Azure, Family: "Microsoft.Network"}, qps: 5, burst: 5},
+		{key: Key{Vendor: enumor.TCloud, Family: "cvm"}, qps: 20, burst: 20},
+	}
+
+	for _, c := range cases {
+		qps, burst := opt.limitOf(c.key)
+		if qps != c.qps || burst != c.burst {
+			t.Errorf("limit of %+v should be %v/%d, got %v/%d", c.key, c.qps, c.burst, qps, burst)
+		}
+	}
+}
+
+func TestKeyOf(t *testing.T) {
+	cases := []struct {
+		vendor enumor.Vendor
+		url    string
+		family string
+	}{
+		{vendor: enumor.TCloud, url: "https://cvm.tencentcloudapi.com/", family: "cvm"},
+		{vendor: enumor.Aws, url: "https://ec2.us-east-1.amazonaws.com/", family: "ec2"},
+		{vendor: enumor.Gcp, url: "https://compute.googleapis.com/compute/v1/projects/p/zones", family: "compute"},
+		{
+			vendor: enumor.Azure,
+			url:    "https://management.azure.com/subscriptions/s/providers/Microsoft.Compute/virtualMachines",
+			family: "Microsoft.Compute",
+		},
+	}
+
+	for _, c := range cases {
+		req, _ := http.NewRequest(http.MethodGet, c.url, nil)
+		key := KeyOf(c.vendor, "account", req)
+		if key.Family != c.family || key.Account != "account" || key.Vendor != c.vendor {
+			t.Errorf("key of %s should be of family %s, got %+v", c.url, c.family, key)
+		}
+	}
+}
+
+func TestIsThrottled(t *testing.T) {
+	body := `{"Response":{"Error":{"Code":"RequestLimitExceeded.UinLimitExceeded","Message":"limit"}}}`
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+	throttled, _ := IsThrottled(enumor.TCloud, resp)
+	if !throttled {
+		t.Error("tcloud request limit exceeded response should be throttled")
+	}
+
+	restored, err := io.ReadAll(resp.Body)
+	if err != nil || string(restored) != body {
+		t.Errorf("response body should be restored, got %s, err: %v", restored, err)
+	}
+
+	resp = &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"3"}}}
+	throttled, retryAfter := IsThrottled(enumor.Azure, resp)
+	if !throttled || retryAfter != 3*time.Second {
+		t.Errorf("429 response should be throttled with retry after 3s, got %v, %v", throttled, retryAfter)
+	}
+
+	resp = &http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{},
+		Body: io.NopCloser(strings.NewReader("<Code>InvalidParameterValue</Code>"))}
+	if throttled, _ = IsThrottled(enumor.Aws, resp); throttled {
+		t.Error("invalid parameter response should not be throttled")
+	}
+
+	body = `{"Response":{"InstanceSet":[{"InstanceName":"RequestLimitExceeded"}],"RequestId":"xxx"}}`
+	resp = &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+	if throttled, _ = IsThrottled(enumor.TCloud, resp); throttled {
+		t.Error("tcloud normal response containing the throttling code in resource name should not be throttled")
+	}
+
+	resp = &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{},
+		Body: io.NopCloser(strings.NewReader(
+			"<Response><Errors><Error><Code>RequestLimitExceeded</Code></Error></Errors></Response>"))}
+	if throttled, _ = IsThrottled(enumor.Aws, resp); !throttled {
+		t.Error("aws request limit exceeded response should be throttled")
+	}
+
+	body = `{"error":{"code":403,"errors":[{"reason":"userRateLimitExceeded"}]}}`
+	resp = &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{},
+		Body: io.NopCloser(strings.NewReader(body))}
+	if throttled, _ = IsThrottled(enumor.Gcp, resp); !throttled {
+		t.Error("gcp user rate limit exceeded response should be throttled")
+	}
+
+	body = `{"error":{"code":403,"message":"rateLimitExceeded","errors":[{"reason":"forbidden"}]}}`
+	resp = &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{},
+		Body: io.NopCloser(strings.NewReader(body))}
+	if throttled, _ = IsThrottled(enumor.Gcp, resp); throttled {
+		t.Error("gcp forbidden response should not be throttled")
+	}
+}
+
+func TestBackoffAndRecover(t *testing.T) {
+	th := New(&Option{Enable: true, QPS: 16, Burst: 16, MaxBackoff: time.Minute})
+	key := Key{Vendor: enumor.TCloud, Account: "100", Family: "cvm"}
+
+	th.Observe(key, true, 0)
+	b := th.getBucket(key, time.Now())
+	if b.limiter.Limit() != 8 || b.limiter.Burst() != 8 {
+		t.Fatalf("rate should be halved after throttled, got %v/%d", b.limiter.Limit(), b.limiter.Burst())
+	}
+	if pause := b.pauseLeft(time.Now()); pause <= 0 || pause > minBackoff {
+		t.Fatalf("calls should be paused for %v, got %v", minBackoff, pause)
+	}
+
+	// throttling of the calls sent before paused is regarded as the same throttling.
+	th.Observe(key, true, 0)
+	if b.limiter.Limit() != 8 {
+		t.Fatalf("rate should not be decreased while paused, got %v", b.limiter.Limit())
+	}
+
+	now := time.Now().Add(2 * recoverInterval)
+	if limit := b.recover(now); limit != 10 {
+		t.Fatalf("rate should be increased by %v, got %v", 16*recoverRatio, limit)
+	}
+	if limit := b.recover(now); limit != 10 {
+		t.Fatalf("rate should not be increased within recover interval, got %v", limit)
+	}
+
+	for i := 0; i < 8; i++ {
+		now = now.Add(recoverInterval)
+		b.recover(now)
+	}
+	if b.limiter.Limit() != rate.Limit(16) || b.backoffDur != 0 {
+		t.Fatalf("rate should be recovered to %v, got %v, backoff: %v", 16, b.limiter.Limit(), b.backoffDur)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	throttled := int32(1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&throttled) == 1 {
+			w.Write([]byte(`{"Response":{"Error":{"Code":"RequestLimitExceeded"}}}`))
+			return
+		}
+		w.Write([]byte(`{"Response":{}}`))
+	}))
+	defer server.Close()
+
+	Init(&Option{Enable: true, QPS: 100, MaxBackoff: time.Minute})
+	defer Init(nil)
+
+	client := &http.Client{Transport: NewTransport(enumor.TCloud, "100", nil)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request failed, err: %v", err)
+	}
+	resp.Body.Close()
+
+	key := KeyOf(enumor.TCloud, "100", resp.Request)
+	if limit := Global().getBucket(key, time.Now()).limiter.Limit(); limit != 50 {
+		t.Fatalf("rate should be halved after throttled, got %v", limit)
+	}
+
+	// calls are paused after throttled.
+	atomic.StoreInt32(&throttled, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("request should be paused after throttled")
+	}
+}
+
+func TestSecretAccount(t *testing.T) {
+	account := SecretAccount("AKIDexample")
+	if strings.Contains(account, "AKIDexample") || !strings.HasPrefix(account, "secret-") {
+		t.Errorf("secret account should not expose the secret id, got: %s", account)
+	}
+
+	if account != SecretAccount("AKIDexample") || account == SecretAccount("AKIDother") {
+		t.Errorf("secret account should be derived from the secret id")
+	}
+}
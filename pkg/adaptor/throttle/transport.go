@@ -0,0 +1,317 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package throttle
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxInspectBodySize is the max size of the response body inspected for throttling error codes, throttling error
+// responses are small, larger bodies are regarded as normal responses and are not buffered.
+const maxInspectBodySize = 64 << 10
+
+// throttleCodes is the error codes returned by vendors when calls are throttled, the error code parsed from the
+// response is throttled if it equals to one of them, or is its sub code.
+var throttleCodes = map[enumor.Vendor][]string{
+	// tcloud returns throttling error in the body of 200 response, such as RequestLimitExceeded.UinLimitExceeded.
+	enumor.TCloud: {"RequestLimitExceeded"},
+	enumor.Aws:    {"RequestLimitExceeded", "Throttling", "ThrottlingException", "TooManyRequestsException"},
+	// gcp returns 403 with reason rateLimitExceeded or userRateLimitExceeded.
+	enumor.Gcp: {"rateLimitExceeded", "userRateLimitExceeded", "RATE_LIMIT_EXCEEDED"},
+}
+
+// NewTransport returns a round tripper which limits the calls of the cloud account by the global throttler, calls are
+// sent by base, http.DefaultTransport is used if base is nil. it's the transport of all the sdk calls of the cloud
+// account, so the span of every call is also recorded here.
+func NewTransport(vendor enumor.Vendor, account string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{vendor: vendor, account: account, base: base}
+}
+
+type transport struct {
+	vendor  enumor.Vendor
+	account string
+	base    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	throttler := Global()
+	key := KeyOf(t.vendor, t.account, req)
+
+	// the span is the child of the span in the context of the sdk call, the wait time of throttling is included.
+	_, span := tracing.Tracer().Start(req.Context(), string(t.vendor)+" "+key.Family,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("cloud.vendor", string(t.vendor)),
+			attribute.String("cloud.account", t.account), attribute.String("cloud.api_family", key.Family),
+			attribute.String("http.method", req.Method), attribute.String("http.host", req.URL.Host)))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	if err = throttler.Wait(req.Context(), key); err != nil {
+		return nil, err
+	}
+
+	resp, err = t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	throttled, retryAfter := IsThrottled(t.vendor, resp)
+	throttler.Observe(key, throttled, retryAfter)
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode), attribute.Bool("cloud.throttled", throttled))
+	return resp, nil
+}
+
+// KeyOf returns the bucket key of the request, the api family is the service name in the host of the endpoint, such
+// as cvm of cvm.tencentcloudapi.com, ec2 of ec2.us-east-1.amazonaws.com, and it's the resource provider namespace for
+// azure, because all the azure apis share the same endpoint.
+func KeyOf(vendor enumor.Vendor, account string, req *http.Request) Key {
+	key := Key{Vendor: vendor, Account: account}
+	if req == nil || req.URL == nil {
+		return key
+	}
+
+	host := req.URL.Hostname()
+	key.Family = host
+	if idx := strings.Index(host, "."); idx > 0 {
+		key.Family = host[:idx]
+	}
+
+	if vendor == enumor.Azure {
+		parts := strings.Split(req.URL.Path, "/")
+		for i := range parts {
+			if strings.EqualFold(parts[i], "providers") && i+1 < len(parts) {
+				key.Family = parts[i+1]
+			}
+		}
+	}
+
+	return key
+}
+
+// IsThrottled checks if the call is throttled by vendor by the status code and the error codes in the response body,
+// and returns the duration suggested by vendor to retry after. the body is restored after inspected.
+func IsThrottled(vendor enumor.Vendor, resp *http.Response) (bool, time.Duration) {
+	if resp == nil {
+		return false, 0
+	}
+
+	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true, retryAfter
+	}
+
+	codes, exists := throttleCodes[vendor]
+	if !exists || resp.Body == nil || !needInspectBody(vendor, resp) || resp.ContentLength > maxInspectBodySize {
+		return false, 0
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxInspectBodySize+1))
+	if err != nil {
+		resp.Body = &restoredBody{Reader: io.MultiReader(bytes.NewReader(body), errReader{err: err}), closer: resp.Body}
+		return false, 0
+	}
+	resp.Body = &restoredBody{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), closer: resp.Body}
+
+	// throttling error responses are small, large bodies are regarded as normal responses.
+	if len(body) > maxInspectBodySize {
+		return false, 0
+	}
+
+	for _, errCode := range parseErrorCodes(vendor, resp.Header, body) {
+		for _, code := range codes {
+			if errCode == code || strings.HasPrefix(errCode, code+".") {
+				return true, retryAfter
+			}
+		}
+	}
+
+	return false, 0
+}
+
+// parseErrorCodes parse the error codes in the error fields of the response, only the error fields are compared
+// with the throttling codes, so that a normal response which contains the code in other fields such as the name
+// of a resource is not regarded as throttled.
+func parseErrorCodes(vendor enumor.Vendor, header http.Header, body []byte) []string {
+	switch vendor {
+	case enumor.TCloud:
+		errResp := new(tcloudErrorResponse)
+		if err := json.Unmarshal(body, errResp); err != nil || errResp.Response.Error == nil {
+			return nil
+		}
+		return []string{errResp.Response.Error.Code}
+
+	case enumor.Aws:
+		return parseAwsErrorCodes(header, body)
+
+	case enumor.Gcp:
+		errResp := new(gcpErrorResponse)
+		if err := json.Unmarshal(body, errResp); err != nil || errResp.Error == nil {
+			return nil
+		}
+
+		codes := make([]string, 0, len(errResp.Error.Errors)+len(errResp.Error.Details))
+		for _, one := range errResp.Error.Errors {
+			codes = append(codes, one.Reason)
+		}
+		for _, one := range errResp.Error.Details {
+			codes = append(codes, one.Reason)
+		}
+		return codes
+
+	default:
+		return nil
+	}
+}
+
+// tcloudErrorResponse is the error response of tcloud api.
+type tcloudErrorResponse struct {
+	Response struct {
+		Error *struct {
+			Code string `json:"Code"`
+		} `json:"Error"`
+	} `json:"Response"`
+}
+
+// gcpErrorResponse is the error response of gcp api, reason is in errors of the json api, and is in details of
+// the newer apis.
+type gcpErrorResponse struct {
+	Error *struct {
+		Errors []struct {
+			Reason string `json:"reason"`
+		} `json:"errors"`
+		Details []struct {
+			Reason string `json:"reason"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// parseAwsErrorCodes parse the error codes of aws, the error code is in the x-amzn-ErrorType header or the __type
+// field of the json protocol apis, and is in the Code element of the xml protocol apis.
+func parseAwsErrorCodes(header http.Header, body []byte) []string {
+	codes := make([]string, 0)
+	if errType := header.Get("X-Amzn-ErrorType"); len(errType) != 0 {
+		// such as: ThrottlingException:http://internal.amazon.com/coral/com.amazon.coral.availability/
+		codes = append(codes, strings.SplitN(errType, ":", 2)[0])
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		errResp := new(struct {
+			Type string `json:"__type"`
+			Code string `json:"code"`
+		})
+		if err := json.Unmarshal(trimmed, errResp); err != nil {
+			return codes
+		}
+
+		// such as: com.amazonaws.dynamodb.v20120810#ThrottlingException
+		if len(errResp.Type) != 0 {
+			codes = append(codes, errResp.Type[strings.LastIndex(errResp.Type, "#")+1:])
+		}
+		if len(errResp.Code) != 0 {
+			codes = append(codes, errResp.Code)
+		}
+		return codes
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(trimmed))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return codes
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "Code" {
+			continue
+		}
+
+		var code string
+		if err = decoder.DecodeElement(&code, &start); err != nil {
+			return codes
+		}
+		codes = append(codes, strings.TrimSpace(code))
+	}
+}
+
+// needInspectBody returns if the response body needs to be inspected for throttling error codes.
+func needInspectBody(vendor enumor.Vendor, resp *http.Response) bool {
+	switch vendor {
+	case enumor.TCloud:
+		// tcloud returns all the errors with 200 status code.
+		return resp.StatusCode == http.StatusOK
+	default:
+		return resp.StatusCode >= http.StatusBadRequest
+	}
+}
+
+// parseRetryAfter parse the Retry-After header which is in seconds.
+func parseRetryAfter(value string) time.Duration {
+	if len(value) == 0 {
+		return 0
+	}
+
+	sec, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || sec < 0 {
+		return 0
+	}
+
+	return time.Duration(sec) * time.Second
+}
+
+// restoredBody is the response body whose beginning is read for inspection and restored.
+type restoredBody struct {
+	io.Reader
+	closer io.Closer
+}
+
+// Close implements io.Closer.
+func (b *restoredBody) Close() error {
+	return b.closer.Close()
+}
+
+// errReader returns the error that occurred when reading the original body.
+type errReader struct {
+	err error
+}
+
+// Read implements io.Reader.
+func (e errReader) Read([]byte) (int, error) {
+	return 0, e.err
+}
@@ -41,9 +41,11 @@ func (t *TCloudAccountInfo) Validate() error {
 }
 
 // AwsAccountInfo define aws account info that used to check account.
+// one of CloudIamUsername and CloudRoleArn is required, CloudRoleArn is used when the account is accessed by assume role.
 type AwsAccountInfo struct {
 	CloudAccountID   string `json:"cloud_account_id"`
 	CloudIamUsername string `json:"cloud_iam_username"`
+	CloudRoleArn     string `json:"cloud_role_arn"`
 }
 
 // Validate AwsAccountInfo
@@ -52,8 +54,8 @@ func (a *AwsAccountInfo) Validate() error {
 		return errf.New(errf.InvalidParameter, "account id is required")
 	}
 
-	if len(a.CloudIamUsername) == 0 {
-		return errf.New(errf.InvalidParameter, "iam user name is required")
+	if len(a.CloudIamUsername) == 0 && len(a.CloudRoleArn) == 0 {
+		return errf.New(errf.InvalidParameter, "iam user name or role arn is required")
 	}
 
 	return nil
@@ -0,0 +1,49 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// ListOrganizationMemberOption define list organization member option.
+type ListOrganizationMemberOption struct {
+	// ParentID is the organization node to list members under, all the members of the organization are listed if
+	// it's empty. it's organizational unit or root id for aws, management group id for azure, organizations/{id} or
+	// folders/{id} for gcp, and node id for tcloud.
+	ParentID string `json:"parent_id" validate:"omitempty"`
+}
+
+// Validate ListOrganizationMemberOption.
+func (opt *ListOrganizationMemberOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// OrganizationMember defines the member of the organization, it's member account for aws and tcloud, subscription
+// for azure, and project for gcp.
+type OrganizationMember struct {
+	// CloudID is the member account id for aws and tcloud, subscription id for azure, and project id for gcp.
+	CloudID string `json:"cloud_id"`
+	Name    string `json:"name"`
+	Email   string `json:"email,omitempty"`
+	Status  string `json:"status,omitempty"`
+	// ParentID is the organization node that the member directly belongs to.
+	ParentID string `json:"parent_id,omitempty"`
+}
@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// ListMissingPermissionOption define list missing permission option.
+type ListMissingPermissionOption struct {
+	// Region is the region used to probe the regional resource api, vendor default region is used if it's empty.
+	Region string `json:"region" validate:"omitempty"`
+}
+
+// Validate ListMissingPermissionOption.
+func (opt *ListMissingPermissionOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// MissingPermission defines the cloud api permission which is required to sync the resource but not granted.
+type MissingPermission struct {
+	ResType enumor.CloudResourceType `json:"res_type"`
+	// Action is the cloud api action, such as ec2:DescribeVpcs.
+	Action string `json:"action"`
+	// Reason is the error message returned by cloud.
+	Reason string `json:"reason"`
+}
+
+// PermissionProbe defines a read only cloud api call that is used to detect whether the credential has the
+// permission to sync the resource or not.
+type PermissionProbe struct {
+	ResType enumor.CloudResourceType
+	Action  string
+	Probe   func() error
+}
+
+// ProbeMissingPermission runs the permission probes, the probe whose error is regarded as permission denied by
+// isDenied is returned as missing permission, other error means the probe is failed and is returned directly.
+func ProbeMissingPermission(probes []PermissionProbe, isDenied func(err error) bool) ([]MissingPermission, error) {
+	missing := make([]MissingPermission, 0)
+	for _, one := range probes {
+		err := one.Probe()
+		if err == nil {
+			continue
+		}
+
+		if !isDenied(err) {
+			return nil, err
+		}
+
+		missing = append(missing, MissingPermission{ResType: one.ResType, Action: one.Action, Reason: err.Error()})
+	}
+
+	return missing, nil
+}
@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/validator"
+)
+
+// BillDateLayout is the layout of bill date.
+const BillDateLayout = "2006-01-02"
+
+// AwsCostItemMaxLimit is the max limit of aws top cost items.
+const AwsCostItemMaxLimit = 100
+
+// -------------------------- DailyCost --------------------------
+
+// AwsDailyCostOption define aws daily cost list option.
+type AwsDailyCostOption struct {
+	// 起始日期，格式为yyyy-mm-dd
+	BeginDate string `json:"begin_date" validate:"required"`
+	// 截止日期，格式为yyyy-mm-dd
+	EndDate string `json:"end_date" validate:"required"`
+}
+
+// Validate aws daily cost list option.
+func (opt AwsDailyCostOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	return validateDateRange(opt.BeginDate, opt.EndDate)
+}
+
+// AwsDailyCost defines aws cost of one day aggregated by service and region.
+type AwsDailyCost struct {
+	// BillDate 账单日期，格式为yyyy-mm-dd
+	BillDate string `json:"bill_date"`
+	// Service 云产品代码，如：AmazonEC2
+	Service  string  `json:"service"`
+	Region   string  `json:"region"`
+	Currency string  `json:"currency"`
+	Cost     float64 `json:"cost"`
+}
+
+// -------------------------- TopCostItem --------------------------
+
+// AwsTopCostItemOption define aws top cost items list option.
+type AwsTopCostItemOption struct {
+	// 账单日期，格式为yyyy-mm-dd
+	BillDate string `json:"bill_date" validate:"required"`
+	// Service 云产品代码，为空表示不限制
+	Service string `json:"service" validate:"omitempty"`
+	// Region 地域，为空表示不限制
+	Region string `json:"region" validate:"omitempty"`
+	Limit  uint   `json:"limit" validate:"required,min=1"`
+}
+
+// Validate aws top cost items list option.
+func (opt AwsTopCostItemOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse(BillDateLayout, opt.BillDate); err != nil {
+		return errors.New("bill_date is invalid, format should be yyyy-mm-dd")
+	}
+
+	if opt.Limit > AwsCostItemMaxLimit {
+		return errors.New("limit should <= 100")
+	}
+
+	return nil
+}
+
+// AwsCostItem defines aws line items cost aggregated by resource and usage type.
+type AwsCostItem struct {
+	Service    string  `json:"service"`
+	Region     string  `json:"region"`
+	ResourceID string  `json:"resource_id"`
+	UsageType  string  `json:"usage_type"`
+	Operation  string  `json:"operation"`
+	Cost       float64 `json:"cost"`
+}
+
+func validateDateRange(beginDate, endDate string) error {
+	begin, err := time.Parse(BillDateLayout, beginDate)
+	if err != nil {
+		return errors.New("begin_date is invalid, format should be yyyy-mm-dd")
+	}
+
+	end, err := time.Parse(BillDateLayout, endDate)
+	if err != nil {
+		return errors.New("end_date is invalid, format should be yyyy-mm-dd")
+	}
+
+	if end.Before(begin) {
+		return errors.New("end_date should not be earlier than begin_date")
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"sync"
+	"time"
+)
+
+// CredentialCacheTTL is the max duration that a cached credential is reused. the cached credentials refresh their
+// short-lived tokens by themselves, the ttl only bounds how long the credentials of deleted or changed accounts
+// are kept in memory.
+const CredentialCacheTTL = time.Hour
+
+// CredentialCache caches the credentials of the cloud sdk which cache and refresh the short-lived tokens.
+// every cached credential belongs to an owner, such as the role or the account it's used for. the key of the
+// credential should change when the secret of the owner changes, then the credential of the previous key is
+// evicted when the credential of the new key is stored, so that the credential of a rotated secret is not kept.
+type CredentialCache struct {
+	ttl     time.Duration
+	lock    sync.Mutex
+	entries map[string]*credentialEntry
+	// keys is the map of owner to the key of its current credential.
+	keys map[string]string
+}
+
+type credentialEntry struct {
+	value    interface{}
+	expireAt time.Time
+}
+
+// NewCredentialCache new credential cache whose credentials expire after ttl.
+func NewCredentialCache(ttl time.Duration) *CredentialCache {
+	return &CredentialCache{
+		ttl:     ttl,
+		entries: make(map[string]*credentialEntry),
+		keys:    make(map[string]string),
+	}
+}
+
+// Load returns the credential of the key if it's cached and not expired.
+func (c *CredentialCache) Load(key string) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, exist := c.entries[key]
+	if !exist || time.Now().After(entry.expireAt) {
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// LoadOrStore returns the credential of the key if it's cached and not expired, otherwise stores the credential
+// for the owner and evicts the previous credential of the owner and the expired credentials.
+func (c *CredentialCache) LoadOrStore(owner, key string, value interface{}) interface{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	if entry, exist := c.entries[key]; exist && now.Before(entry.expireAt) {
+		return entry.value
+	}
+
+	if prevKey, exist := c.keys[owner]; exist && prevKey != key {
+		delete(c.entries, prevKey)
+	}
+
+	for k, entry := range c.entries {
+		if now.After(entry.expireAt) {
+			delete(c.entries, k)
+		}
+	}
+
+	for o, k := range c.keys {
+		if _, exist := c.entries[k]; !exist {
+			delete(c.keys, o)
+		}
+	}
+
+	c.entries[key] = &credentialEntry{value: value, expireAt: now.Add(c.ttl)}
+	c.keys[owner] = key
+	return value
+}
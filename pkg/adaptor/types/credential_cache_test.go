@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCredentialCache(t *testing.T) {
+	cache := NewCredentialCache(time.Hour)
+
+	if actual := cache.LoadOrStore("role", "key1", "cred1"); actual != "cred1" {
+		t.Fatalf("stored credential should be cred1, but got %v", actual)
+	}
+
+	if actual := cache.LoadOrStore("role", "key1", "cred2"); actual != "cred1" {
+		t.Errorf("cached credential should be reused, but got %v", actual)
+	}
+
+	// the secret of the role is changed, the credential of the previous key should be evicted.
+	cache.LoadOrStore("role", "key2", "cred2")
+	if _, exist := cache.Load("key1"); exist {
+		t.Errorf("credential of the previous key should be evicted")
+	}
+
+	if actual, exist := cache.Load("key2"); !exist || actual != "cred2" {
+		t.Errorf("credential of key2 should be cred2, but got %v", actual)
+	}
+
+	expired := NewCredentialCache(-time.Second)
+	expired.LoadOrStore("role", "key1", "cred1")
+	if _, exist := expired.Load("key1"); exist {
+		t.Errorf("expired credential should not be loaded")
+	}
+}
@@ -203,6 +203,7 @@ type AzureCvm struct {
 	VCPUsPerCore        *int32                                        `json:"vcpus_per_core"`
 	TimeCreated         *time.Time                                    `json:"time_created"`
 	StorageProfile      *armcompute.StorageProfile                    `json:"storage_profile"`
+	Tags                map[string]*string                            `json:"tags"`
 }
 
 // GetCloudID ...
@@ -21,6 +21,7 @@ package disk
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -153,3 +154,8 @@ type AwsDisk struct {
 func (disk AwsDisk) GetCloudID() string {
 	return converter.PtrToVal(disk.VolumeId)
 }
+
+// GetTags ...
+func (disk AwsDisk) GetTags() []tag.Tag {
+	return tag.FromAws(disk.Tags)
+}
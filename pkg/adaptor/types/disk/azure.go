@@ -20,6 +20,7 @@
 package disk
 
 import (
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -143,9 +144,15 @@ type AzureDisk struct {
 	Zones    []*string `json:"zone"`
 	SKUName  *string   `json:"sku_name"`
 	SKUTier  *string   `json:"sku_tier"`
+	Tags     []tag.Tag `json:"tags,omitempty"`
 }
 
 // GetCloudID ...
 func (disk AzureDisk) GetCloudID() string {
 	return converter.PtrToVal(disk.ID)
 }
+
+// GetTags ...
+func (disk AzureDisk) GetTags() []tag.Tag {
+	return disk.Tags
+}
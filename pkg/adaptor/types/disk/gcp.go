@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/validator"
 
 	"google.golang.org/api/compute/v1"
@@ -149,3 +150,8 @@ type GcpDisk struct {
 func (disk GcpDisk) GetCloudID() string {
 	return fmt.Sprint(disk.Id)
 }
+
+// GetTags ...
+func (disk GcpDisk) GetTags() []tag.Tag {
+	return tag.FromMap(disk.Labels)
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -281,3 +282,8 @@ type HuaWeiDisk struct {
 func (disk HuaWeiDisk) GetCloudID() string {
 	return disk.Id
 }
+
+// GetTags ...
+func (disk HuaWeiDisk) GetTags() []tag.Tag {
+	return tag.FromMap(disk.Tags)
+}
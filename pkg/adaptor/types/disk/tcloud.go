@@ -21,6 +21,7 @@ package disk
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -194,3 +195,8 @@ type TCloudDisk struct {
 func (disk TCloudDisk) GetCloudID() string {
 	return converter.PtrToVal(disk.DiskId)
 }
+
+// GetTags ...
+func (disk TCloudDisk) GetTags() []tag.Tag {
+	return tag.FromTCloudCbs(disk.Tags)
+}
@@ -20,6 +20,7 @@
 package eip
 
 import (
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/validator"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -59,6 +60,7 @@ type AwsEip struct {
 	NetworkBorderGroup      *string
 	NetworkInterfaceId      *string
 	NetworkInterfaceOwnerId *string
+	Tags                    []tag.Tag
 }
 
 // GetCloudID ...
@@ -66,6 +68,11 @@ func (eip *AwsEip) GetCloudID() string {
 	return eip.CloudID
 }
 
+// GetTags ...
+func (eip *AwsEip) GetTags() []tag.Tag {
+	return eip.Tags
+}
+
 // AwsEipDeleteOption ...
 type AwsEipDeleteOption struct {
 	Region  string `json:"region" validate:"required"`
@@ -20,6 +20,7 @@
 package eip
 
 import (
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/validator"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
@@ -79,6 +80,7 @@ type AzureEip struct {
 	Fqdn                   *string
 	Zones                  []*string
 	PublicIPAddressVersion *string
+	Tags                   []tag.Tag
 }
 
 // GetCloudID ...
@@ -86,6 +88,11 @@ func (eip *AzureEip) GetCloudID() string {
 	return eip.CloudID
 }
 
+// GetTags ...
+func (eip *AzureEip) GetTags() []tag.Tag {
+	return eip.Tags
+}
+
 // AzureEipDeleteOption ...
 type AzureEipDeleteOption struct {
 	ResourceGroupName string `json:"resource_group_name" validate:"required"`
@@ -21,6 +21,7 @@ package eip
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/validator"
 
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
@@ -66,6 +67,7 @@ type TCloudEip struct {
 	Bandwidth               *uint64
 	InternetChargeType      *string
 	InternetServiceProvider *string
+	Tags                    []tag.Tag
 }
 
 // GetCloudID ...
@@ -73,6 +75,11 @@ func (eip *TCloudEip) GetCloudID() string {
 	return eip.CloudID
 }
 
+// GetTags ...
+func (eip *TCloudEip) GetTags() []tag.Tag {
+	return eip.Tags
+}
+
 // TCloudEipDeleteOption ...
 type TCloudEipDeleteOption struct {
 	CloudIDs []string `json:"cloud_ids" validate:"required"`
@@ -21,6 +21,7 @@ package networkinterface
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	coreni "hcm/pkg/api/core/cloud/network-interface"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
@@ -51,6 +52,11 @@ func (ni AzureNI) GetCloudID() string {
 	return *ni.CloudID
 }
 
+// GetTags ...
+func (ni AzureNI) GetTags() []tag.Tag {
+	return ni.Tags
+}
+
 // HuaWeiNI defines huawei network interface.
 type HuaWeiNI CloudNetworkInterface[coreni.HuaWeiNIExtension]
 
@@ -69,22 +75,23 @@ func (ni GcpNI) GetCloudID() string {
 
 // CloudNetworkInterface defines network interface struct.
 type CloudNetworkInterface[T coreni.NetworkInterfaceExtension] struct {
-	Name          *string  `json:"name"`
-	AccountID     *string  `json:"account_id"`
-	Region        *string  `json:"region"`
-	Zone          *string  `json:"zone"`
-	CloudID       *string  `json:"cloud_id"`
-	VpcID         *string  `json:"vpc_id"`
-	CloudVpcID    *string  `json:"cloud_vpc_id"`
-	SubnetID      *string  `json:"subnet_id"`
-	CloudSubnetID *string  `json:"cloud_subnet_id"`
-	PrivateIPv4   []string `json:"private_ipv4"`
-	PrivateIPv6   []string `json:"private_ipv6"`
-	PublicIPv4    []string `json:"public_ipv4"`
-	PublicIPv6    []string `json:"public_ipv6"`
-	BkBizID       *int64   `json:"bk_biz_id"`
-	InstanceID    *string  `json:"instance_id"`
-	Extension     *T       `json:"extension"`
+	Name          *string   `json:"name"`
+	AccountID     *string   `json:"account_id"`
+	Region        *string   `json:"region"`
+	Zone          *string   `json:"zone"`
+	CloudID       *string   `json:"cloud_id"`
+	VpcID         *string   `json:"vpc_id"`
+	CloudVpcID    *string   `json:"cloud_vpc_id"`
+	SubnetID      *string   `json:"subnet_id"`
+	CloudSubnetID *string   `json:"cloud_subnet_id"`
+	PrivateIPv4   []string  `json:"private_ipv4"`
+	PrivateIPv6   []string  `json:"private_ipv6"`
+	PublicIPv4    []string  `json:"public_ipv4"`
+	PublicIPv6    []string  `json:"public_ipv6"`
+	BkBizID       *int64    `json:"bk_biz_id"`
+	InstanceID    *string   `json:"instance_id"`
+	Extension     *T        `json:"extension"`
+	Tags          []tag.Tag `json:"tags,omitempty"`
 }
 
 // AzureInterfaceListResponse defines azure list network interface response.
@@ -21,6 +21,7 @@ package routetable
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/errf"
 )
 
@@ -213,12 +214,13 @@ func (r HuaWeiRouteTableGetOption) Validate() error {
 
 // RouteTable defines route table struct.
 type RouteTable[T RouteTableExtension] struct {
-	CloudID    string  `json:"cloud_id"`
-	Name       string  `json:"name"`
-	CloudVpcID string  `json:"cloud_vpc_id"`
-	Region     string  `json:"region"`
-	Memo       *string `json:"memo,omitempty"`
-	Extension  *T      `json:"extension"`
+	CloudID    string    `json:"cloud_id"`
+	Name       string    `json:"name"`
+	CloudVpcID string    `json:"cloud_vpc_id"`
+	Region     string    `json:"region"`
+	Memo       *string   `json:"memo,omitempty"`
+	Extension  *T        `json:"extension"`
+	Tags       []tag.Tag `json:"tags,omitempty"`
 }
 
 // RouteTableExtension defines route table extensional info.
@@ -281,6 +283,11 @@ func (touteTable TCloudRouteTable) GetCloudID() string {
 	return touteTable.CloudID
 }
 
+// GetTags ...
+func (routeTable TCloudRouteTable) GetTags() []tag.Tag {
+	return routeTable.Tags
+}
+
 // AwsRouteTable defines aws route table.
 type AwsRouteTable RouteTable[AwsRouteTableExtension]
 
@@ -289,6 +296,11 @@ func (touteTable AwsRouteTable) GetCloudID() string {
 	return touteTable.CloudID
 }
 
+// GetTags ...
+func (routeTable AwsRouteTable) GetTags() []tag.Tag {
+	return routeTable.Tags
+}
+
 // AzureRouteTable defines azure route table.
 type AzureRouteTable RouteTable[AzureRouteTableExtension]
 
@@ -297,6 +309,11 @@ func (touteTable AzureRouteTable) GetCloudID() string {
 	return touteTable.CloudID
 }
 
+// GetTags ...
+func (routeTable AzureRouteTable) GetTags() []tag.Tag {
+	return routeTable.Tags
+}
+
 // HuaWeiRouteTable defines huawei route table.
 type HuaWeiRouteTable RouteTable[HuaWeiRouteTableExtension]
 
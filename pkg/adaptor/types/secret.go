@@ -20,6 +20,11 @@
 package types
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 )
@@ -47,26 +52,124 @@ func (b BaseSecret) Validate() error {
 	return nil
 }
 
+// AwsAssumeRole defines the role of aws account which is assumed by the trusted identity of hcm to get short-lived
+// credential.
+type AwsAssumeRole struct {
+	// RoleArn is the arn of the role to be assumed.
+	RoleArn string `json:"role_arn" validate:"required"`
+	// ExternalID is the external id required by the trust policy of the role, optional.
+	ExternalID string `json:"external_id,omitempty"`
+}
+
+// Validate AwsAssumeRole.
+func (a *AwsAssumeRole) Validate() error {
+	return validator.Validate.Struct(a)
+}
+
+// TrustedIdentity defines the identity of hcm itself, which is trusted by the cloud accounts that use short-lived
+// credential instead of secret key.
+type TrustedIdentity struct {
+	// Aws is the secret used to call sts AssumeRole. if it is nil, the default credential chain of the process
+	// (env, shared config, web identity token file, ecs/ec2 role) is used.
+	Aws *BaseSecret
+	// AwsRegion is the region of the aws sts endpoint.
+	AwsRegion string
+	// AwsSessionDuration is the duration of the assumed role session.
+	AwsSessionDuration time.Duration
+	// AzureFederatedTokenFile is the path of the token file used as client assertion in azure workload identity
+	// federation.
+	AzureFederatedTokenFile string
+}
+
 // GcpCredential define gcp credential information.
 type GcpCredential struct {
 	CloudProjectID string `json:"cloud_project_id" validate:"required"`
-	Json           []byte `json:"json,omitempty" validate:"required"`
+	// Json is the service account key when credential type is secret key, and is the credential configuration of
+	// external account or impersonated service account when credential type is workload identity.
+	Json           []byte                     `json:"json,omitempty" validate:"required"`
+	CredentialType enumor.CloudCredentialType `json:"credential_type,omitempty"`
 }
 
 // Validate GcpCredential
 func (g *GcpCredential) Validate() error {
-	return validator.Validate.Struct(g)
+	if err := validator.Validate.Struct(g); err != nil {
+		return err
+	}
+
+	if g.CredentialType.IsSecretKey() {
+		return nil
+	}
+
+	if g.CredentialType != enumor.WorkloadIdentityCredential {
+		return fmt.Errorf("gcp not support credential type: %s", g.CredentialType)
+	}
+
+	return ValidateGcpCredentialConfig(g.Json)
+}
+
+// ValidateGcpCredentialConfig validate the gcp workload identity credential configuration, it must be an external
+// account or impersonated service account configuration, which contains no secret.
+func ValidateGcpCredentialConfig(config []byte) error {
+	cfg := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return fmt.Errorf("gcp credential configuration is invalid, err: %v", err)
+	}
+
+	switch cfg.Type {
+	case "external_account", "impersonated_service_account":
+	default:
+		return fmt.Errorf("gcp credential configuration type: %s is not supported", cfg.Type)
+	}
+
+	return nil
 }
 
 // AzureCredential define azure credential information.
 type AzureCredential struct {
-	CloudTenantID        string `json:"cloud_tenant_id" validate:"required"`
-	CloudSubscriptionID  string `json:"cloud_subscription_id" validate:"required"`
-	CloudApplicationID   string `json:"cloud_application_id" validate:"required"`
-	CloudClientSecretKey string `json:"cloud_client_secret_key" validate:"required"`
+	CloudTenantID       string `json:"cloud_tenant_id" validate:"required"`
+	CloudSubscriptionID string `json:"cloud_subscription_id" validate:"required"`
+	// CloudApplicationID is the application(client) id of the service principal, it's the client id of the user
+	// assigned identity when credential type is managed identity, and empty means using system assigned identity.
+	CloudApplicationID   string                     `json:"cloud_application_id"`
+	CloudClientSecretKey string                     `json:"cloud_client_secret_key"`
+	CredentialType       enumor.CloudCredentialType `json:"credential_type,omitempty"`
+	// FederatedTokenFile is the path of the token file used as client assertion when credential type is workload
+	// identity.
+	FederatedTokenFile string `json:"-"`
 }
 
 // Validate AzureCredential
 func (a *AzureCredential) Validate() error {
-	return validator.Validate.Struct(a)
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	switch {
+	case a.CredentialType.IsSecretKey():
+		if len(a.CloudApplicationID) == 0 {
+			return errf.New(errf.InvalidParameter, "application id is required")
+		}
+
+		if len(a.CloudClientSecretKey) == 0 {
+			return errf.New(errf.InvalidParameter, "client secret key is required")
+		}
+
+	case a.CredentialType == enumor.ManagedIdentityCredential:
+
+	case a.CredentialType == enumor.WorkloadIdentityCredential:
+		if len(a.CloudApplicationID) == 0 {
+			return errf.New(errf.InvalidParameter, "application id is required")
+		}
+
+		if len(a.FederatedTokenFile) == 0 {
+			return errf.New(errf.InvalidParameter, "federated token file is not configured")
+		}
+
+	default:
+		return fmt.Errorf("azure not support credential type: %s", a.CredentialType)
+	}
+
+	return nil
 }
@@ -21,6 +21,7 @@ package securitygroup
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -102,3 +103,8 @@ type AwsSG struct {
 func (sg AwsSG) GetCloudID() string {
 	return converter.PtrToVal(sg.GroupId)
 }
+
+// GetTags ...
+func (sg AwsSG) GetTags() []tag.Tag {
+	return tag.FromAws(sg.Tags)
+}
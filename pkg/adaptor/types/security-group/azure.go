@@ -20,6 +20,7 @@
 package securitygroup
 
 import (
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -108,9 +109,15 @@ type AzureSecurityGroup struct {
 	FlushConnection *bool                      `json:"flush_connection"`
 	ResourceGUID    *string                    `json:"resource_guid"`
 	SecurityRules   []*armnetwork.SecurityRule `json:"security_rules"`
+	Tags            []tag.Tag                  `json:"tags,omitempty"`
 }
 
 // GetCloudID ...
 func (sg AzureSecurityGroup) GetCloudID() string {
 	return converter.PtrToVal(sg.ID)
 }
+
+// GetTags ...
+func (sg AzureSecurityGroup) GetTags() []tag.Tag {
+	return sg.Tags
+}
@@ -21,6 +21,7 @@ package securitygroup
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -116,3 +117,8 @@ type TCloudSG struct {
 func (sg TCloudSG) GetCloudID() string {
 	return converter.PtrToVal(sg.SecurityGroupId)
 }
+
+// GetTags ...
+func (sg TCloudSG) GetTags() []tag.Tag {
+	return tag.FromTCloudVpc(sg.TagSet)
+}
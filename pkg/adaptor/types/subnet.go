@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
@@ -421,13 +422,14 @@ type HuaWeiSubnetListResult struct {
 type Subnet[T SubnetExtension] struct {
 	// TODO: gcp 添加 vpcSelfLink字段，不要和 CloudVpcID 字段混用
 	// CloudVpcID gcp 该字段为 self_link
-	CloudVpcID string   `json:"cloud_vpc_id"`
-	CloudID    string   `json:"cloud_id"`
-	Name       string   `json:"name"`
-	Ipv4Cidr   []string `json:"ipv4_cidr,omitempty"`
-	Ipv6Cidr   []string `json:"ipv6_cidr,omitempty"`
-	Memo       *string  `json:"memo,omitempty"`
-	Extension  *T       `json:"extension"`
+	CloudVpcID string    `json:"cloud_vpc_id"`
+	CloudID    string    `json:"cloud_id"`
+	Name       string    `json:"name"`
+	Ipv4Cidr   []string  `json:"ipv4_cidr,omitempty"`
+	Ipv6Cidr   []string  `json:"ipv6_cidr,omitempty"`
+	Memo       *string   `json:"memo,omitempty"`
+	Extension  *T        `json:"extension"`
+	Tags       []tag.Tag `json:"tags,omitempty"`
 }
 
 // SubnetExtension defines subnet extensional info.
@@ -498,6 +500,11 @@ func (vpc TCloudSubnet) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTags ...
+func (vpc TCloudSubnet) GetTags() []tag.Tag {
+	return vpc.Tags
+}
+
 // AwsSubnet defines aws subnet.
 type AwsSubnet Subnet[AwsSubnetExtension]
 
@@ -506,6 +513,11 @@ func (vpc AwsSubnet) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTags ...
+func (vpc AwsSubnet) GetTags() []tag.Tag {
+	return vpc.Tags
+}
+
 // GcpSubnet defines gcp subnet.
 type GcpSubnet Subnet[GcpSubnetExtension]
 
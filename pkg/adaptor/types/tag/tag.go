@@ -0,0 +1,215 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tag defines the cloud resource tag adaptor types.
+package tag
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+)
+
+// Tag defines cloud resource tag.
+type Tag struct {
+	Key   string `json:"key" validate:"required"`
+	Value string `json:"value" validate:"-"`
+}
+
+// TaggedResource is the cloud resource that carries its tags, the tags are synced to db along with the resource.
+type TaggedResource interface {
+	GetCloudID() string
+	GetTags() []Tag
+}
+
+// FromMap convert the tags of map format to tags, e.g. azure tags, gcp labels and huawei disk tags.
+func FromMap[T string | *string](tags map[string]T) []Tag {
+	result := make([]Tag, 0, len(tags))
+	for key, value := range tags {
+		switch v := any(value).(type) {
+		case string:
+			result = append(result, Tag{Key: key, Value: v})
+		case *string:
+			result = append(result, Tag{Key: key, Value: converter.PtrToVal(v)})
+		}
+	}
+
+	return result
+}
+
+// FromTCloudVpc convert the tags of tcloud vpc service resources, e.g. vpc, subnet, eip and security group.
+func FromTCloudVpc(tags []*vpc.Tag) []Tag {
+	result := make([]Tag, 0, len(tags))
+	for _, one := range tags {
+		if one != nil {
+			result = append(result, Tag{Key: converter.PtrToVal(one.Key), Value: converter.PtrToVal(one.Value)})
+		}
+	}
+
+	return result
+}
+
+// FromTCloudCbs convert the tags of tcloud cbs disks.
+func FromTCloudCbs(tags []*cbs.Tag) []Tag {
+	result := make([]Tag, 0, len(tags))
+	for _, one := range tags {
+		if one != nil {
+			result = append(result, Tag{Key: converter.PtrToVal(one.Key), Value: converter.PtrToVal(one.Value)})
+		}
+	}
+
+	return result
+}
+
+// FromAws convert the tags of aws ec2 resources.
+func FromAws(tags []*ec2.Tag) []Tag {
+	result := make([]Tag, 0, len(tags))
+	for _, one := range tags {
+		if one != nil {
+			result = append(result, Tag{Key: converter.PtrToVal(one.Key), Value: converter.PtrToVal(one.Value)})
+		}
+	}
+
+	return result
+}
+
+// AwsTagUpdateOption defines aws resource tag update options.
+type AwsTagUpdateOption struct {
+	Region     string   `json:"region" validate:"required"`
+	CloudResID string   `json:"cloud_res_id" validate:"required"`
+	UpsertTags []Tag    `json:"upsert_tags" validate:"omitempty,dive"`
+	DeleteKeys []string `json:"delete_keys" validate:"omitempty"`
+}
+
+// Validate aws resource tag update option.
+func (opt AwsTagUpdateOption) Validate() error {
+	if len(opt.UpsertTags) == 0 && len(opt.DeleteKeys) == 0 {
+		return errors.New("upsert_tags or delete_keys is required")
+	}
+
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudTagUpdateOption defines tcloud resource tag update options.
+type TCloudTagUpdateOption struct {
+	Region string `json:"region" validate:"required"`
+	// ServiceType is the tcloud service type of the resource, e.g. cvm, vpc.
+	ServiceType string `json:"service_type" validate:"required"`
+	// ResourcePrefix is the tcloud resource prefix of the resource, e.g. instance, volume.
+	ResourcePrefix string   `json:"resource_prefix" validate:"required"`
+	CloudResID     string   `json:"cloud_res_id" validate:"required"`
+	UpsertTags     []Tag    `json:"upsert_tags" validate:"omitempty,dive"`
+	DeleteKeys     []string `json:"delete_keys" validate:"omitempty"`
+}
+
+// Validate tcloud resource tag update option.
+func (opt TCloudTagUpdateOption) Validate() error {
+	if len(opt.UpsertTags) == 0 && len(opt.DeleteKeys) == 0 {
+		return errors.New("upsert_tags or delete_keys is required")
+	}
+
+	return validator.Validate.Struct(opt)
+}
+
+// AzureTagUpdateOption defines azure resource tag update options.
+type AzureTagUpdateOption struct {
+	CloudResID string   `json:"cloud_res_id" validate:"required"`
+	UpsertTags []Tag    `json:"upsert_tags" validate:"omitempty,dive"`
+	DeleteKeys []string `json:"delete_keys" validate:"omitempty"`
+}
+
+// Validate azure resource tag update option.
+func (opt AzureTagUpdateOption) Validate() error {
+	if len(opt.UpsertTags) == 0 && len(opt.DeleteKeys) == 0 {
+		return errors.New("upsert_tags or delete_keys is required")
+	}
+
+	return validator.Validate.Struct(opt)
+}
+
+// ResType is the cloud resource type whose tags are updated by gcp and huawei, their tag apis differ by resource.
+type ResType string
+
+const (
+	// CvmResType is the cvm resource type.
+	CvmResType ResType = "cvm"
+	// DiskResType is the disk resource type.
+	DiskResType ResType = "disk"
+	// EipResType is the eip resource type.
+	EipResType ResType = "eip"
+	// VpcResType is the vpc resource type.
+	VpcResType ResType = "vpc"
+)
+
+// GcpLabelUpdateOption defines gcp resource label update options, labels are gcp resource tags.
+type GcpLabelUpdateOption struct {
+	// ResType supports cvm, disk and eip.
+	ResType ResType `json:"res_type" validate:"required"`
+	// Location is the zone of cvm and disk, or the region of eip.
+	Location   string   `json:"location" validate:"required"`
+	Name       string   `json:"name" validate:"required"`
+	UpsertTags []Tag    `json:"upsert_tags" validate:"omitempty,dive"`
+	DeleteKeys []string `json:"delete_keys" validate:"omitempty"`
+}
+
+// Validate gcp resource label update option.
+func (opt GcpLabelUpdateOption) Validate() error {
+	switch opt.ResType {
+	case CvmResType, DiskResType, EipResType:
+	default:
+		return fmt.Errorf("gcp %s does not support the update of labels", opt.ResType)
+	}
+
+	if len(opt.UpsertTags) == 0 && len(opt.DeleteKeys) == 0 {
+		return errors.New("upsert_tags or delete_keys is required")
+	}
+
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiTagUpdateOption defines huawei resource tag update options.
+type HuaWeiTagUpdateOption struct {
+	// ResType supports cvm, disk and vpc.
+	ResType    ResType  `json:"res_type" validate:"required"`
+	Region     string   `json:"region" validate:"required"`
+	CloudResID string   `json:"cloud_res_id" validate:"required"`
+	UpsertTags []Tag    `json:"upsert_tags" validate:"omitempty,dive"`
+	DeleteKeys []string `json:"delete_keys" validate:"omitempty"`
+}
+
+// Validate huawei resource tag update option.
+func (opt HuaWeiTagUpdateOption) Validate() error {
+	switch opt.ResType {
+	case CvmResType, DiskResType, VpcResType:
+	default:
+		return fmt.Errorf("huawei %s does not support the update of tags", opt.ResType)
+	}
+
+	if len(opt.UpsertTags) == 0 && len(opt.DeleteKeys) == 0 {
+		return errors.New("upsert_tags or delete_keys is required")
+	}
+
+	return validator.Validate.Struct(opt)
+}
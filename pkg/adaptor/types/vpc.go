@@ -21,6 +21,7 @@ package types
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/api/core/cloud"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
@@ -270,11 +271,12 @@ type HuaWeiVpcListResult struct {
 
 // Vpc defines vpc struct.
 type Vpc[T VpcExtension] struct {
-	CloudID   string  `json:"cloud_id"`
-	Name      string  `json:"name"`
-	Region    string  `json:"region"`
-	Memo      *string `json:"memo,omitempty"`
-	Extension *T      `json:"extension"`
+	CloudID   string    `json:"cloud_id"`
+	Name      string    `json:"name"`
+	Region    string    `json:"region"`
+	Memo      *string   `json:"memo,omitempty"`
+	Extension *T        `json:"extension"`
+	Tags      []tag.Tag `json:"tags,omitempty"`
 }
 
 // AzureVpcExtension defines azure vpc extensional info.
@@ -299,6 +301,11 @@ func (vpc TCloudVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTags ...
+func (vpc TCloudVpc) GetTags() []tag.Tag {
+	return vpc.Tags
+}
+
 // AwsVpc defines aws vpc.
 type AwsVpc Vpc[cloud.AwsVpcExtension]
 
@@ -307,6 +314,11 @@ func (vpc AwsVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTags ...
+func (vpc AwsVpc) GetTags() []tag.Tag {
+	return vpc.Tags
+}
+
 // GcpVpc defines gcp vpc.
 type GcpVpc Vpc[cloud.GcpVpcExtension]
 
@@ -323,6 +335,11 @@ func (vpc AzureVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTags ...
+func (vpc AzureVpc) GetTags() []tag.Tag {
+	return vpc.Tags
+}
+
 // HuaWeiVpc defines huawei vpc.
 type HuaWeiVpc Vpc[cloud.HuaWeiVpcExtension]
 
@@ -331,6 +348,11 @@ func (vpc HuaWeiVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTags ...
+func (vpc HuaWeiVpc) GetTags() []tag.Tag {
+	return vpc.Tags
+}
+
 // VpcUsage define vpc usage.
 type VpcUsage struct {
 	ID           *string  `json:"id"`
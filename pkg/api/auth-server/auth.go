@@ -41,6 +41,12 @@ func (r *InitAuthCenterReq) Validate() error {
 	return nil
 }
 
+// InitRbacReq initialize built-in rbac request.
+type InitRbacReq struct {
+	// Admins are the users to be bound with the builtin admin role on all the resources.
+	Admins []string `json:"admins"`
+}
+
 // PullResourceResp iam pull resource response.
 type PullResourceResp struct {
 	rest.BaseResp `json:",inline"`
@@ -24,7 +24,9 @@ import (
 	"fmt"
 	"regexp"
 
+	"hcm/pkg/adaptor/types"
 	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/json"
 )
@@ -35,6 +37,7 @@ var (
 		"contains lowercase letters(a-z), numbers(0-9) or hyphen(-), end with a lowercase letter or number, " +
 		"length should be 3 to 32 letters")
 	secretEmptyError = errors.New("SecretID/SecretKey can not be empty")
+	roleEmptyError   = errors.New("RoleArn can not be empty")
 	allBizError      = errors.New("can't choose specific biz when choose all biz")
 )
 
@@ -87,7 +90,30 @@ type gcpAccountCloudServiceSecretKey struct {
 	ClientX509CertURL       string `json:"client_x509_cert_url" validate:"required"`
 }
 
-func validateGcpAccountCloudServiceSecretKey(cloudServiceSecretKey string) error {
+// validateCloudCredentialType 校验云账号凭证类型，为空时表示使用密钥
+func validateCloudCredentialType(vendor enumor.Vendor, credentialType enumor.CloudCredentialType) error {
+	if len(credentialType) == 0 {
+		return nil
+	}
+
+	if err := credentialType.Validate(); err != nil {
+		return err
+	}
+
+	return credentialType.ValidateVendor(vendor)
+}
+
+func validateGcpAccountCloudServiceSecretKey(cloudServiceSecretKey string,
+	credentialType enumor.CloudCredentialType) error {
+
+	// 联合身份使用的是不包含密钥的凭证配置
+	if credentialType == enumor.WorkloadIdentityCredential {
+		if cloudServiceSecretKey == "" {
+			return nil
+		}
+		return types.ValidateGcpCredentialConfig([]byte(cloudServiceSecretKey))
+	}
+
 	if cloudServiceSecretKey != "" {
 		var secretKey gcpAccountCloudServiceSecretKey
 		if err := json.UnmarshalFromString(cloudServiceSecretKey, &secretKey); err != nil {
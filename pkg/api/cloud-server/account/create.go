@@ -57,10 +57,13 @@ func (req *TCloudAccountExtensionCreateReq) IsFull() bool {
 
 // AwsAccountExtensionCreateReq ...
 type AwsAccountExtensionCreateReq struct {
-	CloudAccountID   string `json:"cloud_account_id" validate:"required"`
-	CloudIamUsername string `json:"cloud_iam_username" validate:"required"`
-	CloudSecretID    string `json:"cloud_secret_id" validate:"omitempty"`
-	CloudSecretKey   string `json:"cloud_secret_key" validate:"omitempty"`
+	CloudAccountID      string                     `json:"cloud_account_id" validate:"required"`
+	CloudIamUsername    string                     `json:"cloud_iam_username" validate:"omitempty"`
+	CloudSecretID       string                     `json:"cloud_secret_id" validate:"omitempty"`
+	CloudSecretKey      string                     `json:"cloud_secret_key" validate:"omitempty"`
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type" validate:"omitempty"`
+	CloudRoleArn        string                     `json:"cloud_role_arn" validate:"omitempty"`
+	CloudExternalID     string                     `json:"cloud_external_id" validate:"omitempty"`
 }
 
 // Validate ...
@@ -69,8 +72,20 @@ func (req *AwsAccountExtensionCreateReq) Validate(accountType enumor.AccountType
 		return err
 	}
 
+	if err := validateCloudCredentialType(enumor.Aws, req.CloudCredentialType); err != nil {
+		return err
+	}
+
+	// 使用密钥时，需要通过IAM用户名校验密钥的归属
+	if req.CloudCredentialType.IsSecretKey() && req.CloudIamUsername == "" {
+		return errors.New("IamUsername can not be empty")
+	}
+
 	// 登记账号密钥可为空，其他类型则必填
 	if accountType != enumor.RegistrationAccount && !req.IsFull() {
+		if req.CloudCredentialType == enumor.AssumeRoleCredential {
+			return roleEmptyError
+		}
 		return secretEmptyError
 	}
 
@@ -79,6 +94,10 @@ func (req *AwsAccountExtensionCreateReq) Validate(accountType enumor.AccountType
 
 // IsFull 对于不同账号类型，有些字段是允许为空的，这里返回是否所有字段都有值
 func (req *AwsAccountExtensionCreateReq) IsFull() bool {
+	if req.CloudCredentialType == enumor.AssumeRoleCredential {
+		return req.CloudRoleArn != ""
+	}
+
 	return req.CloudSecretID != "" && req.CloudSecretKey != ""
 }
 
@@ -120,6 +139,8 @@ type GcpAccountExtensionCreateReq struct {
 	CloudServiceAccountName string `json:"cloud_service_account_name" validate:"omitempty"`
 	CloudServiceSecretID    string `json:"cloud_service_secret_id" validate:"omitempty"`
 	CloudServiceSecretKey   string `json:"cloud_service_secret_key" validate:"omitempty"`
+	// CloudCredentialType 凭证类型为 workload_identity 时，CloudServiceSecretKey 为联合身份的凭证配置
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type" validate:"omitempty"`
 }
 
 // Validate ...
@@ -128,8 +149,12 @@ func (req *GcpAccountExtensionCreateReq) Validate(accountType enumor.AccountType
 		return err
 	}
 
+	if err := validateCloudCredentialType(enumor.Gcp, req.CloudCredentialType); err != nil {
+		return err
+	}
+
 	// 检查密钥是否符合要求
-	if err := validateGcpAccountCloudServiceSecretKey(req.CloudServiceSecretKey); err != nil {
+	if err := validateGcpAccountCloudServiceSecretKey(req.CloudServiceSecretKey, req.CloudCredentialType); err != nil {
 		return err
 	}
 
@@ -143,6 +168,11 @@ func (req *GcpAccountExtensionCreateReq) Validate(accountType enumor.AccountType
 
 // IsFull  对于不同账号类型，有些字段是允许为空的，这里返回是否所有字段都有值
 func (req *GcpAccountExtensionCreateReq) IsFull() bool {
+	// 联合身份没有服务账号密钥，只需要凭证配置
+	if req.CloudCredentialType == enumor.WorkloadIdentityCredential {
+		return req.CloudServiceSecretKey != ""
+	}
+
 	return req.CloudServiceSecretID != "" &&
 		req.CloudServiceSecretKey != "" &&
 		req.CloudServiceAccountID != "" &&
@@ -158,6 +188,8 @@ type AzureAccountExtensionCreateReq struct {
 	CloudApplicationName  string `json:"cloud_application_name" validate:"omitempty"`
 	CloudClientSecretID   string `json:"cloud_client_secret_id" validate:"omitempty"`
 	CloudClientSecretKey  string `json:"cloud_client_secret_key" validate:"omitempty"`
+	// CloudCredentialType 凭证类型为 managed_identity 时，CloudApplicationID 为用户分配的托管身份的客户端ID，为空时使用系统分配的托管身份
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type" validate:"omitempty"`
 }
 
 // Validate ...
@@ -166,6 +198,10 @@ func (req *AzureAccountExtensionCreateReq) Validate(accountType enumor.AccountTy
 		return err
 	}
 
+	if err := validateCloudCredentialType(enumor.Azure, req.CloudCredentialType); err != nil {
+		return err
+	}
+
 	// 登记账号密钥可为空，其他类型则必填
 	if accountType != enumor.RegistrationAccount && !req.IsFull() {
 		return errors.New("ApplicationID/ApplicationName/SecretID/SecretKey can not be empty")
@@ -176,6 +212,13 @@ func (req *AzureAccountExtensionCreateReq) Validate(accountType enumor.AccountTy
 
 // IsFull  对于不同账号类型，有些字段是允许为空的，这里返回是否所有字段都有值
 func (req *AzureAccountExtensionCreateReq) IsFull() bool {
+	switch req.CloudCredentialType {
+	case enumor.ManagedIdentityCredential:
+		return true
+	case enumor.WorkloadIdentityCredential:
+		return req.CloudApplicationID != ""
+	}
+
 	return req.CloudClientSecretID != "" &&
 		req.CloudClientSecretKey != "" &&
 		req.CloudApplicationID != "" &&
@@ -0,0 +1,254 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"errors"
+	"fmt"
+
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// OrganizationCredential is the credential of organization admin, it's the secret of tcloud group account admin,
+// the secret of aws organization management account, the application of azure tenant or the service account of gcp
+// organization. the secrets of azure and gcp are inherited by the imported member accounts.
+type OrganizationCredential struct {
+	// TCloud, Aws
+	CloudSecretID  string `json:"cloud_secret_id" validate:"omitempty"`
+	CloudSecretKey string `json:"cloud_secret_key" validate:"omitempty"`
+
+	// Azure
+	CloudTenantID        string `json:"cloud_tenant_id" validate:"omitempty"`
+	CloudSubscriptionID  string `json:"cloud_subscription_id" validate:"omitempty"`
+	CloudApplicationID   string `json:"cloud_application_id" validate:"omitempty"`
+	CloudApplicationName string `json:"cloud_application_name" validate:"omitempty"`
+	CloudClientSecretID  string `json:"cloud_client_secret_id" validate:"omitempty"`
+	CloudClientSecretKey string `json:"cloud_client_secret_key" validate:"omitempty"`
+
+	// Gcp
+	CloudProjectID          string `json:"cloud_project_id" validate:"omitempty"`
+	CloudServiceAccountID   string `json:"cloud_service_account_id" validate:"omitempty"`
+	CloudServiceAccountName string `json:"cloud_service_account_name" validate:"omitempty"`
+	CloudServiceSecretID    string `json:"cloud_service_secret_id" validate:"omitempty"`
+	CloudServiceSecretKey   string `json:"cloud_service_secret_key" validate:"omitempty"`
+
+	// CloudCredentialType Azure和Gcp的凭证类型，为空时表示使用密钥
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type" validate:"omitempty"`
+}
+
+// Validate the credential of the vendor.
+func (c *OrganizationCredential) Validate(vendor enumor.Vendor) error {
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	switch vendor {
+	case enumor.TCloud, enumor.Aws:
+		if len(c.CloudSecretID) == 0 || len(c.CloudSecretKey) == 0 {
+			return secretEmptyError
+		}
+
+	case enumor.Azure:
+		if len(c.CloudTenantID) == 0 || len(c.CloudSubscriptionID) == 0 {
+			return errors.New("TenantID/SubscriptionID can not be empty")
+		}
+
+		if err := validateCloudCredentialType(enumor.Azure, c.CloudCredentialType); err != nil {
+			return err
+		}
+
+		extension := &AzureAccountExtensionCreateReq{
+			CloudApplicationID:   c.CloudApplicationID,
+			CloudApplicationName: c.CloudApplicationName,
+			CloudClientSecretID:  c.CloudClientSecretID,
+			CloudClientSecretKey: c.CloudClientSecretKey,
+			CloudCredentialType:  c.CloudCredentialType,
+		}
+		if !extension.IsFull() {
+			return errors.New("ApplicationID/ApplicationName/SecretID/SecretKey can not be empty")
+		}
+
+	case enumor.Gcp:
+		if len(c.CloudProjectID) == 0 {
+			return errors.New("ProjectID can not be empty")
+		}
+
+		if err := validateCloudCredentialType(enumor.Gcp, c.CloudCredentialType); err != nil {
+			return err
+		}
+
+		if err := validateGcpAccountCloudServiceSecretKey(c.CloudServiceSecretKey, c.CloudCredentialType); err != nil {
+			return err
+		}
+
+		extension := &GcpAccountExtensionCreateReq{
+			CloudServiceAccountID:   c.CloudServiceAccountID,
+			CloudServiceAccountName: c.CloudServiceAccountName,
+			CloudServiceSecretID:    c.CloudServiceSecretID,
+			CloudServiceSecretKey:   c.CloudServiceSecretKey,
+			CloudCredentialType:     c.CloudCredentialType,
+		}
+		if !extension.IsFull() {
+			return errors.New("AccountID/AccountName/SecretID/SecretKey can not be empty")
+		}
+
+	default:
+		return fmt.Errorf("organization import not support vendor: %s", vendor)
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// OrganizationMemberListReq list the member accounts of the organization.
+type OrganizationMemberListReq struct {
+	Credential *OrganizationCredential `json:"credential" validate:"required"`
+	// ParentID 组织节点，为空时查询整个组织。
+	// Aws为根或OU的ID，Azure为管理组ID，Gcp为 organizations/{id} 或 folders/{id}，TCloud为集团组织节点ID
+	ParentID string `json:"parent_id" validate:"omitempty"`
+}
+
+// Validate OrganizationMemberListReq.
+func (req *OrganizationMemberListReq) Validate(vendor enumor.Vendor) error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.Credential.Validate(vendor)
+}
+
+// OrganizationMember is the organization member with the import status in hcm.
+type OrganizationMember struct {
+	typeaccount.OrganizationMember `json:",inline"`
+	// Imported 是否已作为账号接入
+	Imported bool `json:"imported"`
+	// AccountID 已接入时对应的账号ID
+	AccountID string `json:"account_id,omitempty"`
+	// AccountName 已接入时对应的账号名称
+	AccountName string `json:"account_name,omitempty"`
+}
+
+// OrganizationMemberListResult is the result of list organization members.
+type OrganizationMemberListResult struct {
+	Details []OrganizationMember `json:"details"`
+}
+
+// -------------------------- Import --------------------------
+
+// OrganizationMemberImportReq import the organization members as resource accounts in batch.
+type OrganizationMemberImportReq struct {
+	Credential *OrganizationCredential `json:"credential" validate:"required"`
+	// ParentID 成员所在的组织节点，用于校验导入的成员属于该组织节点
+	ParentID string                 `json:"parent_id" validate:"omitempty"`
+	Site     enumor.AccountSiteType `json:"site" validate:"required"`
+	// MemberRoleName Aws成员账号中供平台扮演的角色名称，成员账号通过角色扮演接入
+	MemberRoleName string `json:"member_role_name" validate:"omitempty"`
+	// MemberExternalID Aws扮演成员账号角色时使用的外部ID
+	MemberExternalID string                     `json:"member_external_id" validate:"omitempty"`
+	Members          []OrganizationMemberImport `json:"members" validate:"required,min=1,max=100,dive"`
+}
+
+// OrganizationMemberImport is the organization member to import.
+type OrganizationMemberImport struct {
+	CloudID  string   `json:"cloud_id" validate:"required"`
+	Name     string   `json:"name" validate:"required,min=3,max=32"`
+	Managers []string `json:"managers" validate:"required,max=5"`
+	BkBizIDs []int64  `json:"bk_biz_ids" validate:"required"`
+	Memo     *string  `json:"memo" validate:"omitempty"`
+
+	// TCloud 集团组织无法下发成员账号密钥，需要指定成员账号的子账号及其密钥
+	CloudSubAccountID string `json:"cloud_sub_account_id" validate:"omitempty"`
+	CloudSecretID     string `json:"cloud_secret_id" validate:"omitempty"`
+	CloudSecretKey    string `json:"cloud_secret_key" validate:"omitempty"`
+}
+
+// Validate OrganizationMemberImportReq.
+func (req *OrganizationMemberImportReq) Validate(vendor enumor.Vendor) error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.Credential.Validate(vendor); err != nil {
+		return err
+	}
+
+	if err := req.Site.Validate(); err != nil {
+		return err
+	}
+
+	// 部分云只有国际站
+	if (vendor == enumor.Gcp || vendor == enumor.Azure) && req.Site != enumor.InternationalSite {
+		return fmt.Errorf("%s support only international site", vendor)
+	}
+
+	if vendor == enumor.Aws && len(req.MemberRoleName) == 0 {
+		return errors.New("member_role_name is required")
+	}
+
+	cloudIDs := make(map[string]struct{}, len(req.Members))
+	names := make(map[string]struct{}, len(req.Members))
+	for _, member := range req.Members {
+		if _, exists := cloudIDs[member.CloudID]; exists {
+			return fmt.Errorf("member %s is duplicate", member.CloudID)
+		}
+		cloudIDs[member.CloudID] = struct{}{}
+
+		if _, exists := names[member.Name]; exists {
+			return fmt.Errorf("account name %s is duplicate", member.Name)
+		}
+		names[member.Name] = struct{}{}
+
+		if err := member.validate(vendor); err != nil {
+			return fmt.Errorf("member %s is invalid, err: %v", member.CloudID, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *OrganizationMemberImport) validate(vendor enumor.Vendor) error {
+	if err := validateAccountName(m.Name); err != nil {
+		return err
+	}
+
+	// 导入的成员账号均为资源账号
+	if err := validateResAccountBkBizIDs(m.BkBizIDs); err != nil {
+		return err
+	}
+
+	if err := validateBkBizIDs(m.BkBizIDs); err != nil {
+		return err
+	}
+
+	if vendor == enumor.TCloud {
+		if len(m.CloudSubAccountID) == 0 {
+			return errors.New("cloud_sub_account_id is required")
+		}
+
+		if len(m.CloudSecretID) == 0 || len(m.CloudSecretKey) == 0 {
+			return secretEmptyError
+		}
+	}
+
+	return nil
+}
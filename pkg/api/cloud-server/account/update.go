@@ -55,9 +55,12 @@ func (req *TCloudAccountExtensionUpdateReq) IsFull() bool {
 
 // AwsAccountExtensionUpdateReq ...
 type AwsAccountExtensionUpdateReq struct {
-	CloudIamUsername string `json:"cloud_iam_username" validate:"required"`
-	CloudSecretID    string `json:"cloud_secret_id" validate:"omitempty"`
-	CloudSecretKey   string `json:"cloud_secret_key" validate:"omitempty"`
+	CloudIamUsername    string                     `json:"cloud_iam_username" validate:"omitempty"`
+	CloudSecretID       string                     `json:"cloud_secret_id" validate:"omitempty"`
+	CloudSecretKey      string                     `json:"cloud_secret_key" validate:"omitempty"`
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type" validate:"omitempty"`
+	CloudRoleArn        string                     `json:"cloud_role_arn" validate:"omitempty"`
+	CloudExternalID     string                     `json:"cloud_external_id" validate:"omitempty"`
 }
 
 // Validate ...
@@ -66,8 +69,20 @@ func (req *AwsAccountExtensionUpdateReq) Validate(accountType enumor.AccountType
 		return err
 	}
 
+	if err := validateCloudCredentialType(enumor.Aws, req.CloudCredentialType); err != nil {
+		return err
+	}
+
+	// 使用密钥时，需要通过IAM用户名校验密钥的归属
+	if req.CloudCredentialType.IsSecretKey() && req.CloudIamUsername == "" {
+		return errors.New("IamUsername can not be empty")
+	}
+
 	// 登记账号密钥可为空，其他类型则必填
 	if accountType != enumor.RegistrationAccount && !req.IsFull() {
+		if req.CloudCredentialType == enumor.AssumeRoleCredential {
+			return roleEmptyError
+		}
 		return secretEmptyError
 	}
 
@@ -76,6 +91,10 @@ func (req *AwsAccountExtensionUpdateReq) Validate(accountType enumor.AccountType
 
 // IsFull 对于不同账号类型，有些字段是允许为空的，这里返回是否所有字段都有值
 func (req *AwsAccountExtensionUpdateReq) IsFull() bool {
+	if req.CloudCredentialType == enumor.AssumeRoleCredential {
+		return req.CloudRoleArn != ""
+	}
+
 	return req.CloudSecretID != "" && req.CloudSecretKey != ""
 }
 
@@ -112,6 +131,8 @@ type GcpAccountExtensionUpdateReq struct {
 	CloudServiceAccountName string `json:"cloud_service_account_name" validate:"omitempty"`
 	CloudServiceSecretID    string `json:"cloud_service_secret_id" validate:"omitempty"`
 	CloudServiceSecretKey   string `json:"cloud_service_secret_key" validate:"omitempty"`
+	// CloudCredentialType 凭证类型为 workload_identity 时，CloudServiceSecretKey 为联合身份的凭证配置
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type" validate:"omitempty"`
 }
 
 // Validate ...
@@ -120,8 +141,12 @@ func (req *GcpAccountExtensionUpdateReq) Validate(accountType enumor.AccountType
 		return err
 	}
 
+	if err := validateCloudCredentialType(enumor.Gcp, req.CloudCredentialType); err != nil {
+		return err
+	}
+
 	// 检查密钥是否符合要求
-	if err := validateGcpAccountCloudServiceSecretKey(req.CloudServiceSecretKey); err != nil {
+	if err := validateGcpAccountCloudServiceSecretKey(req.CloudServiceSecretKey, req.CloudCredentialType); err != nil {
 		return err
 	}
 
@@ -135,6 +160,11 @@ func (req *GcpAccountExtensionUpdateReq) Validate(accountType enumor.AccountType
 
 // IsFull  对于不同账号类型，有些字段是允许为空的，这里返回是否所有字段都有值
 func (req *GcpAccountExtensionUpdateReq) IsFull() bool {
+	// 联合身份没有服务账号密钥，只需要凭证配置
+	if req.CloudCredentialType == enumor.WorkloadIdentityCredential {
+		return req.CloudServiceSecretKey != ""
+	}
+
 	return req.CloudServiceSecretID != "" &&
 		req.CloudServiceSecretKey != "" &&
 		req.CloudServiceAccountID != "" &&
@@ -147,6 +177,8 @@ type AzureAccountExtensionUpdateReq struct {
 	CloudApplicationName string `json:"cloud_application_name" validate:"omitempty"`
 	CloudClientSecretID  string `json:"cloud_client_secret_id" validate:"omitempty"`
 	CloudClientSecretKey string `json:"cloud_client_secret_key" validate:"omitempty"`
+	// CloudCredentialType 凭证类型为 managed_identity 时，CloudApplicationID 为用户分配的托管身份的客户端ID，为空时使用系统分配的托管身份
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type" validate:"omitempty"`
 }
 
 // Validate ...
@@ -155,6 +187,10 @@ func (req *AzureAccountExtensionUpdateReq) Validate(accountType enumor.AccountTy
 		return err
 	}
 
+	if err := validateCloudCredentialType(enumor.Azure, req.CloudCredentialType); err != nil {
+		return err
+	}
+
 	// 登记账号密钥可为空，其他类型则必填
 	if accountType != enumor.RegistrationAccount && !req.IsFull() {
 		return errors.New("ApplicationID/ApplicationName/SecretID/SecretKey can not be empty")
@@ -165,6 +201,13 @@ func (req *AzureAccountExtensionUpdateReq) Validate(accountType enumor.AccountTy
 
 // IsFull  对于不同账号类型，有些字段是允许为空的，这里返回是否所有字段都有值
 func (req *AzureAccountExtensionUpdateReq) IsFull() bool {
+	switch req.CloudCredentialType {
+	case enumor.ManagedIdentityCredential:
+		return true
+	case enumor.WorkloadIdentityCredential:
+		return req.CloudApplicationID != ""
+	}
+
 	return req.CloudClientSecretID != "" &&
 		req.CloudClientSecretKey != "" &&
 		req.CloudApplicationID != "" &&
@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	typecvm "hcm/pkg/adaptor/types/cvm"
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
@@ -61,6 +62,9 @@ type AwsCvmCreateReq struct {
 	RequiredCount int64 `json:"required_count" validate:"required,min=1,max=500"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
+
+	// Tags 资源创建后设置的标签，需要满足账号和业务的标签策略
+	Tags []coreresourcetag.Tag `json:"tags" validate:"omitempty,dive"`
 }
 
 // Validate ...
@@ -69,6 +73,10 @@ func (req *AwsCvmCreateReq) Validate() error {
 		return err
 	}
 
+	if err := coreresourcetag.ValidateTags(req.Tags); err != nil {
+		return err
+	}
+
 	if err := validator.ValidateCvmName(enumor.Aws, req.Name); err != nil {
 		return errf.NewFromErr(errf.InvalidParameter, err)
 	}
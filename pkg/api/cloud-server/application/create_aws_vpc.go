@@ -20,6 +20,7 @@
 package application
 
 import (
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
 	"hcm/pkg/criteria/validator"
 )
 
@@ -35,6 +36,9 @@ type AwsVpcCreateReq struct {
 	InstanceTenancy string `json:"instance_tenancy" validate:"required,oneof=default dedicated"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
+
+	// Tags 资源创建后设置的标签，需要满足账号和业务的标签策略
+	Tags []coreresourcetag.Tag `json:"tags" validate:"omitempty,dive"`
 }
 
 // Validate ...
@@ -43,5 +47,9 @@ func (req *AwsVpcCreateReq) Validate() error {
 		return err
 	}
 
+	if err := coreresourcetag.ValidateTags(req.Tags); err != nil {
+		return err
+	}
+
 	return nil
 }
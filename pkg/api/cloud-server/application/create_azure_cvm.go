@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	typecvm "hcm/pkg/adaptor/types/cvm"
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
@@ -77,6 +78,9 @@ type AzureCvmCreateReq struct {
 	RequiredCount int64 `json:"required_count" validate:"required,min=1,max=500"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
+
+	// Tags 资源创建后设置的标签，需要满足账号和业务的标签策略
+	Tags []coreresourcetag.Tag `json:"tags" validate:"omitempty,dive"`
 }
 
 // Validate ...
@@ -85,6 +89,10 @@ func (req *AzureCvmCreateReq) Validate() error {
 		return err
 	}
 
+	if err := coreresourcetag.ValidateTags(req.Tags); err != nil {
+		return err
+	}
+
 	if err := validator.ValidateCvmName(enumor.Azure, req.Name); err != nil {
 		return errf.NewFromErr(errf.InvalidParameter, err)
 	}
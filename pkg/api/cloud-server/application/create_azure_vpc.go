@@ -20,6 +20,7 @@
 package application
 
 import (
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/assert"
@@ -41,6 +42,9 @@ type AzureVpcCreateReq struct {
 	} `json:"subnet" validate:"required"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
+
+	// Tags 资源创建后设置的标签，需要满足账号和业务的标签策略
+	Tags []coreresourcetag.Tag `json:"tags" validate:"omitempty,dive"`
 }
 
 // Validate ...
@@ -49,6 +53,10 @@ func (req *AzureVpcCreateReq) Validate() error {
 		return err
 	}
 
+	if err := coreresourcetag.ValidateTags(req.Tags); err != nil {
+		return err
+	}
+
 	// region can be no space lowercase
 	if !assert.IsSameCaseNoSpaceString(req.Region) {
 		return errf.New(errf.InvalidParameter, "region can only be lowercase")
@@ -22,6 +22,7 @@ package application
 import (
 	"errors"
 
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
 	hcproto "hcm/pkg/api/hc-service/disk"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
@@ -41,6 +42,9 @@ type TCloudDiskCreateReq struct {
 	DiskChargeType    string                           `json:"disk_charge_type" validate:"required"`
 	DiskChargePrepaid *hcproto.TCloudDiskChargePrepaid `json:"disk_charge_prepaid"`
 	Memo              *string                          `json:"memo"`
+
+	// Tags 资源创建后设置的标签，需要满足账号和业务的标签策略
+	Tags []coreresourcetag.Tag `json:"tags" validate:"omitempty,dive"`
 }
 
 // Validate ...
@@ -49,7 +53,11 @@ func (req *TCloudDiskCreateReq) Validate() error {
 		return errors.New("disk count should <= 100")
 	}
 
-	return validator.Validate.Struct(req)
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return coreresourcetag.ValidateTags(req.Tags)
 }
 
 // HuaWeiDiskCreateReq ...
@@ -65,6 +73,9 @@ type HuaWeiDiskCreateReq struct {
 	DiskChargeType    *string                          `json:"disk_charge_type" validate:"required"`
 	DiskChargePrepaid *hcproto.HuaWeiDiskChargePrepaid `json:"disk_charge_prepaid"`
 	Memo              *string                          `json:"memo"`
+
+	// Tags 资源创建后设置的标签，需要满足账号和业务的标签策略
+	Tags []coreresourcetag.Tag `json:"tags" validate:"omitempty,dive"`
 }
 
 // Validate ...
@@ -73,7 +84,11 @@ func (req *HuaWeiDiskCreateReq) Validate() error {
 		return errors.New("disk count should <= 100")
 	}
 
-	return validator.Validate.Struct(req)
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return coreresourcetag.ValidateTags(req.Tags)
 }
 
 // GcpDiskCreateReq ...
@@ -87,6 +102,9 @@ type GcpDiskCreateReq struct {
 	DiskSize  int32   `json:"disk_size" validate:"required"`
 	DiskCount int32   `json:"disk_count" validate:"required"`
 	Memo      *string `json:"memo"`
+
+	// Tags 资源创建后设置的标签，需要满足账号和业务的标签策略
+	Tags []coreresourcetag.Tag `json:"tags" validate:"omitempty,dive"`
 }
 
 // Validate ...
@@ -95,7 +113,11 @@ func (req *GcpDiskCreateReq) Validate() error {
 		return errors.New("disk count should <= 100")
 	}
 
-	return validator.Validate.Struct(req)
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return coreresourcetag.ValidateTags(req.Tags)
 }
 
 // AzureDiskCreateReq ...
@@ -110,6 +132,9 @@ type AzureDiskCreateReq struct {
 	DiskSize          int32   `json:"disk_size" validate:"required"`
 	DiskCount         int32   `json:"disk_count" validate:"required"`
 	Memo              *string `json:"memo"`
+
+	// Tags 资源创建后设置的标签，需要满足账号和业务的标签策略
+	Tags []coreresourcetag.Tag `json:"tags" validate:"omitempty,dive"`
 }
 
 // Validate ...
@@ -118,6 +143,10 @@ func (req *AzureDiskCreateReq) Validate() error {
 		return err
 	}
 
+	if err := coreresourcetag.ValidateTags(req.Tags); err != nil {
+		return err
+	}
+
 	if req.DiskCount > requiredCountMaxLimit {
 		return errors.New("disk count should <= 100")
 	}
@@ -145,6 +174,9 @@ type AwsDiskCreateReq struct {
 	DiskSize  int32   `json:"disk_size" validate:"required"`
 	DiskCount int32   `json:"disk_count" validate:"required"`
 	Memo      *string `json:"memo"`
+
+	// Tags 资源创建后设置的标签，需要满足账号和业务的标签策略
+	Tags []coreresourcetag.Tag `json:"tags" validate:"omitempty,dive"`
 }
 
 // Validate ...
@@ -153,5 +185,9 @@ func (req *AwsDiskCreateReq) Validate() error {
 		return errors.New("disk count should <= 100")
 	}
 
-	return validator.Validate.Struct(req)
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return coreresourcetag.ValidateTags(req.Tags)
 }
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- List --------------------------
+
+// CostAnomalyListReq define cost anomaly list req.
+type CostAnomalyListReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate cost anomaly list req.
+func (req *CostAnomalyListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// CostAnomalyUpdateReq define cost anomaly update req.
+type CostAnomalyUpdateReq struct {
+	Status enumor.CostAnomalyStatus `json:"status" validate:"omitempty"`
+	Memo   *string                  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate cost anomaly update req.
+func (req *CostAnomalyUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Status) == 0 && req.Memo == nil {
+		return errors.New("status or memo is required")
+	}
+
+	if len(req.Status) != 0 {
+		if err := req.Status.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bizassignrule defines the cloud-server api types of biz assign rule.
+package bizassignrule
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core"
+	corebizassignrule "hcm/pkg/api/core/biz-assign-rule"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// BizAssignRuleCreateReq define biz assign rule create req.
+type BizAssignRuleCreateReq struct {
+	// Name 规则名称，账号下唯一
+	Name      string                     `json:"name" validate:"required,max=64"`
+	AccountID string                     `json:"account_id" validate:"required,max=64"`
+	ResTypes  []enumor.CloudResourceType `json:"res_types" validate:"required"`
+	// RuleType 规则类型，tag: 按标签匹配，vpc: 按所属VPC匹配
+	RuleType enumor.BizAssignRuleType `json:"rule_type" validate:"required"`
+	TagKey   string                   `json:"tag_key" validate:"omitempty,max=255"`
+	TagValue string                   `json:"tag_value" validate:"omitempty,max=255"`
+	VpcID    string                   `json:"vpc_id" validate:"omitempty,max=64"`
+	BkBizID  int64                    `json:"bk_biz_id" validate:"required,min=1"`
+	// Priority 优先级，数值越小越先执行，默认为0
+	Priority uint `json:"priority" validate:"omitempty"`
+	// Enabled 是否启用，默认启用
+	Enabled *bool   `json:"enabled" validate:"omitempty"`
+	Memo    *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate biz assign rule create req.
+func (req *BizAssignRuleCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := corebizassignrule.ValidateRuleResTypes(req.RuleType, req.ResTypes); err != nil {
+		return err
+	}
+
+	return corebizassignrule.ValidateRuleMatch(req.RuleType, req.TagKey, req.VpcID)
+}
+
+// BizAssignRuleUpdateReq define biz assign rule update req, rule type and its match key can not be updated.
+type BizAssignRuleUpdateReq struct {
+	Name     string                     `json:"name" validate:"omitempty,max=64"`
+	ResTypes []enumor.CloudResourceType `json:"res_types" validate:"omitempty"`
+	TagValue *string                    `json:"tag_value" validate:"omitempty,max=255"`
+	BkBizID  int64                      `json:"bk_biz_id" validate:"omitempty,min=1"`
+	Priority *uint                      `json:"priority" validate:"omitempty"`
+	Enabled  *bool                      `json:"enabled" validate:"omitempty"`
+	Memo     *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate biz assign rule update req.
+func (req *BizAssignRuleUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && len(req.ResTypes) == 0 && req.TagValue == nil && req.BkBizID == 0 &&
+		req.Priority == nil && req.Enabled == nil && req.Memo == nil {
+		return errors.New("at least one of name, res_types, tag_value, bk_biz_id, priority, enabled and memo " +
+			"should be set")
+	}
+
+	return nil
+}
+
+// BizAssignRuleListReq define biz assign rule list req, only the rules of one account can be listed.
+type BizAssignRuleListReq struct {
+	AccountID string             `json:"account_id" validate:"required,max=64"`
+	Filter    *filter.Expression `json:"filter" validate:"omitempty"`
+	Page      *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate biz assign rule list req.
+func (req *BizAssignRuleListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// BizAssignRuleListResult define biz assign rule list result.
+type BizAssignRuleListResult struct {
+	Count   uint64                            `json:"count"`
+	Details []corebizassignrule.BizAssignRule `json:"details"`
+}
+
+// BizAssignRuleApplyReq define biz assign rule apply or preview req, all the enabled rules of the account are
+// applied if the rule ids are not set.
+type BizAssignRuleApplyReq struct {
+	AccountID string   `json:"account_id" validate:"required,max=64"`
+	RuleIDs   []string `json:"rule_ids" validate:"omitempty,max=100"`
+}
+
+// Validate biz assign rule apply req.
+func (req *BizAssignRuleApplyReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
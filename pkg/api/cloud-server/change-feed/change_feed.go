@@ -0,0 +1,51 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package changefeed defines the cloud-server api types of resource change feed.
+package changefeed
+
+import (
+	changefeed "hcm/pkg/api/core/change-feed"
+	"hcm/pkg/criteria/validator"
+)
+
+// DefaultWatchLimit is the default count of the changes returned by the watch api at a time.
+const DefaultWatchLimit = 100
+
+// WatchReq define change feed watch req.
+type WatchReq struct {
+	// Cursor 变更游标，返回提交序号大于该游标的变更，为空时返回最新的游标，用于从当前开始订阅变更
+	Cursor *uint64 `json:"cursor" validate:"omitempty"`
+	// Limit 返回的最大变更数量，默认100，最大500
+	Limit uint `json:"limit" validate:"omitempty,max=500"`
+	// WaitSec 没有新变更时的等待时间，等待期间有新变更时立即返回，为0时不等待
+	WaitSec uint `json:"wait_sec" validate:"omitempty"`
+}
+
+// Validate change feed watch req.
+func (req *WatchReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// WatchResult define change feed watch result.
+type WatchResult struct {
+	// Cursor 下一次订阅使用的游标
+	Cursor  uint64                  `json:"cursor"`
+	Details []changefeed.ChangeFeed `json:"details"`
+}
@@ -0,0 +1,192 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event defines the cloud-server api types of resource lifecycle events and their webhook subscriptions.
+package event
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/event"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Event --------------------------
+
+// EventListReq define event list req, events can be pulled from a cursor by filtering the seq greater than the
+// cursor and sorting by seq in ascending order.
+type EventListReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate event list req.
+func (req *EventListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Subscription --------------------------
+
+// SubscriptionCreateReq define event subscription create req.
+type SubscriptionCreateReq struct {
+	Name string `json:"name" validate:"required,max=64"`
+	// Endpoint 接收事件的回调地址，只支持 http 及 https 协议
+	Endpoint string `json:"endpoint" validate:"required,max=255"`
+	// Secret 签名密钥，为空时自动生成，只在创建时返回
+	Secret string                    `json:"secret" validate:"omitempty,min=16,max=128"`
+	Filter *event.SubscriptionFilter `json:"filter" validate:"required"`
+	// Enabled 是否启用，默认启用
+	Enabled *bool `json:"enabled" validate:"omitempty"`
+	// LastEventID 订阅的起始游标，投递序号大于该游标的事件，为空时从最新的事件开始订阅
+	LastEventID *uint64 `json:"last_event_id" validate:"omitempty"`
+	Memo        *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate event subscription create req.
+func (req *SubscriptionCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := validateEndpoint(req.Endpoint); err != nil {
+		return err
+	}
+
+	return req.Filter.Validate()
+}
+
+// validateEndpoint validate the webhook endpoint of the event subscription.
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("endpoint %s is invalid, err: %v", endpoint, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return fmt.Errorf("endpoint %s should be a http or https url", endpoint)
+	}
+
+	return nil
+}
+
+// SubscriptionCreateResult define event subscription create result, secret is only returned once when created.
+type SubscriptionCreateResult struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+}
+
+// SubscriptionUpdateReq define event subscription update req.
+type SubscriptionUpdateReq struct {
+	Endpoint string `json:"endpoint" validate:"omitempty,max=255"`
+	// Secret 新的签名密钥，用于轮换密钥
+	Secret  string                    `json:"secret" validate:"omitempty,min=16,max=128"`
+	Filter  *event.SubscriptionFilter `json:"filter" validate:"omitempty"`
+	Enabled *bool                     `json:"enabled" validate:"omitempty"`
+	Memo    *string                   `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate event subscription update req.
+func (req *SubscriptionUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Endpoint) == 0 && len(req.Secret) == 0 && req.Filter == nil && req.Enabled == nil && req.Memo == nil {
+		return errors.New("at least one of endpoint, secret, filter, enabled and memo should be set")
+	}
+
+	if len(req.Endpoint) != 0 {
+		if err := validateEndpoint(req.Endpoint); err != nil {
+			return err
+		}
+	}
+
+	if req.Filter != nil {
+		return req.Filter.Validate()
+	}
+
+	return nil
+}
+
+// SubscriptionListReq define event subscription list req.
+type SubscriptionListReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate event subscription list req.
+func (req *SubscriptionListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// SubscriptionListResult define event subscription list result.
+type SubscriptionListResult struct {
+	Count   uint64               `json:"count"`
+	Details []event.Subscription `json:"details"`
+}
+
+// SubscriptionReplayReq define event subscription replay req, the cursor of the subscription is reset, and the
+// matched events whose seq is greater than the cursor are delivered again.
+type SubscriptionReplayReq struct {
+	LastEventID *uint64 `json:"last_event_id" validate:"required"`
+}
+
+// Validate event subscription replay req.
+func (req *SubscriptionReplayReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Dead Letter --------------------------
+
+// DeadLetterListReq define event dead letter list req.
+type DeadLetterListReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate event dead letter list req.
+func (req *DeadLetterListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DeadLetterRedeliverReq define event dead letter redeliver req.
+type DeadLetterRedeliverReq struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate event dead letter redeliver req.
+func (req *DeadLetterRedeliverReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DeadLetterRedeliverResult define event dead letter redeliver result, the redelivered dead letters are removed.
+type DeadLetterRedeliverResult struct {
+	Succeeded []string                  `json:"succeeded"`
+	Failed    []DeadLetterRedeliverFail `json:"failed"`
+}
+
+// DeadLetterRedeliverFail define the dead letter failed to redeliver.
+type DeadLetterRedeliverFail struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
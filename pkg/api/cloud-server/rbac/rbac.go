@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac defines the cloud-server api types of the built-in rbac role management.
+package rbac
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Role --------------------------
+
+// RoleCreateReq define rbac role create req.
+type RoleCreateReq struct {
+	Name    string   `json:"name" validate:"required,max=64"`
+	Actions []string `json:"actions" validate:"required,min=1"`
+	Memo    *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate rbac role create req.
+func (req *RoleCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RoleUpdateReq define rbac role update req.
+type RoleUpdateReq struct {
+	Name    string   `json:"name" validate:"omitempty,max=64"`
+	Actions []string `json:"actions" validate:"omitempty"`
+	Memo    *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate rbac role update req.
+func (req *RoleUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && len(req.Actions) == 0 && req.Memo == nil {
+		return errors.New("at least one of name, actions and memo should be set")
+	}
+
+	return nil
+}
+
+// RoleListReq define rbac role list req.
+type RoleListReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate rbac role list req.
+func (req *RoleListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Role Binding --------------------------
+
+// RoleBindingCreateReq define rbac role binding create req, bind the role to the subjects on the scope.
+type RoleBindingCreateReq struct {
+	RoleID    string               `json:"role_id" validate:"required"`
+	Subjects  []string             `json:"subjects" validate:"required,min=1,max=100"`
+	ScopeType enumor.RbacScopeType `json:"scope_type" validate:"required"`
+	ScopeIDs  []string             `json:"scope_ids" validate:"omitempty,max=100"`
+}
+
+// Validate rbac role binding create req.
+func (req *RoleBindingCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.ScopeType.Validate(); err != nil {
+		return err
+	}
+
+	if req.ScopeType != enumor.AllRbacScope && len(req.ScopeIDs) == 0 {
+		return errors.New("scope_ids is required when scope_type is not all")
+	}
+
+	return nil
+}
+
+// RoleBindingListReq define rbac role binding list req.
+type RoleBindingListReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate rbac role binding list req.
+func (req *RoleBindingListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines the cloud-server api types of resource tag.
+package resourcetag
+
+import (
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
+	"hcm/pkg/criteria/validator"
+)
+
+// ResourceTagUpdateReq defines update resource tag request, all the tags of the resource are replaced by the tags.
+type ResourceTagUpdateReq struct {
+	Tags []coreresourcetag.Tag `json:"tags" validate:"omitempty,dive"`
+}
+
+// Validate ResourceTagUpdateReq.
+func (req *ResourceTagUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return coreresourcetag.ValidateTags(req.Tags)
+}
+
+// ResourceTagListResult defines list resource tag result.
+type ResourceTagListResult struct {
+	Details []coreresourcetag.Tag `json:"details"`
+}
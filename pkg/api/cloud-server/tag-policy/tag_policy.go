@@ -0,0 +1,139 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy defines the cloud-server api types of tag policy.
+package tagpolicy
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core"
+	coretagpolicy "hcm/pkg/api/core/tag-policy"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// TagPolicyCreateReq define tag policy create req.
+type TagPolicyCreateReq struct {
+	Name string `json:"name" validate:"required,max=64"`
+	// AccountID 和 BkBizID 二选一，策略作用于账号下或业务下的资源
+	AccountID string                     `json:"account_id" validate:"omitempty,max=64"`
+	BkBizID   int64                      `json:"bk_biz_id" validate:"omitempty,min=1"`
+	ResTypes  []enumor.CloudResourceType `json:"res_types" validate:"required"`
+	Rules     []coretagpolicy.TagRule    `json:"rules" validate:"required"`
+	// AutoRemediate 是否自动为缺失的必填标签设置默认值，默认不修复
+	AutoRemediate *bool `json:"auto_remediate" validate:"omitempty"`
+	// Enabled 是否启用，默认启用
+	Enabled *bool   `json:"enabled" validate:"omitempty"`
+	Memo    *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate tag policy create req.
+func (req *TagPolicyCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := coretagpolicy.ValidateScope(req.AccountID, req.BkBizID); err != nil {
+		return err
+	}
+
+	if err := coretagpolicy.ValidateResTypes(req.ResTypes); err != nil {
+		return err
+	}
+
+	return coretagpolicy.ValidateRules(req.Rules)
+}
+
+// TagPolicyUpdateReq define tag policy update req, the scope of the policy can not be updated.
+type TagPolicyUpdateReq struct {
+	Name          string                     `json:"name" validate:"omitempty,max=64"`
+	ResTypes      []enumor.CloudResourceType `json:"res_types" validate:"omitempty"`
+	Rules         []coretagpolicy.TagRule    `json:"rules" validate:"omitempty"`
+	AutoRemediate *bool                      `json:"auto_remediate" validate:"omitempty"`
+	Enabled       *bool                      `json:"enabled" validate:"omitempty"`
+	Memo          *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate tag policy update req.
+func (req *TagPolicyUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && len(req.ResTypes) == 0 && len(req.Rules) == 0 && req.AutoRemediate == nil &&
+		req.Enabled == nil && req.Memo == nil {
+		return errors.New("at least one of name, res_types, rules, auto_remediate, enabled and memo should be set")
+	}
+
+	if len(req.ResTypes) != 0 {
+		if err := coretagpolicy.ValidateResTypes(req.ResTypes); err != nil {
+			return err
+		}
+	}
+
+	if len(req.Rules) != 0 {
+		if err := coretagpolicy.ValidateRules(req.Rules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ScopeListReq define the list req of tag policy and its violations, only the data of one account or one biz can be
+// listed.
+type ScopeListReq struct {
+	AccountID string             `json:"account_id" validate:"omitempty,max=64"`
+	BkBizID   int64              `json:"bk_biz_id" validate:"omitempty,min=1"`
+	Filter    *filter.Expression `json:"filter" validate:"omitempty"`
+	Page      *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate scope list req.
+func (req *ScopeListReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return coretagpolicy.ValidateScope(req.AccountID, req.BkBizID)
+}
+
+// TagPolicyListResult define tag policy list result.
+type TagPolicyListResult struct {
+	Count   uint64                    `json:"count"`
+	Details []coretagpolicy.TagPolicy `json:"details"`
+}
+
+// ViolationListResult define tag policy violation list result.
+type ViolationListResult struct {
+	Count   uint64                             `json:"count"`
+	Details []coretagpolicy.TagPolicyViolation `json:"details"`
+}
+
+// TagPolicyEvaluateReq define tag policy evaluate req.
+type TagPolicyEvaluateReq struct {
+	AccountID string `json:"account_id" validate:"required,max=64"`
+}
+
+// Validate tag policy evaluate req.
+func (req *TagPolicyEvaluateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package token defines the cloud-server api types of hcm issued api tokens and service accounts.
+package token
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Api Token --------------------------
+
+// ApiTokenCreateReq define api token create req.
+type ApiTokenCreateReq struct {
+	Name string `json:"name" validate:"required,max=64"`
+	// Scopes is the api path prefixes that the api token can access, e.g. /api/v1/cloud/vpcs, "*" means all apis.
+	Scopes []string `json:"scopes" validate:"required,min=1,max=20,dive,required,max=255"`
+	// ExpiredAt 过期时间，格式为 RFC3339，为空表示永不过期
+	ExpiredAt string  `json:"expired_at" validate:"omitempty"`
+	Memo      *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate api token create req.
+func (req *ApiTokenCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, scope := range req.Scopes {
+		if scope != "*" && !strings.HasPrefix(scope, "/api/v1/") {
+			return fmt.Errorf("scope %s should be * or api path prefix starts with /api/v1/", scope)
+		}
+	}
+
+	if len(req.ExpiredAt) == 0 {
+		return nil
+	}
+
+	expiredAt, err := time.Parse(constant.TimeStdFormat, req.ExpiredAt)
+	if err != nil {
+		return fmt.Errorf("expired_at %s is invalid, err: %v", req.ExpiredAt, err)
+	}
+
+	if expiredAt.Before(time.Now()) {
+		return errors.New("expired_at should be later than now")
+	}
+
+	return nil
+}
+
+// ApiTokenCreateResult define api token create result, token is only returned once when created.
+type ApiTokenCreateResult struct {
+	ID    string `json:"id"`
+	Token string `json:"token"`
+}
+
+// ApiTokenListReq define api token list req.
+type ApiTokenListReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate api token list req.
+func (req *ApiTokenListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Service Account --------------------------
+
+// ServiceAccountCreateReq define service account create req.
+type ServiceAccountCreateReq struct {
+	// Name 服务账号名称，需以 sa- 开头，用于区分服务账号和用户
+	Name     string   `json:"name" validate:"required,max=64"`
+	Managers []string `json:"managers" validate:"required,min=1,max=20"`
+	Memo     *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate service account create req.
+func (req *ServiceAccountCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(req.Name, constant.ServiceAccountPrefix) ||
+		len(req.Name) == len(constant.ServiceAccountPrefix) {
+		return fmt.Errorf("service account name should start with %s", constant.ServiceAccountPrefix)
+	}
+
+	return nil
+}
+
+// ServiceAccountUpdateReq define service account update req.
+type ServiceAccountUpdateReq struct {
+	Managers []string `json:"managers" validate:"omitempty,max=20"`
+	Memo     *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate service account update req.
+func (req *ServiceAccountUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Managers) == 0 && req.Memo == nil {
+		return errors.New("at least one of managers and memo should be set")
+	}
+
+	return nil
+}
+
+// ServiceAccountListReq define service account list req.
+type ServiceAccountListReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate service account list req.
+func (req *ServiceAccountListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
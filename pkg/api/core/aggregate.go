@@ -0,0 +1,167 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package core
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+const (
+	// AggregateMaxGroupBy is the max number of the aggregate group by fields.
+	AggregateMaxGroupBy = 5
+	// AggregateMaxMetrics is the max number of the aggregate metrics.
+	AggregateMaxMetrics = 5
+	// AggregateMaxLimit is the max number of the returned aggregate groups.
+	AggregateMaxLimit = DefaultMaxPageLimit
+)
+
+// AggregateFunc is the aggregate function of the metric.
+type AggregateFunc string
+
+const (
+	// AggregateCount count the resources, field is optional, if set, only count the rows whose field is not null.
+	AggregateCount AggregateFunc = "count"
+	// AggregateSum sum the numeric field.
+	AggregateSum AggregateFunc = "sum"
+	// AggregateMin get the min value of the numeric or time field.
+	AggregateMin AggregateFunc = "min"
+	// AggregateMax get the max value of the numeric or time field.
+	AggregateMax AggregateFunc = "max"
+)
+
+// Validate AggregateFunc.
+func (f AggregateFunc) Validate() error {
+	switch f {
+	case AggregateCount, AggregateSum, AggregateMin, AggregateMax:
+	default:
+		return fmt.Errorf("unsupported aggregate func: %s", f)
+	}
+
+	return nil
+}
+
+// AggregateMetric defines an aggregate metric.
+type AggregateMetric struct {
+	Func  AggregateFunc `json:"func"`
+	Field string        `json:"field,omitempty"`
+}
+
+// Validate AggregateMetric.
+func (m AggregateMetric) Validate() error {
+	if err := m.Func.Validate(); err != nil {
+		return err
+	}
+
+	if m.Func != AggregateCount && len(m.Field) == 0 {
+		return fmt.Errorf("field is required for %s metric", m.Func)
+	}
+
+	return nil
+}
+
+// Key returns the key of the metric in the aggregate result, e.g. count, sum_memory.
+func (m AggregateMetric) Key() string {
+	if len(m.Field) == 0 {
+		return string(m.Func)
+	}
+
+	return string(m.Func) + "_" + m.Field
+}
+
+// AggregateReq is a standard aggregate operation http request.
+type AggregateReq struct {
+	Filter  *filter.Expression `json:"filter"`
+	GroupBy []string           `json:"group_by"`
+	Metrics []AggregateMetric  `json:"metrics"`
+	// Limit is the max number of the returned groups, default and max value is AggregateMaxLimit.
+	Limit uint `json:"limit"`
+}
+
+// Validate AggregateReq.
+func (a *AggregateReq) Validate() error {
+	if a.Filter == nil {
+		return errf.New(errf.InvalidParameter, "filter is required")
+	}
+
+	if len(a.GroupBy) > AggregateMaxGroupBy {
+		return errf.Newf(errf.InvalidParameter, "group_by fields should <= %d", AggregateMaxGroupBy)
+	}
+
+	fields := make(map[string]struct{}, len(a.GroupBy))
+	for _, field := range a.GroupBy {
+		if len(field) == 0 {
+			return errf.New(errf.InvalidParameter, "group_by field is empty")
+		}
+
+		if _, exists := fields[field]; exists {
+			return errf.Newf(errf.InvalidParameter, "group_by field %s is duplicated", field)
+		}
+		fields[field] = struct{}{}
+	}
+
+	if len(a.Metrics) == 0 {
+		return errf.New(errf.InvalidParameter, "metrics are required")
+	}
+
+	if len(a.Metrics) > AggregateMaxMetrics {
+		return errf.Newf(errf.InvalidParameter, "metrics should <= %d", AggregateMaxMetrics)
+	}
+
+	keys := make(map[string]struct{}, len(a.Metrics))
+	for _, metric := range a.Metrics {
+		if err := metric.Validate(); err != nil {
+			return errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		if _, exists := keys[metric.Key()]; exists {
+			return errf.Newf(errf.InvalidParameter, "metric %s is duplicated", metric.Key())
+		}
+		keys[metric.Key()] = struct{}{}
+	}
+
+	if a.Limit > AggregateMaxLimit {
+		return errf.Newf(errf.InvalidParameter, "limit should <= %d", AggregateMaxLimit)
+	}
+
+	return nil
+}
+
+// AggregateResult is a standard aggregate operation result.
+type AggregateResult struct {
+	Details []AggregateDetail `json:"details"`
+}
+
+// AggregateDetail is the aggregate result of a group.
+type AggregateDetail struct {
+	// Group is the group by field and it's value, it is empty if group by fields are not set.
+	Group map[string]interface{} `json:"group"`
+	// Metrics is the metric key and it's value, the key is generated by AggregateMetric.Key.
+	Metrics map[string]interface{} `json:"metrics"`
+}
+
+// AggregateResp is a standard aggregate operation http response.
+type AggregateResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *AggregateResult `json:"data"`
+}
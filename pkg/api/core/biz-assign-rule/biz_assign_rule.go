@@ -0,0 +1,130 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bizassignrule defines the core types of biz assign rule.
+package bizassignrule
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/slice"
+)
+
+// TagRuleResTypes is the resource types that tag rule can be applied to, they are the types that support tags.
+var TagRuleResTypes = []enumor.CloudResourceType{enumor.CvmCloudResType, enumor.VpcCloudResType,
+	enumor.SubnetCloudResType, enumor.DiskCloudResType, enumor.EipCloudResType, enumor.SecurityGroupCloudResType,
+	enumor.RouteTableCloudResType, enumor.NetworkInterfaceCloudResType}
+
+// VpcRuleResTypes is the resource types that vpc rule can be applied to, they are the vpc and the types that
+// belong to vpc.
+var VpcRuleResTypes = []enumor.CloudResourceType{enumor.VpcCloudResType, enumor.SubnetCloudResType,
+	enumor.CvmCloudResType, enumor.RouteTableCloudResType, enumor.NetworkInterfaceCloudResType}
+
+// BizAssignRule 业务自动分配规则，账号下未分配业务的资源匹配规则后自动分配到规则的业务
+type BizAssignRule struct {
+	ID            string                     `json:"id"`
+	Name          string                     `json:"name"`
+	AccountID     string                     `json:"account_id"`
+	ResTypes      []enumor.CloudResourceType `json:"res_types"`
+	RuleType      enumor.BizAssignRuleType   `json:"rule_type"`
+	TagKey        string                     `json:"tag_key"`
+	TagValue      string                     `json:"tag_value"`
+	VpcID         string                     `json:"vpc_id"`
+	BkBizID       int64                      `json:"bk_biz_id"`
+	Priority      uint                       `json:"priority"`
+	Enabled       bool                       `json:"enabled"`
+	Memo          *string                    `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// ValidateRuleResTypes validate if the resource types can be applied by the rule type.
+func ValidateRuleResTypes(ruleType enumor.BizAssignRuleType, resTypes []enumor.CloudResourceType) error {
+	if err := ruleType.Validate(); err != nil {
+		return err
+	}
+
+	if len(resTypes) == 0 {
+		return errors.New("res_types is required")
+	}
+
+	supported := TagRuleResTypes
+	if ruleType == enumor.VpcBizAssignRule {
+		supported = VpcRuleResTypes
+	}
+
+	exists := make(map[enumor.CloudResourceType]struct{}, len(resTypes))
+	for _, resType := range resTypes {
+		if _, duplicated := exists[resType]; duplicated {
+			return fmt.Errorf("res type %s is duplicated", resType)
+		}
+		exists[resType] = struct{}{}
+
+		if !slice.IsItemInSlice(supported, resType) {
+			return fmt.Errorf("%s rule does not support res type %s", ruleType, resType)
+		}
+	}
+
+	return nil
+}
+
+// ValidateRuleMatch validate the match condition of the rule type, tag rule matches by tag key and value, vpc rule
+// matches by vpc id.
+func ValidateRuleMatch(ruleType enumor.BizAssignRuleType, tagKey, vpcID string) error {
+	switch ruleType {
+	case enumor.TagBizAssignRule:
+		if len(tagKey) == 0 {
+			return errors.New("tag_key is required for tag rule")
+		}
+
+		if len(vpcID) != 0 {
+			return errors.New("vpc_id should be empty for tag rule")
+		}
+	case enumor.VpcBizAssignRule:
+		if len(vpcID) == 0 {
+			return errors.New("vpc_id is required for vpc rule")
+		}
+
+		if len(tagKey) != 0 {
+			return errors.New("tag_key should be empty for vpc rule")
+		}
+	default:
+		return fmt.Errorf("unsupported biz assign rule type: %s", ruleType)
+	}
+
+	return nil
+}
+
+// ApplyResult 业务自动分配规则的执行结果
+type ApplyResult struct {
+	// DryRun 是否为预览，预览时不会分配资源
+	DryRun  bool          `json:"dry_run"`
+	Details []ApplyDetail `json:"details"`
+}
+
+// ApplyDetail 单个规则分配的一类资源，资源只会被第一个匹配的规则分配
+type ApplyDetail struct {
+	RuleID   string                   `json:"rule_id"`
+	RuleName string                   `json:"rule_name"`
+	ResType  enumor.CloudResourceType `json:"res_type"`
+	BkBizID  int64                    `json:"bk_biz_id"`
+	ResIDs   []string                 `json:"res_ids"`
+}
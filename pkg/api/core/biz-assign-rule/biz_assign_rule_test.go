@@ -0,0 +1,75 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bizassignrule
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestValidateRuleResTypes(t *testing.T) {
+	cases := []struct {
+		ruleType enumor.BizAssignRuleType
+		resTypes []enumor.CloudResourceType
+		valid    bool
+	}{
+		{ruleType: enumor.TagBizAssignRule, resTypes: []enumor.CloudResourceType{enumor.CvmCloudResType,
+			enumor.DiskCloudResType, enumor.EipCloudResType}, valid: true},
+		{ruleType: enumor.VpcBizAssignRule, resTypes: []enumor.CloudResourceType{enumor.VpcCloudResType,
+			enumor.SubnetCloudResType, enumor.CvmCloudResType}, valid: true},
+		{ruleType: enumor.VpcBizAssignRule, resTypes: []enumor.CloudResourceType{enumor.DiskCloudResType},
+			valid: false},
+		{ruleType: enumor.TagBizAssignRule, resTypes: []enumor.CloudResourceType{enumor.GcpFirewallRuleCloudResType},
+			valid: false},
+		{ruleType: enumor.TagBizAssignRule, resTypes: []enumor.CloudResourceType{enumor.CvmCloudResType,
+			enumor.CvmCloudResType}, valid: false},
+		{ruleType: enumor.TagBizAssignRule, resTypes: nil, valid: false},
+		{ruleType: "unknown", resTypes: []enumor.CloudResourceType{enumor.CvmCloudResType}, valid: false},
+	}
+
+	for idx, c := range cases {
+		if err := ValidateRuleResTypes(c.ruleType, c.resTypes); (err == nil) != c.valid {
+			t.Errorf("case %d, expect valid: %v, but got err: %v", idx, c.valid, err)
+		}
+	}
+}
+
+func TestValidateRuleMatch(t *testing.T) {
+	cases := []struct {
+		ruleType enumor.BizAssignRuleType
+		tagKey   string
+		vpcID    string
+		valid    bool
+	}{
+		{ruleType: enumor.TagBizAssignRule, tagKey: "bk_biz", valid: true},
+		{ruleType: enumor.TagBizAssignRule, valid: false},
+		{ruleType: enumor.TagBizAssignRule, tagKey: "bk_biz", vpcID: "00000001", valid: false},
+		{ruleType: enumor.VpcBizAssignRule, vpcID: "00000001", valid: true},
+		{ruleType: enumor.VpcBizAssignRule, valid: false},
+		{ruleType: enumor.VpcBizAssignRule, tagKey: "bk_biz", vpcID: "00000001", valid: false},
+	}
+
+	for idx, c := range cases {
+		if err := ValidateRuleMatch(c.ruleType, c.tagKey, c.vpcID); (err == nil) != c.valid {
+			t.Errorf("case %d, expect valid: %v, but got err: %v", idx, c.valid, err)
+		}
+	}
+}
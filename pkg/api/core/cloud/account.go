@@ -67,6 +67,12 @@ type AwsAccountExtension struct {
 	CloudIamUsername string `json:"cloud_iam_username"`
 	CloudSecretID    string `json:"cloud_secret_id"`
 	CloudSecretKey   string `json:"cloud_secret_key,omitempty"`
+	// CloudCredentialType 访问云账号的凭证类型，为空时等同于 secret_key
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type,omitempty"`
+	// CloudRoleArn 凭证类型为 assume_role 时，平台可信身份所扮演的角色
+	CloudRoleArn string `json:"cloud_role_arn,omitempty"`
+	// CloudExternalID 凭证类型为 assume_role 时，角色信任策略要求的外部ID
+	CloudExternalID string `json:"cloud_external_id,omitempty"`
 }
 
 // DecryptSecretKey ...
@@ -113,7 +119,11 @@ type GcpAccountExtension struct {
 	CloudServiceAccountID   string `json:"cloud_service_account_id"`
 	CloudServiceAccountName string `json:"cloud_service_account_name"`
 	CloudServiceSecretID    string `json:"cloud_service_secret_id"`
-	CloudServiceSecretKey   string `json:"cloud_service_secret_key,omitempty"`
+	// CloudServiceSecretKey 凭证类型为 workload_identity 时，存放的是 external_account 或
+	// impersonated_service_account 类型的凭证配置
+	CloudServiceSecretKey string `json:"cloud_service_secret_key,omitempty"`
+	// CloudCredentialType 访问云账号的凭证类型，为空时等同于 secret_key
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type,omitempty"`
 }
 
 // DecryptSecretKey ...
@@ -138,6 +148,8 @@ type AzureAccountExtension struct {
 	CloudApplicationName  string `json:"cloud_application_name"`
 	CloudClientSecretID   string `json:"cloud_client_secret_id"`
 	CloudClientSecretKey  string `json:"cloud_client_secret_key,omitempty"`
+	// CloudCredentialType 访问云账号的凭证类型，为空时等同于 secret_key
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type,omitempty"`
 }
 
 // DecryptSecretKey ...
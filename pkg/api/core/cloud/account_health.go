@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// AccountHealth 云账号健康状态
+type AccountHealth struct {
+	ID                  string                     `json:"id"`
+	Vendor              enumor.Vendor              `json:"vendor"`
+	AccountID           string                     `json:"account_id"`
+	Status              enumor.AccountHealthStatus `json:"status"`
+	LastCheckAt         string                     `json:"last_check_at"`
+	LastSuccessAt       string                     `json:"last_success_at"`
+	LastErrorAt         string                     `json:"last_error_at"`
+	LastError           string                     `json:"last_error"`
+	ConsecutiveFailures uint64                     `json:"consecutive_failures"`
+	MissingPermissions  []AccountMissingPermission `json:"missing_permissions"`
+	core.Revision       `json:",inline"`
+}
+
+// AccountMissingPermission defines the cloud api permission which is required to sync the resource but not granted.
+type AccountMissingPermission struct {
+	ResType enumor.CloudResourceType `json:"res_type"`
+	Action  string                   `json:"action"`
+	Reason  string                   `json:"reason"`
+}
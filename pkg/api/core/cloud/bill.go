@@ -64,3 +64,32 @@ type AwsBillConfigExtension struct {
 // GcpBillConfigExtension define gcp bill config extension.
 type GcpBillConfigExtension struct {
 }
+
+// CostAnomaly defines cost anomaly info.
+type CostAnomaly struct {
+	ID             string                      `json:"id"`
+	Vendor         enumor.Vendor               `json:"vendor"`
+	AccountID      string                      `json:"account_id"`
+	Dimension      enumor.CostAnomalyDimension `json:"dimension"`
+	DimensionValue string                      `json:"dimension_value"`
+	BillDate       string                      `json:"bill_date"`
+	Currency       string                      `json:"currency"`
+	ActualCost     float64                     `json:"actual_cost"`
+	ExpectedCost   float64                     `json:"expected_cost"`
+	Ratio          float64                     `json:"ratio"`
+	Score          float64                     `json:"score"`
+	TopItems       []CostAnomalyItem           `json:"top_items"`
+	Status         enumor.CostAnomalyStatus    `json:"status"`
+	Memo           *string                     `json:"memo"`
+	*core.Revision `json:",inline"`
+}
+
+// CostAnomalyItem defines the line items which contribute most to the cost anomaly.
+type CostAnomalyItem struct {
+	Service    string  `json:"service"`
+	Region     string  `json:"region"`
+	ResourceID string  `json:"resource_id"`
+	UsageType  string  `json:"usage_type"`
+	Operation  string  `json:"operation"`
+	Cost       float64 `json:"cost"`
+}
@@ -0,0 +1,180 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event defines the core types of resource lifecycle events and their webhook subscriptions.
+package event
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/slice"
+)
+
+// ApplicationResType is the resource type of application events, the action of which is the application status,
+// e.g. application.pass.
+const ApplicationResType = "application"
+
+// Event 资源生命周期事件，类型为 {res_type}.{action}，如 cvm.create、security_group.update、application.pass，
+// Seq按事件的提交顺序单调递增，作为事件订阅的游标
+type Event struct {
+	ID         uint64          `json:"id"`
+	Seq        uint64          `json:"seq"`
+	Type       string          `json:"type"`
+	ResType    string          `json:"res_type"`
+	Action     string          `json:"action"`
+	ResID      string          `json:"res_id"`
+	CloudResID string          `json:"cloud_res_id"`
+	ResName    string          `json:"res_name"`
+	BkBizID    int64           `json:"bk_biz_id"`
+	Vendor     enumor.Vendor   `json:"vendor"`
+	AccountID  string          `json:"account_id"`
+	Operator   string          `json:"operator"`
+	Rid        string          `json:"rid"`
+	AppCode    string          `json:"app_code"`
+	Detail     json.RawMessage `json:"detail,omitempty"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+// maxFilterItems is the max count of each item of the subscription filter.
+const maxFilterItems = 100
+
+// SubscriptionFilter 事件订阅的过滤条件，各条件之间为与关系，条件内为或关系，条件为空表示不过滤
+type SubscriptionFilter struct {
+	// EventTypes 事件类型，支持通配符，如 cvm.*、*.delete
+	EventTypes []string        `json:"event_types"`
+	Vendors    []enumor.Vendor `json:"vendors"`
+	AccountIDs []string        `json:"account_ids"`
+	BkBizIDs   []int64         `json:"bk_biz_ids"`
+}
+
+// Validate SubscriptionFilter.
+func (f *SubscriptionFilter) Validate() error {
+	if f == nil {
+		return errors.New("filter is required")
+	}
+
+	if len(f.EventTypes) > maxFilterItems || len(f.Vendors) > maxFilterItems ||
+		len(f.AccountIDs) > maxFilterItems || len(f.BkBizIDs) > maxFilterItems {
+		return fmt.Errorf("filter items should <= %d", maxFilterItems)
+	}
+
+	for _, eventType := range f.EventTypes {
+		if len(eventType) == 0 {
+			return errors.New("event type can not be empty")
+		}
+
+		if _, err := path.Match(eventType, ""); err != nil {
+			return fmt.Errorf("invalid event type pattern %s, err: %v", eventType, err)
+		}
+	}
+
+	for _, vendor := range f.Vendors {
+		if err := vendor.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Match returns if the event matches the filter.
+func (f *SubscriptionFilter) Match(e *Event) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.EventTypes) != 0 {
+		matched := false
+		for _, pattern := range f.EventTypes {
+			if ok, _ := path.Match(pattern, e.Type); ok {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.Vendors) != 0 && !slice.IsItemInSlice(f.Vendors, e.Vendor) {
+		return false
+	}
+
+	if len(f.AccountIDs) != 0 && !slice.IsItemInSlice(f.AccountIDs, e.AccountID) {
+		return false
+	}
+
+	if len(f.BkBizIDs) != 0 && !slice.IsItemInSlice(f.BkBizIDs, e.BkBizID) {
+		return false
+	}
+
+	return true
+}
+
+// Subscription 事件的webhook订阅，签名密钥只在创建时设置，不会返回
+type Subscription struct {
+	ID       string              `json:"id"`
+	Name     string              `json:"name"`
+	Endpoint string              `json:"endpoint"`
+	Filter   *SubscriptionFilter `json:"filter"`
+	Enabled  bool                `json:"enabled"`
+	// LastEventID 已投递的最后一个事件的序号，即订阅的游标
+	LastEventID   uint64  `json:"last_event_id"`
+	Memo          *string `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// DeadLetter 重试多次后仍投递失败的事件
+type DeadLetter struct {
+	ID             string `json:"id"`
+	SubscriptionID string `json:"subscription_id"`
+	EventID        uint64 `json:"event_id"`
+	EventType      string `json:"event_type"`
+	Attempts       uint   `json:"attempts"`
+	LastError      string `json:"last_error"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// signaturePrefix is the prefix of the event signature which indicates the signature algorithm.
+const signaturePrefix = "sha256="
+
+// Sign returns the signature of the delivered event body, which is sent by the X-Bkhcm-Event-Signature header with
+// the signed timestamp in the X-Bkhcm-Event-Timestamp header. receivers should verify the signature with the secret
+// of the subscription, and reject the events with stale timestamp to prevent replay attacks.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature verify the signature of the delivered event body.
+func VerifySignature(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestSubscriptionFilterMatch(t *testing.T) {
+	e := &Event{Type: "cvm.delete", ResType: "cvm", Action: "delete", Vendor: enumor.TCloud, AccountID: "0000001",
+		BkBizID: 100}
+
+	cases := []struct {
+		filter  *SubscriptionFilter
+		matched bool
+	}{
+		{filter: nil, matched: true},
+		{filter: &SubscriptionFilter{}, matched: true},
+		{filter: &SubscriptionFilter{EventTypes: []string{"cvm.delete"}}, matched: true},
+		{filter: &SubscriptionFilter{EventTypes: []string{"cvm.*"}}, matched: true},
+		{filter: &SubscriptionFilter{EventTypes: []string{"*.delete"}}, matched: true},
+		{filter: &SubscriptionFilter{EventTypes: []string{"*"}}, matched: true},
+		{filter: &SubscriptionFilter{EventTypes: []string{"security_group.*", "application.pass"}}, matched: false},
+		{filter: &SubscriptionFilter{EventTypes: []string{"cvm.*"}, Vendors: []enumor.Vendor{enumor.Aws}},
+			matched: false},
+		{filter: &SubscriptionFilter{Vendors: []enumor.Vendor{enumor.Aws, enumor.TCloud},
+			AccountIDs: []string{"0000001"}, BkBizIDs: []int64{100}}, matched: true},
+		{filter: &SubscriptionFilter{BkBizIDs: []int64{200}}, matched: false},
+		{filter: &SubscriptionFilter{AccountIDs: []string{"0000002"}}, matched: false},
+	}
+
+	for idx, c := range cases {
+		if matched := c.filter.Match(e); matched != c.matched {
+			t.Errorf("case %d, expect matched: %v, but got: %v", idx, c.matched, matched)
+		}
+	}
+}
+
+func TestSubscriptionFilterValidate(t *testing.T) {
+	if err := (&SubscriptionFilter{EventTypes: []string{"cvm.*", "*.delete"}}).Validate(); err != nil {
+		t.Errorf("validate valid filter failed, err: %v", err)
+	}
+
+	if err := (&SubscriptionFilter{EventTypes: []string{"cvm.[create"}}).Validate(); err == nil {
+		t.Errorf("invalid event type pattern should be rejected")
+	}
+
+	if err := (&SubscriptionFilter{Vendors: []enumor.Vendor{"unknown"}}).Validate(); err == nil {
+		t.Errorf("invalid vendor should be rejected")
+	}
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":1,"type":"cvm.create"}`)
+	signature := Sign("secret", 1686556800, body)
+
+	if !VerifySignature("secret", 1686556800, body, signature) {
+		t.Errorf("verify signature failed")
+	}
+
+	if VerifySignature("another", 1686556800, body, signature) {
+		t.Errorf("signature with another secret should not be verified")
+	}
+
+	if VerifySignature("secret", 1686556801, body, signature) {
+		t.Errorf("signature with another timestamp should not be verified")
+	}
+
+	if VerifySignature("secret", 1686556800, []byte(`{"id":2,"type":"cvm.create"}`), signature) {
+		t.Errorf("signature of another body should not be verified")
+	}
+}
@@ -20,6 +20,9 @@
 package core
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -72,6 +75,14 @@ var DefaultPageOption = &PageOption{
 	DisabledSort:         false,
 }
 
+// DefaultCursorPageOption is the default BasePage's option of the list that supports keyset pagination.
+var DefaultCursorPageOption = &PageOption{
+	EnableUnlimitedLimit: false,
+	MaxLimit:             DefaultMaxPageLimit,
+	DisabledSort:         false,
+	EnableCursor:         true,
+}
+
 // PageOption defines the options to validate the
 // BasePage's configuration.
 type PageOption struct {
@@ -85,6 +96,9 @@ type PageOption struct {
 	// Note: this option does not work when use the page to generate SQL expression,
 	// which means call the method of BasePage's SQLExprAndValue().
 	DisabledSort bool `json:"disabled_sort"`
+	// EnableCursor allows user to query resources with keyset pagination,
+	// which means the page.cursor can be set.
+	EnableCursor bool `json:"enable_cursor"`
 }
 
 // Order is the direction when do sort operation.
@@ -153,6 +167,15 @@ type BasePage struct {
 	// Order is the direction when do sort operation.
 	// it works only when the Sort is set.
 	Order Order `json:"order"`
+	// Cursor is the opaque keyset cursor of the last resource of the previous page,
+	// the resources after it are queried, which avoids the slow OFFSET query on
+	// large tables. it is generated by NewPageCursor.
+	// Note:
+	// 1. Cursor only works when the Count = false, and Start must be 0.
+	// 2. the resources are sorted by the Sort field and then the 'id' field,
+	//    the Sort field should be a not null indexed field.
+	// 3. Cursor only works when the PageOption.EnableCursor = true.
+	Cursor string `json:"cursor,omitempty"`
 }
 
 // Validate the base page's options.
@@ -185,11 +208,16 @@ func (bp BasePage) Validate(opt ...*PageOption) (err error) {
 			return errors.New("count is enabled, page.order should be empty")
 		}
 
+		if len(bp.Cursor) > 0 {
+			return errors.New("count is enabled, page.cursor should be empty")
+		}
+
 		return nil
 	}
 
 	maxLimit := DefaultMaxPageLimit
 	enableUnlimited := false
+	enableCursor := false
 	if len(opt) != 0 {
 		// option is configured, validate it
 		one := opt[0]
@@ -198,6 +226,7 @@ func (bp BasePage) Validate(opt ...*PageOption) (err error) {
 		}
 
 		enableUnlimited = one.EnableUnlimitedLimit
+		enableCursor = one.EnableCursor
 
 		if one.DisabledSort {
 			if len(bp.Sort) > 0 {
@@ -235,5 +264,82 @@ func (bp BasePage) Validate(opt ...*PageOption) (err error) {
 		}
 	}
 
+	if len(bp.Cursor) != 0 {
+		if !enableCursor {
+			return errors.New("page.cursor is not supported")
+		}
+
+		if bp.Start > 0 {
+			return errors.New("page.cursor is set, page.start should be 0")
+		}
+
+		if bp.Limit == 0 {
+			return errors.New("page.cursor is set, page.limit value should >= 1")
+		}
+
+		if _, err := DecodePageCursor(bp.Cursor); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// PageCursor is the keyset position of a resource in the sorted resources.
+type PageCursor struct {
+	// Sort is the sort field of the page, 'id' if the page is sorted by id.
+	Sort string `json:"sort"`
+	// Value is the Sort field's value of the resource.
+	Value interface{} `json:"value"`
+	// ID is the resource's identity, which is used to break the tie of the same Value.
+	ID string `json:"id"`
+}
+
+// NewPageCursor generate the opaque page cursor of a resource, the next page of the resources
+// sorted by the sort field starts after this resource.
+func NewPageCursor(sort string, value interface{}, id string) (string, error) {
+	if len(sort) == 0 {
+		return "", errors.New("page cursor sort is required")
+	}
+
+	if len(id) == 0 {
+		return "", errors.New("page cursor id is required")
+	}
+
+	raw, err := json.Marshal(PageCursor{Sort: sort, Value: value, ID: id})
+	if err != nil {
+		return "", fmt.Errorf("marshal page cursor failed, err: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// NewIDPageCursor generate the opaque page cursor of a resource for the resources sorted by id,
+// which is the default sort of the resources.
+func NewIDPageCursor(id string) string {
+	// the marshal of the string id never fails, the cursor is empty only when id is empty.
+	cursor, _ := NewPageCursor("id", id, id)
+	return cursor
+}
+
+// DecodePageCursor decode the opaque page cursor, the numeric value is decoded as json.Number
+// to keep its precision.
+func DecodePageCursor(cursor string) (*PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("invalid page.cursor")
+	}
+
+	pc := new(PageCursor)
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err = decoder.Decode(pc); err != nil {
+		return nil, errors.New("invalid page.cursor")
+	}
+
+	if len(pc.Sort) == 0 || len(pc.ID) == 0 || pc.Value == nil {
+		return nil, errors.New("invalid page.cursor")
+	}
+
+	return pc, nil
+}
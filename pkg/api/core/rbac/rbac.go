@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac defines the core types of the built-in rbac authorizer.
+package rbac
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Role 内置权限模型中的角色，包含一组操作
+type Role struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Actions       []string `json:"actions"`
+	Builtin       bool     `json:"builtin"`
+	Memo          *string  `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// RoleBinding 将角色在指定的范围内授予用户
+type RoleBinding struct {
+	ID        string               `json:"id"`
+	RoleID    string               `json:"role_id"`
+	Subject   string               `json:"subject"`
+	ScopeType enumor.RbacScopeType `json:"scope_type"`
+	ScopeIDs  []string             `json:"scope_ids"`
+	Creator   string               `json:"creator"`
+	CreatedAt string               `json:"created_at"`
+}
+
+// Action 权限模型中的操作，由注册到蓝鲸权限中心的操作导出
+type Action struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	NameEn string `json:"name_en"`
+	Type   string `json:"type"`
+	// ResourceType 操作关联的资源类型(account:账号 biz:业务)，为空表示操作不关联资源
+	ResourceType string `json:"resource_type"`
+}
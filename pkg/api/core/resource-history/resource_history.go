@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcehistory defines the core types of resource change history.
+package resourcehistory
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// FieldChange 资源字段变更前后的值，变更前不存在或变更后被删除的值为空
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// ResourceHistory 资源的一次变更记录，Changes为该次变更的字段级差异
+type ResourceHistory struct {
+	ID        uint64                  `json:"id"`
+	ResType   string                  `json:"res_type"`
+	ResID     string                  `json:"res_id"`
+	AccountID string                  `json:"account_id"`
+	Action    enumor.ChangeFeedAction `json:"action"`
+	Operator  string                  `json:"operator"`
+	Rid       string                  `json:"rid"`
+	CreatedAt string                  `json:"created_at"`
+	Changes   map[string]FieldChange  `json:"changes"`
+}
+
+// ResourceState 资源在某一时刻的状态，Exist为false时表示该时刻资源尚未创建或已被删除
+type ResourceState struct {
+	Exist     bool                   `json:"exist"`
+	AccountID string                 `json:"account_id"`
+	State     map[string]interface{} `json:"state"`
+}
+
+// ParseSnapshot parse the json snapshot of the resource, numbers are decoded as json.Number to keep the precision
+// of the big integers. empty snapshot is parsed as nil, which means the resource does not exist.
+func ParseSnapshot(snapshot string) (map[string]interface{}, error) {
+	if len(snapshot) == 0 {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewBufferString(snapshot))
+	decoder.UseNumber()
+
+	state := make(map[string]interface{})
+	if err := decoder.Decode(&state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// Diff returns the changed fields from the before state to the after state, a nil state means the resource does not
+// exist, so all the fields of the other state are changed.
+func Diff(before, after map[string]interface{}) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+
+	for field, beforeValue := range before {
+		afterValue, exists := after[field]
+		if exists && reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+		changes[field] = FieldChange{Before: beforeValue, After: afterValue}
+	}
+
+	for field, afterValue := range after {
+		if _, exists := before[field]; exists {
+			continue
+		}
+		changes[field] = FieldChange{Before: nil, After: afterValue}
+	}
+
+	return changes
+}
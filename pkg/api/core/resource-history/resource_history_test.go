@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resourcehistory
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	before, err := ParseSnapshot(`{"id": "00000001", "bk_biz_id": 1234567890123456789, ` +
+		`"private_ipv4_addresses": ["10.0.0.1"], "extension": {"security_group_ids": ["sg-1"]}}`)
+	if err != nil {
+		t.Errorf("parse before snapshot failed, err: %v", err)
+		return
+	}
+
+	after, err := ParseSnapshot(`{"id": "00000001", "bk_biz_id": 1234567890123456789, ` +
+		`"private_ipv4_addresses": ["10.0.0.2"], "extension": {"security_group_ids": ["sg-1", "sg-2"]}}`)
+	if err != nil {
+		t.Errorf("parse after snapshot failed, err: %v", err)
+		return
+	}
+
+	changes := Diff(before, after)
+	if len(changes) != 2 {
+		t.Errorf("changed fields should be 2, but got %v", changes)
+		return
+	}
+
+	change, exists := changes["private_ipv4_addresses"]
+	if !exists {
+		t.Errorf("private_ipv4_addresses should be changed, but got %v", changes)
+		return
+	}
+
+	js, err := json.Marshal(change)
+	if err != nil {
+		t.Errorf("marshal change failed, err: %v", err)
+		return
+	}
+
+	if string(js) != `{"before":["10.0.0.1"],"after":["10.0.0.2"]}` {
+		t.Errorf("private_ipv4_addresses change is not as expected, got %s", js)
+		return
+	}
+
+	deleted := Diff(after, nil)
+	if len(deleted) != 4 || deleted["bk_biz_id"].Before != json.Number("1234567890123456789") ||
+		deleted["bk_biz_id"].After != nil {
+		t.Errorf("all fields should be changed to nil when deleted, but got %v", deleted)
+		return
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines the core types of resource tag.
+package resourcetag
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// TagMaxCount is the max count of tags a resource can have, it's the strictest limit of all vendors.
+const TagMaxCount = 50
+
+// Tag 资源标签键值对
+type Tag struct {
+	Key   string `json:"key" validate:"required,max=255"`
+	Value string `json:"value" validate:"max=255"`
+}
+
+// ValidateTags validate the tags of a resource, tag keys should be unique.
+func ValidateTags(tags []Tag) error {
+	if len(tags) > TagMaxCount {
+		return fmt.Errorf("tags count should <= %d", TagMaxCount)
+	}
+
+	keys := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		if len(tag.Key) == 0 {
+			return errors.New("tag key can not be empty")
+		}
+
+		if _, exists := keys[tag.Key]; exists {
+			return fmt.Errorf("tag key %s is duplicated", tag.Key)
+		}
+		keys[tag.Key] = struct{}{}
+	}
+
+	return nil
+}
+
+// ResourceTag 资源标签，各云厂商资源的标签统一存储为键值对
+type ResourceTag struct {
+	ID        string        `json:"id"`
+	ResType   string        `json:"res_type"`
+	ResID     string        `json:"res_id"`
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	TagKey    string        `json:"tag_key"`
+	TagValue  string        `json:"tag_value"`
+	Creator   string        `json:"creator"`
+	Reviser   string        `json:"reviser"`
+	CreatedAt string        `json:"created_at"`
+	UpdatedAt string        `json:"updated_at"`
+}
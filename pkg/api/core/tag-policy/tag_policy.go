@@ -0,0 +1,308 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy defines the core types of tag policy, and checks the tags of resources against the policy.
+package tagpolicy
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"hcm/pkg/api/core"
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/slice"
+)
+
+// TagRuleMaxCount is the max count of tag rules of a policy.
+const TagRuleMaxCount = 20
+
+// PolicyResTypes is the resource types that tag policy can be applied to, they are the types that support tags.
+var PolicyResTypes = []enumor.CloudResourceType{enumor.CvmCloudResType, enumor.VpcCloudResType,
+	enumor.SubnetCloudResType, enumor.DiskCloudResType, enumor.EipCloudResType, enumor.SecurityGroupCloudResType,
+	enumor.RouteTableCloudResType, enumor.NetworkInterfaceCloudResType}
+
+// TaggedResTypes is the resource types of each vendor whose tags are synced from cloud, the other resources of the
+// vendor have no tags on cloud, so they are not evaluated against the tag policies.
+var TaggedResTypes = map[enumor.Vendor][]enumor.CloudResourceType{
+	enumor.TCloud: {enumor.CvmCloudResType, enumor.VpcCloudResType, enumor.SubnetCloudResType,
+		enumor.DiskCloudResType, enumor.EipCloudResType, enumor.SecurityGroupCloudResType,
+		enumor.RouteTableCloudResType},
+	enumor.Aws: {enumor.CvmCloudResType, enumor.VpcCloudResType, enumor.SubnetCloudResType, enumor.DiskCloudResType,
+		enumor.EipCloudResType, enumor.SecurityGroupCloudResType, enumor.RouteTableCloudResType},
+	enumor.Azure: {enumor.CvmCloudResType, enumor.VpcCloudResType, enumor.DiskCloudResType, enumor.EipCloudResType,
+		enumor.SecurityGroupCloudResType, enumor.RouteTableCloudResType, enumor.NetworkInterfaceCloudResType},
+	enumor.Gcp:    {enumor.CvmCloudResType, enumor.DiskCloudResType, enumor.EipCloudResType},
+	enumor.HuaWei: {enumor.CvmCloudResType, enumor.VpcCloudResType, enumor.DiskCloudResType},
+}
+
+// IsResTypeTagged check if the tags of the vendor's resource type are synced from cloud.
+func IsResTypeTagged(vendor enumor.Vendor, resType enumor.CloudResourceType) bool {
+	return slice.IsItemInSlice(TaggedResTypes[vendor], resType)
+}
+
+// TagPolicy 标签策略，作用于账号或业务下的资源，资源的标签需要满足策略中的所有规则
+type TagPolicy struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// AccountID 和 BkBizID 二选一，策略作用于账号下或业务下的资源
+	AccountID     string                     `json:"account_id"`
+	BkBizID       int64                      `json:"bk_biz_id"`
+	ResTypes      []enumor.CloudResourceType `json:"res_types"`
+	Rules         []TagRule                  `json:"rules"`
+	AutoRemediate bool                       `json:"auto_remediate"`
+	Enabled       bool                       `json:"enabled"`
+	Memo          *string                    `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// TagRule 标签规则，约束一个标签键是否必须存在，以及标签值允许的格式和大小写
+type TagRule struct {
+	Key      string `json:"key" validate:"required,max=255"`
+	Required bool   `json:"required"`
+	// ValuePattern 标签值需要完整匹配的正则表达式，为空时不限制
+	ValuePattern string              `json:"value_pattern" validate:"max=255"`
+	ValueCase    enumor.TagValueCase `json:"value_case"`
+	// DefaultValue 自动修复时为缺失的必填标签设置的默认值，为空时不修复
+	DefaultValue string `json:"default_value" validate:"max=255"`
+}
+
+// ValidateScope validate the scope of the tag policy, one and only one of account id and biz id should be set.
+func ValidateScope(accountID string, bkBizID int64) error {
+	if len(accountID) == 0 && bkBizID <= 0 {
+		return errors.New("one of account_id and bk_biz_id is required")
+	}
+
+	if len(accountID) != 0 && bkBizID != 0 {
+		return errors.New("account_id and bk_biz_id can not be both set")
+	}
+
+	return nil
+}
+
+// ValidateResTypes validate the resource types of the tag policy.
+func ValidateResTypes(resTypes []enumor.CloudResourceType) error {
+	if len(resTypes) == 0 {
+		return errors.New("res_types is required")
+	}
+
+	exists := make(map[enumor.CloudResourceType]struct{}, len(resTypes))
+	for _, resType := range resTypes {
+		if _, duplicated := exists[resType]; duplicated {
+			return fmt.Errorf("res type %s is duplicated", resType)
+		}
+		exists[resType] = struct{}{}
+
+		if !slice.IsItemInSlice(PolicyResTypes, resType) {
+			return fmt.Errorf("tag policy does not support res type %s", resType)
+		}
+	}
+
+	return nil
+}
+
+// ValidateRules validate the tag rules of the tag policy, the rule keys should be unique ignoring case.
+func ValidateRules(rules []TagRule) error {
+	if _, err := NewChecker(rules); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Violation 资源标签不满足标签规则的原因
+type Violation struct {
+	TagKey string                    `json:"tag_key"`
+	Reason enumor.TagViolationReason `json:"reason"`
+	Detail string                    `json:"detail"`
+}
+
+// Checker checks the tags of resources against the tag rules, the value patterns are compiled only once.
+type Checker struct {
+	rules    []TagRule
+	patterns []*regexp.Regexp
+}
+
+// NewChecker validate the tag rules and create the checker of them.
+func NewChecker(rules []TagRule) (*Checker, error) {
+	if len(rules) == 0 {
+		return nil, errors.New("rules is required")
+	}
+
+	if len(rules) > TagRuleMaxCount {
+		return nil, fmt.Errorf("rules count should <= %d", TagRuleMaxCount)
+	}
+
+	c := &Checker{rules: rules, patterns: make([]*regexp.Regexp, len(rules))}
+	keys := make(map[string]struct{}, len(rules))
+	for idx, rule := range rules {
+		if err := validator.Validate.Struct(rule); err != nil {
+			return nil, err
+		}
+
+		lowerKey := strings.ToLower(rule.Key)
+		if _, exists := keys[lowerKey]; exists {
+			return nil, fmt.Errorf("rule key %s is duplicated", rule.Key)
+		}
+		keys[lowerKey] = struct{}{}
+
+		if err := rule.ValueCase.Validate(); err != nil {
+			return nil, err
+		}
+
+		if len(rule.ValuePattern) != 0 {
+			pattern, err := regexp.Compile("^(?:" + rule.ValuePattern + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("rule %s value_pattern is invalid, err: %v", rule.Key, err)
+			}
+			c.patterns[idx] = pattern
+		}
+
+		if len(rule.DefaultValue) != 0 {
+			if violation := c.checkValue(idx, rule.DefaultValue); violation != nil {
+				return nil, fmt.Errorf("rule %s default_value is invalid, %s", rule.Key, violation.Detail)
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// Check the tags against the tag rules, returns the violations of all the rules.
+func (c *Checker) Check(tags []coreresourcetag.Tag) []Violation {
+	violations := make([]Violation, 0)
+	for idx, rule := range c.rules {
+		tag, exists := findTag(tags, rule.Key)
+		if !exists {
+			if rule.Required {
+				violations = append(violations, Violation{TagKey: rule.Key, Reason: enumor.MissingTagViolation,
+					Detail: fmt.Sprintf("required tag %s is missing", rule.Key)})
+			}
+			continue
+		}
+
+		if tag.Key != rule.Key {
+			violations = append(violations, Violation{TagKey: rule.Key, Reason: enumor.KeyCaseTagViolation,
+				Detail: fmt.Sprintf("tag key %s should be %s", tag.Key, rule.Key)})
+		}
+
+		if violation := c.checkValue(idx, tag.Value); violation != nil {
+			violations = append(violations, *violation)
+		}
+	}
+
+	return violations
+}
+
+// Remediate add the default values of the missing required tags to the tags, returns the remediated tags and
+// whether the tags are changed. the other violations need to be fixed manually, so they are not remediated.
+func (c *Checker) Remediate(tags []coreresourcetag.Tag) ([]coreresourcetag.Tag, bool) {
+	result := make([]coreresourcetag.Tag, 0, len(tags)+len(c.rules))
+	result = append(result, tags...)
+
+	changed := false
+	for _, rule := range c.rules {
+		if !rule.Required || len(rule.DefaultValue) == 0 {
+			continue
+		}
+
+		if _, exists := findTag(tags, rule.Key); exists {
+			continue
+		}
+
+		result = append(result, coreresourcetag.Tag{Key: rule.Key, Value: rule.DefaultValue})
+		changed = true
+	}
+
+	return result, changed
+}
+
+// checkValue check the tag value against the case rule and value pattern of the rule.
+func (c *Checker) checkValue(idx int, value string) *Violation {
+	rule := c.rules[idx]
+
+	switch rule.ValueCase {
+	case enumor.LowerTagValueCase:
+		if value != strings.ToLower(value) {
+			return &Violation{TagKey: rule.Key, Reason: enumor.ValueCaseTagViolation,
+				Detail: fmt.Sprintf("tag %s value %s should be lower case", rule.Key, value)}
+		}
+	case enumor.UpperTagValueCase:
+		if value != strings.ToUpper(value) {
+			return &Violation{TagKey: rule.Key, Reason: enumor.ValueCaseTagViolation,
+				Detail: fmt.Sprintf("tag %s value %s should be upper case", rule.Key, value)}
+		}
+	}
+
+	if c.patterns[idx] != nil && !c.patterns[idx].MatchString(value) {
+		return &Violation{TagKey: rule.Key, Reason: enumor.ValueTagViolation,
+			Detail: fmt.Sprintf("tag %s value %s does not match %s", rule.Key, value, rule.ValuePattern)}
+	}
+
+	return nil
+}
+
+// findTag find the tag by key, the exactly matched tag is preferred, otherwise the tag whose key only differs in
+// case is returned, so that the case violation of the key can be reported.
+func findTag(tags []coreresourcetag.Tag, key string) (coreresourcetag.Tag, bool) {
+	var matched *coreresourcetag.Tag
+	for idx := range tags {
+		if tags[idx].Key == key {
+			return tags[idx], true
+		}
+
+		if matched == nil && strings.EqualFold(tags[idx].Key, key) {
+			matched = &tags[idx]
+		}
+	}
+
+	if matched == nil {
+		return coreresourcetag.Tag{}, false
+	}
+
+	return *matched, true
+}
+
+// TagPolicyViolation 资源违反标签策略的记录，每次评估账号资源时整体替换
+type TagPolicyViolation struct {
+	ID        string                    `json:"id"`
+	PolicyID  string                    `json:"policy_id"`
+	ResType   enumor.CloudResourceType  `json:"res_type"`
+	ResID     string                    `json:"res_id"`
+	Vendor    enumor.Vendor             `json:"vendor"`
+	AccountID string                    `json:"account_id"`
+	BkBizID   int64                     `json:"bk_biz_id"`
+	TagKey    string                    `json:"tag_key"`
+	Reason    enumor.TagViolationReason `json:"reason"`
+	Detail    string                    `json:"detail"`
+	CreatedAt string                    `json:"created_at"`
+}
+
+// EvaluateResult 账号资源标签策略的评估结果
+type EvaluateResult struct {
+	// ResCount 评估的资源数
+	ResCount uint64 `json:"res_count"`
+	// ViolatedResCount 违反策略的资源数
+	ViolatedResCount uint64 `json:"violated_res_count"`
+	// ViolationCount 违反策略的记录数
+	ViolationCount uint64 `json:"violation_count"`
+}
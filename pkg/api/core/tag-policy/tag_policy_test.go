@@ -0,0 +1,142 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tagpolicy
+
+import (
+	"testing"
+
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestNewChecker(t *testing.T) {
+	cases := []struct {
+		rules []TagRule
+		valid bool
+	}{
+		{rules: []TagRule{{Key: "owner", Required: true}, {Key: "env", ValuePattern: "prod|test"}}, valid: true},
+		{rules: []TagRule{{Key: "env", Required: true, ValuePattern: "prod|test", DefaultValue: "test"}},
+			valid: true},
+		{rules: []TagRule{{Key: "env", ValuePattern: "prod|test", DefaultValue: "dev"}}, valid: false},
+		{rules: []TagRule{{Key: "env", ValueCase: enumor.LowerTagValueCase, DefaultValue: "Prod"}}, valid: false},
+		{rules: []TagRule{{Key: "env", ValuePattern: "(prod"}}, valid: false},
+		{rules: []TagRule{{Key: "env", ValueCase: "title"}}, valid: false},
+		{rules: []TagRule{{Key: "env"}, {Key: "ENV"}}, valid: false},
+		{rules: []TagRule{{Key: ""}}, valid: false},
+		{rules: nil, valid: false},
+	}
+
+	for idx, c := range cases {
+		if _, err := NewChecker(c.rules); (err == nil) != c.valid {
+			t.Errorf("case %d, expect valid: %v, but got err: %v", idx, c.valid, err)
+		}
+	}
+}
+
+func TestCheckerCheck(t *testing.T) {
+	checker, err := NewChecker([]TagRule{
+		{Key: "owner", Required: true},
+		{Key: "cost-center", Required: true, ValuePattern: `\d{4}`},
+		{Key: "env", Required: true, ValuePattern: "prod|test", ValueCase: enumor.LowerTagValueCase},
+		{Key: "team", ValueCase: enumor.UpperTagValueCase},
+	})
+	if err != nil {
+		t.Fatalf("new checker failed, err: %v", err)
+	}
+
+	cases := []struct {
+		tags    []coreresourcetag.Tag
+		reasons map[string]enumor.TagViolationReason
+	}{
+		{
+			tags: []coreresourcetag.Tag{{Key: "owner", Value: "a"}, {Key: "cost-center", Value: "1234"},
+				{Key: "env", Value: "prod"}},
+			reasons: map[string]enumor.TagViolationReason{},
+		},
+		{
+			tags: []coreresourcetag.Tag{{Key: "Owner", Value: "a"}, {Key: "cost-center", Value: "12345"}},
+			reasons: map[string]enumor.TagViolationReason{"owner": enumor.KeyCaseTagViolation,
+				"cost-center": enumor.ValueTagViolation, "env": enumor.MissingTagViolation},
+		},
+		{
+			tags: []coreresourcetag.Tag{{Key: "owner", Value: "a"}, {Key: "cost-center", Value: "1234"},
+				{Key: "env", Value: "Prod"}, {Key: "team", Value: "ops"}},
+			reasons: map[string]enumor.TagViolationReason{"env": enumor.ValueCaseTagViolation,
+				"team": enumor.ValueCaseTagViolation},
+		},
+	}
+
+	for idx, c := range cases {
+		violations := checker.Check(c.tags)
+		if len(violations) != len(c.reasons) {
+			t.Errorf("case %d, expect %d violations, but got %+v", idx, len(c.reasons), violations)
+			continue
+		}
+
+		for _, violation := range violations {
+			if c.reasons[violation.TagKey] != violation.Reason {
+				t.Errorf("case %d, expect tag %s reason %s, but got %s", idx, violation.TagKey,
+					c.reasons[violation.TagKey], violation.Reason)
+			}
+		}
+	}
+}
+
+func TestCheckerRemediate(t *testing.T) {
+	checker, err := NewChecker([]TagRule{
+		{Key: "owner", Required: true},
+		{Key: "env", Required: true, DefaultValue: "test"},
+		{Key: "team", DefaultValue: "ops"},
+	})
+	if err != nil {
+		t.Fatalf("new checker failed, err: %v", err)
+	}
+
+	tags, changed := checker.Remediate([]coreresourcetag.Tag{{Key: "owner", Value: "a"}})
+	if !changed || len(tags) != 2 || tags[1].Key != "env" || tags[1].Value != "test" {
+		t.Errorf("expect env tag is remediated, but got changed: %v, tags: %+v", changed, tags)
+	}
+
+	_, changed = checker.Remediate([]coreresourcetag.Tag{{Key: "ENV", Value: "prod"}})
+	if changed {
+		t.Errorf("expect tag with different key case is not remediated")
+	}
+}
+
+func TestIsResTypeTagged(t *testing.T) {
+	cases := []struct {
+		vendor  enumor.Vendor
+		resType enumor.CloudResourceType
+		tagged  bool
+	}{
+		{vendor: enumor.TCloud, resType: enumor.SubnetCloudResType, tagged: true},
+		{vendor: enumor.Azure, resType: enumor.SubnetCloudResType, tagged: false},
+		{vendor: enumor.Gcp, resType: enumor.DiskCloudResType, tagged: true},
+		{vendor: enumor.Gcp, resType: enumor.VpcCloudResType, tagged: false},
+		{vendor: enumor.HuaWei, resType: enumor.VpcCloudResType, tagged: true},
+		{vendor: enumor.HuaWei, resType: enumor.EipCloudResType, tagged: false},
+	}
+
+	for idx, c := range cases {
+		if tagged := IsResTypeTagged(c.vendor, c.resType); tagged != c.tagged {
+			t.Errorf("case %d, expect tagged: %v, but got: %v", idx, c.tagged, tagged)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package token defines the core types of hcm issued api tokens and service accounts.
+package token
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// ApiToken 平台签发的API令牌，令牌明文只在创建时返回
+type ApiToken struct {
+	ID          string                     `json:"id"`
+	Name        string                     `json:"name"`
+	SubjectType enumor.ApiTokenSubjectType `json:"subject_type"`
+	Subject     string                     `json:"subject"`
+	TokenPrefix string                     `json:"token_prefix"`
+	Scopes      []string                   `json:"scopes"`
+	// ExpiredAt 过期时间，为空表示永不过期
+	ExpiredAt     string  `json:"expired_at"`
+	Revoked       bool    `json:"revoked"`
+	LastUsedAt    string  `json:"last_used_at"`
+	LastUsedIP    string  `json:"last_used_ip"`
+	Memo          *string `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// ServiceAccount 服务账号，供自动化程序使用其令牌调用API
+type ServiceAccount struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Managers      []string `json:"managers"`
+	Memo          *string  `json:"memo"`
+	core.Revision `json:",inline"`
+}
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package changefeed defines the data-service api types of resource change feed.
+package changefeed
+
+import (
+	changefeed "hcm/pkg/api/core/change-feed"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// ChangeFeedListResp defines list change feed response.
+type ChangeFeedListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ChangeFeedListResult `json:"data"`
+}
+
+// ChangeFeedListResult defines list change feed result.
+type ChangeFeedListResult struct {
+	Count   uint64                  `json:"count"`
+	Details []changefeed.ChangeFeed `json:"details"`
+}
+
+// ChangeFeedDeleteReq defines delete change feed request.
+type ChangeFeedDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate ChangeFeedDeleteReq.
+func (req *ChangeFeedDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ChangeFeedDeleteResp defines delete change feed response.
+type ChangeFeedDeleteResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ChangeFeedDeleteResult `json:"data"`
+}
+
+// ChangeFeedDeleteResult defines delete change feed result.
+type ChangeFeedDeleteResult struct {
+	Deleted int64 `json:"deleted"`
+}
@@ -53,10 +53,15 @@ func (req *TCloudAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography
 
 // AwsAccountExtensionCreateReq ...
 type AwsAccountExtensionCreateReq struct {
-	CloudAccountID   string `json:"cloud_account_id" validate:"required"`
-	CloudIamUsername string `json:"cloud_iam_username" validate:"required"`
+	CloudAccountID string `json:"cloud_account_id" validate:"required"`
+	// CloudIamUsername 使用角色扮演时没有IAM用户
+	CloudIamUsername string `json:"cloud_iam_username" validate:"omitempty"`
 	CloudSecretID    string `json:"cloud_secret_id" validate:"omitempty"`
 	CloudSecretKey   string `json:"cloud_secret_key" validate:"omitempty"`
+
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type,omitempty" validate:"omitempty"`
+	CloudRoleArn        string                     `json:"cloud_role_arn,omitempty" validate:"omitempty"`
+	CloudExternalID     string                     `json:"cloud_external_id,omitempty" validate:"omitempty"`
 }
 
 // EncryptSecretKey ...
@@ -88,6 +93,8 @@ type GcpAccountExtensionCreateReq struct {
 	CloudServiceAccountName string `json:"cloud_service_account_name" validate:"omitempty"`
 	CloudServiceSecretID    string `json:"cloud_service_secret_id" validate:"omitempty"`
 	CloudServiceSecretKey   string `json:"cloud_service_secret_key" validate:"omitempty"`
+
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type,omitempty" validate:"omitempty"`
 }
 
 // EncryptSecretKey ...
@@ -104,6 +111,8 @@ type AzureAccountExtensionCreateReq struct {
 	CloudApplicationName  string `json:"cloud_application_name" validate:"omitempty"`
 	CloudClientSecretID   string `json:"cloud_client_secret_id" validate:"omitempty"`
 	CloudClientSecretKey  string `json:"cloud_client_secret_key" validate:"omitempty"`
+
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type,omitempty" validate:"omitempty"`
 }
 
 // EncryptSecretKey ...
@@ -127,6 +136,16 @@ func (c *AccountCreateReq[T]) Validate() error {
 	return validator.Validate.Struct(c)
 }
 
+// AccountBatchCreateReq batch create accounts in one transaction, used by organization member import.
+type AccountBatchCreateReq[T AccountExtensionCreateReq] struct {
+	Accounts []AccountCreateReq[T] `json:"accounts" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate ...
+func (c *AccountBatchCreateReq[T]) Validate() error {
+	return validator.Validate.Struct(c)
+}
+
 // -------------------------- Update --------------------------
 
 // AccountExtensionUpdateReq Note: DataService的更新是与业务无关的，所以必须支持调用方根据场景需求来更新部分字段
@@ -155,6 +174,10 @@ type AwsAccountExtensionUpdateReq struct {
 	CloudIamUsername string  `json:"cloud_iam_username,omitempty" validate:"omitempty"`
 	CloudSecretID    *string `json:"cloud_secret_id,omitempty" validate:"omitempty"`
 	CloudSecretKey   *string `json:"cloud_secret_key,omitempty" validate:"omitempty"`
+
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type,omitempty" validate:"omitempty"`
+	CloudRoleArn        *string                    `json:"cloud_role_arn,omitempty" validate:"omitempty"`
+	CloudExternalID     *string                    `json:"cloud_external_id,omitempty" validate:"omitempty"`
 }
 
 // EncryptSecretKey ...
@@ -190,6 +213,8 @@ type GcpAccountExtensionUpdateReq struct {
 	CloudServiceAccountName *string `json:"cloud_service_account_name,omitempty" validate:"omitempty"`
 	CloudServiceSecretID    *string `json:"cloud_service_secret_id,omitempty" validate:"omitempty"`
 	CloudServiceSecretKey   *string `json:"cloud_service_secret_key,omitempty" validate:"omitempty"`
+
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type,omitempty" validate:"omitempty"`
 }
 
 // EncryptSecretKey ...
@@ -208,6 +233,8 @@ type AzureAccountExtensionUpdateReq struct {
 	CloudApplicationName  *string `json:"cloud_application_name,omitempty" validate:"omitempty"`
 	CloudClientSecretID   *string `json:"cloud_client_secret_id,omitempty" validate:"omitempty"`
 	CloudClientSecretKey  *string `json:"cloud_client_secret_key,omitempty" validate:"omitempty"`
+
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type,omitempty" validate:"omitempty"`
 }
 
 // EncryptSecretKey ...
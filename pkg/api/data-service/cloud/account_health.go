@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// -------------------------- Record --------------------------
+
+// AccountHealthBatchRecordReq defines batch record account health check result request.
+type AccountHealthBatchRecordReq struct {
+	Records []AccountHealthRecordReq `json:"records" validate:"required,min=1,max=100,dive"`
+}
+
+// AccountHealthRecordReq defines record account health check result request.
+type AccountHealthRecordReq struct {
+	Vendor    enumor.Vendor              `json:"vendor" validate:"required"`
+	AccountID string                     `json:"account_id" validate:"required"`
+	Status    enumor.AccountHealthStatus `json:"status" validate:"required"`
+	// CheckAt 检查时间
+	CheckAt string `json:"check_at" validate:"required"`
+	// Error 检查失败的错误信息，仅账号不可用时有效
+	Error              string                           `json:"error" validate:"omitempty"`
+	MissingPermissions []cloud.AccountMissingPermission `json:"missing_permissions" validate:"omitempty"`
+}
+
+// Validate AccountHealthBatchRecordReq.
+func (req *AccountHealthBatchRecordReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, one := range req.Records {
+		if err := one.Vendor.Validate(); err != nil {
+			return err
+		}
+
+		if err := one.Status.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// AccountHealthListResp defines list account health response.
+type AccountHealthListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *AccountHealthListResult `json:"data"`
+}
+
+// AccountHealthListResult defines list account health result.
+type AccountHealthListResult struct {
+	Count   uint64                `json:"count"`
+	Details []cloud.AccountHealth `json:"details"`
+}
@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// -------------------------- Create --------------------------
+
+// CostAnomalyBatchCreateReq defines batch create cost anomaly request.
+type CostAnomalyBatchCreateReq struct {
+	Anomalies []CostAnomalyCreateReq `json:"anomalies" validate:"required,min=1,max=100,dive"`
+}
+
+// CostAnomalyCreateReq defines create cost anomaly request.
+type CostAnomalyCreateReq struct {
+	Vendor         enumor.Vendor               `json:"vendor" validate:"required"`
+	AccountID      string                      `json:"account_id" validate:"required"`
+	Dimension      enumor.CostAnomalyDimension `json:"dimension" validate:"required"`
+	DimensionValue string                      `json:"dimension_value" validate:"omitempty"`
+	BillDate       string                      `json:"bill_date" validate:"required"`
+	Currency       string                      `json:"currency" validate:"omitempty"`
+	ActualCost     float64                     `json:"actual_cost" validate:"omitempty"`
+	ExpectedCost   float64                     `json:"expected_cost" validate:"omitempty"`
+	Ratio          float64                     `json:"ratio" validate:"omitempty"`
+	Score          float64                     `json:"score" validate:"omitempty"`
+	TopItems       []cloud.CostAnomalyItem     `json:"top_items" validate:"omitempty"`
+}
+
+// Validate CostAnomalyBatchCreateReq.
+func (req *CostAnomalyBatchCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, one := range req.Anomalies {
+		if err := one.Dimension.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- Update --------------------------
+
+// CostAnomalyBatchUpdateReq defines batch update cost anomaly request.
+type CostAnomalyBatchUpdateReq struct {
+	Anomalies []CostAnomalyUpdateReq `json:"anomalies" validate:"required"`
+}
+
+// CostAnomalyUpdateReq defines update cost anomaly request.
+type CostAnomalyUpdateReq struct {
+	ID     string                   `json:"id" validate:"required"`
+	Status enumor.CostAnomalyStatus `json:"status" validate:"omitempty"`
+	Memo   *string                  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate CostAnomalyBatchUpdateReq.
+func (req *CostAnomalyBatchUpdateReq) Validate() error {
+	if len(req.Anomalies) == 0 {
+		return errors.New("cost anomalies are required")
+	}
+
+	if len(req.Anomalies) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("cost anomalies count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, one := range req.Anomalies {
+		if err := validator.Validate.Struct(one); err != nil {
+			return err
+		}
+
+		if len(one.Status) != 0 {
+			if err := one.Status.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// CostAnomalyListResp defines list cost anomaly response.
+type CostAnomalyListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *CostAnomalyListResult `json:"data"`
+}
+
+// CostAnomalyListResult defines list cost anomaly result.
+type CostAnomalyListResult struct {
+	Count   uint64              `json:"count"`
+	Details []cloud.CostAnomaly `json:"details"`
+}
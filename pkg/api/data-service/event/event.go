@@ -0,0 +1,191 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event defines the data-service api types of resource lifecycle events and their webhook subscriptions.
+package event
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core/event"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Event --------------------------
+
+// EventListResp defines list event response.
+type EventListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *EventListResult `json:"data"`
+}
+
+// EventListResult defines list event result.
+type EventListResult struct {
+	Count   uint64        `json:"count"`
+	Details []event.Event `json:"details"`
+}
+
+// EventDeleteReq defines delete event request.
+type EventDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate EventDeleteReq.
+func (req *EventDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// EventDeleteResp defines delete event response.
+type EventDeleteResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *EventDeleteResult `json:"data"`
+}
+
+// EventDeleteResult defines delete event result.
+type EventDeleteResult struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// -------------------------- Subscription --------------------------
+
+// SubscriptionCreateReq defines create event subscription request.
+type SubscriptionCreateReq struct {
+	Name     string                    `json:"name" validate:"required,max=64"`
+	Endpoint string                    `json:"endpoint" validate:"required,url,max=255"`
+	Secret   string                    `json:"secret" validate:"required,max=255"`
+	Filter   *event.SubscriptionFilter `json:"filter" validate:"required"`
+	Enabled  *bool                     `json:"enabled" validate:"omitempty"`
+	// LastEventID 订阅的起始游标，为空时从最新的事件开始订阅
+	LastEventID *uint64 `json:"last_event_id" validate:"omitempty"`
+	Memo        *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubscriptionCreateReq.
+func (req *SubscriptionCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.Filter.Validate()
+}
+
+// SubscriptionUpdateReq defines update event subscription request.
+type SubscriptionUpdateReq struct {
+	Endpoint string                    `json:"endpoint" validate:"omitempty,url,max=255"`
+	Secret   string                    `json:"secret" validate:"omitempty,max=255"`
+	Filter   *event.SubscriptionFilter `json:"filter" validate:"omitempty"`
+	Enabled  *bool                     `json:"enabled" validate:"omitempty"`
+	// LastEventID 订阅的游标，重置游标可以重放该游标之后的事件
+	LastEventID *uint64 `json:"last_event_id" validate:"omitempty"`
+	// PrevLastEventID 更新前的游标，设置时只有当前游标与其一致才更新，避免投递事件时覆盖重置的游标
+	PrevLastEventID *uint64 `json:"prev_last_event_id" validate:"omitempty"`
+	Memo            *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubscriptionUpdateReq.
+func (req *SubscriptionUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Endpoint) == 0 && len(req.Secret) == 0 && req.Filter == nil && req.Enabled == nil &&
+		req.LastEventID == nil && req.Memo == nil {
+		return errors.New("at least one of endpoint, secret, filter, enabled, last_event_id and memo should be set")
+	}
+
+	if req.PrevLastEventID != nil && req.LastEventID == nil {
+		return errors.New("last_event_id is required when prev_last_event_id is set")
+	}
+
+	if req.Filter != nil {
+		return req.Filter.Validate()
+	}
+
+	return nil
+}
+
+// SubscriptionDeleteReq defines delete event subscription request, dead letters of the deleted subscriptions are
+// deleted together.
+type SubscriptionDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate SubscriptionDeleteReq.
+func (req *SubscriptionDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// SubscriptionListResp defines list event subscription response.
+type SubscriptionListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *SubscriptionListResult `json:"data"`
+}
+
+// SubscriptionListResult defines list event subscription result.
+type SubscriptionListResult struct {
+	Count   uint64               `json:"count"`
+	Details []SubscriptionDetail `json:"details"`
+}
+
+// SubscriptionDetail defines event subscription with its encrypted secret, which is only used inside hcm to sign
+// the delivered events.
+type SubscriptionDetail struct {
+	event.Subscription `json:",inline"`
+	Secret             string `json:"secret"`
+}
+
+// -------------------------- Dead Letter --------------------------
+
+// DeadLetterCreateReq defines create event dead letter request.
+type DeadLetterCreateReq struct {
+	SubscriptionID string `json:"subscription_id" validate:"required,max=64"`
+	EventID        uint64 `json:"event_id" validate:"required"`
+	EventType      string `json:"event_type" validate:"required,max=128"`
+	Attempts       uint   `json:"attempts" validate:"required"`
+	LastError      string `json:"last_error" validate:"omitempty"`
+}
+
+// Validate DeadLetterCreateReq.
+func (req *DeadLetterCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DeadLetterDeleteReq defines delete event dead letter request.
+type DeadLetterDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate DeadLetterDeleteReq.
+func (req *DeadLetterDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DeadLetterListResp defines list event dead letter response.
+type DeadLetterListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *DeadLetterListResult `json:"data"`
+}
+
+// DeadLetterListResult defines list event dead letter result.
+type DeadLetterListResult struct {
+	Count   uint64             `json:"count"`
+	Details []event.DeadLetter `json:"details"`
+}
@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idempotency defines the data-service api types of the idempotency keys of mutating requests.
+package idempotency
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// RecordKey defines the key of an idempotency record.
+type RecordKey struct {
+	Owner          string `json:"owner" validate:"required,max=128"`
+	IdempotencyKey string `json:"idempotency_key" validate:"required,max=255"`
+	RequestHash    string `json:"request_hash" validate:"required,len=64"`
+}
+
+// RecordAcquireReq defines acquire idempotency record request.
+type RecordAcquireReq struct {
+	RecordKey `json:",inline"`
+	// TimeoutSec is the processing timeout, the key can be acquired again after the request is timeout.
+	TimeoutSec uint `json:"timeout_sec" validate:"required"`
+}
+
+// Validate RecordAcquireReq.
+func (req *RecordAcquireReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RecordAcquireResult defines acquire idempotency record result.
+type RecordAcquireResult struct {
+	// Acquired is true if the key is not used and saved as processing.
+	Acquired bool `json:"acquired"`
+	// Record is the existing record of the key when it's not acquired.
+	Record *Record `json:"record,omitempty"`
+}
+
+// Record defines the existing idempotency record.
+type Record struct {
+	RequestHash string                  `json:"request_hash"`
+	State       enumor.IdempotencyState `json:"state"`
+	Response    string                  `json:"response,omitempty"`
+}
+
+// RecordAcquireResp defines acquire idempotency record response.
+type RecordAcquireResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *RecordAcquireResult `json:"data"`
+}
+
+// RecordCompleteReq defines complete idempotency record request.
+type RecordCompleteReq struct {
+	RecordKey `json:",inline"`
+	// Response is the response data of the request.
+	Response string `json:"response" validate:"required"`
+	// RetentionSec is the duration that the response is kept for replay.
+	RetentionSec uint `json:"retention_sec" validate:"required"`
+}
+
+// Validate RecordCompleteReq.
+func (req *RecordCompleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RecordReleaseReq defines release idempotency record request.
+type RecordReleaseReq struct {
+	RecordKey `json:",inline"`
+}
+
+// Validate RecordReleaseReq.
+func (req *RecordReleaseReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RecordDeleteExpiredResult defines delete expired idempotency record result.
+type RecordDeleteExpiredResult struct {
+	Count int64 `json:"count"`
+}
+
+// RecordDeleteExpiredResp defines delete expired idempotency record response.
+type RecordDeleteExpiredResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *RecordDeleteExpiredResult `json:"data"`
+}
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac defines the data-service api types of the built-in rbac authorizer.
+package rbac
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core/rbac"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Role --------------------------
+
+// RoleCreateReq defines create rbac role request.
+type RoleCreateReq struct {
+	Name    string   `json:"name" validate:"required,max=64"`
+	Actions []string `json:"actions" validate:"required,min=1"`
+	Builtin bool     `json:"builtin" validate:"omitempty"`
+	Memo    *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate RoleCreateReq.
+func (req *RoleCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RoleUpdateReq defines update rbac role request.
+type RoleUpdateReq struct {
+	Name    string   `json:"name" validate:"omitempty,max=64"`
+	Actions []string `json:"actions" validate:"omitempty"`
+	Memo    *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate RoleUpdateReq.
+func (req *RoleUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && len(req.Actions) == 0 && req.Memo == nil {
+		return errors.New("at least one of name, actions and memo should be set")
+	}
+
+	return nil
+}
+
+// RoleDeleteReq defines delete rbac role request, bindings of the deleted roles are deleted together.
+type RoleDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate RoleDeleteReq.
+func (req *RoleDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RoleListResp defines list rbac role response.
+type RoleListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *RoleListResult `json:"data"`
+}
+
+// RoleListResult defines list rbac role result.
+type RoleListResult struct {
+	Count   uint64      `json:"count"`
+	Details []rbac.Role `json:"details"`
+}
+
+// -------------------------- Role Binding --------------------------
+
+// RoleBindingBatchCreateReq defines batch create rbac role binding request.
+type RoleBindingBatchCreateReq struct {
+	Bindings []RoleBindingCreateReq `json:"bindings" validate:"required,min=1,max=100,dive"`
+}
+
+// RoleBindingCreateReq defines create rbac role binding request.
+type RoleBindingCreateReq struct {
+	RoleID    string               `json:"role_id" validate:"required"`
+	Subject   string               `json:"subject" validate:"required,max=64"`
+	ScopeType enumor.RbacScopeType `json:"scope_type" validate:"required"`
+	ScopeIDs  []string             `json:"scope_ids" validate:"omitempty"`
+}
+
+// Validate RoleBindingBatchCreateReq.
+func (req *RoleBindingBatchCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, one := range req.Bindings {
+		if err := one.ScopeType.Validate(); err != nil {
+			return err
+		}
+
+		if one.ScopeType != enumor.AllRbacScope && len(one.ScopeIDs) == 0 {
+			return errors.New("scope_ids is required when scope_type is not all")
+		}
+	}
+
+	return nil
+}
+
+// RoleBindingDeleteReq defines delete rbac role binding request.
+type RoleBindingDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate RoleBindingDeleteReq.
+func (req *RoleBindingDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RoleBindingListResp defines list rbac role binding response.
+type RoleBindingListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *RoleBindingListResult `json:"data"`
+}
+
+// RoleBindingListResult defines list rbac role binding result.
+type RoleBindingListResult struct {
+	Count   uint64             `json:"count"`
+	Details []rbac.RoleBinding `json:"details"`
+}
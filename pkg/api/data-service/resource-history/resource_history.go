@@ -0,0 +1,119 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcehistory defines the data-service api types of resource change history.
+package resourcehistory
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	resourcehistory "hcm/pkg/api/core/resource-history"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// ResourceHistoryListReq defines list histories of a resource request, the histories are sorted by the change
+// order, so the sort and order of the page are ignored.
+type ResourceHistoryListReq struct {
+	// StartTime and EndTime limit the change time of the histories, format: 2006-01-02T15:04:05Z07:00.
+	StartTime string         `json:"start_time" validate:"omitempty"`
+	EndTime   string         `json:"end_time" validate:"omitempty"`
+	Page      *core.BasePage `json:"page" validate:"required"`
+}
+
+// Validate ResourceHistoryListReq.
+func (req *ResourceHistoryListReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, one := range []string{req.StartTime, req.EndTime} {
+		if len(one) == 0 {
+			continue
+		}
+
+		if _, err := time.Parse(constant.TimeStdFormat, one); err != nil {
+			return fmt.Errorf("time %s is invalid, should be in %s format", one, constant.TimeStdFormat)
+		}
+	}
+
+	return req.Page.Validate()
+}
+
+// ResourceHistoryListResp defines list resource history response.
+type ResourceHistoryListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ResourceHistoryListResult `json:"data"`
+}
+
+// ResourceHistoryListResult defines list resource history result.
+type ResourceHistoryListResult struct {
+	Count   uint64                            `json:"count"`
+	Details []resourcehistory.ResourceHistory `json:"details"`
+}
+
+// ResourceStateReq defines get the state of a resource at the specified time request.
+type ResourceStateReq struct {
+	// Time format: 2006-01-02T15:04:05Z07:00.
+	Time string `json:"time" validate:"required"`
+}
+
+// Validate ResourceStateReq.
+func (req *ResourceStateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse(constant.TimeStdFormat, req.Time); err != nil {
+		return fmt.Errorf("time %s is invalid, should be in %s format", req.Time, constant.TimeStdFormat)
+	}
+
+	return nil
+}
+
+// ResourceStateResp defines get resource state response.
+type ResourceStateResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *resourcehistory.ResourceState `json:"data"`
+}
+
+// ResourceHistoryDeleteReq defines delete resource history request.
+type ResourceHistoryDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate ResourceHistoryDeleteReq.
+func (req *ResourceHistoryDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ResourceHistoryDeleteResp defines delete resource history response.
+type ResourceHistoryDeleteResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ResourceHistoryDeleteResult `json:"data"`
+}
+
+// ResourceHistoryDeleteResult defines delete resource history result.
+type ResourceHistoryDeleteResult struct {
+	Deleted int64 `json:"deleted"`
+}
@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines the data-service api types of resource tag.
+package resourcetag
+
+import (
+	"fmt"
+
+	coreresourcetag "hcm/pkg/api/core/resource-tag"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// ResourceTagListResp defines list resource tag response.
+type ResourceTagListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ResourceTagListResult `json:"data"`
+}
+
+// ResourceTagListResult defines list resource tag result.
+type ResourceTagListResult struct {
+	Count   uint64                        `json:"count"`
+	Details []coreresourcetag.ResourceTag `json:"details"`
+}
+
+// ResourceTagReplaceReq defines replace resource tag request, all the tags of the resources are replaced.
+type ResourceTagReplaceReq struct {
+	ResType   string                   `json:"res_type" validate:"required"`
+	Resources []ResourceTagReplaceItem `json:"resources" validate:"required,min=1,dive"`
+}
+
+// ResourceTagReplaceItem defines the tags of one resource to replace, empty tags means to clear its tags.
+type ResourceTagReplaceItem struct {
+	ResID     string                `json:"res_id" validate:"required"`
+	Vendor    enumor.Vendor         `json:"vendor" validate:"required"`
+	AccountID string                `json:"account_id" validate:"required"`
+	Tags      []coreresourcetag.Tag `json:"tags" validate:"omitempty,dive"`
+}
+
+// Validate ResourceTagReplaceReq.
+func (req *ResourceTagReplaceReq) Validate() error {
+	if len(req.Resources) > constant.RelResourceOperationMaxLimit {
+		return fmt.Errorf("resources count should <= %d", constant.RelResourceOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	resIDs := make(map[string]struct{}, len(req.Resources))
+	for _, one := range req.Resources {
+		if _, exists := resIDs[one.ResID]; exists {
+			return fmt.Errorf("resource %s is duplicated", one.ResID)
+		}
+		resIDs[one.ResID] = struct{}{}
+
+		if err := coreresourcetag.ValidateTags(one.Tags); err != nil {
+			return fmt.Errorf("resource %s tags is invalid, err: %v", one.ResID, err)
+		}
+	}
+
+	return nil
+}
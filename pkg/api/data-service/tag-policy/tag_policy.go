@@ -0,0 +1,147 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy defines the data-service api types of tag policy.
+package tagpolicy
+
+import (
+	"errors"
+
+	coretagpolicy "hcm/pkg/api/core/tag-policy"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// TagPolicyCreateReq defines create tag policy request.
+type TagPolicyCreateReq struct {
+	Name          string                     `json:"name" validate:"required,max=64"`
+	AccountID     string                     `json:"account_id" validate:"omitempty,max=64"`
+	BkBizID       int64                      `json:"bk_biz_id" validate:"omitempty,min=1"`
+	ResTypes      []enumor.CloudResourceType `json:"res_types" validate:"required"`
+	Rules         []coretagpolicy.TagRule    `json:"rules" validate:"required"`
+	AutoRemediate *bool                      `json:"auto_remediate" validate:"omitempty"`
+	Enabled       *bool                      `json:"enabled" validate:"omitempty"`
+	Memo          *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate TagPolicyCreateReq.
+func (req *TagPolicyCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := coretagpolicy.ValidateScope(req.AccountID, req.BkBizID); err != nil {
+		return err
+	}
+
+	if err := coretagpolicy.ValidateResTypes(req.ResTypes); err != nil {
+		return err
+	}
+
+	return coretagpolicy.ValidateRules(req.Rules)
+}
+
+// TagPolicyUpdateReq defines update tag policy request, the scope of the policy can not be updated.
+type TagPolicyUpdateReq struct {
+	Name          string                     `json:"name" validate:"omitempty,max=64"`
+	ResTypes      []enumor.CloudResourceType `json:"res_types" validate:"omitempty"`
+	Rules         []coretagpolicy.TagRule    `json:"rules" validate:"omitempty"`
+	AutoRemediate *bool                      `json:"auto_remediate" validate:"omitempty"`
+	Enabled       *bool                      `json:"enabled" validate:"omitempty"`
+	Memo          *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate TagPolicyUpdateReq.
+func (req *TagPolicyUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && len(req.ResTypes) == 0 && len(req.Rules) == 0 && req.AutoRemediate == nil &&
+		req.Enabled == nil && req.Memo == nil {
+		return errors.New("at least one of name, res_types, rules, auto_remediate, enabled and memo should be set")
+	}
+
+	if len(req.ResTypes) != 0 {
+		if err := coretagpolicy.ValidateResTypes(req.ResTypes); err != nil {
+			return err
+		}
+	}
+
+	if len(req.Rules) != 0 {
+		if err := coretagpolicy.ValidateRules(req.Rules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TagPolicyDeleteReq defines delete tag policy request.
+type TagPolicyDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate TagPolicyDeleteReq.
+func (req *TagPolicyDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TagPolicyListResp defines list tag policy response.
+type TagPolicyListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *TagPolicyListResult `json:"data"`
+}
+
+// TagPolicyListResult defines list tag policy result.
+type TagPolicyListResult struct {
+	Count   uint64                    `json:"count"`
+	Details []coretagpolicy.TagPolicy `json:"details"`
+}
+
+// TagPolicyEvaluateReq defines evaluate tag policy request, all the resources of the account are evaluated by the
+// enabled policies of the account and the bizs that the resources belong to.
+type TagPolicyEvaluateReq struct {
+	AccountID string `json:"account_id" validate:"required,max=64"`
+}
+
+// Validate TagPolicyEvaluateReq.
+func (req *TagPolicyEvaluateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TagPolicyEvaluateResp defines evaluate tag policy response.
+type TagPolicyEvaluateResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *coretagpolicy.EvaluateResult `json:"data"`
+}
+
+// ViolationListResp defines list tag policy violation response.
+type ViolationListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ViolationListResult `json:"data"`
+}
+
+// ViolationListResult defines list tag policy violation result.
+type ViolationListResult struct {
+	Count   uint64                             `json:"count"`
+	Details []coretagpolicy.TagPolicyViolation `json:"details"`
+}
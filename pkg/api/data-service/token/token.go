@@ -0,0 +1,162 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package token defines the data-service api types of hcm issued api tokens and service accounts.
+package token
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core/token"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Api Token --------------------------
+
+// ApiTokenCreateReq defines create api token request.
+type ApiTokenCreateReq struct {
+	Name        string                     `json:"name" validate:"required,max=64"`
+	SubjectType enumor.ApiTokenSubjectType `json:"subject_type" validate:"required"`
+	Subject     string                     `json:"subject" validate:"required,max=64"`
+	TokenHash   string                     `json:"token_hash" validate:"required,len=64"`
+	TokenPrefix string                     `json:"token_prefix" validate:"required,max=16"`
+	Scopes      []string                   `json:"scopes" validate:"required,min=1,max=20"`
+	ExpiredAt   string                     `json:"expired_at" validate:"omitempty"`
+	Memo        *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ApiTokenCreateReq.
+func (req *ApiTokenCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.SubjectType.Validate()
+}
+
+// ApiTokenUpdateReq defines update api token request.
+type ApiTokenUpdateReq struct {
+	Revoked *bool   `json:"revoked" validate:"omitempty"`
+	Memo    *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ApiTokenUpdateReq.
+func (req *ApiTokenUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Revoked == nil && req.Memo == nil {
+		return errors.New("at least one of revoked and memo should be set")
+	}
+
+	return nil
+}
+
+// ApiTokenLastUsedUpdateReq defines update api token last used info request.
+type ApiTokenLastUsedUpdateReq struct {
+	LastUsedAt string `json:"last_used_at" validate:"required"`
+	LastUsedIP string `json:"last_used_ip" validate:"omitempty,max=64"`
+}
+
+// Validate ApiTokenLastUsedUpdateReq.
+func (req *ApiTokenLastUsedUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ApiTokenDeleteReq defines delete api token request.
+type ApiTokenDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate ApiTokenDeleteReq.
+func (req *ApiTokenDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ApiTokenListResp defines list api token response.
+type ApiTokenListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ApiTokenListResult `json:"data"`
+}
+
+// ApiTokenListResult defines list api token result.
+type ApiTokenListResult struct {
+	Count   uint64           `json:"count"`
+	Details []token.ApiToken `json:"details"`
+}
+
+// -------------------------- Service Account --------------------------
+
+// ServiceAccountCreateReq defines create service account request.
+type ServiceAccountCreateReq struct {
+	Name     string   `json:"name" validate:"required,max=64"`
+	Managers []string `json:"managers" validate:"required,min=1"`
+	Memo     *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ServiceAccountCreateReq.
+func (req *ServiceAccountCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ServiceAccountUpdateReq defines update service account request.
+type ServiceAccountUpdateReq struct {
+	Managers []string `json:"managers" validate:"omitempty"`
+	Memo     *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ServiceAccountUpdateReq.
+func (req *ServiceAccountUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Managers) == 0 && req.Memo == nil {
+		return errors.New("at least one of managers and memo should be set")
+	}
+
+	return nil
+}
+
+// ServiceAccountDeleteReq defines delete service account request, tokens of the deleted service accounts are
+// deleted together.
+type ServiceAccountDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate ServiceAccountDeleteReq.
+func (req *ServiceAccountDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ServiceAccountListResp defines list service account response.
+type ServiceAccountListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ServiceAccountListResult `json:"data"`
+}
+
+// ServiceAccountListResult defines list service account result.
+type ServiceAccountListResult struct {
+	Count   uint64                 `json:"count"`
+	Details []token.ServiceAccount `json:"details"`
+}
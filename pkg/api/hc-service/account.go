@@ -20,6 +20,11 @@
 package hcservice
 
 import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 )
 
@@ -39,15 +44,37 @@ func (r *TCloudAccountCheckReq) Validate() error {
 
 // AwsAccountCheckReq ...
 type AwsAccountCheckReq struct {
-	CloudAccountID   string `json:"cloud_account_id" validate:"required"`
-	CloudIamUsername string `json:"cloud_iam_username" validate:"required"`
-	CloudSecretID    string `json:"cloud_secret_id" validate:"required"`
-	CloudSecretKey   string `json:"cloud_secret_key" validate:"required"`
+	CloudAccountID      string                     `json:"cloud_account_id" validate:"required"`
+	CloudIamUsername    string                     `json:"cloud_iam_username" validate:"omitempty"`
+	CloudSecretID       string                     `json:"cloud_secret_id" validate:"omitempty"`
+	CloudSecretKey      string                     `json:"cloud_secret_key" validate:"omitempty"`
+	CloudCredentialType enumor.CloudCredentialType `json:"cloud_credential_type" validate:"omitempty"`
+	CloudRoleArn        string                     `json:"cloud_role_arn" validate:"omitempty"`
+	CloudExternalID     string                     `json:"cloud_external_id" validate:"omitempty"`
 }
 
 // Validate ...
 func (r *AwsAccountCheckReq) Validate() error {
-	return validator.Validate.Struct(r)
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	switch {
+	case r.CloudCredentialType.IsSecretKey():
+		if len(r.CloudIamUsername) == 0 || len(r.CloudSecretID) == 0 || len(r.CloudSecretKey) == 0 {
+			return errors.New("cloud_iam_username, cloud_secret_id and cloud_secret_key is required")
+		}
+
+	case r.CloudCredentialType == enumor.AssumeRoleCredential:
+		if len(r.CloudRoleArn) == 0 {
+			return errors.New("cloud_role_arn is required")
+		}
+
+	default:
+		return fmt.Errorf("aws not support credential type: %s", r.CloudCredentialType)
+	}
+
+	return nil
 }
 
 // HuaWeiAccountCheckReq ...
@@ -68,24 +95,56 @@ func (r *HuaWeiAccountCheckReq) Validate() error {
 
 // GcpAccountCheckReq ...
 type GcpAccountCheckReq struct {
-	CloudProjectID        string `json:"cloud_project_id" validate:"required"`
-	CloudServiceSecretKey string `json:"cloud_service_secret_key" validate:"required"`
+	CloudProjectID        string                     `json:"cloud_project_id" validate:"required"`
+	CloudServiceSecretKey string                     `json:"cloud_service_secret_key" validate:"required"`
+	CloudCredentialType   enumor.CloudCredentialType `json:"cloud_credential_type" validate:"omitempty"`
 }
 
 // Validate ...
 func (r *GcpAccountCheckReq) Validate() error {
-	return validator.Validate.Struct(r)
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	cred := &types.GcpCredential{
+		CloudProjectID: r.CloudProjectID,
+		Json:           []byte(r.CloudServiceSecretKey),
+		CredentialType: r.CloudCredentialType,
+	}
+	return cred.Validate()
 }
 
 // AzureAccountCheckReq ...
 type AzureAccountCheckReq struct {
-	CloudTenantID        string `json:"cloud_tenant_id" validate:"required"`
-	CloudSubscriptionID  string `json:"cloud_subscription_id" validate:"required"`
-	CloudApplicationID   string `json:"cloud_application_id" validate:"required"`
-	CloudClientSecretKey string `json:"cloud_client_secret_key" validate:"required"`
+	CloudTenantID        string                     `json:"cloud_tenant_id" validate:"required"`
+	CloudSubscriptionID  string                     `json:"cloud_subscription_id" validate:"required"`
+	CloudApplicationID   string                     `json:"cloud_application_id" validate:"omitempty"`
+	CloudClientSecretKey string                     `json:"cloud_client_secret_key" validate:"omitempty"`
+	CloudCredentialType  enumor.CloudCredentialType `json:"cloud_credential_type" validate:"omitempty"`
 }
 
 // Validate ...
 func (r *AzureAccountCheckReq) Validate() error {
-	return validator.Validate.Struct(r)
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	switch {
+	case r.CloudCredentialType.IsSecretKey():
+		if len(r.CloudApplicationID) == 0 || len(r.CloudClientSecretKey) == 0 {
+			return errors.New("cloud_application_id and cloud_client_secret_key is required")
+		}
+
+	case r.CloudCredentialType == enumor.ManagedIdentityCredential:
+
+	case r.CloudCredentialType == enumor.WorkloadIdentityCredential:
+		if len(r.CloudApplicationID) == 0 {
+			return errors.New("cloud_application_id is required")
+		}
+
+	default:
+		return fmt.Errorf("azure not support credential type: %s", r.CloudCredentialType)
+	}
+
+	return nil
 }
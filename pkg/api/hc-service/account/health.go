@@ -0,0 +1,50 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	typeaccount "hcm/pkg/adaptor/types/account"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// AccountHealthCheckReq check the health of the account by the stored credential.
+type AccountHealthCheckReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	// Region 用于探测地域级资源接口权限的地域，为空时使用各云默认地域
+	Region string `json:"region" validate:"omitempty"`
+}
+
+// Validate AccountHealthCheckReq.
+func (req *AccountHealthCheckReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AccountHealthCheckResult is the result of account health check.
+type AccountHealthCheckResult struct {
+	// MissingPermissions 资源同步所需但未授权的权限
+	MissingPermissions []typeaccount.MissingPermission `json:"missing_permissions"`
+}
+
+// AccountHealthCheckResp ...
+type AccountHealthCheckResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *AccountHealthCheckResult `json:"data"`
+}
@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"hcm/pkg/adaptor/types"
+	typeaccount "hcm/pkg/adaptor/types/account"
+	hcservice "hcm/pkg/api/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// TCloudOrganizationMemberListReq list tcloud organization members by the secret of the organization admin.
+type TCloudOrganizationMemberListReq struct {
+	CloudSecretID  string `json:"cloud_secret_id" validate:"required"`
+	CloudSecretKey string `json:"cloud_secret_key" validate:"required"`
+	// ParentID 组织节点ID，为空时查询整个集团组织下的成员
+	ParentID string `json:"parent_id" validate:"omitempty"`
+}
+
+// Validate TCloudOrganizationMemberListReq.
+func (req *TCloudOrganizationMemberListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AwsOrganizationMemberListReq list aws organization members by the secret of the management account.
+type AwsOrganizationMemberListReq struct {
+	CloudSecretID  string `json:"cloud_secret_id" validate:"required"`
+	CloudSecretKey string `json:"cloud_secret_key" validate:"required"`
+	// ParentID 组织根或OU的ID，为空时查询组织下的全部成员账号
+	ParentID string `json:"parent_id" validate:"omitempty"`
+}
+
+// Validate AwsOrganizationMemberListReq.
+func (req *AwsOrganizationMemberListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AzureOrganizationMemberListReq list azure subscriptions under the management group.
+type AzureOrganizationMemberListReq struct {
+	CloudTenantID        string                     `json:"cloud_tenant_id" validate:"required"`
+	CloudSubscriptionID  string                     `json:"cloud_subscription_id" validate:"required"`
+	CloudApplicationID   string                     `json:"cloud_application_id" validate:"omitempty"`
+	CloudClientSecretKey string                     `json:"cloud_client_secret_key" validate:"omitempty"`
+	CloudCredentialType  enumor.CloudCredentialType `json:"cloud_credential_type" validate:"omitempty"`
+	// ParentID 管理组ID，为空时查询凭证可访问的全部订阅
+	ParentID string `json:"parent_id" validate:"omitempty"`
+}
+
+// Validate AzureOrganizationMemberListReq.
+func (req *AzureOrganizationMemberListReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	credential := &hcservice.AzureAccountCheckReq{
+		CloudTenantID:        req.CloudTenantID,
+		CloudSubscriptionID:  req.CloudSubscriptionID,
+		CloudApplicationID:   req.CloudApplicationID,
+		CloudClientSecretKey: req.CloudClientSecretKey,
+		CloudCredentialType:  req.CloudCredentialType,
+	}
+	return credential.Validate()
+}
+
+// GcpOrganizationMemberListReq list gcp projects under the organization or folder.
+type GcpOrganizationMemberListReq struct {
+	CloudProjectID        string                     `json:"cloud_project_id" validate:"required"`
+	CloudServiceSecretKey string                     `json:"cloud_service_secret_key" validate:"omitempty"`
+	CloudCredentialType   enumor.CloudCredentialType `json:"cloud_credential_type" validate:"omitempty"`
+	// ParentID 组织或文件夹，格式为 organizations/{id} 或 folders/{id}，为空时查询凭证可访问的全部项目
+	ParentID string `json:"parent_id" validate:"omitempty"`
+}
+
+// Validate GcpOrganizationMemberListReq.
+func (req *GcpOrganizationMemberListReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	credential := &types.GcpCredential{
+		CloudProjectID: req.CloudProjectID,
+		Json:           []byte(req.CloudServiceSecretKey),
+		CredentialType: req.CloudCredentialType,
+	}
+	return credential.Validate()
+}
+
+// OrganizationMemberListResult is the result of list organization members.
+type OrganizationMemberListResult struct {
+	Details []typeaccount.OrganizationMember `json:"details"`
+}
+
+// OrganizationMemberListResp ...
+type OrganizationMemberListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *OrganizationMemberListResult `json:"data"`
+}
@@ -20,6 +20,8 @@
 package bill
 
 import (
+	typesBill "hcm/pkg/adaptor/types/bill"
+	"hcm/pkg/criteria/validator"
 	"hcm/pkg/rest"
 )
 
@@ -36,3 +38,57 @@ type AwsBillListResp struct {
 	rest.BaseResp `json:",inline"`
 	Data          *AwsBillListResult `json:"data"`
 }
+
+// -------------------------- DailyCost --------------------------
+
+// AwsDailyCostListReq define aws daily cost list req.
+type AwsDailyCostListReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	// 起始日期，格式为yyyy-mm-dd
+	BeginDate string `json:"begin_date" validate:"required"`
+	// 截止日期，格式为yyyy-mm-dd
+	EndDate string `json:"end_date" validate:"required"`
+}
+
+// Validate aws daily cost list req.
+func (opt AwsDailyCostListReq) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	return typesBill.AwsDailyCostOption{BeginDate: opt.BeginDate, EndDate: opt.EndDate}.Validate()
+}
+
+// AwsDailyCostListResp define aws daily cost list resp.
+type AwsDailyCostListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          []typesBill.AwsDailyCost `json:"data"`
+}
+
+// -------------------------- TopCostItem --------------------------
+
+// AwsTopCostItemListReq define aws top cost item list req.
+type AwsTopCostItemListReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	// 账单日期，格式为yyyy-mm-dd
+	BillDate string `json:"bill_date" validate:"required"`
+	Service  string `json:"service" validate:"omitempty"`
+	Region   string `json:"region" validate:"omitempty"`
+	Limit    uint   `json:"limit" validate:"required,min=1"`
+}
+
+// Validate aws top cost item list req.
+func (opt AwsTopCostItemListReq) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	return typesBill.AwsTopCostItemOption{BillDate: opt.BillDate, Service: opt.Service, Region: opt.Region,
+		Limit: opt.Limit}.Validate()
+}
+
+// AwsTopCostItemListResp define aws top cost item list resp.
+type AwsTopCostItemListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          []typesBill.AwsCostItem `json:"data"`
+}
@@ -73,9 +73,12 @@ type Setting interface {
 
 // ApiServerSetting defines api server used setting options.
 type ApiServerSetting struct {
-	Network Network   `yaml:"network"`
-	Service Service   `yaml:"service"`
-	Log     LogOption `yaml:"log"`
+	Network   Network   `yaml:"network"`
+	Service   Service   `yaml:"service"`
+	Log       LogOption `yaml:"log"`
+	Tracing   Tracing   `yaml:"tracing"`
+	RateLimit RateLimit `yaml:"rateLimit"`
+	GraphQL   GraphQL   `yaml:"graphql"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -88,6 +91,8 @@ func (s *ApiServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Tracing.trySetDefault()
+	s.GraphQL.trySetDefault()
 
 	return
 }
@@ -103,20 +108,36 @@ func (s ApiServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
+	if err := s.RateLimit.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // CloudServerSetting defines cloud server used setting options.
 type CloudServerSetting struct {
-	Network       Network       `yaml:"network"`
-	Service       Service       `yaml:"service"`
-	Log           LogOption     `yaml:"log"`
-	Crypto        Crypto        `yaml:"crypto"`
-	Esb           Esb           `yaml:"esb"`
-	BkHcmUrl      string        `yaml:"bkHcmUrl"`
-	CloudResource CloudResource `yaml:"cloudResource"`
-	Recycle       Recycle       `yaml:"recycle"`
-	BillConfig    BillConfig    `yaml:"billConfig"`
+	Network         Network         `yaml:"network"`
+	Service         Service         `yaml:"service"`
+	Log             LogOption       `yaml:"log"`
+	Tracing         Tracing         `yaml:"tracing"`
+	Crypto          Crypto          `yaml:"crypto"`
+	Esb             Esb             `yaml:"esb"`
+	BkHcmUrl        string          `yaml:"bkHcmUrl"`
+	CloudResource   CloudResource   `yaml:"cloudResource"`
+	Recycle         Recycle         `yaml:"recycle"`
+	BillConfig      BillConfig      `yaml:"billConfig"`
+	CostAnomaly     CostAnomaly     `yaml:"costAnomaly"`
+	AccountHealth   AccountHealth   `yaml:"accountHealth"`
+	RateLimit       RateLimit       `yaml:"rateLimit"`
+	Idempotency     Idempotency     `yaml:"idempotency"`
+	EventBus        EventBus        `yaml:"eventBus"`
+	ChangeFeed      ChangeFeed      `yaml:"changeFeed"`
+	ResourceHistory ResourceHistory `yaml:"resourceHistory"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -129,6 +150,13 @@ func (s *CloudServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Tracing.trySetDefault()
+	s.CostAnomaly.trySetDefault()
+	s.AccountHealth.trySetDefault()
+	s.Idempotency.trySetDefault()
+	s.EventBus.trySetDefault()
+	s.ChangeFeed.trySetDefault()
+	s.ResourceHistory.trySetDefault()
 
 	return
 }
@@ -144,6 +172,10 @@ func (s CloudServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
 	if err := s.Crypto.validate(); err != nil {
 		return err
 	}
@@ -163,6 +195,18 @@ func (s CloudServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.CostAnomaly.validate(); err != nil {
+		return err
+	}
+
+	if err := s.AccountHealth.validate(); err != nil {
+		return err
+	}
+
+	if err := s.RateLimit.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -171,9 +215,12 @@ type DataServiceSetting struct {
 	Network  Network   `yaml:"network"`
 	Service  Service   `yaml:"service"`
 	Log      LogOption `yaml:"log"`
+	Tracing  Tracing   `yaml:"tracing"`
 	Database DataBase  `yaml:"database"`
 	Crypto   Crypto    `yaml:"crypto"`
 	Esb      Esb       `yaml:"esb"`
+	// Migration 数据库表结构版本迁移配置
+	Migration Migration `yaml:"migration"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -186,7 +233,9 @@ func (s *DataServiceSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Tracing.trySetDefault()
 	s.Database.trySetDefault()
+	s.Migration.trySetDefault()
 
 	return
 }
@@ -201,6 +250,10 @@ func (s DataServiceSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
 	if err := s.Database.validate(); err != nil {
 		return err
 	}
@@ -218,9 +271,13 @@ func (s DataServiceSetting) Validate() error {
 
 // HCServiceSetting defines hc service used setting options.
 type HCServiceSetting struct {
-	Network Network   `yaml:"network"`
-	Service Service   `yaml:"service"`
-	Log     LogOption `yaml:"log"`
+	Network         Network         `yaml:"network"`
+	Service         Service         `yaml:"service"`
+	Log             LogOption       `yaml:"log"`
+	Tracing         Tracing         `yaml:"tracing"`
+	TrustedIdentity TrustedIdentity `yaml:"trustedIdentity"`
+	// CloudApiRateLimit 调用云厂商接口的限流配置
+	CloudApiRateLimit CloudApiRateLimit `yaml:"cloudApiRateLimit"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -233,6 +290,9 @@ func (s *HCServiceSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Tracing.trySetDefault()
+	s.TrustedIdentity.trySetDefault()
+	s.CloudApiRateLimit.trySetDefault()
 
 	return
 }
@@ -248,6 +308,18 @@ func (s HCServiceSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
+	if err := s.TrustedIdentity.validate(); err != nil {
+		return err
+	}
+
+	if err := s.CloudApiRateLimit.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -256,9 +328,11 @@ type AuthServerSetting struct {
 	Network Network   `yaml:"network"`
 	Service Service   `yaml:"service"`
 	Log     LogOption `yaml:"log"`
+	Tracing Tracing   `yaml:"tracing"`
 	Esb     Esb       `yaml:"esb"`
 
-	IAM IAM `yaml:"iam"`
+	Authorizer Authorizer `yaml:"authorizer"`
+	IAM        IAM        `yaml:"iam"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -271,6 +345,8 @@ func (s *AuthServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Tracing.trySetDefault()
+	s.Authorizer.trySetDefault()
 
 	return
 }
@@ -285,14 +361,25 @@ func (s AuthServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
 	if err := s.Esb.validate(); err != nil {
 		return err
 	}
 
-	if err := s.IAM.validate(); err != nil {
+	if err := s.Authorizer.validate(); err != nil {
 		return err
 	}
 
+	// iam settings are only required when authorize by iam
+	if s.Authorizer.Type == IAMAuthorizer {
+		if err := s.IAM.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -301,6 +388,7 @@ type WebServerSetting struct {
 	Network Network   `yaml:"network"`
 	Service Service   `yaml:"service"`
 	Log     LogOption `yaml:"log"`
+	Tracing Tracing   `yaml:"tracing"`
 	Web     Web       `yaml:"web"`
 	Esb     Esb       `yaml:"esb"`
 }
@@ -315,6 +403,8 @@ func (s *WebServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Tracing.trySetDefault()
+	s.Web.trySetDefault()
 
 	return
 }
@@ -330,6 +420,10 @@ func (s WebServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
 	if err := s.Web.validate(); err != nil {
 		return err
 	}
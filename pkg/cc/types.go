@@ -25,9 +25,12 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strings"
 	"time"
 
+	"hcm/pkg/cryptography"
 	"hcm/pkg/logs"
+	"hcm/pkg/tools/anomaly"
 	"hcm/pkg/tools/ssl"
 	"hcm/pkg/version"
 
@@ -156,9 +159,113 @@ func (lm *Limiter) trySetDefault() {
 	}
 }
 
+// RateLimit 接口限流配置，限流阈值为服务所有实例共享的总阈值，实例间通过etcd协调各自分担的阈值
+type RateLimit struct {
+	// Enable 是否开启接口限流
+	Enable bool `yaml:"enable"`
+	// Rules 限流规则，etcd中存在 /hcm/ratelimit/{服务名}/rules 时以etcd中的规则为准，且修改后实时生效
+	Rules []RateLimitRule `yaml:"rules"`
+}
+
+func (r RateLimit) validate() error {
+	if !r.Enable {
+		return nil
+	}
+
+	return ValidateRateLimitRules(r.Rules)
+}
+
+// RateLimitRule 限流规则，请求需满足所有匹配到的规则，每条规则按 KeyBy 的维度分别计数
+type RateLimitRule struct {
+	// Name 规则名称，唯一
+	Name string `yaml:"name"`
+	// AppCodes 匹配的应用，为空表示匹配所有应用
+	AppCodes []string `yaml:"appCodes"`
+	// Users 匹配的用户，为空表示匹配所有用户
+	Users []string `yaml:"users"`
+	// Methods 匹配的HTTP方法，为空表示匹配所有方法
+	Methods []string `yaml:"methods"`
+	// Paths 匹配的路径前缀，为空表示匹配所有路径
+	Paths []string `yaml:"paths"`
+	// KeyBy 计数维度，可选 app_code、user、route，为空表示所有匹配的请求共享计数
+	KeyBy []RateLimitKey `yaml:"keyBy"`
+	// Limit 每个 IntervalSec 时间窗口内允许的请求数
+	Limit uint `yaml:"limit"`
+	// IntervalSec 时间窗口，单位秒，默认为1，即 Limit 为QPS，设置为较大的值可作为配额使用
+	IntervalSec uint `yaml:"intervalSec"`
+	// Burst 允许的突发请求数，默认与 Limit 相同
+	Burst uint `yaml:"burst"`
+}
+
+// RateLimitKey is the dimension that rate limit rule counts requests by.
+type RateLimitKey string
+
+const (
+	// AppCodeRateLimitKey count requests by app code.
+	AppCodeRateLimitKey RateLimitKey = "app_code"
+	// UserRateLimitKey count requests by user.
+	UserRateLimitKey RateLimitKey = "user"
+	// RouteRateLimitKey count requests by http method and route.
+	RouteRateLimitKey RateLimitKey = "route"
+)
+
+// TrySetDefault set the rate limit rule default value if user not configured.
+func (r *RateLimitRule) TrySetDefault() {
+	if r.IntervalSec == 0 {
+		r.IntervalSec = 1
+	}
+
+	if r.Burst == 0 {
+		r.Burst = r.Limit
+	}
+}
+
+// Validate the rate limit rule.
+func (r RateLimitRule) Validate() error {
+	if len(r.Name) == 0 {
+		return errors.New("rate limit rule name is not set")
+	}
+
+	if r.Limit == 0 {
+		return fmt.Errorf("rate limit rule %s limit should >= 1", r.Name)
+	}
+
+	for _, key := range r.KeyBy {
+		switch key {
+		case AppCodeRateLimitKey, UserRateLimitKey, RouteRateLimitKey:
+		default:
+			return fmt.Errorf("rate limit rule %s key %s is invalid", r.Name, key)
+		}
+	}
+
+	return nil
+}
+
+// ValidateRateLimitRules validate the rate limit rules, rule names should be unique.
+func ValidateRateLimitRules(rules []RateLimitRule) error {
+	names := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+
+		if _, exists := names[rule.Name]; exists {
+			return fmt.Errorf("rate limit rule name %s is duplicated", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+	}
+
+	return nil
+}
+
 // DataBase defines database related runtime
 type DataBase struct {
 	Resource ResourceDB `yaml:"resource"`
+	// Replicas defines the read replicas of the resource database, reads outside transactions are routed to the
+	// healthy replicas. database, user and password of a replica are the same as the resource database if not set.
+	Replicas []ResourceDB `yaml:"replicas"`
+	// Replica defines the read replica routing options.
+	Replica ReplicaOption `yaml:"replica"`
 	// MaxSlowLogLatencyMS defines the max tolerance in millisecond to execute
 	// the database command, if the cost time of execute have >= the MaxSlowLogLatencyMS
 	// then this request will be logged.
@@ -172,6 +279,21 @@ type DataBase struct {
 func (s *DataBase) trySetDefault() {
 	s.Resource.trySetDefault()
 
+	for idx := range s.Replicas {
+		replica := &s.Replicas[idx]
+		if len(replica.Database) == 0 {
+			replica.Database = s.Resource.Database
+		}
+
+		if len(replica.User) == 0 {
+			replica.User = s.Resource.User
+			replica.Password = s.Resource.Password
+		}
+
+		replica.trySetDefault()
+	}
+	s.Replica.trySetDefault()
+
 	if s.MaxSlowLogLatencyMS == 0 {
 		s.MaxSlowLogLatencyMS = 100
 	}
@@ -189,6 +311,12 @@ func (s DataBase) validate() error {
 		return err
 	}
 
+	for idx, replica := range s.Replicas {
+		if err := replica.validate(); err != nil {
+			return fmt.Errorf("replicas[%d] is invalid, %v", idx, err)
+		}
+	}
+
 	if s.MaxSlowLogLatencyMS <= 0 {
 		return errors.New("invalid maxSlowLogLatencyMS")
 	}
@@ -202,6 +330,24 @@ func (s DataBase) validate() error {
 	return nil
 }
 
+// ReplicaOption defines the read replica routing options.
+type ReplicaOption struct {
+	// MaxLagSec is the max replication lag in seconds of a replica to accept reads.
+	MaxLagSec uint `yaml:"maxLagSec"`
+	// HealthCheckIntervalSec is the interval in seconds to check the health and replication lag of replicas.
+	HealthCheckIntervalSec uint `yaml:"healthCheckIntervalSec"`
+}
+
+func (r *ReplicaOption) trySetDefault() {
+	if r.MaxLagSec == 0 {
+		r.MaxLagSec = 10
+	}
+
+	if r.HealthCheckIntervalSec == 0 {
+		r.HealthCheckIntervalSec = 5
+	}
+}
+
 // ResourceDB defines database related runtime.
 type ResourceDB struct {
 	// Endpoints is a seed list of host:port addresses of database nodes.
@@ -292,6 +438,8 @@ type LogOption struct {
 	// at the same time.
 	AlsoToStdErr bool `yaml:"alsoToStdErr"`
 	Verbosity    uint `yaml:"verbosity"`
+	// print the log as json objects, so that the log pipeline can collect the fields like rid and user.
+	JSONFormat bool `yaml:"jsonFormat"`
 }
 
 // trySetDefault set the log's default value if user not configured.
@@ -324,11 +472,49 @@ func (log LogOption) Logs() logs.LogConfig {
 		ToStdErr:           log.ToStdErr,
 		AlsoToStdErr:       log.AlsoToStdErr,
 		Verbosity:          log.Verbosity,
+		JSONFormat:         log.JSONFormat,
+		Service:            string(ServiceName()),
 	}
 
 	return l
 }
 
+// Tracing 链路追踪配置，span 通过 OTLP gRPC 协议上报到 OpenTelemetry Collector
+type Tracing struct {
+	// Enable 是否上报 span，未开启时仍会透传上游的链路上下文
+	Enable bool `yaml:"enable"`
+	// Endpoint OTLP gRPC 上报地址，如本机部署的 collector：127.0.0.1:4317
+	Endpoint string `yaml:"endpoint"`
+	// Insecure 是否不使用 TLS 连接上报地址
+	Insecure bool `yaml:"insecure"`
+	// SampleRatio 新链路的采样比例，取值范围(0, 1]，下游服务沿用上游的采样结果
+	SampleRatio float64 `yaml:"sampleRatio"`
+}
+
+// trySetDefault set the tracing's default value if user not configured.
+func (t *Tracing) trySetDefault() {
+	if len(t.Endpoint) == 0 {
+		t.Endpoint = "127.0.0.1:4317"
+	}
+
+	if t.SampleRatio == 0 {
+		t.SampleRatio = 1
+	}
+}
+
+// validate tracing options.
+func (t Tracing) validate() error {
+	if !t.Enable {
+		return nil
+	}
+
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		return fmt.Errorf("tracing.sampleRatio %v is invalid, should be in (0, 1]", t.SampleRatio)
+	}
+
+	return nil
+}
+
 // Network defines all the network related options
 type Network struct {
 	// BindIP is ip where server working on
@@ -463,6 +649,38 @@ func (s IAM) validate() error {
 	return nil
 }
 
+// AuthorizerType is the type of the authorizer used by auth-server.
+type AuthorizerType string
+
+const (
+	// IAMAuthorizer authorize by blueking iam.
+	IAMAuthorizer AuthorizerType = "iam"
+	// RbacAuthorizer authorize by the built-in rbac authorizer, roles and role bindings are stored in data-service.
+	RbacAuthorizer AuthorizerType = "rbac"
+)
+
+// Authorizer 鉴权方式配置
+type Authorizer struct {
+	// Type 鉴权方式，支持iam（蓝鲸权限中心）、rbac（内置的基于角色的权限模型），默认为iam
+	Type AuthorizerType `yaml:"type"`
+}
+
+func (a *Authorizer) trySetDefault() {
+	if len(a.Type) == 0 {
+		a.Type = IAMAuthorizer
+	}
+}
+
+func (a Authorizer) validate() error {
+	switch a.Type {
+	case IAMAuthorizer, RbacAuthorizer:
+	default:
+		return fmt.Errorf("unsupported authorizer.type: %s", a.Type)
+	}
+
+	return nil
+}
+
 // Web 服务依赖所需特有配置， 包括登录、静态文件等配置的定义
 type Web struct {
 	StaticFileDirPath string `yaml:"staticFileDirPath"`
@@ -471,10 +689,17 @@ type Web struct {
 	BkLoginUrl        string `yaml:"bkLoginUrl"`
 	BkComponentApiUrl string `yaml:"bkComponentApiUrl"`
 	BkItsmUrl         string `yaml:"bkItsmUrl"`
+
+	// Login 用户登录认证配置，默认使用蓝鲸统一登录
+	Login Login `yaml:"login"`
+}
+
+func (s *Web) trySetDefault() {
+	s.Login.trySetDefault()
 }
 
 func (s Web) validate() error {
-	if len(s.BkLoginUrl) == 0 {
+	if s.Login.Type == BkLogin && len(s.BkLoginUrl) == 0 {
 		return errors.New("bk_login_url is not set")
 	}
 
@@ -486,6 +711,122 @@ func (s Web) validate() error {
 		return errors.New("bk_itsm_url is not set")
 	}
 
+	if err := s.Login.validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// LoginType is the type of the web-server user login authenticator.
+type LoginType string
+
+const (
+	// BkLogin login by blueking login, bk_token or bk_ticket cookie is verified.
+	BkLogin LoginType = "bk"
+	// OIDCLogin login by OpenID Connect provider with authorization code flow.
+	OIDCLogin LoginType = "oidc"
+)
+
+// Login 用户登录认证配置
+type Login struct {
+	// Type 登录认证方式，支持bk（蓝鲸统一登录）、oidc（OpenID Connect单点登录），默认为bk
+	Type LoginType `yaml:"type"`
+	OIDC OIDC      `yaml:"oidc"`
+}
+
+func (l *Login) trySetDefault() {
+	if len(l.Type) == 0 {
+		l.Type = BkLogin
+	}
+
+	if l.Type == OIDCLogin {
+		l.OIDC.trySetDefault()
+	}
+}
+
+func (l Login) validate() error {
+	switch l.Type {
+	case BkLogin:
+	case OIDCLogin:
+		if err := l.OIDC.validate(); err != nil {
+			return fmt.Errorf("login.oidc validate failed, err: %v", err)
+		}
+	default:
+		return fmt.Errorf("unsupported login.type: %s", l.Type)
+	}
+
+	return nil
+}
+
+// OIDC OpenID Connect单点登录配置
+type OIDC struct {
+	// Issuer 身份提供方地址，通过 {issuer}/.well-known/openid-configuration 获取服务端点
+	Issuer       string `yaml:"issuer"`
+	ClientID     string `yaml:"clientID"`
+	ClientSecret string `yaml:"clientSecret"`
+	// RedirectURL 登录回调地址，需配置为 {hcm访问地址}/sso/callback
+	RedirectURL string `yaml:"redirectURL"`
+	// Scopes 申请的scope，默认为 openid profile email groups
+	Scopes []string `yaml:"scopes"`
+	// UsernameClaim 作为hcm用户名的claim，默认为 preferred_username
+	UsernameClaim string `yaml:"usernameClaim"`
+	// GroupsClaim 用户组claim，默认为 groups
+	GroupsClaim string `yaml:"groupsClaim"`
+	// GroupRoles 用户组与内置权限模型角色的映射，登录时按用户所属的用户组授予或回收对应角色
+	GroupRoles map[string][]string `yaml:"groupRoles"`
+	// SessionSecret 会话Cookie签名密钥，长度不能小于32
+	SessionSecret string `yaml:"sessionSecret"`
+	// SessionTTLSec 会话有效期，单位秒，默认8小时
+	SessionTTLSec uint `yaml:"sessionTTLSec"`
+	// PostLogoutRedirectURL 退出登录后跳转的地址，默认为hcm首页
+	PostLogoutRedirectURL string    `yaml:"postLogoutRedirectURL"`
+	TLS                   TLSConfig `yaml:"tls"`
+}
+
+func (o *OIDC) trySetDefault() {
+	if len(o.Scopes) == 0 {
+		o.Scopes = []string{"openid", "profile", "email", "groups"}
+	}
+
+	if len(o.UsernameClaim) == 0 {
+		o.UsernameClaim = "preferred_username"
+	}
+
+	if len(o.GroupsClaim) == 0 {
+		o.GroupsClaim = "groups"
+	}
+
+	if o.SessionTTLSec == 0 {
+		o.SessionTTLSec = 8 * 3600
+	}
+
+	if len(o.PostLogoutRedirectURL) == 0 {
+		o.PostLogoutRedirectURL = "/"
+	}
+}
+
+func (o OIDC) validate() error {
+	if len(o.Issuer) == 0 {
+		return errors.New("issuer is not set")
+	}
+
+	if len(o.ClientID) == 0 {
+		return errors.New("clientID is not set")
+	}
+
+	if len(o.RedirectURL) == 0 {
+		return errors.New("redirectURL is not set")
+	}
+
+	if len(o.SessionSecret) < 32 {
+		return errors.New("sessionSecret should be at least 32 characters")
+	}
+
+	if err := o.TLS.validate(); err != nil {
+		return fmt.Errorf("tls validate failed, err: %v", err)
+	}
+
 	return nil
 }
 
@@ -541,19 +882,75 @@ func (a AesGcm) validate() error {
 }
 
 // Crypto 定义项目里需要用到的加密，包括选择的算法等
-// TODO: 这里默认只支持AES Gcm算法，后续需要支持国密等的选择，可能还需要支持根据不同场景配置不同（比如不同场景，加密的密钥等都不一样）
 type Crypto struct {
+	// Algorithm 信封加密的数据加密算法，支持aes-gcm、sm4-gcm（国密），默认为aes-gcm，仅在配置了kms时生效
+	Algorithm string `yaml:"algorithm"`
+	// AesGcm 未配置kms时使用的加密配置，配置了kms后仅用于解密启用信封加密前加密的数据
 	AesGcm AesGcm `yaml:"aesGcm"`
+	// Kms 信封加密使用的密钥管理服务配置，为空表示不启用信封加密
+	Kms Kms `yaml:"kms"`
 }
 
 func (c Crypto) validate() error {
-	if err := c.AesGcm.validate(); err != nil {
+	if len(c.Kms.Type) == 0 {
+		if err := c.AesGcm.validate(); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if len(c.AesGcm.Key) != 0 {
+		if err := c.AesGcm.validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(c.Algorithm) != 0 {
+		if err := cryptography.Algorithm(c.Algorithm).Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Kms.validate(); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// CryptoOption returns the option to create the crypto.
+func (c Crypto) CryptoOption() *cryptography.Option {
+	return &cryptography.Option{
+		Algorithm:   cryptography.Algorithm(c.Algorithm),
+		KMSType:     cryptography.KMSType(c.Kms.Type),
+		KMSSource:   c.Kms.KeyFile,
+		LegacyKey:   c.AesGcm.Key,
+		LegacyNonce: c.AesGcm.Nonce,
+	}
+}
+
+// Kms 密钥管理服务配置
+type Kms struct {
+	// Type kms类型，目前仅支持local，即从本地密钥文件加载密钥
+	Type string `yaml:"type"`
+	// KeyFile local类型kms的密钥文件路径
+	KeyFile string `yaml:"keyFile"`
+}
+
+func (k Kms) validate() error {
+	switch cryptography.KMSType(k.Type) {
+	case cryptography.LocalKMS:
+		if len(k.KeyFile) == 0 {
+			return errors.New("crypto.kms.keyFile is required when kms type is local")
+		}
+	default:
+		return fmt.Errorf("unsupported crypto.kms.type: %s", k.Type)
+	}
+
+	return nil
+}
+
 // CloudResource 云资源配置
 type CloudResource struct {
 	Sync CloudResourceSync `yaml:"sync"`
@@ -610,3 +1007,358 @@ func (c BillConfig) validate() error {
 
 	return nil
 }
+
+// CostAnomaly 云账单费用异常检测配置
+type CostAnomaly struct {
+	Enable bool `yaml:"enable"`
+	// DetectIntervalMin 检测间隔，单位：分钟
+	DetectIntervalMin uint64 `yaml:"detectIntervalMin"`
+	// WindowDays 计算费用基线的滚动窗口天数，需不小于两个周季节周期（14天）
+	WindowDays int `yaml:"windowDays"`
+	// MinHistoryDays 参与检测所需的最少历史天数
+	MinHistoryDays int `yaml:"minHistoryDays"`
+	// LookbackDays 每次检测回溯的天数，用于补齐云上账单延迟出账的数据
+	LookbackDays int `yaml:"lookbackDays"`
+	// RatioThreshold 实际费用与预期费用的比值阈值
+	RatioThreshold float64 `yaml:"ratioThreshold"`
+	// ScoreThreshold 实际费用偏离基线的鲁棒z-score阈值
+	ScoreThreshold float64 `yaml:"scoreThreshold"`
+	// MinCostDelta 实际费用比预期费用最少的增长值，小于该值的波动将被忽略
+	MinCostDelta float64 `yaml:"minCostDelta"`
+	// TopItemLimit 异常记录中保存的费用最高的资源明细数量，未配置时默认为10
+	TopItemLimit uint `yaml:"topItemLimit"`
+	// AlertWebhooks 发现新的费用异常时通知的webhook地址
+	AlertWebhooks []string `yaml:"alertWebhooks"`
+}
+
+func (c *CostAnomaly) trySetDefault() {
+	if c.DetectIntervalMin == 0 {
+		c.DetectIntervalMin = 360
+	}
+
+	if c.WindowDays == 0 {
+		c.WindowDays = 28
+	}
+
+	if c.MinHistoryDays == 0 {
+		c.MinHistoryDays = 14
+	}
+
+	if c.LookbackDays == 0 {
+		c.LookbackDays = 3
+	}
+
+	if c.RatioThreshold == 0 {
+		c.RatioThreshold = 2
+	}
+
+	if c.ScoreThreshold == 0 {
+		c.ScoreThreshold = 3
+	}
+
+	if c.MinCostDelta == 0 {
+		c.MinCostDelta = 10
+	}
+
+	if c.TopItemLimit == 0 {
+		c.TopItemLimit = 10
+	}
+}
+
+func (c CostAnomaly) validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if c.WindowDays <= 0 || c.MinHistoryDays <= 0 || c.MinHistoryDays > c.WindowDays {
+		return errors.New("costAnomaly.minHistoryDays should be in (0, costAnomaly.windowDays]")
+	}
+
+	// 基线按周季节性调整，窗口需覆盖至少两个周期
+	if season := anomaly.DefaultOption().SeasonDays; c.WindowDays < 2*season {
+		return fmt.Errorf("costAnomaly.windowDays must >= %d, twice the weekly season days", 2*season)
+	}
+
+	if c.LookbackDays <= 0 {
+		return errors.New("costAnomaly.lookbackDays must > 0")
+	}
+
+	if c.RatioThreshold <= 1 {
+		return errors.New("costAnomaly.ratioThreshold must > 1")
+	}
+
+	if c.ScoreThreshold <= 0 {
+		return errors.New("costAnomaly.scoreThreshold must > 0")
+	}
+
+	if c.MinCostDelta < 0 {
+		return errors.New("costAnomaly.minCostDelta must >= 0")
+	}
+
+	if c.TopItemLimit > 100 {
+		return errors.New("costAnomaly.topItemLimit must <= 100")
+	}
+
+	for _, hook := range c.AlertWebhooks {
+		if !strings.HasPrefix(hook, "http://") && !strings.HasPrefix(hook, "https://") {
+			return fmt.Errorf("costAnomaly.alertWebhooks %s is invalid, should start with http:// or https://", hook)
+		}
+	}
+
+	return nil
+}
+
+// TrustedIdentity 平台自身的可信身份，使用短期凭证（角色扮演、托管身份、联合身份）的云账号通过该身份获取访问凭证
+type TrustedIdentity struct {
+	Aws   AwsTrustedIdentity   `yaml:"aws"`
+	Azure AzureTrustedIdentity `yaml:"azure"`
+}
+
+// AwsTrustedIdentity 用于扮演云账号下角色的 Aws 身份，密钥为空时使用进程默认的凭证链（环境变量、共享配置、
+// Web Identity Token、EC2/ECS 实例角色）
+type AwsTrustedIdentity struct {
+	SecretID  string `yaml:"secretID"`
+	SecretKey string `yaml:"secretKey"`
+	// Region sts 服务所在地域
+	Region string `yaml:"region"`
+	// SessionDurationMin 扮演角色获取的临时凭证有效期，单位：分钟
+	SessionDurationMin uint `yaml:"sessionDurationMin"`
+}
+
+// AzureTrustedIdentity 用于 Azure 工作负载联合身份的配置
+type AzureTrustedIdentity struct {
+	// FederatedTokenFile 联合身份的 OIDC Token 文件路径，默认取环境变量 AZURE_FEDERATED_TOKEN_FILE
+	FederatedTokenFile string `yaml:"federatedTokenFile"`
+}
+
+func (t *TrustedIdentity) trySetDefault() {
+	if len(t.Aws.Region) == 0 {
+		t.Aws.Region = "us-east-1"
+	}
+
+	if t.Aws.SessionDurationMin == 0 {
+		t.Aws.SessionDurationMin = 60
+	}
+
+	if len(t.Azure.FederatedTokenFile) == 0 {
+		t.Azure.FederatedTokenFile = os.Getenv("AZURE_FEDERATED_TOKEN_FILE")
+	}
+}
+
+func (t TrustedIdentity) validate() error {
+	if (len(t.Aws.SecretID) == 0) != (len(t.Aws.SecretKey) == 0) {
+		return errors.New("trustedIdentity.aws.secretID and secretKey should be set at the same time")
+	}
+
+	// aws sts AssumeRole 的临时凭证有效期范围为 15 分钟到 12 小时
+	if t.Aws.SessionDurationMin < 15 || t.Aws.SessionDurationMin > 720 {
+		return errors.New("trustedIdentity.aws.sessionDurationMin should be in [15, 720]")
+	}
+
+	return nil
+}
+
+// Idempotency 写接口幂等键配置，携带 Idempotency-Key 请求头的写请求只会执行一次，重放时返回已保存的响应
+type Idempotency struct {
+	// ProcessingTimeoutSec 请求处理超时时间，超时后仍未完成的请求的幂等键可被重新使用，单位：秒
+	ProcessingTimeoutSec uint `yaml:"processingTimeoutSec"`
+	// RetentionHour 请求成功后响应的保留时间，单位：小时
+	RetentionHour uint `yaml:"retentionHour"`
+}
+
+func (i *Idempotency) trySetDefault() {
+	if i.ProcessingTimeoutSec == 0 {
+		i.ProcessingTimeoutSec = 600
+	}
+
+	if i.RetentionHour == 0 {
+		i.RetentionHour = 24
+	}
+}
+
+// EventBus 资源生命周期事件的webhook投递配置，事件按订阅的游标顺序投递，重试多次后仍失败的事件进入死信队列
+type EventBus struct {
+	Enable bool `yaml:"enable"`
+	// IntervalSec 拉取待投递事件的时间间隔，单位：秒
+	IntervalSec uint `yaml:"intervalSec"`
+	// BatchSize 每个订阅每次拉取的事件数量
+	BatchSize uint `yaml:"batchSize"`
+	// MaxAttempts 每个事件的最大投递次数，超过后事件进入死信队列
+	MaxAttempts uint `yaml:"maxAttempts"`
+	// TimeoutSec 投递请求的超时时间，单位：秒
+	TimeoutSec uint `yaml:"timeoutSec"`
+	// RetentionDay 事件的保留时间，超过后事件被清理且无法重放，单位：天
+	RetentionDay uint `yaml:"retentionDay"`
+}
+
+func (e *EventBus) trySetDefault() {
+	if e.IntervalSec == 0 {
+		e.IntervalSec = 5
+	}
+
+	if e.BatchSize == 0 {
+		e.BatchSize = 100
+	}
+
+	if e.MaxAttempts == 0 {
+		e.MaxAttempts = 5
+	}
+
+	if e.TimeoutSec == 0 {
+		e.TimeoutSec = 10
+	}
+
+	if e.RetentionDay == 0 {
+		e.RetentionDay = 7
+	}
+}
+
+// CloudApiRateLimit 调用云厂商接口的限流配置，同一云账号同一类接口（如腾讯云的cvm、vpc，aws的ec2，azure的
+// Microsoft.Compute）的调用共享令牌桶，被云厂商限流时自适应降低调用频率并暂停调用
+type CloudApiRateLimit struct {
+	Enable bool `yaml:"enable"`
+	// QPS 默认每秒调用次数
+	QPS float64 `yaml:"qps"`
+	// Burst 默认最大突发调用次数，默认与 QPS 相同
+	Burst uint `yaml:"burst"`
+	// MaxBackoffSec 被云厂商限流后暂停调用的最长时间，单位：秒
+	MaxBackoffSec uint `yaml:"maxBackoffSec"`
+	// Rules 按云厂商或接口类型覆盖默认的限流配置
+	Rules []CloudApiRateLimitRule `yaml:"rules"`
+}
+
+// CloudApiRateLimitRule 云厂商或接口类型的限流配置
+type CloudApiRateLimitRule struct {
+	Vendor string `yaml:"vendor"`
+	// Family 接口类型，为空表示该云厂商的所有接口
+	Family string  `yaml:"family"`
+	QPS    float64 `yaml:"qps"`
+	Burst  uint    `yaml:"burst"`
+}
+
+func (c *CloudApiRateLimit) trySetDefault() {
+	if c.QPS == 0 {
+		c.QPS = 20
+	}
+
+	if c.MaxBackoffSec == 0 {
+		c.MaxBackoffSec = 60
+	}
+}
+
+func (c CloudApiRateLimit) validate() error {
+	if c.QPS < 0 {
+		return errors.New("cloudApiRateLimit.qps should >= 0")
+	}
+
+	for _, rule := range c.Rules {
+		if len(rule.Vendor) == 0 {
+			return errors.New("cloudApiRateLimit.rules.vendor is required")
+		}
+
+		if rule.QPS <= 0 {
+			return fmt.Errorf("cloudApiRateLimit rule of %s %s qps should > 0", rule.Vendor, rule.Family)
+		}
+	}
+
+	return nil
+}
+
+// AccountHealth 云账号凭证健康检查配置
+type AccountHealth struct {
+	Enable bool `yaml:"enable"`
+	// CheckIntervalMin 检查间隔，单位：分钟
+	CheckIntervalMin uint64 `yaml:"checkIntervalMin"`
+	// FailureThreshold 账号连续检查失败多少次后告警
+	FailureThreshold uint64 `yaml:"failureThreshold"`
+	// AlertWebhooks 账号健康状态变化时通知的webhook地址
+	AlertWebhooks []string `yaml:"alertWebhooks"`
+}
+
+func (a *AccountHealth) trySetDefault() {
+	if a.CheckIntervalMin == 0 {
+		a.CheckIntervalMin = 60
+	}
+
+	if a.FailureThreshold == 0 {
+		a.FailureThreshold = 3
+	}
+}
+
+func (a AccountHealth) validate() error {
+	if !a.Enable {
+		return nil
+	}
+
+	for _, hook := range a.AlertWebhooks {
+		if !strings.HasPrefix(hook, "http://") && !strings.HasPrefix(hook, "https://") {
+			return fmt.Errorf("accountHealth.alertWebhooks %s is invalid, should start with http:// or https://", hook)
+		}
+	}
+
+	return nil
+}
+
+// ChangeFeed 资源变更订阅配置
+type ChangeFeed struct {
+	// RetentionDay 资源变更记录的保留时间，超过后被清理，消费方需在该时间内消费变更，单位：天
+	RetentionDay uint `yaml:"retentionDay"`
+	// MaxWaitSec 变更订阅接口无新变更时的最大等待时间，单位：秒
+	MaxWaitSec uint `yaml:"maxWaitSec"`
+}
+
+func (c *ChangeFeed) trySetDefault() {
+	if c.RetentionDay == 0 {
+		c.RetentionDay = 7
+	}
+
+	if c.MaxWaitSec == 0 {
+		c.MaxWaitSec = 30
+	}
+}
+
+// ResourceHistory 资源变更历史配置
+type ResourceHistory struct {
+	// RetentionDay 资源变更历史的保留时间，超过后被清理，早于该时间的资源状态无法查询，单位：天
+	RetentionDay uint `yaml:"retentionDay"`
+}
+
+func (h *ResourceHistory) trySetDefault() {
+	if h.RetentionDay == 0 {
+		h.RetentionDay = 30
+	}
+}
+
+// GraphQL graphql 查询网关配置
+type GraphQL struct {
+	// MaxDepth 查询语句的最大嵌套层数，防止过深的关联查询
+	MaxDepth uint `yaml:"maxDepth"`
+	// MaxListLimit 列表查询单次返回的最大资源数量，该数量也是关联资源批量查询的批次大小
+	MaxListLimit uint `yaml:"maxListLimit"`
+}
+
+func (g *GraphQL) trySetDefault() {
+	if g.MaxDepth == 0 {
+		g.MaxDepth = 5
+	}
+
+	if g.MaxListLimit == 0 {
+		g.MaxListLimit = 100
+	}
+}
+
+// Migration 数据库表结构版本迁移配置
+type Migration struct {
+	// Enable 是否在服务启动时自动执行未执行的版本迁移脚本
+	Enable bool `yaml:"enable"`
+	// BaselineVersion 版本记录表为空时，认为小于等于该版本的迁移脚本已被手动执行，只记录版本不执行，用于已部署的环境接入版本迁移
+	BaselineVersion uint `yaml:"baselineVersion"`
+	// LockTimeoutSec 等待数据库迁移锁的超时时间，多个实例同时启动时只有一个实例执行迁移，单位：秒
+	LockTimeoutSec uint `yaml:"lockTimeoutSec"`
+}
+
+func (m *Migration) trySetDefault() {
+	if m.LockTimeoutSec == 0 {
+		m.LockTimeoutSec = 60
+	}
+}
@@ -66,6 +66,28 @@ func (c *Client) InitAuthCenter(ctx context.Context, h http.Header, request *aut
 	return err
 }
 
+// InitRbac init built-in rbac's builtin roles by the auth model.
+func (c *Client) InitRbac(ctx context.Context, h http.Header, request *authserver.InitRbacReq) error {
+	resp := new(rest.BaseResp)
+
+	err := c.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/init/rbac").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
 // PullResource iam pull resource callback.
 func (c *Client) PullResource(ctx context.Context, h http.Header, request *types.PullResourceReq) (
 	interface{}, error) {
@@ -67,6 +67,31 @@ func (a *AccountClient) Create(ctx context.Context, h http.Header,
 	return resp.Data, nil
 }
 
+// BatchCreate accounts in one transaction.
+func (a *AccountClient) BatchCreate(ctx context.Context, h http.Header,
+	request *protocloud.AccountBatchCreateReq[protocloud.AwsAccountExtensionCreateReq]) (
+	*core.BatchCreateResult, error,
+) {
+	resp := new(core.BatchCreateResp)
+
+	err := a.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/accounts/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
 // Update ...
 func (a *AccountClient) Update(ctx context.Context, h http.Header, accountID string,
 	request *protocloud.AccountUpdateReq[protocloud.AwsAccountExtensionUpdateReq]) (
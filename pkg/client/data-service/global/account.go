@@ -207,3 +207,51 @@ func (a *AccountClient) ListAccountBizRel(ctx context.Context, h http.Header, re
 
 	return resp.Data, nil
 }
+
+// BatchRecordHealth batch record account health check result.
+func (a *AccountClient) BatchRecordHealth(ctx context.Context, h http.Header,
+	req *protocloud.AccountHealthBatchRecordReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := a.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/accounts/health/batch/record").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListHealth list account health.
+func (a *AccountClient) ListHealth(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protocloud.AccountHealthListResult, error) {
+
+	resp := new(protocloud.AccountHealthListResp)
+
+	err := a.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/accounts/health/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
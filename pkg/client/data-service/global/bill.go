@@ -87,3 +87,75 @@ func (b *BillClient) BatchDelete(ctx context.Context, h http.Header, req *datase
 
 	return nil
 }
+
+// BatchCreateCostAnomaly batch create cost anomaly.
+func (b *BillClient) BatchCreateCostAnomaly(ctx context.Context, h http.Header,
+	req *datacloudbillproto.CostAnomalyBatchCreateReq) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/bills/cost_anomalies/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateCostAnomaly batch update cost anomaly.
+func (b *BillClient) BatchUpdateCostAnomaly(ctx context.Context, h http.Header,
+	req *datacloudbillproto.CostAnomalyBatchUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := b.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/bills/cost_anomalies/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListCostAnomaly list cost anomaly.
+func (b *BillClient) ListCostAnomaly(ctx context.Context, h http.Header, req *core.ListReq) (
+	*datacloudbillproto.CostAnomalyListResult, error) {
+
+	resp := new(datacloudbillproto.CostAnomalyListResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/bills/cost_anomalies/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
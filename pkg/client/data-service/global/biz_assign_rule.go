@@ -0,0 +1,163 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	corebizassignrule "hcm/pkg/api/core/biz-assign-rule"
+	protobizassignrule "hcm/pkg/api/data-service/biz-assign-rule"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// BizAssignRuleClient is data service biz assign rule api client.
+type BizAssignRuleClient struct {
+	client rest.ClientInterface
+}
+
+// NewBizAssignRuleClient create a new biz assign rule api client.
+func NewBizAssignRuleClient(client rest.ClientInterface) *BizAssignRuleClient {
+	return &BizAssignRuleClient{
+		client: client,
+	}
+}
+
+// CreateBizAssignRule create biz assign rule.
+func (b *BizAssignRuleClient) CreateBizAssignRule(ctx context.Context, h http.Header,
+	req *protobizassignrule.BizAssignRuleCreateReq) (*core.CreateResult, error) {
+
+	resp := new(core.CreateResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/biz_assign_rules/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// UpdateBizAssignRule update biz assign rule.
+func (b *BizAssignRuleClient) UpdateBizAssignRule(ctx context.Context, h http.Header, id string,
+	req *protobizassignrule.BizAssignRuleUpdateReq) error {
+
+	resp := new(core.UpdateResp)
+
+	err := b.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/biz_assign_rules/%s", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListBizAssignRule list biz assign rule.
+func (b *BizAssignRuleClient) ListBizAssignRule(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protobizassignrule.BizAssignRuleListResult, error) {
+
+	resp := new(protobizassignrule.BizAssignRuleListResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/biz_assign_rules/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// DeleteBizAssignRule delete biz assign rule.
+func (b *BizAssignRuleClient) DeleteBizAssignRule(ctx context.Context, h http.Header,
+	req *protobizassignrule.BizAssignRuleDeleteReq) error {
+
+	resp := new(core.DeleteResp)
+
+	err := b.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/biz_assign_rules/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ApplyBizAssignRule apply biz assign rule, returns the matched resources.
+func (b *BizAssignRuleClient) ApplyBizAssignRule(ctx context.Context, h http.Header,
+	req *protobizassignrule.BizAssignRuleApplyReq) (*corebizassignrule.ApplyResult, error) {
+
+	resp := new(protobizassignrule.BizAssignRuleApplyResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/biz_assign_rules/apply").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
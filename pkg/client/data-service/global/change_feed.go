@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	protochangefeed "hcm/pkg/api/data-service/change-feed"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// ChangeFeedClient is data service resource change feed api client.
+type ChangeFeedClient struct {
+	client rest.ClientInterface
+}
+
+// NewChangeFeedClient create a new resource change feed api client.
+func NewChangeFeedClient(client rest.ClientInterface) *ChangeFeedClient {
+	return &ChangeFeedClient{
+		client: client,
+	}
+}
+
+// ListChangeFeed list change feed.
+func (c *ChangeFeedClient) ListChangeFeed(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protochangefeed.ChangeFeedListResult, error) {
+
+	resp := new(protochangefeed.ChangeFeedListResp)
+
+	err := c.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/change_feeds/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// DeleteChangeFeed delete change feed.
+func (c *ChangeFeedClient) DeleteChangeFeed(ctx context.Context, h http.Header,
+	req *protochangefeed.ChangeFeedDeleteReq) (*protochangefeed.ChangeFeedDeleteResult, error) {
+
+	resp := new(protochangefeed.ChangeFeedDeleteResp)
+
+	err := c.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/change_feeds/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
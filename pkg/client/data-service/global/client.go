@@ -48,6 +48,15 @@ type Client struct {
 	Application     *ApplicationClient
 	ApprovalProcess *ApprovalProcessClient
 	Bill            *BillClient
+	Rbac            *RbacClient
+	Token           *TokenClient
+	Idempotency     *IdempotencyClient
+	Event           *EventClient
+	ChangeFeed      *ChangeFeedClient
+	ResourceHistory *ResourceHistoryClient
+	ResourceTag     *ResourceTagClient
+	BizAssignRule   *BizAssignRuleClient
+	TagPolicy       *TagPolicyClient
 }
 
 type restClient struct {
@@ -79,5 +88,14 @@ func NewClient(client rest.ClientInterface) *Client {
 		Application:     NewApplicationClient(client),
 		ApprovalProcess: NewApprovalProcessClient(client),
 		Bill:            NewBillClient(client),
+		Rbac:            NewRbacClient(client),
+		Token:           NewTokenClient(client),
+		Idempotency:     NewIdempotencyClient(client),
+		Event:           NewEventClient(client),
+		ChangeFeed:      NewChangeFeedClient(client),
+		ResourceHistory: NewResourceHistoryClient(client),
+		ResourceTag:     NewResourceTagClient(client),
+		BizAssignRule:   NewBizAssignRuleClient(client),
+		TagPolicy:       NewTagPolicyClient(client),
 	}
 }
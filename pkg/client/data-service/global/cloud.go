@@ -23,6 +23,7 @@ import (
 	"context"
 	"net/http"
 
+	"hcm/pkg/api/core"
 	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
@@ -114,3 +115,27 @@ func (cli *CloudClient) AssignResourceToBiz(ctx context.Context, h http.Header,
 
 	return nil
 }
+
+// AggregateResource aggregate cloud resources grouped by the specified fields.
+func (cli *CloudClient) AggregateResource(ctx context.Context, h http.Header, resType enumor.CloudResourceType,
+	req *core.AggregateReq) (*core.AggregateResult, error) {
+
+	resp := new(core.AggregateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/cloud/resources/aggregate/%s", resType).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
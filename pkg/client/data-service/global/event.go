@@ -0,0 +1,254 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	protoevent "hcm/pkg/api/data-service/event"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// EventClient is data service resource lifecycle event and event subscription api client.
+type EventClient struct {
+	client rest.ClientInterface
+}
+
+// NewEventClient create a new resource lifecycle event and event subscription api client.
+func NewEventClient(client rest.ClientInterface) *EventClient {
+	return &EventClient{
+		client: client,
+	}
+}
+
+// ListEvent list event.
+func (e *EventClient) ListEvent(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protoevent.EventListResult, error) {
+
+	resp := new(protoevent.EventListResp)
+
+	err := e.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/events/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// DeleteEvent delete event.
+func (e *EventClient) DeleteEvent(ctx context.Context, h http.Header, req *protoevent.EventDeleteReq) (
+	*protoevent.EventDeleteResult, error) {
+
+	resp := new(protoevent.EventDeleteResp)
+
+	err := e.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/events/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// CreateSubscription create event subscription.
+func (e *EventClient) CreateSubscription(ctx context.Context, h http.Header, req *protoevent.SubscriptionCreateReq) (
+	*core.CreateResult, error) {
+
+	resp := new(core.CreateResp)
+
+	err := e.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/event_subscriptions/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// UpdateSubscription update event subscription.
+func (e *EventClient) UpdateSubscription(ctx context.Context, h http.Header, id string,
+	req *protoevent.SubscriptionUpdateReq) error {
+
+	resp := new(core.UpdateResp)
+
+	err := e.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/event_subscriptions/%s", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListSubscription list event subscription.
+func (e *EventClient) ListSubscription(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protoevent.SubscriptionListResult, error) {
+
+	resp := new(protoevent.SubscriptionListResp)
+
+	err := e.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/event_subscriptions/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// DeleteSubscription delete event subscription and the dead letters of the subscriptions.
+func (e *EventClient) DeleteSubscription(ctx context.Context, h http.Header,
+	req *protoevent.SubscriptionDeleteReq) error {
+
+	resp := new(core.DeleteResp)
+
+	err := e.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/event_subscriptions/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// CreateDeadLetter create event dead letter.
+func (e *EventClient) CreateDeadLetter(ctx context.Context, h http.Header, req *protoevent.DeadLetterCreateReq) error {
+	resp := new(core.CreateResp)
+
+	err := e.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/event_dead_letters/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListDeadLetter list event dead letter.
+func (e *EventClient) ListDeadLetter(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protoevent.DeadLetterListResult, error) {
+
+	resp := new(protoevent.DeadLetterListResp)
+
+	err := e.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/event_dead_letters/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// DeleteDeadLetter delete event dead letter.
+func (e *EventClient) DeleteDeadLetter(ctx context.Context, h http.Header, req *protoevent.DeadLetterDeleteReq) error {
+	resp := new(core.DeleteResp)
+
+	err := e.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/event_dead_letters/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
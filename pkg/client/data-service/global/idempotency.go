@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	protoidem "hcm/pkg/api/data-service/idempotency"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// IdempotencyClient is data service idempotency record api client.
+type IdempotencyClient struct {
+	client rest.ClientInterface
+}
+
+// NewIdempotencyClient create a new idempotency record api client.
+func NewIdempotencyClient(client rest.ClientInterface) *IdempotencyClient {
+	return &IdempotencyClient{
+		client: client,
+	}
+}
+
+// AcquireRecord acquire idempotency record.
+func (i *IdempotencyClient) AcquireRecord(ctx context.Context, h http.Header, req *protoidem.RecordAcquireReq) (
+	*protoidem.RecordAcquireResult, error) {
+
+	resp := new(protoidem.RecordAcquireResp)
+
+	err := i.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/idempotency_records/acquire").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// CompleteRecord complete idempotency record.
+func (i *IdempotencyClient) CompleteRecord(ctx context.Context, h http.Header, req *protoidem.RecordCompleteReq) error {
+	resp := new(core.UpdateResp)
+
+	err := i.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/idempotency_records/complete").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ReleaseRecord release idempotency record.
+func (i *IdempotencyClient) ReleaseRecord(ctx context.Context, h http.Header, req *protoidem.RecordReleaseReq) error {
+	resp := new(core.DeleteResp)
+
+	err := i.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/idempotency_records/release").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// DeleteExpiredRecord delete expired idempotency records.
+func (i *IdempotencyClient) DeleteExpiredRecord(ctx context.Context, h http.Header) (
+	*protoidem.RecordDeleteExpiredResult, error) {
+
+	resp := new(protoidem.RecordDeleteExpiredResp)
+
+	err := i.client.Delete().
+		WithContext(ctx).
+		SubResourcef("/idempotency_records/expired").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
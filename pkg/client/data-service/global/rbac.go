@@ -0,0 +1,204 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	protorbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// RbacClient is data service built-in rbac api client.
+type RbacClient struct {
+	client rest.ClientInterface
+}
+
+// NewRbacClient create a new built-in rbac api client.
+func NewRbacClient(client rest.ClientInterface) *RbacClient {
+	return &RbacClient{
+		client: client,
+	}
+}
+
+// CreateRole create rbac role.
+func (r *RbacClient) CreateRole(ctx context.Context, h http.Header, req *protorbac.RoleCreateReq) (
+	*core.CreateResult, error) {
+
+	resp := new(core.CreateResp)
+
+	err := r.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/rbac/roles/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// UpdateRole update rbac role.
+func (r *RbacClient) UpdateRole(ctx context.Context, h http.Header, id string, req *protorbac.RoleUpdateReq) error {
+	resp := new(core.UpdateResp)
+
+	err := r.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/rbac/roles/%s", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListRole list rbac role.
+func (r *RbacClient) ListRole(ctx context.Context, h http.Header, req *core.ListReq) (*protorbac.RoleListResult,
+	error) {
+
+	resp := new(protorbac.RoleListResp)
+
+	err := r.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/rbac/roles/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// DeleteRole delete rbac role and the bindings of the roles.
+func (r *RbacClient) DeleteRole(ctx context.Context, h http.Header, req *protorbac.RoleDeleteReq) error {
+	resp := new(core.DeleteResp)
+
+	err := r.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/rbac/roles/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// BatchCreateRoleBinding batch create rbac role binding.
+func (r *RbacClient) BatchCreateRoleBinding(ctx context.Context, h http.Header,
+	req *protorbac.RoleBindingBatchCreateReq) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := r.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/rbac/role_bindings/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// ListRoleBinding list rbac role binding.
+func (r *RbacClient) ListRoleBinding(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protorbac.RoleBindingListResult, error) {
+
+	resp := new(protorbac.RoleBindingListResp)
+
+	err := r.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/rbac/role_bindings/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// DeleteRoleBinding delete rbac role binding.
+func (r *RbacClient) DeleteRoleBinding(ctx context.Context, h http.Header, req *protorbac.RoleBindingDeleteReq) error {
+	resp := new(core.DeleteResp)
+
+	err := r.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/rbac/role_bindings/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
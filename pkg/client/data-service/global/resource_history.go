@@ -0,0 +1,115 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	corehistory "hcm/pkg/api/core/resource-history"
+	protohistory "hcm/pkg/api/data-service/resource-history"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/rest"
+)
+
+// ResourceHistoryClient is data service resource change history api client.
+type ResourceHistoryClient struct {
+	client rest.ClientInterface
+}
+
+// NewResourceHistoryClient create a new resource change history api client.
+func NewResourceHistoryClient(client rest.ClientInterface) *ResourceHistoryClient {
+	return &ResourceHistoryClient{
+		client: client,
+	}
+}
+
+// ListResourceHistory list the change histories of a resource.
+func (c *ResourceHistoryClient) ListResourceHistory(ctx context.Context, h http.Header, resType table.Name,
+	resID string, req *protohistory.ResourceHistoryListReq) (*protohistory.ResourceHistoryListResult, error) {
+
+	resp := new(protohistory.ResourceHistoryListResp)
+
+	err := c.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/resource_histories/%s/%s/list", resType, resID).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// GetResourceState get the state of a resource at the specified time.
+func (c *ResourceHistoryClient) GetResourceState(ctx context.Context, h http.Header, resType table.Name,
+	resID string, req *protohistory.ResourceStateReq) (*corehistory.ResourceState, error) {
+
+	resp := new(protohistory.ResourceStateResp)
+
+	err := c.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/resource_histories/%s/%s/state", resType, resID).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// DeleteResourceHistory delete resource history.
+func (c *ResourceHistoryClient) DeleteResourceHistory(ctx context.Context, h http.Header,
+	req *protohistory.ResourceHistoryDeleteReq) (*protohistory.ResourceHistoryDeleteResult, error) {
+
+	resp := new(protohistory.ResourceHistoryDeleteResp)
+
+	err := c.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/resource_histories/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	protoresourcetag "hcm/pkg/api/data-service/resource-tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// ResourceTagClient is data service resource tag api client.
+type ResourceTagClient struct {
+	client rest.ClientInterface
+}
+
+// NewResourceTagClient create a new resource tag api client.
+func NewResourceTagClient(client rest.ClientInterface) *ResourceTagClient {
+	return &ResourceTagClient{
+		client: client,
+	}
+}
+
+// ListResourceTag list resource tag.
+func (c *ResourceTagClient) ListResourceTag(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protoresourcetag.ResourceTagListResult, error) {
+
+	resp := new(protoresourcetag.ResourceTagListResp)
+
+	err := c.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/resource_tags/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// ReplaceResourceTag replace all the tags of the resources.
+func (c *ResourceTagClient) ReplaceResourceTag(ctx context.Context, h http.Header,
+	req *protoresourcetag.ResourceTagReplaceReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := c.client.Put().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/resource_tags/replace").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
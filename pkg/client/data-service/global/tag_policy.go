@@ -0,0 +1,187 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	coretagpolicy "hcm/pkg/api/core/tag-policy"
+	prototagpolicy "hcm/pkg/api/data-service/tag-policy"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// TagPolicyClient is data service tag policy api client.
+type TagPolicyClient struct {
+	client rest.ClientInterface
+}
+
+// NewTagPolicyClient create a new tag policy api client.
+func NewTagPolicyClient(client rest.ClientInterface) *TagPolicyClient {
+	return &TagPolicyClient{
+		client: client,
+	}
+}
+
+// CreateTagPolicy create tag policy.
+func (t *TagPolicyClient) CreateTagPolicy(ctx context.Context, h http.Header,
+	req *prototagpolicy.TagPolicyCreateReq) (*core.CreateResult, error) {
+
+	resp := new(core.CreateResp)
+
+	err := t.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/tag_policies/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// UpdateTagPolicy update tag policy.
+func (t *TagPolicyClient) UpdateTagPolicy(ctx context.Context, h http.Header, id string,
+	req *prototagpolicy.TagPolicyUpdateReq) error {
+
+	resp := new(core.UpdateResp)
+
+	err := t.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/tag_policies/%s", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListTagPolicy list tag policy.
+func (t *TagPolicyClient) ListTagPolicy(ctx context.Context, h http.Header, req *core.ListReq) (
+	*prototagpolicy.TagPolicyListResult, error) {
+
+	resp := new(prototagpolicy.TagPolicyListResp)
+
+	err := t.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/tag_policies/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// DeleteTagPolicy delete tag policy.
+func (t *TagPolicyClient) DeleteTagPolicy(ctx context.Context, h http.Header,
+	req *prototagpolicy.TagPolicyDeleteReq) error {
+
+	resp := new(core.DeleteResp)
+
+	err := t.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/tag_policies/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// EvaluateTagPolicy evaluate the tags of the account's resources against the tag policies.
+func (t *TagPolicyClient) EvaluateTagPolicy(ctx context.Context, h http.Header,
+	req *prototagpolicy.TagPolicyEvaluateReq) (*coretagpolicy.EvaluateResult, error) {
+
+	resp := new(prototagpolicy.TagPolicyEvaluateResp)
+
+	err := t.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/tag_policies/evaluate").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// ListTagPolicyViolation list tag policy violation.
+func (t *TagPolicyClient) ListTagPolicyViolation(ctx context.Context, h http.Header, req *core.ListReq) (
+	*prototagpolicy.ViolationListResult, error) {
+
+	resp := new(prototagpolicy.ViolationListResp)
+
+	err := t.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/tag_policies/violations/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
@@ -0,0 +1,256 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	prototoken "hcm/pkg/api/data-service/token"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// TokenClient is data service api token and service account api client.
+type TokenClient struct {
+	client rest.ClientInterface
+}
+
+// NewTokenClient create a new api token and service account api client.
+func NewTokenClient(client rest.ClientInterface) *TokenClient {
+	return &TokenClient{
+		client: client,
+	}
+}
+
+// CreateApiToken create api token.
+func (t *TokenClient) CreateApiToken(ctx context.Context, h http.Header, req *prototoken.ApiTokenCreateReq) (
+	*core.CreateResult, error) {
+
+	resp := new(core.CreateResp)
+
+	err := t.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/api_tokens/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// UpdateApiToken update api token.
+func (t *TokenClient) UpdateApiToken(ctx context.Context, h http.Header, id string,
+	req *prototoken.ApiTokenUpdateReq) error {
+
+	resp := new(core.UpdateResp)
+
+	err := t.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/api_tokens/%s", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// UpdateApiTokenLastUsed update api token last used info.
+func (t *TokenClient) UpdateApiTokenLastUsed(ctx context.Context, h http.Header, id string,
+	req *prototoken.ApiTokenLastUsedUpdateReq) error {
+
+	resp := new(core.UpdateResp)
+
+	err := t.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/api_tokens/%s/last_used", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListApiToken list api token.
+func (t *TokenClient) ListApiToken(ctx context.Context, h http.Header, req *core.ListReq) (
+	*prototoken.ApiTokenListResult, error) {
+
+	resp := new(prototoken.ApiTokenListResp)
+
+	err := t.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/api_tokens/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// DeleteApiToken delete api token.
+func (t *TokenClient) DeleteApiToken(ctx context.Context, h http.Header, req *prototoken.ApiTokenDeleteReq) error {
+	resp := new(core.DeleteResp)
+
+	err := t.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/api_tokens/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// CreateServiceAccount create service account.
+func (t *TokenClient) CreateServiceAccount(ctx context.Context, h http.Header,
+	req *prototoken.ServiceAccountCreateReq) (*core.CreateResult, error) {
+
+	resp := new(core.CreateResp)
+
+	err := t.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/service_accounts/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// UpdateServiceAccount update service account.
+func (t *TokenClient) UpdateServiceAccount(ctx context.Context, h http.Header, id string,
+	req *prototoken.ServiceAccountUpdateReq) error {
+
+	resp := new(core.UpdateResp)
+
+	err := t.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/service_accounts/%s", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListServiceAccount list service account.
+func (t *TokenClient) ListServiceAccount(ctx context.Context, h http.Header, req *core.ListReq) (
+	*prototoken.ServiceAccountListResult, error) {
+
+	resp := new(prototoken.ServiceAccountListResp)
+
+	err := t.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/service_accounts/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// DeleteServiceAccount delete service account and the api tokens of the service accounts.
+func (t *TokenClient) DeleteServiceAccount(ctx context.Context, h http.Header,
+	req *prototoken.ServiceAccountDeleteReq) error {
+
+	resp := new(core.DeleteResp)
+
+	err := t.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/service_accounts/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
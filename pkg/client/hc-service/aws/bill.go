@@ -23,6 +23,7 @@ import (
 	"context"
 	"net/http"
 
+	typesBill "hcm/pkg/adaptor/types/bill"
 	hcbillservice "hcm/pkg/api/hc-service/bill"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/rest"
@@ -108,3 +109,51 @@ func (v *BillClient) BillPipeline(ctx context.Context, h http.Header, req *hcbil
 
 	return nil
 }
+
+// ListDailyCost list daily cost aggregated by service and region.
+func (v *BillClient) ListDailyCost(ctx context.Context, h http.Header, req *hcbillservice.AwsDailyCostListReq) (
+	[]typesBill.AwsDailyCost, error) {
+
+	resp := new(hcbillservice.AwsDailyCostListResp)
+
+	err := v.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/bills/daily_costs/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// ListTopCostItem list line items with the highest cost of one day.
+func (v *BillClient) ListTopCostItem(ctx context.Context, h http.Header, req *hcbillservice.AwsTopCostItemListReq) (
+	[]typesBill.AwsCostItem, error) {
+
+	resp := new(hcbillservice.AwsTopCostItemListResp)
+
+	err := v.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/bills/top_cost_items/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
@@ -39,6 +39,7 @@ type Client struct {
 	InstanceType     *InstanceTypeClient
 	NetworkInterface *NetworkInterfaceClient
 	Bill             *BillClient
+	ResourceTag      *ResourceTagClient
 }
 
 // NewClient create a new gcp api client.
@@ -58,5 +59,6 @@ func NewClient(client rest.ClientInterface) *Client {
 		InstanceType:     NewInstanceTypeClient(client),
 		NetworkInterface: NewNetworkInterfaceClient(client),
 		Bill:             NewBillClient(client),
+		ResourceTag:      NewResourceTagClient(client),
 	}
 }
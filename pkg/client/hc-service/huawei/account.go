@@ -89,3 +89,27 @@ func (a *AccountClient) GetRegionQuota(ctx context.Context, h http.Header,
 
 	return resp.Data, nil
 }
+
+// HealthCheck check account health by the stored credential, and list the missing permissions of resource sync.
+func (a *AccountClient) HealthCheck(ctx context.Context, h http.Header,
+	request *protoaccount.AccountHealthCheckReq) (*protoaccount.AccountHealthCheckResult, error) {
+
+	resp := new(protoaccount.AccountHealthCheckResp)
+
+	err := a.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/accounts/health/check").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"context"
+	"net/http"
+
+	protoresourcetag "hcm/pkg/api/hc-service/resource-tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// NewResourceTagClient create a new resource tag api client.
+func NewResourceTagClient(client rest.ClientInterface) *ResourceTagClient {
+	return &ResourceTagClient{
+		client: client,
+	}
+}
+
+// ResourceTagClient is hc service resource tag api client.
+type ResourceTagClient struct {
+	client rest.ClientInterface
+}
+
+// UpdateResourceTag update the tags of the resource on cloud and in db.
+func (cli *ResourceTagClient) UpdateResourceTag(ctx context.Context, h http.Header,
+	req *protoresourcetag.ResourceTagUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Put().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/resource_tags/update").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// AddResourceTag add the tags to the resource on cloud and in db, the other tags of the resource are kept.
+func (cli *ResourceTagClient) AddResourceTag(ctx context.Context, h http.Header,
+	req *protoresourcetag.ResourceTagAddReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Put().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/resource_tags/add").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
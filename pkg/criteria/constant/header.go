@@ -43,6 +43,10 @@ const (
 
 	// RequestSourceKey is blueking hcm request source header key.
 	RequestSourceKey = "X-Bkhcm-Request-Source"
+
+	// ReadPrimaryKey is the header key to force the db reads of the request to be routed to the primary database,
+	// the value should be "true".
+	ReadPrimaryKey = "X-Bkhcm-Read-Primary"
 )
 
 // const for timing sync
@@ -65,3 +69,100 @@ const (
 	// SyncTimingListAzureRegion sync timing list azure region filter
 	SyncTimingListAzureRegion = "Region"
 )
+
+// const for auth-server fetching resource instances by attribute policy
+const (
+	// AuthAttrFetcherUserKey auth attribute fetcher UserKey
+	AuthAttrFetcherUserKey = "hcm-backend-auth"
+
+	// AuthAttrFetcherAppCodeKey auth attribute fetcher AppCodeKey
+	AuthAttrFetcherAppCodeKey = "hcm"
+)
+
+// const for hcm issued api token
+const (
+	// ApiTokenKey is the header key of hcm issued api token, "Authorization: Bearer {token}" is also supported.
+	ApiTokenKey = "X-Bkhcm-Api-Token"
+
+	// ApiTokenPrefix is the prefix of hcm issued api token.
+	ApiTokenPrefix = "hcm_"
+
+	// ApiTokenAppCodeKey is the app code of requests using hcm issued api token.
+	ApiTokenAppCodeKey = "hcm-api-token"
+
+	// ApiTokenValidatorUserKey api token validator UserKey
+	ApiTokenValidatorUserKey = "hcm-backend-token"
+
+	// ServiceAccountPrefix is the name prefix of service account, used to distinguish service accounts from users.
+	ServiceAccountPrefix = "sa-"
+)
+
+// const for web-server syncing user roles by sso groups
+const (
+	// SSORoleSyncUserKey sso role sync UserKey
+	SSORoleSyncUserKey = "hcm-backend-sso"
+
+	// SSORoleSyncAppCodeKey sso role sync AppCodeKey
+	SSORoleSyncAppCodeKey = "hcm-web-server"
+)
+
+// const for idempotency key of mutating requests
+const (
+	// IdempotencyKey is the header key of the idempotency key, requests with the same key are executed only once.
+	IdempotencyKey = "Idempotency-Key"
+
+	// IdempotentReplayedKey is the response header key which is set to "true" when the response is replayed.
+	IdempotentReplayedKey = "Idempotent-Replayed"
+
+	// IdempotencyCleanerUserKey idempotency record cleaner UserKey
+	IdempotencyCleanerUserKey = "hcm-backend-idempotency"
+
+	// IdempotencyCleanerAppCodeKey idempotency record cleaner AppCodeKey
+	IdempotencyCleanerAppCodeKey = "hcm"
+
+	// ChangeFeedCleanerUserKey change feed cleaner UserKey
+	ChangeFeedCleanerUserKey = "hcm-backend-change-feed"
+
+	// ChangeFeedCleanerAppCodeKey change feed cleaner AppCodeKey
+	ChangeFeedCleanerAppCodeKey = "hcm"
+
+	// ChangeFeedSequencerUserKey change feed sequencer UserKey
+	ChangeFeedSequencerUserKey = "hcm-backend-change-feed"
+
+	// ChangeFeedSequencerAppCodeKey change feed sequencer AppCodeKey
+	ChangeFeedSequencerAppCodeKey = "hcm"
+
+	// EventSequencerUserKey event sequencer UserKey
+	EventSequencerUserKey = "hcm-backend-event"
+
+	// EventSequencerAppCodeKey event sequencer AppCodeKey
+	EventSequencerAppCodeKey = "hcm"
+
+	// ResourceHistoryCleanerUserKey resource history cleaner UserKey
+	ResourceHistoryCleanerUserKey = "hcm-backend-resource-history"
+
+	// ResourceHistoryCleanerAppCodeKey resource history cleaner AppCodeKey
+	ResourceHistoryCleanerAppCodeKey = "hcm"
+)
+
+// const for webhook delivery of resource lifecycle events
+const (
+	// EventIDKey is the webhook request header key of the delivered event id.
+	EventIDKey = "X-Bkhcm-Event-Id"
+
+	// EventTypeKey is the webhook request header key of the delivered event type.
+	EventTypeKey = "X-Bkhcm-Event-Type"
+
+	// EventTimestampKey is the webhook request header key of the unix timestamp when the event is signed.
+	EventTimestampKey = "X-Bkhcm-Event-Timestamp"
+
+	// EventSignatureKey is the webhook request header key of the event signature, which is formatted as
+	// sha256=hex(hmac_sha256(secret, timestamp + "." + body)).
+	EventSignatureKey = "X-Bkhcm-Event-Signature"
+
+	// EventDispatcherUserKey event dispatcher UserKey
+	EventDispatcherUserKey = "hcm-backend-event"
+
+	// EventDispatcherAppCodeKey event dispatcher AppCodeKey
+	EventDispatcherAppCodeKey = "hcm"
+)
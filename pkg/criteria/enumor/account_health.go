@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// AccountHealthStatus is the health status of the cloud account's credential.
+type AccountHealthStatus string
+
+// Validate AccountHealthStatus.
+func (s AccountHealthStatus) Validate() error {
+	switch s {
+	case HealthyAccountStatus:
+	case MissingPermissionAccountStatus:
+	case UnavailableAccountStatus:
+	default:
+		return fmt.Errorf("unsupported account health status: %s", s)
+	}
+
+	return nil
+}
+
+// IsHealthy return whether the account is healthy or not.
+func (s AccountHealthStatus) IsHealthy() bool {
+	return s == HealthyAccountStatus
+}
+
+const (
+	// HealthyAccountStatus the credential is valid and has all the permissions required to sync resource.
+	HealthyAccountStatus AccountHealthStatus = "healthy"
+	// MissingPermissionAccountStatus the credential is valid but lack some permissions required to sync resource.
+	MissingPermissionAccountStatus AccountHealthStatus = "missing_permission"
+	// UnavailableAccountStatus the credential is invalid, such as the secret is disabled, deleted or expired.
+	UnavailableAccountStatus AccountHealthStatus = "unavailable"
+)
@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// CostAnomalyDimension is the dimension of the daily cost series which the cost anomaly is detected on.
+type CostAnomalyDimension string
+
+// Validate CostAnomalyDimension.
+func (d CostAnomalyDimension) Validate() error {
+	switch d {
+	case AccountCostDimension:
+	case ServiceCostDimension:
+	case RegionCostDimension:
+	default:
+		return fmt.Errorf("unsupported cost anomaly dimension: %s", d)
+	}
+
+	return nil
+}
+
+const (
+	// AccountCostDimension is the total daily cost of one account.
+	AccountCostDimension CostAnomalyDimension = "account"
+	// ServiceCostDimension is the daily cost of one service (such as AmazonEC2) of one account.
+	ServiceCostDimension CostAnomalyDimension = "service"
+	// RegionCostDimension is the daily cost of one region of one account.
+	RegionCostDimension CostAnomalyDimension = "region"
+)
+
+// CostAnomalyStatus is the handling status of the cost anomaly.
+type CostAnomalyStatus string
+
+// Validate CostAnomalyStatus.
+func (s CostAnomalyStatus) Validate() error {
+	switch s {
+	case OpenCostAnomalyStatus:
+	case AcknowledgedCostAnomalyStatus:
+	case ResolvedCostAnomalyStatus:
+	default:
+		return fmt.Errorf("unsupported cost anomaly status: %s", s)
+	}
+
+	return nil
+}
+
+const (
+	// OpenCostAnomalyStatus cost anomaly is detected but not handled yet.
+	OpenCostAnomalyStatus CostAnomalyStatus = "open"
+	// AcknowledgedCostAnomalyStatus cost anomaly is confirmed by someone.
+	AcknowledgedCostAnomalyStatus CostAnomalyStatus = "acknowledged"
+	// ResolvedCostAnomalyStatus cost anomaly is resolved or regarded as expected.
+	ResolvedCostAnomalyStatus CostAnomalyStatus = "resolved"
+)
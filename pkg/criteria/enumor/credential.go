@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// CloudCredentialType is the type of credential that hcm used to access the cloud account.
+type CloudCredentialType string
+
+// Validate CloudCredentialType.
+func (c CloudCredentialType) Validate() error {
+	switch c {
+	case SecretKeyCredential:
+	case AssumeRoleCredential:
+	case ManagedIdentityCredential:
+	case WorkloadIdentityCredential:
+	default:
+		return fmt.Errorf("unsupported cloud credential type: %s", c)
+	}
+
+	return nil
+}
+
+// ValidateVendor validate the CloudCredentialType is supported by the vendor or not.
+func (c CloudCredentialType) ValidateVendor(vendor Vendor) error {
+	if c == SecretKeyCredential {
+		return nil
+	}
+
+	supported := map[Vendor][]CloudCredentialType{
+		Aws:   {AssumeRoleCredential},
+		Azure: {ManagedIdentityCredential, WorkloadIdentityCredential},
+		Gcp:   {WorkloadIdentityCredential},
+	}
+
+	for _, one := range supported[vendor] {
+		if one == c {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cloud credential type: %s is not supported by vendor: %s", c, vendor)
+}
+
+// IsSecretKey return if the credential is long-lived secret key, empty credential type is regarded as secret key to be
+// compatible with the account created before.
+func (c CloudCredentialType) IsSecretKey() bool {
+	return len(c) == 0 || c == SecretKeyCredential
+}
+
+const (
+	// SecretKeyCredential 使用长期有效的密钥（AK/SK、Client Secret、Service Account Json）访问云账号。
+	SecretKeyCredential CloudCredentialType = "secret_key"
+	// AssumeRoleCredential 由平台自身的可信身份扮演云账号下的角色，获取短期凭证访问云账号，仅 Aws 支持。
+	AssumeRoleCredential CloudCredentialType = "assume_role"
+	// ManagedIdentityCredential 使用平台所在云主机/容器的托管身份访问云账号，仅 Azure 支持。
+	ManagedIdentityCredential CloudCredentialType = "managed_identity"
+	// WorkloadIdentityCredential 使用平台工作负载的联合身份（OIDC Token）换取短期凭证访问云账号，Azure、Gcp 支持。
+	WorkloadIdentityCredential CloudCredentialType = "workload_identity"
+)
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// TagValueCase is the case rule of the tag value in tag policy.
+type TagValueCase string
+
+// Validate TagValueCase.
+func (c TagValueCase) Validate() error {
+	switch c {
+	case AnyTagValueCase:
+	case LowerTagValueCase:
+	case UpperTagValueCase:
+	default:
+		return fmt.Errorf("unsupported tag value case: %s", c)
+	}
+
+	return nil
+}
+
+const (
+	// AnyTagValueCase do not limit the case of the tag value.
+	AnyTagValueCase TagValueCase = ""
+	// LowerTagValueCase requires the tag value to be lower case.
+	LowerTagValueCase TagValueCase = "lower"
+	// UpperTagValueCase requires the tag value to be upper case.
+	UpperTagValueCase TagValueCase = "upper"
+)
+
+// TagViolationReason is the reason why the tags of a resource violate the tag policy.
+type TagViolationReason string
+
+const (
+	// MissingTagViolation the required tag key is missing.
+	MissingTagViolation TagViolationReason = "missing"
+	// KeyCaseTagViolation the tag key exists but its case is different from the policy's tag key.
+	KeyCaseTagViolation TagViolationReason = "invalid_key_case"
+	// ValueCaseTagViolation the tag value does not match the case rule of the policy.
+	ValueCaseTagViolation TagViolationReason = "invalid_value_case"
+	// ValueTagViolation the tag value does not match the allowed value pattern of the policy.
+	ValueTagViolation TagViolationReason = "invalid_value"
+)
@@ -55,4 +55,10 @@ const (
 	DoAuthorizeFailed int32 = 2000007
 	// PartialFailed means batch operation is partially failed.
 	PartialFailed int32 = 2000008
+	// IdempotencyConflict means the idempotency key is used by a request with different payload, or the request
+	// with the same idempotency key is still in progress.
+	IdempotencyConflict int32 = 2000009
+	// ChangeFeedCursorExpired means the changes after the change feed cursor have been cleaned, the consumer should
+	// do a full resync and watch from the latest cursor.
+	ChangeFeedCursorExpired int32 = 2000010
 )
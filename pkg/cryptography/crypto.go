@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import "fmt"
+
+// Option is the option to create the Crypto used by the project.
+type Option struct {
+	// Algorithm is the data encryption algorithm of envelope encryption, default is aes-gcm.
+	Algorithm Algorithm
+	// KMSType is the type of kms, envelope encryption is enabled only when it is set.
+	KMSType KMSType
+	// KMSSource is the source of kms keys, such as the key file path of local kms.
+	KMSSource string
+	// LegacyKey and LegacyNonce is the aes gcm key and nonce used before envelope encryption is enabled,
+	// they are used to decrypt the existing data when envelope encryption is enabled.
+	LegacyKey   string
+	LegacyNonce string
+}
+
+// NewCrypto create the Crypto by option, returns the legacy aes gcm Crypto if kms is not set, otherwise returns
+// the envelope encryption Crypto.
+func NewCrypto(opt *Option) (Crypto, error) {
+	var legacy Crypto
+	if len(opt.LegacyKey) != 0 {
+		aesGcm, err := NewAESGcm([]byte(opt.LegacyKey), []byte(opt.LegacyNonce))
+		if err != nil {
+			return nil, fmt.Errorf("new legacy aes gcm crypto failed, err: %v", err)
+		}
+		legacy = aesGcm
+	}
+
+	if len(opt.KMSType) == 0 {
+		if legacy == nil {
+			return nil, fmt.Errorf("aes gcm key is required when kms is not set")
+		}
+		return legacy, nil
+	}
+
+	kms, err := NewKMS(opt.KMSType, opt.KMSSource)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm := opt.Algorithm
+	if len(algorithm) == 0 {
+		algorithm = AesGcm
+	}
+
+	return NewEnvelope(kms, algorithm, legacy)
+}
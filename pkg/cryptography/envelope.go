@@ -0,0 +1,251 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/TencentBlueKing/gopkg/conv"
+)
+
+const (
+	// envelopePrefix is the prefix of the encrypted text of envelope encryption, the version is used for later
+	// format changes. the text which does not have this prefix is encrypted by the legacy crypto.
+	envelopePrefix = "hcm:v1:"
+	// envelopeSeparator separates the fields of the envelope encrypted text.
+	envelopeSeparator = ":"
+)
+
+// Envelope is the Crypto of envelope encryption. every process generates a random data key, which is encrypted
+// by the primary key of kms, every value is encrypted by the data key with a random nonce, the encrypted text is
+// in the format of: hcm:v1:{algorithm}:{key id}:{base64 encrypted data key}:{base64 nonce + ciphertext}.
+type Envelope struct {
+	kms       KMS
+	algorithm Algorithm
+	// legacy is used to decrypt the text encrypted before envelope encryption is enabled, it can be nil.
+	legacy Crypto
+
+	keyID        string
+	encryptedKey string
+	aead         cipher.AEAD
+
+	// dataKeys caches the decrypted data keys' cipher, key is {key id}:{base64 encrypted data key}.
+	lock     sync.RWMutex
+	dataKeys map[string]cipher.AEAD
+}
+
+// NewEnvelope create an envelope encryption Crypto, legacy is used to decrypt the text which is not encrypted by
+// envelope encryption, it can be nil if there is no such data.
+func NewEnvelope(kms KMS, algorithm Algorithm, legacy Crypto) (*Envelope, error) {
+	if kms == nil {
+		return nil, errors.New("kms is required")
+	}
+
+	if err := algorithm.Validate(); err != nil {
+		return nil, err
+	}
+
+	dataKey := make([]byte, algorithm.KeySize())
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, fmt.Errorf("generate data key failed, err: %v", err)
+	}
+
+	aead, err := newAEAD(algorithm, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	keyID := kms.PrimaryKeyID()
+	encryptedKey, err := kms.EncryptKey(keyID, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt data key by kms key %s failed, err: %v", keyID, err)
+	}
+
+	e := &Envelope{
+		kms:          kms,
+		algorithm:    algorithm,
+		legacy:       legacy,
+		keyID:        keyID,
+		encryptedKey: base64.StdEncoding.EncodeToString(encryptedKey),
+		aead:         aead,
+		dataKeys:     make(map[string]cipher.AEAD),
+	}
+	e.dataKeys[keyID+envelopeSeparator+e.encryptedKey] = aead
+
+	return e, nil
+}
+
+// Encrypt plaintext to envelope encrypted text.
+func (e *Envelope) Encrypt(plaintext []byte) []byte {
+	return conv.StringToBytes(e.encrypt(plaintext))
+}
+
+// Decrypt envelope encrypted text or legacy encrypted text.
+func (e *Envelope) Decrypt(encryptedText []byte) ([]byte, error) {
+	if !strings.HasPrefix(conv.BytesToString(encryptedText), envelopePrefix) {
+		if e.legacy == nil {
+			return nil, errors.New("encrypted text is not envelope encrypted and legacy crypto is not set")
+		}
+		return e.legacy.Decrypt(encryptedText)
+	}
+
+	return e.decrypt(conv.BytesToString(encryptedText))
+}
+
+// EncryptToString encrypt plaintext to envelope encrypted text.
+func (e *Envelope) EncryptToString(plaintext []byte) string {
+	return e.encrypt(plaintext)
+}
+
+// DecryptString decrypt envelope encrypted text or legacy encrypted text.
+func (e *Envelope) DecryptString(encryptedText string) ([]byte, error) {
+	if !strings.HasPrefix(encryptedText, envelopePrefix) {
+		if e.legacy == nil {
+			return nil, errors.New("encrypted text is not envelope encrypted and legacy crypto is not set")
+		}
+		return e.legacy.DecryptString(encryptedText)
+	}
+
+	return e.decrypt(encryptedText)
+}
+
+// EncryptToBase64 encrypt plaintext to envelope encrypted text, the ciphertext fields are base64 encoded, so
+// the text can be saved in the same place as the legacy base64 encrypted text.
+func (e *Envelope) EncryptToBase64(plaintext string) string {
+	return e.encrypt(conv.StringToBytes(plaintext))
+}
+
+// DecryptFromBase64 decrypt envelope encrypted text or legacy base64 encrypted text.
+func (e *Envelope) DecryptFromBase64(encryptedTextB64 string) (string, error) {
+	if !strings.HasPrefix(encryptedTextB64, envelopePrefix) {
+		if e.legacy == nil {
+			return "", errors.New("encrypted text is not envelope encrypted and legacy crypto is not set")
+		}
+		return e.legacy.DecryptFromBase64(encryptedTextB64)
+	}
+
+	plaintext, err := e.decrypt(encryptedTextB64)
+	if err != nil {
+		return "", err
+	}
+
+	return conv.BytesToString(plaintext), nil
+}
+
+// NeedReEncrypt returns whether the encrypted text is not encrypted by the primary key with current algorithm.
+func (e *Envelope) NeedReEncrypt(encryptedTextB64 string) bool {
+	if !strings.HasPrefix(encryptedTextB64, envelopePrefix) {
+		return true
+	}
+
+	fields := strings.Split(strings.TrimPrefix(encryptedTextB64, envelopePrefix), envelopeSeparator)
+	if len(fields) != 4 {
+		return true
+	}
+
+	return Algorithm(fields[0]) != e.algorithm || fields[1] != e.keyID
+}
+
+func (e *Envelope) header(algorithm Algorithm, keyID string) string {
+	return envelopePrefix + string(algorithm) + envelopeSeparator + keyID
+}
+
+func (e *Envelope) encrypt(plaintext []byte) string {
+	nonce := make([]byte, e.aead.NonceSize(), e.aead.NonceSize()+len(plaintext)+e.aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		// reading from the system random source never fails in practice, a fixed nonce must not be used instead.
+		panic(fmt.Sprintf("generate nonce failed, err: %v", err))
+	}
+
+	header := e.header(e.algorithm, e.keyID)
+	ciphertext := e.aead.Seal(nonce, nonce, plaintext, conv.StringToBytes(header))
+
+	return header + envelopeSeparator + e.encryptedKey + envelopeSeparator +
+		base64.StdEncoding.EncodeToString(ciphertext)
+}
+
+func (e *Envelope) decrypt(encryptedText string) ([]byte, error) {
+	fields := strings.Split(strings.TrimPrefix(encryptedText, envelopePrefix), envelopeSeparator)
+	if len(fields) != 4 {
+		return nil, errors.New("envelope encrypted text is invalid")
+	}
+
+	algorithm, keyID, encryptedKey := Algorithm(fields[0]), fields[1], fields[2]
+	aead, err := e.dataKeyAEAD(algorithm, keyID, encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(fields[3])
+	if err != nil {
+		return nil, fmt.Errorf("decode envelope ciphertext failed, err: %v", err)
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("envelope ciphertext is too short")
+	}
+
+	nonce := ciphertext[:aead.NonceSize()]
+	return aead.Open(nil, nonce, ciphertext[aead.NonceSize():], conv.StringToBytes(e.header(algorithm, keyID)))
+}
+
+// dataKeyAEAD returns the cipher of the encrypted data key, the data key is decrypted by kms only once.
+func (e *Envelope) dataKeyAEAD(algorithm Algorithm, keyID, encryptedKey string) (cipher.AEAD, error) {
+	cacheKey := keyID + envelopeSeparator + encryptedKey
+
+	e.lock.RLock()
+	aead, exists := e.dataKeys[cacheKey]
+	e.lock.RUnlock()
+	if exists {
+		return aead, nil
+	}
+
+	if err := algorithm.Validate(); err != nil {
+		return nil, err
+	}
+
+	encryptedKeyBytes, err := base64.StdEncoding.DecodeString(encryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode encrypted data key failed, err: %v", err)
+	}
+
+	dataKey, err := e.kms.DecryptKey(keyID, encryptedKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt data key by kms key %s failed, err: %v", keyID, err)
+	}
+
+	aead, err = newAEAD(algorithm, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	e.lock.Lock()
+	e.dataKeys[cacheKey] = aead
+	e.lock.Unlock()
+
+	return aead, nil
+}
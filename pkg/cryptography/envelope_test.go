@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func testKMS(t *testing.T, primaryKeyID string) KMS {
+	kms, err := newLocalKMS(&LocalKeyFile{
+		PrimaryKeyID: primaryKeyID,
+		Keys: []LocalKey{
+			{ID: "k1", Key: base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))},
+			{ID: "k2", Algorithm: Sm4Gcm, Key: base64.StdEncoding.EncodeToString([]byte("0123456789abcdef"))},
+		},
+	})
+	if err != nil {
+		t.Fatalf("new local kms failed, err: %v", err)
+	}
+
+	return kms
+}
+
+func TestEnvelopeRandomNonce(t *testing.T) {
+	for _, algorithm := range []Algorithm{AesGcm, Sm4Gcm} {
+		envelope, err := NewEnvelope(testKMS(t, "k1"), algorithm, nil)
+		if err != nil {
+			t.Errorf("new envelope failed, err: %v", err)
+			return
+		}
+
+		first := envelope.EncryptToBase64("secret")
+		second := envelope.EncryptToBase64("secret")
+		if first == second {
+			t.Errorf("%s encrypted text of the same plaintext should be different", algorithm)
+			return
+		}
+
+		plaintext, err := envelope.DecryptFromBase64(first)
+		if err != nil || plaintext != "secret" {
+			t.Errorf("%s decrypt failed, plaintext: %s, err: %v", algorithm, plaintext, err)
+			return
+		}
+
+		if !strings.HasPrefix(first, envelopePrefix+string(algorithm)+":k1:") {
+			t.Errorf("encrypted text should contain algorithm and key id, got: %s", first)
+			return
+		}
+	}
+}
+
+func TestEnvelopeKeyRotation(t *testing.T) {
+	legacy, err := NewAESGcm([]byte("0123456789abcdef"), []byte("0123456789ab"))
+	if err != nil {
+		t.Errorf("new legacy aes gcm failed, err: %v", err)
+		return
+	}
+	legacyText := legacy.EncryptToBase64("legacy")
+
+	old, err := NewEnvelope(testKMS(t, "k1"), AesGcm, legacy)
+	if err != nil {
+		t.Errorf("new envelope failed, err: %v", err)
+		return
+	}
+	oldText := old.EncryptToBase64("old")
+
+	// rotate to a new primary key and algorithm in another process.
+	rotated, err := NewEnvelope(testKMS(t, "k2"), Sm4Gcm, legacy)
+	if err != nil {
+		t.Errorf("new envelope failed, err: %v", err)
+		return
+	}
+
+	for text, expected := range map[string]string{legacyText: "legacy", oldText: "old"} {
+		if !rotated.NeedReEncrypt(text) {
+			t.Errorf("text should need re-encrypt, text: %s", text)
+			return
+		}
+
+		plaintext, err := rotated.DecryptFromBase64(text)
+		if err != nil || plaintext != expected {
+			t.Errorf("decrypt rotated text failed, plaintext: %s, err: %v", plaintext, err)
+			return
+		}
+
+		if rotated.NeedReEncrypt(rotated.EncryptToBase64(plaintext)) {
+			t.Errorf("re-encrypted text should not need re-encrypt again")
+			return
+		}
+	}
+}
+
+func TestEnvelopeTamper(t *testing.T) {
+	envelope, err := NewEnvelope(testKMS(t, "k1"), AesGcm, nil)
+	if err != nil {
+		t.Errorf("new envelope failed, err: %v", err)
+		return
+	}
+
+	text := envelope.EncryptToBase64("secret")
+	// the key id is authenticated, it can not be changed.
+	tampered := strings.Replace(text, ":k1:", ":k2:", 1)
+	if _, err = envelope.DecryptFromBase64(tampered); err == nil {
+		t.Errorf("decrypt tampered text should fail")
+		return
+	}
+
+	if _, err = envelope.DecryptFromBase64("bm90IGVuY3J5cHRlZA=="); err == nil {
+		t.Errorf("decrypt legacy text without legacy crypto should fail")
+		return
+	}
+}
@@ -0,0 +1,199 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KMS is the key management service which holds the key encryption keys, it encrypts and decrypts the data keys
+// used by the envelope encryption, so the key encryption keys never leave the kms.
+type KMS interface {
+	// PrimaryKeyID returns the id of the key used to encrypt new data keys.
+	PrimaryKeyID() string
+	// EncryptKey encrypt the data key with the key of the key id.
+	EncryptKey(keyID string, dataKey []byte) ([]byte, error)
+	// DecryptKey decrypt the encrypted data key with the key of the key id.
+	DecryptKey(keyID string, encryptedKey []byte) ([]byte, error)
+}
+
+// KMSType is the type of kms.
+type KMSType string
+
+const (
+	// LocalKMS is the kms which loads keys from a local file.
+	LocalKMS KMSType = "local"
+)
+
+// NewKMS create a kms of the kms type, source is the file path for local kms.
+func NewKMS(kmsType KMSType, source string) (KMS, error) {
+	switch kmsType {
+	case LocalKMS:
+		return NewLocalKMS(source)
+	default:
+		return nil, fmt.Errorf("unsupported kms type: %s", kmsType)
+	}
+}
+
+// LocalKeyFile is the key file of local kms, it is in yaml format, such as:
+//
+//	primaryKeyID: "2023-06"
+//	keys:
+//	  - id: "2023-01"
+//	    algorithm: aes-gcm
+//	    key: <base64 encoded 32 bytes key>
+//	  - id: "2023-06"
+//	    algorithm: sm4-gcm
+//	    key: <base64 encoded 16 bytes key>
+//
+// all the keys can be used to decrypt, only the primary key is used to encrypt. to rotate the key, add a new
+// key and set it as primary, then re-encrypt the existing data before the old key is removed.
+type LocalKeyFile struct {
+	PrimaryKeyID string     `yaml:"primaryKeyID"`
+	Keys         []LocalKey `yaml:"keys"`
+}
+
+// LocalKey is one key encryption key of local kms.
+type LocalKey struct {
+	ID string `yaml:"id"`
+	// Algorithm is the algorithm used to encrypt data keys with this key, default is aes-gcm.
+	Algorithm Algorithm `yaml:"algorithm"`
+	// Key is the base64 encoded key.
+	Key string `yaml:"key"`
+}
+
+type localKMS struct {
+	primaryKeyID string
+	keys         map[string]*localKey
+}
+
+type localKey struct {
+	algorithm Algorithm
+	key       []byte
+}
+
+// NewLocalKMS load the local key file and returns a kms.
+func NewLocalKMS(keyFile string) (KMS, error) {
+	content, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read kms key file %s failed, err: %v", keyFile, err)
+	}
+
+	file := new(LocalKeyFile)
+	if err = yaml.Unmarshal(content, file); err != nil {
+		return nil, fmt.Errorf("unmarshal kms key file %s failed, err: %v", keyFile, err)
+	}
+
+	return newLocalKMS(file)
+}
+
+func newLocalKMS(file *LocalKeyFile) (*localKMS, error) {
+	kms := &localKMS{
+		primaryKeyID: file.PrimaryKeyID,
+		keys:         make(map[string]*localKey, len(file.Keys)),
+	}
+
+	for _, one := range file.Keys {
+		if len(one.ID) == 0 || strings.Contains(one.ID, envelopeSeparator) {
+			return nil, fmt.Errorf("kms key id %s is invalid, should not be empty or contain '%s'", one.ID,
+				envelopeSeparator)
+		}
+
+		if _, exists := kms.keys[one.ID]; exists {
+			return nil, fmt.Errorf("kms key id %s is duplicated", one.ID)
+		}
+
+		algorithm := one.Algorithm
+		if len(algorithm) == 0 {
+			algorithm = AesGcm
+		}
+
+		key, err := base64.StdEncoding.DecodeString(one.Key)
+		if err != nil {
+			return nil, fmt.Errorf("kms key %s is not base64 encoded, err: %v", one.ID, err)
+		}
+
+		// validate the key length by the algorithm.
+		if _, err = newAEAD(algorithm, key); err != nil {
+			return nil, fmt.Errorf("kms key %s is invalid, err: %v", one.ID, err)
+		}
+
+		kms.keys[one.ID] = &localKey{algorithm: algorithm, key: key}
+	}
+
+	if _, exists := kms.keys[kms.primaryKeyID]; !exists {
+		return nil, fmt.Errorf("kms primary key %s is not found", kms.primaryKeyID)
+	}
+
+	return kms, nil
+}
+
+// PrimaryKeyID returns the id of the key used to encrypt new data keys.
+func (k *localKMS) PrimaryKeyID() string {
+	return k.primaryKeyID
+}
+
+// EncryptKey encrypt the data key with the key of the key id, the result is nonce + ciphertext.
+func (k *localKMS) EncryptKey(keyID string, dataKey []byte) ([]byte, error) {
+	one, exists := k.keys[keyID]
+	if !exists {
+		return nil, fmt.Errorf("kms key %s is not found", keyID)
+	}
+
+	aead, err := newAEAD(one.algorithm, one.key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(dataKey)+aead.Overhead())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, dataKey, []byte(keyID)), nil
+}
+
+// DecryptKey decrypt the encrypted data key with the key of the key id.
+func (k *localKMS) DecryptKey(keyID string, encryptedKey []byte) ([]byte, error) {
+	one, exists := k.keys[keyID]
+	if !exists {
+		return nil, fmt.Errorf("kms key %s is not found", keyID)
+	}
+
+	aead, err := newAEAD(one.algorithm, one.key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(encryptedKey) < aead.NonceSize() {
+		return nil, errors.New("encrypted data key is too short")
+	}
+
+	nonce := encryptedKey[:aead.NonceSize()]
+	return aead.Open(nil, nonce, encryptedKey[aead.NonceSize():], []byte(keyID))
+}
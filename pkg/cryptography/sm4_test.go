@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSm4StandardVector(t *testing.T) {
+	key, _ := hex.DecodeString("0123456789abcdeffedcba9876543210")
+	expected, _ := hex.DecodeString("681edf34d206965e86b3e94f536e4246")
+
+	block, err := NewSm4Cipher(key)
+	if err != nil {
+		t.Errorf("new sm4 cipher failed, err: %v", err)
+		return
+	}
+
+	dst := make([]byte, Sm4BlockSize)
+	block.Encrypt(dst, key)
+	if !bytes.Equal(dst, expected) {
+		t.Errorf("sm4 encrypt result is %x, should be %x", dst, expected)
+		return
+	}
+
+	block.Decrypt(dst, dst)
+	if !bytes.Equal(dst, key) {
+		t.Errorf("sm4 decrypt result is %x, should be %x", dst, key)
+		return
+	}
+}
+
+func TestSm4MillionRounds(t *testing.T) {
+	key, _ := hex.DecodeString("0123456789abcdeffedcba9876543210")
+	expected, _ := hex.DecodeString("595298c7c6fd271f0402f804c33d3f66")
+
+	block, err := NewSm4Cipher(key)
+	if err != nil {
+		t.Errorf("new sm4 cipher failed, err: %v", err)
+		return
+	}
+
+	data := make([]byte, Sm4BlockSize)
+	copy(data, key)
+	for i := 0; i < 1000000; i++ {
+		block.Encrypt(data, data)
+	}
+
+	if !bytes.Equal(data, expected) {
+		t.Errorf("sm4 encrypt 1000000 times result is %x, should be %x", data, expected)
+		return
+	}
+}
@@ -19,6 +19,12 @@
 
 package cryptography
 
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+)
+
 // Crypto 定义了需要实现的三组加解密方法，分别是以字节、字符串、Base64字符串格式的方法
 type Crypto interface {
 	Encrypt(plaintext []byte) []byte
@@ -30,3 +36,64 @@ type Crypto interface {
 	EncryptToBase64(plaintext string) string
 	DecryptFromBase64(encryptedTextB64 string) (string, error)
 }
+
+// Rotatable is the Crypto which supports key rotation, the values encrypted by an old key or the legacy
+// algorithm can still be decrypted, and should be re-encrypted to migrate to the current key.
+type Rotatable interface {
+	Crypto
+	// NeedReEncrypt returns whether the encrypted text is not encrypted by the current key and algorithm.
+	NeedReEncrypt(encryptedTextB64 string) bool
+}
+
+// Algorithm is the symmetric encryption algorithm.
+type Algorithm string
+
+const (
+	// AesGcm is AES in Galois/Counter Mode, the key should be 16 or 32 bytes.
+	AesGcm Algorithm = "aes-gcm"
+	// Sm4Gcm is SM4 (国密) in Galois/Counter Mode, the key should be 16 bytes.
+	Sm4Gcm Algorithm = "sm4-gcm"
+)
+
+// Validate Algorithm.
+func (a Algorithm) Validate() error {
+	switch a {
+	case AesGcm:
+	case Sm4Gcm:
+	default:
+		return fmt.Errorf("unsupported crypto algorithm: %s", a)
+	}
+
+	return nil
+}
+
+// KeySize returns the data key size of the algorithm in bytes.
+func (a Algorithm) KeySize() int {
+	switch a {
+	case Sm4Gcm:
+		return Sm4KeySize
+	default:
+		return 32
+	}
+}
+
+// newAEAD returns the authenticated encryption cipher of the algorithm, the nonce size is 12 bytes.
+func newAEAD(algorithm Algorithm, key []byte) (cipher.AEAD, error) {
+	var block cipher.Block
+	var err error
+
+	switch algorithm {
+	case AesGcm:
+		block, err = aes.NewCipher(key)
+	case Sm4Gcm:
+		block, err = NewSm4Cipher(key)
+	default:
+		return nil, fmt.Errorf("unsupported crypto algorithm: %s", algorithm)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
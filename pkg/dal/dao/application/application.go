@@ -42,6 +42,7 @@ import (
 type Application interface {
 	CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *application.ApplicationTable) (string, error)
 	Update(kt *kit.Kit, expr *filter.Expression, model *application.ApplicationTable) error
+	UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression, model *application.ApplicationTable) error
 	List(kt *kit.Kit, opt *types.ListOption) (*types.ListApplicationDetails, error)
 }
 
@@ -80,6 +81,20 @@ func (a *ApplicationDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *applicati
 
 // Update ...
 func (a *ApplicationDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *application.ApplicationTable) error {
+	_, err := a.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, a.UpdateWithTx(kt, txn, filterExpr, model)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateWithTx update application with tx.
+func (a *ApplicationDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression,
+	model *application.ApplicationTable) error {
+
 	if filterExpr == nil {
 		return errf.New(errf.InvalidParameter, "filter expr is nil")
 	}
@@ -101,24 +116,17 @@ func (a *ApplicationDao) Update(kt *kit.Kit, filterExpr *filter.Expression, mode
 
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
 
-	_, err = a.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
-		effected, err := a.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
-		if err != nil {
-			logs.ErrorJson("update application failed, err: %v, filter: %s, rid: %v", err, filterExpr, kt.Rid)
-			return nil, err
-		}
-
-		if effected == 0 {
-			logs.ErrorJson("update application, but record not found, filter: %v, rid: %v", filterExpr, kt.Rid)
-			// return nil, errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
-		}
-
-		return nil, nil
-	})
+	effected, err := a.Orm.Txn(tx).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 	if err != nil {
+		logs.ErrorJson("update application failed, err: %v, filter: %s, rid: %v", err, filterExpr, kt.Rid)
 		return err
 	}
 
+	if effected == 0 {
+		logs.ErrorJson("update application, but record not found, filter: %v, rid: %v", filterExpr, kt.Rid)
+		// return nil, errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
+	}
+
 	return nil
 }
 
@@ -129,11 +137,11 @@ func (a *ApplicationDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAp
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(application.ApplicationColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -133,11 +133,11 @@ func (a *ApprovalProcessDao) List(kt *kit.Kit, opt *types.ListOption) (*types.Li
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(application.ApprovalProcessColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
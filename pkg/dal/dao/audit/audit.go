@@ -24,11 +24,13 @@ import (
 
 	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/event"
 	"hcm/pkg/dal/dao/orm"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
 	"hcm/pkg/dal/table/audit"
+	tableevent "hcm/pkg/dal/table/event"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -49,13 +51,15 @@ var _ Interface = new(Dao)
 // NewAudit new audit.
 func NewAudit(orm orm.Interface) Interface {
 	return &Dao{
-		Orm: orm,
+		Orm:   orm,
+		Event: event.NewEventDao(orm),
 	}
 }
 
-// Dao audit dao.
+// Dao audit dao, resource lifecycle events are emitted with the audits in the same transaction.
 type Dao struct {
-	Orm orm.Interface
+	Orm   orm.Interface
+	Event event.Event
 }
 
 // Create audit.
@@ -65,21 +69,10 @@ func (d Dao) Create(kt *kit.Kit, one *audit.AuditTable) error {
 
 // BatchCreate batch create audit.
 func (d Dao) BatchCreate(kt *kit.Kit, audits []*audit.AuditTable) error {
-	for _, one := range audits {
-		if err := one.CreateValidate(); err != nil {
-			return err
-		}
-	}
-
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AuditTable,
-		audit.AuditColumns.ColumnExpr(), audit.AuditColumns.ColonNameExpr())
-
-	if err := d.Orm.Do().BulkInsert(kt.Ctx, sql, audits); err != nil {
-		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AuditTable, err, kt.Rid)
-		return fmt.Errorf("insert %s failed, err: %v", table.AuditTable, err)
-	}
-
-	return nil
+	_, err := d.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, d.BatchCreateWithTx(kt, txn, audits)
+	})
+	return err
 }
 
 // BatchCreateWithTx batch create audit with tx.
@@ -98,7 +91,16 @@ func (d Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, audits []*audit.AuditTa
 		return fmt.Errorf("insert %s failed, err: %v", table.AuditTable, err)
 	}
 
-	return nil
+	events := make([]*tableevent.EventTable, 0, len(audits))
+	for _, one := range audits {
+		e, err := tableevent.NewEventFromAudit(one)
+		if err != nil {
+			return err
+		}
+		events = append(events, e)
+	}
+
+	return d.Event.BatchCreateWithTx(kt, tx, events)
 }
 
 // List audit.
@@ -108,11 +110,11 @@ func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditDetails,
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(audit.AuditColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,256 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package changefeed defines the dao of resource change feed.
+package changefeed
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	resourcehistory "hcm/pkg/dal/dao/resource-history"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablechangefeed "hcm/pkg/dal/table/change-feed"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Interface only used for resource change feed.
+type Interface interface {
+	CreateByIDsWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, action enumor.ChangeFeedAction,
+		ids []string) error
+	CreateByWhereWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, action enumor.ChangeFeedAction,
+		whereExpr string, whereValue map[string]interface{}) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListChangeFeedDetails, error)
+	Delete(kt *kit.Kit, expr *filter.Expression) (int64, error)
+	Sequence(kt *kit.Kit, limit uint) (int, error)
+}
+
+var _ Interface = new(Dao)
+
+// NewChangeFeedDao new change feed dao.
+func NewChangeFeedDao(orm orm.Interface, history resourcehistory.Interface) Interface {
+	return &Dao{
+		Orm:     orm,
+		History: history,
+	}
+}
+
+// Dao change feed dao.
+type Dao struct {
+	Orm orm.Interface
+	// History records the resource snapshots along with the change feeds, so that every write of the resources
+	// including the sync ones leaves its history.
+	History resourcehistory.Interface
+}
+
+// CreateByIDsWithTx create change feeds of the resources with ids with tx, the change feeds should be created in
+// the same transaction with the resources they describe.
+func (d Dao) CreateByIDsWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, action enumor.ChangeFeedAction,
+	ids []string) error {
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return d.CreateByWhereWithTx(kt, tx, resType, action, "WHERE id IN (:ids)", map[string]interface{}{"ids": ids})
+}
+
+// CreateByWhereWithTx create change feeds and histories of the resources matched by the where expression with tx, it
+// should be called before the resources are updated or deleted, so that the matched resources are still the ones to
+// change and the history snapshots are the states before the change.
+func (d Dao) CreateByWhereWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, action enumor.ChangeFeedAction,
+	whereExpr string, whereValue map[string]interface{}) error {
+
+	if err := action.Validate(); err != nil {
+		return err
+	}
+
+	accountIDColumn, err := tablechangefeed.AccountIDColumn(resType)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (res_type, res_id, account_id, action) SELECT :change_feed_res_type, id, %s, `+
+		`:change_feed_action FROM %s %s`, table.ChangeFeedTable, accountIDColumn, resType, whereExpr)
+
+	args := tools.MapMerge(map[string]interface{}{
+		"change_feed_res_type": resType,
+		"change_feed_action":   action,
+	}, whereValue)
+
+	// Update is used to execute the INSERT ... SELECT statement, because it supports the named and 'in' arguments
+	// of the where expression.
+	if _, err = d.Orm.Txn(tx).Update(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("insert %s %s change feed failed, err: %v, where: %s, rid: %s", resType, action, err, whereExpr,
+			kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.ChangeFeedTable, err)
+	}
+
+	return d.History.CreateByWhereWithTx(kt, tx, resType, action, whereExpr, whereValue)
+}
+
+// List change feed.
+func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListChangeFeedDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list change feed options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablechangefeed.ChangeFeedColumns.ColumnTypes())),
+		core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ChangeFeedTable, whereExpr)
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count change feed failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListChangeFeedDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablechangefeed.ChangeFeedColumns.FieldsNamedExpr(opt.Fields),
+		table.ChangeFeedTable, whereExpr, pageExpr)
+
+	details := make([]tablechangefeed.ChangeFeedTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListChangeFeedDetails{Details: details}, nil
+}
+
+// Delete change feed, returns the deleted count.
+func (d Dao) Delete(kt *kit.Kit, filterExpr *filter.Expression) (int64, error) {
+	if filterExpr == nil {
+		return 0, errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return 0, err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.ChangeFeedTable, whereExpr)
+	deleted, err := d.Orm.Do().Delete(kt.Ctx, sql, whereValue)
+	if err != nil {
+		logs.ErrorJson("delete change feed failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// Sequence assigns the commit sequences to the committed change feeds that are not sequenced yet, returns the count
+// of the sequenced change feeds.
+func (d Dao) Sequence(kt *kit.Kit, limit uint) (int, error) {
+	return SequenceTable(kt, d.Orm, table.ChangeFeedTable, tablechangefeed.SequenceCounterID, limit)
+}
+
+// sequencedRow is the row of the table that is sequenced.
+type sequencedRow struct {
+	ID uint64 `db:"id"`
+}
+
+// SequenceTable assigns the commit sequences to the committed rows of the table that are not sequenced yet, returns
+// the count of the sequenced rows. the table should have the auto increment id and the nullable seq column, and its
+// counter row in the sequence table. the sequences are assigned under the lock of the counter, so the rows that are
+// committed later always get the greater sequences than the ones that are already sequenced, even if their auto
+// increment ids are smaller, and the cursor based on the sequence never skips the rows.
+func SequenceTable(kt *kit.Kit, ormInst orm.Interface, target table.Name, counterID uint64, limit uint) (int,
+	error) {
+
+	if limit == 0 {
+		return 0, errf.New(errf.InvalidParameter, "sequence limit is required")
+	}
+
+	result, err := ormInst.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		// lock the counter first, the rows that are selected after it include all the committed ones.
+		lockSql := fmt.Sprintf(`SELECT id, seq FROM %s WHERE id = :id FOR UPDATE`, table.ChangeFeedSequenceTable)
+		counters := make([]tablechangefeed.ChangeFeedSequenceTable, 0, 1)
+		lockArgs := map[string]interface{}{"id": counterID}
+		if err := ormInst.Txn(txn).Select(kt.Ctx, &counters, lockSql, lockArgs); err != nil {
+			return nil, fmt.Errorf("lock %s sequence failed, err: %v", target, err)
+		}
+
+		if len(counters) != 1 {
+			return nil, fmt.Errorf("%s sequence counter not found", target)
+		}
+		base := counters[0].Seq
+
+		listSql := fmt.Sprintf(`SELECT id FROM %s WHERE seq IS NULL ORDER BY id ASC LIMIT %d`, target, limit)
+		rows := make([]sequencedRow, 0)
+		if err := ormInst.Txn(txn).Select(kt.Ctx, &rows, listSql, map[string]interface{}{}); err != nil {
+			return nil, fmt.Errorf("list unsequenced %s failed, err: %v", target, err)
+		}
+
+		if len(rows) == 0 {
+			return 0, nil
+		}
+
+		ids := make([]uint64, 0, len(rows))
+		for _, one := range rows {
+			ids = append(ids, one.ID)
+		}
+
+		// the sequences keep the order of ids in the batch, the gaps of the ids are left in the sequences.
+		minID, maxID := ids[0], ids[len(ids)-1]
+		updateSql := fmt.Sprintf(`UPDATE %s SET seq = :base + id - :min_id + 1 WHERE id IN (:ids) AND seq IS NULL`,
+			target)
+		args := map[string]interface{}{"base": base, "min_id": minID, "ids": ids}
+		if _, err := ormInst.Txn(txn).Update(kt.Ctx, updateSql, args); err != nil {
+			return nil, fmt.Errorf("update %s sequence failed, err: %v", target, err)
+		}
+
+		counterSql := fmt.Sprintf(`UPDATE %s SET seq = :seq WHERE id = :id`, table.ChangeFeedSequenceTable)
+		counterArgs := map[string]interface{}{"seq": base + maxID - minID + 1, "id": counterID}
+		if _, err := ormInst.Txn(txn).Update(kt.Ctx, counterSql, counterArgs); err != nil {
+			return nil, fmt.Errorf("update %s sequence counter failed, err: %v", target, err)
+		}
+
+		return len(ids), nil
+	})
+	if err != nil {
+		logs.Errorf("sequence %s failed, err: %v, rid: %s", target, err, kt.Rid)
+		return 0, err
+	}
+
+	return result.(int), nil
+}
@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/audit"
+	changefeed "hcm/pkg/dal/dao/change-feed"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
 	"hcm/pkg/dal/dao/tools"
@@ -55,9 +56,10 @@ var _ Account = new(AccountDao)
 
 // AccountDao account dao.
 type AccountDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm        orm.Interface
+	IDGen      idgenerator.IDGenInterface
+	Audit      audit.Interface
+	ChangeFeed changefeed.Interface
 }
 
 // tableNames define table name.
@@ -140,6 +142,12 @@ func (a AccountDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *cloud.AccountT
 		return "", fmt.Errorf("insert %s failed, err: %v", model.TableName(), err)
 	}
 
+	// create change feed.
+	if err = a.ChangeFeed.CreateByIDsWithTx(kt, tx, table.AccountTable, enumor.ChangeFeedInsert,
+		[]string{id}); err != nil {
+		return "", err
+	}
+
 	// create audit.
 	extension := tools.AccountExtensionRemoveSecretKey(string(model.Extension))
 	model.Extension = tabletype.JsonField(extension)
@@ -159,7 +167,7 @@ func (a AccountDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *cloud.AccountT
 			Data: model,
 		},
 	}
-	if err = a.Audit.Create(kt, auditInfo); err != nil {
+	if err = a.Audit.BatchCreateWithTx(kt, tx, []*tableaudit.AuditTable{auditInfo}); err != nil {
 		logs.Errorf("create account audit failed, err: %v, rid: %s", err, kt.Rid)
 		return "", err
 	}
@@ -191,6 +199,12 @@ func (a AccountDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *cl
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
 
 	_, err = a.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := a.ChangeFeed.CreateByWhereWithTx(kt, txn, table.AccountTable, enumor.ChangeFeedUpdate, whereExpr,
+			whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := a.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update account failed, err: %v, filter: %s, rid: %v", err, filterExpr, kt.Rid)
@@ -224,11 +238,11 @@ func (a AccountDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAccount
 	columnTypes["extension.cloud_project_id"] = enumor.String
 	columnTypes["extension.cloud_tenant_id"] = enumor.String
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -273,6 +287,11 @@ func (a AccountDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Ex
 		return err
 	}
 
+	err = a.ChangeFeed.CreateByWhereWithTx(kt, tx, table.AccountTable, enumor.ChangeFeedDelete, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AccountTable, whereExpr)
 	if _, err = a.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete account failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
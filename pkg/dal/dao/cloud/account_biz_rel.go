@@ -76,11 +76,11 @@ func (a AccountBizRelDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListA
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(cloud.AccountBizRelColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
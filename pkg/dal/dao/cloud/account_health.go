@@ -0,0 +1,186 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/cloud"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AccountHealth only used for account health.
+type AccountHealth interface {
+	CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *cloud.AccountHealthTable) (string, error)
+	UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression, model *cloud.AccountHealthTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListAccountHealthDetails, error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ AccountHealth = new(AccountHealthDao)
+
+// AccountHealthDao account health dao.
+type AccountHealthDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// CreateWithTx create account health with tx.
+func (a AccountHealthDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *cloud.AccountHealthTable) (string, error) {
+	if model == nil {
+		return "", errf.New(errf.InvalidParameter, "account health model is nil")
+	}
+
+	id, err := a.IDGen.One(kt, table.AccountHealthTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AccountHealthTable,
+		cloud.AccountHealthColumns.ColumnExpr(), cloud.AccountHealthColumns.ColonNameExpr())
+
+	if err = a.Orm.Txn(tx).Insert(kt.Ctx, sql, model); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AccountHealthTable, err, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %v", table.AccountHealthTable, err)
+	}
+
+	return id, nil
+}
+
+// UpdateWithTx update account health with tx.
+func (a AccountHealthDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression,
+	model *cloud.AccountHealthTable) error {
+
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is nil")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	// 检查成功时连续失败次数需要清零
+	opts := utils.NewFieldOptions().AddBlankedFields("consecutive_failures").
+		AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
+
+	effected, err := a.Orm.Txn(tx).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
+	if err != nil {
+		logs.ErrorJson("update account health failed, filter: %s, err: %v, rid: %v", filterExpr, err, kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		logs.ErrorJson("update account health, but record not found, filter: %v, rid: %v", filterExpr, kt.Rid)
+		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
+	}
+
+	return nil
+}
+
+// List account health.
+func (a AccountHealthDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAccountHealthDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list account health options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(cloud.AccountHealthColumns.ColumnTypes())),
+		core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AccountHealthTable, whereExpr)
+		count, err := a.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count account health failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListAccountHealthDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, cloud.AccountHealthColumns.FieldsNamedExpr(opt.Fields),
+		table.AccountHealthTable, whereExpr, pageExpr)
+
+	details := make([]cloud.AccountHealthTable, 0)
+	if err = a.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListAccountHealthDetails{Details: details}, nil
+}
+
+// DeleteWithTx delete account health with tx.
+func (a AccountHealthDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AccountHealthTable, whereExpr)
+	if _, err := a.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete account health failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesbill "hcm/pkg/dal/dao/types/bill"
+	"hcm/pkg/dal/table"
+	tablebill "hcm/pkg/dal/table/cloud/bill"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CostAnomaly only used for cost anomaly.
+type CostAnomaly interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tablebill.CostAnomalyTable) ([]string, error)
+	Update(kt *kit.Kit, expr *filter.Expression, model *tablebill.CostAnomalyTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*typesbill.ListCostAnomalyDetails, error)
+}
+
+var _ CostAnomaly = new(CostAnomalyDao)
+
+// CostAnomalyDao cost anomaly dao.
+type CostAnomalyDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// BatchCreateWithTx batch create cost anomaly with tx.
+func (c CostAnomalyDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tablebill.CostAnomalyTable) (
+	[]string, error) {
+
+	if len(models) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "models to create cannot be empty")
+	}
+
+	ids, err := c.IDGen.Batch(kt, table.CostAnomalyTable, len(models))
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+
+		if err = models[index].InsertValidate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.CostAnomalyTable,
+		tablebill.CostAnomalyColumns.ColumnExpr(), tablebill.CostAnomalyColumns.ColonNameExpr())
+
+	if err = c.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.CostAnomalyTable, err, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", table.CostAnomalyTable, err)
+	}
+
+	return ids, nil
+}
+
+// Update cost anomaly.
+func (c CostAnomalyDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *tablebill.CostAnomalyTable) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is nil")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
+
+	_, err = c.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		effected, err := c.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
+		if err != nil {
+			logs.ErrorJson("update cost anomaly failed, filter: %s, err: %v, rid: %v", filterExpr, err, kt.Rid)
+			return nil, err
+		}
+
+		if effected == 0 {
+			logs.ErrorJson("update cost anomaly, but record not found, filter: %v, rid: %v", filterExpr, kt.Rid)
+			return nil, errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// List cost anomaly.
+func (c CostAnomalyDao) List(kt *kit.Kit, opt *types.ListOption) (*typesbill.ListCostAnomalyDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list cost anomaly options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablebill.CostAnomalyColumns.ColumnTypes())),
+		core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.CostAnomalyTable, whereExpr)
+		count, err := c.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count cost anomaly failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesbill.ListCostAnomalyDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablebill.CostAnomalyColumns.FieldsNamedExpr(opt.Fields),
+		table.CostAnomalyTable, whereExpr, pageExpr)
+
+	details := make([]tablebill.CostAnomalyTable, 0)
+	if err = c.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &typesbill.ListCostAnomalyDetails{Details: details}, nil
+}
@@ -23,15 +23,23 @@ import (
 	"fmt"
 	"strings"
 
+	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/orm"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
+	tablecloud "hcm/pkg/dal/table/cloud"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	tabledisk "hcm/pkg/dal/table/cloud/disk"
+	tableeip "hcm/pkg/dal/table/cloud/eip"
+	tableni "hcm/pkg/dal/table/cloud/network-interface"
+	routetable "hcm/pkg/dal/table/cloud/route-table"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -41,8 +49,12 @@ type Cloud interface {
 	ListResourceBasicInfo(kt *kit.Kit, resType enumor.CloudResourceType, ids []string, fields ...string) (
 		[]types.CloudResourceBasicInfo, error)
 	ListResourceIDs(kt *kit.Kit, resType enumor.CloudResourceType, expr *filter.Expression) ([]string, error)
+	LockResourceIDsWithTx(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType, expr *filter.Expression) (
+		[]string, error)
 	AssignResourceToBiz(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType, expr *filter.Expression,
 		bizID int64) error
+	Aggregate(kt *kit.Kit, resType enumor.CloudResourceType, opt *types.AggregateOption) ([]core.AggregateDetail,
+		error)
 }
 
 var _ Cloud = new(CloudDao)
@@ -70,6 +82,11 @@ func (dao CloudDao) ListResourceBasicInfo(kt *kit.Kit, resType enumor.CloudResou
 		fields = types.CommonBasicInfoFields
 	}
 
+	// region is used for attribute based authorization, so it's always selected if the table has region column.
+	if tableName != table.GcpFirewallRuleTable && !slice.IsItemInSlice(fields, "region") {
+		fields = append(append(make([]string, 0, len(fields)+1), fields...), "region")
+	}
+
 	// select cloud resource basic infos.
 	sql := fmt.Sprintf("select %s from %s where id in (:ids)", strings.Join(fields, ", "), tableName)
 	if tableName == table.AccountTable {
@@ -101,7 +118,7 @@ func (dao CloudDao) ListResourceIDs(kt *kit.Kit, resType enumor.CloudResourceTyp
 		return nil, errf.New(errf.InvalidParameter, "ids is required")
 	}
 
-	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TagSqlWhereOption(tableName))
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +139,40 @@ func (dao CloudDao) ListResourceIDs(kt *kit.Kit, resType enumor.CloudResourceTyp
 	return ids, nil
 }
 
+// LockResourceIDsWithTx list cloud resource ids with tx, and lock the matched rows until the tx ends.
+func (dao CloudDao) LockResourceIDsWithTx(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType,
+	expr *filter.Expression) ([]string, error) {
+
+	tableName, err := resType.ConvTableName()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if expr == nil {
+		return nil, errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.TagSqlWhereOption(tableName))
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf("select id from %s %s for update", tableName, whereExpr)
+
+	list := make([]types.CloudResourceBasicInfo, 0)
+	if err = dao.Orm.Txn(tx).Select(kt.Ctx, &list, sql, whereValue); err != nil {
+		logs.Errorf("lock %s resource id failed, err: %v, expr: %v, rid: %s", resType, err, expr, kt.Rid)
+		return nil, err
+	}
+
+	ids := make([]string, len(list))
+	for idx, info := range list {
+		ids[idx] = info.ID
+	}
+
+	return ids, nil
+}
+
 // AssignResourceToBiz assign an account's cloud resource to biz, **only for ui**.
 func (dao CloudDao) AssignResourceToBiz(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType,
 	expr *filter.Expression, bizID int64) error {
@@ -151,3 +202,63 @@ func (dao CloudDao) AssignResourceToBiz(kt *kit.Kit, tx *sqlx.Tx, resType enumor
 
 	return nil
 }
+
+// AggregateColumnTypes is the column types of the cloud resource types that can be aggregated.
+var AggregateColumnTypes = map[enumor.CloudResourceType]map[string]enumor.ColumnType{
+	enumor.CvmCloudResType:              tablecvm.TableColumns.ColumnTypes(),
+	enumor.DiskCloudResType:             tabledisk.DiskColumns.ColumnTypes(),
+	enumor.VpcCloudResType:              tablecloud.VpcColumns.ColumnTypes(),
+	enumor.SubnetCloudResType:           tablecloud.SubnetColumns.ColumnTypes(),
+	enumor.EipCloudResType:              tableeip.EipColumns.ColumnTypes(),
+	enumor.SecurityGroupCloudResType:    tablecloud.SecurityGroupColumns.ColumnTypes(),
+	enumor.GcpFirewallRuleCloudResType:  tablecloud.GcpFirewallRuleColumns.ColumnTypes(),
+	enumor.RouteTableCloudResType:       routetable.RouteTableColumns.ColumnTypes(),
+	enumor.NetworkInterfaceCloudResType: tableni.NetworkInterfaceColumns.ColumnTypes(),
+}
+
+// Aggregate count/sum/min/max cloud resources grouped by the specified fields.
+func (dao CloudDao) Aggregate(kt *kit.Kit, resType enumor.CloudResourceType, opt *types.AggregateOption) (
+	[]core.AggregateDetail, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "aggregate option is nil")
+	}
+
+	columnTypes, exists := AggregateColumnTypes[resType]
+	if !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s does not support aggregation", resType)
+	}
+
+	tableName, err := resType.ConvTableName()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err = opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes), filter.EnableTagRule()),
+		columnTypes); err != nil {
+		return nil, err
+	}
+
+	sql, whereValue, err := opt.SQLExpr(tableName, tools.TagSqlWhereOption(tableName))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]types.AggregateRow, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &rows, sql, whereValue); err != nil {
+		logs.Errorf("aggregate %s resource failed, err: %v, sql: %s, rid: %s", resType, err, sql, kt.Rid)
+		return nil, err
+	}
+
+	details := make([]core.AggregateDetail, 0, len(rows))
+	for _, row := range rows {
+		detail, err := opt.Detail(row, columnTypes)
+		if err != nil {
+			logs.Errorf("convert %s aggregate result failed, err: %v, rid: %s", resType, err, kt.Rid)
+			return nil, err
+		}
+		details = append(details, detail)
+	}
+
+	return details, nil
+}
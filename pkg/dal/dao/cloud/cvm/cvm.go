@@ -26,8 +26,10 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/audit"
+	changefeed "hcm/pkg/dal/dao/change-feed"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	resourcetag "hcm/pkg/dal/dao/resource-tag"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -55,9 +57,11 @@ var _ Interface = new(Dao)
 
 // Dao cvm dao.
 type Dao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm         orm.Interface
+	IDGen       idgenerator.IDGenInterface
+	Audit       audit.Interface
+	ChangeFeed  changefeed.Interface
+	ResourceTag resourcetag.Interface
 }
 
 // BatchCreateWithTx cvm.
@@ -83,6 +87,11 @@ func (dao Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []*tablecvm.Ta
 		return nil, fmt.Errorf("insert %s failed, err: %v", table.CvmTable, err)
 	}
 
+	// create change feed.
+	if err = dao.ChangeFeed.CreateByIDsWithTx(kt, tx, table.CvmTable, enumor.ChangeFeedInsert, ids); err != nil {
+		return nil, err
+	}
+
 	// create audit.
 	audits := make([]*tableaudit.AuditTable, 0, len(models))
 	for _, one := range models {
@@ -137,6 +146,12 @@ func (dao Dao) Update(kt *kit.Kit, expr *filter.Expression, model *tablecvm.Tabl
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
 
 	_, err = dao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := dao.ChangeFeed.CreateByWhereWithTx(kt, txn, table.CvmTable, enumor.ChangeFeedUpdate, whereExpr,
+			whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := dao.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update cvm failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -175,6 +190,11 @@ func (dao Dao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, model *tabl
 	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
 
 	toUpdate["id"] = id
+	err = dao.ChangeFeed.CreateByIDsWithTx(kt, tx, table.CvmTable, enumor.ChangeFeedUpdate, []string{id})
+	if err != nil {
+		return err
+	}
+
 	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
 	if err != nil {
 		logs.ErrorJson("update cvm failed, err: %v, id: %s, rid: %v", err, id, kt.Rid)
@@ -193,12 +213,12 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListCvmDetails,
 	columnTypes := tablecvm.TableColumns.ColumnTypes()
 	columnTypes["extension.resource_group_name"] = enumor.String
 	columnTypes["extension.zones"] = enumor.Json
-	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.DefaultPageOption); err != nil {
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes), filter.EnableTagRule()),
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.TagSqlWhereOption(table.CvmTable), types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -241,12 +261,12 @@ func (dao Dao) ListWithTx(kt *kit.Kit, tx *sqlx.Tx, opt *types.ListOption) (*typ
 	columnTypes := tablecvm.TableColumns.ColumnTypes()
 	columnTypes["extension.resource_group_name"] = enumor.String
 	columnTypes["extension.zones"] = enumor.Json
-	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.DefaultPageOption); err != nil {
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes), filter.EnableTagRule()),
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.TagSqlWhereOption(table.CvmTable), types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -291,6 +311,15 @@ func (dao Dao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) e
 		return err
 	}
 
+	err = dao.ChangeFeed.CreateByWhereWithTx(kt, tx, table.CvmTable, enumor.ChangeFeedDelete, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
+	if err = dao.ResourceTag.DeleteByResWhereWithTx(kt, tx, table.CvmTable, whereExpr, whereValue); err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.CvmTable, whereExpr)
 	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete cvm failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
@@ -92,11 +92,11 @@ func (relDao DiskCvmRelDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.Dis
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablecloud.DiskCvmRelColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		logs.Errorf(
 			"gen where expr for list disk cvm rels failed, err: %v, filter: %s, rid: %s",
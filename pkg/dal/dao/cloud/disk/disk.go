@@ -26,8 +26,10 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/audit"
+	changefeed "hcm/pkg/dal/dao/change-feed"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	resourcetag "hcm/pkg/dal/dao/resource-tag"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/dao/types/cloud"
@@ -56,9 +58,11 @@ var _ Disk = new(DiskDao)
 
 // DiskDao disk dao.
 type DiskDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm         orm.Interface
+	IDGen       idgenerator.IDGenInterface
+	Audit       audit.Interface
+	ChangeFeed  changefeed.Interface
+	ResourceTag resourcetag.Interface
 }
 
 // BatchCreateWithTx 批量创建云盘数据
@@ -85,6 +89,11 @@ func (diskDao DiskDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, disks []*disk
 		return nil, fmt.Errorf("insert %s failed, err: %v", table.DiskTable, err)
 	}
 
+	// create change feed.
+	if err = diskDao.ChangeFeed.CreateByIDsWithTx(kt, tx, table.DiskTable, enumor.ChangeFeedInsert, ids); err != nil {
+		return nil, err
+	}
+
 	// create audit.
 	audits := make([]*tableaudit.AuditTable, 0, len(disks))
 	for _, one := range disks {
@@ -135,6 +144,12 @@ func (diskDao DiskDao) Update(kt *kit.Kit, filterExpr *filter.Expression, update
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, table.DiskTable, setExpr, whereExpr)
 
 	_, err = diskDao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := diskDao.ChangeFeed.CreateByWhereWithTx(kt, txn, table.DiskTable, enumor.ChangeFeedUpdate, whereExpr,
+			whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := diskDao.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update disk failed, err: %v, filter: %s, rid: %v", err, filterExpr, kt.Rid)
@@ -167,6 +182,11 @@ func (diskDao DiskDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, diskID string,
 	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.DiskTable, setExpr)
 
 	toUpdate["id"] = diskID
+	err = diskDao.ChangeFeed.CreateByIDsWithTx(kt, tx, table.DiskTable, enumor.ChangeFeedUpdate, []string{diskID})
+	if err != nil {
+		return err
+	}
+
 	_, err = diskDao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
 	if err != nil {
 		logs.ErrorJson("update disk failed, err: %v, id: %s, rid: %v", err, diskID, kt.Rid)
@@ -186,13 +206,13 @@ func (diskDao DiskDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.DiskList
 	columnTypes["extension.resource_group_name"] = enumor.String
 	columnTypes["extension.self_link"] = enumor.String
 	columnTypes["extension.zones"] = enumor.Json
-	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.DefaultPageOption); err != nil {
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes), filter.EnableTagRule()),
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereOpt := tools.TagSqlWhereOption(table.DiskTable)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -236,6 +256,16 @@ func (diskDao DiskDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter
 		return err
 	}
 
+	err = diskDao.ChangeFeed.CreateByWhereWithTx(kt, tx, table.DiskTable, enumor.ChangeFeedDelete, whereExpr,
+		whereValue)
+	if err != nil {
+		return err
+	}
+
+	if err = diskDao.ResourceTag.DeleteByResWhereWithTx(kt, tx, table.DiskTable, whereExpr, whereValue); err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.DiskTable, whereExpr)
 	if _, err = diskDao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete disk failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
@@ -91,11 +91,11 @@ func (relDao EipCvmRelDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.EipC
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablecloud.EipCvmRelColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		logs.Errorf(
 			"gen where expr for list eip cvm rels failed, err: %v, filter: %s, rid: %s",
@@ -26,8 +26,10 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/audit"
+	changefeed "hcm/pkg/dal/dao/change-feed"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	resourcetag "hcm/pkg/dal/dao/resource-tag"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/dao/types/cloud"
@@ -56,9 +58,11 @@ var _ Eip = new(EipDao)
 
 // EipDao eip dao.
 type EipDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm         orm.Interface
+	IDGen       idgenerator.IDGenInterface
+	Audit       audit.Interface
+	ChangeFeed  changefeed.Interface
+	ResourceTag resourcetag.Interface
 }
 
 // BatchCreateWithTx ...
@@ -90,6 +94,11 @@ func (eipDao EipDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, eips []*eip.Eip
 		return nil, fmt.Errorf("insert %s failed, err: %v", table.EipTable, err)
 	}
 
+	// create change feed.
+	if err = eipDao.ChangeFeed.CreateByIDsWithTx(kt, tx, table.EipTable, enumor.ChangeFeedInsert, ids); err != nil {
+		return nil, err
+	}
+
 	// create audit.
 	audits := make([]*tableaudit.AuditTable, 0, len(eips))
 	for _, one := range eips {
@@ -128,13 +137,13 @@ func (eipDao EipDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.EipListRes
 	columnTypes["extension.self_link"] = enumor.String
 	columnTypes["extension.resource_group_name"] = enumor.String
 	columnTypes["extension.zones"] = enumor.Json
-	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.DefaultPageOption); err != nil {
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes), filter.EnableTagRule()),
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereOpt := tools.TagSqlWhereOption(table.EipTable)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -186,6 +195,11 @@ func (eipDao EipDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, eipID string, up
 	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, table.EipTable, setExpr)
 
 	toUpdate["id"] = eipID
+	err = eipDao.ChangeFeed.CreateByIDsWithTx(kt, tx, table.EipTable, enumor.ChangeFeedUpdate, []string{eipID})
+	if err != nil {
+		return err
+	}
+
 	_, err = eipDao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
 	if err != nil {
 		logs.ErrorJson("update eip failed, err: %v, id: %s, rid: %v", err, eipID, kt.Rid)
@@ -219,6 +233,12 @@ func (eipDao EipDao) Update(kt *kit.Kit, filterExpr *filter.Expression, updateDa
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, table.EipTable, setExpr, whereExpr)
 
 	_, err = eipDao.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := eipDao.ChangeFeed.CreateByWhereWithTx(kt, txn, table.EipTable, enumor.ChangeFeedUpdate, whereExpr,
+			whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := eipDao.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update eip failed, err: %v, filter: %s, rid: %v", err, filterExpr, kt.Rid)
@@ -250,6 +270,15 @@ func (eipDao EipDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.E
 		return err
 	}
 
+	err = eipDao.ChangeFeed.CreateByWhereWithTx(kt, tx, table.EipTable, enumor.ChangeFeedDelete, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
+	if err = eipDao.ResourceTag.DeleteByResWhereWithTx(kt, tx, table.EipTable, whereExpr, whereValue); err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.EipTable, whereExpr)
 	if _, err = eipDao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete eip failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
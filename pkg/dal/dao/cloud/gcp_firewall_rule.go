@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/audit"
+	changefeed "hcm/pkg/dal/dao/change-feed"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
 	"hcm/pkg/dal/dao/tools"
@@ -54,9 +55,10 @@ var _ GcpFirewallRule = new(GcpFirewallRuleDao)
 
 // GcpFirewallRuleDao gcp firewall rule dao.
 type GcpFirewallRuleDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm        orm.Interface
+	IDGen      idgenerator.IDGenInterface
+	Audit      audit.Interface
+	ChangeFeed changefeed.Interface
 }
 
 // BatchCreateWithTx rule.
@@ -83,6 +85,12 @@ func (g GcpFirewallRuleDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rules []
 		return nil, fmt.Errorf("insert %s failed, err: %v", table.GcpFirewallRuleTable, err)
 	}
 
+	// create change feed.
+	if err = g.ChangeFeed.CreateByIDsWithTx(kt, tx, table.GcpFirewallRuleTable, enumor.ChangeFeedInsert,
+		ids); err != nil {
+		return nil, err
+	}
+
 	audits := make([]*tableaudit.AuditTable, 0, len(rules))
 	for _, rule := range rules {
 		audits = append(audits, &tableaudit.AuditTable{
@@ -135,6 +143,12 @@ func (g GcpFirewallRuleDao) Update(kt *kit.Kit, expr *filter.Expression, rule *c
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, rule.TableName(), setExpr, whereExpr)
 
 	_, err = g.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := g.ChangeFeed.CreateByWhereWithTx(kt, txn, table.GcpFirewallRuleTable, enumor.ChangeFeedUpdate,
+			whereExpr, whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := g.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update %s failed, err: %v, filter: %s, rid: %v", table.GcpFirewallRuleTable, err,
@@ -178,6 +192,11 @@ func (g GcpFirewallRuleDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string
 	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, rule.TableName(), setExpr)
 
 	toUpdate["id"] = id
+	err = g.ChangeFeed.CreateByIDsWithTx(kt, tx, table.GcpFirewallRuleTable, enumor.ChangeFeedUpdate, []string{id})
+	if err != nil {
+		return err
+	}
+
 	_, err = g.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
 	if err != nil {
 		logs.ErrorJson("update %s failed, err: %v, id: %s, rid: %v", table.GcpFirewallRuleTable, err, id, kt.Rid)
@@ -194,11 +213,11 @@ func (g GcpFirewallRuleDao) List(kt *kit.Kit, opt *types.ListOption) (*types.Lis
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(cloud.GcpFirewallRuleColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -244,6 +263,12 @@ func (g GcpFirewallRuleDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.
 		return err
 	}
 
+	err = g.ChangeFeed.CreateByWhereWithTx(kt, tx, table.GcpFirewallRuleTable, enumor.ChangeFeedDelete,
+		whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.GcpFirewallRuleTable, whereExpr)
 	if _, err = g.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete %s failed, err: %v, filter: %s, rid: %s", table.GcpFirewallRuleTable, err, expr, kt.Rid)
@@ -108,12 +108,12 @@ func (pImageDao ImageDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.Image
 	columnTypes["extension.sku"] = enumor.String
 	columnTypes["extension.self_link"] = enumor.String
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
 	whereOpt := tools.DefaultSqlWhereOption
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -157,11 +157,11 @@ func (dao NiCvmRelDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListNetw
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(
-		nicvmreltable.NetworkInterfaceCvmRelColumns.ColumnTypes())), core.DefaultPageOption); err != nil {
+		nicvmreltable.NetworkInterfaceCvmRelColumns.ColumnTypes())), core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
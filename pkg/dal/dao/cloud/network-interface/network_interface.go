@@ -26,8 +26,10 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/audit"
+	changefeed "hcm/pkg/dal/dao/change-feed"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	resourcetag "hcm/pkg/dal/dao/resource-tag"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	typesni "hcm/pkg/dal/dao/types/network-interface"
@@ -57,9 +59,11 @@ var _ NetworkInterface = new(NetworkInterfaceDao)
 
 // NetworkInterfaceDao network interface dao.
 type NetworkInterfaceDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm         orm.Interface
+	IDGen       idgenerator.IDGenInterface
+	Audit       audit.Interface
+	ChangeFeed  changefeed.Interface
+	ResourceTag resourcetag.Interface
 }
 
 // CreateWithTx network interface with tx.
@@ -91,6 +95,12 @@ func (n NetworkInterfaceDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tab
 		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
 	}
 
+	// create change feed.
+	if err = n.ChangeFeed.CreateByIDsWithTx(kt, tx, table.NetworkInterfaceTable, enumor.ChangeFeedInsert,
+		ids); err != nil {
+		return nil, err
+	}
+
 	// create audit.
 	audits := make([]*tableaudit.AuditTable, 0, len(models))
 	for _, one := range models {
@@ -146,6 +156,12 @@ func (n NetworkInterfaceDao) Update(kt *kit.Kit, filterExpr *filter.Expression,
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
 
 	_, err = n.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := n.ChangeFeed.CreateByWhereWithTx(kt, txn, table.NetworkInterfaceTable, enumor.ChangeFeedUpdate,
+			whereExpr, whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := n.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update network interface failed, filter: %s, err: %v, rid: %v",
@@ -178,12 +194,13 @@ func (n NetworkInterfaceDao) List(kt *kit.Kit, opt *types.ListOption) (*typesni.
 	columnTypes["extension.self_link"] = enumor.String
 	columnTypes["extension.security_group_id"] = enumor.String
 	columnTypes["extension.resource_group_name"] = enumor.String
-	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.DefaultPageOption); err != nil {
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes), filter.EnableTagRule()),
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.TagSqlWhereOption(table.NetworkInterfaceTable),
+		types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -300,6 +317,16 @@ func (n NetworkInterfaceDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter
 
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.NetworkInterfaceTable, whereExpr)
 
+	if err = n.ChangeFeed.CreateByWhereWithTx(kt, tx, table.NetworkInterfaceTable, enumor.ChangeFeedDelete,
+		whereExpr, whereValue); err != nil {
+		return err
+	}
+
+	if err = n.ResourceTag.DeleteByResWhereWithTx(kt, tx, table.NetworkInterfaceTable, whereExpr,
+		whereValue); err != nil {
+		return err
+	}
+
 	if _, err = n.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete azure network interface failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
 		return err
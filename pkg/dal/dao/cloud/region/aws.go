@@ -154,7 +154,7 @@ func (v *awsRegionDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*fi
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(region.AwsRegionColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
@@ -166,7 +166,7 @@ func (v *awsRegionDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*fi
 		}
 		whereOpt = whereOpts[0]
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
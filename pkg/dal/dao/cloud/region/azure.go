@@ -60,11 +60,11 @@ func (a AzureRegionDao) List(kt *kit.Kit, opt *types.ListOption) (*typesregion.L
 		return nil, errf.New(errf.InvalidParameter, "list azure region options is nil")
 	}
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(region.AzureRegionColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, argMap, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, argMap, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +152,7 @@ func (v *gcpRegionDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*fi
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(region.GcpRegionColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
@@ -164,7 +164,7 @@ func (v *gcpRegionDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*fi
 		}
 		whereOpt = whereOpts[0]
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
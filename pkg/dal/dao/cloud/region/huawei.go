@@ -100,11 +100,11 @@ func (h HuaWeiRegionDao) List(kt *kit.Kit, opt *types.ListOption) (*typesregion.
 		return nil, errf.New(errf.InvalidParameter, "list huawei region options is nil")
 	}
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(region.HuaWeiRegionColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, argMap, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, argMap, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -154,7 +154,7 @@ func (v *tcloudRegionDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(region.TCloudRegionColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
@@ -166,7 +166,7 @@ func (v *tcloudRegionDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...
 		}
 		whereOpt = whereOpts[0]
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -99,11 +99,11 @@ func (a AzureRGDao) List(kt *kit.Kit, opt *types.ListOption) (*typesregion.ListA
 		return nil, errf.New(errf.InvalidParameter, "list azure resource group options is nil")
 	}
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(region.AzureRGColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, argMap, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, argMap, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
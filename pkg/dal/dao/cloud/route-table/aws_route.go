@@ -218,7 +218,7 @@ func (r *awsRouteDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*fil
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(routetable.AwsRouteColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
@@ -230,7 +230,7 @@ func (r *awsRouteDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*fil
 		}
 		whereOpt = whereOpts[0]
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -215,7 +215,7 @@ func (r *azureRouteDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*f
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(routetable.AzureRouteColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
@@ -227,7 +227,7 @@ func (r *azureRouteDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*f
 		}
 		whereOpt = whereOpts[0]
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -26,8 +26,10 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/audit"
+	changefeed "hcm/pkg/dal/dao/change-feed"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	resourcetag "hcm/pkg/dal/dao/resource-tag"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -54,17 +56,23 @@ var _ RouteTable = new(routeTableDao)
 
 // routeTableDao route table dao.
 type routeTableDao struct {
-	orm   orm.Interface
-	idGen idgenerator.IDGenInterface
-	audit audit.Interface
+	orm         orm.Interface
+	idGen       idgenerator.IDGenInterface
+	audit       audit.Interface
+	changeFeed  changefeed.Interface
+	resourceTag resourcetag.Interface
 }
 
 // NewRouteTableDao create a route table dao.
-func NewRouteTableDao(orm orm.Interface, idGen idgenerator.IDGenInterface, audit audit.Interface) RouteTable {
+func NewRouteTableDao(orm orm.Interface, idGen idgenerator.IDGenInterface, audit audit.Interface,
+	changeFeed changefeed.Interface, resourceTag resourcetag.Interface) RouteTable {
+
 	return &routeTableDao{
-		orm:   orm,
-		idGen: idGen,
-		audit: audit,
+		orm:         orm,
+		idGen:       idGen,
+		audit:       audit,
+		changeFeed:  changeFeed,
+		resourceTag: resourceTag,
 	}
 }
 
@@ -100,6 +108,11 @@ func (r *routeTableDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []rou
 		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
 	}
 
+	// create change feed.
+	if err = r.changeFeed.CreateByIDsWithTx(kt, tx, table.RouteTableTable, enumor.ChangeFeedInsert, ids); err != nil {
+		return nil, err
+	}
+
 	// create audit.
 	audits := make([]*tableaudit.AuditTable, 0, len(models))
 	for _, one := range models {
@@ -153,6 +166,12 @@ func (r *routeTableDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
 
 	_, err = r.orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := r.changeFeed.CreateByWhereWithTx(kt, txn, table.RouteTableTable, enumor.ChangeFeedUpdate, whereExpr,
+			whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := r.orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update route table failed, err: %v, filter: %s, rid: %v", err, filterExpr, kt.Rid)
@@ -183,12 +202,12 @@ func (r *routeTableDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*f
 
 	columnTypes := routetable.RouteTableColumns.ColumnTypes()
 	columnTypes["extension.resource_group_name"] = enumor.String
-	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.DefaultPageOption); err != nil {
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes), filter.EnableTagRule()),
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TagSqlWhereOption(table.RouteTableTable)
 	if len(whereOpts) != 0 && whereOpts[0] != nil {
 		err := whereOpts[0].Validate()
 		if err != nil {
@@ -196,7 +215,7 @@ func (r *routeTableDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*f
 		}
 		whereOpt = whereOpts[0]
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -241,6 +260,16 @@ func (r *routeTableDao) BatchDeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *
 		return err
 	}
 
+	err = r.changeFeed.CreateByWhereWithTx(kt, tx, table.RouteTableTable, enumor.ChangeFeedDelete, whereExpr,
+		whereValue)
+	if err != nil {
+		return err
+	}
+
+	if err = r.resourceTag.DeleteByResWhereWithTx(kt, tx, table.RouteTableTable, whereExpr, whereValue); err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.RouteTableTable, whereExpr)
 	if _, err = r.orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete route table failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
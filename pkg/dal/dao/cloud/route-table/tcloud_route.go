@@ -215,7 +215,7 @@ func (r *tcloudRouteDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(routetable.TCloudRouteColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
@@ -227,7 +227,7 @@ func (r *tcloudRouteDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*
 		}
 		whereOpt = whereOpts[0]
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
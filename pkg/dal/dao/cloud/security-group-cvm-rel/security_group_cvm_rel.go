@@ -23,10 +23,12 @@ import (
 	"fmt"
 
 	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/cloud/cvm"
 	securitygroup "hcm/pkg/dal/dao/cloud/security-group"
 	"hcm/pkg/dal/dao/orm"
+	resourcehistory "hcm/pkg/dal/dao/resource-history"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -35,6 +37,7 @@ import (
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
 	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -51,7 +54,8 @@ var _ Interface = new(Dao)
 
 // Dao define security group and cvm relation dao.
 type Dao struct {
-	Orm orm.Interface
+	Orm     orm.Interface
+	History resourcehistory.Interface
 }
 
 // ListJoinSecurityGroup rels with security groups.
@@ -107,6 +111,11 @@ func (dao Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rels []cloud.Security
 		return fmt.Errorf("get cvm count not right")
 	}
 
+	// 主机的快照中包含绑定的安全组，绑定前记录主机的变更历史
+	if err = dao.createCvmHistory(kt, tx, cvmIDs); err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.SecurityGroupCvmTable,
 		cloud.SecurityGroupCvmRelColumns.ColumnExpr(), cloud.SecurityGroupCvmRelColumns.ColonNameExpr())
 
@@ -125,11 +134,11 @@ func (dao Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListSecurityGrou
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(cloud.SecurityGroupCvmRelColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -175,6 +184,23 @@ func (dao Dao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) e
 		return err
 	}
 
+	// 主机的快照中包含绑定的安全组，解绑前记录主机的变更历史
+	listSql := fmt.Sprintf(`SELECT DISTINCT cvm_id FROM %s %s`, table.SecurityGroupCvmTable, whereExpr)
+	rels := make([]cloud.SecurityGroupCvmRelTable, 0)
+	if err = dao.Orm.Txn(tx).Select(kt.Ctx, &rels, listSql, whereValue); err != nil {
+		logs.ErrorJson("select security group cvm rels failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	cvmIDs := make([]string, 0, len(rels))
+	for _, rel := range rels {
+		cvmIDs = append(cvmIDs, rel.CvmID)
+	}
+
+	if err = dao.createCvmHistory(kt, tx, cvmIDs); err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.SecurityGroupCvmTable, whereExpr)
 	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete security group cvm rels failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
@@ -183,3 +209,19 @@ func (dao Dao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) e
 
 	return nil
 }
+
+// createCvmHistory create the update histories of the cvms whose bound security groups are going to change.
+func (dao Dao) createCvmHistory(kt *kit.Kit, tx *sqlx.Tx, cvmIDs []string) error {
+	if len(cvmIDs) == 0 {
+		return nil
+	}
+
+	err := dao.History.CreateByWhereWithTx(kt, tx, table.CvmTable, enumor.ChangeFeedUpdate, "WHERE id IN (:ids)",
+		map[string]interface{}{"ids": slice.Unique(cvmIDs)})
+	if err != nil {
+		logs.Errorf("create cvm history failed, err: %v, ids: %v, rid: %s", err, cvmIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
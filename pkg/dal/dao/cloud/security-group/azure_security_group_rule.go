@@ -195,7 +195,7 @@ func (dao *AzureSGRuleDao) List(kt *kit.Kit, opt *types.SGRuleListOption) (*type
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(cloud.AzureSGRuleColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
@@ -212,7 +212,7 @@ func (dao *AzureSGRuleDao) List(kt *kit.Kit, opt *types.SGRuleListOption) (*type
 			},
 		},
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -26,8 +26,10 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/audit"
+	changefeed "hcm/pkg/dal/dao/change-feed"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	resourcetag "hcm/pkg/dal/dao/resource-tag"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -54,9 +56,11 @@ var _ SecurityGroup = new(SecurityGroupDao)
 
 // SecurityGroupDao security group dao.
 type SecurityGroupDao struct {
-	Orm   orm.Interface
-	IDGen idgenerator.IDGenInterface
-	Audit audit.Interface
+	Orm         orm.Interface
+	IDGen       idgenerator.IDGenInterface
+	Audit       audit.Interface
+	ChangeFeed  changefeed.Interface
+	ResourceTag resourcetag.Interface
 }
 
 // BatchCreateWithTx sg with tx.
@@ -83,6 +87,12 @@ func (s SecurityGroupDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, sgs []*clo
 		return nil, fmt.Errorf("insert %s failed, err: %v", table.SecurityGroupTable, err)
 	}
 
+	// create change feed.
+	if err = s.ChangeFeed.CreateByIDsWithTx(kt, tx, table.SecurityGroupTable, enumor.ChangeFeedInsert,
+		ids); err != nil {
+		return nil, err
+	}
+
 	// create audit.
 	audits := make([]*tableaudit.AuditTable, 0, len(sgs))
 	for _, one := range sgs {
@@ -136,6 +146,12 @@ func (s SecurityGroupDao) Update(kt *kit.Kit, expr *filter.Expression, sg *cloud
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, sg.TableName(), setExpr, whereExpr)
 
 	_, err = s.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := s.ChangeFeed.CreateByWhereWithTx(kt, txn, table.SecurityGroupTable, enumor.ChangeFeedUpdate,
+			whereExpr, whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := s.Orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update security group failed, err: %v, filter: %s, rid: %v", err, expr, kt.Rid)
@@ -175,6 +191,11 @@ func (s SecurityGroupDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
 	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, sg.TableName(), setExpr)
 
 	toUpdate["id"] = id
+	err = s.ChangeFeed.CreateByIDsWithTx(kt, tx, table.SecurityGroupTable, enumor.ChangeFeedUpdate, []string{id})
+	if err != nil {
+		return err
+	}
+
 	_, err = s.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
 	if err != nil {
 		logs.ErrorJson("update security group failed, err: %v, id: %s, rid: %v", err, id, kt.Rid)
@@ -193,12 +214,13 @@ func (s SecurityGroupDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListS
 	columnTypes := cloud.SecurityGroupColumns.ColumnTypes()
 	columnTypes["extension.resource_group_name"] = enumor.String
 	columnTypes["extension.vpc_id"] = enumor.String
-	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.DefaultPageOption); err != nil {
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes), filter.EnableTagRule()),
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.TagSqlWhereOption(table.SecurityGroupTable),
+		types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -244,6 +266,16 @@ func (s SecurityGroupDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Ex
 		return err
 	}
 
+	err = s.ChangeFeed.CreateByWhereWithTx(kt, tx, table.SecurityGroupTable, enumor.ChangeFeedDelete, whereExpr,
+		whereValue)
+	if err != nil {
+		return err
+	}
+
+	if err = s.ResourceTag.DeleteByResWhereWithTx(kt, tx, table.SecurityGroupTable, whereExpr, whereValue); err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.SecurityGroupTable, whereExpr)
 	if _, err = s.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete security group failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
@@ -243,7 +243,7 @@ func (dao *TCloudSGRuleDao) List(kt *kit.Kit, opt *types.SGRuleListOption) (*typ
 	}
 
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(cloud.TCloudSGRuleColumns.ColumnTypes())),
-		core.DefaultPageOption); err != nil {
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
@@ -260,7 +260,7 @@ func (dao *TCloudSGRuleDao) List(kt *kit.Kit, opt *types.SGRuleListOption) (*typ
 			},
 		},
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -26,8 +26,10 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/audit"
+	changefeed "hcm/pkg/dal/dao/change-feed"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	resourcetag "hcm/pkg/dal/dao/resource-tag"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -54,17 +56,23 @@ var _ Subnet = new(subnetDao)
 
 // subnetDao subnet dao.
 type subnetDao struct {
-	orm   orm.Interface
-	idGen idgenerator.IDGenInterface
-	audit audit.Interface
+	orm         orm.Interface
+	idGen       idgenerator.IDGenInterface
+	audit       audit.Interface
+	changeFeed  changefeed.Interface
+	resourceTag resourcetag.Interface
 }
 
 // NewSubnetDao create a subnet dao.
-func NewSubnetDao(orm orm.Interface, idGen idgenerator.IDGenInterface, audit audit.Interface) Subnet {
+func NewSubnetDao(orm orm.Interface, idGen idgenerator.IDGenInterface, audit audit.Interface,
+	changeFeed changefeed.Interface, resourceTag resourcetag.Interface) Subnet {
+
 	return &subnetDao{
-		orm:   orm,
-		idGen: idGen,
-		audit: audit,
+		orm:         orm,
+		idGen:       idGen,
+		audit:       audit,
+		changeFeed:  changeFeed,
+		resourceTag: resourceTag,
 	}
 }
 
@@ -106,6 +114,11 @@ func (s *subnetDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []cloud.S
 		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
 	}
 
+	// create change feed.
+	if err = s.changeFeed.CreateByIDsWithTx(kt, tx, table.SubnetTable, enumor.ChangeFeedInsert, ids); err != nil {
+		return nil, err
+	}
+
 	// create audit.
 	audits := make([]*tableaudit.AuditTable, 0, len(models))
 	for _, one := range models {
@@ -160,6 +173,12 @@ func (s *subnetDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *cl
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
 
 	_, err = s.orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := s.changeFeed.CreateByWhereWithTx(kt, txn, table.SubnetTable, enumor.ChangeFeedUpdate, whereExpr,
+			whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := s.orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update subnet failed, err: %v, filter: %s, rid: %v", err, filterExpr, kt.Rid)
@@ -192,12 +211,12 @@ func (s *subnetDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*filte
 	columnTypes["extension.self_link"] = enumor.String
 	columnTypes["extension.resource_group_name"] = enumor.String
 	columnTypes["extension.security_group_id"] = enumor.String
-	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.DefaultPageOption); err != nil {
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes), filter.EnableTagRule()),
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TagSqlWhereOption(table.SubnetTable)
 	if len(whereOpts) != 0 && whereOpts[0] != nil {
 		err := whereOpts[0].Validate()
 		if err != nil {
@@ -205,7 +224,7 @@ func (s *subnetDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*filte
 		}
 		whereOpt = whereOpts[0]
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -250,6 +269,15 @@ func (s *subnetDao) BatchDeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filt
 		return err
 	}
 
+	err = s.changeFeed.CreateByWhereWithTx(kt, tx, table.SubnetTable, enumor.ChangeFeedDelete, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
+	if err = s.resourceTag.DeleteByResWhereWithTx(kt, tx, table.SubnetTable, whereExpr, whereValue); err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.SubnetTable, whereExpr)
 	if _, err = s.orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete subnet failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
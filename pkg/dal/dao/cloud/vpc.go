@@ -26,8 +26,10 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/audit"
+	changefeed "hcm/pkg/dal/dao/change-feed"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	resourcetag "hcm/pkg/dal/dao/resource-tag"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/table"
@@ -53,17 +55,23 @@ var _ Vpc = new(vpcDao)
 
 // vpcDao vpc dao.
 type vpcDao struct {
-	orm   orm.Interface
-	idGen idgenerator.IDGenInterface
-	audit audit.Interface
+	orm         orm.Interface
+	idGen       idgenerator.IDGenInterface
+	audit       audit.Interface
+	changeFeed  changefeed.Interface
+	resourceTag resourcetag.Interface
 }
 
 // NewVpcDao create a vpc dao.
-func NewVpcDao(orm orm.Interface, idGen idgenerator.IDGenInterface, audit audit.Interface) Vpc {
+func NewVpcDao(orm orm.Interface, idGen idgenerator.IDGenInterface, audit audit.Interface,
+	changeFeed changefeed.Interface, resourceTag resourcetag.Interface) Vpc {
+
 	return &vpcDao{
-		orm:   orm,
-		idGen: idGen,
-		audit: audit,
+		orm:         orm,
+		idGen:       idGen,
+		audit:       audit,
+		changeFeed:  changeFeed,
+		resourceTag: resourceTag,
 	}
 }
 
@@ -97,6 +105,11 @@ func (v *vpcDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []cloud.VpcT
 		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
 	}
 
+	// create change feed.
+	if err = v.changeFeed.CreateByIDsWithTx(kt, tx, table.VpcTable, enumor.ChangeFeedInsert, ids); err != nil {
+		return nil, err
+	}
+
 	// create audit.
 	audits := make([]*tableaudit.AuditTable, 0, len(models))
 	for _, one := range models {
@@ -150,6 +163,11 @@ func (v *vpcDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *cloud
 	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
 
 	_, err = v.orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		err := v.changeFeed.CreateByWhereWithTx(kt, txn, table.VpcTable, enumor.ChangeFeedUpdate, whereExpr, whereValue)
+		if err != nil {
+			return nil, err
+		}
+
 		effected, err := v.orm.Txn(txn).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
 		if err != nil {
 			logs.ErrorJson("update vpc failed, err: %v, filter: %s, rid: %v", err, filterExpr, kt.Rid)
@@ -181,12 +199,12 @@ func (v *vpcDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*filter.S
 	columnTypes := cloud.VpcColumns.ColumnTypes()
 	columnTypes["extension.self_link"] = enumor.String
 	columnTypes["extension.resource_group_name"] = enumor.String
-	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
-		core.DefaultPageOption); err != nil {
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes), filter.EnableTagRule()),
+		core.DefaultCursorPageOption); err != nil {
 		return nil, err
 	}
 
-	whereOpt := tools.DefaultSqlWhereOption
+	whereOpt := tools.TagSqlWhereOption(table.VpcTable)
 	if len(whereOpts) != 0 && whereOpts[0] != nil {
 		err := whereOpts[0].Validate()
 		if err != nil {
@@ -194,7 +212,7 @@ func (v *vpcDao) List(kt *kit.Kit, opt *types.ListOption, whereOpts ...*filter.S
 		}
 		whereOpt = whereOpts[0]
 	}
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	whereExpr, whereValue, err := opt.SQLWhereExpr(whereOpt, types.DefaultPageSQLOption)
 	if err != nil {
 		return nil, err
 	}
@@ -239,6 +257,15 @@ func (v *vpcDao) BatchDeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.
 		return err
 	}
 
+	err = v.changeFeed.CreateByWhereWithTx(kt, tx, table.VpcTable, enumor.ChangeFeedDelete, whereExpr, whereValue)
+	if err != nil {
+		return err
+	}
+
+	if err = v.resourceTag.DeleteByResWhereWithTx(kt, tx, table.VpcTable, whereExpr, whereValue); err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.VpcTable, whereExpr)
 	if _, err = v.orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
 		logs.ErrorJson("delete vpc failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
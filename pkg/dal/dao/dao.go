@@ -28,6 +28,8 @@ import (
 	"hcm/pkg/dal/dao/application"
 	"hcm/pkg/dal/dao/audit"
 	"hcm/pkg/dal/dao/auth"
+	bizassignrule "hcm/pkg/dal/dao/biz-assign-rule"
+	changefeed "hcm/pkg/dal/dao/change-feed"
 	"hcm/pkg/dal/dao/cloud"
 	"hcm/pkg/dal/dao/cloud/bill"
 	"hcm/pkg/dal/dao/cloud/cvm"
@@ -44,9 +46,16 @@ import (
 	securitygroup "hcm/pkg/dal/dao/cloud/security-group"
 	sgcvmrel "hcm/pkg/dal/dao/cloud/security-group-cvm-rel"
 	"hcm/pkg/dal/dao/cloud/zone"
+	"hcm/pkg/dal/dao/event"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/idempotency"
 	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/rbac"
 	recyclerecord "hcm/pkg/dal/dao/recycle-record"
+	resourcehistory "hcm/pkg/dal/dao/resource-history"
+	resourcetag "hcm/pkg/dal/dao/resource-tag"
+	tagpolicy "hcm/pkg/dal/dao/tag-policy"
+	"hcm/pkg/dal/dao/token"
 	"hcm/pkg/kit"
 	"hcm/pkg/metrics"
 
@@ -68,6 +77,7 @@ type Set interface {
 	GcpFirewallRule() cloud.GcpFirewallRule
 	Cloud() cloud.Cloud
 	AccountBizRel() cloud.AccountBizRel
+	AccountHealth() cloud.AccountHealth
 	Vpc() cloud.Vpc
 	Subnet() cloud.Subnet
 	HuaWeiRegion() region.HuaWeiRegion
@@ -91,34 +101,72 @@ type Set interface {
 	DiskCvmRel() diskcvmrel.DiskCvmRel
 	EipCvmRel() eipcvmrel.EipCvmRel
 	AccountBillConfig() bill.Interface
+	CostAnomaly() bill.CostAnomaly
+	RbacRole() rbac.Role
+	RbacRoleBinding() rbac.RoleBinding
+	ApiToken() token.ApiToken
+	ServiceAccount() token.ServiceAccount
+	IdempotencyRecord() idempotency.Record
+	Event() event.Event
+	EventSubscription() event.Subscription
+	EventDeadLetter() event.DeadLetter
+	ChangeFeed() changefeed.Interface
+	ResourceHistory() resourcehistory.Interface
+	ResourceTag() resourcetag.Interface
+	BizAssignRule() bizassignrule.Interface
+	TagPolicy() tagpolicy.Policy
+	TagPolicyViolation() tagpolicy.Violation
 
 	Txn() *Txn
 }
 
 // NewDaoSet create the DAO set instance.
 func NewDaoSet(opt cc.DataBase) (Set, error) {
-	db, err := connect(opt.Resource)
+	db, err := Connect(opt.Resource)
 	if err != nil {
 		return nil, fmt.Errorf("init sharding failed, err: %v", err)
 	}
 
-	ormInst := orm.InitOrm(db, orm.MetricsRegisterer(metrics.Register()),
-		orm.IngressLimiter(opt.Limiter.QPS, opt.Limiter.Burst), orm.SlowRequestMS(opt.MaxSlowLogLatencyMS))
+	ormOpts := []orm.Option{orm.MetricsRegisterer(metrics.Register()),
+		orm.IngressLimiter(opt.Limiter.QPS, opt.Limiter.Burst), orm.SlowRequestMS(opt.MaxSlowLogLatencyMS)}
+
+	// read replicas have their own connection pools, so that reads do not compete with writes on the primary.
+	if len(opt.Replicas) != 0 {
+		replicas := make(map[string]*sqlx.DB, len(opt.Replicas))
+		for _, replicaOpt := range opt.Replicas {
+			replica, err := openReplica(replicaOpt)
+			if err != nil {
+				return nil, fmt.Errorf("init read replica failed, err: %v", err)
+			}
+			replicas[strings.Join(replicaOpt.Endpoints, ",")] = replica
+		}
+
+		ormOpts = append(ormOpts, orm.Replicas(replicas, orm.ReplicaOption{
+			MaxLag:        time.Duration(opt.Replica.MaxLagSec) * time.Second,
+			CheckInterval: time.Duration(opt.Replica.HealthCheckIntervalSec) * time.Second,
+		}))
+	}
+
+	ormInst := orm.InitOrm(db, ormOpts...)
 
 	idGen := idgenerator.New(db, idgenerator.DefaultMaxRetryCount)
+	history := resourcehistory.NewResourceHistoryDao(ormInst)
 
 	s := &set{
-		idGen: idGen,
-		orm:   ormInst,
-		db:    db,
-		audit: audit.NewAudit(ormInst),
+		idGen:       idGen,
+		orm:         ormInst,
+		db:          db,
+		audit:       audit.NewAudit(ormInst),
+		changeFeed:  changefeed.NewChangeFeedDao(ormInst, history),
+		history:     history,
+		resourceTag: resourcetag.NewResourceTagDao(ormInst, idGen),
 	}
 
 	return s, nil
 }
 
-// connect to mysql
-func connect(opt cc.ResourceDB) (*sqlx.DB, error) {
+// Connect to mysql
+func Connect(opt cc.ResourceDB) (*sqlx.DB, error) {
 	db, err := sqlx.Connect("mysql", uri(opt))
 	if err != nil {
 		return nil, fmt.Errorf("connect to mysql failed, err: %v", err)
@@ -131,6 +179,21 @@ func connect(opt cc.ResourceDB) (*sqlx.DB, error) {
 	return db, nil
 }
 
+// openReplica open the read replica without connecting to it, so that an unavailable replica does not block the
+// service starting, the replica health is checked by orm before routing reads to it.
+func openReplica(opt cc.ResourceDB) (*sqlx.DB, error) {
+	db, err := sqlx.Open("mysql", uri(opt))
+	if err != nil {
+		return nil, fmt.Errorf("open mysql replica failed, err: %v", err)
+	}
+
+	db.SetMaxOpenConns(int(opt.MaxOpenConn))
+	db.SetMaxIdleConns(int(opt.MaxIdleConn))
+	db.SetConnMaxLifetime(time.Duration(opt.MaxIdleTimeoutMin) * time.Minute)
+
+	return db, nil
+}
+
 // uri generate the standard db connection string format uri.
 func uri(opt cc.ResourceDB) string {
 	return fmt.Sprintf(
@@ -147,10 +210,13 @@ func uri(opt cc.ResourceDB) string {
 }
 
 type set struct {
-	idGen idgenerator.IDGenInterface
-	orm   orm.Interface
-	db    *sqlx.DB
-	audit audit.Interface
+	idGen       idgenerator.IDGenInterface
+	orm         orm.Interface
+	db          *sqlx.DB
+	audit       audit.Interface
+	changeFeed  changefeed.Interface
+	history     resourcehistory.Interface
+	resourceTag resourcetag.Interface
 }
 
 // EipCvmRel return EipCvmRel dao.
@@ -192,18 +258,22 @@ func (s *set) NiCvmRel() nicvmrel.NiCvmRel {
 // Disk return Disk dao.
 func (s *set) Disk() disk.Disk {
 	return &disk.DiskDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:         s.orm,
+		IDGen:       s.idGen,
+		Audit:       s.audit,
+		ChangeFeed:  s.changeFeed,
+		ResourceTag: s.resourceTag,
 	}
 }
 
 // Eip return Eip dao.
 func (s *set) Eip() eip.Eip {
 	return &eip.EipDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:         s.orm,
+		IDGen:       s.idGen,
+		Audit:       s.audit,
+		ChangeFeed:  s.changeFeed,
+		ResourceTag: s.resourceTag,
 	}
 }
 
@@ -242,9 +312,10 @@ func (s *set) HuaWeiRegion() region.HuaWeiRegion {
 // Account return account dao.
 func (s *set) Account() cloud.Account {
 	return &cloud.AccountDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:        s.orm,
+		IDGen:      s.idGen,
+		Audit:      s.audit,
+		ChangeFeed: s.changeFeed,
 	}
 }
 
@@ -255,14 +326,122 @@ func (s *set) AccountBizRel() cloud.AccountBizRel {
 	}
 }
 
+// AccountHealth returns account health dao.
+func (s *set) AccountHealth() cloud.AccountHealth {
+	return &cloud.AccountHealthDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// RbacRole returns built-in rbac role dao.
+func (s *set) RbacRole() rbac.Role {
+	return &rbac.RoleDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// RbacRoleBinding returns built-in rbac role binding dao.
+func (s *set) RbacRoleBinding() rbac.RoleBinding {
+	return &rbac.RoleBindingDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ApiToken returns api token dao.
+func (s *set) ApiToken() token.ApiToken {
+	return &token.ApiTokenDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ServiceAccount returns service account dao.
+func (s *set) ServiceAccount() token.ServiceAccount {
+	return &token.ServiceAccountDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// IdempotencyRecord returns idempotency record dao.
+func (s *set) IdempotencyRecord() idempotency.Record {
+	return &idempotency.RecordDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// Event returns resource lifecycle event dao.
+func (s *set) Event() event.Event {
+	return event.NewEventDao(s.orm)
+}
+
+// EventSubscription returns event subscription dao.
+func (s *set) EventSubscription() event.Subscription {
+	return &event.SubscriptionDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// EventDeadLetter returns event dead letter dao.
+func (s *set) EventDeadLetter() event.DeadLetter {
+	return &event.DeadLetterDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ChangeFeed returns resource change feed dao.
+func (s *set) ChangeFeed() changefeed.Interface {
+	return s.changeFeed
+}
+
+// ResourceHistory returns resource change history dao.
+func (s *set) ResourceHistory() resourcehistory.Interface {
+	return s.history
+}
+
+// ResourceTag returns resource tag dao.
+func (s *set) ResourceTag() resourcetag.Interface {
+	return s.resourceTag
+}
+
+// BizAssignRule returns biz assign rule dao.
+func (s *set) BizAssignRule() bizassignrule.Interface {
+	return &bizassignrule.Dao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// TagPolicy returns tag policy dao.
+func (s *set) TagPolicy() tagpolicy.Policy {
+	return &tagpolicy.PolicyDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// TagPolicyViolation returns tag policy violation dao.
+func (s *set) TagPolicyViolation() tagpolicy.Violation {
+	return &tagpolicy.ViolationDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
 // Vpc returns vpc dao.
 func (s *set) Vpc() cloud.Vpc {
-	return cloud.NewVpcDao(s.orm, s.idGen, s.audit)
+	return cloud.NewVpcDao(s.orm, s.idGen, s.audit, s.changeFeed, s.resourceTag)
 }
 
 // Subnet returns subnet dao.
 func (s *set) Subnet() cloud.Subnet {
-	return cloud.NewSubnetDao(s.orm, s.idGen, s.audit)
+	return cloud.NewSubnetDao(s.orm, s.idGen, s.audit, s.changeFeed, s.resourceTag)
 }
 
 // Auth return auth dao.
@@ -282,16 +461,19 @@ func (s *set) Cloud() cloud.Cloud {
 // SecurityGroup return security group dao.
 func (s *set) SecurityGroup() securitygroup.SecurityGroup {
 	return &securitygroup.SecurityGroupDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:         s.orm,
+		IDGen:       s.idGen,
+		Audit:       s.audit,
+		ChangeFeed:  s.changeFeed,
+		ResourceTag: s.resourceTag,
 	}
 }
 
 // SGCvmRel return security group cvm rel dao.
 func (s *set) SGCvmRel() sgcvmrel.Interface {
 	return &sgcvmrel.Dao{
-		Orm: s.orm,
+		Orm:     s.orm,
+		History: s.history,
 	}
 }
 
@@ -307,9 +489,10 @@ func (s *set) TCloudSGRule() securitygroup.TCloudSGRule {
 // GcpFirewallRule return gcp firewall rule dao.
 func (s *set) GcpFirewallRule() cloud.GcpFirewallRule {
 	return &cloud.GcpFirewallRuleDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:        s.orm,
+		IDGen:      s.idGen,
+		Audit:      s.audit,
+		ChangeFeed: s.changeFeed,
 	}
 }
 
@@ -343,9 +526,11 @@ func (s *set) AzureSGRule() securitygroup.AzureSGRule {
 // Cvm return cvm dao.
 func (s *set) Cvm() cvm.Interface {
 	return &cvm.Dao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:         s.orm,
+		IDGen:       s.idGen,
+		Audit:       s.audit,
+		ChangeFeed:  s.changeFeed,
+		ResourceTag: s.resourceTag,
 	}
 }
 
@@ -366,7 +551,7 @@ func (s *set) GcpRegion() region.GcpRegion {
 
 // RouteTable returns route table dao.
 func (s *set) RouteTable() routetable.RouteTable {
-	return routetable.NewRouteTableDao(s.orm, s.idGen, s.audit)
+	return routetable.NewRouteTableDao(s.orm, s.idGen, s.audit, s.changeFeed, s.resourceTag)
 }
 
 // Route returns route dao.
@@ -398,9 +583,11 @@ func (s *set) ApprovalProcess() application.ApprovalProcess {
 // NetworkInterface return network interface dao.
 func (s *set) NetworkInterface() networkinterface.NetworkInterface {
 	return &networkinterface.NetworkInterfaceDao{
-		Orm:   s.orm,
-		IDGen: s.idGen,
-		Audit: s.audit,
+		Orm:         s.orm,
+		IDGen:       s.idGen,
+		Audit:       s.audit,
+		ChangeFeed:  s.changeFeed,
+		ResourceTag: s.resourceTag,
 	}
 }
 
@@ -434,3 +621,11 @@ func (s *set) AccountBillConfig() bill.Interface {
 		Audit: s.audit,
 	}
 }
+
+// CostAnomaly returns cost anomaly dao.
+func (s *set) CostAnomaly() bill.CostAnomaly {
+	return &bill.CostAnomalyDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
@@ -0,0 +1,166 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/event"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DeadLetter only used for event dead letter.
+type DeadLetter interface {
+	Create(kt *kit.Kit, model *event.DeadLetterTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListEventDeadLetterDetails, error)
+	Delete(kt *kit.Kit, expr *filter.Expression) error
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ DeadLetter = new(DeadLetterDao)
+
+// DeadLetterDao event dead letter dao.
+type DeadLetterDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// Create event dead letter, if the event is already in the dead letter of the subscription, its attempts is
+// accumulated and the last error is updated.
+func (d DeadLetterDao) Create(kt *kit.Kit, model *event.DeadLetterTable) error {
+	if model == nil {
+		return errf.New(errf.InvalidParameter, "event dead letter model is nil")
+	}
+
+	id, err := d.IDGen.One(kt, table.EventDeadLetterTable)
+	if err != nil {
+		return err
+	}
+	model.ID = id
+
+	if err = model.InsertValidate(); err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s) ON DUPLICATE KEY UPDATE attempts = attempts + VALUES(attempts),
+		last_error = VALUES(last_error)`, table.EventDeadLetterTable, event.DeadLetterColumns.ColumnExpr(),
+		event.DeadLetterColumns.ColonNameExpr())
+
+	if err = d.Orm.Do().Insert(kt.Ctx, sql, model); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.EventDeadLetterTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.EventDeadLetterTable, err)
+	}
+
+	return nil
+}
+
+// List event dead letter.
+func (d DeadLetterDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListEventDeadLetterDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list event dead letter options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(event.DeadLetterColumns.ColumnTypes())),
+		core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.EventDeadLetterTable, whereExpr)
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count event dead letter failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListEventDeadLetterDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, event.DeadLetterColumns.FieldsNamedExpr(opt.Fields),
+		table.EventDeadLetterTable, whereExpr, pageExpr)
+
+	details := make([]event.DeadLetterTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListEventDeadLetterDetails{Details: details}, nil
+}
+
+// Delete event dead letter.
+func (d DeadLetterDao) Delete(kt *kit.Kit, filterExpr *filter.Expression) error {
+	whereExpr, whereValue, err := d.deleteWhereExpr(filterExpr)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.EventDeadLetterTable, whereExpr)
+	if _, err = d.Orm.Do().Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete event dead letter failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteWithTx delete event dead letter with tx.
+func (d DeadLetterDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression) error {
+	whereExpr, whereValue, err := d.deleteWhereExpr(filterExpr)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.EventDeadLetterTable, whereExpr)
+	if _, err = d.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete event dead letter failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func (d DeadLetterDao) deleteWhereExpr(filterExpr *filter.Expression) (string, map[string]interface{}, error) {
+	if filterExpr == nil {
+		return "", nil, errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	return filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+}
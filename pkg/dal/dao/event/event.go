@@ -0,0 +1,156 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event defines the dao of resource lifecycle events and their webhook subscriptions.
+package event
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	changefeed "hcm/pkg/dal/dao/change-feed"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablechangefeed "hcm/pkg/dal/table/change-feed"
+	"hcm/pkg/dal/table/event"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Event only used for resource lifecycle event.
+type Event interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []*event.EventTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListEventDetails, error)
+	Delete(kt *kit.Kit, expr *filter.Expression) (int64, error)
+	Sequence(kt *kit.Kit, limit uint) (int, error)
+}
+
+var _ Event = new(EventDao)
+
+// NewEventDao new event dao.
+func NewEventDao(orm orm.Interface) Event {
+	return &EventDao{
+		Orm: orm,
+	}
+}
+
+// EventDao event dao.
+type EventDao struct {
+	Orm orm.Interface
+}
+
+// BatchCreateWithTx create events with tx, events should be created in the same transaction with the data they
+// describe, so that the events are never lost or emitted for the rolled back data.
+func (d EventDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []*event.EventTable) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	for _, one := range models {
+		if err := one.InsertValidate(); err != nil {
+			return err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.EventTable, event.EventColumns.ColumnExpr(),
+		event.EventColumns.ColonNameExpr())
+
+	if err := d.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.EventTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.EventTable, err)
+	}
+
+	return nil
+}
+
+// List event.
+func (d EventDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListEventDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list event options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(event.EventColumns.ColumnTypes())),
+		core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.EventTable, whereExpr)
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count event failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListEventDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, event.EventColumns.FieldsNamedExpr(opt.Fields), table.EventTable,
+		whereExpr, pageExpr)
+
+	details := make([]event.EventTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListEventDetails{Details: details}, nil
+}
+
+// Delete event, returns the deleted count.
+func (d EventDao) Delete(kt *kit.Kit, filterExpr *filter.Expression) (int64, error) {
+	if filterExpr == nil {
+		return 0, errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return 0, err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.EventTable, whereExpr)
+	deleted, err := d.Orm.Do().Delete(kt.Ctx, sql, whereValue)
+	if err != nil {
+		logs.ErrorJson("delete event failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// Sequence assigns the commit sequences to the committed events that are not sequenced yet by the same sequencer
+// of change feeds, returns the count of the sequenced events.
+func (d EventDao) Sequence(kt *kit.Kit, limit uint) (int, error) {
+	return changefeed.SequenceTable(kt, d.Orm, table.EventTable, tablechangefeed.EventSequenceCounterID, limit)
+}
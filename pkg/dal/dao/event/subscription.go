@@ -0,0 +1,182 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/event"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Subscription only used for event subscription.
+type Subscription interface {
+	CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *event.SubscriptionTable) (string, error)
+	Update(kt *kit.Kit, expr *filter.Expression, model *event.SubscriptionTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListEventSubscriptionDetails, error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ Subscription = new(SubscriptionDao)
+
+// SubscriptionDao event subscription dao.
+type SubscriptionDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// CreateWithTx create event subscription with tx.
+func (d SubscriptionDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *event.SubscriptionTable) (string, error) {
+	if model == nil {
+		return "", errf.New(errf.InvalidParameter, "event subscription model is nil")
+	}
+
+	id, err := d.IDGen.One(kt, table.EventSubscriptionTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.EventSubscriptionTable,
+		event.SubscriptionColumns.ColumnExpr(), event.SubscriptionColumns.ColonNameExpr())
+
+	if err = d.Orm.Txn(tx).Insert(kt.Ctx, sql, model); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.EventSubscriptionTable, err, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %v", table.EventSubscriptionTable, err)
+	}
+
+	return id, nil
+}
+
+// Update event subscription.
+func (d SubscriptionDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *event.SubscriptionTable) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is nil")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
+
+	effected, err := d.Orm.Do().Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
+	if err != nil {
+		logs.ErrorJson("update event subscription failed, filter: %s, err: %v, rid: %v", filterExpr, err, kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		logs.ErrorJson("update event subscription, but record not found, filter: %v, rid: %v", filterExpr, kt.Rid)
+		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
+	}
+
+	return nil
+}
+
+// List event subscription.
+func (d SubscriptionDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListEventSubscriptionDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list event subscription options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(event.SubscriptionColumns.ColumnTypes())),
+		core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.EventSubscriptionTable, whereExpr)
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count event subscription failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListEventSubscriptionDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, event.SubscriptionColumns.FieldsNamedExpr(opt.Fields),
+		table.EventSubscriptionTable, whereExpr, pageExpr)
+
+	details := make([]event.SubscriptionTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListEventSubscriptionDetails{Details: details}, nil
+}
+
+// DeleteWithTx delete event subscription with tx.
+func (d SubscriptionDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.EventSubscriptionTable, whereExpr)
+	if _, err := d.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete event subscription failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
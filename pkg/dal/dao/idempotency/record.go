@@ -0,0 +1,199 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idempotency defines the dao of the idempotency keys of mutating requests.
+package idempotency
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/idempotency"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Record only used for idempotency record.
+type Record interface {
+	Acquire(kt *kit.Kit, model *idempotency.RecordTable) (bool, *idempotency.RecordTable, error)
+	Complete(kt *kit.Kit, model *idempotency.RecordTable) error
+	Release(kt *kit.Kit, model *idempotency.RecordTable) error
+	DeleteExpired(kt *kit.Kit) (int64, error)
+}
+
+var _ Record = new(RecordDao)
+
+// RecordDao idempotency record dao.
+type RecordDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// now returns the current time in the format of expired_at, utc is used so that it's comparable as string.
+func now() string {
+	return time.Now().UTC().Format(constant.TimeStdFormat)
+}
+
+// Acquire save the record as processing if the key of the owner is not used or the used one is expired, returns true
+// if the record is saved, otherwise returns false and the existing record of the key.
+func (d RecordDao) Acquire(kt *kit.Kit, model *idempotency.RecordTable) (bool, *idempotency.RecordTable, error) {
+	if model == nil {
+		return false, nil, errf.New(errf.InvalidParameter, "idempotency record model is nil")
+	}
+
+	keyArgs := map[string]interface{}{
+		"owner":           model.Owner,
+		"idempotency_key": model.IdempotencyKey,
+		"now":             now(),
+	}
+
+	// remove the expired record so that the key can be used again.
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE owner = :owner AND idempotency_key = :idempotency_key AND `+
+		`expired_at < :now`, table.IdempotencyRecordTable)
+	if _, err := d.Orm.Do().Delete(kt.Ctx, sql, keyArgs); err != nil {
+		logs.Errorf("delete expired idempotency record failed, err: %v, key: %s, rid: %s", err,
+			model.IdempotencyKey, kt.Rid)
+		return false, nil, err
+	}
+
+	id, err := d.IDGen.One(kt, table.IdempotencyRecordTable)
+	if err != nil {
+		return false, nil, err
+	}
+	model.ID = id
+	model.State = enumor.IdempotencyProcessing
+
+	if err = model.InsertValidate(); err != nil {
+		return false, nil, err
+	}
+
+	// the unique key of owner and idempotency key guarantees that only one of the concurrent requests is saved.
+	sql = fmt.Sprintf(`INSERT IGNORE INTO %s (id, owner, idempotency_key, request_hash, state, expired_at, creator) `+
+		`VALUES(:id, :owner, :idempotency_key, :request_hash, :state, :expired_at, :creator)`,
+		table.IdempotencyRecordTable)
+	args := map[string]interface{}{
+		"id":              model.ID,
+		"owner":           model.Owner,
+		"idempotency_key": model.IdempotencyKey,
+		"request_hash":    model.RequestHash,
+		"state":           model.State,
+		"expired_at":      model.ExpiredAt,
+		"creator":         model.Creator,
+	}
+	effected, err := d.Orm.Do().Update(kt.Ctx, sql, args)
+	if err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.IdempotencyRecordTable, err, kt.Rid)
+		return false, nil, fmt.Errorf("insert %s failed, err: %v", table.IdempotencyRecordTable, err)
+	}
+
+	if effected == 1 {
+		return true, nil, nil
+	}
+
+	sql = fmt.Sprintf(`SELECT %s FROM %s WHERE owner = :owner AND idempotency_key = :idempotency_key`,
+		idempotency.RecordColumns.NamedExpr(), table.IdempotencyRecordTable)
+	existing := make([]idempotency.RecordTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &existing, sql, keyArgs); err != nil {
+		logs.Errorf("get idempotency record failed, err: %v, key: %s, rid: %s", err, model.IdempotencyKey, kt.Rid)
+		return false, nil, err
+	}
+
+	if len(existing) == 0 {
+		// the existing record is released by the other request concurrently, the caller can retry.
+		return false, nil, errf.New(errf.Aborted, "idempotency record is changed concurrently, please retry")
+	}
+
+	return false, &existing[0], nil
+}
+
+// Complete save the response of the processing record and extend its expired time.
+func (d RecordDao) Complete(kt *kit.Kit, model *idempotency.RecordTable) error {
+	if model == nil || model.Response == nil {
+		return errf.New(errf.InvalidParameter, "idempotency record response is required")
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s SET state = :state, response = :response, expired_at = :expired_at WHERE `+
+		`owner = :owner AND idempotency_key = :idempotency_key AND request_hash = :request_hash AND `+
+		`state = :processing`, table.IdempotencyRecordTable)
+	args := map[string]interface{}{
+		"state":           enumor.IdempotencyCompleted,
+		"response":        *model.Response,
+		"expired_at":      model.ExpiredAt,
+		"owner":           model.Owner,
+		"idempotency_key": model.IdempotencyKey,
+		"request_hash":    model.RequestHash,
+		"processing":      enumor.IdempotencyProcessing,
+	}
+
+	effected, err := d.Orm.Do().Update(kt.Ctx, sql, args)
+	if err != nil {
+		logs.Errorf("complete idempotency record failed, err: %v, key: %s, rid: %s", err, model.IdempotencyKey,
+			kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		return errf.New(errf.RecordNotFound, "processing idempotency record not found")
+	}
+
+	return nil
+}
+
+// Release delete the processing record, so that the request can be retried with the same key.
+func (d RecordDao) Release(kt *kit.Kit, model *idempotency.RecordTable) error {
+	if model == nil {
+		return errf.New(errf.InvalidParameter, "idempotency record model is nil")
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE owner = :owner AND idempotency_key = :idempotency_key AND `+
+		`request_hash = :request_hash AND state = :processing`, table.IdempotencyRecordTable)
+	args := map[string]interface{}{
+		"owner":           model.Owner,
+		"idempotency_key": model.IdempotencyKey,
+		"request_hash":    model.RequestHash,
+		"processing":      enumor.IdempotencyProcessing,
+	}
+
+	if _, err := d.Orm.Do().Delete(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("release idempotency record failed, err: %v, key: %s, rid: %s", err, model.IdempotencyKey,
+			kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteExpired delete all the expired records, returns the count of deleted records.
+func (d RecordDao) DeleteExpired(kt *kit.Kit) (int64, error) {
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE expired_at < :now`, table.IdempotencyRecordTable)
+
+	effected, err := d.Orm.Do().Delete(kt.Ctx, sql, map[string]interface{}{"now": now()})
+	if err != nil {
+		logs.Errorf("delete expired idempotency record failed, err: %v, rid: %s", err, kt.Rid)
+		return 0, err
+	}
+
+	return effected, nil
+}
@@ -49,6 +49,26 @@ func initMetric(register prometheus.Registerer) *metric {
 		}, []string{"cmd"})
 	register.MustRegister(m.errCounter)
 
+	m.replicaHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   metrics.Namespace,
+			Subsystem:   metrics.OrmCmdSubSys,
+			Name:        "replica_healthy",
+			Help:        "if the db read replica is healthy to accept reads, 1 is healthy",
+			ConstLabels: labels,
+		}, []string{"replica"})
+	register.MustRegister(m.replicaHealthy)
+
+	m.replicaLagSec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   metrics.Namespace,
+			Subsystem:   metrics.OrmCmdSubSys,
+			Name:        "replica_lag_seconds",
+			Help:        "the replication lag(seconds) of the db read replica",
+			ConstLabels: labels,
+		}, []string{"replica"})
+	register.MustRegister(m.replicaLagSec)
+
 	return m
 }
 
@@ -58,4 +78,10 @@ type metric struct {
 
 	// errCounter record the total error count when exec an orm command.
 	errCounter *prometheus.CounterVec
+
+	// replicaHealthy record if the read replica is healthy.
+	replicaHealthy *prometheus.GaugeVec
+
+	// replicaLagSec record the replication lag of the read replica.
+	replicaLagSec *prometheus.GaugeVec
 }
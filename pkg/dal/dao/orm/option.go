@@ -22,6 +22,7 @@ package orm
 import (
 	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/time/rate"
 )
@@ -35,6 +36,10 @@ type options struct {
 	mc *metric
 	// slowRequestMS db slow request time, beyond this time, the db request will be logged. unit: millisecond
 	slowRequestMS time.Duration
+	// replicas read replicas of the db, key is the replica name.
+	replicas map[string]*sqlx.DB
+	// replicaOpt read replica routing options.
+	replicaOpt ReplicaOption
 }
 
 // Option orm option func defines.
@@ -67,3 +72,12 @@ func SlowRequestMS(ms uint) Option {
 		opt.slowRequestMS = time.Duration(ms) * time.Millisecond
 	}
 }
+
+// Replicas set the read replicas, reads outside transactions are routed to the healthy replicas, key of the dbs is
+// the replica name.
+func Replicas(dbs map[string]*sqlx.DB, replicaOpt ReplicaOption) Option {
+	return func(opt *options) {
+		opt.replicas = dbs
+		opt.replicaOpt = replicaOpt
+	}
+}
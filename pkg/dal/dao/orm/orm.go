@@ -31,9 +31,11 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
+	"hcm/pkg/tracing"
 
 	"github.com/jmoiron/sqlx"
 	prm "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/time/rate"
 )
 
@@ -91,23 +93,50 @@ func InitOrm(db *sqlx.DB, opts ...Option) Interface {
 		ormOpts.slowRequestMS = 50 * time.Millisecond
 	}
 
-	return &runtimeOrm{
+	ro := &runtimeOrm{
 		db:             db,
 		mc:             ormOpts.mc,
 		ingressLimiter: ormOpts.ingressLimiter,
 		logLimiter:     ormOpts.logLimiter,
 		slowRequestMS:  ormOpts.slowRequestMS,
 	}
+
+	if len(ormOpts.replicas) != 0 {
+		ro.replicas = newReplicaSet(ormOpts.replicas, ormOpts.replicaOpt, ormOpts.mc)
+	}
+
+	return ro
 }
 
 type runtimeOrm struct {
 	db             *sqlx.DB
+	replicas       *replicaSet
 	ingressLimiter *rate.Limiter
 	logLimiter     *rate.Limiter
 	mc             *metric
 	slowRequestMS  time.Duration
 }
 
+// readDB returns the db to read, reads are routed to the primary db if the context is marked to read primary,
+// or there is no healthy replica.
+func (o *runtimeOrm) readDB(ctx context.Context) *sqlx.DB {
+	if o.replicas == nil || kit.IsReadPrimary(ctx) {
+		return o.db
+	}
+
+	if db := o.replicas.pick(); db != nil {
+		return db
+	}
+
+	return o.db
+}
+
+// markWritten mark the following reads of the context to be routed to the primary db after writes, so that the
+// written data can be read at once even if the replicas lag behind.
+func markWritten(ctx context.Context) {
+	kit.MarkReadPrimary(ctx)
+}
+
 func (o *runtimeOrm) logSlowCmd(ctx context.Context, sql string, latency time.Duration) {
 	if latency < o.slowRequestMS {
 		return
@@ -136,19 +165,23 @@ func (o *runtimeOrm) tryAccept() error {
 	return errf.New(errf.TooManyRequest, "orm too many requests")
 }
 
-// Do create a new orm do instance.
+// Do create a new orm do instance, the span of every command is recorded.
 func (o *runtimeOrm) Do() DoOrm {
-	return &do{
-		db: o.db,
-		ro: o,
+	return &tracedDo{
+		do: &do{
+			db: o.db,
+			ro: o,
+		},
 	}
 }
 
-// Txn create a new transaction orm instance.
+// Txn create a new transaction orm instance, the span of every command is recorded.
 func (o *runtimeOrm) Txn(tx *sqlx.Tx) DoOrmWithTransaction {
-	return &doTxn{
-		tx: tx,
-		ro: o,
+	return &tracedTxn{
+		txn: &doTxn{
+			tx: tx,
+			ro: o,
+		},
 	}
 }
 
@@ -172,6 +205,23 @@ func (o *runtimeOrm) AutoTxn(kit *kit.Kit, run TxnFunc) (interface{}, error) {
 		return nil, errors.New("transaction function is nil")
 	}
 
+	// the commands in the transaction use the context of kit, it's replaced during the transaction so that their
+	// spans are the children of the transaction span.
+	ctx := kit.Ctx
+	var span trace.Span
+	kit.Ctx, span = tracing.Tracer().Start(ctx, "orm transaction")
+	defer func() {
+		kit.Ctx = ctx
+	}()
+
+	result, err := o.autoTxnWithRetry(kit, run)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+// autoTxnWithRetry do the transaction, and retry it if it needs to be retried.
+func (o *runtimeOrm) autoTxnWithRetry(kit *kit.Kit, run TxnFunc) (interface{}, error) {
 	retry, result, err := o.autoTxn(kit, run)
 	if err == nil {
 		return result, nil
@@ -231,6 +281,7 @@ func (o *runtimeOrm) autoTxn(kit *kit.Kit, run TxnFunc) (bool, interface{}, erro
 	if err := txn.Commit(); err != nil {
 		return false, nil, fmt.Errorf("commit sharding transaction failed, err: %v", err)
 	}
+	markWritten(kit.Ctx)
 
 	return false, result, nil
 }
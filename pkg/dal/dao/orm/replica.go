@@ -0,0 +1,199 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"hcm/pkg/logs"
+
+	"github.com/jmoiron/sqlx"
+	prm "github.com/prometheus/client_golang/prometheus"
+)
+
+// errReplicationStopped is returned when the replication of a replica is not running.
+var errReplicationStopped = errors.New("replication is not running")
+
+// ReplicaOption defines the read replica routing options.
+type ReplicaOption struct {
+	// MaxLag is the max replication lag of a replica to accept reads.
+	MaxLag time.Duration
+	// CheckInterval is the interval to check the health and replication lag of replicas.
+	CheckInterval time.Duration
+}
+
+// replica is a read replica of the primary database, it has its own connection pool.
+type replica struct {
+	name string
+	db   *sqlx.DB
+	// healthy is 1 if the replica is reachable and its replication lag is acceptable.
+	healthy int32
+}
+
+// replicaSet routes the reads outside transactions to the healthy replicas in turn.
+type replicaSet struct {
+	replicas []*replica
+	next     uint32
+	opt      ReplicaOption
+	mc       *metric
+}
+
+func newReplicaSet(dbs map[string]*sqlx.DB, opt ReplicaOption, mc *metric) *replicaSet {
+	if opt.MaxLag <= 0 {
+		opt.MaxLag = 10 * time.Second
+	}
+
+	if opt.CheckInterval <= 0 {
+		opt.CheckInterval = 5 * time.Second
+	}
+
+	rs := &replicaSet{replicas: make([]*replica, 0, len(dbs)), opt: opt, mc: mc}
+	for name, db := range dbs {
+		rs.replicas = append(rs.replicas, &replica{name: name, db: db})
+	}
+
+	// check the replicas before routing reads to them.
+	rs.checkAll()
+	go rs.run()
+
+	return rs
+}
+
+// pick returns a healthy replica in turn, returns nil if no replica is healthy.
+func (rs *replicaSet) pick() *sqlx.DB {
+	total := uint32(len(rs.replicas))
+	for i := uint32(0); i < total; i++ {
+		r := rs.replicas[atomic.AddUint32(&rs.next, 1)%total]
+		if atomic.LoadInt32(&r.healthy) == 1 {
+			return r.db
+		}
+	}
+
+	return nil
+}
+
+func (rs *replicaSet) run() {
+	ticker := time.NewTicker(rs.opt.CheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		rs.checkAll()
+	}
+}
+
+func (rs *replicaSet) checkAll() {
+	for _, r := range rs.replicas {
+		healthy := int32(0)
+		lag, err := rs.lag(r.db)
+		switch {
+		case err != nil:
+			logs.Errorf("check db replica %s failed, err: %v", r.name, err)
+		case lag > rs.opt.MaxLag:
+			logs.Warnf("db replica %s lag %s exceeds the max lag %s, skip it", r.name, lag, rs.opt.MaxLag)
+		default:
+			healthy = 1
+		}
+
+		if old := atomic.SwapInt32(&r.healthy, healthy); old != healthy {
+			logs.Infof("db replica %s health changed to %v", r.name, healthy == 1)
+		}
+		rs.mc.replicaHealthy.With(prm.Labels{"replica": r.name}).Set(float64(healthy))
+		rs.mc.replicaLagSec.With(prm.Labels{"replica": r.name}).Set(lag.Seconds())
+	}
+}
+
+// lag returns the replication lag of the replica, the lag is 0 if the replica does not report replication status,
+// e.g. a read-only node behind a proxy.
+func (rs *replicaSet) lag(db *sqlx.DB) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rs.opt.CheckInterval)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		return 0, err
+	}
+
+	// 'show replica status' is supported since mysql 8.0.22, fall back to 'show slave status' for old versions.
+	status, err := queryReplicaStatus(ctx, db, "show replica status")
+	if err != nil {
+		if status, err = queryReplicaStatus(ctx, db, "show slave status"); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(status) == 0 {
+		return 0, nil
+	}
+
+	seconds, exists := status["Seconds_Behind_Source"]
+	if !exists {
+		seconds = status["Seconds_Behind_Master"]
+	}
+
+	if !seconds.Valid {
+		return 0, errReplicationStopped
+	}
+
+	lag, err := strconv.ParseInt(seconds.String, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(lag) * time.Second, nil
+}
+
+// queryReplicaStatus returns the replication status columns and values, it is empty if the db is not a replica.
+func queryReplicaStatus(ctx context.Context, db *sqlx.DB, query string) (map[string]sql.NullString, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	status := make(map[string]sql.NullString)
+	if !rows.Next() {
+		return status, rows.Err()
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for idx := range values {
+		dest[idx] = &values[idx]
+	}
+
+	if err = rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	for idx, column := range columns {
+		status[column] = values[idx]
+	}
+
+	return status, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package orm
+
+import (
+	"net/http"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func TestReadDB(t *testing.T) {
+	primary, replica1, replica2 := new(sqlx.DB), new(sqlx.DB), new(sqlx.DB)
+	ro := &runtimeOrm{
+		db: primary,
+		replicas: &replicaSet{replicas: []*replica{{name: "r1", db: replica1, healthy: 1},
+			{name: "r2", db: replica2, healthy: 0}}},
+	}
+
+	kt := kit.New()
+	for i := 0; i < 3; i++ {
+		if db := ro.readDB(kt.Ctx); db != replica1 {
+			t.Errorf("reads should be routed to the healthy replica")
+		}
+	}
+
+	// reads after writes are routed to the primary.
+	markWritten(kt.Ctx)
+	if db := ro.readDB(kt.Ctx); db != primary {
+		t.Errorf("reads after writes should be routed to the primary")
+	}
+
+	header := http.Header{}
+	header.Set(constant.ReadPrimaryKey, "true")
+	ctx := kit.WithReadPrimaryFlag(kit.New().Ctx, header)
+	if db := ro.readDB(ctx); db != primary {
+		t.Errorf("reads with read primary header should be routed to the primary")
+	}
+
+	ro.replicas.replicas[0].healthy = 0
+	if db := ro.readDB(kit.New().Ctx); db != primary {
+		t.Errorf("reads should be routed to the primary if no replica is healthy")
+	}
+}
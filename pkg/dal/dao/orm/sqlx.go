@@ -63,7 +63,8 @@ func (do *do) Get(ctx context.Context, dest interface{}, expr string, arg map[st
 		return err
 	}
 
-	rows, err := do.db.QueryContext(ctx, do.db.Rebind(query), args...)
+	db := do.ro.readDB(ctx)
+	rows, err := db.QueryContext(ctx, db.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "get"}).Inc()
 		return err
@@ -100,7 +101,8 @@ func (do *do) Select(ctx context.Context, dest interface{}, expr string, arg map
 		return err
 	}
 
-	rows, err := do.db.QueryContext(ctx, do.db.Rebind(query), args...)
+	db := do.ro.readDB(ctx)
+	rows, err := db.QueryContext(ctx, db.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "select"}).Inc()
 		return err
@@ -137,7 +139,8 @@ func (do *do) Count(ctx context.Context, expr string, arg map[string]interface{}
 		return 0, err
 	}
 
-	rows, err := do.db.QueryContext(ctx, do.db.Rebind(query), args...)
+	db := do.ro.readDB(ctx)
+	rows, err := db.QueryContext(ctx, db.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "count"}).Inc()
 		return 0, err
@@ -177,6 +180,7 @@ func (do *do) Delete(ctx context.Context, expr string, arg map[string]interface{
 		return 0, err
 	}
 
+	markWritten(ctx)
 	result, err := do.db.ExecContext(ctx, do.db.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "delete"}).Inc()
@@ -219,6 +223,7 @@ func (do *do) Update(ctx context.Context, expr string, arg map[string]interface{
 		return 0, err
 	}
 
+	markWritten(ctx)
 	result, err := do.db.ExecContext(ctx, do.db.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "update"}).Inc()
@@ -245,6 +250,7 @@ func (do *do) Insert(ctx context.Context, expr string, data interface{}) error {
 
 	start := time.Now()
 
+	markWritten(ctx)
 	_, err := do.db.NamedExecContext(ctx, expr, data)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "insert"}).Inc()
@@ -265,6 +271,7 @@ func (do *do) Exec(ctx context.Context, expr string) (int64, error) {
 
 	start := time.Now()
 
+	markWritten(ctx)
 	result, err := do.db.ExecContext(ctx, expr)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "exec"}).Inc()
@@ -292,6 +299,7 @@ func (do *do) BulkInsert(ctx context.Context, expr string, args interface{}) err
 
 	start := time.Now()
 
+	markWritten(ctx)
 	_, err := do.db.NamedExecContext(ctx, expr, args)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "bulk-insert"}).Inc()
@@ -408,6 +416,7 @@ func (do *doTxn) Delete(ctx context.Context, expr string, arg map[string]interfa
 		return 0, err
 	}
 
+	markWritten(ctx)
 	result, err := do.tx.ExecContext(ctx, do.tx.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "delete"}).Inc()
@@ -438,6 +447,7 @@ func (do *doTxn) Insert(ctx context.Context, expr string, args interface{}) erro
 
 	start := time.Now()
 
+	markWritten(ctx)
 	_, err := do.tx.NamedExecContext(ctx, expr, args)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "insert"}).Inc()
@@ -459,6 +469,7 @@ func (do *doTxn) BulkInsert(ctx context.Context, expr string, args interface{})
 
 	start := time.Now()
 
+	markWritten(ctx)
 	_, err := do.tx.NamedExecContext(ctx, expr, args)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "bulk-insert"}).Inc()
@@ -495,6 +506,7 @@ func (do *doTxn) Update(ctx context.Context, expr string, arg map[string]interfa
 		return 0, err
 	}
 
+	markWritten(ctx)
 	result, err := do.tx.ExecContext(ctx, do.tx.Rebind(query), args...)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "update"}).Inc()
@@ -0,0 +1,174 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package orm
+
+import (
+	"context"
+
+	"hcm/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxStatementLen is the max length of the sql statement recorded in the span, the statement is the named sql
+// without the argument values, it's truncated because the bulk insert statement may be very long.
+const maxStatementLen = 2048
+
+// startSpan starts the span of an orm command.
+func startSpan(ctx context.Context, cmd string, expr string) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if len(expr) > maxStatementLen {
+		expr = expr[:maxStatementLen]
+	}
+
+	return tracing.Tracer().Start(ctx, "orm "+cmd, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", "mysql"), attribute.String("db.operation", cmd),
+			attribute.String("db.statement", expr)))
+}
+
+var _ DoOrm = new(tracedDo)
+
+// tracedDo records the span of every command of the orm do instance.
+type tracedDo struct {
+	do DoOrm
+}
+
+// Get one data and decode into dest *struct{}.
+func (t *tracedDo) Get(ctx context.Context, dest interface{}, expr string, arg map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "get", expr)
+	err := t.do.Get(ctx, dest, expr, arg)
+	tracing.End(span, err)
+	return err
+}
+
+// Select a collection of data, and decode into dest *[]struct{}.
+func (t *tracedDo) Select(ctx context.Context, dest interface{}, expr string, arg map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "select", expr)
+	err := t.do.Select(ctx, dest, expr, arg)
+	tracing.End(span, err)
+	return err
+}
+
+// Count the number of the filtered resource.
+func (t *tracedDo) Count(ctx context.Context, expr string, arg map[string]interface{}) (uint64, error) {
+	ctx, span := startSpan(ctx, "count", expr)
+	count, err := t.do.Count(ctx, expr, arg)
+	tracing.End(span, err)
+	return count, err
+}
+
+// Delete a collection of data.
+func (t *tracedDo) Delete(ctx context.Context, expr string, arg map[string]interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "delete", expr)
+	deleted, err := t.do.Delete(ctx, expr, arg)
+	tracing.End(span, err)
+	return deleted, err
+}
+
+// Update a collection of data.
+func (t *tracedDo) Update(ctx context.Context, expr string, arg map[string]interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "update", expr)
+	updated, err := t.do.Update(ctx, expr, arg)
+	tracing.End(span, err)
+	return updated, err
+}
+
+// Exec a command.
+func (t *tracedDo) Exec(ctx context.Context, expr string) (int64, error) {
+	ctx, span := startSpan(ctx, "exec", expr)
+	affected, err := t.do.Exec(ctx, expr)
+	tracing.End(span, err)
+	return affected, err
+}
+
+// Insert a row data to db.
+func (t *tracedDo) Insert(ctx context.Context, expr string, data interface{}) error {
+	ctx, span := startSpan(ctx, "insert", expr)
+	err := t.do.Insert(ctx, expr, data)
+	tracing.End(span, err)
+	return err
+}
+
+// BulkInsert insert multiple rows data to db.
+func (t *tracedDo) BulkInsert(ctx context.Context, expr string, args interface{}) error {
+	ctx, span := startSpan(ctx, "bulk-insert", expr)
+	err := t.do.BulkInsert(ctx, expr, args)
+	tracing.End(span, err)
+	return err
+}
+
+var _ DoOrmWithTransaction = new(tracedTxn)
+
+// tracedTxn records the span of every command of the transaction orm instance.
+type tracedTxn struct {
+	txn DoOrmWithTransaction
+}
+
+// Count the number of the filtered resource.
+func (t *tracedTxn) Count(ctx context.Context, expr string, arg map[string]interface{}) (uint64, error) {
+	ctx, span := startSpan(ctx, "count", expr)
+	count, err := t.txn.Count(ctx, expr, arg)
+	tracing.End(span, err)
+	return count, err
+}
+
+// Select a collection of data, and decode into dest *[]struct{}.
+func (t *tracedTxn) Select(ctx context.Context, dest interface{}, expr string, arg map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "select", expr)
+	err := t.txn.Select(ctx, dest, expr, arg)
+	tracing.End(span, err)
+	return err
+}
+
+// Delete a collection of data.
+func (t *tracedTxn) Delete(ctx context.Context, expr string, args map[string]interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "delete", expr)
+	deleted, err := t.txn.Delete(ctx, expr, args)
+	tracing.End(span, err)
+	return deleted, err
+}
+
+// Update a collection of data.
+func (t *tracedTxn) Update(ctx context.Context, expr string, args map[string]interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "update", expr)
+	updated, err := t.txn.Update(ctx, expr, args)
+	tracing.End(span, err)
+	return updated, err
+}
+
+// Insert a row data to db.
+func (t *tracedTxn) Insert(ctx context.Context, expr string, args interface{}) error {
+	ctx, span := startSpan(ctx, "insert", expr)
+	err := t.txn.Insert(ctx, expr, args)
+	tracing.End(span, err)
+	return err
+}
+
+// BulkInsert insert multiple rows data to db.
+func (t *tracedTxn) BulkInsert(ctx context.Context, expr string, args interface{}) error {
+	ctx, span := startSpan(ctx, "bulk-insert", expr)
+	err := t.txn.BulkInsert(ctx, expr, args)
+	tracing.End(span, err)
+	return err
+}
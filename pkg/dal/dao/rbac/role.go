@@ -0,0 +1,183 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac defines the dao of the built-in rbac authorizer.
+package rbac
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/rbac"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Role only used for built-in rbac role.
+type Role interface {
+	CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *rbac.RoleTable) (string, error)
+	Update(kt *kit.Kit, expr *filter.Expression, model *rbac.RoleTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListRbacRoleDetails, error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ Role = new(RoleDao)
+
+// RoleDao rbac role dao.
+type RoleDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// CreateWithTx create rbac role with tx.
+func (r RoleDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *rbac.RoleTable) (string, error) {
+	if model == nil {
+		return "", errf.New(errf.InvalidParameter, "rbac role model is nil")
+	}
+
+	id, err := r.IDGen.One(kt, table.RbacRoleTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.RbacRoleTable, rbac.RoleColumns.ColumnExpr(),
+		rbac.RoleColumns.ColonNameExpr())
+
+	if err = r.Orm.Txn(tx).Insert(kt.Ctx, sql, model); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.RbacRoleTable, err, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %v", table.RbacRoleTable, err)
+	}
+
+	return id, nil
+}
+
+// Update rbac role.
+func (r RoleDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *rbac.RoleTable) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is nil")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
+
+	effected, err := r.Orm.Do().Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
+	if err != nil {
+		logs.ErrorJson("update rbac role failed, filter: %s, err: %v, rid: %v", filterExpr, err, kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		logs.ErrorJson("update rbac role, but record not found, filter: %v, rid: %v", filterExpr, kt.Rid)
+		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
+	}
+
+	return nil
+}
+
+// List rbac role.
+func (r RoleDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListRbacRoleDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list rbac role options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(rbac.RoleColumns.ColumnTypes())),
+		core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.RbacRoleTable, whereExpr)
+		count, err := r.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count rbac role failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListRbacRoleDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, rbac.RoleColumns.FieldsNamedExpr(opt.Fields),
+		table.RbacRoleTable, whereExpr, pageExpr)
+
+	details := make([]rbac.RoleTable, 0)
+	if err = r.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListRbacRoleDetails{Details: details}, nil
+}
+
+// DeleteWithTx delete rbac role with tx.
+func (r RoleDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.RbacRoleTable, whereExpr)
+	if _, err := r.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete rbac role failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/rbac"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// RoleBinding only used for built-in rbac role binding.
+type RoleBinding interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []rbac.RoleBindingTable) ([]string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListRbacRoleBindingDetails, error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ RoleBinding = new(RoleBindingDao)
+
+// RoleBindingDao rbac role binding dao.
+type RoleBindingDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// BatchCreateWithTx create rbac role bindings with tx.
+func (r RoleBindingDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []rbac.RoleBindingTable) (
+	[]string, error) {
+
+	if len(models) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "rbac role binding models is required")
+	}
+
+	ids, err := r.IDGen.Batch(kt, table.RbacRoleBindingTable, len(models))
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+
+		if err = models[index].InsertValidate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.RbacRoleBindingTable,
+		rbac.RoleBindingColumns.ColumnExpr(), rbac.RoleBindingColumns.ColonNameExpr())
+
+	if err = r.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.RbacRoleBindingTable, err, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", table.RbacRoleBindingTable, err)
+	}
+
+	return ids, nil
+}
+
+// List rbac role binding.
+func (r RoleBindingDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListRbacRoleBindingDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list rbac role binding options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(rbac.RoleBindingColumns.ColumnTypes())),
+		core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.RbacRoleBindingTable, whereExpr)
+		count, err := r.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count rbac role binding failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListRbacRoleBindingDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, rbac.RoleBindingColumns.FieldsNamedExpr(opt.Fields),
+		table.RbacRoleBindingTable, whereExpr, pageExpr)
+
+	details := make([]rbac.RoleBindingTable, 0)
+	if err = r.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListRbacRoleBindingDetails{Details: details}, nil
+}
+
+// DeleteWithTx delete rbac role binding with tx.
+func (r RoleBindingDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.RbacRoleBindingTable, whereExpr)
+	if _, err := r.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete rbac role binding failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
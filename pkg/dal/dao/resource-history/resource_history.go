@@ -0,0 +1,192 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcehistory defines the dao of resource change history.
+package resourcehistory
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tablehistory "hcm/pkg/dal/table/resource-history"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Interface only used for resource change history.
+type Interface interface {
+	CreateByWhereWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, action enumor.ChangeFeedAction,
+		whereExpr string, whereValue map[string]interface{}) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResourceHistoryDetails, error)
+	GetSnapshot(kt *kit.Kit, resType table.Name, resID string) (*tabletypes.JsonField, error)
+	Delete(kt *kit.Kit, expr *filter.Expression) (int64, error)
+}
+
+var _ Interface = new(Dao)
+
+// NewResourceHistoryDao new resource history dao.
+func NewResourceHistoryDao(orm orm.Interface) Interface {
+	return &Dao{
+		Orm: orm,
+	}
+}
+
+// Dao resource history dao.
+type Dao struct {
+	Orm orm.Interface
+}
+
+// CreateByWhereWithTx create the histories of the resources matched by the where expression with tx, the snapshot
+// of the resources before the change is recorded, so it should be called before the resources are updated or
+// deleted, and after the resources are inserted. resource types that do not record history are skipped.
+func (d Dao) CreateByWhereWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, action enumor.ChangeFeedAction,
+	whereExpr string, whereValue map[string]interface{}) error {
+
+	if !tablehistory.IsSupported(resType) {
+		return nil
+	}
+
+	if err := action.Validate(); err != nil {
+		return err
+	}
+
+	snapshotExpr := "NULL"
+	if action != enumor.ChangeFeedInsert {
+		var err error
+		if snapshotExpr, err = tablehistory.SnapshotExpr(resType); err != nil {
+			return err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (res_type, res_id, account_id, action, snapshot, operator, rid) SELECT `+
+		`:history_res_type, id, account_id, :history_action, %s, :history_operator, :history_rid FROM %s %s`,
+		table.ResourceHistoryTable, snapshotExpr, resType, whereExpr)
+
+	args := tools.MapMerge(map[string]interface{}{
+		"history_res_type": resType,
+		"history_action":   action,
+		"history_operator": kt.User,
+		"history_rid":      kt.Rid,
+	}, whereValue)
+
+	if _, err := d.Orm.Txn(tx).Update(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("insert %s %s history failed, err: %v, where: %s, rid: %s", resType, action, err, whereExpr,
+			kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.ResourceHistoryTable, err)
+	}
+
+	return nil
+}
+
+// List resource history.
+func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResourceHistoryDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list resource history options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(
+		tablehistory.ResourceHistoryColumns.ColumnTypes())), core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResourceHistoryTable, whereExpr)
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count resource history failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResourceHistoryDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablehistory.ResourceHistoryColumns.FieldsNamedExpr(opt.Fields),
+		table.ResourceHistoryTable, whereExpr, pageExpr)
+
+	details := make([]tablehistory.ResourceHistoryTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListResourceHistoryDetails{Details: details}, nil
+}
+
+// GetSnapshot get the current snapshot of the resource, it is built in the same way as the recorded history, so
+// that they can be compared. returns nil if the resource does not exist.
+func (d Dao) GetSnapshot(kt *kit.Kit, resType table.Name, resID string) (*tabletypes.JsonField, error) {
+	snapshotExpr, err := tablehistory.SnapshotExpr(resType)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s WHERE id = :id`, snapshotExpr, resType)
+
+	snapshots := make([]tabletypes.JsonField, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &snapshots, sql, map[string]interface{}{"id": resID}); err != nil {
+		logs.Errorf("get %s %s snapshot failed, err: %v, rid: %s", resType, resID, err, kt.Rid)
+		return nil, err
+	}
+
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+
+	return &snapshots[0], nil
+}
+
+// Delete resource history, returns the deleted count.
+func (d Dao) Delete(kt *kit.Kit, filterExpr *filter.Expression) (int64, error) {
+	if filterExpr == nil {
+		return 0, errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return 0, err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.ResourceHistoryTable, whereExpr)
+	deleted, err := d.Orm.Do().Delete(kt.Ctx, sql, whereValue)
+	if err != nil {
+		logs.ErrorJson("delete resource history failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return 0, err
+	}
+
+	return deleted, nil
+}
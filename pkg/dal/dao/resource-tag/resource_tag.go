@@ -0,0 +1,192 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines the dao of resource tag.
+package resourcetag
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableresourcetag "hcm/pkg/dal/table/resource-tag"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Interface only used for resource tag.
+type Interface interface {
+	ReplaceWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, resIDs []string,
+		models []tableresourcetag.ResourceTagTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResourceTagDetails, error)
+	DeleteByResWhereWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, whereExpr string,
+		whereValue map[string]interface{}) error
+}
+
+var _ Interface = new(Dao)
+
+// NewResourceTagDao new resource tag dao.
+func NewResourceTagDao(orm orm.Interface, idGen idgenerator.IDGenInterface) Interface {
+	return &Dao{
+		Orm:   orm,
+		IDGen: idGen,
+	}
+}
+
+// Dao resource tag dao.
+type Dao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// ReplaceWithTx replace all the tags of the resources with tx, the resources' tags that are not in the models are
+// deleted, so resources without tags should also be in the resIDs to clear their tags.
+func (d Dao) ReplaceWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, resIDs []string,
+	models []tableresourcetag.ResourceTagTable) error {
+
+	if err := tableresourcetag.ValidateResType(resType); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(resIDs) == 0 {
+		return nil
+	}
+
+	resIDMap := make(map[string]struct{}, len(resIDs))
+	for _, id := range resIDs {
+		resIDMap[id] = struct{}{}
+	}
+
+	for _, model := range models {
+		if model.ResType != resType {
+			return errf.Newf(errf.InvalidParameter, "tag res_type %s is not %s", model.ResType, resType)
+		}
+
+		if _, exists := resIDMap[model.ResID]; !exists {
+			return errf.Newf(errf.InvalidParameter, "tag res_id %s is not in the replaced resources", model.ResID)
+		}
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE res_type = :res_type AND res_id IN (:res_ids)`, table.ResourceTagTable)
+	args := map[string]interface{}{"res_type": resType, "res_ids": resIDs}
+	if _, err := d.Orm.Txn(tx).Delete(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("delete %s tags failed, err: %v, res ids: %v, rid: %s", resType, err, resIDs, kt.Rid)
+		return err
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	ids, err := d.IDGen.Batch(kt, table.ResourceTagTable, len(models))
+	if err != nil {
+		return err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+
+		if err = models[index].InsertValidate(); err != nil {
+			return errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	sql = fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.ResourceTagTable,
+		tableresourcetag.ResourceTagColumns.ColumnExpr(), tableresourcetag.ResourceTagColumns.ColonNameExpr())
+
+	if err = d.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.ResourceTagTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.ResourceTagTable, err)
+	}
+
+	return nil
+}
+
+// List resource tag.
+func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResourceTagDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list resource tag options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(
+		filter.RuleFields(tableresourcetag.ResourceTagColumns.ColumnTypes())),
+		core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResourceTagTable, whereExpr)
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count resource tag failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResourceTagDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableresourcetag.ResourceTagColumns.FieldsNamedExpr(opt.Fields),
+		table.ResourceTagTable, whereExpr, pageExpr)
+
+	details := make([]tableresourcetag.ResourceTagTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListResourceTagDetails{Details: details}, nil
+}
+
+// DeleteByResWhereWithTx delete the tags of the resources matched by the where expression with tx, it should be
+// called before the resources are deleted, so that the matched resources are still the ones to delete.
+func (d Dao) DeleteByResWhereWithTx(kt *kit.Kit, tx *sqlx.Tx, resType table.Name, whereExpr string,
+	whereValue map[string]interface{}) error {
+
+	if err := tableresourcetag.ValidateResType(resType); err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE res_type = :resource_tag_res_type AND res_id IN (SELECT id FROM %s %s)`,
+		table.ResourceTagTable, resType, whereExpr)
+
+	args := tools.MapMerge(map[string]interface{}{"resource_tag_res_type": resType}, whereValue)
+	if _, err := d.Orm.Txn(tx).Delete(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("delete %s tags failed, err: %v, where: %s, rid: %s", resType, err, whereExpr, kt.Rid)
+		return fmt.Errorf("delete %s failed, err: %v", table.ResourceTagTable, err)
+	}
+
+	return nil
+}
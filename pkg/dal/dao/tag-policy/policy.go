@@ -0,0 +1,191 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy defines the tag policy and tag policy violation dao.
+package tagpolicy
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tabletagpolicy "hcm/pkg/dal/table/tag-policy"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Policy only used for tag policy.
+type Policy interface {
+	CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *tabletagpolicy.TagPolicyTable) (string, error)
+	Update(kt *kit.Kit, expr *filter.Expression, model *tabletagpolicy.TagPolicyTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListTagPolicyDetails, error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ Policy = new(PolicyDao)
+
+// PolicyDao tag policy dao.
+type PolicyDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// CreateWithTx create tag policy with tx.
+func (d PolicyDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *tabletagpolicy.TagPolicyTable) (string, error) {
+	if model == nil {
+		return "", errf.New(errf.InvalidParameter, "tag policy model is nil")
+	}
+
+	id, err := d.IDGen.One(kt, table.TagPolicyTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.TagPolicyTable,
+		tabletagpolicy.TagPolicyColumns.ColumnExpr(), tabletagpolicy.TagPolicyColumns.ColonNameExpr())
+
+	if err = d.Orm.Txn(tx).Insert(kt.Ctx, sql, model); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.TagPolicyTable, err, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %v", table.TagPolicyTable, err)
+	}
+
+	return id, nil
+}
+
+// Update tag policy.
+func (d PolicyDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *tabletagpolicy.TagPolicyTable) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is nil")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
+
+	effected, err := d.Orm.Do().Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
+	if err != nil {
+		logs.ErrorJson("update tag policy failed, filter: %s, err: %v, rid: %v", filterExpr, err, kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		logs.ErrorJson("update tag policy, but record not found, filter: %v, rid: %v", filterExpr, kt.Rid)
+		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
+	}
+
+	return nil
+}
+
+// List tag policy.
+func (d PolicyDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListTagPolicyDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list tag policy options is nil")
+	}
+
+	columnTypes := tabletagpolicy.TagPolicyColumns.ColumnTypes()
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
+		core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.TagPolicyTable, whereExpr)
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count tag policy failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListTagPolicyDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tabletagpolicy.TagPolicyColumns.FieldsNamedExpr(opt.Fields),
+		table.TagPolicyTable, whereExpr, pageExpr)
+
+	details := make([]tabletagpolicy.TagPolicyTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListTagPolicyDetails{Details: details}, nil
+}
+
+// DeleteWithTx delete tag policy with tx, the violations of the deleted policies are also deleted.
+func (d PolicyDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE policy_id IN (SELECT id FROM %s %s)`, table.TagPolicyViolationTable,
+		table.TagPolicyTable, whereExpr)
+	if _, err = d.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete tag policy violation failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return err
+	}
+
+	sql = fmt.Sprintf(`DELETE FROM %s %s`, table.TagPolicyTable, whereExpr)
+	if _, err = d.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete tag policy failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tagpolicy
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tabletagpolicy "hcm/pkg/dal/table/tag-policy"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Violation only used for tag policy violation.
+type Violation interface {
+	ReplaceWithTx(kt *kit.Kit, tx *sqlx.Tx, accountID string, models []tabletagpolicy.ViolationTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListTagPolicyViolationDetails, error)
+}
+
+var _ Violation = new(ViolationDao)
+
+// ViolationDao tag policy violation dao.
+type ViolationDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// ReplaceWithTx replace all the violations of the account's resources with tx, the violations are evaluated for all
+// the resources of the account at once, so the former violations are all outdated.
+func (d ViolationDao) ReplaceWithTx(kt *kit.Kit, tx *sqlx.Tx, accountID string,
+	models []tabletagpolicy.ViolationTable) error {
+
+	if len(accountID) == 0 {
+		return errf.New(errf.InvalidParameter, "account id is required")
+	}
+
+	for _, model := range models {
+		if model.AccountID != accountID {
+			return errf.Newf(errf.InvalidParameter, "violation account_id %s is not %s", model.AccountID,
+				accountID)
+		}
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE account_id = :account_id`, table.TagPolicyViolationTable)
+	if _, err := d.Orm.Txn(tx).Delete(kt.Ctx, sql, map[string]interface{}{"account_id": accountID}); err != nil {
+		logs.Errorf("delete account %s tag policy violations failed, err: %v, rid: %s", accountID, err, kt.Rid)
+		return err
+	}
+
+	sql = fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.TagPolicyViolationTable,
+		tabletagpolicy.ViolationColumns.ColumnExpr(), tabletagpolicy.ViolationColumns.ColonNameExpr())
+
+	for _, batch := range slice.Split(models, constant.BatchOperationMaxLimit) {
+		ids, err := d.IDGen.Batch(kt, table.TagPolicyViolationTable, len(batch))
+		if err != nil {
+			return err
+		}
+
+		for index := range batch {
+			batch[index].ID = ids[index]
+
+			if err = batch[index].InsertValidate(); err != nil {
+				return errf.NewFromErr(errf.InvalidParameter, err)
+			}
+		}
+
+		if err = d.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, batch); err != nil {
+			logs.Errorf("insert %s failed, err: %v, rid: %s", table.TagPolicyViolationTable, err, kt.Rid)
+			return fmt.Errorf("insert %s failed, err: %v", table.TagPolicyViolationTable, err)
+		}
+	}
+
+	return nil
+}
+
+// List tag policy violation.
+func (d ViolationDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListTagPolicyViolationDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list tag policy violation options is nil")
+	}
+
+	columnTypes := tabletagpolicy.ViolationColumns.ColumnTypes()
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
+		core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.TagPolicyViolationTable, whereExpr)
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count tag policy violation failed, err: %v, filter: %s, rid: %s", err, opt.Filter,
+				kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListTagPolicyViolationDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tabletagpolicy.ViolationColumns.FieldsNamedExpr(opt.Fields),
+		table.TagPolicyViolationTable, whereExpr, pageExpr)
+
+	details := make([]tabletagpolicy.ViolationTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListTagPolicyViolationDetails{Details: details}, nil
+}
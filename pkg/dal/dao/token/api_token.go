@@ -0,0 +1,206 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package token defines the dao of hcm issued api tokens and service accounts.
+package token
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/token"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ApiToken only used for api token.
+type ApiToken interface {
+	CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *token.ApiTokenTable) (string, error)
+	Update(kt *kit.Kit, expr *filter.Expression, model *token.ApiTokenTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListApiTokenDetails, error)
+	UpdateLastUsed(kt *kit.Kit, id string, lastUsedAt string, lastUsedIP string) error
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ ApiToken = new(ApiTokenDao)
+
+// ApiTokenDao api token dao.
+type ApiTokenDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// CreateWithTx create api token with tx.
+func (d ApiTokenDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *token.ApiTokenTable) (string, error) {
+	if model == nil {
+		return "", errf.New(errf.InvalidParameter, "api token model is nil")
+	}
+
+	id, err := d.IDGen.One(kt, table.ApiTokenTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.ApiTokenTable, token.ApiTokenColumns.ColumnExpr(),
+		token.ApiTokenColumns.ColonNameExpr())
+
+	if err = d.Orm.Txn(tx).Insert(kt.Ctx, sql, model); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.ApiTokenTable, err, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %v", table.ApiTokenTable, err)
+	}
+
+	return id, nil
+}
+
+// Update api token.
+func (d ApiTokenDao) Update(kt *kit.Kit, filterExpr *filter.Expression, model *token.ApiTokenTable) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is nil")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
+
+	effected, err := d.Orm.Do().Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
+	if err != nil {
+		logs.ErrorJson("update api token failed, filter: %s, err: %v, rid: %v", filterExpr, err, kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		logs.ErrorJson("update api token, but record not found, filter: %v, rid: %v", filterExpr, kt.Rid)
+		return errf.New(errf.RecordNotFound, orm.ErrRecordNotFound.Error())
+	}
+
+	return nil
+}
+
+// UpdateLastUsed update api token's last used info, reviser is not changed because it is not an user operation.
+func (d ApiTokenDao) UpdateLastUsed(kt *kit.Kit, id string, lastUsedAt string, lastUsedIP string) error {
+	if len(id) == 0 || len(lastUsedAt) == 0 {
+		return errf.New(errf.InvalidParameter, "id and last used time are required")
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s SET last_used_at = :last_used_at, last_used_ip = :last_used_ip WHERE id = :id`,
+		table.ApiTokenTable)
+	args := map[string]interface{}{
+		"id":           id,
+		"last_used_at": lastUsedAt,
+		"last_used_ip": lastUsedIP,
+	}
+
+	if _, err := d.Orm.Do().Update(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("update api token last used info failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// List api token.
+func (d ApiTokenDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListApiTokenDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list api token options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(token.ApiTokenColumns.ColumnTypes())),
+		core.DefaultCursorPageOption); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ApiTokenTable, whereExpr)
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count api token failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListApiTokenDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, token.ApiTokenColumns.FieldsNamedExpr(opt.Fields),
+		table.ApiTokenTable, whereExpr, pageExpr)
+
+	details := make([]token.ApiTokenTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListApiTokenDetails{Details: details}, nil
+}
+
+// DeleteWithTx delete api token with tx.
+func (d ApiTokenDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.ApiTokenTable, whereExpr)
+	if _, err := d.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete api token failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -22,6 +22,7 @@ package tools
 import (
 	"fmt"
 
+	"hcm/pkg/dal/table"
 	"hcm/pkg/runtime/filter"
 )
 
@@ -70,6 +71,18 @@ var DefaultSqlWhereOption = &filter.SQLWhereOption{
 	Priority: filter.Priority{"id"},
 }
 
+// TagSqlWhereOption define sql where option of the resource that supports tag rules, the tag rules are converted to
+// the sub query of the resource tag table.
+func TagSqlWhereOption(resType table.Name) *filter.SQLWhereOption {
+	return &filter.SQLWhereOption{
+		Priority: filter.Priority{"id"},
+		TagOption: &filter.TagOption{
+			Table:   string(table.ResourceTagTable),
+			ResType: string(resType),
+		},
+	}
+}
+
 // And merge expressions using 'and' operation.
 func And(rules ...filter.RuleFactory) (*filter.Expression, error) {
 	if len(rules) == 0 {
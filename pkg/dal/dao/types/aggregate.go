@@ -0,0 +1,203 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/runtime/filter"
+)
+
+// AggregateOption defines options to aggregate resources.
+type AggregateOption struct {
+	Filter  *filter.Expression
+	GroupBy []string
+	Metrics []core.AggregateMetric
+	Limit   uint
+}
+
+// Validate aggregate option, group by fields and metric fields are validated by the table's column types.
+func (opt *AggregateOption) Validate(eo *filter.ExprOption, columnTypes map[string]enumor.ColumnType) error {
+	req := &core.AggregateReq{Filter: opt.Filter, GroupBy: opt.GroupBy, Metrics: opt.Metrics, Limit: opt.Limit}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	if eo == nil {
+		return errf.New(errf.InvalidParameter, "filter expr option is required")
+	}
+
+	if err := opt.Filter.Validate(eo); err != nil {
+		return err
+	}
+
+	for _, field := range opt.GroupBy {
+		colType, exists := columnTypes[field]
+		if !exists {
+			return errf.Newf(errf.InvalidParameter, "group by field %s is not exists", field)
+		}
+
+		switch colType {
+		case enumor.String, enumor.Numeric, enumor.Boolean:
+		default:
+			return errf.Newf(errf.InvalidParameter, "can not group by %s field %s", colType, field)
+		}
+	}
+
+	for _, metric := range opt.Metrics {
+		if metric.Func == core.AggregateCount && len(metric.Field) == 0 {
+			continue
+		}
+
+		colType, exists := columnTypes[metric.Field]
+		if !exists {
+			return errf.Newf(errf.InvalidParameter, "metric field %s is not exists", metric.Field)
+		}
+
+		switch metric.Func {
+		case core.AggregateSum:
+			if colType != enumor.Numeric {
+				return errf.Newf(errf.InvalidParameter, "can not sum %s field %s", colType, metric.Field)
+			}
+		case core.AggregateMin, core.AggregateMax:
+			if colType != enumor.Numeric && colType != enumor.Time {
+				return errf.Newf(errf.InvalidParameter, "can not %s %s field %s", metric.Func, colType,
+					metric.Field)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SQLExpr generate the aggregate sql expression of the table, group by fields are selected as g0, g1...
+// and metrics are selected as m0, m1... so that the result can be scanned into AggregateRow.
+func (opt *AggregateOption) SQLExpr(tableName table.Name, whereOpt *filter.SQLWhereOption) (string,
+	map[string]interface{}, error) {
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	selects := make([]string, 0, len(opt.GroupBy)+len(opt.Metrics))
+	for idx, field := range opt.GroupBy {
+		selects = append(selects, fmt.Sprintf("%s as g%d", field, idx))
+	}
+
+	for idx, metric := range opt.Metrics {
+		field := metric.Field
+		if len(field) == 0 {
+			field = "*"
+		}
+		selects = append(selects, fmt.Sprintf("%s(%s) as m%d", metric.Func, field, idx))
+	}
+
+	sql := fmt.Sprintf("select %s from %s %s", strings.Join(selects, ", "), tableName, whereExpr)
+	if len(opt.GroupBy) == 0 {
+		return sql, whereValue, nil
+	}
+
+	limit := opt.Limit
+	if limit == 0 {
+		limit = core.AggregateMaxLimit
+	}
+
+	groupBy := strings.Join(opt.GroupBy, ", ")
+	sql = fmt.Sprintf("%s group by %s order by %s limit %d", sql, groupBy, groupBy, limit)
+
+	return sql, whereValue, nil
+}
+
+// AggregateRow is the aggregate sql result row, the number of the group and metric fields are limited by
+// core.AggregateMaxGroupBy and core.AggregateMaxMetrics.
+type AggregateRow struct {
+	G0 sql.NullString `db:"g0"`
+	G1 sql.NullString `db:"g1"`
+	G2 sql.NullString `db:"g2"`
+	G3 sql.NullString `db:"g3"`
+	G4 sql.NullString `db:"g4"`
+	M0 sql.NullString `db:"m0"`
+	M1 sql.NullString `db:"m1"`
+	M2 sql.NullString `db:"m2"`
+	M3 sql.NullString `db:"m3"`
+	M4 sql.NullString `db:"m4"`
+}
+
+// Detail convert the aggregate sql result row to aggregate detail, values are converted by the column types.
+func (opt *AggregateOption) Detail(row AggregateRow, columnTypes map[string]enumor.ColumnType) (
+	core.AggregateDetail, error) {
+
+	groups := []sql.NullString{row.G0, row.G1, row.G2, row.G3, row.G4}
+	metrics := []sql.NullString{row.M0, row.M1, row.M2, row.M3, row.M4}
+
+	detail := core.AggregateDetail{
+		Group:   make(map[string]interface{}, len(opt.GroupBy)),
+		Metrics: make(map[string]interface{}, len(opt.Metrics)),
+	}
+
+	for idx, field := range opt.GroupBy {
+		value, err := convAggregateValue(groups[idx], columnTypes[field])
+		if err != nil {
+			return core.AggregateDetail{}, fmt.Errorf("convert group field %s failed, err: %v", field, err)
+		}
+		detail.Group[field] = value
+	}
+
+	for idx, metric := range opt.Metrics {
+		colType := columnTypes[metric.Field]
+		if metric.Func == core.AggregateCount {
+			colType = enumor.Numeric
+		}
+
+		value, err := convAggregateValue(metrics[idx], colType)
+		if err != nil {
+			return core.AggregateDetail{}, fmt.Errorf("convert metric %s failed, err: %v", metric.Key(), err)
+		}
+		detail.Metrics[metric.Key()] = value
+	}
+
+	return detail, nil
+}
+
+func convAggregateValue(value sql.NullString, colType enumor.ColumnType) (interface{}, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+
+	switch colType {
+	case enumor.Numeric:
+		if intVal, err := strconv.ParseInt(value.String, 10, 64); err == nil {
+			return intVal, nil
+		}
+		return strconv.ParseFloat(value.String, 64)
+	case enumor.Boolean:
+		return strconv.ParseBool(value.String)
+	default:
+		return value.String, nil
+	}
+}
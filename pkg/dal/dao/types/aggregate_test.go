@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/runtime/filter"
+)
+
+var aggregateTestColumns = map[string]enumor.ColumnType{
+	"vendor":     enumor.String,
+	"region":     enumor.String,
+	"bk_biz_id":  enumor.Numeric,
+	"memory":     enumor.Numeric,
+	"extension":  enumor.Json,
+	"created_at": enumor.Time,
+}
+
+func TestAggregateOptionValidate(t *testing.T) {
+	eo := filter.NewExprOption(filter.RuleFields(aggregateTestColumns))
+	count := core.AggregateMetric{Func: core.AggregateCount}
+
+	cases := []struct {
+		opt   *AggregateOption
+		valid bool
+	}{
+		{opt: &AggregateOption{GroupBy: []string{"vendor", "region"}, Metrics: []core.AggregateMetric{count}},
+			valid: true},
+		{opt: &AggregateOption{Metrics: []core.AggregateMetric{{Func: core.AggregateMax, Field: "created_at"}}},
+			valid: true},
+		{opt: &AggregateOption{GroupBy: []string{"not_exists"}, Metrics: []core.AggregateMetric{count}}},
+		{opt: &AggregateOption{GroupBy: []string{"extension"}, Metrics: []core.AggregateMetric{count}}},
+		{opt: &AggregateOption{Metrics: []core.AggregateMetric{{Func: core.AggregateSum, Field: "vendor"}}}},
+		{opt: &AggregateOption{Metrics: []core.AggregateMetric{{Func: "avg", Field: "memory"}}}},
+	}
+
+	for idx, c := range cases {
+		c.opt.Filter = tools.EqualExpression("vendor", "tcloud")
+		err := c.opt.Validate(eo, aggregateTestColumns)
+		if c.valid && err != nil {
+			t.Errorf("case %d, aggregate option should be valid, but got err: %v", idx, err)
+		}
+
+		if !c.valid && err == nil {
+			t.Errorf("case %d, aggregate option should be invalid", idx)
+		}
+	}
+}
+
+func TestAggregateOptionSQLExpr(t *testing.T) {
+	opt := &AggregateOption{
+		Filter:  tools.EqualExpression("vendor", "tcloud"),
+		GroupBy: []string{"region", "bk_biz_id"},
+		Metrics: []core.AggregateMetric{{Func: core.AggregateCount}, {Func: core.AggregateSum, Field: "memory"}},
+		Limit:   10,
+	}
+
+	expr, value, err := opt.SQLExpr("cvm", tools.DefaultSqlWhereOption)
+	if err != nil {
+		t.Fatalf("generate aggregate sql failed, err: %v", err)
+	}
+
+	if !strings.HasPrefix(expr, "select region as g0, bk_biz_id as g1, count(*) as m0, sum(memory) as m1 from cvm "+
+		"WHERE ") || !strings.HasSuffix(expr, "group by region, bk_biz_id order by region, bk_biz_id limit 10") ||
+		len(value) != 1 {
+		t.Errorf("unexpected aggregate sql: %s, value: %v", expr, value)
+	}
+
+	row := AggregateRow{
+		G0: sql.NullString{String: "ap-guangzhou", Valid: true},
+		G1: sql.NullString{String: "2", Valid: true},
+		M0: sql.NullString{String: "3", Valid: true},
+	}
+	detail, err := opt.Detail(row, aggregateTestColumns)
+	if err != nil {
+		t.Fatalf("convert aggregate row failed, err: %v", err)
+	}
+
+	if detail.Group["region"] != "ap-guangzhou" || detail.Group["bk_biz_id"] != int64(2) ||
+		detail.Metrics["count"] != int64(3) || detail.Metrics["sum_memory"] != nil {
+		t.Errorf("unexpected aggregate detail: %+v", detail)
+	}
+}
@@ -30,6 +30,7 @@ type CloudResourceBasicInfo struct {
 	// these fields are basic info for some resource, needs to be specified explicitly.
 	Region        string `json:"region" db:"region"`
 	RecycleStatus string `json:"recycle_status" db:"recycle_status"`
+	CloudID       string `json:"cloud_id" db:"cloud_id"`
 }
 
 // CommonBasicInfoFields defines common cloud resource basic info fields.
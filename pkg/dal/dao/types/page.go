@@ -25,6 +25,7 @@ import (
 
 	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/runtime/filter"
 )
 
 // PageSQLOption defines the options to generate a sql expression
@@ -51,6 +52,30 @@ type SortOption struct {
 	ForceOverlap bool `json:"force_overlap"`
 }
 
+// SortField returns the sort field of the page according to the sort options.
+func (ps *PageSQLOption) SortField(bp *core.BasePage) string {
+	var sort string
+	if ps.Sort.ForceOverlap {
+		// force overlapped user defined sort field.
+		sort = ps.Sort.Sort
+	} else {
+		if ps.Sort.IfNotPresent && len(bp.Sort) == 0 {
+			// user note defined sort, then use default sort.
+			sort = ps.Sort.Sort
+		} else {
+			// use user defined sort column
+			sort = bp.Sort
+		}
+	}
+	if len(sort) == 0 {
+		// if sort is not set, use the default resource's
+		// identity id as the default sort column.
+		sort = "id"
+	}
+
+	return sort
+}
+
 // PageSQLExpr return the expression of the query clause based one the page options.
 // Note:
 //  1. do not call this, when it's a count request.
@@ -79,23 +104,18 @@ func PageSQLExpr(bp *core.BasePage, ps *PageSQLOption) (where string, err error)
 		// it means do not need to sort.
 		return "", nil
 	}
-	var sort string
-	if ps.Sort.ForceOverlap {
-		// force overlapped user defined sort field.
-		sort = ps.Sort.Sort
-	} else {
-		if ps.Sort.IfNotPresent && len(bp.Sort) == 0 {
-			// user note defined sort, then use default sort.
-			sort = ps.Sort.Sort
-		} else {
-			// use user defined sort column
-			sort = bp.Sort
+	sort := ps.SortField(bp)
+	if len(bp.Cursor) != 0 {
+		// keyset pagination, the resources after the cursor are already filtered by CursorFilter,
+		// so only sort and limit them, 'id' is used to break the tie of the same sort value.
+		if bp.Start != 0 || bp.Limit == 0 {
+			return "", errors.New("page.cursor is set, page.start should be 0 and page.limit should >= 1")
 		}
-	}
-	if len(sort) == 0 {
-		// if sort is not set, use the default resource's
-		// identity id as the default sort column.
-		sort = "id"
+		order := bp.Order.Order()
+		if sort == "id" {
+			return fmt.Sprintf("ORDER BY id %s LIMIT %d", order, bp.Limit), nil
+		}
+		return fmt.Sprintf("ORDER BY %s %s, id %s LIMIT %d", sort, order, order, bp.Limit), nil
 	}
 	expr := fmt.Sprintf("ORDER BY %s", sort)
 	if bp.Start == 0 && bp.Limit == 0 {
@@ -110,3 +130,57 @@ func PageSQLExpr(bp *core.BasePage, ps *PageSQLOption) (where string, err error)
 	expr = fmt.Sprintf("%s %s LIMIT %d OFFSET %d", expr, bp.Order.Order(), bp.Limit, bp.Start)
 	return expr, nil
 }
+
+// CursorFilter merge the keyset condition of the page cursor into the filter expression, the
+// resources after the cursor's resource in the order of (sort field, id) are matched.
+// if the page cursor is not set, the filter expression is returned as it is.
+// Note: the returned expression is a new one, the filter expression is not changed.
+func CursorFilter(expr *filter.Expression, bp *core.BasePage, ps *PageSQLOption) (*filter.Expression, error) {
+	if bp == nil || len(bp.Cursor) == 0 {
+		return expr, nil
+	}
+
+	if ps == nil {
+		return nil, errf.New(errf.InvalidParameter, "page sql option is nil")
+	}
+
+	cursor, err := core.DecodePageCursor(bp.Cursor)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	sort := ps.SortField(bp)
+	if cursor.Sort != sort {
+		return nil, errf.Newf(errf.InvalidParameter, "page.cursor is generated by sort %s, not %s", cursor.Sort,
+			sort)
+	}
+
+	op := filter.GreaterThan.Factory()
+	if bp.Order.Order() == core.Descending {
+		op = filter.LessThan.Factory()
+	}
+
+	var keyset filter.RuleFactory = &filter.AtomRule{Field: "id", Op: op, Value: cursor.ID}
+	if sort != "id" {
+		// sort > value OR (sort = value AND id > cursor id)
+		keyset = &filter.Expression{
+			Op: filter.Or,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: sort, Op: op, Value: cursor.Value},
+				&filter.Expression{
+					Op: filter.And,
+					Rules: []filter.RuleFactory{
+						&filter.AtomRule{Field: sort, Op: filter.Equal.Factory(), Value: cursor.Value},
+						keyset,
+					},
+				},
+			},
+		}
+	}
+
+	if expr.IsEmpty() {
+		return &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{keyset}}, nil
+	}
+
+	return &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{expr, keyset}}, nil
+}
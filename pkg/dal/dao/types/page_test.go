@@ -0,0 +1,95 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/dal/dao/tools"
+)
+
+func TestPageSQLExprWithCursor(t *testing.T) {
+	cursor := core.NewIDPageCursor("00000010")
+
+	cases := []struct {
+		page   *core.BasePage
+		expect string
+	}{
+		{page: &core.BasePage{Limit: 100, Cursor: cursor}, expect: "ORDER BY id ASC LIMIT 100"},
+		{page: &core.BasePage{Limit: 100, Sort: "name", Order: core.Descending, Cursor: cursor},
+			expect: "ORDER BY name DESC, id DESC LIMIT 100"},
+	}
+
+	for idx, c := range cases {
+		expr, err := PageSQLExpr(c.page, DefaultPageSQLOption)
+		if err != nil {
+			t.Errorf("case %d, generate page sql expr failed, err: %v", idx, err)
+			continue
+		}
+
+		if expr != c.expect {
+			t.Errorf("case %d, expect page sql expr: %s, but got: %s", idx, c.expect, expr)
+		}
+	}
+
+	if _, err := PageSQLExpr(&core.BasePage{Start: 1, Limit: 100, Cursor: cursor}, DefaultPageSQLOption); err == nil {
+		t.Errorf("page with both start and cursor should be invalid")
+	}
+}
+
+func TestListOptionSQLWhereExprWithCursor(t *testing.T) {
+	opt := &ListOption{Filter: tools.EqualExpression("vendor", "tcloud"),
+		Page: &core.BasePage{Limit: 100, Cursor: core.NewIDPageCursor("00000010")}}
+
+	where, value, err := opt.SQLWhereExpr(tools.DefaultSqlWhereOption, DefaultPageSQLOption)
+	if err != nil {
+		t.Fatalf("generate sql where expr failed, err: %v", err)
+	}
+
+	if !strings.Contains(where, "vendor = ") || !strings.Contains(where, "id > ") || len(value) != 2 {
+		t.Errorf("sql where expr should filter vendor and id after the cursor, but got: %s, value: %v", where, value)
+	}
+
+	if len(opt.Filter.Rules) != 1 {
+		t.Errorf("filter of the list option should not be changed, but got %d rules", len(opt.Filter.Rules))
+	}
+
+	cursor, err := core.NewPageCursor("name", "cvm-1", "00000010")
+	if err != nil {
+		t.Fatalf("generate page cursor failed, err: %v", err)
+	}
+
+	opt.Page = &core.BasePage{Limit: 100, Sort: "name", Cursor: cursor}
+	where, value, err = opt.SQLWhereExpr(tools.DefaultSqlWhereOption, DefaultPageSQLOption)
+	if err != nil {
+		t.Fatalf("generate sql where expr failed, err: %v", err)
+	}
+
+	if !strings.Contains(where, "name > ") || !strings.Contains(where, "name = ") || len(value) != 4 {
+		t.Errorf("sql where expr should filter the resources after (name, id), but got: %s, value: %v", where, value)
+	}
+
+	opt.Page = &core.BasePage{Limit: 100, Sort: "memo", Cursor: cursor}
+	if _, _, err = opt.SQLWhereExpr(tools.DefaultSqlWhereOption, DefaultPageSQLOption); err == nil {
+		t.Errorf("cursor generated by another sort field should be invalid")
+	}
+}
@@ -96,3 +96,15 @@ func (opt *SGRuleListOption) Validate(eo *filter.ExprOption, po *core.PageOption
 
 	return nil
 }
+
+// SQLWhereExpr generate the sql where expression of the list option's filter with the page cursor's keyset condition.
+func (opt *SGRuleListOption) SQLWhereExpr(whereOpt *filter.SQLWhereOption, ps *PageSQLOption) (string,
+	map[string]interface{}, error) {
+
+	expr, err := CursorFilter(opt.Filter, opt.Page, ps)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return expr.SQLWhereExpr(whereOpt)
+}
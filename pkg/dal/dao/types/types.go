@@ -61,6 +61,20 @@ func (opt ListOption) Validate(eo *filter.ExprOption, po *core.PageOption) error
 	return nil
 }
 
+// SQLWhereExpr generate the sql where expression of the list option's filter, if the page cursor is set,
+// the keyset condition of the cursor is merged into the filter, and the page expression should be generated
+// by PageSQLExpr with the same page sql option.
+func (opt *ListOption) SQLWhereExpr(whereOpt *filter.SQLWhereOption, ps *PageSQLOption) (string,
+	map[string]interface{}, error) {
+
+	expr, err := CursorFilter(opt.Filter, opt.Page, ps)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return expr.SQLWhereExpr(whereOpt)
+}
+
 // CountOption defines options to count resources.
 type CountOption struct {
 	Filter  *filter.Expression
@@ -0,0 +1,160 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package migration is the versioned database schema migration runner, the migration scripts are applied in the
+// order of their versions, and the applied versions are recorded in the schema_migration table.
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fileNameRegexp matches the migration script file name, e.g. 0001_20230227_2045_init_db.sql.
+var fileNameRegexp = regexp.MustCompile(`^(\d{4,})_([0-9A-Za-z_]*)\.sql$`)
+
+// Migration is a versioned schema migration script.
+type Migration struct {
+	Version  uint
+	Name     string
+	SQL      string
+	Checksum string
+}
+
+// Statements split the migration script into sql statements.
+func (m Migration) Statements() []string {
+	return SplitStatements(m.SQL)
+}
+
+// Load the migration scripts from the root dir of the file system, they are sorted by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migration scripts dir failed, err: %v", err)
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	versions := make(map[uint]string, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		matches := fileNameRegexp.FindStringSubmatch(entry.Name())
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("migration script %s name is invalid, should be {version}_{name}.sql",
+				entry.Name())
+		}
+
+		version, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse migration script %s version failed, err: %v", entry.Name(), err)
+		}
+
+		if version == 0 {
+			return nil, fmt.Errorf("migration script %s version should > 0", entry.Name())
+		}
+
+		if exists, ok := versions[uint(version)]; ok {
+			return nil, fmt.Errorf("migration script %s and %s have the same version", exists, entry.Name())
+		}
+		versions[uint(version)] = entry.Name()
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read migration script %s failed, err: %v", entry.Name(), err)
+		}
+
+		sum := sha256.Sum256(content)
+		migrations = append(migrations, Migration{
+			Version:  uint(version),
+			Name:     entry.Name(),
+			SQL:      string(content),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// SplitStatements split the sql script into statements by ';', comments are removed, and the ';' in quoted
+// strings or identifiers is not treated as the delimiter.
+func SplitStatements(script string) []string {
+	statements := make([]string, 0)
+	stmt := new(strings.Builder)
+
+	appendStmt := func() {
+		if s := strings.TrimSpace(stmt.String()); len(s) != 0 {
+			statements = append(statements, s)
+		}
+		stmt.Reset()
+	}
+
+	runes := []rune(script)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\'' || r == '"' || r == '`':
+			// copy the quoted content as it is, backslash escapes are not supported in identifiers.
+			stmt.WriteRune(r)
+			for i++; i < len(runes); i++ {
+				stmt.WriteRune(runes[i])
+				if runes[i] == '\\' && r != '`' && i+1 < len(runes) {
+					i++
+					stmt.WriteRune(runes[i])
+					continue
+				}
+
+				if runes[i] == r {
+					break
+				}
+			}
+		case r == '#' || (r == '-' && i+2 < len(runes) && runes[i+1] == '-' && (runes[i+2] == ' ' ||
+			runes[i+2] == '\t')):
+			// skip the line comment.
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			stmt.WriteRune('\n')
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			// skip the block comment.
+			for i += 3; i < len(runes) && !(runes[i-1] == '*' && runes[i] == '/'); i++ {
+			}
+			stmt.WriteRune(' ')
+		case r == ';':
+			appendStmt()
+		default:
+			stmt.WriteRune(r)
+		}
+	}
+	appendStmt()
+
+	return statements
+}
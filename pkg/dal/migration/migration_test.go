@@ -0,0 +1,168 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package migration
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"hcm/pkg/kit"
+	sqlscripts "hcm/scripts/sql"
+
+	_ "github.com/go-sql-driver/mysql" // import mysql drive, used to create conn.
+	"github.com/jmoiron/sqlx"
+)
+
+func TestSplitStatements(t *testing.T) {
+	script := `/* table description; */
+create table if not exists a (id varchar(64) not null, memo varchar(64) default 'a;b'); -- comment;
+# another comment;
+insert into a (id, memo) values ('1', 'it\'s;'), ("2", "--x");
+`
+	expect := []string{
+		"create table if not exists a (id varchar(64) not null, memo varchar(64) default 'a;b')",
+		`insert into a (id, memo) values ('1', 'it\'s;'), ("2", "--x")`,
+	}
+
+	if stmts := SplitStatements(script); !reflect.DeepEqual(stmts, expect) {
+		t.Errorf("expect statements: %q, but got: %q", expect, stmts)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_20230329_1510.sql":         {Data: []byte("select 2;")},
+		"0001_20230227_2045_init_db.sql": {Data: []byte("select 1;")},
+		"sql.go":                         {Data: []byte("package sql")},
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("load migrations failed, err: %v", err)
+	}
+
+	if len(migrations) != 2 || migrations[0].Version != 1 || migrations[1].Version != 2 ||
+		len(migrations[0].Checksum) != 64 {
+		t.Errorf("unexpected migrations: %+v", migrations)
+	}
+
+	fsys["0002_duplicate.sql"] = &fstest.MapFile{Data: []byte("select 2;")}
+	if _, err = Load(fsys); err == nil {
+		t.Errorf("migrations with duplicate version should be invalid")
+	}
+
+	if _, err = Load(fstest.MapFS{"init.sql": {Data: []byte("select 1;")}}); err == nil {
+		t.Errorf("migration without version should be invalid")
+	}
+}
+
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	migrations, err := Load(sqlscripts.Migrations)
+	if err != nil {
+		t.Fatalf("load embedded migrations failed, err: %v", err)
+	}
+
+	for idx, m := range migrations {
+		if m.Version != uint(idx+1) {
+			t.Errorf("embedded migration %s version should be %d", m.Name, idx+1)
+		}
+
+		if len(m.Statements()) == 0 {
+			t.Errorf("embedded migration %s has no statement", m.Name)
+		}
+	}
+}
+
+func TestRunnerPending(t *testing.T) {
+	r := &Runner{migrations: []Migration{{Version: 1, Name: "0001.sql", Checksum: "a"},
+		{Version: 2, Name: "0002.sql", Checksum: "b"}, {Version: 3, Name: "0003.sql", Checksum: "c"}}}
+
+	pending, current, err := r.pending(map[uint]appliedVersion{1: {Version: 1, Checksum: "a"}})
+	if err != nil || current != 1 || len(pending) != 2 || pending[0].Version != 2 {
+		t.Errorf("unexpected pending migrations: %+v, current: %d, err: %v", pending, current, err)
+	}
+
+	if _, _, err = r.pending(map[uint]appliedVersion{1: {Version: 1, Checksum: "changed"}}); err == nil {
+		t.Errorf("changed applied migration should be invalid")
+	}
+
+	if _, _, err = r.pending(map[uint]appliedVersion{2: {Version: 2, Checksum: "b"}}); err == nil {
+		t.Errorf("migration older than current version should be invalid")
+	}
+
+	if _, _, err = r.pending(map[uint]appliedVersion{4: {Version: 4, Name: "0004.sql"}}); err == nil {
+		t.Errorf("applied migration not found should be invalid")
+	}
+}
+
+// TestRunnerMigrate test the runner against the local mysql instance, it is skipped if mysql is not available.
+func TestRunnerMigrate(t *testing.T) {
+	source := "root:admin@tcp(127.0.0.1:3306)/?parseTime=true&charset=utf8mb4"
+	admin, err := sqlx.Connect("mysql", source)
+	if err != nil {
+		t.Skipf("local mysql is not available, err: %v", err)
+	}
+	defer admin.Close()
+
+	if _, err = admin.Exec("create database if not exists hcm_migration_test"); err != nil {
+		t.Fatalf("create test database failed, err: %v", err)
+	}
+	defer admin.Exec("drop database if exists hcm_migration_test")
+
+	db, err := sqlx.Connect("mysql", "root:admin@tcp(127.0.0.1:3306)/hcm_migration_test?parseTime=true")
+	if err != nil {
+		t.Fatalf("connect test database failed, err: %v", err)
+	}
+	defer db.Close()
+
+	fsys := fstest.MapFS{
+		"0001_init.sql": {Data: []byte("create table a (id varchar(64) not null); insert into a values ('1');")},
+		"0002_add.sql":  {Data: []byte("alter table a add column name varchar(64) default '';")},
+	}
+
+	runner, err := NewRunner(db, fsys, nil)
+	if err != nil {
+		t.Fatalf("new runner failed, err: %v", err)
+	}
+
+	result, err := runner.Migrate(kit.New(), true)
+	if err != nil || len(result.Migrations) != 2 || len(result.Migrations[0].SQL) != 2 {
+		t.Fatalf("unexpected dry run result: %+v, err: %v", result, err)
+	}
+
+	if result, err = runner.Migrate(kit.New(), false); err != nil || len(result.Migrations) != 2 {
+		t.Fatalf("unexpected migrate result: %+v, err: %v", result, err)
+	}
+
+	if result, err = runner.Migrate(kit.New(), false); err != nil || result.CurrentVersion != 2 ||
+		len(result.Migrations) != 0 {
+		t.Fatalf("migrate again should apply nothing, result: %+v, err: %v", result, err)
+	}
+
+	fsys["0001_init.sql"] = &fstest.MapFile{Data: []byte("create table a (id varchar(32) not null);")}
+	if runner, err = NewRunner(db, fsys, nil); err != nil {
+		t.Fatalf("new runner failed, err: %v", err)
+	}
+
+	if _, err = runner.Migrate(kit.New(), false); err == nil {
+		t.Errorf("migrate with changed applied migration should be failed")
+	}
+}
@@ -0,0 +1,343 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// VersionTable is the table that records the applied migration versions.
+	VersionTable = "schema_migration"
+	// lockName is the name of the db lock, which makes sure only one migration runs at the same time.
+	lockName = "hcm_schema_migration"
+	// defaultLockTimeoutSec is the default timeout to wait for the db lock.
+	defaultLockTimeoutSec = 60
+)
+
+// Option is the migration runner options.
+type Option struct {
+	// BaselineVersion is used by the databases that migrated by hand before, if the version table is empty,
+	// migrations whose version <= BaselineVersion are recorded as applied without executing them.
+	BaselineVersion uint
+	// LockTimeoutSec is the timeout to wait for the db lock in seconds.
+	LockTimeoutSec uint
+}
+
+// Runner applies the pending migrations to the database.
+type Runner struct {
+	db         *sqlx.DB
+	migrations []Migration
+	opt        Option
+}
+
+// NewRunner create a migration runner with the migration scripts in the root dir of the file system.
+func NewRunner(db *sqlx.DB, fsys fs.FS, opt *Option) (*Runner, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db is nil")
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Runner{db: db, migrations: migrations}
+	if opt != nil {
+		r.opt = *opt
+	}
+
+	if r.opt.LockTimeoutSec == 0 {
+		r.opt.LockTimeoutSec = defaultLockTimeoutSec
+	}
+
+	return r, nil
+}
+
+// Result is the migration result.
+type Result struct {
+	// CurrentVersion is the schema version before migration.
+	CurrentVersion uint `json:"current_version"`
+	// DryRun defines if the migrations are only printed without applying.
+	DryRun bool `json:"dry_run"`
+	// Baselined is the versions that are recorded as applied by the baseline version without executing.
+	Baselined []uint `json:"baselined,omitempty"`
+	// Migrations is the pending migrations that are applied, or to be applied if it's dry run.
+	Migrations []PendingMigration `json:"migrations"`
+}
+
+// PendingMigration is the pending migration info.
+type PendingMigration struct {
+	Version  uint   `json:"version"`
+	Name     string `json:"name"`
+	Checksum string `json:"checksum"`
+	// SQL is the statements to be applied, only returned for dry run.
+	SQL []string `json:"sql,omitempty"`
+}
+
+// appliedVersion is the applied migration version record.
+type appliedVersion struct {
+	Version  uint   `db:"version"`
+	Name     string `db:"name"`
+	Checksum string `db:"checksum"`
+}
+
+// Migrate apply the pending migrations in order of version, if dry run is set, the pending migrations are only
+// returned with their sql statements. migrations run with a db lock so that multiple instances do not conflict.
+func (r *Runner) Migrate(kt *kit.Kit, dryRun bool) (*Result, error) {
+	conn, err := r.db.Connx(kt.Ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get db connection failed, err: %v", err)
+	}
+	defer conn.Close()
+
+	if !dryRun {
+		if err = r.lock(kt.Ctx, conn); err != nil {
+			return nil, err
+		}
+		defer r.unlock(kt, conn)
+
+		if err = r.createVersionTable(kt.Ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+
+	applied, err := r.listApplied(kt.Ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{DryRun: dryRun, Migrations: make([]PendingMigration, 0)}
+
+	if len(applied) == 0 {
+		if r.opt.BaselineVersion > 0 {
+			applied, result.Baselined, err = r.baseline(kt, conn, dryRun)
+		} else {
+			err = r.checkEmpty(kt.Ctx, conn)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pending, currentVersion, err := r.pending(applied)
+	if err != nil {
+		return nil, err
+	}
+	result.CurrentVersion = currentVersion
+
+	for _, m := range pending {
+		info := PendingMigration{Version: m.Version, Name: m.Name, Checksum: m.Checksum}
+		if dryRun {
+			info.SQL = m.Statements()
+			result.Migrations = append(result.Migrations, info)
+			continue
+		}
+
+		if err = r.apply(kt, conn, m); err != nil {
+			return nil, err
+		}
+		result.Migrations = append(result.Migrations, info)
+	}
+
+	return result, nil
+}
+
+// pending verify the applied migrations and returns the pending migrations and the current version.
+func (r *Runner) pending(applied map[uint]appliedVersion) ([]Migration, uint, error) {
+	currentVersion := uint(0)
+	for version := range applied {
+		if version > currentVersion {
+			currentVersion = version
+		}
+	}
+
+	found := make(map[uint]struct{}, len(applied))
+	pending := make([]Migration, 0)
+	for _, m := range r.migrations {
+		record, exists := applied[m.Version]
+		if !exists {
+			if m.Version < currentVersion {
+				return nil, 0, fmt.Errorf("migration %s is older than the current version %d", m.Name,
+					currentVersion)
+			}
+			pending = append(pending, m)
+			continue
+		}
+
+		found[m.Version] = struct{}{}
+		if record.Checksum != m.Checksum {
+			return nil, 0, fmt.Errorf("migration %s has been changed after applied, checksum: %s, applied: %s",
+				m.Name, m.Checksum, record.Checksum)
+		}
+	}
+
+	for version, record := range applied {
+		if _, exists := found[version]; !exists {
+			return nil, 0, fmt.Errorf("applied migration %s is not found", record.Name)
+		}
+	}
+
+	return pending, currentVersion, nil
+}
+
+// baseline record the migrations whose version <= baseline version as applied.
+func (r *Runner) baseline(kt *kit.Kit, conn *sqlx.Conn, dryRun bool) (map[uint]appliedVersion, []uint, error) {
+	applied := make(map[uint]appliedVersion)
+	versions := make([]uint, 0)
+	for _, m := range r.migrations {
+		if m.Version > r.opt.BaselineVersion {
+			break
+		}
+
+		if !dryRun {
+			if err := r.record(kt.Ctx, conn, m); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		applied[m.Version] = appliedVersion{Version: m.Version, Name: m.Name, Checksum: m.Checksum}
+		versions = append(versions, m.Version)
+	}
+
+	if !dryRun {
+		logs.Infof("baseline schema migration versions: %v, rid: %s", versions, kt.Rid)
+	}
+
+	return applied, versions, nil
+}
+
+// apply execute the statements of the migration one by one and record the version, ddl statements are committed
+// implicitly by mysql, so a failed migration needs to be fixed by hand before migrating again.
+func (r *Runner) apply(kt *kit.Kit, conn *sqlx.Conn, m Migration) error {
+	start := time.Now()
+	for idx, stmt := range m.Statements() {
+		if _, err := conn.ExecContext(kt.Ctx, stmt); err != nil {
+			logs.Errorf("apply migration %s statement %d failed, err: %v, sql: %s, rid: %s", m.Name, idx, err, stmt,
+				kt.Rid)
+			return fmt.Errorf("apply migration %s statement %d failed, err: %v", m.Name, idx, err)
+		}
+	}
+
+	if err := r.record(kt.Ctx, conn, m); err != nil {
+		return err
+	}
+
+	logs.Infof("apply schema migration %s success, cost: %s, rid: %s", m.Name, time.Since(start), kt.Rid)
+	return nil
+}
+
+func (r *Runner) record(ctx context.Context, conn *sqlx.Conn, m Migration) error {
+	sql := fmt.Sprintf("insert into %s (version, name, checksum) values (?, ?, ?)", VersionTable)
+	if _, err := conn.ExecContext(ctx, sql, m.Version, m.Name, m.Checksum); err != nil {
+		return fmt.Errorf("record migration %s version failed, err: %v", m.Name, err)
+	}
+
+	return nil
+}
+
+func (r *Runner) createVersionTable(ctx context.Context, conn *sqlx.Conn) error {
+	sql := fmt.Sprintf(`create table if not exists %s
+(
+    version    bigint unsigned not null,
+    name       varchar(255)    not null,
+    checksum   varchar(64)     not null,
+    applied_at timestamp       not null default current_timestamp,
+    primary key (version)
+) engine = innodb
+  default charset = utf8mb4`, VersionTable)
+
+	if _, err := conn.ExecContext(ctx, sql); err != nil {
+		return fmt.Errorf("create migration version table failed, err: %v", err)
+	}
+
+	return nil
+}
+
+// checkEmpty make sure the database is empty when no version is applied, otherwise the database is migrated by
+// hand before, and the baseline version should be set.
+func (r *Runner) checkEmpty(ctx context.Context, conn *sqlx.Conn) error {
+	var count int
+	sql := "select count(*) from information_schema.tables where table_schema = database() and table_name != ?"
+	if err := conn.GetContext(ctx, &count, sql, VersionTable); err != nil {
+		return fmt.Errorf("count database tables failed, err: %v", err)
+	}
+
+	if count != 0 {
+		return fmt.Errorf("database has %d tables but no migration version is applied, baseline version should "+
+			"be set to the version of the migrations applied by hand", count)
+	}
+
+	return nil
+}
+
+func (r *Runner) listApplied(ctx context.Context, conn *sqlx.Conn) (map[uint]appliedVersion, error) {
+	var count int
+	sql := "select count(*) from information_schema.tables where table_schema = database() and table_name = ?"
+	if err := conn.GetContext(ctx, &count, sql, VersionTable); err != nil {
+		return nil, fmt.Errorf("check migration version table failed, err: %v", err)
+	}
+
+	applied := make(map[uint]appliedVersion)
+	if count == 0 {
+		return applied, nil
+	}
+
+	list := make([]appliedVersion, 0)
+	sql = fmt.Sprintf("select version, name, checksum from %s", VersionTable)
+	if err := conn.SelectContext(ctx, &list, sql); err != nil {
+		return nil, fmt.Errorf("list applied migration versions failed, err: %v", err)
+	}
+
+	for _, one := range list {
+		applied[one.Version] = one
+	}
+
+	return applied, nil
+}
+
+// lock acquire the db lock by mysql GET_LOCK, the lock is bound to the connection.
+func (r *Runner) lock(ctx context.Context, conn *sqlx.Conn) error {
+	var locked *int
+	if err := conn.GetContext(ctx, &locked, "select get_lock(?, ?)", lockName, r.opt.LockTimeoutSec); err != nil {
+		return fmt.Errorf("get migration lock failed, err: %v", err)
+	}
+
+	if locked == nil || *locked != 1 {
+		return fmt.Errorf("get migration lock timeout after %ds, another migration may be running",
+			r.opt.LockTimeoutSec)
+	}
+
+	return nil
+}
+
+func (r *Runner) unlock(kt *kit.Kit, conn *sqlx.Conn) {
+	if _, err := conn.ExecContext(kt.Ctx, "select release_lock(?)", lockName); err != nil {
+		logs.Errorf("release migration lock failed, err: %v, rid: %s", err, kt.Rid)
+	}
+}
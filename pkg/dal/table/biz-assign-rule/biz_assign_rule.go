@@ -0,0 +1,161 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bizassignrule defines the biz assign rule table.
+package bizassignrule
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// BizAssignRuleColumns defines all the biz assign rule table's columns.
+var BizAssignRuleColumns = utils.MergeColumns(nil, BizAssignRuleColumnDescriptor)
+
+// BizAssignRuleColumnDescriptor is BizAssignRuleTable's column descriptors.
+var BizAssignRuleColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "res_types", NamedC: "res_types", Type: enumor.Json},
+	{Column: "rule_type", NamedC: "rule_type", Type: enumor.String},
+	{Column: "tag_key", NamedC: "tag_key", Type: enumor.String},
+	{Column: "tag_value", NamedC: "tag_value", Type: enumor.String},
+	{Column: "vpc_id", NamedC: "vpc_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "priority", NamedC: "priority", Type: enumor.Numeric},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// BizAssignRuleTable biz_assign_rule表，账号下未分配业务的资源按规则自动分配到业务，资源同步后按优先级依次执行
+type BizAssignRuleTable struct {
+	// ID 规则ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Name 规则名称，账号下唯一
+	Name string `db:"name" json:"name" validate:"lte=64"`
+	// AccountID 规则所属账号ID，规则只作用于该账号下的资源
+	AccountID string `db:"account_id" json:"account_id" validate:"lte=64"`
+	// ResTypes 规则作用的资源类型
+	ResTypes types.StringArray `db:"res_types" json:"res_types"`
+	// RuleType 规则类型，tag: 按标签匹配，vpc: 按所属VPC匹配
+	RuleType enumor.BizAssignRuleType `db:"rule_type" json:"rule_type" validate:"lte=16"`
+	// TagKey 标签规则匹配的标签键
+	TagKey string `db:"tag_key" json:"tag_key" validate:"lte=255"`
+	// TagValue 标签规则匹配的标签值
+	TagValue *string `db:"tag_value" json:"tag_value" validate:"omitempty,lte=255"`
+	// VpcID VPC规则匹配的VPC ID
+	VpcID string `db:"vpc_id" json:"vpc_id" validate:"lte=64"`
+	// BkBizID 匹配的资源分配到的业务ID
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// Priority 优先级，数值越小越先执行，资源只会被第一个匹配的规则分配
+	Priority *uint `db:"priority" json:"priority"`
+	// Enabled 是否启用，资源同步后只执行启用的规则
+	Enabled *bool `db:"enabled" json:"enabled"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"max=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"max=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return biz assign rule table name.
+func (r BizAssignRuleTable) TableName() table.Name {
+	return table.BizAssignRuleTable
+}
+
+// InsertValidate validate biz assign rule table on insert.
+func (r BizAssignRuleTable) InsertValidate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if len(r.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(r.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if len(r.AccountID) == 0 {
+		return errors.New("account_id can not be empty")
+	}
+
+	if len(r.ResTypes) == 0 {
+		return errors.New("res_types can not be empty")
+	}
+
+	if err := r.RuleType.Validate(); err != nil {
+		return err
+	}
+
+	if r.BkBizID <= 0 {
+		return errors.New("bk_biz_id should be > 0")
+	}
+
+	if r.Priority == nil {
+		return errors.New("priority can not be empty")
+	}
+
+	if r.Enabled == nil {
+		return errors.New("enabled can not be empty")
+	}
+
+	if len(r.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate biz assign rule table on update.
+func (r BizAssignRuleTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if len(r.AccountID) != 0 {
+		return errors.New("account_id can not update")
+	}
+
+	if len(r.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(r.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package changefeed defines the resource change feed table.
+package changefeed
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ChangeFeedColumns defines all the change feed table's columns.
+var ChangeFeedColumns = utils.MergeColumns(nil, ChangeFeedColumnDescriptor)
+
+// ChangeFeedColumnDescriptor is ChangeFeedTable's column descriptors.
+var ChangeFeedColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.Numeric},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "action", NamedC: "action", Type: enumor.String},
+	{Column: "seq", NamedC: "seq", Type: enumor.Numeric},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// ChangeFeedTable change_feed表，记录资源的增删改，由DAO的写操作在同一事务中写入。自增ID在并发事务中可能乱序提交，
+// 所以由定序器在变更提交后按提交顺序分配Seq，Seq作为变更订阅的游标
+type ChangeFeedTable struct {
+	// ID 自增ID
+	ID uint64 `db:"id" json:"id"`
+	// ResType 资源类型，即资源的表名
+	ResType table.Name `db:"res_type" json:"res_type"`
+	// ResID 资源ID
+	ResID string `db:"res_id" json:"res_id"`
+	// AccountID 资源所属账号ID，用于变更订阅的鉴权
+	AccountID string `db:"account_id" json:"account_id"`
+	// Action 变更动作
+	Action enumor.ChangeFeedAction `db:"action" json:"action"`
+	// Seq 提交序号，变更游标，未定序的变更为空
+	Seq *uint64 `db:"seq" json:"seq"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+}
+
+// TableName return change feed table name.
+func (c ChangeFeedTable) TableName() table.Name {
+	return table.ChangeFeedTable
+}
+
+// ChangeFeedSequenceTable change_feed_sequence表，提交序号计数器，每个定序的表一行，定序器加锁后基于它分配该表的Seq
+type ChangeFeedSequenceTable struct {
+	// ID 计数器ID，如SequenceCounterID、EventSequenceCounterID
+	ID uint64 `db:"id" json:"id"`
+	// Seq 已分配的最大提交序号
+	Seq uint64 `db:"seq" json:"seq"`
+}
+
+const (
+	// SequenceCounterID is the id of the counter row of the change feeds in the change feed sequence table.
+	SequenceCounterID = 1
+	// EventSequenceCounterID is the id of the counter row of the resource lifecycle events in the change feed
+	// sequence table.
+	EventSequenceCounterID = 2
+)
+
+// TableName return change feed sequence table name.
+func (c ChangeFeedSequenceTable) TableName() table.Name {
+	return table.ChangeFeedSequenceTable
+}
+
+// accountIDColumns is the resource types that support change feed, and the column of their account id.
+var accountIDColumns = map[table.Name]string{
+	table.AccountTable:          "id",
+	table.CvmTable:              "account_id",
+	table.VpcTable:              "account_id",
+	table.SubnetTable:           "account_id",
+	table.DiskTable:             "account_id",
+	table.EipTable:              "account_id",
+	table.SecurityGroupTable:    "account_id",
+	table.GcpFirewallRuleTable:  "account_id",
+	table.RouteTableTable:       "account_id",
+	table.NetworkInterfaceTable: "account_id",
+}
+
+// ValidateResType validate if the resource type supports change feed.
+func ValidateResType(resType table.Name) error {
+	_, err := AccountIDColumn(resType)
+	return err
+}
+
+// AccountIDColumn returns the column of the account id of the resource type.
+func AccountIDColumn(resType table.Name) (string, error) {
+	column, exists := accountIDColumns[resType]
+	if !exists {
+		return "", fmt.Errorf("resource type %s does not support change feed", resType)
+	}
+
+	return column, nil
+}
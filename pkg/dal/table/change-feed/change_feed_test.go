@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package changefeed
+
+import (
+	"testing"
+
+	"hcm/pkg/dal/table"
+)
+
+func TestAccountIDColumn(t *testing.T) {
+	column, err := AccountIDColumn(table.AccountTable)
+	if err != nil {
+		t.Errorf("get account table account id column failed, err: %v", err)
+		return
+	}
+
+	if column != "id" {
+		t.Errorf("account table account id column should be id, but got %s", column)
+		return
+	}
+
+	column, err = AccountIDColumn(table.CvmTable)
+	if err != nil {
+		t.Errorf("get cvm table account id column failed, err: %v", err)
+		return
+	}
+
+	if column != "account_id" {
+		t.Errorf("cvm table account id column should be account_id, but got %s", column)
+		return
+	}
+
+	if err = ValidateResType(table.ChangeFeedTable); err == nil {
+		t.Errorf("change feed table should not support change feed")
+		return
+	}
+}
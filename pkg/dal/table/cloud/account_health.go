@@ -0,0 +1,148 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AccountHealthColumns defines all the account health table's columns.
+var AccountHealthColumns = utils.MergeColumns(nil, AccountHealthColumnDescriptor)
+
+// AccountHealthColumnDescriptor is AccountHealthTable's column descriptors.
+var AccountHealthColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "last_check_at", NamedC: "last_check_at", Type: enumor.String},
+	{Column: "last_success_at", NamedC: "last_success_at", Type: enumor.String},
+	{Column: "last_error_at", NamedC: "last_error_at", Type: enumor.String},
+	{Column: "last_error", NamedC: "last_error", Type: enumor.String},
+	{Column: "consecutive_failures", NamedC: "consecutive_failures", Type: enumor.Numeric},
+	{Column: "missing_permissions", NamedC: "missing_permissions", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AccountHealthTable account_health表
+type AccountHealthTable struct {
+	// ID 自增ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor" validate:"lte=16"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" json:"account_id" validate:"lte=64"`
+	// Status 账号健康状态(healthy:健康 missing_permission:缺少权限 unavailable:不可用)
+	Status enumor.AccountHealthStatus `db:"status" json:"status" validate:"lte=32"`
+	// LastCheckAt 最近一次检查时间
+	LastCheckAt string `db:"last_check_at" json:"last_check_at" validate:"lte=32"`
+	// LastSuccessAt 最近一次检查成功的时间
+	LastSuccessAt string `db:"last_success_at" json:"last_success_at" validate:"lte=32"`
+	// LastErrorAt 最近一次检查失败的时间
+	LastErrorAt string `db:"last_error_at" json:"last_error_at" validate:"lte=32"`
+	// LastError 最近一次检查失败的错误信息
+	LastError string `db:"last_error" json:"last_error" validate:"lte=1024"`
+	// ConsecutiveFailures 连续检查失败的次数
+	ConsecutiveFailures uint64 `db:"consecutive_failures" json:"consecutive_failures"`
+	// MissingPermissions 资源同步所需但未授权的权限
+	MissingPermissions types.JsonField `db:"missing_permissions" json:"missing_permissions"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"max=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"max=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return account health table name.
+func (a AccountHealthTable) TableName() table.Name {
+	return table.AccountHealthTable
+}
+
+// InsertValidate validate account health table on insert.
+func (a AccountHealthTable) InsertValidate() error {
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	if len(a.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if err := a.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	if len(a.AccountID) == 0 {
+		return errors.New("account_id can not be empty")
+	}
+
+	if err := a.Status.Validate(); err != nil {
+		return err
+	}
+
+	if len(a.LastCheckAt) == 0 {
+		return errors.New("last_check_at can not be empty")
+	}
+
+	if len(a.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate account health table on update.
+func (a AccountHealthTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	if len(a.Status) != 0 {
+		if err := a.Status.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(a.Vendor) != 0 || len(a.AccountID) != 0 {
+		return errors.New("vendor and account_id can not update")
+	}
+
+	if len(a.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(a.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
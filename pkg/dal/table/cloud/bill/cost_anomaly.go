@@ -0,0 +1,169 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// CostAnomalyColumns defines all the cost anomaly table's columns.
+var CostAnomalyColumns = utils.MergeColumns(nil, CostAnomalyColumnDescriptor)
+
+// CostAnomalyColumnDescriptor is CostAnomalyTable's column descriptors.
+var CostAnomalyColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "dimension", NamedC: "dimension", Type: enumor.String},
+	{Column: "dimension_value", NamedC: "dimension_value", Type: enumor.String},
+	{Column: "bill_date", NamedC: "bill_date", Type: enumor.String},
+	{Column: "currency", NamedC: "currency", Type: enumor.String},
+	{Column: "actual_cost", NamedC: "actual_cost", Type: enumor.Numeric},
+	{Column: "expected_cost", NamedC: "expected_cost", Type: enumor.Numeric},
+	{Column: "ratio", NamedC: "ratio", Type: enumor.Numeric},
+	{Column: "score", NamedC: "score", Type: enumor.Numeric},
+	{Column: "top_items", NamedC: "top_items", Type: enumor.Json},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// CostAnomalyTable cost_anomaly表
+type CostAnomalyTable struct {
+	// ID 自增ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor" validate:"lte=16"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" json:"account_id" validate:"lte=64"`
+	// Dimension 检测的费用维度(account:账号总费用 service:云产品费用 region:地域费用)
+	Dimension enumor.CostAnomalyDimension `db:"dimension" json:"dimension" validate:"lte=16"`
+	// DimensionValue 维度的值，如云产品代码、地域，账号维度时为空
+	DimensionValue string `db:"dimension_value" json:"dimension_value" validate:"lte=255"`
+	// BillDate 账单日期，格式为yyyy-mm-dd
+	BillDate string `db:"bill_date" json:"bill_date" validate:"lte=10"`
+	// Currency 币种
+	Currency string `db:"currency" json:"currency" validate:"lte=16"`
+	// ActualCost 当天实际费用
+	ActualCost float64 `db:"actual_cost" json:"actual_cost"`
+	// ExpectedCost 根据历史基线计算的预期费用
+	ExpectedCost float64 `db:"expected_cost" json:"expected_cost"`
+	// Ratio 实际费用与预期费用的比值
+	Ratio float64 `db:"ratio" json:"ratio"`
+	// Score 实际费用偏离基线的鲁棒z分数
+	Score float64 `db:"score" json:"score"`
+	// TopItems 当天费用最高的账单明细
+	TopItems types.JsonField `db:"top_items" json:"top_items"`
+	// Status 处理状态(open:待处理 acknowledged:已确认 resolved:已解决)
+	Status enumor.CostAnomalyStatus `db:"status" json:"status" validate:"lte=32"`
+	// Memo 处理备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"max=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"max=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return cost anomaly table name.
+func (c CostAnomalyTable) TableName() table.Name {
+	return table.CostAnomalyTable
+}
+
+// InsertValidate validate cost anomaly table on insert.
+func (c CostAnomalyTable) InsertValidate() error {
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	if len(c.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if err := c.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	if len(c.AccountID) == 0 {
+		return errors.New("account_id can not be empty")
+	}
+
+	if err := c.Dimension.Validate(); err != nil {
+		return err
+	}
+
+	if len(c.BillDate) == 0 {
+		return errors.New("bill_date can not be empty")
+	}
+
+	if err := c.Status.Validate(); err != nil {
+		return err
+	}
+
+	if len(c.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate cost anomaly table on update.
+func (c CostAnomalyTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	if len(c.Status) == 0 && c.Memo == nil {
+		return errors.New("status or memo must be set")
+	}
+
+	if len(c.Status) != 0 {
+		if err := c.Status.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(c.Vendor) != 0 || len(c.AccountID) != 0 || len(c.Dimension) != 0 || len(c.DimensionValue) != 0 ||
+		len(c.BillDate) != 0 {
+		return errors.New("only status and memo can be updated")
+	}
+
+	if len(c.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(c.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
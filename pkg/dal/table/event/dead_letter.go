@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// DeadLetterColumns defines all the event dead letter table's columns.
+var DeadLetterColumns = utils.MergeColumns(nil, DeadLetterColumnDescriptor)
+
+// DeadLetterColumnDescriptor is DeadLetterTable's column descriptors.
+var DeadLetterColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "subscription_id", NamedC: "subscription_id", Type: enumor.String},
+	{Column: "event_id", NamedC: "event_id", Type: enumor.Numeric},
+	{Column: "event_type", NamedC: "event_type", Type: enumor.String},
+	{Column: "attempts", NamedC: "attempts", Type: enumor.Numeric},
+	{Column: "last_error", NamedC: "last_error", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// DeadLetterTable event_dead_letter表，重试多次后仍投递失败的事件，可以在排查问题后重新投递
+type DeadLetterTable struct {
+	// ID 自增ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// SubscriptionID 订阅ID
+	SubscriptionID string `db:"subscription_id" json:"subscription_id" validate:"lte=64"`
+	// EventID 事件ID
+	EventID uint64 `db:"event_id" json:"event_id"`
+	// EventType 事件类型
+	EventType string `db:"event_type" json:"event_type" validate:"lte=128"`
+	// Attempts 投递次数
+	Attempts uint `db:"attempts" json:"attempts"`
+	// LastError 最后一次投递失败的原因
+	LastError string `db:"last_error" json:"last_error" validate:"lte=1024"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+}
+
+// TableName return event dead letter table name.
+func (d DeadLetterTable) TableName() table.Name {
+	return table.EventDeadLetterTable
+}
+
+// InsertValidate validate event dead letter table on insert.
+func (d DeadLetterTable) InsertValidate() error {
+	if err := validator.Validate.Struct(d); err != nil {
+		return err
+	}
+
+	if len(d.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(d.SubscriptionID) == 0 {
+		return errors.New("subscription_id can not be empty")
+	}
+
+	if d.EventID == 0 {
+		return errors.New("event_id can not be empty")
+	}
+
+	if len(d.EventType) == 0 {
+		return errors.New("event_type can not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event defines the tables of resource lifecycle events and their webhook subscriptions.
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// EventColumns defines all the event table's columns.
+var EventColumns = utils.MergeColumns(utils.InsertWithoutPrimaryID, EventColumnDescriptor)
+
+// EventColumnDescriptor is EventTable's column descriptors.
+var EventColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.Numeric},
+	{Column: "type", NamedC: "type", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "action", NamedC: "action", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "cloud_res_id", NamedC: "cloud_res_id", Type: enumor.String},
+	{Column: "res_name", NamedC: "res_name", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "operator", NamedC: "operator", Type: enumor.String},
+	{Column: "rid", NamedC: "rid", Type: enumor.String},
+	{Column: "app_code", NamedC: "app_code", Type: enumor.String},
+	{Column: "detail", NamedC: "detail", Type: enumor.Json},
+	{Column: "seq", NamedC: "seq", Type: enumor.Numeric},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// EventTable event表，资源生命周期事件，与审计或业务数据在同一事务中写入。自增ID在并发事务中可能乱序提交，
+// 所以由定序器在事件提交后按提交顺序分配Seq，Seq作为事件订阅及重放的游标
+type EventTable struct {
+	// ID 自增ID
+	ID uint64 `db:"id" json:"id"`
+	// Type 事件类型，格式为 {res_type}.{action}，如 cvm.create、application.pass
+	Type string `db:"type" json:"type" validate:"lte=128"`
+	// ResType 资源类型
+	ResType string `db:"res_type" json:"res_type" validate:"lte=50"`
+	// Action 资源动作或状态
+	Action string `db:"action" json:"action" validate:"lte=32"`
+	// ResID 资源ID
+	ResID string `db:"res_id" json:"res_id" validate:"lte=64"`
+	// CloudResID 云资源ID
+	CloudResID string `db:"cloud_res_id" json:"cloud_res_id" validate:"lte=255"`
+	// ResName 资源名称
+	ResName string `db:"res_name" json:"res_name" validate:"lte=255"`
+	// BkBizID 业务ID
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor" validate:"lte=16"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" json:"account_id" validate:"lte=64"`
+	// Operator 操作者
+	Operator string `db:"operator" json:"operator" validate:"lte=64"`
+	// Rid 请求ID
+	Rid string `db:"rid" json:"rid" validate:"lte=64"`
+	// AppCode 应用代码
+	AppCode string `db:"app_code" json:"app_code" validate:"lte=64"`
+	// Detail 事件详情
+	Detail types.JsonField `db:"detail" json:"detail"`
+	// Seq 提交序号，事件游标，未定序的事件为空
+	Seq *uint64 `db:"seq" json:"seq"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+}
+
+// TableName return event table name.
+func (e EventTable) TableName() table.Name {
+	return table.EventTable
+}
+
+// InsertValidate validate event table on insert.
+func (e EventTable) InsertValidate() error {
+	if err := validator.Validate.Struct(e); err != nil {
+		return err
+	}
+
+	if e.ID != 0 {
+		return errors.New("id can not set")
+	}
+
+	if e.Seq != nil {
+		return errors.New("seq can not set")
+	}
+
+	if len(e.ResType) == 0 {
+		return errors.New("res_type can not be empty")
+	}
+
+	if len(e.Action) == 0 {
+		return errors.New("action can not be empty")
+	}
+
+	if e.Type != EventType(e.ResType, e.Action) {
+		return fmt.Errorf("type should be %s", EventType(e.ResType, e.Action))
+	}
+
+	if len(e.Detail) == 0 {
+		return errors.New("detail can not be empty")
+	}
+
+	return nil
+}
+
+// EventType returns the event type of the resource type and action.
+func EventType(resType, action string) string {
+	return resType + "." + action
+}
+
+// NewEventFromAudit convert the audit to the resource lifecycle event.
+func NewEventFromAudit(one *audit.AuditTable) (*EventTable, error) {
+	detail := types.JsonField("{}")
+	if one.Detail != nil {
+		js, err := json.Marshal(one.Detail)
+		if err != nil {
+			return nil, fmt.Errorf("marshal audit detail failed, err: %v", err)
+		}
+		detail = types.JsonField(js)
+	}
+
+	return &EventTable{
+		Type:       EventType(string(one.ResType), string(one.Action)),
+		ResType:    string(one.ResType),
+		Action:     string(one.Action),
+		ResID:      one.ResID,
+		CloudResID: one.CloudResID,
+		ResName:    one.ResName,
+		BkBizID:    one.BkBizID,
+		Vendor:     one.Vendor,
+		AccountID:  one.AccountID,
+		Operator:   one.Operator,
+		Rid:        one.Rid,
+		AppCode:    one.AppCode,
+		Detail:     detail,
+	}, nil
+}
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SubscriptionColumns defines all the event subscription table's columns.
+var SubscriptionColumns = utils.MergeColumns(nil, SubscriptionColumnDescriptor)
+
+// SubscriptionColumnDescriptor is SubscriptionTable's column descriptors.
+var SubscriptionColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "endpoint", NamedC: "endpoint", Type: enumor.String},
+	{Column: "secret", NamedC: "secret", Type: enumor.String},
+	{Column: "filter", NamedC: "filter", Type: enumor.Json},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "last_event_id", NamedC: "last_event_id", Type: enumor.Numeric},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SubscriptionTable event_subscription表，事件的webhook订阅，匹配过滤条件的事件按顺序投递到订阅的回调地址
+type SubscriptionTable struct {
+	// ID 自增ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Name 订阅名称，全局唯一
+	Name string `db:"name" json:"name" validate:"lte=64"`
+	// Endpoint 接收事件的回调地址
+	Endpoint string `db:"endpoint" json:"endpoint" validate:"lte=255"`
+	// Secret 加密后的签名密钥，用于对投递的事件进行签名
+	Secret string `db:"secret" json:"secret" validate:"lte=255"`
+	// Filter 事件过滤条件
+	Filter types.JsonField `db:"filter" json:"filter"`
+	// Enabled 是否启用
+	Enabled *bool `db:"enabled" json:"enabled"`
+	// LastEventID 已投递的最后一个事件的序号，即订阅的游标，重置后可以重放该游标之后的事件
+	LastEventID *uint64 `db:"last_event_id" json:"last_event_id"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"max=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"max=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return event subscription table name.
+func (s SubscriptionTable) TableName() table.Name {
+	return table.EventSubscriptionTable
+}
+
+// InsertValidate validate event subscription table on insert.
+func (s SubscriptionTable) InsertValidate() error {
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	if len(s.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(s.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if len(s.Endpoint) == 0 {
+		return errors.New("endpoint can not be empty")
+	}
+
+	if len(s.Secret) == 0 {
+		return errors.New("secret can not be empty")
+	}
+
+	if len(s.Filter) == 0 {
+		return errors.New("filter can not be empty")
+	}
+
+	if s.Enabled == nil {
+		return errors.New("enabled can not be empty")
+	}
+
+	if s.LastEventID == nil {
+		return errors.New("last_event_id can not be empty")
+	}
+
+	if len(s.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate event subscription table on update.
+func (s SubscriptionTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	if len(s.Name) != 0 {
+		return errors.New("name can not update")
+	}
+
+	if len(s.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(s.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
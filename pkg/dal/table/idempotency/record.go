@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package idempotency defines the table of the idempotency keys of mutating requests.
+package idempotency
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// RecordColumns defines all the idempotency record table's columns.
+var RecordColumns = utils.MergeColumns(nil, RecordColumnDescriptor)
+
+// RecordColumnDescriptor is RecordTable's column descriptors.
+var RecordColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "owner", NamedC: "owner", Type: enumor.String},
+	{Column: "idempotency_key", NamedC: "idempotency_key", Type: enumor.String},
+	{Column: "request_hash", NamedC: "request_hash", Type: enumor.String},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "response", NamedC: "response", Type: enumor.String},
+	{Column: "expired_at", NamedC: "expired_at", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// RecordTable idempotency_record表，存储携带幂等键的写请求的请求摘要及响应，用于重放请求时返回已保存的结果
+type RecordTable struct {
+	// ID 自增ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Owner 幂等键所属的调用方，由应用及用户组成，不同调用方的幂等键互不影响
+	Owner string `db:"owner" json:"owner" validate:"lte=128"`
+	// IdempotencyKey 幂等键
+	IdempotencyKey string `db:"idempotency_key" json:"idempotency_key" validate:"lte=255"`
+	// RequestHash 请求方法、路径及请求体的sha256哈希值
+	RequestHash string `db:"request_hash" json:"request_hash" validate:"lte=64"`
+	// State 请求状态(processing:处理中 completed:已完成)
+	State enumor.IdempotencyState `db:"state" json:"state" validate:"lte=32"`
+	// Response 请求完成后的响应数据
+	Response *string `db:"response" json:"response"`
+	// ExpiredAt 过期时间，处理中的记录超时后或已完成的记录超过保留时间后过期，过期后幂等键可被重新使用
+	ExpiredAt string `db:"expired_at" json:"expired_at" validate:"lte=64"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"max=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return idempotency record table name.
+func (t RecordTable) TableName() table.Name {
+	return table.IdempotencyRecordTable
+}
+
+// InsertValidate validate idempotency record table on insert.
+func (t RecordTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(t.Owner) == 0 {
+		return errors.New("owner can not be empty")
+	}
+
+	if len(t.IdempotencyKey) == 0 {
+		return errors.New("idempotency_key can not be empty")
+	}
+
+	if len(t.RequestHash) == 0 {
+		return errors.New("request_hash can not be empty")
+	}
+
+	if err := t.State.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.ExpiredAt) == 0 {
+		return errors.New("expired_at can not be empty")
+	}
+
+	return nil
+}
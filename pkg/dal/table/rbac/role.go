@@ -0,0 +1,124 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac defines the tables of the built-in rbac authorizer.
+package rbac
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// RoleColumns defines all the rbac role table's columns.
+var RoleColumns = utils.MergeColumns(nil, RoleColumnDescriptor)
+
+// RoleColumnDescriptor is RoleTable's column descriptors.
+var RoleColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "actions", NamedC: "actions", Type: enumor.Json},
+	{Column: "builtin", NamedC: "builtin", Type: enumor.Boolean},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// RoleTable rbac_role表
+type RoleTable struct {
+	// ID 自增ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Name 角色名称
+	Name string `db:"name" json:"name" validate:"lte=64"`
+	// Actions 角色包含的操作，操作ID与蓝鲸权限中心注册的操作ID一致
+	Actions types.StringArray `db:"actions" json:"actions"`
+	// Builtin 是否为内置角色，内置角色由权限模型导出生成，不允许修改和删除
+	Builtin *bool `db:"builtin" json:"builtin"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"max=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"max=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return rbac role table name.
+func (r RoleTable) TableName() table.Name {
+	return table.RbacRoleTable
+}
+
+// InsertValidate validate rbac role table on insert.
+func (r RoleTable) InsertValidate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if len(r.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(r.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if len(r.Actions) == 0 {
+		return errors.New("actions can not be empty")
+	}
+
+	if r.Builtin == nil {
+		return errors.New("builtin can not be empty")
+	}
+
+	if len(r.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate rbac role table on update.
+func (r RoleTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if r.Builtin != nil {
+		return errors.New("builtin can not update")
+	}
+
+	if len(r.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(r.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// RoleBindingColumns defines all the rbac role binding table's columns.
+var RoleBindingColumns = utils.MergeColumns(nil, RoleBindingColumnDescriptor)
+
+// RoleBindingColumnDescriptor is RoleBindingTable's column descriptors.
+var RoleBindingColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "role_id", NamedC: "role_id", Type: enumor.String},
+	{Column: "subject", NamedC: "subject", Type: enumor.String},
+	{Column: "scope_type", NamedC: "scope_type", Type: enumor.String},
+	{Column: "scope_ids", NamedC: "scope_ids", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// RoleBindingTable rbac_role_binding表，将角色在指定范围内授予用户
+type RoleBindingTable struct {
+	// ID 自增ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// RoleID 角色ID
+	RoleID string `db:"role_id" json:"role_id" validate:"lte=64"`
+	// Subject 被授权的用户名
+	Subject string `db:"subject" json:"subject" validate:"lte=64"`
+	// ScopeType 授权范围类型(all:全部资源 biz:指定业务 account:指定账号)
+	ScopeType enumor.RbacScopeType `db:"scope_type" json:"scope_type" validate:"lte=16"`
+	// ScopeIDs 授权范围的业务ID或账号ID列表，授权范围为全部资源时为空
+	ScopeIDs types.StringArray `db:"scope_ids" json:"scope_ids"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"max=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+}
+
+// TableName return rbac role binding table name.
+func (r RoleBindingTable) TableName() table.Name {
+	return table.RbacRoleBindingTable
+}
+
+// InsertValidate validate rbac role binding table on insert.
+func (r RoleBindingTable) InsertValidate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if len(r.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(r.RoleID) == 0 {
+		return errors.New("role_id can not be empty")
+	}
+
+	if len(r.Subject) == 0 {
+		return errors.New("subject can not be empty")
+	}
+
+	if err := r.ScopeType.Validate(); err != nil {
+		return err
+	}
+
+	if r.ScopeType != enumor.AllRbacScope && len(r.ScopeIDs) == 0 {
+		return errors.New("scope_ids can not be empty when scope type is not all")
+	}
+
+	if len(r.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
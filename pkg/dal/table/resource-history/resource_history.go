@@ -0,0 +1,160 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcehistory defines the resource change history table.
+package resourcehistory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	tablecloud "hcm/pkg/dal/table/cloud"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	tabledisk "hcm/pkg/dal/table/cloud/disk"
+	tableeip "hcm/pkg/dal/table/cloud/eip"
+	tableni "hcm/pkg/dal/table/cloud/network-interface"
+	routetable "hcm/pkg/dal/table/cloud/route-table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ResourceHistoryColumns defines all the resource history table's columns.
+var ResourceHistoryColumns = utils.MergeColumns(nil, ResourceHistoryColumnDescriptor)
+
+// ResourceHistoryColumnDescriptor is ResourceHistoryTable's column descriptors.
+var ResourceHistoryColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.Numeric},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "action", NamedC: "action", Type: enumor.String},
+	{Column: "snapshot", NamedC: "snapshot", Type: enumor.Json},
+	{Column: "operator", NamedC: "operator", Type: enumor.String},
+	{Column: "rid", NamedC: "rid", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// ResourceHistoryTable resource_history表，记录资源每次变更前的完整快照，由DAO的写操作在同一事务中写入，
+// 相邻两条快照（最新一条与资源当前状态）之间的差异即为该次变更的字段级差异
+type ResourceHistoryTable struct {
+	// ID 自增ID，同一资源的变更按ID排序
+	ID uint64 `db:"id" json:"id"`
+	// ResType 资源类型，即资源的表名
+	ResType table.Name `db:"res_type" json:"res_type"`
+	// ResID 资源ID
+	ResID string `db:"res_id" json:"res_id"`
+	// AccountID 资源所属账号ID，用于变更历史的鉴权
+	AccountID string `db:"account_id" json:"account_id"`
+	// Action 变更动作
+	Action enumor.ChangeFeedAction `db:"action" json:"action"`
+	// Snapshot 变更前的资源快照，新增的资源变更前不存在，为空
+	Snapshot *types.JsonField `db:"snapshot" json:"snapshot"`
+	// Operator 变更操作人，同步产生的变更为同步的用户
+	Operator string `db:"operator" json:"operator"`
+	// Rid 变更请求的ID
+	Rid string `db:"rid" json:"rid"`
+	// CreatedAt 变更时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+}
+
+// TableName return resource history table name.
+func (r ResourceHistoryTable) TableName() table.Name {
+	return table.ResourceHistoryTable
+}
+
+// resColumns is the resource types that record change history, and the columns of their snapshot. account is not
+// included because its extension holds the secret of the cloud account.
+var resColumns = map[table.Name]*utils.Columns{
+	table.CvmTable:              tablecvm.TableColumns,
+	table.VpcTable:              tablecloud.VpcColumns,
+	table.SubnetTable:           tablecloud.SubnetColumns,
+	table.DiskTable:             tabledisk.DiskColumns,
+	table.EipTable:              tableeip.EipColumns,
+	table.SecurityGroupTable:    tablecloud.SecurityGroupColumns,
+	table.GcpFirewallRuleTable:  tablecloud.GcpFirewallRuleColumns,
+	table.RouteTableTable:       routetable.RouteTableColumns,
+	table.NetworkInterfaceTable: tableni.NetworkInterfaceColumns,
+}
+
+// resRelExprs is the relations that are folded into the snapshot of the resource type, so that binding or unbinding
+// a relation is recorded as a change of the resource, e.g. the security groups bound to the cvm. the outer table is
+// referenced by its table name, so the snapshot must be selected from the resource table without an alias.
+var resRelExprs = map[table.Name]map[string]string{
+	table.CvmTable: {
+		"security_group_ids": fmt.Sprintf("CAST(CONCAT('[', IFNULL((SELECT GROUP_CONCAT(JSON_QUOTE(rel.security_group_id) "+
+			"ORDER BY rel.security_group_id) FROM %s AS rel WHERE rel.cvm_id = %s.id), ''), ']') AS JSON)",
+			table.SecurityGroupCvmTable, table.CvmTable),
+	},
+}
+
+// IsSupported returns if the resource type records change history.
+func IsSupported(resType table.Name) bool {
+	_, exists := resColumns[resType]
+	return exists
+}
+
+// ValidateResType validate if the resource type records change history.
+func ValidateResType(resType table.Name) error {
+	if !IsSupported(resType) {
+		return fmt.Errorf("resource type %s does not support change history", resType)
+	}
+
+	return nil
+}
+
+// SnapshotExpr returns the sql expression that builds the json snapshot of a row of the resource type, columns are
+// sorted so that the expression is stable, time columns are formatted without the fraction, and the relations of the
+// resource type are appended as extra keys.
+func SnapshotExpr(resType table.Name) (string, error) {
+	columns, exists := resColumns[resType]
+	if !exists {
+		return "", fmt.Errorf("resource type %s does not support change history", resType)
+	}
+
+	columnTypes := columns.ColumnTypes()
+	relExprs := resRelExprs[resType]
+	names := make([]string, 0, len(columnTypes)+len(relExprs))
+	for name := range columnTypes {
+		names = append(names, name)
+	}
+	for name := range relExprs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		if expr, exists := relExprs[name]; exists {
+			pairs = append(pairs, fmt.Sprintf("'%s', %s", name, expr))
+			continue
+		}
+
+		if columnTypes[name] == enumor.Time {
+			// %T is used instead of %H:%i:%s, because the colon is the prefix of the named arguments.
+			pairs = append(pairs, fmt.Sprintf("'%s', DATE_FORMAT(`%s`, '%%Y-%%m-%%d %%T')", name, name))
+			continue
+		}
+		pairs = append(pairs, fmt.Sprintf("'%s', `%s`", name, name))
+	}
+
+	return fmt.Sprintf("JSON_OBJECT(%s)", strings.Join(pairs, ", ")), nil
+}
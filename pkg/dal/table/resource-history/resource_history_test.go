@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resourcehistory
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/dal/table"
+)
+
+func TestSnapshotExpr(t *testing.T) {
+	expr, err := SnapshotExpr(table.CvmTable)
+	if err != nil {
+		t.Errorf("get cvm snapshot expr failed, err: %v", err)
+		return
+	}
+
+	if !strings.HasPrefix(expr, "JSON_OBJECT('account_id', `account_id`, ") {
+		t.Errorf("cvm snapshot expr should start with the sorted account_id column, but got %s", expr)
+		return
+	}
+
+	if !strings.Contains(expr, "'created_at', DATE_FORMAT(`created_at`, '%Y-%m-%d %T')") {
+		t.Errorf("cvm snapshot expr should format the created_at column, but got %s", expr)
+		return
+	}
+
+	if !strings.Contains(expr, "'security_group_ids', CAST(") {
+		t.Errorf("cvm snapshot expr should contain the bound security group ids, but got %s", expr)
+		return
+	}
+
+	if _, err = SnapshotExpr(table.AccountTable); err == nil {
+		t.Errorf("account table should not support change history")
+		return
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines the resource tag table.
+package resourcetag
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ResourceTagColumns defines all the resource tag table's columns.
+var ResourceTagColumns = utils.MergeColumns(nil, ResourceTagColumnDescriptor)
+
+// ResourceTagColumnDescriptor is ResourceTagTable's column descriptors.
+var ResourceTagColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "tag_key", NamedC: "tag_key", Type: enumor.String},
+	{Column: "tag_value", NamedC: "tag_value", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ResourceTagTable resource_tag表，各云厂商资源的标签统一存储为键值对，由资源同步写入，用于按标签过滤资源
+type ResourceTagTable struct {
+	// ID 标签ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// ResType 资源类型，即资源的表名
+	ResType table.Name `db:"res_type" json:"res_type" validate:"lte=64"`
+	// ResID 资源ID
+	ResID string `db:"res_id" json:"res_id" validate:"lte=64"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor" validate:"lte=16"`
+	// AccountID 资源所属账号ID
+	AccountID string `db:"account_id" json:"account_id" validate:"lte=64"`
+	// TagKey 标签键
+	TagKey string `db:"tag_key" json:"tag_key" validate:"lte=255"`
+	// TagValue 标签值
+	TagValue string `db:"tag_value" json:"tag_value" validate:"lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"max=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"max=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return resource tag table name.
+func (t ResourceTagTable) TableName() table.Name {
+	return table.ResourceTagTable
+}
+
+// InsertValidate validate resource tag table on insert.
+func (t ResourceTagTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if err := ValidateResType(t.ResType); err != nil {
+		return err
+	}
+
+	if len(t.ResID) == 0 {
+		return errors.New("res_id can not be empty")
+	}
+
+	if err := t.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id can not be empty")
+	}
+
+	if len(t.TagKey) == 0 {
+		return errors.New("tag_key can not be empty")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// supportedResTypes is the resource types that support resource tag.
+var supportedResTypes = map[table.Name]struct{}{
+	table.CvmTable:              {},
+	table.VpcTable:              {},
+	table.SubnetTable:           {},
+	table.DiskTable:             {},
+	table.EipTable:              {},
+	table.SecurityGroupTable:    {},
+	table.RouteTableTable:       {},
+	table.NetworkInterfaceTable: {},
+}
+
+// ValidateResType validate if the resource type supports resource tag.
+func ValidateResType(resType table.Name) error {
+	if _, exists := supportedResTypes[resType]; !exists {
+		return fmt.Errorf("resource type %s does not support resource tag", resType)
+	}
+
+	return nil
+}
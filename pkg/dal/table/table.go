@@ -120,6 +120,40 @@ const (
 	NetworkInterfaceCvmRelTable Name = "network_interface_cvm_rel"
 	// AccountBillConfigTable is account bill config table's name.
 	AccountBillConfigTable Name = "account_bill_config"
+	// CostAnomalyTable is cost anomaly table's name.
+	CostAnomalyTable Name = "cost_anomaly"
+	// AccountHealthTable is account health table's name.
+	AccountHealthTable Name = "account_health"
+	// RbacRoleTable is built-in rbac role table's name.
+	RbacRoleTable Name = "rbac_role"
+	// RbacRoleBindingTable is built-in rbac role binding table's name.
+	RbacRoleBindingTable Name = "rbac_role_binding"
+	// ApiTokenTable is api token table's name.
+	ApiTokenTable Name = "api_token"
+	// ServiceAccountTable is service account table's name.
+	ServiceAccountTable Name = "service_account"
+	// IdempotencyRecordTable is idempotency record table's name.
+	IdempotencyRecordTable Name = "idempotency_record"
+	// EventTable is resource lifecycle event table's name.
+	EventTable Name = "event"
+	// EventSubscriptionTable is event subscription table's name.
+	EventSubscriptionTable Name = "event_subscription"
+	// EventDeadLetterTable is event dead letter table's name.
+	EventDeadLetterTable Name = "event_dead_letter"
+	// ChangeFeedTable is resource change feed table's name.
+	ChangeFeedTable Name = "change_feed"
+	// ChangeFeedSequenceTable is resource change feed sequence counter table's name.
+	ChangeFeedSequenceTable Name = "change_feed_sequence"
+	// ResourceHistoryTable is resource change history table's name.
+	ResourceHistoryTable Name = "resource_history"
+	// ResourceTagTable is resource tag table's name.
+	ResourceTagTable Name = "resource_tag"
+	// BizAssignRuleTable is biz assign rule table's name.
+	BizAssignRuleTable Name = "biz_assign_rule"
+	// TagPolicyTable is tag policy table's name.
+	TagPolicyTable Name = "tag_policy"
+	// TagPolicyViolationTable is tag policy violation table's name.
+	TagPolicyViolationTable Name = "tag_policy_violation"
 
 	// TODO: 之后考虑非表id的id_generator如何更优雅的使用
 	// RecycleRecordTableTaskID is recycle record table's task id.
@@ -184,6 +218,23 @@ var TableMap = map[Name]struct{}{
 	DiskCvmRelTableName:          {},
 	EipCvmRelTableName:           {},
 	AccountBillConfigTable:       {},
+	CostAnomalyTable:             {},
+	AccountHealthTable:           {},
+	RbacRoleTable:                {},
+	RbacRoleBindingTable:         {},
+	ApiTokenTable:                {},
+	ServiceAccountTable:          {},
+	IdempotencyRecordTable:       {},
+	EventTable:                   {},
+	EventSubscriptionTable:       {},
+	EventDeadLetterTable:         {},
+	ChangeFeedTable:              {},
+	ChangeFeedSequenceTable:      {},
+	ResourceHistoryTable:         {},
+	ResourceTagTable:             {},
+	BizAssignRuleTable:           {},
+	TagPolicyTable:               {},
+	TagPolicyViolationTable:      {},
 
 	// TODO: 临时方案
 	RecycleRecordTableTaskID: {},
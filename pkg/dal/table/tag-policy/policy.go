@@ -0,0 +1,152 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tagpolicy defines the tag policy and tag policy violation table.
+package tagpolicy
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// TagPolicyColumns defines all the tag policy table's columns.
+var TagPolicyColumns = utils.MergeColumns(nil, TagPolicyColumnDescriptor)
+
+// TagPolicyColumnDescriptor is TagPolicyTable's column descriptors.
+var TagPolicyColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "res_types", NamedC: "res_types", Type: enumor.Json},
+	{Column: "rules", NamedC: "rules", Type: enumor.Json},
+	{Column: "auto_remediate", NamedC: "auto_remediate", Type: enumor.Boolean},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// TagPolicyTable tag_policy表，约束账号或业务下资源的标签，资源同步后评估并记录违规资源
+type TagPolicyTable struct {
+	// ID 策略ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Name 策略名称
+	Name string `db:"name" json:"name" validate:"lte=64"`
+	// AccountID 策略作用的账号ID，与业务ID二选一
+	AccountID string `db:"account_id" json:"account_id" validate:"lte=64"`
+	// BkBizID 策略作用的业务ID，与账号ID二选一，为0时表示不按业务生效
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// ResTypes 策略作用的资源类型
+	ResTypes types.StringArray `db:"res_types" json:"res_types"`
+	// Rules 标签规则
+	Rules types.JsonField `db:"rules" json:"rules"`
+	// AutoRemediate 是否自动为缺失的必填标签设置默认值
+	AutoRemediate *bool `db:"auto_remediate" json:"auto_remediate"`
+	// Enabled 是否启用，资源同步后只评估启用的策略
+	Enabled *bool `db:"enabled" json:"enabled"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"max=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"max=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return tag policy table name.
+func (p TagPolicyTable) TableName() table.Name {
+	return table.TagPolicyTable
+}
+
+// InsertValidate validate tag policy table on insert.
+func (p TagPolicyTable) InsertValidate() error {
+	if err := validator.Validate.Struct(p); err != nil {
+		return err
+	}
+
+	if len(p.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(p.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if len(p.AccountID) == 0 && p.BkBizID <= 0 {
+		return errors.New("one of account_id and bk_biz_id is required")
+	}
+
+	if len(p.ResTypes) == 0 {
+		return errors.New("res_types can not be empty")
+	}
+
+	if len(p.Rules) == 0 {
+		return errors.New("rules can not be empty")
+	}
+
+	if p.AutoRemediate == nil {
+		return errors.New("auto_remediate can not be empty")
+	}
+
+	if p.Enabled == nil {
+		return errors.New("enabled can not be empty")
+	}
+
+	if len(p.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate tag policy table on update.
+func (p TagPolicyTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(p); err != nil {
+		return err
+	}
+
+	if len(p.AccountID) != 0 {
+		return errors.New("account_id can not update")
+	}
+
+	if p.BkBizID != 0 {
+		return errors.New("bk_biz_id can not update")
+	}
+
+	if len(p.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(p.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tagpolicy
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ViolationColumns defines all the tag policy violation table's columns.
+var ViolationColumns = utils.MergeColumns(nil, ViolationColumnDescriptor)
+
+// ViolationColumnDescriptor is ViolationTable's column descriptors.
+var ViolationColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "policy_id", NamedC: "policy_id", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "tag_key", NamedC: "tag_key", Type: enumor.String},
+	{Column: "reason", NamedC: "reason", Type: enumor.String},
+	{Column: "detail", NamedC: "detail", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ViolationTable tag_policy_violation表，资源违反标签策略的记录，每次评估账号资源后整体替换该账号的记录
+type ViolationTable struct {
+	// ID 违规记录ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// PolicyID 违反的标签策略ID
+	PolicyID string `db:"policy_id" json:"policy_id" validate:"lte=64"`
+	// ResType 资源类型
+	ResType enumor.CloudResourceType `db:"res_type" json:"res_type" validate:"lte=64"`
+	// ResID 资源ID
+	ResID string `db:"res_id" json:"res_id" validate:"lte=64"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor" validate:"lte=16"`
+	// AccountID 资源所属账号ID
+	AccountID string `db:"account_id" json:"account_id" validate:"lte=64"`
+	// BkBizID 资源所属业务ID
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// TagKey 违反规则的标签键
+	TagKey string `db:"tag_key" json:"tag_key" validate:"lte=255"`
+	// Reason 违规原因
+	Reason enumor.TagViolationReason `db:"reason" json:"reason" validate:"lte=32"`
+	// Detail 违规详情
+	Detail string `db:"detail" json:"detail" validate:"lte=1024"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"max=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"max=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return tag policy violation table name.
+func (v ViolationTable) TableName() table.Name {
+	return table.TagPolicyViolationTable
+}
+
+// InsertValidate validate tag policy violation table on insert.
+func (v ViolationTable) InsertValidate() error {
+	if err := validator.Validate.Struct(v); err != nil {
+		return err
+	}
+
+	if len(v.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(v.PolicyID) == 0 {
+		return errors.New("policy_id can not be empty")
+	}
+
+	if len(v.ResType) == 0 {
+		return errors.New("res_type can not be empty")
+	}
+
+	if len(v.ResID) == 0 {
+		return errors.New("res_id can not be empty")
+	}
+
+	if len(v.AccountID) == 0 {
+		return errors.New("account_id can not be empty")
+	}
+
+	if len(v.Reason) == 0 {
+		return errors.New("reason can not be empty")
+	}
+
+	if len(v.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package token defines the tables of hcm issued api tokens and service accounts.
+package token
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ApiTokenColumns defines all the api token table's columns.
+var ApiTokenColumns = utils.MergeColumns(nil, ApiTokenColumnDescriptor)
+
+// ApiTokenColumnDescriptor is ApiTokenTable's column descriptors.
+var ApiTokenColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "subject_type", NamedC: "subject_type", Type: enumor.String},
+	{Column: "subject", NamedC: "subject", Type: enumor.String},
+	{Column: "token_hash", NamedC: "token_hash", Type: enumor.String},
+	{Column: "token_prefix", NamedC: "token_prefix", Type: enumor.String},
+	{Column: "scopes", NamedC: "scopes", Type: enumor.Json},
+	{Column: "expired_at", NamedC: "expired_at", Type: enumor.String},
+	{Column: "revoked", NamedC: "revoked", Type: enumor.Boolean},
+	{Column: "last_used_at", NamedC: "last_used_at", Type: enumor.String},
+	{Column: "last_used_ip", NamedC: "last_used_ip", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ApiTokenTable api_token表，存储个人及服务账号的API令牌，令牌明文仅在创建时返回，只存储其哈希值
+type ApiTokenTable struct {
+	// ID 自增ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Name 令牌名称
+	Name string `db:"name" json:"name" validate:"lte=64"`
+	// SubjectType 令牌所属主体类型(user:个人 service_account:服务账号)
+	SubjectType enumor.ApiTokenSubjectType `db:"subject_type" json:"subject_type" validate:"lte=32"`
+	// Subject 令牌所属主体，个人令牌为用户名，服务账号令牌为服务账号名称，使用令牌访问时作为请求用户
+	Subject string `db:"subject" json:"subject" validate:"lte=64"`
+	// TokenHash 令牌的sha256哈希值
+	TokenHash string `db:"token_hash" json:"token_hash" validate:"lte=64"`
+	// TokenPrefix 令牌前缀，用于展示和辨识令牌
+	TokenPrefix string `db:"token_prefix" json:"token_prefix" validate:"lte=16"`
+	// Scopes 令牌可访问的API路径前缀，"*"表示可以访问所有API
+	Scopes types.StringArray `db:"scopes" json:"scopes"`
+	// ExpiredAt 过期时间，为空表示永不过期
+	ExpiredAt string `db:"expired_at" json:"expired_at" validate:"lte=64"`
+	// Revoked 是否已吊销
+	Revoked *bool `db:"revoked" json:"revoked"`
+	// LastUsedAt 最近使用时间
+	LastUsedAt string `db:"last_used_at" json:"last_used_at" validate:"lte=64"`
+	// LastUsedIP 最近使用的来源地址
+	LastUsedIP string `db:"last_used_ip" json:"last_used_ip" validate:"lte=64"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"max=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"max=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return api token table name.
+func (t ApiTokenTable) TableName() table.Name {
+	return table.ApiTokenTable
+}
+
+// InsertValidate validate api token table on insert.
+func (t ApiTokenTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if err := t.SubjectType.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Subject) == 0 {
+		return errors.New("subject can not be empty")
+	}
+
+	if len(t.TokenHash) == 0 {
+		return errors.New("token_hash can not be empty")
+	}
+
+	if len(t.Scopes) == 0 {
+		return errors.New("scopes can not be empty")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate api token table on update.
+func (t ApiTokenTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.SubjectType) != 0 || len(t.Subject) != 0 {
+		return errors.New("subject can not update")
+	}
+
+	if len(t.TokenHash) != 0 || len(t.TokenPrefix) != 0 {
+		return errors.New("token can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
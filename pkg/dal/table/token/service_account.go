@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package token
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ServiceAccountColumns defines all the service account table's columns.
+var ServiceAccountColumns = utils.MergeColumns(nil, ServiceAccountColumnDescriptor)
+
+// ServiceAccountColumnDescriptor is ServiceAccountTable's column descriptors.
+var ServiceAccountColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "managers", NamedC: "managers", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ServiceAccountTable service_account表，服务账号用于自动化程序调用API，其名称作为请求用户进行鉴权
+type ServiceAccountTable struct {
+	// ID 自增ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Name 服务账号名称，全局唯一
+	Name string `db:"name" json:"name" validate:"lte=64"`
+	// Managers 负责人，可以管理该服务账号的令牌
+	Managers types.StringArray `db:"managers" json:"managers"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"max=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"max=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return service account table name.
+func (s ServiceAccountTable) TableName() table.Name {
+	return table.ServiceAccountTable
+}
+
+// InsertValidate validate service account table on insert.
+func (s ServiceAccountTable) InsertValidate() error {
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	if len(s.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(s.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if len(s.Managers) == 0 {
+		return errors.New("managers can not be empty")
+	}
+
+	if len(s.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate service account table on update.
+func (s ServiceAccountTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	if len(s.Name) != 0 {
+		return errors.New("name can not update")
+	}
+
+	if len(s.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(s.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
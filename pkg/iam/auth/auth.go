@@ -20,6 +20,8 @@
 package auth
 
 import (
+	"encoding/json"
+
 	asproto "hcm/pkg/api/auth-server"
 	"hcm/pkg/cc"
 	"hcm/pkg/client/auth-server"
@@ -189,6 +191,27 @@ func (a authorizer) ListAuthInstWithFilter(kt *kit.Kit, input *meta.ListAuthResI
 		return nil, false, err
 	}
 
+	// user has attribute based policy, use the filter generated by the policy.
+	if len(authInst.Filter) != 0 {
+		authExpr := new(filter.Expression)
+		if err = json.Unmarshal(authInst.Filter, authExpr); err != nil {
+			logs.Errorf("unmarshal authorized filter failed, err: %v, filter: %s, rid: %s", err, authInst.Filter,
+				kt.Rid)
+			return nil, false, err
+		}
+		replaceExprField(authExpr, "id", resIDField)
+
+		if expr == nil {
+			return authExpr, false, nil
+		}
+
+		filterExpr, err := tools.And(authExpr, expr)
+		if err != nil {
+			return nil, false, err
+		}
+		return filterExpr, false, nil
+	}
+
 	if authInst.IsAny {
 		return expr, false, err
 	}
@@ -213,6 +236,20 @@ func (a authorizer) ListAuthInstWithFilter(kt *kit.Kit, input *meta.ListAuthResI
 	return filterExpr, false, nil
 }
 
+// replaceExprField replace the field of all the rules in expression.
+func replaceExprField(expr *filter.Expression, from, to string) {
+	for _, rule := range expr.Rules {
+		switch r := rule.(type) {
+		case *filter.AtomRule:
+			if r.Field == from {
+				r.Field = to
+			}
+		case *filter.Expression:
+			replaceExprField(r, from, to)
+		}
+	}
+}
+
 // RegisterResourceCreatorAction registers iam resource instance so that creator will be authorized on related actions
 func (a authorizer) RegisterResourceCreatorAction(kt *kit.Kit, input *meta.RegisterResCreatorActionInst) error {
 	if input == nil || len(input.Type) == 0 || len(input.ID) == 0 || len(input.Name) == 0 {
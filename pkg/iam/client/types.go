@@ -25,6 +25,7 @@ import (
 	"sync"
 
 	"hcm/pkg/iam/sdk/operator"
+	"hcm/pkg/runtime/filter"
 	"hcm/pkg/tools/ssl"
 )
 
@@ -453,6 +454,11 @@ type AuthorizeList struct {
 	Ids []string `json:"ids"`
 	// IsAny = true means the user have all the permissions to access the resources.
 	IsAny bool `json:"isAny"`
+	// Filter is the resource filter converted from the attribute based policy, only set when user's policy has
+	// attribute conditions. In this case, Ids and IsAny are the upper bound of the authorized instances.
+	Filter *filter.Expression `json:"filter,omitempty"`
+	// Policy is the user's attribute based policy, it is only used inside auth-server to generate the Filter.
+	Policy *operator.Policy `json:"-"`
 }
 
 // Decision describes authorize decision, have already been authorized(true) or not(false)
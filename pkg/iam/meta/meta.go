@@ -19,6 +19,8 @@
 
 package meta
 
+import "encoding/json"
+
 // UserInfo user info for authorization use.
 type UserInfo struct {
 	// UserName the name of this user.
@@ -30,6 +32,8 @@ type ResourceAttribute struct {
 	*Basic
 	// BizID biz id of the iam resource.
 	BizID int64 `json:"biz_id,omitempty"`
+	// Attribute attributes of the resource used for attribute based authorization, e.g. vendor and region.
+	Attribute map[string]interface{} `json:"attribute,omitempty"`
 }
 
 // Basic defines the basic info for a resource.
@@ -105,6 +109,10 @@ type AuthorizedInstances struct {
 	IDs []string `json:"ids"`
 	// IsAny == true means the user have all the permissions to access the resources.
 	IsAny bool `json:"isAny"`
+	// Filter is the json of resource filter expression generated by attribute based policy, it takes precedence
+	// over IDs and IsAny when listing resources, its resource id field is "id" and should be replaced by the
+	// actual field. it is raw json because meta package can not depend on filter package.
+	Filter json.RawMessage `json:"filter,omitempty"`
 }
 
 // RegisterResCreatorActionInst defines instance to register resource creator action.
@@ -67,4 +67,10 @@ const (
 	InstanceType ResourceType = "instance_type"
 	// CostManage defines cost manage's hcm auth resource type
 	CostManage ResourceType = "cost_manage"
+	// Rbac defines built-in rbac role and role binding's hcm auth resource type
+	Rbac ResourceType = "rbac"
+	// ServiceAccount defines service account and its api token's hcm auth resource type
+	ServiceAccount ResourceType = "service_account"
+	// EventSubscription defines resource lifecycle event and its webhook subscription's hcm auth resource type
+	EventSubscription ResourceType = "event_subscription"
 )
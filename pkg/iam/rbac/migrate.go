@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"reflect"
+	"sort"
+
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	protorbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/sys"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+const (
+	// AdminRole is the builtin role which contains all the actions.
+	AdminRole = "admin"
+	// ViewerRole is the builtin role which contains all the view actions.
+	ViewerRole = "viewer"
+)
+
+// ListActions export the action model registered to iam as the rbac actions.
+func ListActions() []corerbac.Action {
+	resActions := sys.GenerateStaticActions()
+
+	actions := make([]corerbac.Action, 0, len(resActions))
+	for _, one := range resActions {
+		action := corerbac.Action{
+			ID:     string(one.ID),
+			Name:   one.Name,
+			NameEn: one.NameEn,
+			Type:   string(one.Type),
+		}
+		if len(one.RelatedResourceTypes) != 0 {
+			action.ResourceType = string(one.RelatedResourceTypes[0].ID)
+		}
+		actions = append(actions, action)
+	}
+
+	return actions
+}
+
+// genBuiltinRoles generate the builtin roles by the action model registered to iam.
+func genBuiltinRoles() map[string][]string {
+	roles := make(map[string][]string)
+
+	for _, action := range sys.GenerateStaticActions() {
+		roles[AdminRole] = append(roles[AdminRole], string(action.ID))
+		if action.Type == sys.View {
+			roles[ViewerRole] = append(roles[ViewerRole], string(action.ID))
+		}
+	}
+
+	for _, creator := range sys.GenerateResourceCreatorActions().Config {
+		name := creatorRoleName(creator.ResourceID)
+		for _, action := range creator.Actions {
+			roles[name] = append(roles[name], string(action.ID))
+		}
+	}
+
+	for name := range roles {
+		sort.Strings(roles[name])
+	}
+
+	return roles
+}
+
+// InitBuiltinRoles export the action model registered to iam as the builtin roles, existing builtin roles are
+// updated to the latest actions, then bind the admin role to the admins on all the resources.
+func (a *Authorizer) InitBuiltinRoles(kt *kit.Kit, admins []string) error {
+	builtinRoles := genBuiltinRoles()
+
+	names := make([]string, 0, len(builtinRoles))
+	for name := range builtinRoles {
+		names = append(names, name)
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("name", names),
+		Page:   core.DefaultBasePage,
+	}
+	existRoles, err := a.ds.Global.Rbac.ListRole(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list rbac builtin roles failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	existMap := make(map[string]corerbac.Role, len(existRoles.Details))
+	for _, one := range existRoles.Details {
+		existMap[one.Name] = one
+	}
+
+	roleIDMap := make(map[string]string, len(builtinRoles))
+	for name, actions := range builtinRoles {
+		exist, exists := existMap[name]
+		if !exists {
+			createReq := &protorbac.RoleCreateReq{
+				Name:    name,
+				Actions: actions,
+				Builtin: true,
+			}
+			result, err := a.ds.Global.Rbac.CreateRole(kt.Ctx, kt.Header(), createReq)
+			if err != nil {
+				logs.Errorf("create rbac builtin role %s failed, err: %v, rid: %s", name, err, kt.Rid)
+				return err
+			}
+			roleIDMap[name] = result.ID
+			continue
+		}
+
+		roleIDMap[name] = exist.ID
+		existActions := append([]string{}, exist.Actions...)
+		sort.Strings(existActions)
+		if reflect.DeepEqual(existActions, actions) {
+			continue
+		}
+
+		if err = a.ds.Global.Rbac.UpdateRole(kt.Ctx, kt.Header(), exist.ID,
+			&protorbac.RoleUpdateReq{Actions: actions}); err != nil {
+			logs.Errorf("update rbac builtin role %s failed, err: %v, rid: %s", name, err, kt.Rid)
+			return err
+		}
+	}
+
+	if len(admins) == 0 {
+		return nil
+	}
+
+	return a.bindAdmins(kt, roleIDMap[AdminRole], admins)
+}
+
+// bindAdmins bind the admin role to the admins who have not been bound yet.
+func (a *Authorizer) bindAdmins(kt *kit.Kit, roleID string, admins []string) error {
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "role_id", Op: filter.Equal.Factory(), Value: roleID},
+				&filter.AtomRule{Field: "scope_type", Op: filter.Equal.Factory(), Value: string(enumor.AllRbacScope)},
+				&filter.AtomRule{Field: "subject", Op: filter.In.Factory(), Value: admins},
+			},
+		},
+		Page: core.DefaultBasePage,
+	}
+	bindings, err := a.ds.Global.Rbac.ListRoleBinding(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list rbac admin role bindings failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	boundMap := make(map[string]struct{}, len(bindings.Details))
+	for _, one := range bindings.Details {
+		boundMap[one.Subject] = struct{}{}
+	}
+
+	createReq := &protorbac.RoleBindingBatchCreateReq{Bindings: make([]protorbac.RoleBindingCreateReq, 0)}
+	for _, admin := range admins {
+		if _, exists := boundMap[admin]; exists {
+			continue
+		}
+		boundMap[admin] = struct{}{}
+
+		createReq.Bindings = append(createReq.Bindings, protorbac.RoleBindingCreateReq{
+			RoleID:    roleID,
+			Subject:   admin,
+			ScopeType: enumor.AllRbacScope,
+		})
+	}
+
+	if len(createReq.Bindings) == 0 {
+		return nil
+	}
+
+	if _, err = a.ds.Global.Rbac.BatchCreateRoleBinding(kt.Ctx, kt.Header(), createReq); err != nil {
+		logs.Errorf("bind rbac admin role failed, admins: %v, err: %v, rid: %s", admins, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// IsBuiltinRole returns if the role name is reserved by the builtin roles.
+func IsBuiltinRole(name string) bool {
+	_, exists := genBuiltinRoles()[name]
+	return exists
+}
@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/sys"
+)
+
+// resTypeScopeMap is the iam resource type to the rbac scope type which the resource can be scoped by.
+var resTypeScopeMap = map[client.TypeID]enumor.RbacScopeType{
+	sys.Biz:     enumor.BizRbacScope,
+	sys.Account: enumor.AccountRbacScope,
+}
+
+// policy is the actions a user has on the scope of a role binding.
+type policy struct {
+	actions   map[string]struct{}
+	scopeType enumor.RbacScopeType
+	scopeIDs  map[string]struct{}
+}
+
+// hasAction returns if the policy contains the action.
+func (p policy) hasAction(action string) bool {
+	_, exists := p.actions[action]
+	return exists
+}
+
+// match returns if the resource is in the scope of the policy.
+func (p policy) match(res client.Resource) bool {
+	if p.scopeType == enumor.AllRbacScope {
+		return true
+	}
+
+	if resTypeScopeMap[res.Type] != p.scopeType {
+		return false
+	}
+
+	_, exists := p.scopeIDs[res.ID]
+	return exists
+}
+
+// policies is all the policies of a user.
+type policies []policy
+
+// authorize returns if every resource is in the scope of one of the policies that contains the action, action which
+// is not related to resources or resource without id(authorize any) only requires the action.
+func (ps policies) authorize(action string, resources []client.Resource) bool {
+	for _, res := range resources {
+		if len(res.ID) == 0 {
+			continue
+		}
+
+		matched := false
+		for _, p := range ps {
+			if p.hasAction(action) && p.match(res) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return ps.authorizeAny(action)
+}
+
+// authorizeAny returns if one of the policies contains the action.
+func (ps policies) authorizeAny(action string) bool {
+	for _, p := range ps {
+		if p.hasAction(action) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listInstances returns the resource ids that the user has the action on.
+func (ps policies) listInstances(action string, resType client.TypeID) *client.AuthorizeList {
+	scopeType, exists := resTypeScopeMap[resType]
+
+	ids := make([]string, 0)
+	idMap := make(map[string]struct{})
+	for _, p := range ps {
+		if !p.hasAction(action) {
+			continue
+		}
+
+		if p.scopeType == enumor.AllRbacScope {
+			return &client.AuthorizeList{IsAny: true}
+		}
+
+		if !exists || p.scopeType != scopeType {
+			continue
+		}
+
+		for id := range p.scopeIDs {
+			if _, ok := idMap[id]; ok {
+				continue
+			}
+			idMap[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	return &client.AuthorizeList{Ids: ids}
+}
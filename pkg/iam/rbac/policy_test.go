@@ -0,0 +1,111 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"sort"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/sys"
+)
+
+func newPolicy(scopeType enumor.RbacScopeType, scopeIDs []string, actions ...client.ActionID) policy {
+	p := policy{
+		actions:   make(map[string]struct{}),
+		scopeType: scopeType,
+		scopeIDs:  make(map[string]struct{}),
+	}
+	for _, action := range actions {
+		p.actions[string(action)] = struct{}{}
+	}
+	for _, id := range scopeIDs {
+		p.scopeIDs[id] = struct{}{}
+	}
+	return p
+}
+
+func TestPolicies_Authorize(t *testing.T) {
+	ps := policies{
+		newPolicy(enumor.AccountRbacScope, []string{"a1", "a2"}, sys.AccountFind, sys.AccountEdit),
+		newPolicy(enumor.BizRbacScope, []string{"10"}, sys.BizAccess),
+		newPolicy(enumor.AllRbacScope, nil, sys.ResourceFind),
+	}
+
+	account := func(id string) client.Resource {
+		return client.Resource{System: sys.SystemIDHCM, Type: sys.Account, ID: id}
+	}
+	biz := func(id string) client.Resource {
+		return client.Resource{System: sys.SystemIDCMDB, Type: sys.Biz, ID: id}
+	}
+
+	cases := []struct {
+		name      string
+		action    client.ActionID
+		resources []client.Resource
+		expect    bool
+	}{
+		{name: "account in scope", action: sys.AccountEdit, resources: []client.Resource{account("a1")}, expect: true},
+		{name: "account out of scope", action: sys.AccountEdit, resources: []client.Resource{account("a3")}},
+		{name: "action not granted", action: sys.AccountDelete, resources: []client.Resource{account("a1")}},
+		{name: "biz in scope", action: sys.BizAccess, resources: []client.Resource{biz("10")}, expect: true},
+		{name: "biz scope not match account", action: sys.BizAccess, resources: []client.Resource{account("10")}},
+		{name: "all scope", action: sys.ResourceFind, resources: []client.Resource{account("a9")}, expect: true},
+		{name: "authorize any", action: sys.AccountFind, resources: []client.Resource{account("")}, expect: true},
+		{name: "no related resource", action: sys.AccountFind, expect: true},
+		{name: "no related resource without action", action: sys.AccountImport},
+		{name: "multiple resources", action: sys.AccountFind,
+			resources: []client.Resource{account("a1"), account("a3")}},
+	}
+
+	for _, c := range cases {
+		if got := ps.authorize(string(c.action), c.resources); got != c.expect {
+			t.Errorf("%s: authorize expect %v, but got %v", c.name, c.expect, got)
+		}
+	}
+}
+
+func TestPolicies_ListInstances(t *testing.T) {
+	ps := policies{
+		newPolicy(enumor.AccountRbacScope, []string{"a1", "a2"}, sys.AccountFind),
+		newPolicy(enumor.AccountRbacScope, []string{"a2", "a3"}, sys.AccountFind),
+		newPolicy(enumor.BizRbacScope, []string{"10"}, sys.AccountFind),
+		newPolicy(enumor.AllRbacScope, nil, sys.ResourceFind),
+	}
+
+	list := ps.listInstances(string(sys.AccountFind), sys.Account)
+	if list.IsAny {
+		t.Fatalf("list account instances should not be any")
+	}
+
+	sort.Strings(list.Ids)
+	if len(list.Ids) != 3 || list.Ids[0] != "a1" || list.Ids[1] != "a2" || list.Ids[2] != "a3" {
+		t.Errorf("list account instances expect [a1 a2 a3], but got %v", list.Ids)
+	}
+
+	if list = ps.listInstances(string(sys.ResourceFind), sys.Account); !list.IsAny {
+		t.Errorf("list instances of all scope should be any")
+	}
+
+	if list = ps.listInstances(string(sys.AccountDelete), sys.Account); list.IsAny || len(list.Ids) != 0 {
+		t.Errorf("list instances without action should be empty, but got %+v", list)
+	}
+}
@@ -0,0 +1,272 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac is the built-in role based authorizer, it implements the same authorize semantics with iam over the
+// roles and role bindings stored in data-service, so that hcm can run without blueking iam.
+package rbac
+
+import (
+	"context"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	protorbac "hcm/pkg/api/data-service/rbac"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/iam/sdk/auth"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+var _ auth.Authorizer = new(Authorizer)
+
+// Authorizer is the built-in rbac authorizer.
+type Authorizer struct {
+	ds *dataservice.Client
+}
+
+// NewAuthorizer create a built-in rbac authorizer.
+func NewAuthorizer(ds *dataservice.Client) (*Authorizer, error) {
+	if ds == nil {
+		return nil, errf.New(errf.InvalidParameter, "data service client is nil")
+	}
+
+	return &Authorizer{ds: ds}, nil
+}
+
+// Authorize check if a user's operate resource is already authorized or not.
+func (a *Authorizer) Authorize(ctx context.Context, opts *client.AuthOptions) (*client.Decision, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	kt := newKit(ctx)
+	policies, err := a.listPolicies(kt, opts.Subject.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client.Decision{Authorized: policies.authorize(opts.Action.ID, opts.Resources)}, nil
+}
+
+// AuthorizeBatch check if a user's operate resources is authorized or not batch.
+func (a *Authorizer) AuthorizeBatch(ctx context.Context, opts *client.AuthBatchOptions) ([]*client.Decision, error) {
+	return a.authorizeBatch(ctx, opts, true)
+}
+
+// AuthorizeAnyBatch check if a user have any authority of the operate actions batch.
+func (a *Authorizer) AuthorizeAnyBatch(ctx context.Context, opts *client.AuthBatchOptions) ([]*client.Decision,
+	error) {
+
+	return a.authorizeBatch(ctx, opts, false)
+}
+
+func (a *Authorizer) authorizeBatch(ctx context.Context, opts *client.AuthBatchOptions, exact bool) (
+	[]*client.Decision, error) {
+
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	kt := newKit(ctx)
+	policies, err := a.listPolicies(kt, opts.Subject.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	decisions := make([]*client.Decision, len(opts.Batch))
+	for index, batch := range opts.Batch {
+		var authorized bool
+		if exact {
+			authorized = policies.authorize(batch.Action.ID, batch.Resources)
+		} else {
+			authorized = policies.authorizeAny(batch.Action.ID)
+		}
+		decisions[index] = &client.Decision{Authorized: authorized}
+	}
+
+	return decisions, nil
+}
+
+// ListAuthorizedInstances list a user's all the authorized resource instance list with an action.
+func (a *Authorizer) ListAuthorizedInstances(ctx context.Context, opts *client.AuthOptions,
+	resourceType client.TypeID) (*client.AuthorizeList, error) {
+
+	kt := newKit(ctx)
+	policies, err := a.listPolicies(kt, opts.Subject.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return policies.listInstances(opts.Action.ID, resourceType), nil
+}
+
+// RegisterResourceCreatorAction bind the creator role of the resource type to the creator, the binding is scoped
+// to the created instance.
+func (a *Authorizer) RegisterResourceCreatorAction(ctx context.Context, opts *client.InstanceWithCreator) (
+	[]client.CreatorActionPolicy, error) {
+
+	scopeType, exists := resTypeScopeMap[client.TypeID(opts.Type)]
+	if !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s has no creator role", opts.Type)
+	}
+
+	kt := newKit(ctx)
+	roleName := creatorRoleName(client.TypeID(opts.Type))
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("name", roleName),
+		Page:   core.DefaultBasePage,
+	}
+	roles, err := a.ds.Global.Rbac.ListRole(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list rbac creator role %s failed, err: %v, rid: %s", roleName, err, kt.Rid)
+		return nil, err
+	}
+
+	if len(roles.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "rbac creator role %s not found, need to init rbac first",
+			roleName)
+	}
+
+	createReq := &protorbac.RoleBindingBatchCreateReq{
+		Bindings: []protorbac.RoleBindingCreateReq{{
+			RoleID:    roles.Details[0].ID,
+			Subject:   opts.Creator,
+			ScopeType: scopeType,
+			ScopeIDs:  []string{opts.ID},
+		}},
+	}
+	if _, err = a.ds.Global.Rbac.BatchCreateRoleBinding(kt.Ctx, kt.Header(), createReq); err != nil {
+		logs.Errorf("create rbac creator role binding failed, err: %v, req: %+v, rid: %s", err, createReq, kt.Rid)
+		return nil, err
+	}
+
+	policies := make([]client.CreatorActionPolicy, 0, len(roles.Details[0].Actions))
+	for _, action := range roles.Details[0].Actions {
+		policies = append(policies, client.CreatorActionPolicy{Action: client.ActionWithID{ID: client.ActionID(action)}})
+	}
+
+	return policies, nil
+}
+
+// GetApplyPermUrl built-in rbac has no permission apply page, permissions are granted by role bindings.
+func (a *Authorizer) GetApplyPermUrl(_ context.Context, _ *meta.IamPermission) (string, error) {
+	return "", nil
+}
+
+// listPolicies list all the role bindings of the user with the actions of the bound roles.
+func (a *Authorizer) listPolicies(kt *kit.Kit, user string) (policies, error) {
+	bindings := make([]corerbac.RoleBinding, 0)
+	page := &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit}
+	for {
+		listReq := &core.ListReq{
+			Filter: tools.EqualExpression("subject", user),
+			Page:   page,
+		}
+		result, err := a.ds.Global.Rbac.ListRoleBinding(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list rbac role binding failed, user: %s, err: %v, rid: %s", user, err, kt.Rid)
+			return nil, err
+		}
+
+		bindings = append(bindings, result.Details...)
+
+		if len(result.Details) < int(page.Limit) {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+
+	if len(bindings) == 0 {
+		return make(policies, 0), nil
+	}
+
+	roleIDs := make([]string, 0, len(bindings))
+	for _, one := range bindings {
+		roleIDs = append(roleIDs, one.RoleID)
+	}
+	roleIDs = slice.Unique(roleIDs)
+
+	roleActions := make(map[string]map[string]struct{}, len(roleIDs))
+	for _, ids := range slice.Split(roleIDs, int(core.DefaultMaxPageLimit)) {
+		listReq := &core.ListReq{
+			Filter: tools.ContainersExpression("id", ids),
+			Page:   core.DefaultBasePage,
+			Fields: []string{"id", "actions"},
+		}
+		result, err := a.ds.Global.Rbac.ListRole(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list rbac role failed, ids: %v, err: %v, rid: %s", ids, err, kt.Rid)
+			return nil, err
+		}
+
+		for _, role := range result.Details {
+			actions := make(map[string]struct{}, len(role.Actions))
+			for _, action := range role.Actions {
+				actions[action] = struct{}{}
+			}
+			roleActions[role.ID] = actions
+		}
+	}
+
+	result := make(policies, 0, len(bindings))
+	for _, one := range bindings {
+		actions, exists := roleActions[one.RoleID]
+		if !exists {
+			continue
+		}
+
+		scopeIDs := make(map[string]struct{}, len(one.ScopeIDs))
+		for _, id := range one.ScopeIDs {
+			scopeIDs[id] = struct{}{}
+		}
+
+		result = append(result, policy{
+			actions:   actions,
+			scopeType: one.ScopeType,
+			scopeIDs:  scopeIDs,
+		})
+	}
+
+	return result, nil
+}
+
+// newKit the authorizer interface is context based, generate a kit to request data-service.
+func newKit(ctx context.Context) *kit.Kit {
+	kt := kit.New()
+	if ctx != nil {
+		kt.Ctx = ctx
+		if rid, ok := ctx.Value(constant.RidKey).(string); ok && len(rid) != 0 {
+			kt.Rid = rid
+		}
+	}
+	kt.User = constant.RbacAuthorizerUserKey
+	kt.AppCode = constant.RbacAuthorizerAppCodeKey
+	return kt
+}
+
+func creatorRoleName(resType client.TypeID) string {
+	return fmt.Sprintf("%s_creator", resType)
+}
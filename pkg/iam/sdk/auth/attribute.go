@@ -0,0 +1,169 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/sdk/operator"
+	"hcm/pkg/runtime/filter"
+)
+
+// attrOpMap defines the iam policy operator to filter operator map that attribute policy supports.
+var attrOpMap = map[operator.OpType]filter.OpType{
+	operator.Equal:  filter.Equal,
+	operator.NEqual: filter.NotEqual,
+	operator.In:     filter.In,
+	operator.Nin:    filter.NotIn,
+	// e.g. region policy "ap-*" is a starts_with condition, which is converted to the prefix filter.
+	operator.StartWith:  filter.StartsWith,
+	operator.NStartWith: filter.NotStartsWith,
+}
+
+// HasAttribute returns if the policy has attribute conditions except for the resource id.
+func HasAttribute(p *operator.Policy) bool {
+	return len(ListPolicyAttributes(p)) > 0
+}
+
+// ListPolicyAttributes list all the attributes except for the resource id that the policy uses.
+func ListPolicyAttributes(p *operator.Policy) []string {
+	if p == nil {
+		return make([]string, 0)
+	}
+
+	attrMap := make(map[string]struct{})
+	collectPolicyAttributes(p, attrMap)
+
+	attrs := make([]string, 0, len(attrMap))
+	for attr := range attrMap {
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
+
+func collectPolicyAttributes(p *operator.Policy, attrMap map[string]struct{}) {
+	switch ele := p.Element.(type) {
+	case *operator.Content:
+		for _, policy := range ele.Content {
+			collectPolicyAttributes(policy, attrMap)
+		}
+	case *operator.FieldValue:
+		if ele.Field.Attribute != client.IamIDKey && ele.Field.Attribute != "" {
+			attrMap[ele.Field.Attribute] = struct{}{}
+		}
+	}
+}
+
+// PolicyToExpr convert iam policy to filter expression, fields is the policy attribute to db field map.
+// if relaxed is true, conditions on attributes that are not in fields are regarded as matched, which is used
+// to calculate the upper bound of the matched resources, otherwise an error is returned for these conditions.
+// returns isAny = true when the policy matches all the resources.
+func PolicyToExpr(p *operator.Policy, fields map[string]string, relaxed bool) (*filter.Expression, bool, error) {
+	if p == nil {
+		return nil, false, errors.New("policy is nil")
+	}
+
+	if hasIamPath(p) {
+		return nil, false, errors.New("policy content has _bk_iam_path_, not support for now")
+	}
+
+	rule, isAny, err := policyToRule(p, fields, relaxed)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if isAny {
+		return nil, true, nil
+	}
+
+	if expr, ok := rule.(*filter.Expression); ok {
+		return expr, false, nil
+	}
+
+	return &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{rule}}, false, nil
+}
+
+func policyToRule(p *operator.Policy, fields map[string]string, relaxed bool) (filter.RuleFactory, bool, error) {
+	switch p.Operator {
+	case operator.Any:
+		return nil, true, nil
+
+	case operator.And, operator.Or:
+		content, can := p.Element.(*operator.Content)
+		if !can {
+			return nil, false, errors.New("policy with invalid content field")
+		}
+
+		rules := make([]filter.RuleFactory, 0, len(content.Content))
+		for _, policy := range content.Content {
+			rule, isAny, err := policyToRule(policy, fields, relaxed)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if !isAny {
+				rules = append(rules, rule)
+				continue
+			}
+
+			// one matched condition makes the whole "or" policy matched, and is ignored in the "and" policy.
+			if p.Operator == operator.Or {
+				return nil, true, nil
+			}
+		}
+
+		if len(rules) == 0 {
+			if p.Operator == operator.And {
+				return nil, true, nil
+			}
+			return nil, false, errors.New("policy with empty content")
+		}
+
+		op := filter.And
+		if p.Operator == operator.Or {
+			op = filter.Or
+		}
+		return &filter.Expression{Op: op, Rules: rules}, false, nil
+
+	default:
+		fv, can := p.Element.(*operator.FieldValue)
+		if !can {
+			return nil, false, errors.New("policy with invalid FieldValue field")
+		}
+
+		field, exists := fields[fv.Field.Attribute]
+		if !exists {
+			if relaxed {
+				return nil, true, nil
+			}
+			return nil, false, fmt.Errorf("policy attribute %s is not supported", fv.Field.Attribute)
+		}
+
+		op, exists := attrOpMap[p.Operator]
+		if !exists {
+			return nil, false, fmt.Errorf("unsupported operator %s with attribute %s", p.Operator,
+				fv.Field.Attribute)
+		}
+
+		return &filter.AtomRule{Field: field, Op: op.Factory(), Value: fv.Value}, false, nil
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package auth
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"hcm/pkg/iam/sdk/operator"
+	"hcm/pkg/runtime/filter"
+)
+
+func TestPolicyToExpr(t *testing.T) {
+	raw := `{"op":"OR","content":[{"op":"in","field":"account.id","value":["1","2"]},
+{"op":"AND","content":[{"op":"eq","field":"account.vendor","value":"aws"},
+{"op":"in","field":"account.region","value":["ap-southeast-1","ap-northeast-1"]}]}]}`
+
+	policy := new(operator.Policy)
+	if err := json.Unmarshal([]byte(raw), policy); err != nil {
+		t.Fatalf("unmarshal policy failed, err: %v", err)
+	}
+
+	if !HasAttribute(policy) {
+		t.Fatalf("policy should have attributes")
+	}
+
+	fields := map[string]string{"id": "account_id", "vendor": "vendor", "region": "region"}
+	expr, isAny, err := PolicyToExpr(policy, fields, false)
+	if err != nil {
+		t.Fatalf("convert policy failed, err: %v", err)
+	}
+
+	if isAny {
+		t.Fatalf("policy should not match all resources")
+	}
+
+	js, err := json.Marshal(expr)
+	if err != nil {
+		t.Fatalf("marshal expression failed, err: %v", err)
+	}
+
+	expected := `{"op":"or","rules":[{"field":"account_id","op":"in","value":["1","2"]},{"op":"and","rules":[` +
+		`{"field":"vendor","op":"eq","value":"aws"},` +
+		`{"field":"region","op":"in","value":["ap-southeast-1","ap-northeast-1"]}]}]}`
+	if string(js) != expected {
+		t.Fatalf("expression is not expected, got: %s", js)
+	}
+
+	// region is not an account attribute, regard it as matched when relaxed.
+	accountFields := map[string]string{"id": "id", "vendor": "vendor"}
+	if _, _, err = PolicyToExpr(policy, accountFields, false); err == nil {
+		t.Fatalf("convert policy with unsupported attribute should fail")
+	}
+
+	expr, isAny, err = PolicyToExpr(policy, accountFields, true)
+	if err != nil || isAny {
+		t.Fatalf("convert relaxed policy failed, err: %v, isAny: %v", err, isAny)
+	}
+
+	js, _ = json.Marshal(expr)
+	expected = `{"op":"or","rules":[{"field":"id","op":"in","value":["1","2"]},{"op":"and","rules":[` +
+		`{"field":"vendor","op":"eq","value":"aws"}]}]}`
+	if string(js) != expected {
+		t.Fatalf("relaxed expression is not expected, got: %s", js)
+	}
+}
+
+func TestStartsWithPolicyToExpr(t *testing.T) {
+	raw := `{"op":"starts_with","field":"account.region","value":"ap-"}`
+	policy := new(operator.Policy)
+	if err := json.Unmarshal([]byte(raw), policy); err != nil {
+		t.Fatalf("unmarshal policy failed, err: %v", err)
+	}
+
+	expr, isAny, err := PolicyToExpr(policy, map[string]string{"region": "region"}, false)
+	if err != nil || isAny {
+		t.Fatalf("convert starts with policy failed, err: %v, isAny: %v", err, isAny)
+	}
+
+	js, _ := json.Marshal(expr)
+	expected := `{"op":"and","rules":[{"field":"region","op":"starts_with","value":"ap-"}]}`
+	if string(js) != expected {
+		t.Fatalf("starts with expression is not expected, got: %s", js)
+	}
+
+	sql, value, err := expr.SQLWhereExpr(&filter.SQLWhereOption{Priority: filter.Priority{"region"}})
+	if err != nil {
+		t.Fatalf("generate sql of starts with expression failed, err: %v", err)
+	}
+
+	if !strings.HasPrefix(sql, "WHERE region LIKE BINARY :region_") || len(value) != 1 {
+		t.Fatalf("starts with sql is not expected, got: %s", sql)
+	}
+
+	for _, v := range value {
+		if v != "ap-%" {
+			t.Fatalf("starts with value is not expected, got: %v", v)
+		}
+	}
+}
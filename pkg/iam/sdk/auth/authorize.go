@@ -248,6 +248,13 @@ func (a *Authorize) ListAuthorizedInstances(ctx context.Context, opts *client.Au
 	if policy == nil || policy.Operator == "" {
 		return &client.AuthorizeList{}, nil
 	}
+
+	// attribute based policy can not be converted to instance id list, returns the policy for the caller to
+	// generate the resource filter.
+	if HasAttribute(policy) {
+		return &client.AuthorizeList{Policy: policy}, nil
+	}
+
 	return a.countPolicy(ctx, policy, resourceType)
 }
 
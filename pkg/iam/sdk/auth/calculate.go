@@ -285,6 +285,16 @@ func (a *Authorize) authElement(ctx context.Context, policy *operator.Policy, rs
 		}
 
 	default:
+		// the attribute is carried by the resource to be authorized, calculate it directly.
+		if value, exists := authRsc.Attribute[fv.Field.Attribute]; exists {
+			authorized, err = policy.Operator.Operator().Match(value, fv.Value)
+			if err != nil {
+				return false, false, fmt.Errorf("do %s match attribute %s calculate failed, err: %v",
+					policy.Operator, fv.Field.Attribute, err)
+			}
+			break
+		}
+
 		// other attributes only support operator: 'eq', 'in'
 		if policy.Operator != operator.Equal && policy.Operator != operator.In {
 			return false, false, fmt.Errorf("unsupported operator %s with attribute auth", policy.Operator)
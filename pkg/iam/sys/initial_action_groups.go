@@ -81,6 +81,9 @@ func genBusinessManagementActionGroups() []client.ActionGroup {
 				Actions: []client.ActionWithID{
 					{ID: CostManage},
 					{ID: AccountKeyAccess},
+					{ID: RbacManage},
+					{ID: ServiceAccountManage},
+					{ID: EventSubscriptionManage},
 				},
 			},
 		},
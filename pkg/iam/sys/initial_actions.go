@@ -35,6 +35,21 @@ var (
 		},
 	}
 
+	// accountAttrResource 可同时选择账号实例和配置资源属性(如云厂商、地域)的关联资源类型
+	accountAttrResource = []client.RelateResourceType{
+		{
+			SystemID:      SystemIDHCM,
+			ID:            Account,
+			SelectionMode: client.ModeAll,
+			InstanceSelections: []client.RelatedInstanceSelection{
+				{
+					SystemID: SystemIDHCM,
+					ID:       AccountSelection,
+				},
+			},
+		},
+	}
+
 	bizResource = []client.RelateResourceType{
 		{
 			SystemID: SystemIDCMDB,
@@ -141,7 +156,7 @@ func genResourceActions() []client.ResourceAction {
 		Name:                 ActionIDNameMap[ResourceFind],
 		NameEn:               "Find Resource",
 		Type:                 View,
-		RelatedResourceTypes: accountResource,
+		RelatedResourceTypes: accountAttrResource,
 		RelatedActions:       []client.ActionID{RecycleBinFind},
 		Version:              1,
 	}, {
@@ -149,7 +164,7 @@ func genResourceActions() []client.ResourceAction {
 		Name:                 ActionIDNameMap[ResourceAssign],
 		NameEn:               "Assign Resource To Business",
 		Type:                 Edit,
-		RelatedResourceTypes: append(accountResource, bizResource...),
+		RelatedResourceTypes: append(accountAttrResource, bizResource...),
 		RelatedActions:       nil,
 		Version:              1,
 	}, {
@@ -165,7 +180,7 @@ func genResourceActions() []client.ResourceAction {
 		Name:                 ActionIDNameMap[IaaSResourceOperate],
 		NameEn:               "Operate IaaS Resource",
 		Type:                 Edit,
-		RelatedResourceTypes: accountResource,
+		RelatedResourceTypes: accountAttrResource,
 		RelatedActions:       []client.ActionID{ResourceFind},
 		Version:              1,
 	}, {
@@ -173,7 +188,7 @@ func genResourceActions() []client.ResourceAction {
 		Name:                 ActionIDNameMap[IaaSResourceDelete],
 		NameEn:               "Delete IaaS Resource",
 		Type:                 Delete,
-		RelatedResourceTypes: accountResource,
+		RelatedResourceTypes: accountAttrResource,
 		RelatedActions:       []client.ActionID{ResourceFind},
 		Version:              1,
 	}}
@@ -236,5 +251,29 @@ func genSystemManageActions() []client.ResourceAction {
 		RelatedResourceTypes: accountResource,
 		RelatedActions:       nil,
 		Version:              1,
+	}, {
+		ID:                   RbacManage,
+		Name:                 ActionIDNameMap[RbacManage],
+		NameEn:               "Rbac Manage",
+		Type:                 Edit,
+		RelatedResourceTypes: nil,
+		RelatedActions:       nil,
+		Version:              1,
+	}, {
+		ID:                   ServiceAccountManage,
+		Name:                 ActionIDNameMap[ServiceAccountManage],
+		NameEn:               "Service Account Manage",
+		Type:                 Edit,
+		RelatedResourceTypes: nil,
+		RelatedActions:       nil,
+		Version:              1,
+	}, {
+		ID:                   EventSubscriptionManage,
+		Name:                 ActionIDNameMap[EventSubscriptionManage],
+		NameEn:               "Event Subscription Manage",
+		Type:                 Edit,
+		RelatedResourceTypes: nil,
+		RelatedActions:       nil,
+		Version:              1,
 	}}
 }
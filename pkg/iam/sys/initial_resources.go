@@ -27,6 +27,33 @@ var ResourceTypeIDMap = map[client.TypeID]string{
 	Biz:     "业务",
 }
 
+// 账号关联资源支持配置的属性，用于基于属性的鉴权，如"操作云厂商为 aws 且地域为 ap-southeast-1 的主机"。
+const (
+	// VendorAttribute 云厂商，账号和账号下的资源均具有该属性。
+	VendorAttribute = "vendor"
+	// RegionAttribute 地域，仅账号下的资源具有该属性，需要鉴权时由调用方在资源属性中传入。
+	RegionAttribute = "region"
+)
+
+// AttributeNameMap resource attribute id to display name map.
+var AttributeNameMap = map[string]string{
+	VendorAttribute: "云厂商",
+	RegionAttribute: "地域",
+}
+
+// ResourceAttributeFields 账号下资源的属性与资源表字段的映射，其中账号 ID 对应资源的所属账号字段，由使用方替换。
+var ResourceAttributeFields = map[string]string{
+	client.IamIDKey: "id",
+	VendorAttribute: "vendor",
+	RegionAttribute: "region",
+}
+
+// AccountAttributeFields 账号自身具有的属性与账号表字段的映射。
+var AccountAttributeFields = map[string]string{
+	client.IamIDKey: "id",
+	VendorAttribute: "vendor",
+}
+
 // GenerateStaticResourceTypes generate all the static resource types to register to IAM.
 func GenerateStaticResourceTypes() []client.ResourceType {
 	resourceTypeList := make([]client.ResourceType, 0)
@@ -94,31 +94,43 @@ const (
 	// CostManage bill manage action id to register iam.
 	CostManage client.ActionID = "cost_manage"
 
+	// RbacManage built-in rbac role and role binding manage action id to register iam.
+	RbacManage client.ActionID = "rbac_manage"
+
+	// ServiceAccountManage service account and its api token manage action id to register iam.
+	ServiceAccountManage client.ActionID = "service_account_manage"
+
+	// EventSubscriptionManage resource lifecycle event and its webhook subscription manage action id to register iam.
+	EventSubscriptionManage client.ActionID = "event_subscription_manage"
+
 	// Skip is an action that no need to auth
 	Skip client.ActionID = "skip"
 )
 
 // ActionIDNameMap is action id type map.
 var ActionIDNameMap = map[client.ActionID]string{
-	BizAccess:           "业务访问",
-	BizIaaSResCreate:    "业务-IaaS资源创建",
-	BizIaaSResOperate:   "业务-IaaS资源操作",
-	BizIaaSResDelete:    "业务-IaaS资源删除",
-	AccountFind:         "账号查看",
-	AccountImport:       "账号录入",
-	AccountEdit:         "账号编辑",
-	AccountDelete:       "账号删除",
-	AccountKeyAccess:    "账号密钥访问",
-	ResourceFind:        "资源查看",
-	ResourceAssign:      "资源分配",
-	IaaSResourceCreate:  "IaaS资源创建",
-	IaaSResourceOperate: "IaaS资源操作",
-	IaaSResourceDelete:  "IaaS资源删除",
-	RecycleBinFind:      "回收站查看",
-	RecycleBinManage:    "回收站管理",
-	BizAuditFind:        "业务审计查看",
-	ResourceAuditFind:   "资源审计查看",
-	CostManage:          "成本管理",
+	BizAccess:               "业务访问",
+	BizIaaSResCreate:        "业务-IaaS资源创建",
+	BizIaaSResOperate:       "业务-IaaS资源操作",
+	BizIaaSResDelete:        "业务-IaaS资源删除",
+	AccountFind:             "账号查看",
+	AccountImport:           "账号录入",
+	AccountEdit:             "账号编辑",
+	AccountDelete:           "账号删除",
+	AccountKeyAccess:        "账号密钥访问",
+	ResourceFind:            "资源查看",
+	ResourceAssign:          "资源分配",
+	IaaSResourceCreate:      "IaaS资源创建",
+	IaaSResourceOperate:     "IaaS资源操作",
+	IaaSResourceDelete:      "IaaS资源删除",
+	RecycleBinFind:          "回收站查看",
+	RecycleBinManage:        "回收站管理",
+	BizAuditFind:            "业务审计查看",
+	ResourceAuditFind:       "资源审计查看",
+	CostManage:              "成本管理",
+	RbacManage:              "权限管理",
+	ServiceAccountManage:    "服务账号管理",
+	EventSubscriptionManage: "事件订阅管理",
 }
 
 const (
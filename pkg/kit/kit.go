@@ -27,15 +27,20 @@ import (
 
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/logs"
 	"hcm/pkg/tools/uuid"
+	"hcm/pkg/tracing"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // New initial a kit with rid and context.
 func New() *Kit {
 	rid := uuid.UUID()
+	ctx := context.WithValue(context.TODO(), readPrimaryCtxKey{}, new(readPrimaryFlag))
 	return &Kit{
 		Rid: rid,
-		Ctx: context.WithValue(context.TODO(), constant.RidKey, rid),
+		Ctx: context.WithValue(ctx, constant.RidKey, rid),
 	}
 }
 
@@ -80,11 +85,27 @@ func (kt *Kit) ContextWithRid() context.Context {
 // CtxWithTimeoutMS create a new context with basic info and timout configuration.
 func (kt *Kit) CtxWithTimeoutMS(timeoutMS int) context.CancelFunc {
 	ctx := context.WithValue(context.TODO(), constant.RidKey, kt.Rid)
+	if flag, ok := kt.Ctx.Value(readPrimaryCtxKey{}).(*readPrimaryFlag); ok {
+		ctx = context.WithValue(ctx, readPrimaryCtxKey{}, flag)
+	}
+	// keep the span of the kit, so that the spans created with the new context are still in the trace.
+	ctx = trace.ContextWithSpanContext(ctx, trace.SpanContextFromContext(kt.Ctx))
 	var cancel context.CancelFunc
 	kt.Ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutMS)*time.Millisecond)
 	return cancel
 }
 
+// Logger returns the structured logger with the request fields of the kit, e.g.
+// kt.Logger().Errorw("create vpc failed", logs.FieldVendor, vendor, "err", err)
+func (kt *Kit) Logger() *logs.Entry {
+	fields := []interface{}{logs.FieldRid, kt.Rid, logs.FieldUser, kt.User, logs.FieldAppCode, kt.AppCode}
+	if traceID := tracing.TraceID(kt.Ctx); len(traceID) != 0 {
+		fields = append(fields, logs.FieldTraceID, traceID)
+	}
+
+	return logs.With(fields...)
+}
+
 // Validate context kit.
 func (kt *Kit) Validate() error {
 	if kt.Ctx == nil {
@@ -115,13 +136,23 @@ func (kt *Kit) Validate() error {
 
 // Header generate header by kit
 func (kt *Kit) Header() http.Header {
-	return http.Header{
+	header := http.Header{
 		constant.UserKey:          []string{kt.User},
 		constant.RidKey:           []string{kt.Rid},
 		constant.AppCodeKey:       []string{kt.AppCode},
 		constant.TenantIDKey:      []string{kt.TenantID},
 		constant.RequestSourceKey: []string{string(kt.RequestSource)},
 	}
+
+	if IsReadPrimary(kt.Ctx) {
+		header.Set(constant.ReadPrimaryKey, "true")
+	}
+
+	if kt.Ctx != nil {
+		tracing.Inject(kt.Ctx, header)
+	}
+
+	return header
 }
 
 // FromHeader http request header to context kit and validate.
@@ -131,7 +162,7 @@ func FromHeader(ctx context.Context, header http.Header) (*Kit, error) {
 	}
 
 	kt := &Kit{
-		Ctx:           ctx,
+		Ctx:           WithReadPrimaryFlag(ctx, header),
 		User:          header.Get(constant.UserKey),
 		Rid:           header.Get(constant.RidKey),
 		AppCode:       header.Get(constant.AppCodeKey),
@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package kit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"hcm/pkg/criteria/constant"
+)
+
+// readPrimaryCtxKey is the context key of the read primary flag.
+type readPrimaryCtxKey struct{}
+
+// readPrimaryFlag defines if the db reads of the request should be routed to the primary database, it is shared
+// by the contexts derived from the request context, so that the reads after writes of a request are not routed
+// to the read replicas that may lag behind.
+type readPrimaryFlag struct {
+	force int32
+}
+
+// WithReadPrimaryFlag returns a context that carries the read primary flag, the flag is set if the header asks
+// to read from the primary database.
+func WithReadPrimaryFlag(ctx context.Context, header http.Header) context.Context {
+	flag := new(readPrimaryFlag)
+	if force, _ := strconv.ParseBool(header.Get(constant.ReadPrimaryKey)); force {
+		flag.force = 1
+	}
+
+	return context.WithValue(ctx, readPrimaryCtxKey{}, flag)
+}
+
+// MarkReadPrimary mark the following db reads of the context to be routed to the primary database, it is called
+// after writes. it does nothing if the context does not carry the read primary flag.
+func MarkReadPrimary(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+
+	if flag, ok := ctx.Value(readPrimaryCtxKey{}).(*readPrimaryFlag); ok {
+		atomic.StoreInt32(&flag.force, 1)
+	}
+}
+
+// IsReadPrimary returns if the db reads of the context should be routed to the primary database.
+func IsReadPrimary(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	flag, ok := ctx.Value(readPrimaryCtxKey{}).(*readPrimaryFlag)
+	return ok && atomic.LoadInt32(&flag.force) == 1
+}
+
+// ForceReadPrimary force the db reads of the kit to be routed to the primary database, the flag is also passed
+// to other services by the kit header.
+func (kt *Kit) ForceReadPrimary() {
+	if _, ok := kt.Ctx.Value(readPrimaryCtxKey{}).(*readPrimaryFlag); !ok {
+		kt.Ctx = context.WithValue(kt.Ctx, readPrimaryCtxKey{}, new(readPrimaryFlag))
+	}
+
+	MarkReadPrimary(kt.Ctx)
+}
@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package logs
+
+import (
+	"hcm/pkg/logs/glog"
+)
+
+// keys of the common fields of the structured logs, the log pipeline indexes the logs by these fields.
+const (
+	FieldRid       = "rid"
+	FieldUser      = "user"
+	FieldAppCode   = "app_code"
+	FieldVendor    = "vendor"
+	FieldAccountID = "account_id"
+	FieldService   = "service"
+	FieldTraceID   = "trace_id"
+)
+
+// Entry is the structured logger, its fields are added to every log printed by it.
+// Fields and the key value pairs of the log methods are alternating keys and values, keys should be strings.
+// e.g. logs.With(logs.FieldVendor, vendor).Errorw("sync vpc failed", logs.FieldAccountID, accountID, "err", err)
+type Entry struct {
+	fields []interface{}
+}
+
+// With returns the structured logger with the key value pairs as fields.
+func With(kvs ...interface{}) *Entry {
+	return &Entry{fields: kvs}
+}
+
+// With returns a new structured logger with the key value pairs added to the fields of the entry.
+func (e *Entry) With(kvs ...interface{}) *Entry {
+	fields := make([]interface{}, 0, len(e.fields)+len(kvs))
+	fields = append(fields, e.fields...)
+	return &Entry{fields: append(fields, kvs...)}
+}
+
+// Infow print info log with the fields of the entry and the key value pairs.
+func (e *Entry) Infow(msg string, kvs ...interface{}) {
+	glog.InfoDepthw(1, msg, e.merge(kvs)...)
+}
+
+// Warnw print warning log with the fields of the entry and the key value pairs.
+func (e *Entry) Warnw(msg string, kvs ...interface{}) {
+	glog.WarningDepthw(1, msg, e.merge(kvs)...)
+}
+
+// Errorw print error log with the fields of the entry and the key value pairs.
+func (e *Entry) Errorw(msg string, kvs ...interface{}) {
+	glog.ErrorDepthw(1, msg, e.merge(kvs)...)
+}
+
+func (e *Entry) merge(kvs []interface{}) []interface{} {
+	if len(kvs) == 0 {
+		return e.fields
+	}
+
+	if len(e.fields) == 0 {
+		return kvs
+	}
+
+	merged := make([]interface{}, 0, len(e.fields)+len(kvs))
+	merged = append(merged, e.fields...)
+	return append(merged, kvs...)
+}
+
+// Infow print info log with the key value pairs.
+func Infow(msg string, kvs ...interface{}) {
+	glog.InfoDepthw(1, msg, kvs...)
+}
+
+// Warnw print warning log with the key value pairs.
+func Warnw(msg string, kvs ...interface{}) {
+	glog.WarningDepthw(1, msg, kvs...)
+}
+
+// Errorw print error log with the key value pairs.
+func Errorw(msg string, kvs ...interface{}) {
+	glog.ErrorDepthw(1, msg, kvs...)
+}
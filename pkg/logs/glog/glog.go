@@ -537,10 +537,23 @@ func (l *loggingT) putBuffer(b *buffer) {
 
 var timeNow = time.Now // Stubbed out for testing.
 
+// caller returns the file name and line number of the source line that calls the exported log function.
+// The depth specifies how many stack frames above lives the source line to be identified in the log message.
+func caller(depth int) (string, int) {
+	_, file, line, ok := runtime.Caller(4 + depth)
+	if !ok {
+		return "???", 1
+	}
+
+	slash := strings.LastIndex(file, "/")
+	if slash >= 0 {
+		file = file[slash+1:]
+	}
+	return file, line
+}
+
 /*
-header formats a log header as defined by the C++ implementation.
-It returns a buffer containing the formatted header and the user's file and line number.
-The depth specifies how many stack frames above lives the source line to be identified in the log message.
+formatHeader formats a log header as defined by the C++ implementation.
 
 Log lines have this form:
 
@@ -557,21 +570,6 @@ where the fields are defined as follows:
 	line             The line number
 	msg              The user-supplied message
 */
-func (l *loggingT) header(s severity, depth int) (*buffer, string, int) {
-	_, file, line, ok := runtime.Caller(3 + depth)
-	if !ok {
-		file = "???"
-		line = 1
-	} else {
-		slash := strings.LastIndex(file, "/")
-		if slash >= 0 {
-			file = file[slash+1:]
-		}
-	}
-	return l.formatHeader(s, file, line), file, line
-}
-
-// formatHeader formats a log header using the provided file name and line number.
 func (l *loggingT) formatHeader(s severity, file string, line int) *buffer {
 	now := timeNow()
 	if line < 0 {
@@ -653,9 +651,7 @@ func (buf *buffer) someDigits(i, d int) int {
 }
 
 func (l *loggingT) println(s severity, args ...interface{}) {
-	buf, file, line := l.header(s, 0)
-	fmt.Fprintln(buf, args...)
-	l.output(s, buf, file, line, false)
+	l.emit(s, 0, fmt.Sprintln(args...), nil)
 }
 
 func (l *loggingT) print(s severity, args ...interface{}) {
@@ -663,68 +659,66 @@ func (l *loggingT) print(s severity, args ...interface{}) {
 }
 
 func (l *loggingT) printDepth(s severity, depth int, args ...interface{}) {
-	buf, file, line := l.header(s, depth)
-	fmt.Fprint(buf, args...)
-	if buf.Bytes()[buf.Len()-1] != '\n' {
-		buf.WriteByte('\n')
-	}
-	l.output(s, buf, file, line, false)
+	l.emit(s, depth, fmt.Sprint(args...), nil)
 }
 
 func (l *loggingT) printf(s severity, format string, args ...interface{}) {
-	buf, file, line := l.header(s, 0)
-
-	// log content handle.
-	logContent := fmt.Sprintf(format, args...)
-
-	// if log content <= LineMaxSize, not need to handle.
-	logContentLen := uint32(len(logContent))
-	if logContentLen <= LineMaxSize() {
-		fmt.Fprintf(buf, logContent)
-	} else {
-		halfLineMaxSize := LineMaxSize() / 2
-		fmt.Fprintf(buf, logContent[:halfLineMaxSize]+lineWrapStandard+logContent[logContentLen-halfLineMaxSize:])
-	}
-
-	if buf.Bytes()[buf.Len()-1] != '\n' {
-		buf.WriteByte('\n')
-	}
-	l.output(s, buf, file, line, false)
+	l.emit(s, 0, truncate(fmt.Sprintf(format, args...)), nil)
 }
 
 func (l *loggingT) printDepthf(s severity, format string, depth int, args ...interface{}) {
-	buf, file, line := l.header(s, depth)
-
-	// log content handle.
-	logContent := fmt.Sprintf(format, args...)
-
-	// if log content <= LineMaxSize, not need to handle.
-	logContentLen := uint32(len(logContent))
-	if logContentLen <= LineMaxSize() {
-		fmt.Fprintf(buf, logContent)
-	} else {
-		halfLineMaxSize := LineMaxSize() / 2
-		fmt.Fprintf(buf, logContent[:halfLineMaxSize]+lineWrapStandard+logContent[logContentLen-halfLineMaxSize:])
-	}
+	l.emit(s, depth, truncate(fmt.Sprintf(format, args...)), nil)
+}
 
-	if buf.Bytes()[buf.Len()-1] != '\n' {
-		buf.WriteByte('\n')
-	}
-	l.output(s, buf, file, line, false)
+// printw logs the message with the key value pairs, in json format the pairs are the fields of the json object,
+// otherwise they are appended to the message like "msg, key1: value1, key2: value2".
+func (l *loggingT) printw(s severity, depth int, msg string, kvs []interface{}) {
+	l.emit(s, depth, truncate(msg), kvs)
 }
 
 // printWithFileLine behaves like print but uses the provided file and line number.  If
 // alsoLogToStderr is true, the log message always appears on standard error; it
 // will also appear in the log file unless --logtostderr is set.
 func (l *loggingT) printWithFileLine(s severity, file string, line int, alsoToStderr bool, args ...interface{}) {
+	l.emitWithFileLine(s, file, line, alsoToStderr, fmt.Sprint(args...), nil)
+}
+
+// emit formats the log line of the message with the header of the source line identified by depth, and outputs it.
+func (l *loggingT) emit(s severity, depth int, msg string, kvs []interface{}) {
+	file, line := caller(depth)
+	l.emitWithFileLine(s, file, line, false, msg, kvs)
+}
+
+// emitWithFileLine formats the log line in the configured format and outputs it.
+func (l *loggingT) emitWithFileLine(s severity, file string, line int, alsoToStderr bool, msg string,
+	kvs []interface{}) {
+
+	if jsonFormat {
+		l.output(s, l.formatJSON(s, file, line, msg, kvs), file, line, alsoToStderr)
+		return
+	}
+
 	buf := l.formatHeader(s, file, line)
-	fmt.Fprint(buf, args...)
+	buf.WriteString(msg)
+	writeTextFields(buf, kvs)
 	if buf.Bytes()[buf.Len()-1] != '\n' {
 		buf.WriteByte('\n')
 	}
 	l.output(s, buf, file, line, alsoToStderr)
 }
 
+// truncate cuts the middle of the log content that exceeds the line max size.
+func truncate(logContent string) string {
+	// if log content <= LineMaxSize, not need to handle.
+	logContentLen := uint32(len(logContent))
+	if logContentLen <= LineMaxSize() {
+		return logContent
+	}
+
+	halfLineMaxSize := LineMaxSize() / 2
+	return logContent[:halfLineMaxSize] + lineWrapStandard + logContent[logContentLen-halfLineMaxSize:]
+}
+
 // output writes the data to the log files and releases the buffer.
 func (l *loggingT) output(s severity, buf *buffer, file string, line int, alsoToStderr bool) {
 	l.mu.Lock()
@@ -1150,6 +1144,22 @@ func ErrorDepthf(depth int, format string, args ...interface{}) {
 	logging.printDepthf(errorLog, format, depth, args...)
 }
 
+// InfoDepthw logs the message with the key value pairs to the INFO log, depth is used to determine which call
+// frame to log. Key value pairs are the fields of the json object in json format, or appended to the message.
+func InfoDepthw(depth int, msg string, kvs ...interface{}) {
+	logging.printw(infoLog, depth, msg, kvs)
+}
+
+// WarningDepthw acts as InfoDepthw but logs to the WARNING and INFO logs.
+func WarningDepthw(depth int, msg string, kvs ...interface{}) {
+	logging.printw(warningLog, depth, msg, kvs)
+}
+
+// ErrorDepthw acts as InfoDepthw but logs to the ERROR, WARNING, and INFO logs.
+func ErrorDepthw(depth int, msg string, kvs ...interface{}) {
+	logging.printw(errorLog, depth, msg, kvs)
+}
+
 // Fatalf logs to the FATAL, ERROR, WARNING, and INFO logs,
 // including a stack trace of all running goroutines, then calls os.Exit(255).
 // Arguments are handled in the manner of fmt.Printf; a newline is appended if missing.
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package glog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+var (
+	// jsonFormat defines whether the log lines are formatted as json objects, it's only set when init logs.
+	jsonFormat bool
+	// staticFields are the key value pairs that are added to every json log line, e.g. the service name.
+	staticFields []interface{}
+)
+
+// SetJSONFormat formats the log lines as json objects, so that the log pipeline can collect the fields of the
+// log without parsing the message. fields are the key value pairs that are added to every log line.
+func SetJSONFormat(fields ...interface{}) {
+	jsonFormat = true
+	staticFields = fields
+}
+
+// jsonTimeLayout is the layout of the time field of the json log line.
+const jsonTimeLayout = "2006-01-02T15:04:05.000000Z07:00"
+
+/*
+formatJSON formats the log line as a json object, log lines have this form:
+
+	{"time":"2023-06-17T10:00:00.000000+08:00","level":"INFO","caller":"file:line","msg":"...","key":"value"}
+
+the static fields and the key value pairs of the log are appended after msg.
+*/
+func (l *loggingT) formatJSON(s severity, file string, line int, msg string, kvs []interface{}) *buffer {
+	if s > fatalLog {
+		s = infoLog // for safety.
+	}
+
+	buf := l.getBuffer()
+	buf.WriteString(`{"time":"`)
+	buf.WriteString(timeNow().Format(jsonTimeLayout))
+	buf.WriteString(`","level":"`)
+	buf.WriteString(severityName[s])
+	buf.WriteString(`","caller":`)
+	writeJSONString(buf, file+":"+strconv.Itoa(line))
+	buf.WriteString(`,"msg":`)
+	// the message of the formatted logs ends with new line which is meaningless in json.
+	if len(msg) > 0 && msg[len(msg)-1] == '\n' {
+		msg = msg[:len(msg)-1]
+	}
+	writeJSONString(buf, msg)
+	writeJSONFields(buf, staticFields)
+	writeJSONFields(buf, kvs)
+	buf.WriteString("}\n")
+	return buf
+}
+
+// writeJSONFields writes the key value pairs as the fields of the json object, the value of the last key is null
+// if the length of key value pairs is odd.
+func writeJSONFields(buf *buffer, kvs []interface{}) {
+	for i := 0; i < len(kvs); i += 2 {
+		buf.WriteByte(',')
+		writeJSONString(buf, fieldKey(kvs[i]))
+		buf.WriteByte(':')
+
+		if i+1 >= len(kvs) {
+			buf.WriteString("null")
+			continue
+		}
+
+		value, err := json.Marshal(fieldValue(kvs[i+1]))
+		if err != nil {
+			writeJSONString(buf, fmt.Sprintf("%+v", kvs[i+1]))
+			continue
+		}
+		buf.Write(value)
+	}
+}
+
+// writeTextFields appends the key value pairs to the message like "msg, key1: value1, key2: value2".
+func writeTextFields(buf *buffer, kvs []interface{}) {
+	if len(kvs) == 0 {
+		return
+	}
+
+	// key value pairs are written before the end of the line.
+	if buf.Len() > 0 && buf.Bytes()[buf.Len()-1] == '\n' {
+		buf.Truncate(buf.Len() - 1)
+	}
+
+	for i := 0; i < len(kvs); i += 2 {
+		buf.WriteString(", ")
+		buf.WriteString(fieldKey(kvs[i]))
+		buf.WriteString(": ")
+
+		if i+1 >= len(kvs) {
+			buf.WriteString("<nil>")
+			continue
+		}
+		fmt.Fprintf(buf, "%+v", fieldValue(kvs[i+1]))
+	}
+}
+
+// fieldKey returns the key of the field, keys are supposed to be strings.
+func fieldKey(key interface{}) string {
+	if k, ok := key.(string); ok {
+		return k
+	}
+
+	return fmt.Sprint(key)
+}
+
+// fieldValue converts the error value to its message, because json encodes most of the errors as empty objects.
+func fieldValue(value interface{}) interface{} {
+	if err, ok := value.(error); ok && err != nil {
+		return err.Error()
+	}
+
+	return value
+}
+
+// writeJSONString writes the string as json string, json.Marshal never fails on string.
+func writeJSONString(buf *buffer, str string) {
+	byt, _ := json.Marshal(str)
+	buf.Write(byt)
+}
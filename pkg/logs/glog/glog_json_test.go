@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package glog
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatJSON(t *testing.T) {
+	timeNow = func() time.Time {
+		return time.Date(2023, 6, 17, 10, 0, 0, 0, time.UTC)
+	}
+	defer func() {
+		timeNow = time.Now
+	}()
+
+	staticFields = []interface{}{"service", "cloud-server"}
+	defer func() {
+		staticFields = nil
+	}()
+
+	l := new(loggingT)
+	buf := l.formatJSON(errorLog, "handler.go", 10, "create vpc failed\n",
+		[]interface{}{"rid", "abc", "count", 2, "err", errors.New("quota exceeded"), "odd"})
+
+	line := buf.String()
+	if !strings.HasSuffix(line, "}\n") {
+		t.Fatalf("json log line should end with new line, line: %s", line)
+	}
+
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		t.Fatalf("unmarshal json log line failed, err: %v, line: %s", err, line)
+	}
+
+	expected := map[string]interface{}{
+		"time":    "2023-06-17T10:00:00.000000Z",
+		"level":   "ERROR",
+		"caller":  "handler.go:10",
+		"msg":     "create vpc failed",
+		"service": "cloud-server",
+		"rid":     "abc",
+		"count":   float64(2),
+		"err":     "quota exceeded",
+		"odd":     nil,
+	}
+	if len(fields) != len(expected) {
+		t.Errorf("json log fields %v is not equal to %v", fields, expected)
+	}
+	for key, value := range expected {
+		if fields[key] != value {
+			t.Errorf("json log field %s is %v, expected %v", key, fields[key], value)
+		}
+	}
+}
+
+func TestWriteTextFields(t *testing.T) {
+	buf := new(buffer)
+	buf.WriteString("create vpc failed\n")
+	writeTextFields(buf, []interface{}{"rid", "abc", "err", errors.New("quota exceeded")})
+
+	expected := "create vpc failed, rid: abc, err: quota exceeded"
+	if buf.String() != expected {
+		t.Errorf("text log is %s, expected %s", buf.String(), expected)
+	}
+}
@@ -72,6 +72,9 @@ type LogConfig struct {
 	StdErrThreshold    string
 	VModule            string
 	TraceLocation      string
+	// JSONFormat print the logs as json objects, the Service is added to every log as a field.
+	JSONFormat bool
+	Service    string
 }
 
 // InitLogger initializes logs the way we want for blog.
@@ -80,6 +83,10 @@ func InitLogger(logConfig LogConfig) {
 		int32(logConfig.Verbosity), logConfig.StdErrThreshold, logConfig.VModule, logConfig.TraceLocation,
 		logConfig.LogDir, logConfig.LogMaxSize, logConfig.LogLineMaxSize, int(logConfig.LogMaxNum))
 
+	if logConfig.JSONFormat {
+		glog.SetJSONFormat(FieldService, logConfig.Service)
+	}
+
 	// show inner start info.
 	glog.Info(version.GetStartInfo())
 	glog.Flush()
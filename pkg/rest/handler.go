@@ -34,9 +34,12 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
+	"hcm/pkg/tracing"
 
 	"github.com/emicklei/go-restful/v3"
 	prm "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var once sync.Once
@@ -127,7 +130,18 @@ func (r *Handler) wrapperAction(action *action) func(req *restful.Request, resp
 		cts.Request = req
 		cts.resp = resp
 
-		kt, err := kit.FromHeader(req.Request.Context(), req.Request.Header)
+		// the trace context of the caller is carried by the headers, the handler span continues the trace.
+		ctx := tracing.Extract(req.Request.Context(), req.Request.Header)
+		ctx, span := tracing.Tracer().Start(ctx, action.Alias, trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("http.method", action.Verb),
+				attribute.String("http.route", action.Path)))
+
+		var err error
+		defer func() {
+			tracing.End(span, err)
+		}()
+
+		kt, err := kit.FromHeader(ctx, req.Request.Header)
 		if err != nil {
 			rid := req.Request.Header.Get(constant.RidKey)
 			logs.Errorf("invalid request for %s, err: %v, rid: %s", action.Alias, err, rid)
@@ -145,6 +159,8 @@ func (r *Handler) wrapperAction(action *action) func(req *restful.Request, resp
 		}()
 
 		cts.Kit = kt
+		span.SetAttributes(attribute.String("rid", kt.Rid), attribute.String("user", kt.User),
+			attribute.String("app_code", kt.AppCode))
 
 		// print request log when log level is 4 or request is write request
 		if (bool(logs.V(4)) || (!strings.Contains(req.Request.URL.Path, "/list/") &&
@@ -167,14 +183,20 @@ func (r *Handler) wrapperAction(action *action) func(req *restful.Request, resp
 			if err := json.Compact(compactJson, byt); err == nil {
 				compactBody = compactJson.String()
 			}
-			logs.Infof("%s received restful request, body: %s, rid: %s", action.Alias, compactBody, kt.Rid)
+			kt.Logger().Infow("received restful request", "action", action.Alias, "body", compactBody)
+		}
+
+		record, done := beginIdempotent(cts, action.Verb)
+		if done {
+			return
 		}
 
 		start := time.Now()
 		reply, err := action.Handler(cts)
+		endIdempotent(cts, record, reply, err)
 		if err != nil {
 			if logs.V(2) {
-				logs.Errorf("do restful request %s failed, err: %v, rid: %s", action.Alias, err, cts.Kit.Rid)
+				kt.Logger().Errorw("do restful request failed", "action", action.Alias, "err", err)
 			}
 
 			if reply != nil {
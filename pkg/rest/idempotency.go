@@ -0,0 +1,166 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// maxIdempotencyKeyLen is the max length of the idempotency key.
+const maxIdempotencyKeyLen = 255
+
+// IdempotencyRecord is the record of a request with idempotency key.
+type IdempotencyRecord struct {
+	// Owner is the caller that the key belongs to, keys of different callers do not affect each other.
+	Owner string
+	Key   string
+	// RequestHash is the sha256 of the request method, url and body, it's used to reject the conflicting payloads.
+	RequestHash string
+	// Completed is true if the request is succeeded and the response is saved.
+	Completed bool
+	// Response is the response data of the completed request.
+	Response json.RawMessage
+}
+
+// IdempotencyStore persists the idempotency keys of the mutating requests and their responses.
+type IdempotencyStore interface {
+	// Acquire saves the record as processing if the key is not used, returns nil if it's saved, otherwise returns the
+	// existing record of the key.
+	Acquire(kt *kit.Kit, record *IdempotencyRecord) (*IdempotencyRecord, error)
+	// Complete saves the response of the succeeded request for replay.
+	Complete(kt *kit.Kit, record *IdempotencyRecord) error
+	// Release removes the processing record of the failed request, so that it can be retried with the same key.
+	Release(kt *kit.Kit, record *IdempotencyRecord) error
+}
+
+// idempotencyStore is the idempotency store of the process, idempotency key is ignored if it's not set.
+var idempotencyStore IdempotencyStore
+
+// SetIdempotencyStore enables the idempotency key for all the mutating routes of the process, the non GET requests
+// with Idempotency-Key header are executed only once, and the saved response is returned when they are replayed.
+// it should be called before the server is started.
+func SetIdempotencyStore(store IdempotencyStore) {
+	idempotencyStore = store
+}
+
+// beginIdempotent checks the idempotency key of the request, returns the acquired record if the request should be
+// executed, and returns done if the response has been written because the request is replayed or rejected.
+func beginIdempotent(cts *Contexts, verb string) (record *IdempotencyRecord, done bool) {
+	store := idempotencyStore
+	if store == nil || verb == http.MethodGet {
+		return nil, false
+	}
+
+	key := cts.Request.Request.Header.Get(constant.IdempotencyKey)
+	if len(key) == 0 {
+		return nil, false
+	}
+
+	if len(key) > maxIdempotencyKeyLen {
+		cts.WithStatusCode(http.StatusBadRequest)
+		cts.respError(errf.New(errf.InvalidParameter, fmt.Sprintf("%s length should <= %d", constant.IdempotencyKey,
+			maxIdempotencyKeyLen)))
+		return nil, true
+	}
+
+	body, err := cts.RequestBody()
+	if err != nil {
+		cts.WithStatusCode(http.StatusBadRequest)
+		cts.respError(errf.NewFromErr(errf.InvalidParameter, err))
+		return nil, true
+	}
+
+	record = &IdempotencyRecord{
+		Owner:       cts.Kit.AppCode + "/" + cts.Kit.User,
+		Key:         key,
+		RequestHash: requestHash(cts.Request.Request, body),
+	}
+
+	existing, err := store.Acquire(cts.Kit, record)
+	if err != nil {
+		logs.Errorf("acquire idempotency key %s failed, err: %v, rid: %s", key, err, cts.Kit.Rid)
+		cts.respError(err)
+		return nil, true
+	}
+
+	if existing == nil {
+		return record, false
+	}
+
+	if existing.RequestHash != record.RequestHash {
+		cts.WithStatusCode(http.StatusUnprocessableEntity)
+		cts.respError(errf.New(errf.IdempotencyConflict, "idempotency key is already used by another request "+
+			"with different payload"))
+		return nil, true
+	}
+
+	if !existing.Completed {
+		cts.WithStatusCode(http.StatusConflict)
+		cts.respError(errf.New(errf.IdempotencyConflict, "request with the same idempotency key is in progress"))
+		return nil, true
+	}
+
+	logs.V(3).Infof("replay request with idempotency key %s, rid: %s", key, cts.Kit.Rid)
+	cts.resp.Header().Set(constant.IdempotentReplayedKey, "true")
+	cts.respEntity(existing.Response)
+	return nil, true
+}
+
+// endIdempotent saves the response of the succeeded request, or releases the key of the failed request so that it
+// can be retried.
+func endIdempotent(cts *Contexts, record *IdempotencyRecord, reply interface{}, err error) {
+	if record == nil {
+		return
+	}
+
+	store := idempotencyStore
+	if err == nil {
+		record.Response, err = json.Marshal(reply)
+	}
+
+	if err != nil {
+		if releaseErr := store.Release(cts.Kit, record); releaseErr != nil {
+			logs.Errorf("release idempotency key %s failed, err: %v, rid: %s", record.Key, releaseErr, cts.Kit.Rid)
+		}
+		return
+	}
+
+	record.Completed = true
+	if err = store.Complete(cts.Kit, record); err != nil {
+		logs.Errorf("complete idempotency key %s failed, err: %v, rid: %s", record.Key, err, cts.Kit.Rid)
+	}
+}
+
+// requestHash returns the sha256 of the request method, url and body.
+func requestHash(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
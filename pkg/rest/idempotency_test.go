@@ -0,0 +1,232 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+type memIdempotencyStore struct {
+	lock    sync.Mutex
+	records map[string]*IdempotencyRecord
+}
+
+func (s *memIdempotencyStore) Acquire(_ *kit.Kit, record *IdempotencyRecord) (*IdempotencyRecord, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	id := record.Owner + "/" + record.Key
+	if existing, exist := s.records[id]; exist {
+		return existing, nil
+	}
+
+	s.records[id] = &IdempotencyRecord{Owner: record.Owner, Key: record.Key, RequestHash: record.RequestHash}
+	return nil, nil
+}
+
+func (s *memIdempotencyStore) Complete(_ *kit.Kit, record *IdempotencyRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.records[record.Owner+"/"+record.Key] = record
+	return nil
+}
+
+func (s *memIdempotencyStore) Release(_ *kit.Kit, record *IdempotencyRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.records, record.Owner+"/"+record.Key)
+	return nil
+}
+
+type idempotencyTester struct {
+	t         *testing.T
+	container *restful.Container
+	store     *memIdempotencyStore
+	executed  int
+}
+
+func newIdempotencyTester(t *testing.T) *idempotencyTester {
+	tester := &idempotencyTester{
+		t:     t,
+		store: &memIdempotencyStore{records: make(map[string]*IdempotencyRecord)},
+	}
+	SetIdempotencyStore(tester.store)
+	t.Cleanup(func() { SetIdempotencyStore(nil) })
+
+	handler := func(cts *Contexts) (interface{}, error) {
+		req := make(map[string]interface{})
+		if err := cts.DecodeInto(&req); err != nil {
+			return nil, err
+		}
+
+		if req["fail"] == true {
+			return nil, errf.New(errf.Aborted, "failed")
+		}
+
+		tester.executed++
+		return map[string]int{"executed": tester.executed}, nil
+	}
+
+	h := NewHandler()
+	h.Add("Create", http.MethodPost, "/items/create", handler)
+	h.Add("List", http.MethodGet, "/items", func(cts *Contexts) (interface{}, error) {
+		tester.executed++
+		return nil, nil
+	})
+
+	ws := new(restful.WebService)
+	ws.Path("/api/v1").Produces(restful.MIME_JSON)
+	h.Load(ws)
+
+	tester.container = restful.NewContainer()
+	tester.container.Add(ws)
+	return tester
+}
+
+func (it *idempotencyTester) do(method, key, body string) *httptest.ResponseRecorder {
+	path := "/api/v1/items/create"
+	if method == http.MethodGet {
+		path = "/api/v1/items"
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", restful.MIME_JSON)
+	req.Header.Set(constant.UserKey, "tester")
+	req.Header.Set(constant.AppCodeKey, "hcm")
+	req.Header.Set(constant.RidKey, "idempotency-test-rid-0001")
+	if len(key) != 0 {
+		req.Header.Set(constant.IdempotencyKey, key)
+	}
+
+	w := httptest.NewRecorder()
+	it.container.ServeHTTP(w, req)
+	return w
+}
+
+func (it *idempotencyTester) executedOf(w *httptest.ResponseRecorder) int {
+	resp := new(struct {
+		Code int32 `json:"code"`
+		Data struct {
+			Executed int `json:"executed"`
+		} `json:"data"`
+	})
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		it.t.Fatalf("unmarshal response %s failed, err: %v", w.Body.String(), err)
+	}
+
+	if resp.Code != errf.OK {
+		it.t.Fatalf("request failed, response: %s", w.Body.String())
+	}
+	return resp.Data.Executed
+}
+
+func TestIdempotencyReplay(t *testing.T) {
+	it := newIdempotencyTester(t)
+
+	first := it.do(http.MethodPost, "key-1", `{"name":"a"}`)
+	if executed := it.executedOf(first); executed != 1 {
+		t.Fatalf("first request should be executed, got %d", executed)
+	}
+
+	if first.Header().Get(constant.IdempotentReplayedKey) != "" {
+		t.Fatalf("first request should not be marked as replayed")
+	}
+
+	replay := it.do(http.MethodPost, "key-1", `{"name":"a"}`)
+	if executed := it.executedOf(replay); executed != 1 {
+		t.Fatalf("replayed request should return the stored response, got %d", executed)
+	}
+
+	if replay.Header().Get(constant.IdempotentReplayedKey) != "true" {
+		t.Fatalf("replayed request should be marked as replayed")
+	}
+
+	if it.executed != 1 {
+		t.Fatalf("replayed request should not be executed, executed %d times", it.executed)
+	}
+
+	// request without idempotency key is always executed.
+	if executed := it.executedOf(it.do(http.MethodPost, "", `{"name":"a"}`)); executed != 2 {
+		t.Fatalf("request without key should be executed, got %d", executed)
+	}
+}
+
+func TestIdempotencyConflict(t *testing.T) {
+	it := newIdempotencyTester(t)
+
+	it.executedOf(it.do(http.MethodPost, "key-1", `{"name":"a"}`))
+
+	w := it.do(http.MethodPost, "key-1", `{"name":"b"}`)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("conflicting payload should be rejected with 422, got %d", w.Code)
+	}
+
+	it.store.records["hcm/tester/key-2"] = &IdempotencyRecord{Owner: "hcm/tester", Key: "key-2",
+		RequestHash: requestHash(httptest.NewRequest(http.MethodPost, "/api/v1/items/create", nil), []byte(`{}`))}
+	w = it.do(http.MethodPost, "key-2", `{}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("in progress request should be rejected with 409, got %d", w.Code)
+	}
+
+	if it.executed != 1 {
+		t.Fatalf("rejected request should not be executed, executed %d times", it.executed)
+	}
+}
+
+func TestIdempotencyReleaseOnError(t *testing.T) {
+	it := newIdempotencyTester(t)
+
+	it.do(http.MethodPost, "key-1", `{"fail":true}`)
+	if _, exist := it.store.records["hcm/tester/key-1"]; exist {
+		t.Fatalf("key of the failed request should be released")
+	}
+
+	// a different payload is allowed to retry with the released key.
+	if executed := it.executedOf(it.do(http.MethodPost, "key-1", `{"fail":false}`)); executed != 1 {
+		t.Fatalf("retried request should be executed, got %d", executed)
+	}
+}
+
+func TestIdempotencyIgnoreGet(t *testing.T) {
+	it := newIdempotencyTester(t)
+
+	it.do(http.MethodGet, "key-1", "")
+	it.do(http.MethodGet, "key-1", "")
+	if it.executed != 2 {
+		t.Fatalf("get request should ignore idempotency key, executed %d times", it.executed)
+	}
+
+	if len(it.store.records) != 0 {
+		t.Fatalf("get request should not save idempotency record")
+	}
+}
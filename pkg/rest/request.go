@@ -40,8 +40,11 @@ import (
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest/client"
+	"hcm/pkg/tracing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // VerbType http request verb type
@@ -336,13 +339,34 @@ func (r *Request) tryThrottle(url string) {
 
 // Do http request do.
 func (r *Request) Do() *Result {
-	result := new(Result)
-
 	rid := ridFromContext(r.ctx)
 	if rid == "" {
 		rid = r.headers.Get(constant.RidKey)
 	}
 
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := tracing.Tracer().Start(ctx, string(r.verb)+" "+r.subPath, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("http.method", string(r.verb)), attribute.String("rid", rid)))
+
+	result := r.do(ctx, rid)
+
+	err := result.Err
+	if err == nil && result.StatusCode >= http.StatusBadRequest {
+		err = errors.New(result.Status)
+	}
+	span.SetAttributes(attribute.Int("http.status_code", result.StatusCode))
+	tracing.End(span, err)
+
+	return result
+}
+
+// do the http request, the trace context of ctx is passed to the server by the headers.
+func (r *Request) do(ctx context.Context, rid string) *Result {
+	result := new(Result)
+
 	if r.err != nil {
 		result.Err = r.err
 		return result
@@ -362,7 +386,7 @@ func (r *Request) Do() *Result {
 	maxRetryCycle := 3
 	for try := 0; try < maxRetryCycle; try++ {
 		for index, host := range hosts {
-			result, isComplete := r.doWithHost(client, host, try+index, rid)
+			result, isComplete := r.doWithHost(ctx, client, host, try+index, rid)
 			if isComplete {
 				return result
 			}
@@ -374,7 +398,9 @@ func (r *Request) Do() *Result {
 }
 
 // doWithHost http request do with specific host.
-func (r *Request) doWithHost(client client.HTTPClient, host string, retries int, rid string) (*Result, bool) {
+func (r *Request) doWithHost(ctx context.Context, client client.HTTPClient, host string, retries int, rid string) (
+	*Result, bool) {
+
 	contentType := r.contentType
 
 	switch r.contentType {
@@ -400,6 +426,8 @@ func (r *Request) doWithHost(client client.HTTPClient, host string, retries int,
 		req.Header = make(http.Header)
 	}
 
+	tracing.Inject(ctx, req.Header)
+
 	req.Header.Del("Accept-Encoding")
 	req.Header.Set("Content-Type", string(contentType))
 	req.Header.Set("Accept", "application/json")
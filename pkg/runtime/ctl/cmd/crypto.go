@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmd
+
+import (
+	"hcm/pkg/kit"
+)
+
+// ReEncryptFunc re-encrypt the secrets by the current crypto key, only returns the result if dry run is set.
+type ReEncryptFunc func(kt *kit.Kit, dryRun bool) (interface{}, error)
+
+// WithReEncryptAccountSecret init and returns the re-encrypt account secret command, it is used to migrate the
+// account secrets to the current crypto key after the key is rotated.
+func WithReEncryptAccountSecret(reEncrypt ReEncryptFunc) Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name: "re-encrypt-account-secret",
+			Usage: "re-encrypt account secrets and secrets in unfinished applications which are not encrypted by " +
+				"the current crypto key and algorithm",
+			Parameters: []Parameter{{
+				Name:  "dry_run",
+				Usage: "defines if only count the secrets need to be re-encrypted, default is false",
+				Value: new(bool),
+			}},
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				dryRun := false
+				if val, exists := params["dry_run"]; exists {
+					dryRun = *val.(*bool)
+				}
+
+				return reEncrypt(kt, dryRun)
+			},
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmd
+
+import (
+	"hcm/pkg/kit"
+)
+
+// MigrateFunc apply the pending schema migrations, only returns the pending migrations if dry run is set.
+type MigrateFunc func(kt *kit.Kit, dryRun bool) (interface{}, error)
+
+// WithMigrate init and returns the schema migration command.
+func WithMigrate(migrate MigrateFunc) Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name:  "migrate",
+			Usage: "apply the pending db schema migrations in order of version",
+			Parameters: []Parameter{{
+				Name:  "dry_run",
+				Usage: "defines if only return the pending migrations and their sql without applying, default is false",
+				Value: new(bool),
+			}},
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				dryRun := false
+				if val, exists := params["dry_run"]; exists {
+					dryRun = *val.(*bool)
+				}
+
+				return migrate(kt, dryRun)
+			},
+		},
+	}
+
+	return cmd
+}
@@ -63,6 +63,8 @@ type ExprOption struct {
 	// MaxRulesLimit defines the max number of rules an expression allows.
 	// If not set, then use default value: DefaultMaxRuleLimit
 	MaxRulesLimit uint
+	// TagRule defines if the tag rules whose field is prefixed with TagFieldPrefix are allowed.
+	TagRule bool
 }
 
 // ExprOptionFunc expr option func defines.
@@ -96,8 +98,15 @@ func MaxRulesLimit(limit uint) ExprOptionFunc {
 	}
 }
 
+// EnableTagRule allow tag rules func.
+func EnableTagRule() ExprOptionFunc {
+	return func(opt *ExprOption) {
+		opt.TagRule = true
+	}
+}
+
 // NewExprOption new expr option.
-// ExprOptionFunc: RuleFields、MaxInLimit、MaxNotInLimit、MaxRulesLimit
+// ExprOptionFunc: RuleFields、MaxInLimit、MaxNotInLimit、MaxRulesLimit、EnableTagRule
 func NewExprOption(opts ...ExprOptionFunc) *ExprOption {
 	exprOpt := new(ExprOption)
 	for _, opt := range opts {
@@ -165,6 +174,10 @@ func (exp Expression) Validate(opt *ExprOption) (hitErr error) {
 
 		// all the rule's filed should exist in the reminder.
 		for one := range fieldsReminder {
+			if opt.TagRule && IsTagField(one) {
+				continue
+			}
+
 			if exist := reminder[one]; !exist {
 				return fmt.Errorf("expression rules filed(%s) should not exist(not supported)", one)
 			}
@@ -447,6 +460,10 @@ func (ar AtomRule) Validate(opt *ExprOption) error {
 		return errors.New("rule value can not be nil")
 	}
 
+	if IsTagField(ar.Field) {
+		return ar.validateTagRule(opt)
+	}
+
 	if opt != nil {
 		typ, exist := opt.RuleFields[ar.Field]
 		if !exist {
@@ -537,6 +554,10 @@ func (ar AtomRule) RuleField() string {
 
 // SQLExprAndValue convert this atom rule to a mysql's sub query expression, and field's value.
 func (ar AtomRule) SQLExprAndValue(opt *SQLWhereOption) (string, map[string]interface{}, error) {
+	if IsTagField(ar.Field) {
+		return ar.tagSQLExprAndValue(opt)
+	}
+
 	expr, value, err := ar.Op.Operator().SQLExprAndValue(ar.Field, ar.Value)
 	if err != nil {
 		return "", nil, err
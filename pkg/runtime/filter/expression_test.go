@@ -479,3 +479,69 @@ func TestNestedSqlWhereExpr(t *testing.T) {
 		return
 	}
 }
+
+func TestTagRuleSQLWhereExpr(t *testing.T) {
+	expr := &Expression{
+		Op: And,
+		Rules: []RuleFactory{
+			&AtomRule{Field: "vendor", Op: Equal.Factory(), Value: "tcloud"},
+			&AtomRule{Field: "tags.env", Op: In.Factory(), Value: []interface{}{"prod", "test"}},
+		},
+	}
+
+	opt := NewExprOption(RuleFields(map[string]enumor.ColumnType{"vendor": enumor.String}))
+	if err := expr.Validate(opt); err == nil {
+		t.Errorf("validate tag rule without tag rule enabled should be failed")
+		return
+	}
+
+	opt = NewExprOption(RuleFields(map[string]enumor.ColumnType{"vendor": enumor.String}), EnableTagRule())
+	if err := expr.Validate(opt); err != nil {
+		t.Errorf("validate tag rule failed, err: %v", err)
+		return
+	}
+
+	invalid := &Expression{
+		Op:    And,
+		Rules: []RuleFactory{&AtomRule{Field: "tags.env", Op: GreaterThan.Factory(), Value: "prod"}},
+	}
+	if err := invalid.Validate(opt); err == nil {
+		t.Errorf("validate tag rule with unsupported operator should be failed")
+		return
+	}
+
+	if _, _, err := expr.SQLWhereExpr(&SQLWhereOption{Priority: Priority{"id"}}); err == nil {
+		t.Errorf("generate tag rule sql without tag option should be failed")
+		return
+	}
+
+	where, value, err := expr.SQLWhereExpr(&SQLWhereOption{
+		Priority:  Priority{"id"},
+		TagOption: &TagOption{Table: "resource_tag", ResType: "cvm"},
+	})
+	if err != nil {
+		t.Errorf("generate tag rule sql failed, err: %v", err)
+		return
+	}
+
+	if !strings.Contains(where, "id IN (SELECT res_id FROM resource_tag WHERE res_type = :tag_res_type") ||
+		!strings.Contains(where, "tag_value IN (:tag_value") {
+		t.Errorf("tag rule sql is not expected, sql: %s", where)
+		return
+	}
+
+	var hasResType, hasKey bool
+	for _, one := range value {
+		switch one {
+		case "cvm":
+			hasResType = true
+		case "env":
+			hasKey = true
+		}
+	}
+
+	if !hasResType || !hasKey {
+		t.Errorf("tag rule sql value is not expected, value: %v", value)
+		return
+	}
+}
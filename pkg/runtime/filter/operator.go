@@ -56,6 +56,9 @@ func init() {
 	opFactory[ContainsSensitive.Factory()] = ContainsSensitiveOp(ContainsSensitive)
 	opFactory[ContainsInsensitive.Factory()] = ContainsInsensitiveOp(ContainsInsensitive)
 
+	opFactory[StartsWith.Factory()] = StartsWithOp(StartsWith)
+	opFactory[NotStartsWith.Factory()] = NotStartsWithOp(NotStartsWith)
+
 	opFactory[JSONEqual.Factory()] = JSONEqualOp(JSONEqual)
 	opFactory[JSONIn.Factory()] = JSONInOp(JSONIn)
 	opFactory[JSONContains.Factory()] = JSONContainsOp(JSONContains)
@@ -134,6 +137,10 @@ const (
 	// ContainsInsensitive operator match the value with
 	// regular expression with case-insensitive.
 	ContainsInsensitive OpType = "cis"
+	// StartsWith operator match the value as the case-sensitive prefix of the field.
+	StartsWith OpType = "starts_with"
+	// NotStartsWith operator match the field that does not start with the value.
+	NotStartsWith OpType = "not_starts_with"
 )
 
 // reference: https://dev.mysql.com/doc/refman/5.7/en/json-function-reference.html
@@ -164,7 +171,8 @@ func (op OpType) Validate() error {
 		GreaterThan, GreaterThanEqual,
 		LessThan, LessThanEqual,
 		In, NotIn,
-		ContainsSensitive, ContainsInsensitive:
+		ContainsSensitive, ContainsInsensitive,
+		StartsWith, NotStartsWith:
 
 	case JSONEqual, JSONIn, JSONContains, JSONOverlaps,
 		JSONContainsPath, JSONNotContainsPath, JSONLength:
@@ -677,6 +685,84 @@ func (cio ContainsInsensitiveOp) SQLExprAndValue(field string, value interface{}
 		map[string]interface{}{placeholder: "%" + s + "%"}, nil
 }
 
+// StartsWithOp is starts with operator
+type StartsWithOp OpType
+
+// Name is 'like' expression with prefix operator
+func (swo StartsWithOp) Name() OpType {
+	return StartsWith
+}
+
+// ValidateValue validate 'like' prefix operator's value
+func (swo StartsWithOp) ValidateValue(v interface{}, opt *ExprOption) error {
+	_, err := prefixValue(StartsWith, v)
+	return err
+}
+
+// SQLExprAndValue convert this operator's field and value to a mysql's sub
+// query expression.
+func (swo StartsWithOp) SQLExprAndValue(field string, value interface{}) (string, map[string]interface{},
+	error) {
+
+	return prefixSQLExprAndValue(StartsWith, "LIKE BINARY", field, value)
+}
+
+// NotStartsWithOp is not starts with operator
+type NotStartsWithOp OpType
+
+// Name is 'not like' expression with prefix operator
+func (nswo NotStartsWithOp) Name() OpType {
+	return NotStartsWith
+}
+
+// ValidateValue validate 'not like' prefix operator's value
+func (nswo NotStartsWithOp) ValidateValue(v interface{}, opt *ExprOption) error {
+	_, err := prefixValue(NotStartsWith, v)
+	return err
+}
+
+// SQLExprAndValue convert this operator's field and value to a mysql's sub
+// query expression.
+func (nswo NotStartsWithOp) SQLExprAndValue(field string, value interface{}) (string, map[string]interface{},
+	error) {
+
+	return prefixSQLExprAndValue(NotStartsWith, "NOT LIKE BINARY", field, value)
+}
+
+// prefixValue returns the prefix value of the starts with operators, which should be a non-empty string.
+func prefixValue(op OpType, v interface{}) (string, error) {
+	value, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%s operator's value should be an string", op)
+	}
+
+	if len(value) == 0 {
+		return "", fmt.Errorf("%s operator's value can not be a empty string", op)
+	}
+
+	return value, nil
+}
+
+// likePrefixEscaper escapes the wildcard characters of 'like' expression, so that the prefix is matched literally.
+var likePrefixEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func prefixSQLExprAndValue(op OpType, like string, field string, value interface{}) (string,
+	map[string]interface{}, error) {
+
+	if len(field) == 0 {
+		return "", nil, errors.New("field is empty")
+	}
+
+	prefix, err := prefixValue(op, value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	placeholder := fieldPlaceholderName(field)
+	return fmt.Sprintf(`%s %s %s%s`, field, like, SqlPlaceholder, placeholder),
+		map[string]interface{}{placeholder: likePrefixEscaper.Replace(prefix) + "%"}, nil
+}
+
 // JSONEqualOp is json field equal operator
 type JSONEqualOp OpType
 
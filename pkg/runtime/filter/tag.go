@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TagFieldPrefix is the field prefix of the tag rule, e.g. field "tags.env" matches the resources whose tag "env"
+// value matches the rule. Resources without the tag never match the tag rule, including neq and nin rules.
+const TagFieldPrefix = "tags."
+
+// tagKeyMaxLength is the max length of tag key.
+const tagKeyMaxLength = 255
+
+// TagOption defines how to convert the tag rules to SQL sub query of the resource tag table.
+type TagOption struct {
+	// Table is the resource tag table name.
+	Table string
+	// ResType is the resource type of the queried resource table.
+	ResType string
+}
+
+// IsTagField returns if the rule field is a tag field.
+func IsTagField(field string) bool {
+	return strings.HasPrefix(field, TagFieldPrefix)
+}
+
+// tagKey returns the tag key of the tag field.
+func tagKey(field string) string {
+	return strings.TrimPrefix(field, TagFieldPrefix)
+}
+
+// validateTagRule validate the tag rule, tag value is always string, only string operators are supported.
+func (ar AtomRule) validateTagRule(opt *ExprOption) error {
+	if opt == nil || !opt.TagRule {
+		return fmt.Errorf("tag rule field: %s is not supported", ar.Field)
+	}
+
+	key := tagKey(ar.Field)
+	if len(key) == 0 || len(key) > tagKeyMaxLength {
+		return fmt.Errorf("tag rule field: %s tag key length should be 1-%d", ar.Field, tagKeyMaxLength)
+	}
+
+	switch OpType(ar.Op) {
+	case Equal, NotEqual, In, NotIn, ContainsSensitive, ContainsInsensitive:
+	default:
+		return fmt.Errorf("tag rule field: %s does not support operator %s", ar.Field, ar.Op)
+	}
+
+	value := reflect.ValueOf(ar.Value)
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		for i := 0; i < value.Len(); i++ {
+			if _, ok := value.Index(i).Interface().(string); !ok {
+				return fmt.Errorf("tag rule field: %s value should be string", ar.Field)
+			}
+		}
+	} else if _, ok := ar.Value.(string); !ok {
+		return fmt.Errorf("tag rule field: %s value should be string", ar.Field)
+	}
+
+	if err := ar.Op.Operator().ValidateValue(ar.Value, opt); err != nil {
+		return fmt.Errorf("%s validate failed, %v", ar.Field, err)
+	}
+
+	return nil
+}
+
+// tagSQLExprAndValue convert the tag rule to the sub query of the resource tag table.
+func (ar AtomRule) tagSQLExprAndValue(opt *SQLWhereOption) (string, map[string]interface{}, error) {
+	if opt == nil || opt.TagOption == nil {
+		return "", nil, fmt.Errorf("tag rule field: %s is not supported", ar.Field)
+	}
+
+	if len(opt.TagOption.Table) == 0 || len(opt.TagOption.ResType) == 0 {
+		return "", nil, errors.New("tag option table and res type are required")
+	}
+
+	valueExpr, value, err := ar.Op.Operator().SQLExprAndValue("tag_value", ar.Value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	resTypePlaceholder := fieldPlaceholderName("tag_res_type")
+	keyPlaceholder := fieldPlaceholderName("tag_key")
+	value[resTypePlaceholder] = opt.TagOption.ResType
+	value[keyPlaceholder] = tagKey(ar.Field)
+
+	expr := fmt.Sprintf(`id IN (SELECT res_id FROM %s WHERE res_type = %s%s AND tag_key = %s%s AND %s)`,
+		opt.TagOption.Table, SqlPlaceholder, resTypePlaceholder, SqlPlaceholder, keyPlaceholder, valueExpr)
+
+	return expr, value, nil
+}
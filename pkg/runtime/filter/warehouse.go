@@ -68,6 +68,8 @@ type SQLWhereOption struct {
 	// field during query.
 	Priority      Priority
 	CrownedOption *CrownedOption
+	// TagOption defines how to convert the tag rules to SQL expression, tag rules are not supported if it is nil.
+	TagOption *TagOption
 }
 
 // Validate the options is valid or not
@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package graphql
+
+// Document is the parsed graphql query document.
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is an operation definition of the document.
+type Operation struct {
+	// Type is the operation type, query, mutation or subscription.
+	Type      string
+	Name      string
+	Variables []*VariableDefinition
+	// SelectionSet is the top level selections of the operation.
+	SelectionSet []Selection
+}
+
+// VariableDefinition is the definition of an operation variable.
+type VariableDefinition struct {
+	Name string
+	// Type is the literal type of the variable, e.g. [String!]!, variables are not type checked for now.
+	Type    string
+	Default interface{}
+}
+
+// Fragment is a named fragment definition.
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+// Selection is one of *Field, *FragmentSpread and *InlineFragment.
+type Selection interface {
+	directives() []*Directive
+}
+
+// Field is a field selection.
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+}
+
+// ResponseKey returns the key of the field in the response.
+func (f *Field) ResponseKey() string {
+	if len(f.Alias) != 0 {
+		return f.Alias
+	}
+	return f.Name
+}
+
+func (f *Field) directives() []*Directive {
+	return f.Directives
+}
+
+// FragmentSpread is a named fragment spread selection.
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+}
+
+func (f *FragmentSpread) directives() []*Directive {
+	return f.Directives
+}
+
+// InlineFragment is an inline fragment selection.
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+func (f *InlineFragment) directives() []*Directive {
+	return f.Directives
+}
+
+// Argument is an argument of field or directive.
+type Argument struct {
+	Name string
+	// Value is the argument value, it can be string, int64, float64, bool, nil, EnumValue, Variable,
+	// []interface{} or map[string]interface{}.
+	Value interface{}
+}
+
+// Directive is a directive of selection, only skip and include directives are supported.
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+}
+
+// Variable is a variable reference value.
+type Variable struct {
+	Name string
+}
+
+// EnumValue is an enum literal value.
+type EnumValue string
@@ -0,0 +1,480 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// typeNameField is the meta field that returns the object type name.
+const typeNameField = "__typename"
+
+// Execute parse and execute the graphql query request.
+func (s *Schema) Execute(kt *kit.Kit, req *Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return ErrorResponse(errf.NewFromErr(errf.InvalidParameter, err))
+	}
+
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return ErrorResponse(err)
+	}
+
+	e := &executor{
+		kit:       kt,
+		fragments: doc.Fragments,
+		variables: mergeVariables(op.Variables, req.Variables),
+		errors:    make([]*Error, 0),
+	}
+
+	if err = e.validate(s.Query, op.SelectionSet, 1, s.MaxDepth); err != nil {
+		return ErrorResponse(err)
+	}
+
+	root := &node{source: make(Source), result: newResultMap()}
+	e.executeNodes(s.Query, []*node{root}, op.SelectionSet)
+
+	return &Response{Data: root.result, Errors: e.errors}
+}
+
+// operation returns the operation to execute by operation name.
+func (d *Document) operation(name string) (*Operation, error) {
+	var op *Operation
+	if len(name) == 0 {
+		if len(d.Operations) > 1 {
+			return nil, errf.New(errf.InvalidParameter, "operationName is required when document has multiple "+
+				"operations")
+		}
+		op = d.Operations[0]
+	} else {
+		for _, one := range d.Operations {
+			if one.Name == name {
+				op = one
+				break
+			}
+		}
+
+		if op == nil {
+			return nil, errf.Newf(errf.InvalidParameter, "operation %s is not found", name)
+		}
+	}
+
+	if op.Type != "query" {
+		return nil, errf.Newf(errf.InvalidParameter, "%s operation is not supported, only query is supported",
+			op.Type)
+	}
+
+	return op, nil
+}
+
+// mergeVariables returns the variables with the default values of the operation variable definitions.
+func mergeVariables(definitions []*VariableDefinition, input map[string]interface{}) map[string]interface{} {
+	variables := make(map[string]interface{})
+	for _, definition := range definitions {
+		if value, exists := input[definition.Name]; exists {
+			variables[definition.Name] = normalizeValue(value)
+			continue
+		}
+
+		variables[definition.Name] = definition.Default
+	}
+
+	return variables
+}
+
+// normalizeValue convert the json decoded variable value to the same types of parsed literal values.
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+
+	case float64:
+		if v == float64(int64(v)) {
+			return int64(v)
+		}
+		return v
+
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i := range v {
+			list[i] = normalizeValue(v[i])
+		}
+		return list
+
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			object[key] = normalizeValue(val)
+		}
+		return object
+
+	default:
+		return v
+	}
+}
+
+type executor struct {
+	kit       *kit.Kit
+	fragments map[string]*Fragment
+	variables map[string]interface{}
+	errors    []*Error
+}
+
+// node is a resolved object with its response path and result.
+type node struct {
+	source Source
+	path   []interface{}
+	result *ResultMap
+}
+
+// collectedField is the field selections of the same response key.
+type collectedField struct {
+	key          string
+	name         string
+	arguments    []*Argument
+	selectionSet []Selection
+}
+
+// collectFields collect the field selections of the object type, fragments are expanded and selections of the
+// same response key are merged.
+func (e *executor) collectFields(obj *Object, selections []Selection) ([]*collectedField, error) {
+	fields := make([]*collectedField, 0)
+	index := make(map[string]*collectedField)
+
+	if err := e.doCollectFields(obj, selections, &fields, index, make(map[string]bool)); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func (e *executor) doCollectFields(obj *Object, selections []Selection, fields *[]*collectedField,
+	index map[string]*collectedField, visited map[string]bool) error {
+
+	for _, selection := range selections {
+		included, err := e.included(selection.directives())
+		if err != nil {
+			return err
+		}
+
+		if !included {
+			continue
+		}
+
+		switch s := selection.(type) {
+		case *Field:
+			key := s.ResponseKey()
+			if collected, exists := index[key]; exists {
+				if collected.name != s.Name {
+					return errf.Newf(errf.InvalidParameter, "fields %s and %s conflict because they have the "+
+						"same response name %s", collected.name, s.Name, key)
+				}
+				collected.selectionSet = append(collected.selectionSet, s.SelectionSet...)
+				continue
+			}
+
+			collected := &collectedField{key: key, name: s.Name, arguments: s.Arguments,
+				selectionSet: append([]Selection{}, s.SelectionSet...)}
+			index[key] = collected
+			*fields = append(*fields, collected)
+
+		case *FragmentSpread:
+			if visited[s.Name] {
+				continue
+			}
+			visited[s.Name] = true
+
+			fragment, exists := e.fragments[s.Name]
+			if !exists {
+				return errf.Newf(errf.InvalidParameter, "fragment %s is not defined", s.Name)
+			}
+
+			if fragment.TypeCondition != obj.Name {
+				continue
+			}
+
+			if err = e.doCollectFields(obj, fragment.SelectionSet, fields, index, visited); err != nil {
+				return err
+			}
+
+		case *InlineFragment:
+			if len(s.TypeCondition) != 0 && s.TypeCondition != obj.Name {
+				continue
+			}
+
+			if err = e.doCollectFields(obj, s.SelectionSet, fields, index, visited); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// included returns if the selection is included by the skip and include directives.
+func (e *executor) included(directives []*Directive) (bool, error) {
+	for _, directive := range directives {
+		if directive.Name != "skip" && directive.Name != "include" {
+			continue
+		}
+
+		args := e.arguments(directive.Arguments)
+		cond, ok := args["if"].(bool)
+		if !ok {
+			return false, errf.Newf(errf.InvalidParameter, "argument if of directive @%s must be boolean",
+				directive.Name)
+		}
+
+		if (directive.Name == "skip" && cond) || (directive.Name == "include" && !cond) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// validate the selections against the schema before execution, so that no resolver is called for invalid query.
+func (e *executor) validate(obj *Object, selections []Selection, depth uint, maxDepth uint) error {
+	if maxDepth > 0 && depth > maxDepth {
+		return errf.Newf(errf.InvalidParameter, "query exceeds max depth %d", maxDepth)
+	}
+
+	fields, err := e.collectFields(obj, selections)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if field.name == typeNameField {
+			continue
+		}
+
+		definition, exists := obj.Fields[field.name]
+		if !exists {
+			return errf.Newf(errf.InvalidParameter, "cannot query field %s on type %s", field.name, obj.Name)
+		}
+
+		if definition.Type == nil {
+			if len(field.selectionSet) != 0 {
+				return errf.Newf(errf.InvalidParameter, "field %s of type %s is scalar and must not have "+
+					"selection", field.name, obj.Name)
+			}
+			continue
+		}
+
+		if len(field.selectionSet) == 0 {
+			return errf.Newf(errf.InvalidParameter, "field %s of type %s must have selection of type %s",
+				field.name, obj.Name, definition.Type.Name)
+		}
+
+		if err = e.validate(definition.Type, field.selectionSet, depth+1, maxDepth); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// arguments returns the argument values with variables replaced.
+func (e *executor) arguments(arguments []*Argument) map[string]interface{} {
+	args := make(map[string]interface{}, len(arguments))
+	for _, argument := range arguments {
+		args[argument.Name] = e.value(argument.Value)
+	}
+
+	return args
+}
+
+func (e *executor) value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *Variable:
+		return e.variables[v.Name]
+
+	case EnumValue:
+		return string(v)
+
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i := range v {
+			list[i] = e.value(v[i])
+		}
+		return list
+
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			object[key] = e.value(val)
+		}
+		return object
+
+	default:
+		return v
+	}
+}
+
+// executeNodes resolve the selections of all the nodes of the object type, each field is resolved once for all the
+// nodes, and the child nodes of all the nodes are resolved together.
+func (e *executor) executeNodes(obj *Object, nodes []*node, selections []Selection) {
+	fields, err := e.collectFields(obj, selections)
+	if err != nil {
+		// fields are already collected in validation, this should not happen.
+		logs.Errorf("collect graphql fields of type %s failed, err: %v, rid: %s", obj.Name, err, e.kit.Rid)
+		return
+	}
+
+	for _, field := range fields {
+		if field.name == typeNameField {
+			for _, n := range nodes {
+				n.result.Set(field.key, obj.Name)
+			}
+			continue
+		}
+
+		definition := obj.Fields[field.name]
+		values, err := e.resolve(field, definition, nodes)
+		if err != nil {
+			for _, n := range nodes {
+				n.result.Set(field.key, nil)
+				e.errors = append(e.errors, newError(err, appendPath(n.path, field.key)))
+			}
+			continue
+		}
+
+		children := make([]*node, 0)
+		for i, n := range nodes {
+			path := appendPath(n.path, field.key)
+
+			switch value := values[i].(type) {
+			case error:
+				n.result.Set(field.key, nil)
+				e.errors = append(e.errors, newError(value, path))
+
+			case Source:
+				if definition.Type == nil {
+					n.result.Set(field.key, value)
+					continue
+				}
+
+				child := &node{source: value, path: path, result: newResultMap()}
+				children = append(children, child)
+				n.result.Set(field.key, child.result)
+
+			case []Source:
+				if definition.Type == nil {
+					n.result.Set(field.key, value)
+					continue
+				}
+
+				list := make([]*ResultMap, len(value))
+				for idx, source := range value {
+					child := &node{source: source, path: appendPath(path, idx), result: newResultMap()}
+					children = append(children, child)
+					list[idx] = child.result
+				}
+				n.result.Set(field.key, list)
+
+			case []interface{}:
+				if definition.Type == nil {
+					n.result.Set(field.key, value)
+					continue
+				}
+
+				// list elements can be Source, error or nil, so that elements are resolved or failed separately.
+				list := make([]*ResultMap, len(value))
+				for idx, elem := range value {
+					elemPath := appendPath(path, idx)
+					switch v := elem.(type) {
+					case Source:
+						child := &node{source: v, path: elemPath, result: newResultMap()}
+						children = append(children, child)
+						list[idx] = child.result
+					case error:
+						e.errors = append(e.errors, newError(v, elemPath))
+					}
+				}
+				n.result.Set(field.key, list)
+
+			default:
+				if definition.Type != nil && value != nil {
+					logs.Errorf("graphql field %s of type %s resolved invalid value type %T, rid: %s", field.name,
+						obj.Name, value, e.kit.Rid)
+					n.result.Set(field.key, nil)
+					e.errors = append(e.errors, newError(errf.Newf(errf.Unknown, "field %s resolved invalid "+
+						"value", field.name), path))
+					continue
+				}
+				n.result.Set(field.key, value)
+			}
+		}
+
+		if len(children) != 0 {
+			e.executeNodes(definition.Type, children, field.selectionSet)
+		}
+	}
+}
+
+// resolve the field values of all the nodes.
+func (e *executor) resolve(field *collectedField, definition *FieldDefinition, nodes []*node) ([]interface{},
+	error) {
+
+	if definition.Resolve == nil {
+		values := make([]interface{}, len(nodes))
+		for i, n := range nodes {
+			values[i] = n.source[field.name]
+		}
+		return values, nil
+	}
+
+	sources := make([]Source, len(nodes))
+	for i, n := range nodes {
+		sources[i] = n.source
+	}
+
+	values, err := definition.Resolve(&ResolveParams{Kit: e.kit, Sources: sources,
+		Args: e.arguments(field.arguments)})
+	if err != nil {
+		logs.Errorf("resolve graphql field %s failed, err: %v, rid: %s", field.name, err, e.kit.Rid)
+		return nil, err
+	}
+
+	if len(values) != len(nodes) {
+		logs.Errorf("graphql field %s resolved %d values, but has %d sources, rid: %s", field.name, len(values),
+			len(nodes), e.kit.Rid)
+		return nil, fmt.Errorf("field %s resolved values mismatch with sources", field.name)
+	}
+
+	return values, nil
+}
+
+func appendPath(path []interface{}, elem interface{}) []interface{} {
+	newPath := make([]interface{}, len(path), len(path)+1)
+	copy(newPath, path)
+	return append(newPath, elem)
+}
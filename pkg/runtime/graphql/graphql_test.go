@@ -0,0 +1,160 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package graphql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+func TestParse(t *testing.T) {
+	query := `
+	# get cvm with disks
+	query GetCvm($id: String!, $withDisk: Boolean = true) {
+		cvm(id: $id) {
+			...cvmFields
+			disks @include(if: $withDisk) { id, name }
+			... on Cvm { alias: name }
+		}
+	}
+
+	fragment cvmFields on Cvm {
+		id
+		name
+	}`
+
+	doc, err := Parse(query)
+	if err != nil {
+		t.Errorf("parse query failed, err: %v", err)
+		return
+	}
+
+	if len(doc.Operations) != 1 || doc.Operations[0].Name != "GetCvm" || doc.Operations[0].Type != "query" {
+		t.Errorf("parsed operation is invalid: %+v", doc.Operations)
+		return
+	}
+
+	if len(doc.Operations[0].Variables) != 2 || doc.Operations[0].Variables[1].Default != true {
+		t.Errorf("parsed variables are invalid: %+v", doc.Operations[0].Variables)
+		return
+	}
+
+	cvm, ok := doc.Operations[0].SelectionSet[0].(*Field)
+	if !ok || cvm.Name != "cvm" || len(cvm.SelectionSet) != 3 {
+		t.Errorf("parsed cvm field is invalid: %+v", doc.Operations[0].SelectionSet[0])
+		return
+	}
+
+	if _, exists := doc.Fragments["cvmFields"]; !exists {
+		t.Errorf("fragment cvmFields is not parsed")
+		return
+	}
+
+	invalidQueries := []string{`{ cvm(id: "1" { id } }`, `{ cvm { } }`, `{ cvm(id: "1) { id } }`,
+		`fragment on on Cvm { id }`}
+	for _, invalid := range invalidQueries {
+		if _, err = Parse(invalid); err == nil {
+			t.Errorf("parse invalid query %s should fail", invalid)
+			return
+		}
+	}
+}
+
+func TestExecute(t *testing.T) {
+	diskResolveCount := 0
+	disk := &Object{Name: "Disk", Fields: map[string]*FieldDefinition{"id": {}}}
+	cvm := &Object{
+		Name: "Cvm",
+		Fields: map[string]*FieldDefinition{
+			"id": {},
+			"disks": {
+				Type: disk,
+				Resolve: func(params *ResolveParams) ([]interface{}, error) {
+					diskResolveCount++
+					values := make([]interface{}, len(params.Sources))
+					for i, source := range params.Sources {
+						if source["id"] == "2" {
+							values[i] = errf.New(errf.PermissionDenied, "no permission")
+							continue
+						}
+						values[i] = []Source{{"id": source["id"].(string) + "-disk"}}
+					}
+					return values, nil
+				},
+			},
+		},
+	}
+	schema := &Schema{
+		Query: &Object{
+			Name: "Query",
+			Fields: map[string]*FieldDefinition{
+				"cvms": {
+					Type: cvm,
+					Resolve: func(params *ResolveParams) ([]interface{}, error) {
+						return []interface{}{[]Source{{"id": "1"}, {"id": "2"}}}, nil
+					},
+				},
+			},
+		},
+		MaxDepth: 3,
+	}
+
+	resp := schema.Execute(kit.New(), &Request{Query: `{ cvms { id, __typename, disks { id } } }`})
+	if diskResolveCount != 1 {
+		t.Errorf("disks should be resolved in one batch, but resolved %d times", diskResolveCount)
+		return
+	}
+
+	data, err := json.Marshal(resp.Data)
+	if err != nil {
+		t.Errorf("marshal response data failed, err: %v", err)
+		return
+	}
+
+	expected := `{"cvms":[{"id":"1","__typename":"Cvm","disks":[{"id":"1-disk"}]},` +
+		`{"id":"2","__typename":"Cvm","disks":null}]}`
+	if string(data) != expected {
+		t.Errorf("response data %s is not as expected %s", data, expected)
+		return
+	}
+
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != errf.PermissionDenied {
+		t.Errorf("response errors are not as expected: %+v", resp.Errors)
+		return
+	}
+
+	invalidRequests := []*Request{
+		{Query: `{ cvms { name } }`},
+		{Query: `{ cvms }`},
+		{Query: `{ cvms { disks { id { id } } } }`},
+		{Query: `mutation { cvms { id } }`},
+		{Query: `{ a: cvms { id } b: cvms { disks { a: id } } }`, OperationName: "missing"},
+	}
+	for _, req := range invalidRequests {
+		resp = schema.Execute(kit.New(), req)
+		if len(resp.Errors) == 0 || resp.Data != nil {
+			t.Errorf("execute invalid request %s should fail", req.Query)
+			return
+		}
+	}
+}
@@ -0,0 +1,801 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+// byteOrderMark is the unicode BOM which is ignored as white space.
+const byteOrderMark = "\uFEFF"
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "<EOF>"
+	}
+	return strconv.Quote(t.value)
+}
+
+// lexer split the graphql query to tokens.
+type lexer struct {
+	src string
+	pos int
+}
+
+// skipIgnored skip the white spaces, line terminators, commas and comments.
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; c {
+		case ' ', '\t', '\n', '\r', ',':
+			l.pos++
+		case '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' && l.src[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			if strings.HasPrefix(l.src[l.pos:], byteOrderMark) {
+				l.pos += len(byteOrderMark)
+				continue
+			}
+			return
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.IndexByte("!$&():=@[]{}|", c) >= 0:
+		l.pos++
+		return token{kind: tokenPunct, value: string(c), pos: start}, nil
+
+	case c == '.':
+		if !strings.HasPrefix(l.src[l.pos:], "...") {
+			return token{}, fmt.Errorf("unexpected character '.' at position %d", start)
+		}
+		l.pos += 3
+		return token{kind: tokenPunct, value: "...", pos: start}, nil
+
+	case isNameStart(c):
+		for l.pos < len(l.src) && (isNameStart(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], pos: start}, nil
+
+	case c == '-' || isDigit(c):
+		return l.readNumber()
+
+	case c == '"':
+		if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			return l.readBlockString()
+		}
+		return l.readString()
+
+	default:
+		r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+		return token{}, fmt.Errorf("unexpected character %q at position %d", r, start)
+	}
+}
+
+func (l *lexer) readNumber() (token, error) {
+	start := l.pos
+	kind := tokenInt
+
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+
+	if err := l.readDigits(); err != nil {
+		return token{}, err
+	}
+
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokenFloat
+		l.pos++
+		if err := l.readDigits(); err != nil {
+			return token{}, err
+		}
+	}
+
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokenFloat
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if err := l.readDigits(); err != nil {
+			return token{}, err
+		}
+	}
+
+	return token{kind: kind, value: l.src[start:l.pos], pos: start}, nil
+}
+
+func (l *lexer) readDigits() error {
+	start := l.pos
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+
+	if start == l.pos {
+		return fmt.Errorf("invalid number at position %d, expect digit", start)
+	}
+	return nil
+}
+
+func (l *lexer) readString() (token, error) {
+	start := l.pos
+	l.pos++
+
+	var sb strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			return token{kind: tokenString, value: sb.String(), pos: start}, nil
+
+		case '\n', '\r':
+			return token{}, fmt.Errorf("unterminated string at position %d", start)
+
+		case '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, fmt.Errorf("unterminated string at position %d", start)
+			}
+
+			escaped := l.src[l.pos+1]
+			l.pos += 2
+			switch escaped {
+			case '"', '\\', '/':
+				sb.WriteByte(escaped)
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.src) {
+					return token{}, fmt.Errorf("invalid unicode escape at position %d", l.pos-2)
+				}
+				code, err := strconv.ParseUint(l.src[l.pos:l.pos+4], 16, 32)
+				if err != nil {
+					return token{}, fmt.Errorf("invalid unicode escape at position %d", l.pos-2)
+				}
+				sb.WriteRune(rune(code))
+				l.pos += 4
+			default:
+				return token{}, fmt.Errorf("invalid escape character %q at position %d", escaped, l.pos-2)
+			}
+
+		default:
+			sb.WriteByte(c)
+			l.pos++
+		}
+	}
+
+	return token{}, fmt.Errorf("unterminated string at position %d", start)
+}
+
+// readBlockString read the block string, the common indentation of block string is not removed.
+func (l *lexer) readBlockString() (token, error) {
+	start := l.pos
+	l.pos += 3
+
+	var sb strings.Builder
+	for l.pos < len(l.src) {
+		if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			l.pos += 3
+			return token{kind: tokenString, value: sb.String(), pos: start}, nil
+		}
+
+		if strings.HasPrefix(l.src[l.pos:], `\"""`) {
+			sb.WriteString(`"""`)
+			l.pos += 4
+			continue
+		}
+
+		sb.WriteByte(l.src[l.pos])
+		l.pos++
+	}
+
+	return token{}, fmt.Errorf("unterminated block string at position %d", start)
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// Parse parse the graphql query document.
+func Parse(query string) (*Document, error) {
+	p := &parser{lex: &lexer{src: query}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	return p.parseDocument()
+}
+
+// parser is a recursive descent parser of graphql executable document.
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+
+	p.tok = tok
+	return nil
+}
+
+// peek returns if current token is the punctuator or name of the value.
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+// skip advance the token if current token matches, returns if it matches.
+func (p *parser) skip(kind tokenKind, value string) (bool, error) {
+	if !p.peek(kind, value) {
+		return false, nil
+	}
+
+	return true, p.advance()
+}
+
+func (p *parser) expect(kind tokenKind, value string) error {
+	if !p.peek(kind, value) {
+		return fmt.Errorf("expect %q, but got %s at position %d", value, p.tok, p.tok.pos)
+	}
+
+	return p.advance()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", fmt.Errorf("expect name, but got %s at position %d", p.tok, p.tok.pos)
+	}
+
+	name := p.tok.value
+	return name, p.advance()
+}
+
+func (p *parser) parseDocument() (*Document, error) {
+	doc := &Document{Operations: make([]*Operation, 0), Fragments: make(map[string]*Fragment)}
+
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunct, "{"):
+			selections, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", SelectionSet: selections})
+
+		case p.peek(tokenName, "query"), p.peek(tokenName, "mutation"), p.peek(tokenName, "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+
+		case p.peek(tokenName, "fragment"):
+			fragment, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+
+			if _, exists := doc.Fragments[fragment.Name]; exists {
+				return nil, fmt.Errorf("fragment %s is defined more than once", fragment.Name)
+			}
+			doc.Fragments[fragment.Name] = fragment
+
+		default:
+			return nil, fmt.Errorf("unexpected %s at position %d, expect operation or fragment", p.tok, p.tok.pos)
+		}
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, fmt.Errorf("document has no operation")
+	}
+
+	return doc, nil
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	op := &Operation{Type: p.tok.value}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if p.tok.kind == tokenName {
+		if op.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek(tokenPunct, "(") {
+		if op.Variables, err = p.parseVariableDefinitions(); err != nil {
+			return nil, err
+		}
+	}
+
+	// operation directives have no effect on query execution.
+	if _, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	if op.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*VariableDefinition, error) {
+	if err := p.expect(tokenPunct, "("); err != nil {
+		return nil, err
+	}
+
+	definitions := make([]*VariableDefinition, 0)
+	for {
+		end, err := p.skip(tokenPunct, ")")
+		if err != nil {
+			return nil, err
+		}
+		if end {
+			return definitions, nil
+		}
+
+		if err = p.expect(tokenPunct, "$"); err != nil {
+			return nil, err
+		}
+
+		definition := new(VariableDefinition)
+		if definition.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+
+		if err = p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+
+		if definition.Type, err = p.parseType(); err != nil {
+			return nil, err
+		}
+
+		hasDefault, err := p.skip(tokenPunct, "=")
+		if err != nil {
+			return nil, err
+		}
+
+		if hasDefault {
+			if definition.Default, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err = p.parseDirectives(); err != nil {
+			return nil, err
+		}
+
+		definitions = append(definitions, definition)
+	}
+}
+
+func (p *parser) parseType() (string, error) {
+	var typ string
+
+	isList, err := p.skip(tokenPunct, "[")
+	if err != nil {
+		return "", err
+	}
+
+	if isList {
+		elem, err := p.parseType()
+		if err != nil {
+			return "", err
+		}
+
+		if err = p.expect(tokenPunct, "]"); err != nil {
+			return "", err
+		}
+		typ = "[" + elem + "]"
+	} else {
+		if typ, err = p.expectName(); err != nil {
+			return "", err
+		}
+	}
+
+	nonNull, err := p.skip(tokenPunct, "!")
+	if err != nil {
+		return "", err
+	}
+
+	if nonNull {
+		typ += "!"
+	}
+
+	return typ, nil
+}
+
+func (p *parser) parseFragment() (*Fragment, error) {
+	if err := p.expect(tokenName, "fragment"); err != nil {
+		return nil, err
+	}
+
+	if p.peek(tokenName, "on") {
+		return nil, fmt.Errorf("invalid fragment name \"on\" at position %d", p.tok.pos)
+	}
+
+	var err error
+	fragment := new(Fragment)
+	if fragment.Name, err = p.expectName(); err != nil {
+		return nil, err
+	}
+
+	if err = p.expect(tokenName, "on"); err != nil {
+		return nil, err
+	}
+
+	if fragment.TypeCondition, err = p.expectName(); err != nil {
+		return nil, err
+	}
+
+	if fragment.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	if fragment.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+
+	return fragment, nil
+}
+
+func (p *parser) parseSelectionSet() ([]Selection, error) {
+	if err := p.expect(tokenPunct, "{"); err != nil {
+		return nil, err
+	}
+
+	selections := make([]Selection, 0)
+	for {
+		end, err := p.skip(tokenPunct, "}")
+		if err != nil {
+			return nil, err
+		}
+
+		if end {
+			if len(selections) == 0 {
+				return nil, fmt.Errorf("selection set is empty at position %d", p.tok.pos)
+			}
+			return selections, nil
+		}
+
+		selection, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+}
+
+func (p *parser) parseSelection() (Selection, error) {
+	isFragment, err := p.skip(tokenPunct, "...")
+	if err != nil {
+		return nil, err
+	}
+
+	if !isFragment {
+		return p.parseField()
+	}
+
+	// fragment spread
+	if p.tok.kind == tokenName && p.tok.value != "on" {
+		spread := new(FragmentSpread)
+		if spread.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+
+		if spread.Directives, err = p.parseDirectives(); err != nil {
+			return nil, err
+		}
+		return spread, nil
+	}
+
+	// inline fragment
+	inline := new(InlineFragment)
+	hasType, err := p.skip(tokenName, "on")
+	if err != nil {
+		return nil, err
+	}
+
+	if hasType {
+		if inline.TypeCondition, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if inline.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	if inline.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+
+	return inline, nil
+}
+
+func (p *parser) parseField() (*Field, error) {
+	field := new(Field)
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	hasAlias, err := p.skip(tokenPunct, ":")
+	if err != nil {
+		return nil, err
+	}
+
+	if hasAlias {
+		field.Alias = name
+		if name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+	field.Name = name
+
+	if p.peek(tokenPunct, "(") {
+		if field.Arguments, err = p.parseArguments(); err != nil {
+			return nil, err
+		}
+	}
+
+	if field.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	if p.peek(tokenPunct, "{") {
+		if field.SelectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+
+	return field, nil
+}
+
+func (p *parser) parseArguments() ([]*Argument, error) {
+	if err := p.expect(tokenPunct, "("); err != nil {
+		return nil, err
+	}
+
+	arguments := make([]*Argument, 0)
+	for {
+		end, err := p.skip(tokenPunct, ")")
+		if err != nil {
+			return nil, err
+		}
+		if end {
+			return arguments, nil
+		}
+
+		argument := new(Argument)
+		if argument.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+
+		if err = p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+
+		if argument.Value, err = p.parseValue(false); err != nil {
+			return nil, err
+		}
+
+		arguments = append(arguments, argument)
+	}
+}
+
+func (p *parser) parseDirectives() ([]*Directive, error) {
+	directives := make([]*Directive, 0)
+	for {
+		isDirective, err := p.skip(tokenPunct, "@")
+		if err != nil {
+			return nil, err
+		}
+		if !isDirective {
+			return directives, nil
+		}
+
+		directive := new(Directive)
+		if directive.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+
+		if p.peek(tokenPunct, "(") {
+			if directive.Arguments, err = p.parseArguments(); err != nil {
+				return nil, err
+			}
+		}
+
+		directives = append(directives, directive)
+	}
+}
+
+// parseValue parse the input value, variable is not allowed if it is a const value.
+func (p *parser) parseValue(isConst bool) (interface{}, error) {
+	tok := p.tok
+
+	switch tok.kind {
+	case tokenInt:
+		value, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid int value %s at position %d", tok.value, tok.pos)
+		}
+		return value, p.advance()
+
+	case tokenFloat:
+		value, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float value %s at position %d", tok.value, tok.pos)
+		}
+		return value, p.advance()
+
+	case tokenString:
+		return tok.value, p.advance()
+
+	case tokenName:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		switch tok.value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		default:
+			return EnumValue(tok.value), nil
+		}
+
+	case tokenPunct:
+		switch tok.value {
+		case "$":
+			if isConst {
+				return nil, fmt.Errorf("variable is not allowed in const value at position %d", tok.pos)
+			}
+
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			return &Variable{Name: name}, nil
+
+		case "[":
+			return p.parseListValue(isConst)
+
+		case "{":
+			return p.parseObjectValue(isConst)
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected %s at position %d, expect value", tok, tok.pos)
+}
+
+func (p *parser) parseListValue(isConst bool) (interface{}, error) {
+	if err := p.expect(tokenPunct, "["); err != nil {
+		return nil, err
+	}
+
+	list := make([]interface{}, 0)
+	for {
+		end, err := p.skip(tokenPunct, "]")
+		if err != nil {
+			return nil, err
+		}
+		if end {
+			return list, nil
+		}
+
+		value, err := p.parseValue(isConst)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+	}
+}
+
+func (p *parser) parseObjectValue(isConst bool) (interface{}, error) {
+	if err := p.expect(tokenPunct, "{"); err != nil {
+		return nil, err
+	}
+
+	object := make(map[string]interface{})
+	for {
+		end, err := p.skip(tokenPunct, "}")
+		if err != nil {
+			return nil, err
+		}
+		if end {
+			return object, nil
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		if err = p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+
+		if object[name], err = p.parseValue(isConst); err != nil {
+			return nil, err
+		}
+	}
+}
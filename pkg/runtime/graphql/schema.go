@@ -0,0 +1,179 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package graphql is a lightweight read-only graphql query engine. Fields are resolved in batches, all the
+// parent objects of the same field are resolved by one call of the field resolver, so that the relationships
+// of a list of resources can be queried by one batch request instead of one request per resource.
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+// Schema is the graphql schema, only query operation is supported.
+type Schema struct {
+	// Query is the root query object type.
+	Query *Object
+	// MaxDepth is the max nesting depth of the query, 0 means no limitation.
+	MaxDepth uint
+}
+
+// Object is an object type of the schema.
+type Object struct {
+	Name   string
+	Fields map[string]*FieldDefinition
+}
+
+// FieldDefinition is the definition of an object field.
+type FieldDefinition struct {
+	// Type is the object type of the field, nil means the field is a scalar or a list of scalars.
+	Type *Object
+	// Resolve batch resolve the field of all the parent sources, nil means the field value is the value of the
+	// source with the field name.
+	Resolve ResolveFunc
+}
+
+// Source is the resolved object value, fields without resolver are read from it by field name.
+type Source map[string]interface{}
+
+// ResolveParams is the parameters of the field resolver.
+type ResolveParams struct {
+	Kit *kit.Kit
+	// Sources are the parent objects of the field.
+	Sources []Source
+	// Args are the arguments of the field with the variables replaced.
+	Args map[string]interface{}
+}
+
+// ResolveFunc batch resolve the field of the parent sources, the returned values must be in the same order and
+// length of the sources. For object type field, the value can be Source, []Source, []interface{} of Source or error
+// elements, or nil. The value or list element can also be an error, then it is null and the error is returned with
+// its path, e.g. the object is not authorized. If an error is returned, all the values of the field are null.
+type ResolveFunc func(params *ResolveParams) ([]interface{}, error)
+
+// Request is the graphql request.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response is the graphql response.
+type Response struct {
+	Data   *ResultMap `json:"data"`
+	Errors []*Error   `json:"errors,omitempty"`
+}
+
+// Error is the graphql error.
+type Error struct {
+	Message string `json:"message"`
+	// Path is the response path of the field that the error occurred.
+	Path []interface{} `json:"path,omitempty"`
+	// Extensions contains the hcm error code.
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// ErrorResponse returns the response of the request level error, e.g. the query is invalid.
+func ErrorResponse(err error) *Response {
+	return &Response{Errors: []*Error{newError(err, nil)}}
+}
+
+func newError(err error, path []interface{}) *Error {
+	ef := errf.Error(err)
+	return &Error{Message: ef.Message, Path: path, Extensions: map[string]interface{}{"code": ef.Code}}
+}
+
+// ResultMap is the response object that keeps the order of the fields as in the query.
+type ResultMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newResultMap() *ResultMap {
+	return &ResultMap{keys: make([]string, 0), values: make(map[string]interface{})}
+}
+
+// Set the value of the key.
+func (m *ResultMap) Set(key string, value interface{}) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Get the value of the key.
+func (m *ResultMap) Get(key string) (interface{}, bool) {
+	value, exists := m.values[key]
+	return value, exists
+}
+
+// MarshalJSON marshal the result map in order of the keys.
+func (m *ResultMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	buf := []byte{'{'}
+	for i, key := range m.keys {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, fmt.Errorf("marshal field %s failed, err: %v", key, err)
+		}
+
+		buf = append(buf, k...)
+		buf = append(buf, ':')
+		buf = append(buf, v...)
+	}
+	buf = append(buf, '}')
+
+	return buf, nil
+}
+
+// ToSource convert the struct to Source by its json tags.
+func ToSource(obj interface{}) (Source, error) {
+	byt, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// use number to keep the precision of int64 values.
+	decoder := json.NewDecoder(bytes.NewReader(byt))
+	decoder.UseNumber()
+
+	source := make(Source)
+	if err = decoder.Decode(&source); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
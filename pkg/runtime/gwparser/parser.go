@@ -87,7 +87,7 @@ func (p *defaultParser) Parse(ctx context.Context, header http.Header) (*kit.Kit
 	}
 
 	kt := &kit.Kit{
-		Ctx:     ctx,
+		Ctx:     kit.WithReadPrimaryFlag(ctx, header),
 		User:    header.Get(constant.UserKey),
 		Rid:     header.Get(constant.RidKey),
 		AppCode: header.Get(constant.AppCodeKey),
@@ -127,7 +127,7 @@ func (p *jwtParser) Parse(ctx context.Context, header http.Header) (*kit.Kit, er
 	}
 
 	kt := &kit.Kit{
-		Ctx:     ctx,
+		Ctx:     kit.WithReadPrimaryFlag(ctx, header),
 		User:    token.User.UserName,
 		AppCode: token.App.AppCode,
 		Rid:     header.Get(constant.RidKey),
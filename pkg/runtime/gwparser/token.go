@@ -0,0 +1,167 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gwparser
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/uuid"
+)
+
+// TokenIdentity is the identity of a valid hcm issued api token.
+type TokenIdentity struct {
+	// TokenID is the id of the api token.
+	TokenID string
+	// User is the subject of the api token, it is user name for personal token and service account name for
+	// service account token.
+	User string
+	// Scopes is the api path prefixes that the api token can access, "*" means all the apis.
+	Scopes []string
+}
+
+// TokenValidator validates hcm issued api token.
+type TokenValidator interface {
+	// Validate the api token and returns its identity, clientIP is used for last used tracking.
+	Validate(ctx context.Context, token string, clientIP string) (*TokenIdentity, error)
+}
+
+// InitTokenParser enable hcm issued api token, requests with api token are parsed by the validator, other requests
+// are still parsed by the api-gateway or default parser.
+func InitTokenParser(validator TokenValidator) {
+	parser = &tokenParser{
+		validator: validator,
+		next:      parser,
+	}
+}
+
+type scopesCtxKey struct{}
+
+// tokenParser used to parse requests using hcm issued api token.
+type tokenParser struct {
+	validator TokenValidator
+	// next is the parser to parse requests that does not use api token.
+	next Parser
+}
+
+// Parse api token request header to context kit and validate.
+func (p *tokenParser) Parse(ctx context.Context, header http.Header) (*kit.Kit, error) {
+	token := GetApiToken(header)
+	if len(token) == 0 {
+		return p.next.Parse(ctx, header)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	identity, err := p.validator.Validate(ctx, token, getClientIP(header))
+	if err != nil {
+		return nil, err
+	}
+
+	// automation tools may not set request id, generate one for them.
+	rid := header.Get(constant.RidKey)
+	if len(rid) == 0 {
+		rid = uuid.UUID()
+	}
+
+	kt := &kit.Kit{
+		Ctx:     kit.WithReadPrimaryFlag(context.WithValue(ctx, scopesCtxKey{}, identity.Scopes), header),
+		User:    identity.User,
+		AppCode: constant.ApiTokenAppCodeKey,
+		Rid:     rid,
+	}
+
+	if err := kt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return kt, nil
+}
+
+// GetApiToken get hcm issued api token from request header, returns empty string if not exists.
+func GetApiToken(header http.Header) string {
+	if token := header.Get(constant.ApiTokenKey); len(token) != 0 {
+		return token
+	}
+
+	auth := header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return ""
+	}
+
+	// only hcm issued api token is parsed here, other bearer tokens are left to the next parser.
+	token := strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	if !strings.HasPrefix(token, constant.ApiTokenPrefix) {
+		return ""
+	}
+
+	return token
+}
+
+// ValidateScope validate if the request path is in the scopes of the api token that kit is parsed from, requests
+// that are not using api token are not restricted.
+func ValidateScope(kt *kit.Kit, reqPath string) error {
+	scopes, ok := kt.Ctx.Value(scopesCtxKey{}).([]string)
+	if !ok {
+		return nil
+	}
+
+	// clean the path so that "/scope/../other" can not escape from the scope.
+	cleaned := path.Clean("/" + reqPath)
+	for _, scope := range scopes {
+		if scope == "*" || inScope(cleaned, scope) {
+			return nil
+		}
+	}
+
+	return errf.Newf(errf.PermissionDenied, "api token has no permission to access %s", reqPath)
+}
+
+// inScope returns if the cleaned path is the scope or under the scope, the scope is matched by path segments,
+// e.g. scope "/api/v1/cloud/vpcs" matches "/api/v1/cloud/vpcs/list" but not "/api/v1/cloud/vpcs_admin".
+func inScope(cleaned, scope string) bool {
+	scope = path.Clean("/" + scope)
+	if cleaned == scope {
+		return true
+	}
+
+	return strings.HasPrefix(cleaned, strings.TrimSuffix(scope, "/")+"/")
+}
+
+func getClientIP(header http.Header) string {
+	if forwarded := header.Get("X-Forwarded-For"); len(forwarded) != 0 {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+
+	ip := header.Get("X-Real-Ip")
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		return host
+	}
+
+	return ip
+}
@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gwparser
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+)
+
+type fakeValidator struct{}
+
+func (fakeValidator) Validate(_ context.Context, token string, _ string) (*TokenIdentity, error) {
+	if token != "hcm_valid" {
+		return nil, errors.New("invalid token")
+	}
+	return &TokenIdentity{TokenID: "1", User: "sa-ci", Scopes: []string{"/api/v1/cloud/vpcs"}}, nil
+}
+
+func TestTokenParser(t *testing.T) {
+	p := &tokenParser{validator: fakeValidator{}, next: &defaultParser{}}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer hcm_valid")
+	kt, err := p.Parse(context.Background(), header)
+	if err != nil {
+		t.Fatalf("parse valid token failed, err: %v", err)
+	}
+
+	if kt.User != "sa-ci" || kt.AppCode != constant.ApiTokenAppCodeKey || len(kt.Rid) == 0 {
+		t.Errorf("unexpected kit, user: %s, app code: %s, rid: %s", kt.User, kt.AppCode, kt.Rid)
+	}
+
+	if err = ValidateScope(kt, "/api/v1/cloud/vpcs/list"); err != nil {
+		t.Errorf("path in scope should be allowed, err: %v", err)
+	}
+
+	if err = ValidateScope(kt, "/api/v1/cloud/accounts/list"); err == nil {
+		t.Errorf("path out of scope should be denied")
+	}
+
+	if err = ValidateScope(kt, "/api/v1/cloud/vpcs_admin/list"); err == nil {
+		t.Errorf("path with the scope as prefix of its segment should be denied")
+	}
+
+	if err = ValidateScope(kt, "/api/v1/cloud/vpcs/../accounts/list"); err == nil {
+		t.Errorf("path escaped from the scope should be denied")
+	}
+
+	header = http.Header{}
+	header.Set(constant.ApiTokenKey, "hcm_invalid")
+	if _, err = p.Parse(context.Background(), header); err == nil {
+		t.Errorf("parse invalid token should fail")
+	}
+
+	header = http.Header{}
+	header.Set("Authorization", "Bearer other")
+	if token := GetApiToken(header); len(token) != 0 {
+		t.Errorf("non hcm bearer token should be ignored, got: %s", token)
+	}
+}
@@ -0,0 +1,209 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/uuid"
+
+	etcd3 "go.etcd.io/etcd/client/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// instanceTTLSec is the lease ttl of the registered instance key.
+	instanceTTLSec = 15
+	// retryInterval is the interval of retrying when etcd operation failed.
+	retryInterval = 5 * time.Second
+)
+
+// rulesKey returns the etcd key of the service's rate limit rules.
+func rulesKey(name cc.Name) string {
+	return fmt.Sprintf("/hcm/ratelimit/%s/rules", name)
+}
+
+// instancesPath returns the etcd path of the service's instances that share the limits.
+func instancesPath(name cc.Name) string {
+	return fmt.Sprintf("/hcm/ratelimit/%s/instances/", name)
+}
+
+// NewFromConfig create the rate limiter of the service by config and coordinate it through etcd, returns nil if
+// rate limit is not enabled.
+func NewFromConfig(ctx context.Context, name cc.Name, cfg cc.RateLimit, etcd cc.Etcd) (*Limiter, error) {
+	if !cfg.Enable {
+		return nil, nil
+	}
+
+	l, err := New(cfg.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = Coordinate(ctx, l, name, etcd, cfg.Rules); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Coordinate coordinates the limiter with the other instances of the service through etcd:
+// 1. register current instance and watch the number of instances, so that each instance takes an equal share of
+// the limits.
+// 2. watch the rules stored in etcd, the rules in etcd take precedence over the rules in config and take effect
+// without restart, the rules in config are used again when the rules in etcd are deleted.
+func Coordinate(ctx context.Context, l *Limiter, name cc.Name, etcd cc.Etcd, defaultRules []cc.RateLimitRule) error {
+	etcdOpt, err := etcd.ToConfig()
+	if err != nil {
+		return fmt.Errorf("get etcd config failed, err: %v", err)
+	}
+
+	cli, err := etcd3.New(etcdOpt)
+	if err != nil {
+		return fmt.Errorf("new etcd client failed, err: %v", err)
+	}
+
+	c := &coordinator{
+		cli:          cli,
+		limiter:      l,
+		name:         name,
+		defaultRules: defaultRules,
+	}
+
+	go c.keepRegistered(ctx)
+	go c.watch(ctx, instancesPath(name), c.syncPeers, etcd3.WithPrefix())
+	go c.watch(ctx, rulesKey(name), c.syncRules)
+
+	return nil
+}
+
+type coordinator struct {
+	cli          *etcd3.Client
+	limiter      *Limiter
+	name         cc.Name
+	defaultRules []cc.RateLimitRule
+}
+
+// keepRegistered register current instance with lease, and register again if the lease is lost.
+func (c *coordinator) keepRegistered(ctx context.Context) {
+	key := instancesPath(c.name) + uuid.UUID()
+	for {
+		if err := c.register(ctx, key); err != nil {
+			logs.Errorf("register %s rate limit instance failed, err: %v", c.name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// register current instance and keep the lease alive until it is lost.
+func (c *coordinator) register(ctx context.Context, key string) error {
+	lease, err := c.cli.Grant(ctx, instanceTTLSec)
+	if err != nil {
+		return err
+	}
+
+	if _, err = c.cli.Put(ctx, key, "", etcd3.WithLease(lease.ID)); err != nil {
+		return err
+	}
+
+	keepAlive, err := c.cli.KeepAlive(ctx, lease.ID)
+	if err != nil {
+		return err
+	}
+
+	for range keepAlive {
+	}
+
+	return fmt.Errorf("lease %d keep alive stopped", lease.ID)
+}
+
+// watch get the current value of the key and sync it, then watch the changes, it rewatches when watch is broken.
+func (c *coordinator) watch(ctx context.Context, key string, sync func(ctx context.Context, key string) error,
+	opts ...etcd3.OpOption) {
+
+	for {
+		if err := sync(ctx, key); err != nil {
+			logs.Errorf("sync %s rate limit by etcd key %s failed, err: %v", c.name, key, err)
+		}
+
+		watchCh := c.cli.Watch(ctx, key, opts...)
+		for resp := range watchCh {
+			if err := resp.Err(); err != nil {
+				logs.Errorf("watch %s rate limit etcd key %s failed, err: %v", c.name, key, err)
+				break
+			}
+
+			if err := sync(ctx, key); err != nil {
+				logs.Errorf("sync %s rate limit by etcd key %s failed, err: %v", c.name, key, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// syncPeers set the number of registered instances to limiter.
+func (c *coordinator) syncPeers(ctx context.Context, path string) error {
+	resp, err := c.cli.Get(ctx, path, etcd3.WithPrefix(), etcd3.WithCountOnly())
+	if err != nil {
+		return err
+	}
+
+	c.limiter.SetPeers(int(resp.Count))
+	return nil
+}
+
+// syncRules set the rules in etcd to limiter, the rules in config are used if the rules in etcd are not exist.
+func (c *coordinator) syncRules(ctx context.Context, key string) error {
+	resp, err := c.cli.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Kvs) == 0 {
+		return c.limiter.SetRules(c.defaultRules)
+	}
+
+	rules := make([]cc.RateLimitRule, 0)
+	if err = yaml.Unmarshal(resp.Kvs[0].Value, &rules); err != nil {
+		return fmt.Errorf("unmarshal rate limit rules failed, err: %v", err)
+	}
+
+	// invalid rules are ignored, the previous rules are kept.
+	if err = c.limiter.SetRules(rules); err != nil {
+		return err
+	}
+
+	logs.Infof("%s rate limit rules are reloaded from etcd, rules: %+v", c.name, rules)
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/metrics"
+	"hcm/pkg/rest"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	once            sync.Once
+	rejectedCounter *prometheus.CounterVec
+)
+
+func initMetric() {
+	rejectedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "ratelimit",
+		Name:      "total_rejected_count",
+		Help:      "the total count of requests rejected by rate limit rules",
+	}, []string{"rule"})
+	metrics.Register().MustRegister(rejectedCounter)
+}
+
+// RestfulFilter returns the restful filter that rejects the requests exceeding the rate limit rules, app code and
+// user are got from request header.
+func RestfulFilter(l *Limiter) restful.FilterFunction {
+	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		limitReq := &Request{
+			AppCode: req.Request.Header.Get(constant.AppCodeKey),
+			User:    req.Request.Header.Get(constant.UserKey),
+			Method:  req.Request.Method,
+			Path:    req.Request.URL.Path,
+			Route:   routeOf(req),
+		}
+
+		if decision := l.Allow(limitReq); !decision.Allowed {
+			WriteRejected(resp.ResponseWriter, decision)
+			return
+		}
+
+		chain.ProcessFilter(req, resp)
+	}
+}
+
+// WriteRejected write the standard 429 response of the request rejected by rate limit rule.
+func WriteRejected(w http.ResponseWriter, decision *Decision) {
+	once.Do(initMetric)
+	rejectedCounter.With(prometheus.Labels{"rule": decision.Rule}).Inc()
+
+	retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	w.Header().Set("X-RateLimit-Limit", strconv.FormatUint(uint64(decision.Limit), 10))
+	w.Header().Set("X-RateLimit-Interval", strconv.FormatUint(uint64(decision.IntervalSec), 10))
+	w.Header().Set("X-RateLimit-Remaining", "0")
+	w.Header().Set("X-RateLimit-Rule", decision.Rule)
+	w.WriteHeader(http.StatusTooManyRequests)
+
+	rest.WriteResp(w, rest.NewBaseResp(errf.TooManyRequest, "too many requests, exceeded rate limit rule "+
+		decision.Rule+", retry after "+strconv.Itoa(retryAfter)+" seconds"))
+}
+
+// routeOf returns the route pattern of the request, proxy routes that match all paths are ignored.
+func routeOf(req *restful.Request) string {
+	route := req.SelectedRoutePath()
+	if strings.Contains(route, "{.*}") {
+		return ""
+	}
+
+	return route
+}
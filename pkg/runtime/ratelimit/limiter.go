@@ -0,0 +1,265 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package ratelimit implements the api rate limiting keyed by app code, user and route, the limits are shared by
+// all the instances of a service and coordinated through etcd.
+package ratelimit
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/tools/slice"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// bucketIdleTimeout is the idle time after which the bucket is removed.
+	bucketIdleTimeout = 10 * time.Minute
+	// sweepInterval is the interval of removing idle buckets.
+	sweepInterval = time.Minute
+)
+
+// Request is the attributes of a request used to match rate limit rules.
+type Request struct {
+	AppCode string
+	User    string
+	Method  string
+	// Path is the request path used to match path prefix of rules.
+	Path string
+	// Route is the route pattern of the request, it is used as route dimension, Path is used if it is empty.
+	Route string
+}
+
+// Decision is the rate limit decision of a request.
+type Decision struct {
+	Allowed bool
+	// Rule is the name of the rule that rejects the request.
+	Rule string
+	// Limit is the limit of the rule that rejects the request.
+	Limit uint
+	// IntervalSec is the interval of the rule that rejects the request.
+	IntervalSec uint
+	// RetryAfter is the duration after which the request can be retried.
+	RetryAfter time.Duration
+}
+
+// Limiter limits the requests by rate limit rules.
+type Limiter struct {
+	lock    sync.Mutex
+	rules   []cc.RateLimitRule
+	peers   int
+	buckets map[string]*bucket
+	// lastSweep is the last time that idle buckets are removed.
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// New create a rate limiter with rules.
+func New(rules []cc.RateLimitRule) (*Limiter, error) {
+	l := &Limiter{
+		peers:     1,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+
+	if err := l.SetRules(rules); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// SetRules replace the rate limit rules, counters of the requests are reset.
+func (l *Limiter) SetRules(rules []cc.RateLimitRule) error {
+	if err := cc.ValidateRateLimitRules(rules); err != nil {
+		return err
+	}
+
+	copied := make([]cc.RateLimitRule, len(rules))
+	for i := range rules {
+		copied[i] = rules[i]
+		copied[i].TrySetDefault()
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.rules = copied
+	l.buckets = make(map[string]*bucket)
+	return nil
+}
+
+// SetPeers set the number of service instances that share the limits, each instance takes an equal share of the
+// limits, counters of the requests are reset when it is changed.
+func (l *Limiter) SetPeers(peers int) {
+	if peers < 1 {
+		peers = 1
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.peers == peers {
+		return
+	}
+
+	l.peers = peers
+	l.buckets = make(map[string]*bucket)
+}
+
+// Allow check if the request is allowed by all the matched rules, request is rejected if any of the rules is
+// exceeded, and the quota reserved from the other rules is returned.
+func (l *Limiter) Allow(req *Request) *Decision {
+	now := time.Now()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.sweep(now)
+
+	reserved := make([]*rate.Reservation, 0)
+	for i := range l.rules {
+		rule := &l.rules[i]
+		if !matchRule(rule, req) {
+			continue
+		}
+
+		b := l.getBucket(rule, req, now)
+		reservation := b.limiter.ReserveN(now, 1)
+		delay := reservation.DelayFrom(now)
+		if reservation.OK() && delay == 0 {
+			reserved = append(reserved, reservation)
+			continue
+		}
+
+		if reservation.OK() {
+			reservation.CancelAt(now)
+		} else {
+			delay = time.Duration(rule.IntervalSec) * time.Second
+		}
+
+		for _, one := range reserved {
+			one.CancelAt(now)
+		}
+
+		return &Decision{
+			Allowed:     false,
+			Rule:        rule.Name,
+			Limit:       rule.Limit,
+			IntervalSec: rule.IntervalSec,
+			RetryAfter:  delay,
+		}
+	}
+
+	return &Decision{Allowed: true}
+}
+
+// getBucket get the bucket of the request's dimensions of the rule, create it if not exists.
+func (l *Limiter) getBucket(rule *cc.RateLimitRule, req *Request, now time.Time) *bucket {
+	key := bucketKey(rule, req)
+	b, exists := l.buckets[key]
+	if !exists {
+		// the limit is shared by all the instances, each instance takes an equal share.
+		limit := rate.Limit(float64(rule.Limit) / float64(rule.IntervalSec) / float64(l.peers))
+		burst := int(math.Ceil(float64(rule.Burst) / float64(l.peers)))
+		b = &bucket{limiter: rate.NewLimiter(limit, burst)}
+		l.buckets[key] = b
+	}
+
+	b.lastSeen = now
+	return b
+}
+
+// sweep remove the idle buckets.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > bucketIdleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+func bucketKey(rule *cc.RateLimitRule, req *Request) string {
+	parts := []string{rule.Name}
+	for _, key := range rule.KeyBy {
+		switch key {
+		case cc.AppCodeRateLimitKey:
+			parts = append(parts, req.AppCode)
+		case cc.UserRateLimitKey:
+			parts = append(parts, req.User)
+		case cc.RouteRateLimitKey:
+			route := req.Route
+			if len(route) == 0 {
+				route = req.Path
+			}
+			parts = append(parts, req.Method+" "+route)
+		}
+	}
+
+	return strings.Join(parts, "\x00")
+}
+
+func matchRule(rule *cc.RateLimitRule, req *Request) bool {
+	if len(rule.AppCodes) != 0 && !slice.IsItemInSlice(rule.AppCodes, req.AppCode) {
+		return false
+	}
+
+	if len(rule.Users) != 0 && !slice.IsItemInSlice(rule.Users, req.User) {
+		return false
+	}
+
+	if len(rule.Methods) != 0 && !containsFold(rule.Methods, req.Method) {
+		return false
+	}
+
+	if len(rule.Paths) == 0 {
+		return true
+	}
+
+	for _, path := range rule.Paths {
+		if strings.HasPrefix(req.Path, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsFold(list []string, item string) bool {
+	for _, one := range list {
+		if strings.EqualFold(one, item) {
+			return true
+		}
+	}
+	return false
+}
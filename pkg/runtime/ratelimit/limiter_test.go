@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ratelimit
+
+import (
+	"testing"
+
+	"hcm/pkg/cc"
+)
+
+func stopCvmRule() cc.RateLimitRule {
+	return cc.RateLimitRule{
+		Name:        "batch-stop-cvm",
+		Methods:     []string{"POST"},
+		Paths:       []string{"/api/v1/cloud/cvms/batch/stop"},
+		KeyBy:       []cc.RateLimitKey{cc.AppCodeRateLimitKey, cc.UserRateLimitKey},
+		Limit:       2,
+		IntervalSec: 60,
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	l, err := New([]cc.RateLimitRule{stopCvmRule()})
+	if err != nil {
+		t.Fatalf("new limiter failed, err: %v", err)
+	}
+
+	req := &Request{AppCode: "app", User: "alice", Method: "POST", Path: "/api/v1/cloud/cvms/batch/stop"}
+	for i := 0; i < 2; i++ {
+		if d := l.Allow(req); !d.Allowed {
+			t.Fatalf("request %d should be allowed, rejected by rule %s", i, d.Rule)
+		}
+	}
+
+	d := l.Allow(req)
+	if d.Allowed {
+		t.Fatal("request exceeds limit should be rejected")
+	}
+	if d.Rule != "batch-stop-cvm" || d.Limit != 2 || d.IntervalSec != 60 {
+		t.Errorf("unexpected decision: %+v", d)
+	}
+	if d.RetryAfter <= 0 {
+		t.Errorf("retry after should be set, got %v", d.RetryAfter)
+	}
+
+	// other user has its own quota.
+	other := &Request{AppCode: "app", User: "bob", Method: "POST", Path: "/api/v1/cloud/cvms/batch/stop"}
+	if d := l.Allow(other); !d.Allowed {
+		t.Errorf("request of other user should be allowed")
+	}
+
+	// request not matched by any rule is not limited.
+	unmatched := &Request{AppCode: "app", User: "alice", Method: "GET", Path: "/api/v1/cloud/cvms/batch/stop"}
+	for i := 0; i < 5; i++ {
+		if d := l.Allow(unmatched); !d.Allowed {
+			t.Fatalf("unmatched request should be allowed")
+		}
+	}
+}
+
+func TestLimiterRejectReturnsReservedQuota(t *testing.T) {
+	global := cc.RateLimitRule{Name: "global", Limit: 3, IntervalSec: 60}
+	stop := stopCvmRule()
+	stop.Limit = 1
+	l, err := New([]cc.RateLimitRule{global, stop})
+	if err != nil {
+		t.Fatalf("new limiter failed, err: %v", err)
+	}
+
+	stopReq := &Request{AppCode: "app", User: "alice", Method: "POST", Path: "/api/v1/cloud/cvms/batch/stop"}
+	if d := l.Allow(stopReq); !d.Allowed {
+		t.Fatalf("first request should be allowed")
+	}
+	// rejected by stop rule, the quota reserved from global rule should be returned.
+	if d := l.Allow(stopReq); d.Allowed || d.Rule != "batch-stop-cvm" {
+		t.Fatalf("second request should be rejected by stop rule, got %+v", d)
+	}
+
+	listReq := &Request{AppCode: "app", User: "alice", Method: "POST", Path: "/api/v1/cloud/vpcs/list"}
+	for i := 0; i < 2; i++ {
+		if d := l.Allow(listReq); !d.Allowed {
+			t.Fatalf("list request %d should be allowed, rejected by rule %s", i, d.Rule)
+		}
+	}
+	if d := l.Allow(listReq); d.Allowed || d.Rule != "global" {
+		t.Fatalf("list request should be rejected by global rule, got %+v", d)
+	}
+}
+
+func TestLimiterSetPeers(t *testing.T) {
+	l, err := New([]cc.RateLimitRule{{Name: "global", Limit: 4, IntervalSec: 60}})
+	if err != nil {
+		t.Fatalf("new limiter failed, err: %v", err)
+	}
+
+	// each of the 2 instances takes half of the limit.
+	l.SetPeers(2)
+	req := &Request{Method: "GET", Path: "/api/v1/cloud/vpcs/list"}
+	for i := 0; i < 2; i++ {
+		if d := l.Allow(req); !d.Allowed {
+			t.Fatalf("request %d should be allowed", i)
+		}
+	}
+	if d := l.Allow(req); d.Allowed {
+		t.Fatal("request exceeds the share of instance should be rejected")
+	}
+
+	// counters are reset and the whole limit is taken when the other instance is gone.
+	l.SetPeers(1)
+	for i := 0; i < 4; i++ {
+		if d := l.Allow(req); !d.Allowed {
+			t.Fatalf("request %d should be allowed after peers changed", i)
+		}
+	}
+	if d := l.Allow(req); d.Allowed {
+		t.Fatal("request exceeds the limit should be rejected")
+	}
+}
+
+func TestLimiterSetRules(t *testing.T) {
+	l, err := New(nil)
+	if err != nil {
+		t.Fatalf("new limiter failed, err: %v", err)
+	}
+
+	dup := []cc.RateLimitRule{stopCvmRule(), stopCvmRule()}
+	if err := l.SetRules(dup); err == nil {
+		t.Error("duplicated rule name should be rejected")
+	}
+
+	invalid := stopCvmRule()
+	invalid.KeyBy = []cc.RateLimitKey{"ip"}
+	if err := l.SetRules([]cc.RateLimitRule{invalid}); err == nil {
+		t.Error("invalid key should be rejected")
+	}
+
+	noDefault := cc.RateLimitRule{Name: "default", Limit: 1}
+	if err := l.SetRules([]cc.RateLimitRule{noDefault}); err != nil {
+		t.Fatalf("set rules failed, err: %v", err)
+	}
+	req := &Request{Method: "GET", Path: "/api/v1/cloud/vpcs/list"}
+	if d := l.Allow(req); !d.Allowed {
+		t.Fatal("first request should be allowed")
+	}
+	if d := l.Allow(req); d.Allowed || d.IntervalSec != 1 {
+		t.Fatalf("second request should be rejected with default interval, got %+v", d)
+	}
+}
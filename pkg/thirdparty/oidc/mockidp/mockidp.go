@@ -0,0 +1,227 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package mockidp implements a local OpenID Connect identity provider for tests, it approves every authorization
+// request as the configured user without login page.
+package mockidp
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const keyID = "mock-idp-key"
+
+// User is the user that mock identity provider logs in as.
+type User struct {
+	Username string
+	Groups   []string
+}
+
+// IdP is the mock OpenID Connect identity provider.
+type IdP struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+
+	lock  sync.Mutex
+	user  User
+	codes map[string]authorization
+}
+
+type authorization struct {
+	nonce       string
+	redirectURI string
+	user        User
+}
+
+// New create and start a mock identity provider for the client.
+func New(clientID string, user User) (*IdP, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	idp := &IdP{
+		key:      key,
+		clientID: clientID,
+		user:     user,
+		codes:    make(map[string]authorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+	mux.HandleFunc("/jwks", idp.jwks)
+	mux.HandleFunc("/logout", idp.logout)
+	idp.server = httptest.NewServer(mux)
+
+	return idp, nil
+}
+
+// Issuer returns the issuer url of the mock identity provider.
+func (idp *IdP) Issuer() string {
+	return idp.server.URL
+}
+
+// SetUser set the user that mock identity provider logs in as.
+func (idp *IdP) SetUser(user User) {
+	idp.lock.Lock()
+	defer idp.lock.Unlock()
+	idp.user = user
+}
+
+// Close shutdown the mock identity provider.
+func (idp *IdP) Close() {
+	idp.server.Close()
+}
+
+func (idp *IdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]string{
+		"issuer":                 idp.server.URL,
+		"authorization_endpoint": idp.server.URL + "/authorize",
+		"token_endpoint":         idp.server.URL + "/token",
+		"jwks_uri":               idp.server.URL + "/jwks",
+		"end_session_endpoint":   idp.server.URL + "/logout",
+	})
+}
+
+// authorize approves the authorization request and redirects back with code.
+func (idp *IdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != idp.clientID || query.Get("response_type") != "code" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	idp.lock.Lock()
+	idp.codes[code] = authorization{nonce: query.Get("nonce"), redirectURI: redirectURI.String(), user: idp.user}
+	idp.lock.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+// token exchanges code to id token, code can only be used once.
+func (idp *IdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	clientID, _, ok := r.BasicAuth()
+	if !ok {
+		clientID = r.PostForm.Get("client_id")
+	}
+
+	code := r.PostForm.Get("code")
+	idp.lock.Lock()
+	auth, exists := idp.codes[code]
+	delete(idp.codes, code)
+	idp.lock.Unlock()
+
+	if !exists || clientID != idp.clientID || r.PostForm.Get("redirect_uri") != auth.redirectURI {
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":                idp.server.URL,
+		"sub":                auth.user.Username,
+		"aud":                idp.clientID,
+		"exp":                now.Add(time.Hour).Unix(),
+		"iat":                now.Unix(),
+		"nonce":              auth.nonce,
+		"preferred_username": auth.user.Username,
+		"groups":             auth.user.Groups,
+	})
+	token.Header["kid"] = keyID
+
+	idToken, err := token.SignedString(idp.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func (idp *IdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	pub := idp.key.PublicKey
+	writeJSON(w, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": keyID,
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (idp *IdP) logout(w http.ResponseWriter, r *http.Request) {
+	redirect := r.URL.Query().Get("post_logout_redirect_uri")
+	if len(redirect) == 0 {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Redirect(w, r, redirect, http.StatusFound)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
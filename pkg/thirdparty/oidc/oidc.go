@@ -0,0 +1,289 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package oidc implements the OpenID Connect relying party of authorization code flow.
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/oauth2"
+)
+
+// Option is the option of OpenID Connect provider.
+type Option struct {
+	// Issuer is the issuer url of the provider.
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// Client is the http client to request the provider, http.DefaultClient is used if not set.
+	Client *http.Client
+}
+
+// Provider is the OpenID Connect provider.
+type Provider struct {
+	issuer   string
+	clientID string
+	client   *http.Client
+	oauth    *oauth2.Config
+	metadata *Metadata
+
+	lock sync.RWMutex
+	keys map[string]*rsa.PublicKey
+}
+
+// Metadata is the provider metadata returned by discovery endpoint.
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"`
+}
+
+// NewProvider discover the provider metadata and create provider.
+func NewProvider(ctx context.Context, opt *Option) (*Provider, error) {
+	cli := opt.Client
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+
+	issuer := strings.TrimSuffix(opt.Issuer, "/")
+	metadata := new(Metadata)
+	if err := getJSON(ctx, cli, issuer+"/.well-known/openid-configuration", metadata); err != nil {
+		return nil, fmt.Errorf("discover oidc provider %s failed, err: %v", issuer, err)
+	}
+
+	if strings.TrimSuffix(metadata.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc issuer %s not match the discovered issuer %s", issuer, metadata.Issuer)
+	}
+
+	if len(metadata.AuthorizationEndpoint) == 0 || len(metadata.TokenEndpoint) == 0 || len(metadata.JwksURI) == 0 {
+		return nil, errors.New("oidc provider metadata lacks authorization, token or jwks endpoint")
+	}
+
+	return &Provider{
+		issuer:   metadata.Issuer,
+		clientID: opt.ClientID,
+		client:   cli,
+		oauth: &oauth2.Config{
+			ClientID:     opt.ClientID,
+			ClientSecret: opt.ClientSecret,
+			RedirectURL:  opt.RedirectURL,
+			Scopes:       opt.Scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  metadata.AuthorizationEndpoint,
+				TokenURL: metadata.TokenEndpoint,
+			},
+		},
+		metadata: metadata,
+		keys:     make(map[string]*rsa.PublicKey),
+	}, nil
+}
+
+// Metadata returns the provider metadata.
+func (p *Provider) Metadata() Metadata {
+	return *p.metadata
+}
+
+// AuthCodeURL returns the url of provider's login page, state and nonce are used to prevent CSRF and replay attack.
+func (p *Provider) AuthCodeURL(state, nonce string) string {
+	return p.oauth.AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce))
+}
+
+// EndSessionURL returns the url to logout from provider, returns empty string if provider does not support it.
+func (p *Provider) EndSessionURL(idTokenHint, postLogoutRedirectURL string) string {
+	if len(p.metadata.EndSessionEndpoint) == 0 {
+		return ""
+	}
+
+	u, err := url.Parse(p.metadata.EndSessionEndpoint)
+	if err != nil {
+		return ""
+	}
+
+	query := u.Query()
+	query.Set("client_id", p.clientID)
+	if len(idTokenHint) != 0 {
+		query.Set("id_token_hint", idTokenHint)
+	}
+	if len(postLogoutRedirectURL) != 0 {
+		query.Set("post_logout_redirect_uri", postLogoutRedirectURL)
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// Exchange the authorization code to tokens and verify the id token, returns the raw id token and its claims.
+func (p *Provider) Exchange(ctx context.Context, code, nonce string) (string, jwt.MapClaims, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+	token, err := p.oauth.Exchange(ctx, code)
+	if err != nil {
+		return "", nil, fmt.Errorf("exchange authorization code failed, err: %v", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || len(rawIDToken) == 0 {
+		return "", nil, errors.New("token response lacks id_token")
+	}
+
+	claims, err := p.VerifyIDToken(ctx, rawIDToken, nonce)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return rawIDToken, claims, nil
+}
+
+// VerifyIDToken verify the signature, issuer, audience, expiry and nonce of the id token.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "RS384", "RS512"}))
+	_, err := parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.getKey(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("verify id token failed, err: %v", err)
+	}
+
+	if !claims.VerifyIssuer(p.issuer, true) {
+		return nil, errors.New("id token issuer is invalid")
+	}
+
+	if !claims.VerifyAudience(p.clientID, true) {
+		return nil, errors.New("id token audience is invalid")
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, errors.New("id token nonce is invalid")
+	}
+
+	return claims, nil
+}
+
+// getKey get the signing key of id token by key id, keys are refreshed when the key id is not found, in case that
+// the provider rotates its keys.
+func (p *Provider) getKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	p.lock.RLock()
+	key, exists := p.lookupKey(kid)
+	p.lock.RUnlock()
+	if exists {
+		return key, nil
+	}
+
+	keys, err := p.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.keys = keys
+
+	key, exists = p.lookupKey(kid)
+	if !exists {
+		return nil, fmt.Errorf("signing key %s not found", kid)
+	}
+	return key, nil
+}
+
+// lookupKey lookup key by key id, the only key is used if key id is not specified.
+func (p *Provider) lookupKey(kid string) (*rsa.PublicKey, bool) {
+	if len(kid) == 0 && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	key, exists := p.keys[kid]
+	return key, exists
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func (p *Provider) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	jwks := new(struct {
+		Keys []jsonWebKey `json:"keys"`
+	})
+	if err := getJSON(ctx, p.client, p.metadata.JwksURI, jwks); err != nil {
+		return nil, fmt.Errorf("get oidc jwks failed, err: %v", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || (len(jwk.Use) != 0 && jwk.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("decode jwk %s modulus failed, err: %v", jwk.Kid, err)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("decode jwk %s exponent failed, err: %v", jwk.Kid, err)
+		}
+
+		keys[jwk.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	return keys, nil
+}
+
+func getJSON(ctx context.Context, cli *http.Client, rawURL string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request %s failed, status code: %d", rawURL, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
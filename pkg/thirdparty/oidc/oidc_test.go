@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"hcm/pkg/thirdparty/oidc/mockidp"
+)
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	idp, err := mockidp.New("hcm", mockidp.User{Username: "tom", Groups: []string{"ops"}})
+	if err != nil {
+		t.Fatalf("create mock idp failed, err: %v", err)
+	}
+	defer idp.Close()
+
+	ctx := context.Background()
+	provider, err := NewProvider(ctx, &Option{
+		Issuer:       idp.Issuer(),
+		ClientID:     "hcm",
+		ClientSecret: "secret",
+		RedirectURL:  "http://hcm.example.com/sso/callback",
+		Scopes:       []string{"openid", "groups"},
+	})
+	if err != nil {
+		t.Fatalf("create provider failed, err: %v", err)
+	}
+
+	code := authorize(t, provider.AuthCodeURL("state", "nonce"))
+
+	if _, _, err = provider.Exchange(ctx, code, "other"); err == nil {
+		t.Errorf("exchange with invalid nonce should fail")
+	}
+
+	code = authorize(t, provider.AuthCodeURL("state", "nonce"))
+	rawIDToken, claims, err := provider.Exchange(ctx, code, "nonce")
+	if err != nil {
+		t.Fatalf("exchange code failed, err: %v", err)
+	}
+
+	if claims["preferred_username"] != "tom" {
+		t.Errorf("unexpected username claim: %v", claims["preferred_username"])
+	}
+
+	if _, _, err = provider.Exchange(ctx, code, "nonce"); err == nil {
+		t.Errorf("code should not be used twice")
+	}
+
+	logoutURL, err := url.Parse(provider.EndSessionURL(rawIDToken, "http://hcm.example.com/"))
+	if err != nil || logoutURL.Query().Get("post_logout_redirect_uri") != "http://hcm.example.com/" {
+		t.Errorf("unexpected end session url: %v, err: %v", logoutURL, err)
+	}
+}
+
+// authorize request the authorization url and returns the code in the redirect url.
+func authorize(t *testing.T, authURL string) string {
+	cli := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := cli.Get(authURL)
+	if err != nil {
+		t.Fatalf("request authorization url failed, err: %v", err)
+	}
+	defer resp.Body.Close()
+
+	location, err := resp.Location()
+	if err != nil {
+		t.Fatalf("authorization response lacks location, err: %v", err)
+	}
+
+	if location.Query().Get("state") != "state" {
+		t.Fatalf("unexpected state in redirect url: %s", location)
+	}
+
+	return location.Query().Get("code")
+}
@@ -0,0 +1,270 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package anomaly detects spikes in daily time series (such as daily cost) with a rolling robust baseline
+// and a weekly seasonality adjustment.
+package anomaly
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// DateLayout is the layout of the date of a daily series point.
+const DateLayout = "2006-01-02"
+
+// madScale scales the median absolute deviation to be a consistent estimator of the standard deviation.
+const madScale = 1.4826
+
+// Option defines the options to detect anomalies.
+type Option struct {
+	// WindowDays is the rolling window size in days used to calculate the baseline.
+	WindowDays int `json:"window_days"`
+	// MinHistoryDays is the minimum number of history days needed before a day can be evaluated.
+	MinHistoryDays int `json:"min_history_days"`
+	// SeasonDays is the length of the seasonality period in days, 7 means weekly seasonality, 0 disables it.
+	SeasonDays int `json:"season_days"`
+	// RatioThreshold is the minimum ratio of actual value to expected value to be regarded as an anomaly.
+	RatioThreshold float64 `json:"ratio_threshold"`
+	// ScoreThreshold is the minimum robust z-score to be regarded as an anomaly.
+	ScoreThreshold float64 `json:"score_threshold"`
+	// MinDelta is the minimum absolute increase over the expected value to be regarded as an anomaly,
+	// it is used to ignore spikes on series which are too small to care about.
+	MinDelta float64 `json:"min_delta"`
+}
+
+// DefaultOption returns the default detect option.
+func DefaultOption() *Option {
+	return &Option{
+		WindowDays:     28,
+		MinHistoryDays: 14,
+		SeasonDays:     7,
+		RatioThreshold: 2,
+		ScoreThreshold: 3,
+		MinDelta:       10,
+	}
+}
+
+// Validate detect option.
+func (opt Option) Validate() error {
+	if opt.WindowDays <= 0 {
+		return errors.New("window days should > 0")
+	}
+
+	if opt.MinHistoryDays <= 0 || opt.MinHistoryDays > opt.WindowDays {
+		return errors.New("min history days should be in (0, window days]")
+	}
+
+	if opt.SeasonDays < 0 || opt.SeasonDays > opt.WindowDays/2 {
+		return errors.New("season days should be in [0, window days/2]")
+	}
+
+	if opt.RatioThreshold <= 1 {
+		return errors.New("ratio threshold should > 1")
+	}
+
+	if opt.ScoreThreshold <= 0 {
+		return errors.New("score threshold should > 0")
+	}
+
+	if opt.MinDelta < 0 {
+		return errors.New("min delta should >= 0")
+	}
+
+	return nil
+}
+
+// Point is one day value of a daily series.
+type Point struct {
+	// Date is the day of the point, format: yyyy-mm-dd.
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+// Result is the evaluation result of one day.
+type Result struct {
+	Date     string  `json:"date"`
+	Actual   float64 `json:"actual"`
+	Expected float64 `json:"expected"`
+	// Ratio is actual/expected, it is 0 when the expected value is 0.
+	Ratio float64 `json:"ratio"`
+	// Score is the robust z-score of the actual value against the baseline, it is 0 when the history
+	// has no variance.
+	Score     float64 `json:"score"`
+	IsAnomaly bool    `json:"is_anomaly"`
+}
+
+// Detect evaluates every day of the series which has enough history and returns the results ordered by date.
+// Missing days between the first and the last day of the series are regarded as 0. Only the days which are
+// not before since (format: yyyy-mm-dd, empty means all days) are returned.
+func Detect(points []Point, since string, opt *Option) ([]Result, error) {
+	if opt == nil {
+		return nil, errors.New("detect option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	dates, values, err := fill(points)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0)
+	for idx := opt.MinHistoryDays; idx < len(values); idx++ {
+		if since != "" && dates[idx] < since {
+			continue
+		}
+
+		start := idx - opt.WindowDays
+		if start < 0 {
+			start = 0
+		}
+
+		result := evaluate(values[start:idx], values[idx], opt)
+		result.Date = dates[idx]
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// evaluate the actual value against the history window, the last element of history is the day before actual.
+func evaluate(history []float64, actual float64, opt *Option) Result {
+	baseline := median(history)
+
+	expected := baseline
+	if factor, ok := seasonFactor(history, baseline, opt.SeasonDays); ok {
+		expected = baseline * factor
+	}
+
+	deviations := make([]float64, len(history))
+	for idx, value := range history {
+		deviations[idx] = math.Abs(value - baseline)
+	}
+	spread := median(deviations) * madScale
+
+	// a flat history has no spread, use a small part of the expected value instead so that small
+	// fluctuations of a very stable series are not regarded as anomalies.
+	if minSpread := math.Abs(expected) * 0.05; spread < minSpread {
+		spread = minSpread
+	}
+
+	delta := actual - expected
+
+	result := Result{
+		Actual:   actual,
+		Expected: expected,
+	}
+
+	// a series which rises from nothing always exceeds the ratio threshold.
+	ratioExceeded := true
+	if expected > 0 {
+		result.Ratio = actual / expected
+		ratioExceeded = result.Ratio >= opt.RatioThreshold
+	}
+
+	// a history without any variance regards every increase as significant.
+	scoreExceeded := delta > 0
+	if spread > 0 {
+		result.Score = delta / spread
+		scoreExceeded = result.Score >= opt.ScoreThreshold
+	}
+
+	result.IsAnomaly = delta > 0 && delta >= opt.MinDelta && ratioExceeded && scoreExceeded
+
+	return result
+}
+
+// seasonFactor calculates the ratio of the same phase days' median to the baseline, the last element of
+// history is one day before the evaluated day, so the same phase days are history[len-season*k].
+func seasonFactor(history []float64, baseline float64, season int) (float64, bool) {
+	if season <= 1 || baseline <= 0 || len(history) < season*2 {
+		return 0, false
+	}
+
+	samePhase := make([]float64, 0, len(history)/season)
+	for idx := len(history) - season; idx >= 0; idx -= season {
+		samePhase = append(samePhase, history[idx])
+	}
+
+	factor := median(samePhase) / baseline
+	if factor <= 0 {
+		return 0, false
+	}
+
+	// limit the seasonality effect, a weekday pattern should not hide a real spike.
+	return math.Max(0.5, math.Min(2, factor)), true
+}
+
+// fill sorts the points and fills the missing days with 0, points of the same day are summed.
+func fill(points []Point) ([]string, []float64, error) {
+	if len(points) == 0 {
+		return nil, nil, nil
+	}
+
+	valueMap := make(map[string]float64, len(points))
+	var first, last time.Time
+	for _, one := range points {
+		day, err := time.Parse(DateLayout, one.Date)
+		if err != nil {
+			return nil, nil, errors.New("invalid point date: " + one.Date)
+		}
+
+		valueMap[one.Date] += one.Value
+
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+
+		if last.IsZero() || day.After(last) {
+			last = day
+		}
+	}
+
+	dates := make([]string, 0)
+	values := make([]float64, 0)
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		date := day.Format(DateLayout)
+		dates = append(dates, date)
+		values = append(values, valueMap[date])
+	}
+
+	return dates, values, nil
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
@@ -0,0 +1,139 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package anomaly
+
+import (
+	"testing"
+	"time"
+)
+
+func series(start string, values []float64) []Point {
+	day, _ := time.Parse(DateLayout, start)
+	points := make([]Point, 0, len(values))
+	for idx, value := range values {
+		points = append(points, Point{Date: day.AddDate(0, 0, idx).Format(DateLayout), Value: value})
+	}
+	return points
+}
+
+func TestDetectSpike(t *testing.T) {
+	values := make([]float64, 0)
+	for i := 0; i < 28; i++ {
+		values = append(values, 100+float64(i%3))
+	}
+	values = append(values, 300)
+
+	results, err := Detect(series("2023-05-01", values), "2023-05-29", DefaultOption())
+	if err != nil {
+		t.Errorf("detect failed, err: %v", err)
+		return
+	}
+
+	if len(results) != 1 {
+		t.Errorf("detect result count should be 1, got: %d", len(results))
+		return
+	}
+
+	if !results[0].IsAnomaly {
+		t.Errorf("3x spike should be an anomaly, got: %+v", results[0])
+		return
+	}
+
+	if results[0].Ratio < 2.9 {
+		t.Errorf("spike ratio should be about 3, got: %+v", results[0])
+		return
+	}
+}
+
+func TestDetectWeeklySeasonality(t *testing.T) {
+	values := make([]float64, 0)
+	for i := 0; i < 35; i++ {
+		// every 7th day is a batch day which costs 3x.
+		if i%7 == 0 {
+			values = append(values, 300)
+			continue
+		}
+		values = append(values, 100)
+	}
+
+	results, err := Detect(series("2023-05-01", values), "2023-05-29", DefaultOption())
+	if err != nil {
+		t.Errorf("detect failed, err: %v", err)
+		return
+	}
+
+	for _, one := range results {
+		if one.IsAnomaly {
+			t.Errorf("weekly batch day should not be an anomaly, got: %+v", one)
+			return
+		}
+	}
+}
+
+func TestDetectIgnoreSmallDelta(t *testing.T) {
+	values := make([]float64, 0)
+	for i := 0; i < 20; i++ {
+		values = append(values, 1)
+	}
+	values = append(values, 5)
+
+	results, err := Detect(series("2023-05-01", values), "", DefaultOption())
+	if err != nil {
+		t.Errorf("detect failed, err: %v", err)
+		return
+	}
+
+	last := results[len(results)-1]
+	if last.IsAnomaly {
+		t.Errorf("spike less than min delta should not be an anomaly, got: %+v", last)
+		return
+	}
+}
+
+func TestDetectFillMissingDays(t *testing.T) {
+	points := []Point{{Date: "2023-05-01", Value: 1}, {Date: "2023-05-04", Value: 2}, {Date: "2023-05-01", Value: 1}}
+	dates, values, err := fill(points)
+	if err != nil {
+		t.Errorf("fill failed, err: %v", err)
+		return
+	}
+
+	if len(dates) != 4 || values[0] != 2 || values[1] != 0 || values[3] != 2 {
+		t.Errorf("fill result is invalid, dates: %v, values: %v", dates, values)
+		return
+	}
+}
+
+func TestDetectRiseFromZero(t *testing.T) {
+	values := make([]float64, 20)
+	values = append(values, 500)
+
+	results, err := Detect(series("2023-05-01", values), "", DefaultOption())
+	if err != nil {
+		t.Errorf("detect failed, err: %v", err)
+		return
+	}
+
+	last := results[len(results)-1]
+	if !last.IsAnomaly || last.Ratio != 0 || last.Score != 0 {
+		t.Errorf("rise from zero should be an anomaly with zero ratio and score, got: %+v", last)
+		return
+	}
+}
@@ -23,7 +23,9 @@ import (
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/iam/meta"
+	"hcm/pkg/iam/sys"
 	"hcm/pkg/rest"
 	"hcm/pkg/runtime/filter"
 )
@@ -42,7 +44,7 @@ func ResValidWithAuth(cts *rest.Contexts, opt *ValidWithAuthOption) error {
 		}
 
 		authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: opt.ResType, Action: opt.Action,
-			ResourceID: opt.BasicInfo.AccountID}}
+			ResourceID: opt.BasicInfo.AccountID}, Attribute: genResAuthAttribute(opt.BasicInfo)}
 		return opt.Authorizer.AuthorizeWithPerm(cts.Kit, authRes)
 	}
 
@@ -60,7 +62,7 @@ func ResValidWithAuth(cts *rest.Contexts, opt *ValidWithAuthOption) error {
 		}
 
 		authRes = append(authRes, meta.ResourceAttribute{Basic: &meta.Basic{Type: opt.ResType, Action: opt.Action,
-			ResourceID: info.AccountID}})
+			ResourceID: info.AccountID}, Attribute: genResAuthAttribute(&info)})
 	}
 
 	if len(assignedIDs) > 0 {
@@ -74,6 +76,18 @@ func ResValidWithAuth(cts *rest.Contexts, opt *ValidWithAuthOption) error {
 	return opt.Authorizer.AuthorizeWithPerm(cts.Kit, authRes...)
 }
 
+// genResAuthAttribute generate cloud resource attributes used for attribute based authorization.
+func genResAuthAttribute(info *types.CloudResourceBasicInfo) map[string]interface{} {
+	attribute := map[string]interface{}{sys.VendorAttribute: string(info.Vendor)}
+
+	// region is always selected in basic info, only resources that do not have region (e.g. account) lack it.
+	if len(info.Region) != 0 {
+		attribute[sys.RegionAttribute] = info.Region
+	}
+
+	return attribute
+}
+
 // ListResourceAuthRes list authorized cloud resource for resource manager.
 func ListResourceAuthRes(cts *rest.Contexts, opt *ListAuthResOption) (*filter.Expression, bool, error) {
 	authOpt := &meta.ListAuthResInput{Type: opt.ResType, Action: opt.Action}
@@ -0,0 +1,139 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tracing provides the distributed tracing of the requests across the services based on OpenTelemetry,
+// spans are exported to the OTLP collector, and the trace context is passed through the http headers along with
+// the request id.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/shutdown"
+	"hcm/pkg/version"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer that creates all the spans of hcm.
+const instrumentationName = "hcm"
+
+// flushTimeout is the max duration to export the remaining spans when the process is shutting down.
+const flushTimeout = 5 * time.Second
+
+// InitTracing init the trace context propagator, and the tracer provider which exports spans to the OTLP collector
+// if tracing is enabled. the trace context is always propagated even if tracing is disabled, so that the traces
+// are not broken by the services that do not export spans.
+func InitTracing(opt cc.Tracing) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{},
+		propagation.Baggage{}))
+
+	if !opt.Enable {
+		return nil
+	}
+
+	clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opt.Endpoint)}
+	if opt.Insecure {
+		clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+	}
+
+	// the exporter connects to the collector in background, spans are dropped when the collector is unavailable.
+	exporter, err := otlptracegrpc.New(context.Background(), clientOpts...)
+	if err != nil {
+		return fmt.Errorf("new otlp trace exporter failed, err: %v", err)
+	}
+
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String(string(cc.ServiceName())),
+		semconv.ServiceVersionKey.String(version.VERSION),
+	)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opt.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	notifier := shutdown.AddNotifier()
+	go func() {
+		<-notifier.Signal
+		defer notifier.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			logs.Errorf("shutdown tracer provider failed, err: %v", err)
+		}
+	}()
+
+	logs.Infof("tracing is enabled, spans are exported to %s, sample ratio: %v", opt.Endpoint, opt.SampleRatio)
+	return nil
+}
+
+// Tracer returns the tracer of hcm.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Extract returns the context with the trace context carried by the http headers.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// Inject set the trace context of the context to the http headers.
+func Inject(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// End set the status of the span by the error and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// TraceID returns the trace id of the context, returns empty string if the context has no valid trace.
+func TraceID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+
+	return sc.TraceID().String()
+}
@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"hcm/pkg/cc"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPropagateTraceContext(t *testing.T) {
+	if err := InitTracing(cc.Tracing{}); err != nil {
+		t.Fatalf("init tracing failed, err: %v", err)
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03},
+		SpanID:     trace.SpanID{0x04, 0x05},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	header := http.Header{}
+	Inject(ctx, header)
+	if header.Get("traceparent") == "" {
+		t.Fatalf("trace context is not injected to header")
+	}
+
+	extracted := Extract(context.Background(), header)
+	if TraceID(extracted) != sc.TraceID().String() {
+		t.Errorf("extracted trace id %s is not equal to %s", TraceID(extracted), sc.TraceID().String())
+	}
+
+	if TraceID(context.Background()) != "" {
+		t.Errorf("trace id of the context without trace should be empty")
+	}
+}
@@ -0,0 +1,28 @@
+insert into id_generator(`resource`, `max_id`)
+values ('cost_anomaly', '0');
+
+CREATE TABLE `cost_anomaly`
+(
+    `id`              varchar(64)    not null,
+    `vendor`          varchar(16)    not null default '',
+    `account_id`      varchar(64)    not null,
+    `dimension`       varchar(16)    not null,
+    `dimension_value` varchar(255)   not null default '',
+    `bill_date`       varchar(10)    not null,
+    `currency`        varchar(16)             default '',
+    `actual_cost`     decimal(30, 8) not null default 0,
+    `expected_cost`   decimal(30, 8) not null default 0,
+    `ratio`           double         not null default 0,
+    `score`           double         not null default 0,
+    `top_items`       json                    default NULL,
+    `status`          varchar(32)    not null default 'open',
+    `memo`            varchar(255)            default '',
+    `creator`         varchar(64)             default '',
+    `reviser`         varchar(64)             default '',
+    `created_at`      timestamp      not null default current_timestamp,
+    `updated_at`      timestamp      not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_account_id_bill_date_dimension` (`account_id`, `bill_date`, `dimension`, `dimension_value`),
+    index `idx_status` (`status`)
+) engine = innodb
+  default charset = utf8mb4;
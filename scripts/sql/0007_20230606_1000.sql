@@ -0,0 +1,24 @@
+insert into id_generator(`resource`, `max_id`)
+values ('account_health', '0');
+
+CREATE TABLE `account_health`
+(
+    `id`                   varchar(64)     not null,
+    `vendor`               varchar(16)     not null default '',
+    `account_id`           varchar(64)     not null,
+    `status`               varchar(32)     not null,
+    `last_check_at`        varchar(32)     not null default '',
+    `last_success_at`      varchar(32)     not null default '',
+    `last_error_at`        varchar(32)     not null default '',
+    `last_error`           varchar(1024)   not null default '',
+    `consecutive_failures` bigint unsigned not null default 0,
+    `missing_permissions`  json                     default NULL,
+    `creator`              varchar(64)              default '',
+    `reviser`              varchar(64)              default '',
+    `created_at`           timestamp       not null default current_timestamp,
+    `updated_at`           timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_account_id` (`account_id`),
+    index `idx_status` (`status`)
+) engine = innodb
+  default charset = utf8mb4;
@@ -0,0 +1,34 @@
+insert into id_generator(`resource`, `max_id`)
+values ('rbac_role', '0'),
+       ('rbac_role_binding', '0');
+
+CREATE TABLE `rbac_role`
+(
+    `id`         varchar(64)  not null,
+    `name`       varchar(64)  not null,
+    `actions`    json         not null,
+    `builtin`    boolean               default false,
+    `memo`       varchar(255)          default '',
+    `creator`    varchar(64)           default '',
+    `reviser`    varchar(64)           default '',
+    `created_at` timestamp    not null default current_timestamp,
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE TABLE `rbac_role_binding`
+(
+    `id`         varchar(64) not null,
+    `role_id`    varchar(64) not null,
+    `subject`    varchar(64) not null,
+    `scope_type` varchar(16) not null,
+    `scope_ids`  json                 default NULL,
+    `creator`    varchar(64)          default '',
+    `created_at` timestamp   not null default current_timestamp,
+    primary key (`id`),
+    index `idx_subject` (`subject`),
+    index `idx_role_id` (`role_id`)
+) engine = innodb
+  default charset = utf8mb4;
@@ -0,0 +1,42 @@
+insert into id_generator(`resource`, `max_id`)
+values ('api_token', '0'),
+       ('service_account', '0');
+
+CREATE TABLE `service_account`
+(
+    `id`         varchar(64) not null,
+    `name`       varchar(64) not null,
+    `managers`   json        not null,
+    `memo`       varchar(255)         default '',
+    `creator`    varchar(64)          default '',
+    `reviser`    varchar(64)          default '',
+    `created_at` timestamp   not null default current_timestamp,
+    `updated_at` timestamp   not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE TABLE `api_token`
+(
+    `id`           varchar(64) not null,
+    `name`         varchar(64) not null,
+    `subject_type` varchar(32) not null,
+    `subject`      varchar(64) not null,
+    `token_hash`   char(64)    not null,
+    `token_prefix` varchar(16)          default '',
+    `scopes`       json        not null,
+    `expired_at`   varchar(64)          default '',
+    `revoked`      boolean              default false,
+    `last_used_at` varchar(64)          default '',
+    `last_used_ip` varchar(64)          default '',
+    `memo`         varchar(255)         default '',
+    `creator`      varchar(64)          default '',
+    `reviser`      varchar(64)          default '',
+    `created_at`   timestamp   not null default current_timestamp,
+    `updated_at`   timestamp   not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_token_hash` (`token_hash`),
+    index `idx_subject` (`subject_type`, `subject`)
+) engine = innodb
+  default charset = utf8mb4;
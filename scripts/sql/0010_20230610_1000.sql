@@ -0,0 +1,20 @@
+insert into id_generator(`resource`, `max_id`)
+values ('idempotency_record', '0');
+
+CREATE TABLE `idempotency_record`
+(
+    `id`              varchar(64)  not null,
+    `owner`           varchar(128) not null,
+    `idempotency_key` varchar(255) not null,
+    `request_hash`    char(64)     not null,
+    `state`           varchar(32)  not null,
+    `response`        mediumtext,
+    `expired_at`      varchar(64)  not null,
+    `creator`         varchar(64)           default '',
+    `created_at`      timestamp    not null default current_timestamp,
+    `updated_at`      timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_owner_key` (`owner`, `idempotency_key`),
+    index `idx_expired_at` (`expired_at`)
+) engine = innodb
+  default charset = utf8mb4;
@@ -0,0 +1,58 @@
+insert into id_generator(`resource`, `max_id`)
+values ('event_subscription', '0'),
+       ('event_dead_letter', '0');
+
+CREATE TABLE `event`
+(
+    `id`           bigint(1) unsigned not null auto_increment,
+    `type`         varchar(128)       not null,
+    `res_type`     varchar(50)        not null,
+    `action`       varchar(32)        not null,
+    `res_id`       varchar(64)                 default '',
+    `cloud_res_id` varchar(255)                default '',
+    `res_name`     varchar(255)                default '',
+    `bk_biz_id`    bigint(1)          not null default -1,
+    `vendor`       varchar(16)                 default '',
+    `account_id`   varchar(64)                 default '',
+    `operator`     varchar(64)        not null,
+    `rid`          varchar(64)        not null,
+    `app_code`     varchar(64)                 default '',
+    `detail`       json                        default null,
+    `created_at`   timestamp          not null default current_timestamp,
+    primary key (`id`),
+    index `idx_created_at` (`created_at`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE TABLE `event_subscription`
+(
+    `id`            varchar(64)        not null,
+    `name`          varchar(64)        not null,
+    `endpoint`      varchar(255)       not null,
+    `secret`        varchar(255)       not null,
+    `filter`        json               not null,
+    `enabled`       boolean                     default true,
+    `last_event_id` bigint(1) unsigned not null default 0,
+    `memo`          varchar(255)                default '',
+    `creator`       varchar(64)                 default '',
+    `reviser`       varchar(64)                 default '',
+    `created_at`    timestamp          not null default current_timestamp,
+    `updated_at`    timestamp          not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE TABLE `event_dead_letter`
+(
+    `id`              varchar(64)        not null,
+    `subscription_id` varchar(64)        not null,
+    `event_id`        bigint(1) unsigned not null,
+    `event_type`      varchar(128)       not null,
+    `attempts`        int(1) unsigned    not null default 0,
+    `last_error`      varchar(1024)               default '',
+    `created_at`      timestamp          not null default current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_subscription_event` (`subscription_id`, `event_id`)
+) engine = innodb
+  default charset = utf8mb4;
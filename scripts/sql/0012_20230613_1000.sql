@@ -0,0 +1,13 @@
+CREATE TABLE `change_feed`
+(
+    `id`         bigint(1) unsigned not null auto_increment,
+    `res_type`   varchar(64)        not null,
+    `res_id`     varchar(64)        not null,
+    `account_id` varchar(64)                 default '',
+    `action`     varchar(16)        not null,
+    `created_at` timestamp          not null default current_timestamp,
+    primary key (`id`),
+    index `idx_res_type_id` (`res_type`, `id`),
+    index `idx_created_at` (`created_at`)
+) engine = innodb
+  default charset = utf8mb4;
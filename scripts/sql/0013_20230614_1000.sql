@@ -0,0 +1,21 @@
+insert into id_generator(`resource`, `max_id`)
+values ('resource_tag', '0');
+
+CREATE TABLE `resource_tag`
+(
+    `id`         varchar(64)  not null,
+    `res_type`   varchar(64)  not null,
+    `res_id`     varchar(64)  not null,
+    `vendor`     varchar(16)  not null,
+    `account_id` varchar(64)  not null,
+    `tag_key`    varchar(255) not null,
+    `tag_value`  varchar(255)          default '',
+    `creator`    varchar(64)  not null,
+    `reviser`    varchar(64)  not null,
+    `created_at` timestamp    not null default current_timestamp,
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_res_type_res_id_tag_key` (`res_type`, `res_id`, `tag_key`),
+    index `idx_res_type_tag_key_tag_value` (`res_type`, `tag_key`, `tag_value`)
+) engine = innodb
+  default charset = utf8mb4;
@@ -0,0 +1,26 @@
+insert into id_generator(`resource`, `max_id`)
+values ('biz_assign_rule', '0');
+
+CREATE TABLE `biz_assign_rule`
+(
+    `id`         varchar(64)     not null,
+    `name`       varchar(64)     not null,
+    `account_id` varchar(64)     not null,
+    `res_types`  json            not null,
+    `rule_type`  varchar(16)     not null,
+    `tag_key`    varchar(255)             default '',
+    `tag_value`  varchar(255)             default '',
+    `vpc_id`     varchar(64)              default '',
+    `bk_biz_id`  bigint(1)       not null,
+    `priority`   int(1) unsigned not null default 0,
+    `enabled`    boolean                  default true,
+    `memo`       varchar(255)             default '',
+    `creator`    varchar(64)     not null,
+    `reviser`    varchar(64)     not null,
+    `created_at` timestamp       not null default current_timestamp,
+    `updated_at` timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_account_id_name` (`account_id`, `name`),
+    index `idx_account_id_priority` (`account_id`, `priority`)
+) engine = innodb
+  default charset = utf8mb4;
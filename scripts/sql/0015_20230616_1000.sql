@@ -0,0 +1,47 @@
+insert into id_generator(`resource`, `max_id`)
+values ('tag_policy', '0'),
+       ('tag_policy_violation', '0');
+
+CREATE TABLE `tag_policy`
+(
+    `id`             varchar(64)  not null,
+    `name`           varchar(64)  not null,
+    `account_id`     varchar(64)           default '',
+    `bk_biz_id`      bigint(1)             default 0,
+    `res_types`      json         not null,
+    `rules`          json         not null,
+    `auto_remediate` boolean               default false,
+    `enabled`        boolean               default true,
+    `memo`           varchar(255)          default '',
+    `creator`        varchar(64)  not null,
+    `reviser`        varchar(64)  not null,
+    `created_at`     timestamp    not null default current_timestamp,
+    `updated_at`     timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    index `idx_account_id` (`account_id`),
+    index `idx_bk_biz_id` (`bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE TABLE `tag_policy_violation`
+(
+    `id`         varchar(64)   not null,
+    `policy_id`  varchar(64)   not null,
+    `res_type`   varchar(64)   not null,
+    `res_id`     varchar(64)   not null,
+    `vendor`     varchar(16)   not null,
+    `account_id` varchar(64)   not null,
+    `bk_biz_id`  bigint(1)     not null default -1,
+    `tag_key`    varchar(255)  not null,
+    `reason`     varchar(32)   not null,
+    `detail`     varchar(1024)          default '',
+    `creator`    varchar(64)   not null,
+    `reviser`    varchar(64)   not null,
+    `created_at` timestamp     not null default current_timestamp,
+    `updated_at` timestamp     not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    index `idx_account_id` (`account_id`),
+    index `idx_policy_id` (`policy_id`),
+    index `idx_res_type_res_id` (`res_type`, `res_id`)
+) engine = innodb
+  default charset = utf8mb4;
@@ -0,0 +1,16 @@
+CREATE TABLE `resource_history`
+(
+    `id`         bigint(1) unsigned not null auto_increment,
+    `res_type`   varchar(64)        not null,
+    `res_id`     varchar(64)        not null,
+    `account_id` varchar(64)                 default '',
+    `action`     varchar(16)        not null,
+    `snapshot`   json                        default null,
+    `operator`   varchar(64)                 default '',
+    `rid`        varchar(64)                 default '',
+    `created_at` timestamp          not null default current_timestamp,
+    primary key (`id`),
+    index `idx_res_type_res_id_id` (`res_type`, `res_id`, `id`),
+    index `idx_created_at` (`created_at`)
+) engine = innodb
+  default charset = utf8mb4;
@@ -0,0 +1,19 @@
+ALTER TABLE `change_feed`
+    ADD COLUMN `seq` bigint(1) unsigned default null,
+    ADD INDEX `idx_res_type_seq` (`res_type`, `seq`),
+    ADD INDEX `idx_seq` (`seq`);
+
+UPDATE `change_feed`
+SET `seq` = `id`;
+
+CREATE TABLE `change_feed_sequence`
+(
+    `id`  bigint(1) unsigned not null,
+    `seq` bigint(1) unsigned not null default 0,
+    primary key (`id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+INSERT INTO `change_feed_sequence` (`id`, `seq`)
+SELECT 1, IFNULL(MAX(`id`), 0)
+FROM `change_feed`;
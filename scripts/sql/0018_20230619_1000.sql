@@ -0,0 +1,10 @@
+ALTER TABLE `event`
+    ADD COLUMN `seq` bigint(1) unsigned default null,
+    ADD INDEX `idx_seq` (`seq`);
+
+UPDATE `event`
+SET `seq` = `id`;
+
+INSERT INTO `change_feed_sequence` (`id`, `seq`)
+SELECT 2, IFNULL(MAX(`id`), 0)
+FROM `event`;